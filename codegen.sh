@@ -40,6 +40,11 @@ function types {
 	./build/gen-type-set --types Chart       --output compose/types/chart.gen.go
 	./build/gen-type-set --types Record      --output compose/types/record.gen.go
 	./build/gen-type-set --types ModuleField --output compose/types/module_field.gen.go
+	./build/gen-type-set --types ModuleMigration --output compose/types/module_migration.gen.go
+	./build/gen-type-set --types RecordTransition --output compose/types/record_workflow.gen.go
+	./build/gen-type-set --types RecordComment --output compose/types/record_comment.gen.go
+	./build/gen-type-set --types RecordChange --output compose/types/record_change.gen.go
+	./build/gen-type-set --types ChartSubscription --output compose/types/chart_subscription.gen.go
 
 	./build/gen-type-set-test --types Namespace   --output compose/types/namespace.gen_test.go
 	./build/gen-type-set-test --types Attachment  --output compose/types/attachment.gen_test.go
@@ -48,6 +53,11 @@ function types {
 	./build/gen-type-set-test --types Chart       --output compose/types/chart.gen_test.go
 	./build/gen-type-set-test --types Record      --output compose/types/record.gen_test.go
 	./build/gen-type-set-test --types ModuleField --output compose/types/module_field.gen_test.go
+	./build/gen-type-set-test --types ModuleMigration --output compose/types/module_migration.gen_test.go
+	./build/gen-type-set-test --types RecordTransition --output compose/types/record_workflow.gen_test.go
+	./build/gen-type-set-test --types RecordComment --output compose/types/record_comment.gen_test.go
+	./build/gen-type-set-test --types RecordChange --output compose/types/record_change.gen_test.go
+	./build/gen-type-set-test --types ChartSubscription --output compose/types/chart_subscription.gen_test.go
 
 	./build/gen-type-set --with-primary-key=false --types RecordValue --output compose/types/record_value.gen.go
 	./build/gen-type-set-test --with-primary-key=false --types RecordValue --output compose/types/record_value.gen_test.go
@@ -58,6 +68,7 @@ function types {
 	./build/gen-type-set --types Message           --output messaging/types/message.gen.go
 	./build/gen-type-set --types Channel           --output messaging/types/channel.gen.go
 	./build/gen-type-set --types Webhook           --output messaging/types/webhook.gen.go
+	./build/gen-type-set --types ScheduledMessage  --output messaging/types/scheduled_message.gen.go
 
 	./build/gen-type-set-test --types MessageAttachment --output messaging/types/attachment.gen_test.go
 	./build/gen-type-set-test --types Mention           --output messaging/types/mention.gen_test.go
@@ -65,16 +76,19 @@ function types {
 	./build/gen-type-set-test --types Message           --output messaging/types/message.gen_test.go
 	./build/gen-type-set-test --types Channel           --output messaging/types/channel.gen_test.go
 	./build/gen-type-set-test --types Webhook           --output messaging/types/webhook.gen_test.go
+	./build/gen-type-set-test --types ScheduledMessage  --output messaging/types/scheduled_message.gen_test.go
 
 	./build/gen-type-set --with-primary-key=false --types ChannelMember --output messaging/types/channel_member.gen.go
 	./build/gen-type-set --with-primary-key=false --types Command       --output messaging/types/command.gen.go
 	./build/gen-type-set --with-primary-key=false --types CommandParam  --output messaging/types/command_param.gen.go
 	./build/gen-type-set --with-primary-key=false --types Unread        --output messaging/types/unread.gen.go
+	./build/gen-type-set --with-primary-key=false --types Presence      --output messaging/types/presence.gen.go
 
 	./build/gen-type-set-test --with-primary-key=false --types ChannelMember --output messaging/types/channel_member.gen_test.go
 	./build/gen-type-set-test --with-primary-key=false --types Command       --output messaging/types/command.gen_test.go
 	./build/gen-type-set-test --with-primary-key=false --types CommandParam  --output messaging/types/command_param.gen_test.go
 	./build/gen-type-set-test --with-primary-key=false --types Unread        --output messaging/types/unread.gen_test.go
+	./build/gen-type-set-test --with-primary-key=false --types Presence      --output messaging/types/presence.gen_test.go
 
 	./build/gen-type-set --types User         --output system/types/user.gen.go
 	./build/gen-type-set --types Application  --output system/types/application.gen.go
@@ -83,6 +97,7 @@ function types {
 	./build/gen-type-set --types Credentials  --output system/types/credentials.gen.go
 	./build/gen-type-set --types Reminder     --output system/types/reminder.gen.go
 	./build/gen-type-set --types Attachment   --output system/types/attachment.gen.go
+	./build/gen-type-set --types Webhook,WebhookDelivery --output system/types/webhook.gen.go
 
 	./build/gen-type-set-test --types User         --output system/types/user.gen_test.go
 	./build/gen-type-set-test --types Application  --output system/types/application.gen_test.go
@@ -91,6 +106,7 @@ function types {
 	./build/gen-type-set-test --types Credentials  --output system/types/credentials.gen_test.go
 	./build/gen-type-set-test --types Reminder     --output system/types/reminder.gen_test.go
 	./build/gen-type-set-test --types Attachment   --output system/types/attachment.gen_test.go
+	./build/gen-type-set-test --types Webhook,WebhookDelivery --output system/types/webhook.gen_test.go
 
 	./build/gen-type-set --types Value --output pkg/settings/types.gen.go --with-primary-key=false --package settings
 	./build/gen-type-set-test --types Value --output pkg/settings/types.gen_test.go --with-primary-key=false --package settings
@@ -103,6 +119,10 @@ function types {
 
 	./build/gen-type-set --types Script --output pkg/corredor/types.gen.go --with-primary-key=false --package corredor
 	./build/gen-type-set-test --types Script --output pkg/corredor/types.gen_test.go --with-primary-key=false --package corredor
+	./build/gen-type-set --types Run --output pkg/corredor/run.gen.go --package corredor
+	./build/gen-type-set-test --types Run --output pkg/corredor/run.gen_test.go --package corredor
+	./build/gen-type-set --types Job --output pkg/jobs/job.gen.go --package jobs
+	./build/gen-type-set-test --types Job --output pkg/jobs/job.gen_test.go --package jobs
 
 
 	green "OK"
@@ -17,6 +17,7 @@ import (
 	"github.com/cortezaproject/corteza-server/compose/service"
 	"github.com/cortezaproject/corteza-server/compose/service/event"
 	"github.com/cortezaproject/corteza-server/pkg/app"
+	"github.com/cortezaproject/corteza-server/pkg/app/options"
 	"github.com/cortezaproject/corteza-server/pkg/auth"
 	"github.com/cortezaproject/corteza-server/pkg/corredor"
 	"github.com/cortezaproject/corteza-server/pkg/scheduler"
@@ -39,6 +40,7 @@ func (app *App) Setup(log *zap.Logger, opts *app.Options) (err error) {
 	scheduler.Service().OnTick(
 		event.ComposeOnInterval(),
 		event.ComposeOnTimestamp(),
+		event.ComposeOnCron(),
 	)
 
 	// @todo Wire in cross-service JWT maker for Corredor
@@ -62,7 +64,9 @@ func (app *App) Upgrade(ctx context.Context) (err error) {
 func (app *App) Initialize(ctx context.Context) (err error) {
 	// Connects to all services it needs to
 	err = service.Initialize(ctx, app.Log, service.Config{
-		Storage: app.Opts.Storage,
+		Storage:          app.Opts.Storage,
+		Trash:            app.Opts.Trash,
+		GRPCClientSystem: *options.GRPCServer("system"),
 	})
 
 	if err != nil {
@@ -103,6 +107,7 @@ func (app *App) RegisterCliCommands(p *cobra.Command) {
 		commands.Importer(),
 		commands.Exporter(),
 		commands.NGImporter(),
+		commands.Namespaces(),
 		// temp command, will be removed in 2020.6
 		automation.ScriptExporter(SERVICE),
 	)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"regexp"
 	"strconv"
 	"strings"
@@ -13,6 +14,7 @@ import (
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
 
+	"github.com/cortezaproject/corteza-server/compose/encoder"
 	"github.com/cortezaproject/corteza-server/compose/repository"
 	"github.com/cortezaproject/corteza-server/compose/service"
 	"github.com/cortezaproject/corteza-server/compose/types"
@@ -39,6 +41,7 @@ func Exporter() *cobra.Command {
 				nsFlag = cmd.Flags().Lookup("namespace").Value.String()
 				sFlag  = cmd.Flags().Lookup("settings").Changed
 				pFlag  = cmd.Flags().Lookup("permissions").Changed
+				rFlag  = cmd.Flags().Lookup("with-records").Changed
 
 				out = &Compose{
 					Namespaces: map[string]Namespace{},
@@ -49,6 +52,17 @@ func Exporter() *cobra.Command {
 				cli.HandleError(errors.New("Specify namespace or setting or permissions flag"))
 			}
 
+			if rFlag {
+				// Namespace with records is exported as an archive
+				// that can be imported into another deployment
+				if nsFlag == "" {
+					cli.HandleError(errors.New("Specify namespace to export with records"))
+				}
+
+				nsArchiveExporter(ctx, cmd.OutOrStdout(), nsFlag)
+				return
+			}
+
 			if nsFlag != "" {
 				nsExporter(ctx, out, nsFlag, args)
 			}
@@ -69,6 +83,7 @@ func Exporter() *cobra.Command {
 	cmd.Flags().String("namespace", "", "Export namespace resources (by ID or string)")
 	cmd.Flags().BoolP("settings", "s", false, "Export settings")
 	cmd.Flags().BoolP("permissions", "p", false, "Export system permissions")
+	cmd.Flags().Bool("with-records", false, "Export namespace with records and attachments as zip archive")
 
 	return cmd
 }
@@ -135,6 +150,29 @@ func nsExporter(ctx context.Context, out *Compose, nsFlag string, args []string)
 	out.Namespaces[ns.Slug] = nsOut
 }
 
+// nsArchiveExporter writes namespace with all its resources, records and attachments
+// as a template package (zip archive)
+func nsArchiveExporter(ctx context.Context, w io.Writer, nsFlag string) {
+	var (
+		svc = service.DefaultNamespace.With(ctx)
+		ns  *types.Namespace
+		err error
+	)
+
+	if namespaceID, _ := strconv.ParseUint(nsFlag, 10, 64); namespaceID > 0 {
+		ns, err = svc.FindByID(namespaceID)
+	} else {
+		ns, err = svc.FindByHandle(nsFlag)
+	}
+
+	cli.HandleError(err)
+
+	tpl, err := svc.Template(ns.ID, true)
+	cli.HandleError(err)
+
+	cli.HandleError(encoder.NamespaceTemplate(w, tpl))
+}
+
 func settingExporter(ctx context.Context, out *Compose) {
 	var (
 		err error
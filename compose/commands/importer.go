@@ -1,12 +1,16 @@
 package commands
 
 import (
+	"bytes"
+	"context"
 	"io"
 	"os"
 	"strconv"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/cortezaproject/corteza-server/compose/decoder"
 	"github.com/cortezaproject/corteza-server/compose/importer"
 	"github.com/cortezaproject/corteza-server/compose/repository"
 	"github.com/cortezaproject/corteza-server/compose/service"
@@ -43,12 +47,28 @@ func Importer() *cobra.Command {
 			}
 
 			if len(args) > 0 {
-				ff = make([]io.Reader, len(args))
-				for a, arg := range args {
-					ff[a], err = os.Open(arg)
+				ff = make([]io.Reader, 0, len(args))
+				for _, arg := range args {
+					f, err := os.Open(arg)
 					cli.HandleError(err)
+
+					if isArchive(f) {
+						// Archives (made with export --with-records) are always
+						// installed as a new namespace
+						if ns != nil {
+							cli.HandleError(errors.New("archive can not be imported into an existing namespace"))
+						}
+
+						nsArchiveImporter(ctx, cmd, f)
+						continue
+					}
+
+					ff = append(ff, f)
+				}
+
+				if len(ff) > 0 {
+					cli.HandleError(importer.Import(ctx, ns, ff...))
 				}
-				cli.HandleError(importer.Import(ctx, ns, ff...))
 			} else {
 				cli.HandleError(importer.Import(ctx, ns, os.Stdin))
 			}
@@ -59,3 +79,27 @@ func Importer() *cobra.Command {
 
 	return cmd
 }
+
+// isArchive checks zip signature at the beginning of the file
+func isArchive(f *os.File) bool {
+	var sig = make([]byte, 4)
+
+	defer f.Seek(0, io.SeekStart)
+
+	n, _ := f.Read(sig)
+	return n == 4 && bytes.Equal(sig, []byte("PK\x03\x04"))
+}
+
+// nsArchiveImporter installs namespace archive with all its resources, records and attachments
+func nsArchiveImporter(ctx context.Context, cmd *cobra.Command, f *os.File) {
+	fi, err := f.Stat()
+	cli.HandleError(err)
+
+	tpl, err := decoder.NamespaceTemplate(f, fi.Size())
+	cli.HandleError(err)
+
+	ns, err := service.DefaultNamespace.With(ctx).Install(tpl, "", "")
+	cli.HandleError(err)
+
+	cmd.Printf("Archive %s imported, new namespace ID: %d\n", f.Name(), ns.ID)
+}
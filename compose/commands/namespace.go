@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cortezaproject/corteza-server/compose/decoder"
+	"github.com/cortezaproject/corteza-server/compose/encoder"
+	"github.com/cortezaproject/corteza-server/compose/service"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/cli"
+)
+
+func Namespaces() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "namespace",
+		Short: "Namespace cloning and template packages",
+	}
+
+	clone := &cobra.Command{
+		Use:   "clone [namespace ID] [name]",
+		Short: "Clone namespace with modules, pages, charts and access rules",
+		Args:  cobra.ExactArgs(2),
+
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				ctx         = auth.SetSuperUserContext(cli.Context())
+				slug        = cmd.Flags().Lookup("slug").Value.String()
+				withRecords = cmd.Flags().Lookup("with-records").Changed
+			)
+
+			namespaceID, err := strconv.ParseUint(args[0], 10, 64)
+			cli.HandleError(err)
+
+			ns, err := service.DefaultNamespace.With(ctx).Clone(namespaceID, args[1], slug, withRecords)
+			cli.HandleError(err)
+
+			cmd.Printf("Namespace cloned, new namespace ID: %d\n", ns.ID)
+		},
+	}
+
+	clone.Flags().String("slug", "", "Slug of the new namespace")
+	clone.Flags().Bool("with-records", false, "Copy records")
+
+	pkg := &cobra.Command{
+		Use:   "package [namespace ID] [file]",
+		Short: "Package namespace into a template package that can be installed elsewhere",
+		Args:  cobra.ExactArgs(2),
+
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				ctx         = auth.SetSuperUserContext(cli.Context())
+				withRecords = cmd.Flags().Lookup("with-records").Changed
+			)
+
+			namespaceID, err := strconv.ParseUint(args[0], 10, 64)
+			cli.HandleError(err)
+
+			tpl, err := service.DefaultNamespace.With(ctx).Template(namespaceID, withRecords)
+			cli.HandleError(err)
+
+			f, err := os.Create(args[1])
+			cli.HandleError(err)
+			defer f.Close()
+
+			cli.HandleError(encoder.NamespaceTemplate(f, tpl))
+		},
+	}
+
+	pkg.Flags().Bool("with-records", false, "Include records")
+
+	install := &cobra.Command{
+		Use:   "install [file]",
+		Short: "Install template package as a new namespace",
+		Args:  cobra.ExactArgs(1),
+
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				ctx  = auth.SetSuperUserContext(cli.Context())
+				name = cmd.Flags().Lookup("name").Value.String()
+				slug = cmd.Flags().Lookup("slug").Value.String()
+			)
+
+			f, err := os.Open(args[0])
+			cli.HandleError(err)
+			defer f.Close()
+
+			fi, err := f.Stat()
+			cli.HandleError(err)
+
+			tpl, err := decoder.NamespaceTemplate(f, fi.Size())
+			cli.HandleError(err)
+
+			ns, err := service.DefaultNamespace.With(ctx).Install(tpl, name, slug)
+			cli.HandleError(err)
+
+			cmd.Printf("Template installed, new namespace ID: %d\n", ns.ID)
+		},
+	}
+
+	install.Flags().String("name", "", "Name of the new namespace (defaults to name from the package)")
+	install.Flags().String("slug", "", "Slug of the new namespace (defaults to slug from the package)")
+
+	cmd.AddCommand(clone, pkg, install)
+
+	return cmd
+}
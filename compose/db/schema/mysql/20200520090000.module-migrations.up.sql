@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS `compose_module_migration` (
+  `id`               BIGINT(20) UNSIGNED NOT NULL,
+  `rel_namespace`    BIGINT(20) UNSIGNED NOT NULL,
+  `rel_module`       BIGINT(20) UNSIGNED NOT NULL,
+  `steps`            JSON                NOT NULL               COMMENT 'Planned migration steps',
+  `status`           VARCHAR(16)         NOT NULL               COMMENT 'pending, running, completed or failed',
+  `total`            INT UNSIGNED        NOT NULL DEFAULT 0     COMMENT 'Number of values to migrate',
+  `processed`        INT UNSIGNED        NOT NULL DEFAULT 0     COMMENT 'Number of migrated values',
+  `error`            TEXT                NOT NULL               COMMENT 'Reason why migration failed',
+
+  `created_at`       DATETIME            NOT NULL DEFAULT NOW(),
+  `created_by`       BIGINT(20) UNSIGNED NOT NULL DEFAULT 0,
+  `started_at`       DATETIME                NULL DEFAULT NULL,
+  `completed_at`     DATETIME                NULL DEFAULT NULL,
+
+  PRIMARY KEY (`id`),
+  KEY `rel_module` (`rel_module`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE IF NOT EXISTS `compose_record_value_archive` (
+  `record_id`        BIGINT(20) UNSIGNED NOT NULL,
+  `name`             VARCHAR(64)         NOT NULL,
+  `value`            LONGTEXT,
+  `ref`              BIGINT(20) UNSIGNED NOT NULL DEFAULT 0,
+  `place`            INT UNSIGNED        NOT NULL DEFAULT 0,
+  `rel_migration`    BIGINT(20) UNSIGNED NOT NULL               COMMENT 'Migration that archived the value',
+  `archived_at`      DATETIME            NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (`rel_migration`, `record_id`, `name`, `place`),
+  KEY `record_id` (`record_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
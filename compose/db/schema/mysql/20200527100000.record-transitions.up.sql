@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS `compose_record_transition` (
+  `id`               BIGINT(20) UNSIGNED NOT NULL,
+  `rel_namespace`    BIGINT(20) UNSIGNED NOT NULL,
+  `rel_module`       BIGINT(20) UNSIGNED NOT NULL,
+  `rel_record`       BIGINT(20) UNSIGNED NOT NULL,
+  `field`            VARCHAR(64)         NOT NULL               COMMENT 'Workflow field',
+  `transition`       VARCHAR(64)         NOT NULL               COMMENT 'Name of the transition',
+  `from_state`       VARCHAR(255)        NOT NULL,
+  `to_state`         VARCHAR(255)        NOT NULL,
+
+  `created_at`       DATETIME            NOT NULL DEFAULT NOW(),
+  `created_by`       BIGINT(20) UNSIGNED NOT NULL DEFAULT 0,
+
+  PRIMARY KEY (`id`),
+  KEY `rel_record` (`rel_record`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS `compose_record_comment` (
+  `id`               BIGINT(20) UNSIGNED NOT NULL,
+  `rel_namespace`    BIGINT(20) UNSIGNED NOT NULL,
+  `rel_module`       BIGINT(20) UNSIGNED NOT NULL,
+  `rel_record`       BIGINT(20) UNSIGNED NOT NULL,
+  `reply_to`         BIGINT(20) UNSIGNED NOT NULL DEFAULT 0 COMMENT 'Comment this one is a reply to',
+  `message`          TEXT                NOT NULL,
+  `mentions`         JSON                NOT NULL               COMMENT 'IDs of mentioned users',
+  `attachments`      JSON                NOT NULL               COMMENT 'IDs of attached files',
+
+  `created_at`       DATETIME            NOT NULL DEFAULT NOW(),
+  `created_by`       BIGINT(20) UNSIGNED NOT NULL DEFAULT 0,
+  `updated_at`       DATETIME                NULL DEFAULT NULL,
+  `deleted_at`       DATETIME                NULL DEFAULT NULL,
+
+  PRIMARY KEY (`id`),
+  KEY `rel_record` (`rel_record`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `compose_record_change` (
+  `id`               BIGINT(20) UNSIGNED NOT NULL,
+  `rel_namespace`    BIGINT(20) UNSIGNED NOT NULL,
+  `rel_module`       BIGINT(20) UNSIGNED NOT NULL,
+  `rel_record`       BIGINT(20) UNSIGNED NOT NULL,
+  `fields`           JSON                NOT NULL               COMMENT 'Old and new values of changed fields',
+
+  `created_at`       DATETIME            NOT NULL DEFAULT NOW(),
+  `created_by`       BIGINT(20) UNSIGNED NOT NULL DEFAULT 0,
+
+  PRIMARY KEY (`id`),
+  KEY `rel_record` (`rel_record`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS `compose_chart_subscription` (
+  `id`               BIGINT(20) UNSIGNED NOT NULL,
+  `rel_namespace`    BIGINT(20) UNSIGNED NOT NULL,
+  `rel_chart`        BIGINT(20) UNSIGNED NOT NULL,
+  `frequency`        VARCHAR(16)         NOT NULL               COMMENT 'daily, weekly, monthly',
+  `format`           VARCHAR(8)          NOT NULL               COMMENT 'Format of the rendered chart (png, svg)',
+  `recipients`       JSON                NOT NULL               COMMENT 'Users (IDs, emails) and roles',
+  `next_run_at`      DATETIME            NOT NULL,
+  `last_run_at`      DATETIME                NULL DEFAULT NULL,
+
+  `created_at`       DATETIME            NOT NULL DEFAULT NOW(),
+  `created_by`       BIGINT(20) UNSIGNED NOT NULL DEFAULT 0,
+  `updated_at`       DATETIME                NULL DEFAULT NULL,
+  `deleted_at`       DATETIME                NULL DEFAULT NULL,
+
+  PRIMARY KEY (`id`),
+  KEY `rel_chart` (`rel_chart`),
+  KEY `next_run_at` (`next_run_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
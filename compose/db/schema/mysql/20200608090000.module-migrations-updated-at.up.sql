@@ -0,0 +1 @@
+ALTER TABLE `compose_module_migration` ADD `updated_at` DATETIME NULL DEFAULT NULL AFTER `completed_at`;
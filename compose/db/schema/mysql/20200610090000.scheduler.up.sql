@@ -0,0 +1,18 @@
+-- Scheduler tables are shared by all services;
+-- created here for compose running as a standalone service (with its own database)
+CREATE TABLE IF NOT EXISTS sys_scheduler_lease (
+    `name`       VARCHAR(64)  NOT NULL,
+    `owner`      VARCHAR(255) NOT NULL COMMENT 'Node that dispatches scheduled events',
+    `expires_at` DATETIME     NOT NULL,
+
+    PRIMARY KEY (`name`)
+
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE IF NOT EXISTS sys_scheduler_state (
+    `event`       VARCHAR(128) NOT NULL COMMENT 'Resource & event type (eg: compose.onInterval)',
+    `last_run_at` DATETIME     NOT NULL COMMENT 'Last dispatched tick',
+
+    PRIMARY KEY (`event`)
+
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
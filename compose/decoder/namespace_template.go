@@ -0,0 +1,102 @@
+package decoder
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+
+	"github.com/cortezaproject/corteza-server/compose/encoder"
+	"github.com/cortezaproject/corteza-server/compose/types"
+)
+
+type (
+	// namespaceTemplateFiles opens attachment files from the template package
+	namespaceTemplateFiles struct {
+		z *zip.Reader
+	}
+)
+
+// NamespaceTemplate reads template package created by encoder.NamespaceTemplate
+//
+// Attachment files are read from the package when template is installed
+func NamespaceTemplate(r io.ReaderAt, size int64) (tpl *types.NamespaceTemplate, err error) {
+	var (
+		z        *zip.Reader
+		manifest encoder.NamespaceTemplateManifest
+	)
+
+	if z, err = zip.NewReader(r, size); err != nil {
+		return nil, errors.Wrap(err, "could not open template package")
+	}
+
+	if err = readZipJSON(z, encoder.NamespaceTemplateManifestFile, &manifest); err != nil {
+		return
+	}
+
+	if manifest.Format != encoder.NamespaceTemplateFormat {
+		return nil, errors.Errorf("unknown template package format %q", manifest.Format)
+	}
+
+	tpl = &types.NamespaceTemplate{}
+	if err = readZipJSON(z, encoder.NamespaceTemplateFile, tpl); err != nil {
+		return nil, err
+	}
+
+	tpl.Files = namespaceTemplateFiles{z: z}
+	return tpl, nil
+}
+
+func (f namespaceTemplateFiles) Original(a *types.Attachment) (io.ReadSeeker, error) {
+	return f.open(encoder.NamespaceTemplateOriginalFile(a.ID))
+}
+
+func (f namespaceTemplateFiles) Preview(a *types.Attachment) (io.ReadSeeker, error) {
+	return f.open(encoder.NamespaceTemplatePreviewFile(a.ID))
+}
+
+// open reads the whole file into memory, zipped files can not be seeked
+func (f namespaceTemplateFiles) open(name string) (io.ReadSeeker, error) {
+	for _, zf := range f.z.File {
+		if zf.Name != name {
+			continue
+		}
+
+		rc, err := zf.Open()
+		if err != nil {
+			return nil, err
+		}
+
+		defer rc.Close()
+
+		buf, err := ioutil.ReadAll(rc)
+		if err != nil {
+			return nil, err
+		}
+
+		return bytes.NewReader(buf), nil
+	}
+
+	return nil, errors.Errorf("template package is missing %s", name)
+}
+
+func readZipJSON(z *zip.Reader, name string, v interface{}) error {
+	for _, f := range z.File {
+		if f.Name != name {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+
+		defer rc.Close()
+		return errors.Wrapf(json.NewDecoder(rc).Decode(v), "could not decode %s", name)
+	}
+
+	return errors.Errorf("template package is missing %s", name)
+}
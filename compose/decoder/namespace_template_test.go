@@ -0,0 +1,100 @@
+package decoder
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortezaproject/corteza-server/compose/encoder"
+	"github.com/cortezaproject/corteza-server/compose/types"
+)
+
+type (
+	testTemplateFiles map[string]string
+)
+
+func (ff testTemplateFiles) Original(a *types.Attachment) (io.ReadSeeker, error) {
+	return strings.NewReader(ff[a.Url]), nil
+}
+
+func (ff testTemplateFiles) Preview(a *types.Attachment) (io.ReadSeeker, error) {
+	return strings.NewReader(ff[a.PreviewUrl]), nil
+}
+
+func TestNamespaceTemplate(t *testing.T) {
+	var (
+		req = require.New(t)
+		buf = &bytes.Buffer{}
+
+		tpl = &types.NamespaceTemplate{
+			Version:   types.NamespaceTemplateVersion,
+			Namespace: &types.Namespace{ID: 1, Name: "Test", Slug: "test"},
+			Modules: types.ModuleSet{
+				{ID: 2, NamespaceID: 1, Name: "Mod", Fields: types.ModuleFieldSet{{ID: 3, Name: "f", Kind: "String"}}},
+			},
+			Pages: types.PageSet{
+				{ID: 4, NamespaceID: 1, ModuleID: 2, Title: "Page"},
+			},
+		}
+	)
+
+	req.NoError(encoder.NamespaceTemplate(buf, tpl))
+
+	out, err := NamespaceTemplate(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	req.NoError(err)
+	req.Equal(tpl.Namespace.Slug, out.Namespace.Slug)
+	req.Len(out.Modules, 1)
+	req.Len(out.Modules[0].Fields, 1)
+	req.Equal("f", out.Modules[0].Fields[0].Name)
+	req.Len(out.Pages, 1)
+	req.Equal(uint64(2), out.Pages[0].ModuleID)
+
+	_, err = NamespaceTemplate(bytes.NewReader([]byte("not a zip")), 9)
+	req.Error(err)
+}
+
+func TestNamespaceTemplate_withAttachments(t *testing.T) {
+	var (
+		req = require.New(t)
+		buf = &bytes.Buffer{}
+
+		tpl = &types.NamespaceTemplate{
+			Version:   types.NamespaceTemplateVersion,
+			Namespace: &types.Namespace{ID: 1, Name: "Test", Slug: "test"},
+			Attachments: types.AttachmentSet{
+				{ID: 5, Url: "5.txt", Name: "file.txt"},
+				{ID: 6, Url: "6.jpg", PreviewUrl: "6_preview.jpg", Name: "image.jpg"},
+			},
+			Files: testTemplateFiles{
+				"5.txt":         "text",
+				"6.jpg":         "image",
+				"6_preview.jpg": "preview",
+			},
+		}
+	)
+
+	req.NoError(encoder.NamespaceTemplate(buf, tpl))
+
+	out, err := NamespaceTemplate(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	req.NoError(err)
+	req.Len(out.Attachments, 2)
+	req.NotNil(out.Files)
+
+	read := func(r io.ReadSeeker, err error) string {
+		req.NoError(err)
+		b, err := ioutil.ReadAll(r)
+		req.NoError(err)
+		return string(b)
+	}
+
+	req.Equal("text", read(out.Files.Original(out.Attachments[0])))
+	req.Equal("image", read(out.Files.Original(out.Attachments[1])))
+	req.Equal("preview", read(out.Files.Preview(out.Attachments[1])))
+
+	_, err = out.Files.Preview(out.Attachments[0])
+	req.Error(err)
+}
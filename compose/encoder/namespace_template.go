@@ -0,0 +1,124 @@
+package encoder
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+)
+
+type (
+	// NamespaceTemplateManifest describes content of the template package
+	NamespaceTemplateManifest struct {
+		Format    string    `json:"format"`
+		Version   int       `json:"version"`
+		Name      string    `json:"name"`
+		Slug      string    `json:"slug"`
+		CreatedAt time.Time `json:"createdAt"`
+
+		Modules     uint `json:"modules"`
+		Pages       uint `json:"pages"`
+		Charts      uint `json:"charts"`
+		Records     uint `json:"records"`
+		Attachments uint `json:"attachments"`
+	}
+)
+
+const (
+	NamespaceTemplateFormat       = "corteza-namespace-template"
+	NamespaceTemplateManifestFile = "manifest.json"
+	NamespaceTemplateFile         = "namespace.json"
+)
+
+// NamespaceTemplateOriginalFile returns location of the original attachment file in the template package
+func NamespaceTemplateOriginalFile(attachmentID uint64) string {
+	return fmt.Sprintf("attachments/%d", attachmentID)
+}
+
+// NamespaceTemplatePreviewFile returns location of the attachment preview in the template package
+func NamespaceTemplatePreviewFile(attachmentID uint64) string {
+	return fmt.Sprintf("attachments/%d.preview", attachmentID)
+}
+
+// NamespaceTemplate writes template package (zip archive with manifest, template and attachment files)
+func NamespaceTemplate(w io.Writer, tpl *types.NamespaceTemplate) (err error) {
+	var (
+		z = zip.NewWriter(w)
+
+		manifest = NamespaceTemplateManifest{
+			Format:      NamespaceTemplateFormat,
+			Version:     tpl.Version,
+			Name:        tpl.Namespace.Name,
+			Slug:        tpl.Namespace.Slug,
+			CreatedAt:   time.Now().UTC(),
+			Modules:     uint(len(tpl.Modules)),
+			Pages:       uint(len(tpl.Pages)),
+			Charts:      uint(len(tpl.Charts)),
+			Records:     uint(len(tpl.Records)),
+			Attachments: uint(len(tpl.Attachments)),
+		}
+	)
+
+	if err = writeZipJSON(z, NamespaceTemplateManifestFile, manifest); err != nil {
+		return
+	}
+
+	if err = writeZipJSON(z, NamespaceTemplateFile, tpl); err != nil {
+		return
+	}
+
+	if len(tpl.Attachments) > 0 && tpl.Files == nil {
+		return errors.New("can not package attachments: files not available")
+	}
+
+	for _, a := range tpl.Attachments {
+		if err = writeZipFile(z, NamespaceTemplateOriginalFile(a.ID), a, tpl.Files.Original); err != nil {
+			return
+		}
+
+		if a.PreviewUrl == "" {
+			continue
+		}
+
+		if err = writeZipFile(z, NamespaceTemplatePreviewFile(a.ID), a, tpl.Files.Preview); err != nil {
+			return
+		}
+	}
+
+	return z.Close()
+}
+
+func writeZipFile(z *zip.Writer, name string, a *types.Attachment, open func(*types.Attachment) (io.ReadSeeker, error)) error {
+	src, err := open(a)
+	if err != nil {
+		return errors.Wrapf(err, "could not open attachment %d", a.ID)
+	}
+
+	if c, ok := src.(io.Closer); ok {
+		defer c.Close()
+	}
+
+	dst, err := z.Create(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(dst, src)
+	return err
+}
+
+func writeZipJSON(z *zip.Writer, name string, v interface{}) error {
+	f, err := z.Create(name)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
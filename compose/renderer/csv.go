@@ -0,0 +1,38 @@
+package renderer
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// CSV writes dataset as CSV, one row per label
+func CSV(w io.Writer, d *Dataset) error {
+	var (
+		cw  = csv.NewWriter(w)
+		row = make([]string, len(d.Series)+1)
+	)
+
+	row[0] = ""
+	for i, s := range d.Series {
+		row[i+1] = s.Label
+	}
+
+	if err := cw.Write(row); err != nil {
+		return err
+	}
+
+	for l, label := range d.Labels {
+		row[0] = label
+		for i, s := range d.Series {
+			row[i+1] = strconv.FormatFloat(s.Values[l], 'f', -1, 64)
+		}
+
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
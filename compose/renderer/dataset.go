@@ -0,0 +1,158 @@
+package renderer
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+)
+
+type (
+	// Dataset is chart's report data, prepared for rendering
+	Dataset struct {
+		Title string
+
+		// Values of the (first) dimension
+		Labels []string
+		Series []*Series
+	}
+
+	// Series holds values of one of the metrics, one for each of the labels
+	Series struct {
+		Label  string
+		Type   string
+		Color  string
+		Values []float64
+	}
+
+	Options struct {
+		Width  int
+		Height int
+	}
+)
+
+const (
+	DefaultWidth  = 800
+	DefaultHeight = 400
+
+	// Limits for requested image width & height
+	MinSize = 50
+	MaxSize = 4096
+)
+
+// MakeDataset converts rows returned from record report into dataset
+//
+// Only the first dimension is used for labels
+func MakeDataset(title string, r *types.ChartConfigReport, rows []map[string]interface{}) *Dataset {
+	var (
+		d = &Dataset{
+			Title:  title,
+			Labels: make([]string, len(rows)),
+		}
+
+		dimension = &types.ChartReportDimension{}
+	)
+
+	if dd := r.ReportDimensions(); len(dd) > 0 {
+		dimension = dd[0]
+	}
+
+	for i, row := range rows {
+		if v := row[dimension.Alias(0)]; v != nil {
+			d.Labels[i] = fmt.Sprintf("%v", v)
+		} else {
+			d.Labels[i] = dimension.Default
+		}
+	}
+
+	for i, m := range r.ReportMetrics() {
+		s := &Series{
+			Label:  m.Label,
+			Type:   m.Type,
+			Color:  m.Color,
+			Values: make([]float64, len(rows)),
+		}
+
+		for j, row := range rows {
+			s.Values[j] = toFloat(row[m.Alias(i)])
+		}
+
+		d.Series = append(d.Series, s)
+	}
+
+	return d
+}
+
+// IsPie returns true when dataset should be rendered as a pie chart
+//
+// Pie charts only show the first series
+func (d Dataset) IsPie() bool {
+	if len(d.Series) == 0 {
+		return false
+	}
+
+	switch d.Series[0].Type {
+	case "pie", "doughnut":
+		return true
+	}
+
+	return false
+}
+
+// Max returns the largest value of all series
+func (d Dataset) Max() (max float64) {
+	for _, s := range d.Series {
+		for _, v := range s.Values {
+			if v > max {
+				max = v
+			}
+		}
+	}
+
+	return
+}
+
+// Validate checks if requested width & height are within limits
+//
+// Zero means default size
+func (o Options) Validate() error {
+	for _, s := range []int{o.Width, o.Height} {
+		if s != 0 && (s < MinSize || s > MaxSize) {
+			return fmt.Errorf("chart size must be between %d and %d", MinSize, MaxSize)
+		}
+	}
+
+	return nil
+}
+
+func (o Options) size() (int, int) {
+	var w, h = o.Width, o.Height
+	if w <= 0 {
+		w = DefaultWidth
+	}
+
+	if h <= 0 {
+		h = DefaultHeight
+	}
+
+	return w, h
+}
+
+func toFloat(v interface{}) float64 {
+	switch c := v.(type) {
+	case float64:
+		return c
+	case int64:
+		return float64(c)
+	case int:
+		return float64(c)
+	case string:
+		f, _ := strconv.ParseFloat(c, 64)
+		return f
+	case []byte:
+		f, _ := strconv.ParseFloat(string(c), 64)
+		return f
+	}
+
+	return 0
+}
@@ -0,0 +1,329 @@
+package renderer
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type (
+	// canvas is implemented by each of the output formats
+	//
+	// Layout is calculated once (see layout()) and the same set of primitives
+	// is used for SVG and PNG output
+	canvas interface {
+		rect(x, y, w, h float64, c color.RGBA)
+		line(x1, y1, x2, y2 float64, c color.RGBA)
+		polyline(pp []point, c color.RGBA)
+		slice(cx, cy, r, start, end float64, c color.RGBA)
+		text(x, y float64, s string, a anchor, c color.RGBA)
+	}
+
+	point struct {
+		x, y float64
+	}
+
+	anchor int
+)
+
+const (
+	anchorStart anchor = iota
+	anchorMiddle
+	anchorEnd
+)
+
+const (
+	padding      = 20.0
+	axisWidth    = 50.0
+	headerHeight = 60.0
+	footerHeight = 30.0
+
+	// approx. width of a single character (matches font used in PNG output)
+	charWidth = 7.0
+)
+
+var (
+	colorBackground = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	colorText       = color.RGBA{R: 0x33, G: 0x33, B: 0x33, A: 0xff}
+	colorGrid       = color.RGBA{R: 0xdd, G: 0xdd, B: 0xdd, A: 0xff}
+
+	// Used for series (and pie slices) without color
+	palette = []color.RGBA{
+		{R: 0x4e, G: 0x79, B: 0xa7, A: 0xff},
+		{R: 0xf2, G: 0x8e, B: 0x2b, A: 0xff},
+		{R: 0xe1, G: 0x57, B: 0x59, A: 0xff},
+		{R: 0x76, G: 0xb7, B: 0xb2, A: 0xff},
+		{R: 0x59, G: 0xa1, B: 0x4f, A: 0xff},
+		{R: 0xed, G: 0xc9, B: 0x48, A: 0xff},
+		{R: 0xb0, G: 0x7a, B: 0xa1, A: 0xff},
+		{R: 0xff, G: 0x9d, B: 0xa7, A: 0xff},
+	}
+)
+
+// layout lays out the dataset on the canvas
+func layout(c canvas, d *Dataset, width, height int) {
+	var (
+		w, h = float64(width), float64(height)
+	)
+
+	c.rect(0, 0, w, h, colorBackground)
+	c.text(w/2, padding, d.Title, anchorMiddle, colorText)
+
+	if d.IsPie() {
+		drawPie(c, d, w, h)
+	} else {
+		drawAxisChart(c, d, w, h)
+	}
+}
+
+func drawAxisChart(c canvas, d *Dataset, w, h float64) {
+	var (
+		left   = padding + axisWidth
+		right  = w - padding
+		top    = headerHeight
+		bottom = h - footerHeight
+
+		plotW = right - left
+		plotH = bottom - top
+
+		max = niceMax(d.Max())
+
+		bars = make([]*Series, 0, len(d.Series))
+	)
+
+	legend := make([]string, len(d.Series))
+	for i, s := range d.Series {
+		legend[i] = s.Label
+	}
+
+	drawLegend(c, legend, d.seriesColor, w)
+
+	if plotW <= 0 || plotH <= 0 {
+		// no room for the plot
+		return
+	}
+
+	// Horizontal grid lines with values on the y axis
+	const gridLines = 5
+	for i := 0; i <= gridLines; i++ {
+		var (
+			y = bottom - plotH*float64(i)/gridLines
+			v = max * float64(i) / gridLines
+		)
+
+		c.line(left, y, right, y, colorGrid)
+		c.text(left-5, y+4, formatValue(v), anchorEnd, colorText)
+	}
+
+	if len(d.Labels) == 0 {
+		return
+	}
+
+	var (
+		groupW = plotW / float64(len(d.Labels))
+
+		// show every n-th label when they do not fit
+		labelStep = int(math.Ceil(float64(len(d.Labels)) * charWidth * 8 / plotW))
+	)
+
+	if labelStep < 1 {
+		labelStep = 1
+	}
+
+	for i, l := range d.Labels {
+		if i%labelStep == 0 {
+			c.text(left+groupW*(float64(i)+0.5), bottom+16, truncate(l, int(groupW*float64(labelStep)/charWidth)), anchorMiddle, colorText)
+		}
+	}
+
+	for _, s := range d.Series {
+		if s.Type != "line" {
+			bars = append(bars, s)
+		}
+	}
+
+	scale := func(v float64) float64 {
+		if max == 0 {
+			return 0
+		}
+
+		return plotH * v / max
+	}
+
+	if len(bars) > 0 {
+		barW := groupW * 0.8 / float64(len(bars))
+		for b, s := range bars {
+			clr := d.seriesColor(d.seriesIndex(s))
+			for i, v := range s.Values {
+				var (
+					x  = left + groupW*float64(i) + groupW*0.1 + barW*float64(b)
+					bh = scale(v)
+				)
+
+				c.rect(x, bottom-bh, barW, bh, clr)
+			}
+		}
+	}
+
+	for si, s := range d.Series {
+		if s.Type != "line" {
+			continue
+		}
+
+		pp := make([]point, len(s.Values))
+		for i, v := range s.Values {
+			pp[i] = point{x: left + groupW*(float64(i)+0.5), y: bottom - scale(v)}
+		}
+
+		c.polyline(pp, d.seriesColor(si))
+	}
+
+	c.line(left, bottom, right, bottom, colorText)
+	c.line(left, top, left, bottom, colorText)
+}
+
+func drawPie(c canvas, d *Dataset, w, h float64) {
+	var (
+		s     = d.Series[0]
+		total float64
+
+		cx = w / 2
+		cy = headerHeight + (h-headerHeight-padding)/2
+		r  = math.Min(w-2*padding, h-headerHeight-padding) / 2
+
+		sliceColor = func(i int) color.RGBA {
+			return palette[i%len(palette)]
+		}
+	)
+
+	drawLegend(c, d.Labels, sliceColor, w)
+
+	if r <= 0 {
+		return
+	}
+
+	for _, v := range s.Values {
+		if v > 0 {
+			total += v
+		}
+	}
+
+	if total == 0 {
+		return
+	}
+
+	var start = -math.Pi / 2
+	for i, v := range s.Values {
+		if v <= 0 {
+			continue
+		}
+
+		end := start + 2*math.Pi*v/total
+		c.slice(cx, cy, r, start, end, sliceColor(i))
+		start = end
+	}
+}
+
+// drawLegend draws a row of color boxes with labels below the title
+func drawLegend(c canvas, labels []string, clr func(int) color.RGBA, w float64) {
+	const (
+		box = 10.0
+		gap = 15.0
+	)
+
+	var (
+		y     = padding + 16
+		width float64
+	)
+
+	for _, l := range labels {
+		width += box + 4 + float64(len(l))*charWidth + gap
+	}
+
+	x := math.Max(padding, (w-width)/2)
+	for i, l := range labels {
+		c.rect(x, y, box, box, clr(i))
+		c.text(x+box+4, y+box, l, anchorStart, colorText)
+		x += box + 4 + float64(len(l))*charWidth + gap
+	}
+}
+
+func (d Dataset) seriesIndex(s *Series) int {
+	for i := range d.Series {
+		if d.Series[i] == s {
+			return i
+		}
+	}
+
+	return 0
+}
+
+func (d Dataset) seriesColor(i int) color.RGBA {
+	if i < len(d.Series) {
+		if c, ok := parseColor(d.Series[i].Color); ok {
+			return c
+		}
+	}
+
+	return palette[i%len(palette)]
+}
+
+// parseColor parses #rgb and #rrggbb colors
+func parseColor(s string) (c color.RGBA, ok bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+
+	if len(s) != 6 {
+		return
+	}
+
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return
+	}
+
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}, true
+}
+
+// niceMax rounds max value up to 1, 2 or 5 times power of 10
+func niceMax(max float64) float64 {
+	if max <= 0 {
+		return 1
+	}
+
+	var (
+		exp  = math.Pow(10, math.Floor(math.Log10(max)))
+		frac = max / exp
+	)
+
+	switch {
+	case frac <= 1:
+		return exp
+	case frac <= 2:
+		return 2 * exp
+	case frac <= 5:
+		return 5 * exp
+	default:
+		return 10 * exp
+	}
+}
+
+func formatValue(v float64) string {
+	if v == math.Trunc(v) {
+		return strconv.FormatFloat(v, 'f', 0, 64)
+	}
+
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func truncate(s string, max int) string {
+	var rr = []rune(s)
+	if max < 3 || len(rr) <= max {
+		return s
+	}
+
+	return string(rr[:max-2]) + ".."
+}
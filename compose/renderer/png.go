@@ -0,0 +1,125 @@
+package renderer
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+type (
+	pngCanvas struct {
+		img *image.RGBA
+	}
+)
+
+// PNG renders dataset as PNG image
+func PNG(w io.Writer, d *Dataset, o Options) error {
+	if err := o.Validate(); err != nil {
+		return err
+	}
+
+	var (
+		width, height = o.size()
+		c             = &pngCanvas{img: image.NewRGBA(image.Rect(0, 0, width, height))}
+	)
+
+	layout(c, d, width, height)
+
+	return png.Encode(w, c.img)
+}
+
+func (c *pngCanvas) rect(x, y, w, h float64, clr color.RGBA) {
+	r := image.Rect(round(x), round(y), round(x+w), round(y+h))
+	draw.Draw(c.img, r, &image.Uniform{C: clr}, image.Point{}, draw.Src)
+}
+
+func (c *pngCanvas) line(x1, y1, x2, y2 float64, clr color.RGBA) {
+	c.stroke(x1, y1, x2, y2, clr, 1)
+}
+
+func (c *pngCanvas) polyline(pp []point, clr color.RGBA) {
+	for i := 1; i < len(pp); i++ {
+		c.stroke(pp[i-1].x, pp[i-1].y, pp[i].x, pp[i].y, clr, 2)
+	}
+}
+
+// stroke draws a line by stepping along its longer axis
+func (c *pngCanvas) stroke(x1, y1, x2, y2 float64, clr color.RGBA, width int) {
+	var (
+		dx    = x2 - x1
+		dy    = y2 - y1
+		steps = int(math.Max(math.Abs(dx), math.Abs(dy)))
+	)
+
+	if steps == 0 {
+		steps = 1
+	}
+
+	for i := 0; i <= steps; i++ {
+		var (
+			t = float64(i) / float64(steps)
+			x = round(x1 + dx*t)
+			y = round(y1 + dy*t)
+		)
+
+		for wx := 0; wx < width; wx++ {
+			for wy := 0; wy < width; wy++ {
+				c.img.SetRGBA(x+wx, y+wy, clr)
+			}
+		}
+	}
+}
+
+func (c *pngCanvas) slice(cx, cy, r, start, end float64, clr color.RGBA) {
+	for y := round(cy - r); y <= round(cy+r); y++ {
+		for x := round(cx - r); x <= round(cx+r); x++ {
+			var (
+				dx = float64(x) - cx
+				dy = float64(y) - cy
+			)
+
+			if dx*dx+dy*dy > r*r {
+				continue
+			}
+
+			// normalize angle to [start, start + 2π)
+			a := math.Atan2(dy, dx)
+			for a < start {
+				a += 2 * math.Pi
+			}
+
+			if a < end {
+				c.img.SetRGBA(x, y, clr)
+			}
+		}
+	}
+}
+
+func (c *pngCanvas) text(x, y float64, s string, a anchor, clr color.RGBA) {
+	d := &font.Drawer{
+		Dst:  c.img,
+		Src:  &image.Uniform{C: clr},
+		Face: basicfont.Face7x13,
+	}
+
+	switch a {
+	case anchorMiddle:
+		x -= float64(d.MeasureString(s).Round()) / 2
+	case anchorEnd:
+		x -= float64(d.MeasureString(s).Round())
+	}
+
+	d.Dot = fixed.P(round(x), round(y))
+	d.DrawString(s)
+}
+
+func round(f float64) int {
+	return int(math.Round(f))
+}
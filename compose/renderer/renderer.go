@@ -0,0 +1,43 @@
+package renderer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	FormatPNG = "png"
+	FormatSVG = "svg"
+)
+
+// IsSupported checks if the image format is supported
+func IsSupported(format string) bool {
+	switch strings.ToLower(format) {
+	case FormatPNG, FormatSVG:
+		return true
+	}
+
+	return false
+}
+
+// Render renders dataset in the given image format
+func Render(w io.Writer, format string, d *Dataset, o Options) error {
+	switch strings.ToLower(format) {
+	case FormatPNG:
+		return PNG(w, d, o)
+	case FormatSVG:
+		return SVG(w, d, o)
+	}
+
+	return fmt.Errorf("unsupported chart format %q", format)
+}
+
+// ContentType returns mime type of the image format
+func ContentType(format string) string {
+	if strings.ToLower(format) == FormatSVG {
+		return "image/svg+xml"
+	}
+
+	return "image/png"
+}
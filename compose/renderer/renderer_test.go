@@ -0,0 +1,142 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+)
+
+func testDataset() *Dataset {
+	r := &types.ChartConfigReport{
+		Metrics: []map[string]interface{}{
+			{"field": "count", "label": "Deals", "backgroundColor": "#ff0000"},
+			{"field": "amount", "aggregate": "SUM", "label": "Amount", "type": "line"},
+		},
+		Dimensions: []map[string]interface{}{
+			{"field": "stage", "default": "none"},
+		},
+	}
+
+	return MakeDataset("Pipeline", r, []map[string]interface{}{
+		{"count": int64(3), "metric_1": 1500.0, "dimension_0": "Prospecting"},
+		{"count": int64(1), "metric_1": 200.5, "dimension_0": nil},
+	})
+}
+
+func TestMakeDataset(t *testing.T) {
+	var (
+		req = require.New(t)
+		d   = testDataset()
+	)
+
+	req.Equal([]string{"Prospecting", "none"}, d.Labels)
+	req.Len(d.Series, 2)
+	req.Equal([]float64{3, 1}, d.Series[0].Values)
+	req.Equal([]float64{1500, 200.5}, d.Series[1].Values)
+	req.Equal(1500.0, d.Max())
+	req.False(d.IsPie())
+}
+
+func TestSVG(t *testing.T) {
+	var (
+		req = require.New(t)
+		buf = &bytes.Buffer{}
+	)
+
+	req.NoError(SVG(buf, testDataset(), Options{Width: 400, Height: 300}))
+	req.NoError(xml.Unmarshal(buf.Bytes(), new(interface{})))
+	req.Contains(buf.String(), `width="400" height="300"`)
+	req.Contains(buf.String(), `>Pipeline</text>`)
+	req.Contains(buf.String(), `fill="#ff0000"`)
+	req.Contains(buf.String(), `<polyline`)
+}
+
+func TestSVG_pie(t *testing.T) {
+	var (
+		req = require.New(t)
+		buf = &bytes.Buffer{}
+		d   = testDataset()
+	)
+
+	d.Series[0].Type = "pie"
+	req.NoError(SVG(buf, d, Options{}))
+	req.Equal(2, strings.Count(buf.String(), "<path"))
+}
+
+func TestPNG(t *testing.T) {
+	var (
+		req = require.New(t)
+		buf = &bytes.Buffer{}
+	)
+
+	req.NoError(PNG(buf, testDataset(), Options{Width: 300, Height: 200}))
+
+	img, err := png.Decode(buf)
+	req.NoError(err)
+	req.Equal(300, img.Bounds().Dx())
+	req.Equal(200, img.Bounds().Dy())
+}
+
+func TestLayout_tinySize(t *testing.T) {
+	var (
+		req = require.New(t)
+		pie = testDataset()
+		one = testDataset()
+	)
+
+	pie.Series[0].Type = "pie"
+
+	// single label makes label step round to zero on narrow plots
+	one.Labels = one.Labels[:1]
+
+	for _, d := range []*Dataset{testDataset(), pie, one} {
+		for _, size := range [][2]int{{1, 1}, {10, 10}, {50, 50}, {90, 400}, {1, 400}, {800, 1}} {
+			req.NotPanics(func() {
+				layout(&svgCanvas{}, d, size[0], size[1])
+				layout(&pngCanvas{img: image.NewRGBA(image.Rect(0, 0, size[0], size[1]))}, d, size[0], size[1])
+			})
+		}
+	}
+}
+
+func TestOptions_Validate(t *testing.T) {
+	var (
+		req = require.New(t)
+		buf = &bytes.Buffer{}
+	)
+
+	req.NoError(Options{}.Validate())
+	req.NoError(Options{Width: MinSize, Height: MaxSize}.Validate())
+	req.Error(Options{Width: 10}.Validate())
+	req.Error(Options{Height: -1}.Validate())
+	req.Error(Options{Width: 100000, Height: 100000}.Validate())
+
+	req.Error(PNG(buf, testDataset(), Options{Width: MaxSize + 1}))
+	req.Error(SVG(buf, testDataset(), Options{Height: 1}))
+	req.Zero(buf.Len())
+}
+
+func TestCSV(t *testing.T) {
+	var (
+		req = require.New(t)
+		buf = &bytes.Buffer{}
+	)
+
+	req.NoError(CSV(buf, testDataset()))
+	req.Equal(",Deals,Amount\nProspecting,3,1500\nnone,1,200.5\n", buf.String())
+}
+
+func TestNiceMax(t *testing.T) {
+	req := require.New(t)
+	req.Equal(1.0, niceMax(0))
+	req.Equal(2000.0, niceMax(1500))
+	req.Equal(50.0, niceMax(42))
+	req.Equal(10.0, niceMax(10))
+}
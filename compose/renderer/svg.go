@@ -0,0 +1,98 @@
+package renderer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"strings"
+)
+
+type (
+	svgCanvas struct {
+		buf bytes.Buffer
+	}
+)
+
+// SVG renders dataset as SVG image
+func SVG(w io.Writer, d *Dataset, o Options) error {
+	if err := o.Validate(); err != nil {
+		return err
+	}
+
+	var (
+		c             = &svgCanvas{}
+		width, height = o.size()
+	)
+
+	layout(c, d, width, height)
+
+	_, err := fmt.Fprintf(
+		w,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">%s</svg>`,
+		width, height, width, height,
+		c.buf.String(),
+	)
+
+	return err
+}
+
+func (c *svgCanvas) rect(x, y, w, h float64, clr color.RGBA) {
+	fmt.Fprintf(&c.buf, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`, x, y, w, h, svgColor(clr))
+}
+
+func (c *svgCanvas) line(x1, y1, x2, y2 float64, clr color.RGBA) {
+	fmt.Fprintf(&c.buf, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s"/>`, x1, y1, x2, y2, svgColor(clr))
+}
+
+func (c *svgCanvas) polyline(pp []point, clr color.RGBA) {
+	var coords = make([]string, len(pp))
+	for i, p := range pp {
+		coords[i] = fmt.Sprintf("%.1f,%.1f", p.x, p.y)
+	}
+
+	fmt.Fprintf(&c.buf, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`, strings.Join(coords, " "), svgColor(clr))
+}
+
+func (c *svgCanvas) slice(cx, cy, r, start, end float64, clr color.RGBA) {
+	if end-start >= 2*math.Pi-1e-9 {
+		// arc can not describe a full circle
+		fmt.Fprintf(&c.buf, `<circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s"/>`, cx, cy, r, svgColor(clr))
+		return
+	}
+
+	var large = 0
+	if end-start > math.Pi {
+		large = 1
+	}
+
+	fmt.Fprintf(
+		&c.buf,
+		`<path d="M%.1f,%.1f L%.1f,%.1f A%.1f,%.1f 0 %d 1 %.1f,%.1f Z" fill="%s"/>`,
+		cx, cy,
+		cx+r*math.Cos(start), cy+r*math.Sin(start),
+		r, r, large,
+		cx+r*math.Cos(end), cy+r*math.Sin(end),
+		svgColor(clr),
+	)
+}
+
+func (c *svgCanvas) text(x, y float64, s string, a anchor, clr color.RGBA) {
+	var ta = "start"
+	switch a {
+	case anchorMiddle:
+		ta = "middle"
+	case anchorEnd:
+		ta = "end"
+	}
+
+	fmt.Fprintf(&c.buf, `<text x="%.1f" y="%.1f" text-anchor="%s" fill="%s">`, x, y, ta, svgColor(clr))
+	_ = xml.EscapeText(&c.buf, []byte(s))
+	c.buf.WriteString(`</text>`)
+}
+
+func svgColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
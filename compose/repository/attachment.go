@@ -20,6 +20,9 @@ type (
 		FindByID(namespaceID, attachmentID uint64) (*types.Attachment, error)
 		Create(mod *types.Attachment) (*types.Attachment, error)
 		DeleteByID(namespaceID, attachmentID uint64) error
+
+		FindByRecords(recordIDs ...uint64) (types.AttachmentSet, error)
+		Purge(attachmentIDs ...uint64) error
 	}
 
 	attachment struct {
@@ -175,3 +178,46 @@ func (r attachment) DeleteByID(namespaceID, attachmentID uint64) error {
 
 	return err
 }
+
+// FindByRecords returns all record attachments that are referenced from values of the given records
+//
+// Values of deleted records and deleted attachments are included
+func (r attachment) FindByRecords(recordIDs ...uint64) (set types.AttachmentSet, err error) {
+	if len(recordIDs) == 0 {
+		return
+	}
+
+	refs, args, err := squirrel.
+		Select("ref").
+		From("compose_record_value").
+		Where(squirrel.Eq{"record_id": recordIDs}).
+		ToSql()
+
+	if err != nil {
+		return
+	}
+
+	// Not using r.query() here; (soft) deleted attachments are included
+	query := squirrel.
+		Select(r.columns()...).
+		From(r.table()+" AS a").
+		Where(squirrel.Eq{"a.kind": types.RecordAttachment}).
+		Where("a.id IN ("+refs+")", args...)
+
+	return set, rh.FetchAll(r.db(), query, &set)
+}
+
+// Purge permanently removes attachments
+func (r attachment) Purge(attachmentIDs ...uint64) error {
+	if len(attachmentIDs) == 0 {
+		return nil
+	}
+
+	sql, args, err := squirrel.Delete(r.table()).Where(squirrel.Eq{"id": attachmentIDs}).ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db().Exec(sql, args...)
+	return errors.Wrap(err, "could not purge attachments")
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/titpetric/factory"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	ChartSubscriptionRepository interface {
+		With(ctx context.Context, db *factory.DB) ChartSubscriptionRepository
+
+		FindByID(namespaceID, subscriptionID uint64) (*types.ChartSubscription, error)
+		Find(filter types.ChartSubscriptionFilter) (set types.ChartSubscriptionSet, f types.ChartSubscriptionFilter, err error)
+		FindDue(now time.Time, limit uint) (set types.ChartSubscriptionSet, err error)
+		Create(s *types.ChartSubscription) (*types.ChartSubscription, error)
+		Update(s *types.ChartSubscription) (*types.ChartSubscription, error)
+		Claim(s *types.ChartSubscription, due time.Time) (bool, error)
+		DeleteByID(namespaceID, subscriptionID uint64) error
+	}
+
+	chartSubscription struct {
+		*repository
+	}
+)
+
+const (
+	ErrChartSubscriptionNotFound = repositoryError("ChartSubscriptionNotFound")
+)
+
+func ChartSubscription(ctx context.Context, db *factory.DB) ChartSubscriptionRepository {
+	return (&chartSubscription{}).With(ctx, db)
+}
+
+func (r chartSubscription) With(ctx context.Context, db *factory.DB) ChartSubscriptionRepository {
+	return &chartSubscription{
+		repository: r.repository.With(ctx, db),
+	}
+}
+
+func (r chartSubscription) table() string {
+	return "compose_chart_subscription"
+}
+
+func (r chartSubscription) columns() []string {
+	return []string{
+		"id",
+		"rel_namespace",
+		"rel_chart",
+		"frequency",
+		"format",
+		"recipients",
+		"next_run_at",
+		"last_run_at",
+		"created_at",
+		"created_by",
+		"updated_at",
+		"deleted_at",
+	}
+}
+
+func (r chartSubscription) query() squirrel.SelectBuilder {
+	return squirrel.
+		Select(r.columns()...).
+		From(r.table()).
+		Where("deleted_at IS NULL")
+}
+
+func (r chartSubscription) FindByID(namespaceID, subscriptionID uint64) (*types.ChartSubscription, error) {
+	var (
+		s = &types.ChartSubscription{}
+
+		q = r.query().
+			Where(squirrel.Eq{"id": subscriptionID, "rel_namespace": namespaceID})
+	)
+
+	if err := rh.FetchOne(r.db(), q, s); err != nil {
+		return nil, err
+	} else if s.ID == 0 {
+		return nil, ErrChartSubscriptionNotFound
+	}
+
+	return s, nil
+}
+
+func (r chartSubscription) Find(filter types.ChartSubscriptionFilter) (set types.ChartSubscriptionSet, f types.ChartSubscriptionFilter, err error) {
+	f = filter
+
+	if f.Sort == "" {
+		f.Sort = "id ASC"
+	}
+
+	query := r.query()
+
+	if filter.NamespaceID > 0 {
+		query = query.Where(squirrel.Eq{"rel_namespace": filter.NamespaceID})
+	}
+
+	if filter.ChartID > 0 {
+		query = query.Where(squirrel.Eq{"rel_chart": filter.ChartID})
+	}
+
+	var orderBy []string
+	if orderBy, err = rh.ParseOrder(f.Sort, r.columns()...); err != nil {
+		return
+	} else {
+		query = query.OrderBy(orderBy...)
+	}
+
+	if f.Count, err = rh.Count(r.db(), query); err != nil || f.Count == 0 {
+		return
+	}
+
+	return set, f, rh.FetchPaged(r.db(), query, f.PageFilter, &set)
+}
+
+// FindDue returns subscriptions that should have been sent by now
+func (r chartSubscription) FindDue(now time.Time, limit uint) (set types.ChartSubscriptionSet, err error) {
+	query := r.query().
+		Where(squirrel.LtOrEq{"next_run_at": now}).
+		OrderBy("next_run_at ASC").
+		Limit(uint64(limit))
+
+	return set, rh.FetchAll(r.db(), query, &set)
+}
+
+func (r chartSubscription) Create(s *types.ChartSubscription) (*types.ChartSubscription, error) {
+	s.ID = factory.Sonyflake.NextID()
+	rh.SetCurrentTimeRounded(&s.CreatedAt)
+
+	return s, r.db().Insert(r.table(), s)
+}
+
+func (r chartSubscription) Update(s *types.ChartSubscription) (*types.ChartSubscription, error) {
+	rh.SetCurrentTimeRounded(&s.UpdatedAt)
+
+	return s, r.db().Update(r.table(), s, "id")
+}
+
+// Claim stores next and last run of the subscription
+// if it is still scheduled for the given time
+//
+// Returns false when subscription was already claimed (by another node)
+func (r chartSubscription) Claim(s *types.ChartSubscription, due time.Time) (bool, error) {
+	rh.SetCurrentTimeRounded(&s.UpdatedAt)
+
+	res, err := r.db().Exec(
+		"UPDATE "+r.table()+" SET next_run_at = ?, last_run_at = ?, updated_at = ? WHERE id = ? AND next_run_at = ? AND deleted_at IS NULL",
+		s.NextRunAt,
+		s.LastRunAt,
+		s.UpdatedAt,
+		s.ID,
+		due,
+	)
+
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (r chartSubscription) DeleteByID(namespaceID, subscriptionID uint64) error {
+	_, err := r.db().Exec(
+		"UPDATE "+r.table()+" SET deleted_at = ? WHERE rel_namespace = ? AND id = ?",
+		time.Now(),
+		namespaceID,
+		subscriptionID,
+	)
+
+	return err
+}
@@ -25,7 +25,7 @@ type (
 		FindFields(moduleIDs ...uint64) (ff types.ModuleFieldSet, err error)
 		Create(mod *types.Module) (*types.Module, error)
 		Update(mod *types.Module) (*types.Module, error)
-		UpdateFields(moduleID uint64, ff types.ModuleFieldSet, hasRecords bool) (err error)
+		UpdateFields(moduleID uint64, ff types.ModuleFieldSet) (err error)
 		DeleteByID(namespaceID, moduleID uint64) error
 	}
 
@@ -177,7 +177,7 @@ func (r module) Update(mod *types.Module) (*types.Module, error) {
 	return mod, r.db().Update(r.table(), mod, "id")
 }
 
-func (r module) UpdateFields(moduleID uint64, ff types.ModuleFieldSet, hasRecords bool) error {
+func (r module) UpdateFields(moduleID uint64, ff types.ModuleFieldSet) error {
 	if existing, err := r.FindFields(moduleID); err != nil {
 		return err
 	} else {
@@ -197,16 +197,7 @@ func (r module) UpdateFields(moduleID uint64, ff types.ModuleFieldSet, hasRecord
 		for idx, f := range ff {
 			if e := existing.FindByID(f.ID); e != nil {
 				f.CreatedAt = e.CreatedAt
-
-				// We do not have any other code in place that would handle changes of field name and kind, so we need
-				// to reset any changes made to the field.
-				// @todo remove when we are able to handle field rename & type change
-				if hasRecords {
-					f.Name = e.Name
-					f.Kind = e.Kind
-				} else {
-					rh.SetCurrentTimeRounded(&f.UpdatedAt)
-				}
+				rh.SetCurrentTimeRounded(&f.UpdatedAt)
 			} else {
 				f.ID = 0
 			}
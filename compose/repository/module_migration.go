@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/titpetric/factory"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	ModuleMigrationRepository interface {
+		With(ctx context.Context, db *factory.DB) ModuleMigrationRepository
+
+		FindByID(migrationID uint64) (*types.ModuleMigration, error)
+		Find(filter types.ModuleMigrationFilter) (set types.ModuleMigrationSet, f types.ModuleMigrationFilter, err error)
+		FindUnfinished(staleBefore time.Time) (set types.ModuleMigrationSet, err error)
+		Create(mm *types.ModuleMigration) (*types.ModuleMigration, error)
+		Update(mm *types.ModuleMigration) (*types.ModuleMigration, error)
+		Claim(mm *types.ModuleMigration) (bool, error)
+	}
+
+	moduleMigration struct {
+		*repository
+	}
+)
+
+const (
+	ErrModuleMigrationNotFound = repositoryError("ModuleMigrationNotFound")
+)
+
+func ModuleMigration(ctx context.Context, db *factory.DB) ModuleMigrationRepository {
+	return (&moduleMigration{}).With(ctx, db)
+}
+
+func (r moduleMigration) With(ctx context.Context, db *factory.DB) ModuleMigrationRepository {
+	return &moduleMigration{
+		repository: r.repository.With(ctx, db),
+	}
+}
+
+func (r moduleMigration) table() string {
+	return "compose_module_migration"
+}
+
+func (r moduleMigration) columns() []string {
+	return []string{
+		"id",
+		"rel_namespace",
+		"rel_module",
+		"steps",
+		"status",
+		"total",
+		"processed",
+		"error",
+		"created_at",
+		"created_by",
+		"started_at",
+		"completed_at",
+		"updated_at",
+	}
+}
+
+func (r moduleMigration) query() squirrel.SelectBuilder {
+	return squirrel.
+		Select(r.columns()...).
+		From(r.table())
+}
+
+func (r moduleMigration) FindByID(migrationID uint64) (*types.ModuleMigration, error) {
+	var (
+		mm = &types.ModuleMigration{}
+
+		q = r.query().
+			Where(squirrel.Eq{"id": migrationID})
+
+		err = rh.FetchOne(r.db(), q, mm)
+	)
+
+	if err != nil {
+		return nil, err
+	} else if mm.ID == 0 {
+		return nil, ErrModuleMigrationNotFound
+	}
+
+	return mm, nil
+}
+
+func (r moduleMigration) Find(filter types.ModuleMigrationFilter) (set types.ModuleMigrationSet, f types.ModuleMigrationFilter, err error) {
+	f = filter
+
+	if f.Sort == "" {
+		f.Sort = "id DESC"
+	}
+
+	query := r.query()
+
+	if filter.NamespaceID > 0 {
+		query = query.Where(squirrel.Eq{"rel_namespace": filter.NamespaceID})
+	}
+
+	if filter.ModuleID > 0 {
+		query = query.Where(squirrel.Eq{"rel_module": filter.ModuleID})
+	}
+
+	var orderBy []string
+	if orderBy, err = rh.ParseOrder(f.Sort, r.columns()...); err != nil {
+		return
+	} else {
+		query = query.OrderBy(orderBy...)
+	}
+
+	if f.Count, err = rh.Count(r.db(), query); err != nil || f.Count == 0 {
+		return
+	}
+
+	return set, f, rh.FetchPaged(r.db(), query, f.PageFilter, &set)
+}
+
+// FindUnfinished returns pending migrations and migrations that are running
+// but were not updated since staleBefore
+func (r moduleMigration) FindUnfinished(staleBefore time.Time) (set types.ModuleMigrationSet, err error) {
+	query := r.query().
+		Where(squirrel.Or{
+			squirrel.Eq{"status": types.ModuleMigrationPending},
+			squirrel.And{
+				squirrel.Eq{"status": types.ModuleMigrationRunning},
+				squirrel.Or{
+					squirrel.Expr("updated_at IS NULL"),
+					squirrel.Lt{"updated_at": staleBefore},
+				},
+			},
+		}).
+		OrderBy("id")
+
+	return set, rh.FetchAll(r.db(), query, &set)
+}
+
+func (r moduleMigration) Create(mm *types.ModuleMigration) (*types.ModuleMigration, error) {
+	mm.ID = factory.Sonyflake.NextID()
+	rh.SetCurrentTimeRounded(&mm.CreatedAt)
+
+	return mm, r.db().Insert(r.table(), mm)
+}
+
+func (r moduleMigration) Update(mm *types.ModuleMigration) (*types.ModuleMigration, error) {
+	rh.SetCurrentTimeRounded(&mm.UpdatedAt)
+
+	return mm, r.db().Update(r.table(), mm, "id")
+}
+
+// Claim sets migration status to running when migration was not changed
+// (eg: claimed by another node) since it was loaded
+func (r moduleMigration) Claim(mm *types.ModuleMigration) (bool, error) {
+	var (
+		updatedAt *time.Time
+
+		query = squirrel.Update(r.table()).
+			Where(squirrel.Eq{"id": mm.ID, "status": mm.Status})
+	)
+
+	if mm.UpdatedAt == nil {
+		query = query.Where("updated_at IS NULL")
+	} else {
+		query = query.Where(squirrel.Eq{"updated_at": *mm.UpdatedAt})
+	}
+
+	rh.SetCurrentTimeRounded(&updatedAt)
+
+	rsp, err := squirrel.ExecWith(r.db(), query.SetMap(squirrel.Eq{
+		"status":     types.ModuleMigrationRunning,
+		"updated_at": updatedAt,
+	}))
+
+	if err != nil {
+		return false, err
+	}
+
+	n, err := rsp.RowsAffected()
+	if err != nil || n == 0 {
+		return false, err
+	}
+
+	mm.Status, mm.UpdatedAt = types.ModuleMigrationRunning, updatedAt
+	return true, nil
+}
@@ -3,7 +3,9 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
@@ -22,6 +24,7 @@ type (
 		FindByID(namespaceID, recordID uint64) (*types.Record, error)
 
 		Report(module *types.Module, metrics, dimensions, filter string) (results interface{}, err error)
+		Aggregate(module *types.Module, report *types.RecordReport, lookup RecordReportModuleLookup) (*types.RecordReportResult, error)
 		Find(module *types.Module, filter types.RecordFilter) (set types.RecordSet, f types.RecordFilter, err error)
 		Export(module *types.Module, filter types.RecordFilter) (set types.RecordSet, err error)
 
@@ -34,6 +37,26 @@ type (
 		DeleteValues(record *types.Record) error
 		UpdateValues(recordID uint64, rvs types.RecordValueSet) (err error)
 		PartialUpdateValues(rvs ...*types.RecordValue) (err error)
+
+		CountValues(moduleID uint64, names ...string) (counts map[string]uint, err error)
+		FindValuesAfter(moduleID uint64, name string, recordID uint64, place uint, limit uint) (rvs types.RecordValueSet, err error)
+		RenameValues(moduleID uint64, from, to string) (err error)
+		ArchiveValues(migrationID, moduleID uint64, name string) (err error)
+		ArchiveValue(migrationID uint64, v *types.RecordValue) (err error)
+
+		LoadModuleValues(moduleID uint64, names ...string) (rvs types.RecordValueSet, err error)
+		LoadDuplicateCandidates(moduleID, recordID uint64, names []string, patterns map[string][]string) (rvs types.RecordValueSet, err error)
+		RepointRefs(moduleID uint64, names []string, from, to uint64) (err error)
+		FindRefs(moduleID uint64, names []string, refs ...uint64) (rvs types.RecordValueSet, err error)
+		ClearRefs(moduleID uint64, names []string, deletedAt time.Time, refs ...uint64) (err error)
+		RestoreRefs(moduleID uint64, names []string, ref uint64, deletedAt time.Time) (count uint, err error)
+		FindDeletedRefs(moduleID uint64, names []string, ref uint64, deletedAt time.Time) (set types.RecordSet, err error)
+
+		FindDeletedByID(namespaceID, recordID uint64) (*types.Record, error)
+		FindDeleted(filter types.RecordTrashFilter) (set types.RecordSet, f types.RecordTrashFilter, err error)
+		FindPurgeable(deletedBefore time.Time, limit uint) (set types.RecordSet, err error)
+		Undelete(record *types.Record) error
+		Purge(recordIDs ...uint64) error
 	}
 
 	record struct {
@@ -213,6 +236,14 @@ func (r record) buildQuery(module *types.Module, f types.RecordFilter) (query sq
 	// Inc/exclude deleted records according to filter settings
 	query = rh.FilterNullByState(query, "r.deleted_at", f.Deleted)
 
+	if f.AfterID > 0 {
+		query = query.Where("r.id > ?", f.AfterID)
+	}
+
+	if f.BeforeID > 0 {
+		query = query.Where("r.id < ?", f.BeforeID)
+	}
+
 	// Parse filters.
 	if f.Query != "" {
 		var (
@@ -358,6 +389,122 @@ func (r record) LoadValues(fieldNames []string, IDs []uint64) (rvs types.RecordV
 	}
 }
 
+// CountValues counts stored values (of deleted records too) for each of the given field names
+func (r record) CountValues(moduleID uint64, names ...string) (counts map[string]uint, err error) {
+	var (
+		rows = []struct {
+			Name  string `db:"name"`
+			Count uint   `db:"count"`
+		}{}
+
+		sql = "SELECT v.name, COUNT(*) AS count " +
+			"  FROM compose_record_value AS v INNER JOIN compose_record AS r ON (r.id = v.record_id) " +
+			" WHERE r.module_id = ? " +
+			"   AND v.name IN (?) " +
+			" GROUP BY v.name"
+	)
+
+	counts = make(map[string]uint)
+	if len(names) == 0 {
+		return
+	}
+
+	if sql, args, err := sqlx.In(sql, moduleID, names); err != nil {
+		return nil, err
+	} else if err = r.db().Select(&rows, sql, args...); err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.Name] = row.Count
+	}
+
+	return
+}
+
+// FindValuesAfter loads next batch of field values, ordered by record ID and place
+func (r record) FindValuesAfter(moduleID uint64, name string, recordID uint64, place uint, limit uint) (rvs types.RecordValueSet, err error) {
+	var sql = "SELECT v.record_id, v.name, v.value, v.ref, v.place, v.deleted_at " +
+		"  FROM compose_record_value AS v INNER JOIN compose_record AS r ON (r.id = v.record_id) " +
+		" WHERE r.module_id = ? " +
+		"   AND v.name = ? " +
+		"   AND (v.record_id > ? OR (v.record_id = ? AND v.place > ?)) " +
+		" ORDER BY v.record_id, v.place " +
+		" LIMIT ?"
+
+	return rvs, r.db().Select(&rvs, sql, moduleID, name, recordID, recordID, place, limit)
+}
+
+// RenameValues moves all values of one field to another
+func (r record) RenameValues(moduleID uint64, from, to string) (err error) {
+	_, err = r.db().Exec(
+		"UPDATE compose_record_value AS v INNER JOIN compose_record AS r ON (r.id = v.record_id) "+
+			"   SET v.name = ? "+
+			" WHERE r.module_id = ? "+
+			"   AND v.name = ?",
+		to,
+		moduleID,
+		from,
+	)
+
+	return errors.Wrap(err, "could not rename record values")
+}
+
+// ArchiveValues moves all values of a field to archive
+func (r record) ArchiveValues(migrationID, moduleID uint64, name string) (err error) {
+	_, err = r.db().Exec(
+		"INSERT INTO compose_record_value_archive (record_id, name, value, ref, place, rel_migration, archived_at) "+
+			"SELECT v.record_id, v.name, v.value, v.ref, v.place, ?, NOW() "+
+			"  FROM compose_record_value AS v INNER JOIN compose_record AS r ON (r.id = v.record_id) "+
+			" WHERE r.module_id = ? "+
+			"   AND v.name = ?",
+		migrationID,
+		moduleID,
+		name,
+	)
+
+	if err != nil {
+		return errors.Wrap(err, "could not archive record values")
+	}
+
+	_, err = r.db().Exec(
+		"DELETE v FROM compose_record_value AS v INNER JOIN compose_record AS r ON (r.id = v.record_id) "+
+			" WHERE r.module_id = ? "+
+			"   AND v.name = ?",
+		moduleID,
+		name,
+	)
+
+	return errors.Wrap(err, "could not remove archived record values")
+}
+
+// ArchiveValue moves a single value to archive
+func (r record) ArchiveValue(migrationID uint64, v *types.RecordValue) (err error) {
+	_, err = r.db().Exec(
+		"INSERT INTO compose_record_value_archive (record_id, name, value, ref, place, rel_migration, archived_at) "+
+			"VALUES (?, ?, ?, ?, ?, ?, NOW())",
+		v.RecordID,
+		v.Name,
+		v.Value,
+		v.Ref,
+		v.Place,
+		migrationID,
+	)
+
+	if err != nil {
+		return errors.Wrap(err, "could not archive record value")
+	}
+
+	_, err = r.db().Exec(
+		"DELETE FROM compose_record_value WHERE record_id = ? AND name = ? AND place = ?",
+		v.RecordID,
+		v.Name,
+		v.Place,
+	)
+
+	return errors.Wrap(err, "could not remove archived record value")
+}
+
 // Checks if field name is "real column", reformats it and returns
 func isRealRecordCol(name string) (string, bool) {
 	switch name {
@@ -390,3 +537,353 @@ func isRealRecordCol(name string) (string, bool) {
 
 	return name, false
 }
+
+// LoadModuleValues loads values of the given fields for all (non-deleted) records of a module
+func (r record) LoadModuleValues(moduleID uint64, names ...string) (rvs types.RecordValueSet, err error) {
+	if len(names) == 0 {
+		return
+	}
+
+	var sql = "SELECT v.record_id, v.name, v.value, v.ref, v.place, v.deleted_at " +
+		"  FROM compose_record_value AS v INNER JOIN compose_record AS r ON (r.id = v.record_id) " +
+		" WHERE r.module_id = ? " +
+		"   AND r.deleted_at IS NULL " +
+		"   AND v.name IN (?) " +
+		"   AND v.deleted_at IS NULL " +
+		" ORDER BY v.record_id, v.place"
+
+	if sql, args, err := sqlx.In(sql, moduleID, names); err != nil {
+		return nil, err
+	} else {
+		return rvs, r.db().Select(&rvs, sql, args...)
+	}
+}
+
+// LoadDuplicateCandidates loads values of the given fields for (non-deleted) records of a module
+// that have at least one value matching any of the patterns of its field
+//
+// Patterns (SQL LIKE) are matched against trimmed, lowercase values; given record is skipped
+func (r record) LoadDuplicateCandidates(moduleID, recordID uint64, names []string, patterns map[string][]string) (rvs types.RecordValueSet, err error) {
+	var (
+		match = squirrel.Or{}
+	)
+
+	for name, pp := range patterns {
+		like := squirrel.Or{}
+		for _, p := range pp {
+			like = append(like, squirrel.Expr("LOWER(TRIM(c.value)) LIKE ?", p))
+		}
+
+		match = append(match, squirrel.And{squirrel.Eq{"c.name": name}, like})
+	}
+
+	if len(names) == 0 || len(match) == 0 {
+		return
+	}
+
+	candidates, args, err := squirrel.
+		Select("DISTINCT c.record_id").
+		From("compose_record_value AS c").
+		Join("compose_record AS r ON (r.id = c.record_id)").
+		Where(squirrel.Eq{"r.module_id": moduleID, "r.deleted_at": nil, "c.deleted_at": nil}).
+		Where(squirrel.NotEq{"c.record_id": recordID}).
+		Where(match).
+		ToSql()
+
+	if err != nil {
+		return
+	}
+
+	query := squirrel.
+		Select("v.record_id", "v.name", "v.value", "v.ref", "v.place", "v.deleted_at").
+		From("compose_record_value AS v").
+		Where(squirrel.Eq{"v.name": names, "v.deleted_at": nil}).
+		Where("v.record_id IN ("+candidates+")", args...).
+		OrderBy("v.record_id", "v.place")
+
+	return rvs, rh.FetchAll(r.db(), query, &rvs)
+}
+
+// RepointRefs changes references from one record to another in all given fields of a module
+//
+// When record already references the new record in the same field, reference to the
+// old one is removed instead so that there are no duplicated references
+func (r record) RepointRefs(moduleID uint64, names []string, from, to uint64) (err error) {
+	if len(names) == 0 {
+		return
+	}
+
+	var (
+		rvs types.RecordValueSet
+
+		// record fields that already reference the new record
+		has = map[string]bool{}
+
+		key = func(v *types.RecordValue) string {
+			return strconv.FormatUint(v.RecordID, 10) + ":" + v.Name
+		}
+
+		query = squirrel.
+			Select("v.record_id", "v.name", "v.value", "v.ref", "v.place", "v.deleted_at").
+			From("compose_record_value AS v").
+			Join("compose_record AS r ON (r.id = v.record_id)").
+			Where(squirrel.Eq{"r.module_id": moduleID, "v.name": names, "v.ref": []uint64{from, to}}).
+			OrderBy("v.record_id", "v.name", "v.place")
+	)
+
+	if err = rh.FetchAll(r.db(), query, &rvs); err != nil {
+		return errors.Wrap(err, "could not load record references")
+	}
+
+	for _, v := range rvs {
+		if v.Ref == to && v.DeletedAt == nil {
+			has[key(v)] = true
+		}
+	}
+
+	for _, v := range rvs {
+		if v.Ref != from {
+			continue
+		}
+
+		update := squirrel.
+			Update("compose_record_value").
+			Where(squirrel.Eq{"record_id": v.RecordID, "name": v.Name, "place": v.Place})
+
+		if v.DeletedAt == nil && has[key(v)] {
+			update = update.Set("deleted_at", time.Now())
+		} else {
+			update = update.Set("ref", to).Set("value", strconv.FormatUint(to, 10))
+
+			if v.DeletedAt == nil {
+				has[key(v)] = true
+			}
+		}
+
+		if _, err = squirrel.ExecWith(r.db(), update); err != nil {
+			return errors.Wrap(err, "could not repoint record references")
+		}
+	}
+
+	return nil
+}
+
+// FindRefs finds values of (non-deleted) records of a module that reference any of the given records
+func (r record) FindRefs(moduleID uint64, names []string, refs ...uint64) (rvs types.RecordValueSet, err error) {
+	if len(names) == 0 || len(refs) == 0 {
+		return
+	}
+
+	var sql = "SELECT v.record_id, v.name, v.value, v.ref, v.place, v.deleted_at " +
+		"  FROM compose_record_value AS v INNER JOIN compose_record AS r ON (r.id = v.record_id) " +
+		" WHERE r.module_id = ? " +
+		"   AND r.deleted_at IS NULL " +
+		"   AND v.name IN (?) " +
+		"   AND v.ref IN (?) " +
+		"   AND v.deleted_at IS NULL " +
+		" ORDER BY v.record_id, v.place"
+
+	if sql, args, err := sqlx.In(sql, moduleID, names, refs); err != nil {
+		return nil, err
+	} else {
+		return rvs, r.db().Select(&rvs, sql, args...)
+	}
+}
+
+// ClearRefs removes values of record-reference fields of a module that reference any of the given records
+//
+// Values are marked as deleted at the same time as the referenced record so they can be restored with it
+func (r record) ClearRefs(moduleID uint64, names []string, deletedAt time.Time, refs ...uint64) (err error) {
+	if len(names) == 0 || len(refs) == 0 {
+		return
+	}
+
+	var sql = "UPDATE compose_record_value AS v INNER JOIN compose_record AS r ON (r.id = v.record_id) " +
+		"   SET v.deleted_at = ? " +
+		" WHERE r.module_id = ? " +
+		"   AND v.name IN (?) " +
+		"   AND v.ref IN (?) " +
+		"   AND v.deleted_at IS NULL"
+
+	sql, args, err := sqlx.In(sql, deletedAt, moduleID, names, refs)
+	if err != nil {
+		return
+	}
+
+	_, err = r.db().Exec(sql, args...)
+	return errors.Wrap(err, "could not clear record references")
+}
+
+// RestoreRefs restores values of record-reference fields of a module that were cleared
+// when the referenced record was deleted
+//
+// Values of deleted records are not restored
+func (r record) RestoreRefs(moduleID uint64, names []string, ref uint64, deletedAt time.Time) (count uint, err error) {
+	if len(names) == 0 {
+		return
+	}
+
+	var sql = "UPDATE compose_record_value AS v INNER JOIN compose_record AS r ON (r.id = v.record_id) " +
+		"   SET v.deleted_at = NULL " +
+		" WHERE r.module_id = ? " +
+		"   AND r.deleted_at IS NULL " +
+		"   AND v.name IN (?) " +
+		"   AND v.ref = ? " +
+		"   AND v.deleted_at = ?"
+
+	sql, args, err := sqlx.In(sql, moduleID, names, ref, deletedAt)
+	if err != nil {
+		return
+	}
+
+	res, err := r.db().Exec(sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not restore record references")
+	}
+
+	affected, err := res.RowsAffected()
+	return uint(affected), err
+}
+
+// FindDeletedRefs finds records of a module that were deleted at the given time
+// and referenced the given record when they were deleted
+func (r record) FindDeletedRefs(moduleID uint64, names []string, ref uint64, deletedAt time.Time) (set types.RecordSet, err error) {
+	if len(names) == 0 {
+		return
+	}
+
+	referencing, args, err := squirrel.
+		Select("DISTINCT v.record_id").
+		From("compose_record_value AS v").
+		Where(squirrel.Eq{"v.name": names, "v.ref": ref, "v.deleted_at": deletedAt}).
+		ToSql()
+
+	if err != nil {
+		return
+	}
+
+	query := r.query().
+		Where(squirrel.Eq{"r.module_id": moduleID, "r.deleted_at": deletedAt}).
+		Where("r.id IN ("+referencing+")", args...).
+		OrderBy("r.id")
+
+	return set, rh.FetchAll(r.db(), query, &set)
+}
+
+// FindDeletedByID finds deleted record
+func (r record) FindDeletedByID(namespaceID, recordID uint64) (*types.Record, error) {
+	var (
+		rec = &types.Record{}
+
+		q = r.query().
+			Where("r.deleted_at IS NOT NULL").
+			Where(squirrel.Eq{"id": recordID, "rel_namespace": namespaceID})
+
+		err = rh.FetchOne(r.db(), q, rec)
+	)
+
+	if err != nil {
+		return nil, err
+	} else if rec.ID == 0 {
+		return nil, ErrRecordNotFound
+	}
+
+	return rec, nil
+}
+
+// FindDeleted lists deleted records
+func (r record) FindDeleted(filter types.RecordTrashFilter) (set types.RecordSet, f types.RecordTrashFilter, err error) {
+	f = filter
+
+	if f.Sort == "" {
+		f.Sort = "deleted_at DESC"
+	}
+
+	query := r.query().
+		Where("r.deleted_at IS NOT NULL").
+		Where("r.rel_namespace = ?", f.NamespaceID)
+
+	if f.ModuleID > 0 {
+		query = query.Where("r.module_id = ?", f.ModuleID)
+	}
+
+	if f.IsUndeletable != nil {
+		query = query.Where(f.IsUndeletable)
+	}
+
+	var orderBy []string
+	if orderBy, err = rh.ParseOrder(f.Sort, r.columns()...); err != nil {
+		return
+	} else {
+		query = query.OrderBy(orderBy...)
+	}
+
+	if f.Count, err = rh.Count(r.db(), query); err != nil || f.Count == 0 {
+		return
+	}
+
+	return set, f, rh.FetchPaged(r.db(), query, f.PageFilter, &set)
+}
+
+// FindPurgeable returns records deleted before the given time
+func (r record) FindPurgeable(deletedBefore time.Time, limit uint) (set types.RecordSet, err error) {
+	query := r.query().
+		Where("r.deleted_at IS NOT NULL").
+		Where("r.deleted_at < ?", deletedBefore).
+		OrderBy("r.deleted_at").
+		Limit(uint64(limit))
+
+	return set, rh.FetchAll(r.db(), query, &set)
+}
+
+// Undelete restores deleted record and all values that were deleted with it
+func (r record) Undelete(record *types.Record) error {
+	if record.DeletedAt == nil {
+		return nil
+	}
+
+	_, err := r.db().Exec(
+		"UPDATE compose_record_value SET deleted_at = NULL WHERE record_id = ? AND deleted_at = ?",
+		record.ID,
+		record.DeletedAt,
+	)
+
+	if err != nil {
+		return errors.Wrap(err, "could not restore record values")
+	}
+
+	_, err = r.db().Exec(
+		"UPDATE compose_record SET deleted_at = NULL, deleted_by = 0 WHERE rel_namespace = ? AND id = ?",
+		record.NamespaceID,
+		record.ID,
+	)
+
+	return errors.Wrap(err, "could not restore record")
+}
+
+// Purge permanently removes records and all their values
+func (r record) Purge(recordIDs ...uint64) (err error) {
+	if len(recordIDs) == 0 {
+		return
+	}
+
+	var (
+		values   = squirrel.Delete("compose_record_value").Where(squirrel.Eq{"record_id": recordIDs})
+		comments = squirrel.Delete("compose_record_comment").Where(squirrel.Eq{"rel_record": recordIDs})
+		changes  = squirrel.Delete("compose_record_change").Where(squirrel.Eq{"rel_record": recordIDs})
+		records  = squirrel.Delete("compose_record").Where(squirrel.Eq{"id": recordIDs})
+	)
+
+	for _, q := range []squirrel.DeleteBuilder{values, comments, changes, records} {
+		sql, args, err := q.ToSql()
+		if err != nil {
+			return err
+		}
+
+		if _, err = r.db().Exec(sql, args...); err != nil {
+			return errors.Wrap(err, "could not purge records")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/titpetric/factory"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	RecordChangeRepository interface {
+		With(ctx context.Context, db *factory.DB) RecordChangeRepository
+
+		Find(filter types.RecordChangeFilter) (set types.RecordChangeSet, f types.RecordChangeFilter, err error)
+		Create(c *types.RecordChange) (*types.RecordChange, error)
+	}
+
+	recordChange struct {
+		*repository
+	}
+)
+
+func RecordChange(ctx context.Context, db *factory.DB) RecordChangeRepository {
+	return (&recordChange{}).With(ctx, db)
+}
+
+func (r recordChange) With(ctx context.Context, db *factory.DB) RecordChangeRepository {
+	return &recordChange{
+		repository: r.repository.With(ctx, db),
+	}
+}
+
+func (r recordChange) table() string {
+	return "compose_record_change"
+}
+
+func (r recordChange) columns() []string {
+	return []string{
+		"id",
+		"rel_namespace",
+		"rel_module",
+		"rel_record",
+		"fields",
+		"created_at",
+		"created_by",
+	}
+}
+
+func (r recordChange) query() squirrel.SelectBuilder {
+	return squirrel.
+		Select(r.columns()...).
+		From(r.table())
+}
+
+func (r recordChange) Find(filter types.RecordChangeFilter) (set types.RecordChangeSet, f types.RecordChangeFilter, err error) {
+	f = filter
+
+	if f.Sort == "" {
+		f.Sort = "id ASC"
+	}
+
+	query := r.query()
+
+	if filter.NamespaceID > 0 {
+		query = query.Where(squirrel.Eq{"rel_namespace": filter.NamespaceID})
+	}
+
+	if filter.ModuleID > 0 {
+		query = query.Where(squirrel.Eq{"rel_module": filter.ModuleID})
+	}
+
+	if filter.RecordID > 0 {
+		query = query.Where(squirrel.Eq{"rel_record": filter.RecordID})
+	}
+
+	var orderBy []string
+	if orderBy, err = rh.ParseOrder(f.Sort, r.columns()...); err != nil {
+		return
+	} else {
+		query = query.OrderBy(orderBy...)
+	}
+
+	if f.Count, err = rh.Count(r.db(), query); err != nil || f.Count == 0 {
+		return
+	}
+
+	return set, f, rh.FetchPaged(r.db(), query, f.PageFilter, &set)
+}
+
+func (r recordChange) Create(c *types.RecordChange) (*types.RecordChange, error) {
+	c.ID = factory.Sonyflake.NextID()
+	rh.SetCurrentTimeRounded(&c.CreatedAt)
+
+	return c, r.db().Insert(r.table(), c)
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/titpetric/factory"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	RecordCommentRepository interface {
+		With(ctx context.Context, db *factory.DB) RecordCommentRepository
+
+		FindByID(namespaceID, commentID uint64) (*types.RecordComment, error)
+		Find(filter types.RecordCommentFilter) (set types.RecordCommentSet, f types.RecordCommentFilter, err error)
+		Create(c *types.RecordComment) (*types.RecordComment, error)
+		Update(c *types.RecordComment) (*types.RecordComment, error)
+		DeleteByID(namespaceID, commentID uint64) error
+	}
+
+	recordComment struct {
+		*repository
+	}
+)
+
+const (
+	ErrRecordCommentNotFound = repositoryError("RecordCommentNotFound")
+)
+
+func RecordComment(ctx context.Context, db *factory.DB) RecordCommentRepository {
+	return (&recordComment{}).With(ctx, db)
+}
+
+func (r recordComment) With(ctx context.Context, db *factory.DB) RecordCommentRepository {
+	return &recordComment{
+		repository: r.repository.With(ctx, db),
+	}
+}
+
+func (r recordComment) table() string {
+	return "compose_record_comment"
+}
+
+func (r recordComment) columns() []string {
+	return []string{
+		"id",
+		"rel_namespace",
+		"rel_module",
+		"rel_record",
+		"reply_to",
+		"message",
+		"mentions",
+		"attachments",
+		"created_at",
+		"created_by",
+		"updated_at",
+		"deleted_at",
+	}
+}
+
+func (r recordComment) query() squirrel.SelectBuilder {
+	return squirrel.
+		Select(r.columns()...).
+		From(r.table()).
+		Where("deleted_at IS NULL")
+}
+
+func (r recordComment) FindByID(namespaceID, commentID uint64) (*types.RecordComment, error) {
+	var (
+		c = &types.RecordComment{}
+
+		q = r.query().
+			Where(squirrel.Eq{"id": commentID, "rel_namespace": namespaceID})
+	)
+
+	if err := rh.FetchOne(r.db(), q, c); err != nil {
+		return nil, err
+	} else if c.ID == 0 {
+		return nil, ErrRecordCommentNotFound
+	}
+
+	return c, nil
+}
+
+func (r recordComment) Find(filter types.RecordCommentFilter) (set types.RecordCommentSet, f types.RecordCommentFilter, err error) {
+	f = filter
+
+	if f.Sort == "" {
+		f.Sort = "id ASC"
+	}
+
+	query := r.query()
+
+	if filter.NamespaceID > 0 {
+		query = query.Where(squirrel.Eq{"rel_namespace": filter.NamespaceID})
+	}
+
+	if filter.ModuleID > 0 {
+		query = query.Where(squirrel.Eq{"rel_module": filter.ModuleID})
+	}
+
+	if filter.RecordID > 0 {
+		query = query.Where(squirrel.Eq{"rel_record": filter.RecordID})
+	}
+
+	var orderBy []string
+	if orderBy, err = rh.ParseOrder(f.Sort, r.columns()...); err != nil {
+		return
+	} else {
+		query = query.OrderBy(orderBy...)
+	}
+
+	if f.Count, err = rh.Count(r.db(), query); err != nil || f.Count == 0 {
+		return
+	}
+
+	return set, f, rh.FetchPaged(r.db(), query, f.PageFilter, &set)
+}
+
+func (r recordComment) Create(c *types.RecordComment) (*types.RecordComment, error) {
+	c.ID = factory.Sonyflake.NextID()
+	rh.SetCurrentTimeRounded(&c.CreatedAt)
+
+	return c, r.db().Insert(r.table(), c)
+}
+
+func (r recordComment) Update(c *types.RecordComment) (*types.RecordComment, error) {
+	rh.SetCurrentTimeRounded(&c.UpdatedAt)
+
+	return c, r.db().Update(r.table(), c, "id")
+}
+
+func (r recordComment) DeleteByID(namespaceID, commentID uint64) error {
+	_, err := r.db().Exec(
+		"UPDATE "+r.table()+" SET deleted_at = ? WHERE rel_namespace = ? AND id = ?",
+		time.Now(),
+		namespaceID,
+		commentID,
+	)
+
+	return err
+}
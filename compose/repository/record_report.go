@@ -0,0 +1,389 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/ql"
+)
+
+type (
+	// RecordReportModuleLookup loads modules that are referenced from report's field paths
+	RecordReportModuleLookup func(moduleID uint64) (*types.Module, error)
+
+	// recordReportQuery builds queries for typed record reports
+	//
+	// Fields from referenced modules are joined through compose_record_value.ref
+	recordReportQuery struct {
+		module *types.Module
+		report *types.RecordReport
+		lookup RecordReportModuleLookup
+
+		// Joined tables, indexed by field path
+		joins map[string]string
+
+		query  squirrel.SelectBuilder
+		parser *ql.Parser
+	}
+)
+
+// NewRecordReportQuery prepares query builder for the validated report
+func NewRecordReportQuery(module *types.Module, report *types.RecordReport, lookup RecordReportModuleLookup) *recordReportQuery {
+	return &recordReportQuery{
+		module: module,
+		report: report,
+		lookup: lookup,
+	}
+}
+
+// Build returns query that aggregates all metrics except percentiles
+func (b *recordReportQuery) Build() (string, []interface{}, error) {
+	if err := b.reset(); err != nil {
+		return "", nil, err
+	}
+
+	for i, m := range b.report.Metrics {
+		var (
+			col  string
+			expr string
+			err  error
+		)
+
+		if m.Field != "" {
+			if col, err = b.column(m.Field); err != nil {
+				return "", nil, err
+			}
+		}
+
+		switch m.Aggregate {
+		case types.RecordReportCount:
+			if col == "" {
+				expr = "COUNT(DISTINCT r.id)"
+			} else {
+				expr = fmt.Sprintf("COUNT(%s)", col)
+			}
+		case types.RecordReportCountD:
+			expr = fmt.Sprintf("COUNT(DISTINCT %s)", col)
+		case types.RecordReportPercentile:
+			// calculated from raw values, see BuildValues()
+			expr = "NULL"
+		default:
+			expr = fmt.Sprintf("%s(CAST(%s AS DECIMAL(30,4)))", m.Aggregate, col)
+		}
+
+		b.query = b.query.Column(fmt.Sprintf("%s AS m%d", expr, i))
+	}
+
+	for i := range b.report.Dimensions {
+		b.query = b.query.
+			GroupBy(fmt.Sprintf("d%d", i)).
+			OrderBy(fmt.Sprintf("d%d", i))
+	}
+
+	return b.query.ToSql()
+}
+
+// BuildValues returns query that selects raw values of percentile metrics
+//
+// MySQL does not support percentile functions; values are fetched
+// ordered by dimensions and aggregated by the repository
+func (b *recordReportQuery) BuildValues() (string, []interface{}, error) {
+	if err := b.reset(); err != nil {
+		return "", nil, err
+	}
+
+	for i, m := range b.report.Metrics {
+		if m.Aggregate != types.RecordReportPercentile {
+			continue
+		}
+
+		col, err := b.column(m.Field)
+		if err != nil {
+			return "", nil, err
+		}
+
+		b.query = b.query.Column(fmt.Sprintf("CAST(%s AS DECIMAL(30,4)) AS m%d", col, i))
+	}
+
+	for i := range b.report.Dimensions {
+		b.query = b.query.OrderBy(fmt.Sprintf("d%d", i))
+	}
+
+	return b.query.ToSql()
+}
+
+// reset starts a new query with dimensions and filter
+func (b *recordReportQuery) reset() (err error) {
+	b.joins = map[string]string{}
+	b.parser = ql.NewParser()
+	b.parser.OnFunction = stdFilterFuncHandler
+	b.parser.OnIdent = func(i ql.Ident) (ql.Ident, error) {
+		i.Value, err = b.column(i.Value)
+		return i, err
+	}
+
+	b.query = squirrel.
+		Select().
+		From("compose_record AS r").
+		Where("r.deleted_at IS NULL").
+		Where("r.module_id = ?", b.module.ID)
+
+	for i, d := range b.report.Dimensions {
+		var col string
+		if col, err = b.column(d.Field); err != nil {
+			return
+		}
+
+		b.query = b.query.Column(fmt.Sprintf("%s AS d%d", recordReportBucketExpr(d.Bucket, col), i))
+	}
+
+	if len(b.report.Filter) > 0 {
+		var filter ql.ASTNode
+		if filter, err = b.parser.ParseExpression(b.report.Filter); err != nil {
+			return errors.Wrapf(err, "could not parse filter %q", b.report.Filter)
+		}
+
+		b.query = b.query.Where(filter)
+	}
+
+	return nil
+}
+
+// column resolves field path and joins all tables needed
+//
+// Each of the path's segments, except the last one, must be a
+// single-value record field; last segment is a field or system column
+func (b *recordReportQuery) column(path string) (string, error) {
+	var (
+		m     = b.module
+		table = "r"
+		segs  = strings.Split(path, ".")
+	)
+
+	for s, name := range segs {
+		var (
+			last  = s == len(segs)-1
+			sub   = strings.Join(segs[:s+1], ".")
+			alias = b.joins[sub]
+		)
+
+		if last {
+			if col, is := isRealRecordCol(name); is {
+				return table + strings.TrimPrefix(col, "r"), nil
+			}
+		}
+
+		f := m.Fields.FindByName(name)
+		if f == nil {
+			return "", errors.Errorf("unknown field %q", sub)
+		}
+
+		if alias == "" {
+			alias = fmt.Sprintf("rv%d", len(b.joins))
+			b.joins[sub] = alias
+			b.query = b.query.LeftJoin(fmt.Sprintf(
+				"compose_record_value AS %s ON (%s.record_id = %s.id AND %s.name = ? AND %s.deleted_at IS NULL)",
+				alias, alias, table, alias, alias,
+			), f.Name)
+		}
+
+		if last {
+			return alias + ".value", nil
+		}
+
+		if f.Kind != "Record" || f.Multi {
+			return "", errors.Errorf("field %q is not a single-value record field", sub)
+		}
+
+		ref, err := b.lookup(f.Options.ModuleID())
+		if err != nil {
+			return "", errors.Wrapf(err, "can not load module referenced from %q", sub)
+		}
+
+		var refAlias = "r" + alias
+		if _, joined := b.joins[sub+".*"]; !joined {
+			b.joins[sub+".*"] = refAlias
+			b.query = b.query.LeftJoin(fmt.Sprintf(
+				"compose_record AS %s ON (%s.id = %s.ref AND %s.module_id = ? AND %s.deleted_at IS NULL)",
+				refAlias, refAlias, alias, refAlias, refAlias,
+			), ref.ID)
+		}
+
+		m, table = ref, refAlias
+	}
+
+	return "", errors.Errorf("invalid field path %q", path)
+}
+
+// recordReportBucketExpr wraps column into function that returns bucket's label
+//
+// Labels must match the ones produced by types.RecordReportBucket.Format()
+func recordReportBucketExpr(b types.RecordReportBucket, col string) string {
+	switch b {
+	case types.RecordReportBucketDay:
+		return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-%%d')", col)
+	case types.RecordReportBucketWeek:
+		return fmt.Sprintf("DATE_FORMAT(%s, '%%x-W%%v')", col)
+	case types.RecordReportBucketMonth:
+		return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m')", col)
+	case types.RecordReportBucketQuarter:
+		return fmt.Sprintf("CONCAT(YEAR(%s), '-Q', QUARTER(%s))", col, col)
+	case types.RecordReportBucketYear:
+		return fmt.Sprintf("DATE_FORMAT(%s, '%%Y')", col)
+	}
+
+	return col
+}
+
+// Aggregate runs typed report and returns post-processed results
+func (r record) Aggregate(module *types.Module, report *types.RecordReport, lookup RecordReportModuleLookup) (res *types.RecordReportResult, err error) {
+	var (
+		q     = NewRecordReportQuery(module, report, lookup)
+		index = map[string]*types.RecordReportRow{}
+
+		dims    = len(report.Dimensions)
+		metrics = len(report.Metrics)
+	)
+
+	res = types.MakeRecordReportResult(report)
+
+	query, args, err := q.Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "can not generate report query")
+	}
+
+	err = r.scanReport(query, args, dims, metrics, func(d []*string, m []sql.NullFloat64) {
+		row := &types.RecordReportRow{Dimensions: d, Metrics: make([]float64, metrics)}
+		for i := range m {
+			row.Metrics[i] = m[i].Float64
+		}
+
+		res.Rows = append(res.Rows, row)
+		index[recordReportKey(d)] = row
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var percentiles = make([]int, 0)
+	for i, m := range report.Metrics {
+		if m.Aggregate == types.RecordReportPercentile {
+			percentiles = append(percentiles, i)
+		}
+	}
+
+	if len(percentiles) > 0 {
+		if query, args, err = q.BuildValues(); err != nil {
+			return nil, errors.Wrap(err, "can not generate report values query")
+		}
+
+		var values = map[string][][]float64{}
+		err = r.scanReport(query, args, dims, len(percentiles), func(d []*string, m []sql.NullFloat64) {
+			var key = recordReportKey(d)
+			if values[key] == nil {
+				values[key] = make([][]float64, len(percentiles))
+			}
+
+			for p := range m {
+				if m[p].Valid {
+					values[key][p] = append(values[key][p], m[p].Float64)
+				}
+			}
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		for key, vv := range values {
+			if row, ok := index[key]; ok {
+				for p, i := range percentiles {
+					row.Metrics[i] = percentile(vv[p], report.Metrics[i].Percentile)
+				}
+			}
+		}
+	}
+
+	return res, res.PostProcess(report)
+}
+
+// scanReport executes report query and scans dimensions and metrics from each row
+func (r record) scanReport(query string, args []interface{}, dims, metrics int, fn func([]*string, []sql.NullFloat64)) error {
+	rows, err := r.db().Query(query, args...)
+	if err != nil {
+		return errors.Wrapf(err, "can not execute report query (%s)", query)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			d    = make([]sql.NullString, dims)
+			m    = make([]sql.NullFloat64, metrics)
+			dest = make([]interface{}, 0, dims+metrics)
+			out  = make([]*string, dims)
+		)
+
+		for i := range d {
+			dest = append(dest, &d[i])
+		}
+
+		for i := range m {
+			dest = append(dest, &m[i])
+		}
+
+		if err = rows.Scan(dest...); err != nil {
+			return err
+		}
+
+		for i := range d {
+			if d[i].Valid {
+				out[i] = &d[i].String
+			}
+		}
+
+		fn(out, m)
+	}
+
+	return rows.Err()
+}
+
+func recordReportKey(dd []*string) string {
+	var kk = make([]string, len(dd))
+	for i, d := range dd {
+		if d == nil {
+			kk[i] = "\x01"
+		} else {
+			kk[i] = *d
+		}
+	}
+
+	return strings.Join(kk, "\x00")
+}
+
+// percentile calculates p-th percentile with linear interpolation between closest ranks
+func percentile(vv []float64, p float64) float64 {
+	if len(vv) == 0 {
+		return 0
+	}
+
+	sort.Float64s(vv)
+
+	var (
+		rank  = p / 100 * float64(len(vv)-1)
+		lower = int(rank)
+	)
+
+	if lower >= len(vv)-1 {
+		return vv[len(vv)-1]
+	}
+
+	return vv[lower] + (vv[lower+1]-vv[lower])*(rank-float64(lower))
+}
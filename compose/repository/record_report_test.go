@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+)
+
+func TestRecordReportQuery(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		account = &types.Module{
+			ID: 2000,
+			Fields: types.ModuleFieldSet{
+				&types.ModuleField{Name: "industry"},
+			},
+		}
+
+		opportunity = &types.Module{
+			ID: 1000,
+			Fields: types.ModuleFieldSet{
+				&types.ModuleField{Name: "amount"},
+				&types.ModuleField{Name: "account", Kind: "Record", Options: types.ModuleFieldOptions{"moduleID": "2000"}},
+				&types.ModuleField{Name: "contacts", Kind: "Record", Multi: true},
+			},
+		}
+
+		lookup = func(moduleID uint64) (*types.Module, error) {
+			if moduleID == account.ID {
+				return account, nil
+			}
+
+			return nil, errors.New("not found")
+		}
+
+		report = &types.RecordReport{
+			Metrics: types.RecordReportMetricSet{
+				{},
+				{Field: "amount", Aggregate: "countd"},
+				{Field: "amount", Aggregate: "percentile", Percentile: 50},
+			},
+			Dimensions: types.RecordReportDimensionSet{
+				{Field: "account.industry"},
+				{Field: "account.createdAt", Bucket: types.RecordReportBucketQuarter},
+			},
+			Filter: "amount > 100",
+		}
+	)
+
+	req.NoError(report.Validate())
+
+	var joins = "FROM compose_record AS r " +
+		"LEFT JOIN compose_record_value AS rv0 ON (rv0.record_id = r.id AND rv0.name = ? AND rv0.deleted_at IS NULL) " +
+		"LEFT JOIN compose_record AS rrv0 ON (rrv0.id = rv0.ref AND rrv0.module_id = ? AND rrv0.deleted_at IS NULL) " +
+		"LEFT JOIN compose_record_value AS rv2 ON (rv2.record_id = rrv0.id AND rv2.name = ? AND rv2.deleted_at IS NULL) " +
+		"LEFT JOIN compose_record_value AS rv3 ON (rv3.record_id = r.id AND rv3.name = ? AND rv3.deleted_at IS NULL) " +
+		"WHERE r.deleted_at IS NULL AND r.module_id = ? AND rv3.value > 100"
+
+	sql, args, err := NewRecordReportQuery(opportunity, report, lookup).Build()
+	req.NoError(err)
+	req.Equal(
+		"SELECT rv2.value AS d0, CONCAT(YEAR(rrv0.created_at), '-Q', QUARTER(rrv0.created_at)) AS d1, "+
+			"COUNT(DISTINCT r.id) AS m0, COUNT(DISTINCT rv3.value) AS m1, NULL AS m2 "+
+			joins+" "+
+			"GROUP BY d0, d1 ORDER BY d0, d1",
+		sql,
+	)
+	req.Equal([]interface{}{"account", uint64(2000), "industry", "amount", uint64(1000)}, args)
+
+	sql, _, err = NewRecordReportQuery(opportunity, report, lookup).BuildValues()
+	req.NoError(err)
+	req.Equal(
+		"SELECT rv2.value AS d0, CONCAT(YEAR(rrv0.created_at), '-Q', QUARTER(rrv0.created_at)) AS d1, "+
+			"CAST(rv3.value AS DECIMAL(30,4)) AS m2 "+
+			joins+" "+
+			"ORDER BY d0, d1",
+		sql,
+	)
+
+	report.Dimensions[0].Field = "contacts.name"
+	_, _, err = NewRecordReportQuery(opportunity, report, lookup).Build()
+	req.EqualError(err, `field "contacts" is not a single-value record field`)
+
+	report.Dimensions[0].Field = "account.foo"
+	_, _, err = NewRecordReportQuery(opportunity, report, lookup).Build()
+	req.EqualError(err, `unknown field "account.foo"`)
+}
+
+func TestPercentile(t *testing.T) {
+	req := require.New(t)
+	req.Equal(0.0, percentile(nil, 50))
+	req.Equal(3.0, percentile([]float64{5, 1, 3}, 50))
+	req.Equal(2.5, percentile([]float64{4, 1, 3, 2}, 50))
+	req.Equal(4.0, percentile([]float64{4, 1, 3, 2}, 100))
+	req.Equal(1.0, percentile([]float64{4, 1, 3, 2}, 0))
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/titpetric/factory"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	RecordTransitionRepository interface {
+		With(ctx context.Context, db *factory.DB) RecordTransitionRepository
+
+		Find(filter types.RecordTransitionFilter) (set types.RecordTransitionSet, f types.RecordTransitionFilter, err error)
+		Create(t *types.RecordTransition) (*types.RecordTransition, error)
+	}
+
+	recordTransition struct {
+		*repository
+	}
+)
+
+func RecordTransition(ctx context.Context, db *factory.DB) RecordTransitionRepository {
+	return (&recordTransition{}).With(ctx, db)
+}
+
+func (r recordTransition) With(ctx context.Context, db *factory.DB) RecordTransitionRepository {
+	return &recordTransition{
+		repository: r.repository.With(ctx, db),
+	}
+}
+
+func (r recordTransition) table() string {
+	return "compose_record_transition"
+}
+
+func (r recordTransition) columns() []string {
+	return []string{
+		"id",
+		"rel_namespace",
+		"rel_module",
+		"rel_record",
+		"field",
+		"transition",
+		"from_state",
+		"to_state",
+		"created_at",
+		"created_by",
+	}
+}
+
+func (r recordTransition) query() squirrel.SelectBuilder {
+	return squirrel.
+		Select(r.columns()...).
+		From(r.table())
+}
+
+func (r recordTransition) Find(filter types.RecordTransitionFilter) (set types.RecordTransitionSet, f types.RecordTransitionFilter, err error) {
+	f = filter
+
+	if f.Sort == "" {
+		f.Sort = "id ASC"
+	}
+
+	query := r.query()
+
+	if filter.NamespaceID > 0 {
+		query = query.Where(squirrel.Eq{"rel_namespace": filter.NamespaceID})
+	}
+
+	if filter.ModuleID > 0 {
+		query = query.Where(squirrel.Eq{"rel_module": filter.ModuleID})
+	}
+
+	if filter.RecordID > 0 {
+		query = query.Where(squirrel.Eq{"rel_record": filter.RecordID})
+	}
+
+	var orderBy []string
+	if orderBy, err = rh.ParseOrder(f.Sort, r.columns()...); err != nil {
+		return
+	} else {
+		query = query.OrderBy(orderBy...)
+	}
+
+	if f.Count, err = rh.Count(r.db(), query); err != nil || f.Count == 0 {
+		return
+	}
+
+	return set, f, rh.FetchPaged(r.db(), query, f.PageFilter, &set)
+}
+
+func (r recordTransition) Create(t *types.RecordTransition) (*types.RecordTransition, error) {
+	t.ID = factory.Sonyflake.NextID()
+	rh.SetCurrentTimeRounded(&t.CreatedAt)
+
+	return t, r.db().Insert(r.table(), t)
+}
@@ -1,10 +1,13 @@
 package rest
 
 import (
+	"bytes"
 	"context"
+	"net/http"
 
 	"github.com/titpetric/factory/resputil"
 
+	"github.com/cortezaproject/corteza-server/compose/renderer"
 	"github.com/cortezaproject/corteza-server/compose/rest/request"
 	"github.com/cortezaproject/corteza-server/compose/service"
 	"github.com/cortezaproject/corteza-server/compose/types"
@@ -88,6 +91,37 @@ func (ctrl Chart) Read(ctx context.Context, r *request.ChartRead) (interface{},
 
 }
 
+// Render runs chart's report and renders it as PNG or SVG image
+func (ctrl Chart) Render(ctx context.Context, r *request.ChartRender) (interface{}, error) {
+	if !renderer.IsSupported(r.Ext) {
+		return nil, errors.Errorf("unsupported chart format %q", r.Ext)
+	}
+
+	o := renderer.Options{Width: int(r.Width), Height: int(r.Height)}
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	d, err := ctrl.chart.With(ctx).Dataset(r.NamespaceID, r.ChartID)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w http.ResponseWriter, req *http.Request) {
+		var (
+			buf = &bytes.Buffer{}
+		)
+
+		if err := renderer.Render(buf, r.Ext, d, o); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Add("Content-Type", renderer.ContentType(r.Ext))
+		_, _ = buf.WriteTo(w)
+	}, nil
+}
+
 func (ctrl Chart) Update(ctx context.Context, r *request.ChartUpdate) (interface{}, error) {
 	var (
 		err error
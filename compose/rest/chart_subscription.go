@@ -0,0 +1,70 @@
+package rest
+
+import (
+	"context"
+
+	"github.com/titpetric/factory/resputil"
+
+	"github.com/cortezaproject/corteza-server/compose/rest/request"
+	"github.com/cortezaproject/corteza-server/compose/service"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	chartSubscriptionSetPayload struct {
+		Filter types.ChartSubscriptionFilter `json:"filter"`
+		Set    types.ChartSubscriptionSet    `json:"set"`
+	}
+
+	ChartSubscription struct {
+		subscription service.ChartSubscriptionService
+	}
+)
+
+func (ChartSubscription) New() *ChartSubscription {
+	return &ChartSubscription{
+		subscription: service.DefaultChartSubscription,
+	}
+}
+
+func (ctrl ChartSubscription) List(ctx context.Context, r *request.ChartSubscriptionList) (interface{}, error) {
+	f := types.ChartSubscriptionFilter{
+		NamespaceID: r.NamespaceID,
+		ChartID:     r.ChartID,
+
+		Sort: r.Sort,
+
+		PageFilter: rh.Paging(r),
+	}
+
+	set, filter, err := ctrl.subscription.With(ctx).Find(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chartSubscriptionSetPayload{Filter: filter, Set: set}, nil
+}
+
+func (ctrl ChartSubscription) Create(ctx context.Context, r *request.ChartSubscriptionCreate) (interface{}, error) {
+	s := &types.ChartSubscription{
+		NamespaceID: r.NamespaceID,
+		ChartID:     r.ChartID,
+		Frequency:   types.ChartSubscriptionFrequency(r.Frequency),
+		Format:      r.Format,
+		Recipients: types.ChartSubscriptionRecipients{
+			Users: r.Users,
+			Roles: r.Roles,
+		},
+	}
+
+	if r.NextRunAt != nil {
+		s.NextRunAt = *r.NextRunAt
+	}
+
+	return ctrl.subscription.With(ctx).Create(s)
+}
+
+func (ctrl ChartSubscription) Delete(ctx context.Context, r *request.ChartSubscriptionDelete) (interface{}, error) {
+	return resputil.OK(), ctrl.subscription.With(ctx).DeleteByID(r.NamespaceID, r.ChartID, r.SubscriptionID)
+}
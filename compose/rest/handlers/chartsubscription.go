@@ -0,0 +1,116 @@
+package handlers
+
+/*
+	Hello! This file is auto-generated from `docs/src/spec.json`.
+
+	For development:
+	In order to update the generated files, edit this file under the location,
+	add your struct fields, imports, API definitions and whatever you want, and:
+
+	1. run [spec](https://github.com/titpetric/spec) in the same folder,
+	2. run `./_gen.php` in this folder.
+
+	You may edit `chartsubscription.go`, `chartsubscription.util.go` or `chartsubscription_test.go` to
+	implement your API calls, helper functions and tests. The file `chartsubscription.go`
+	is only generated the first time, and will not be overwritten if it exists.
+*/
+
+import (
+	"context"
+
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/titpetric/factory/resputil"
+
+	"github.com/cortezaproject/corteza-server/compose/rest/request"
+	"github.com/cortezaproject/corteza-server/pkg/logger"
+)
+
+// Internal API interface
+type ChartSubscriptionAPI interface {
+	List(context.Context, *request.ChartSubscriptionList) (interface{}, error)
+	Create(context.Context, *request.ChartSubscriptionCreate) (interface{}, error)
+	Delete(context.Context, *request.ChartSubscriptionDelete) (interface{}, error)
+}
+
+// HTTP API interface
+type ChartSubscription struct {
+	List   func(http.ResponseWriter, *http.Request)
+	Create func(http.ResponseWriter, *http.Request)
+	Delete func(http.ResponseWriter, *http.Request)
+}
+
+func NewChartSubscription(h ChartSubscriptionAPI) *ChartSubscription {
+	return &ChartSubscription{
+		List: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewChartSubscriptionList()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("ChartSubscription.List", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.List(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("ChartSubscription.List", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("ChartSubscription.List", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Create: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewChartSubscriptionCreate()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("ChartSubscription.Create", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Create(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("ChartSubscription.Create", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("ChartSubscription.Create", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Delete: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewChartSubscriptionDelete()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("ChartSubscription.Delete", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Delete(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("ChartSubscription.Delete", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("ChartSubscription.Delete", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+	}
+}
+
+func (h ChartSubscription) MountRoutes(r chi.Router, middlewares ...func(http.Handler) http.Handler) {
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares...)
+		r.Get("/namespace/{namespaceID}/chart/{chartID}/subscription/", h.List)
+		r.Post("/namespace/{namespaceID}/chart/{chartID}/subscription/", h.Create)
+		r.Delete("/namespace/{namespaceID}/chart/{chartID}/subscription/{subscriptionID}", h.Delete)
+	})
+}
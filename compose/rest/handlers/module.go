@@ -33,6 +33,8 @@ type ModuleAPI interface {
 	Create(context.Context, *request.ModuleCreate) (interface{}, error)
 	Read(context.Context, *request.ModuleRead) (interface{}, error)
 	Update(context.Context, *request.ModuleUpdate) (interface{}, error)
+	MigrationPlan(context.Context, *request.ModuleMigrationPlan) (interface{}, error)
+	MigrationList(context.Context, *request.ModuleMigrationList) (interface{}, error)
 	Delete(context.Context, *request.ModuleDelete) (interface{}, error)
 	TriggerScript(context.Context, *request.ModuleTriggerScript) (interface{}, error)
 }
@@ -43,6 +45,8 @@ type Module struct {
 	Create        func(http.ResponseWriter, *http.Request)
 	Read          func(http.ResponseWriter, *http.Request)
 	Update        func(http.ResponseWriter, *http.Request)
+	MigrationPlan func(http.ResponseWriter, *http.Request)
+	MigrationList func(http.ResponseWriter, *http.Request)
 	Delete        func(http.ResponseWriter, *http.Request)
 	TriggerScript func(http.ResponseWriter, *http.Request)
 }
@@ -129,6 +133,46 @@ func NewModule(h ModuleAPI) *Module {
 				resputil.JSON(w, value)
 			}
 		},
+		MigrationPlan: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewModuleMigrationPlan()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Module.MigrationPlan", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.MigrationPlan(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Module.MigrationPlan", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Module.MigrationPlan", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		MigrationList: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewModuleMigrationList()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Module.MigrationList", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.MigrationList(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Module.MigrationList", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Module.MigrationList", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
 		Delete: func(w http.ResponseWriter, r *http.Request) {
 			defer r.Body.Close()
 			params := request.NewModuleDelete()
@@ -179,6 +223,8 @@ func (h Module) MountRoutes(r chi.Router, middlewares ...func(http.Handler) http
 		r.Post("/namespace/{namespaceID}/module/", h.Create)
 		r.Get("/namespace/{namespaceID}/module/{moduleID}", h.Read)
 		r.Post("/namespace/{namespaceID}/module/{moduleID}", h.Update)
+		r.Post("/namespace/{namespaceID}/module/{moduleID}/migration/plan", h.MigrationPlan)
+		r.Get("/namespace/{namespaceID}/module/{moduleID}/migration", h.MigrationList)
 		r.Delete("/namespace/{namespaceID}/module/{moduleID}", h.Delete)
 		r.Post("/namespace/{namespaceID}/module/{moduleID}/trigger", h.TriggerScript)
 	})
@@ -35,6 +35,10 @@ type NamespaceAPI interface {
 	Update(context.Context, *request.NamespaceUpdate) (interface{}, error)
 	Delete(context.Context, *request.NamespaceDelete) (interface{}, error)
 	TriggerScript(context.Context, *request.NamespaceTriggerScript) (interface{}, error)
+	Clone(context.Context, *request.NamespaceClone) (interface{}, error)
+	Template(context.Context, *request.NamespaceTemplate) (interface{}, error)
+	Install(context.Context, *request.NamespaceInstall) (interface{}, error)
+	Trash(context.Context, *request.NamespaceTrash) (interface{}, error)
 }
 
 // HTTP API interface
@@ -45,6 +49,10 @@ type Namespace struct {
 	Update        func(http.ResponseWriter, *http.Request)
 	Delete        func(http.ResponseWriter, *http.Request)
 	TriggerScript func(http.ResponseWriter, *http.Request)
+	Clone         func(http.ResponseWriter, *http.Request)
+	Template      func(http.ResponseWriter, *http.Request)
+	Install       func(http.ResponseWriter, *http.Request)
+	Trash         func(http.ResponseWriter, *http.Request)
 }
 
 func NewNamespace(h NamespaceAPI) *Namespace {
@@ -169,6 +177,86 @@ func NewNamespace(h NamespaceAPI) *Namespace {
 				resputil.JSON(w, value)
 			}
 		},
+		Clone: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewNamespaceClone()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Namespace.Clone", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Clone(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Namespace.Clone", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Namespace.Clone", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Template: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewNamespaceTemplate()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Namespace.Template", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Template(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Namespace.Template", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Namespace.Template", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Install: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewNamespaceInstall()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Namespace.Install", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Install(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Namespace.Install", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Namespace.Install", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Trash: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewNamespaceTrash()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Namespace.Trash", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Trash(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Namespace.Trash", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Namespace.Trash", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
 	}
 }
 
@@ -181,5 +269,9 @@ func (h Namespace) MountRoutes(r chi.Router, middlewares ...func(http.Handler) h
 		r.Post("/namespace/{namespaceID}", h.Update)
 		r.Delete("/namespace/{namespaceID}", h.Delete)
 		r.Post("/namespace/{namespaceID}/trigger", h.TriggerScript)
+		r.Post("/namespace/{namespaceID}/clone", h.Clone)
+		r.Get("/namespace/{namespaceID}/template", h.Template)
+		r.Post("/namespace/install", h.Install)
+		r.Get("/namespace/{namespaceID}/trash", h.Trash)
 	})
 }
@@ -30,17 +30,26 @@ import (
 // Internal API interface
 type RecordAPI interface {
 	Report(context.Context, *request.RecordReport) (interface{}, error)
+	Aggregate(context.Context, *request.RecordAggregate) (interface{}, error)
 	List(context.Context, *request.RecordList) (interface{}, error)
 	ImportInit(context.Context, *request.RecordImportInit) (interface{}, error)
 	ImportRun(context.Context, *request.RecordImportRun) (interface{}, error)
 	ImportProgress(context.Context, *request.RecordImportProgress) (interface{}, error)
 	Export(context.Context, *request.RecordExport) (interface{}, error)
+	ExportJob(context.Context, *request.RecordExportJob) (interface{}, error)
 	Exec(context.Context, *request.RecordExec) (interface{}, error)
 	Create(context.Context, *request.RecordCreate) (interface{}, error)
 	Read(context.Context, *request.RecordRead) (interface{}, error)
+	Transitions(context.Context, *request.RecordTransitions) (interface{}, error)
+	Activity(context.Context, *request.RecordActivity) (interface{}, error)
+	Duplicates(context.Context, *request.RecordDuplicates) (interface{}, error)
+	RecordDuplicates(context.Context, *request.RecordRecordDuplicates) (interface{}, error)
+	Merge(context.Context, *request.RecordMerge) (interface{}, error)
+	DeleteImpact(context.Context, *request.RecordDeleteImpact) (interface{}, error)
 	Update(context.Context, *request.RecordUpdate) (interface{}, error)
 	BulkDelete(context.Context, *request.RecordBulkDelete) (interface{}, error)
 	Delete(context.Context, *request.RecordDelete) (interface{}, error)
+	Undelete(context.Context, *request.RecordUndelete) (interface{}, error)
 	Upload(context.Context, *request.RecordUpload) (interface{}, error)
 	TriggerScript(context.Context, *request.RecordTriggerScript) (interface{}, error)
 	TriggerScriptOnList(context.Context, *request.RecordTriggerScriptOnList) (interface{}, error)
@@ -49,17 +58,26 @@ type RecordAPI interface {
 // HTTP API interface
 type Record struct {
 	Report              func(http.ResponseWriter, *http.Request)
+	Aggregate           func(http.ResponseWriter, *http.Request)
 	List                func(http.ResponseWriter, *http.Request)
 	ImportInit          func(http.ResponseWriter, *http.Request)
 	ImportRun           func(http.ResponseWriter, *http.Request)
 	ImportProgress      func(http.ResponseWriter, *http.Request)
 	Export              func(http.ResponseWriter, *http.Request)
+	ExportJob           func(http.ResponseWriter, *http.Request)
 	Exec                func(http.ResponseWriter, *http.Request)
 	Create              func(http.ResponseWriter, *http.Request)
 	Read                func(http.ResponseWriter, *http.Request)
+	Transitions         func(http.ResponseWriter, *http.Request)
+	Activity            func(http.ResponseWriter, *http.Request)
+	Duplicates          func(http.ResponseWriter, *http.Request)
+	RecordDuplicates    func(http.ResponseWriter, *http.Request)
+	Merge               func(http.ResponseWriter, *http.Request)
+	DeleteImpact        func(http.ResponseWriter, *http.Request)
 	Update              func(http.ResponseWriter, *http.Request)
 	BulkDelete          func(http.ResponseWriter, *http.Request)
 	Delete              func(http.ResponseWriter, *http.Request)
+	Undelete            func(http.ResponseWriter, *http.Request)
 	Upload              func(http.ResponseWriter, *http.Request)
 	TriggerScript       func(http.ResponseWriter, *http.Request)
 	TriggerScriptOnList func(http.ResponseWriter, *http.Request)
@@ -87,6 +105,26 @@ func NewRecord(h RecordAPI) *Record {
 				resputil.JSON(w, value)
 			}
 		},
+		Aggregate: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordAggregate()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Record.Aggregate", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Aggregate(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Record.Aggregate", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Record.Aggregate", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
 		List: func(w http.ResponseWriter, r *http.Request) {
 			defer r.Body.Close()
 			params := request.NewRecordList()
@@ -187,6 +225,26 @@ func NewRecord(h RecordAPI) *Record {
 				resputil.JSON(w, value)
 			}
 		},
+		ExportJob: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordExportJob()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Record.ExportJob", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.ExportJob(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Record.ExportJob", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Record.ExportJob", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
 		Exec: func(w http.ResponseWriter, r *http.Request) {
 			defer r.Body.Close()
 			params := request.NewRecordExec()
@@ -247,6 +305,126 @@ func NewRecord(h RecordAPI) *Record {
 				resputil.JSON(w, value)
 			}
 		},
+		Transitions: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordTransitions()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Record.Transitions", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Transitions(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Record.Transitions", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Record.Transitions", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Activity: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordActivity()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Record.Activity", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Activity(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Record.Activity", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Record.Activity", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Duplicates: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordDuplicates()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Record.Duplicates", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Duplicates(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Record.Duplicates", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Record.Duplicates", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		RecordDuplicates: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordRecordDuplicates()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Record.RecordDuplicates", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.RecordDuplicates(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Record.RecordDuplicates", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Record.RecordDuplicates", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Merge: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordMerge()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Record.Merge", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Merge(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Record.Merge", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Record.Merge", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		DeleteImpact: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordDeleteImpact()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Record.DeleteImpact", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.DeleteImpact(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Record.DeleteImpact", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Record.DeleteImpact", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
 		Update: func(w http.ResponseWriter, r *http.Request) {
 			defer r.Body.Close()
 			params := request.NewRecordUpdate()
@@ -307,6 +485,26 @@ func NewRecord(h RecordAPI) *Record {
 				resputil.JSON(w, value)
 			}
 		},
+		Undelete: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordUndelete()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Record.Undelete", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Undelete(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Record.Undelete", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Record.Undelete", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
 		Upload: func(w http.ResponseWriter, r *http.Request) {
 			defer r.Body.Close()
 			params := request.NewRecordUpload()
@@ -374,17 +572,26 @@ func (h Record) MountRoutes(r chi.Router, middlewares ...func(http.Handler) http
 	r.Group(func(r chi.Router) {
 		r.Use(middlewares...)
 		r.Get("/namespace/{namespaceID}/module/{moduleID}/record/report", h.Report)
+		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/aggregate", h.Aggregate)
 		r.Get("/namespace/{namespaceID}/module/{moduleID}/record/", h.List)
 		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/import", h.ImportInit)
 		r.Patch("/namespace/{namespaceID}/module/{moduleID}/record/import/{sessionID}", h.ImportRun)
 		r.Get("/namespace/{namespaceID}/module/{moduleID}/record/import/{sessionID}", h.ImportProgress)
 		r.Get("/namespace/{namespaceID}/module/{moduleID}/record/export{filename}.{ext}", h.Export)
+		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/export{filename}.{ext}", h.ExportJob)
 		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/exec/{procedure}", h.Exec)
 		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/", h.Create)
 		r.Get("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}", h.Read)
+		r.Get("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}/transitions", h.Transitions)
+		r.Get("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}/activity", h.Activity)
+		r.Get("/namespace/{namespaceID}/module/{moduleID}/record/duplicates", h.Duplicates)
+		r.Get("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}/duplicates", h.RecordDuplicates)
+		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}/merge", h.Merge)
+		r.Get("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}/impact", h.DeleteImpact)
 		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}", h.Update)
 		r.Delete("/namespace/{namespaceID}/module/{moduleID}/record/", h.BulkDelete)
 		r.Delete("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}", h.Delete)
+		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}/undelete", h.Undelete)
 		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/attachment", h.Upload)
 		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}/trigger", h.TriggerScript)
 		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/trigger", h.TriggerScriptOnList)
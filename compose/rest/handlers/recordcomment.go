@@ -0,0 +1,139 @@
+package handlers
+
+/*
+	Hello! This file is auto-generated from `docs/src/spec.json`.
+
+	For development:
+	In order to update the generated files, edit this file under the location,
+	add your struct fields, imports, API definitions and whatever you want, and:
+
+	1. run [spec](https://github.com/titpetric/spec) in the same folder,
+	2. run `./_gen.php` in this folder.
+
+	You may edit `recordcomment.go`, `recordcomment.util.go` or `recordcomment_test.go` to
+	implement your API calls, helper functions and tests. The file `recordcomment.go`
+	is only generated the first time, and will not be overwritten if it exists.
+*/
+
+import (
+	"context"
+
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/titpetric/factory/resputil"
+
+	"github.com/cortezaproject/corteza-server/compose/rest/request"
+	"github.com/cortezaproject/corteza-server/pkg/logger"
+)
+
+// Internal API interface
+type RecordCommentAPI interface {
+	List(context.Context, *request.RecordCommentList) (interface{}, error)
+	Create(context.Context, *request.RecordCommentCreate) (interface{}, error)
+	Update(context.Context, *request.RecordCommentUpdate) (interface{}, error)
+	Delete(context.Context, *request.RecordCommentDelete) (interface{}, error)
+}
+
+// HTTP API interface
+type RecordComment struct {
+	List   func(http.ResponseWriter, *http.Request)
+	Create func(http.ResponseWriter, *http.Request)
+	Update func(http.ResponseWriter, *http.Request)
+	Delete func(http.ResponseWriter, *http.Request)
+}
+
+func NewRecordComment(h RecordCommentAPI) *RecordComment {
+	return &RecordComment{
+		List: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordCommentList()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("RecordComment.List", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.List(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("RecordComment.List", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("RecordComment.List", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Create: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordCommentCreate()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("RecordComment.Create", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Create(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("RecordComment.Create", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("RecordComment.Create", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Update: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordCommentUpdate()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("RecordComment.Update", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Update(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("RecordComment.Update", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("RecordComment.Update", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Delete: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewRecordCommentDelete()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("RecordComment.Delete", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Delete(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("RecordComment.Delete", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("RecordComment.Delete", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+	}
+}
+
+func (h RecordComment) MountRoutes(r chi.Router, middlewares ...func(http.Handler) http.Handler) {
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares...)
+		r.Get("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}/comment/", h.List)
+		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}/comment/", h.Create)
+		r.Post("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}/comment/{commentID}", h.Update)
+		r.Delete("/namespace/{namespaceID}/module/{moduleID}/record/{recordID}/comment/{commentID}", h.Delete)
+	})
+}
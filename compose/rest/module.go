@@ -123,10 +123,27 @@ func (ctrl *Module) Update(ctx context.Context, r *request.ModuleUpdate) (interf
 		}
 	)
 
-	mod, err = ctrl.module.With(ctx).Update(mod)
+	if r.ConfirmMigration {
+		mod, err = ctrl.module.With(ctx).UpdateConfirmed(mod)
+	} else {
+		mod, err = ctrl.module.With(ctx).Update(mod)
+	}
+
 	return ctrl.makePayload(ctx, mod, err)
 }
 
+func (ctrl *Module) MigrationPlan(ctx context.Context, r *request.ModuleMigrationPlan) (interface{}, error) {
+	return ctrl.module.With(ctx).PlanMigration(&types.Module{
+		ID:          r.ModuleID,
+		NamespaceID: r.NamespaceID,
+		Fields:      r.Fields,
+	})
+}
+
+func (ctrl *Module) MigrationList(ctx context.Context, r *request.ModuleMigrationList) (interface{}, error) {
+	return ctrl.module.With(ctx).FindMigrations(r.NamespaceID, r.ModuleID)
+}
+
 func (ctrl *Module) Delete(ctx context.Context, r *request.ModuleDelete) (interface{}, error) {
 	_, err := ctrl.module.With(ctx).FindByID(r.NamespaceID, r.ModuleID)
 	if err != nil {
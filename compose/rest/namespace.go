@@ -2,9 +2,13 @@ package rest
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 
 	"github.com/titpetric/factory/resputil"
 
+	"github.com/cortezaproject/corteza-server/compose/decoder"
+	"github.com/cortezaproject/corteza-server/compose/encoder"
 	"github.com/cortezaproject/corteza-server/compose/rest/request"
 	"github.com/cortezaproject/corteza-server/compose/service"
 	"github.com/cortezaproject/corteza-server/compose/service/event"
@@ -31,8 +35,14 @@ type (
 		Set    []*namespacePayload   `json:"set"`
 	}
 
+	namespaceTrashPayload struct {
+		Filter types.RecordTrashFilter `json:"filter"`
+		Set    types.RecordSet         `json:"set"`
+	}
+
 	Namespace struct {
 		namespace service.NamespaceService
+		record    service.RecordService
 		ac        namespaceAccessController
 	}
 
@@ -52,6 +62,7 @@ type (
 func (Namespace) New() *Namespace {
 	return &Namespace{
 		namespace: service.DefaultNamespace,
+		record:    service.DefaultRecord,
 		ac:        service.DefaultAccessControl,
 	}
 }
@@ -135,6 +146,65 @@ func (ctrl *Namespace) TriggerScript(ctx context.Context, r *request.NamespaceTr
 	return ctrl.makePayload(ctx, namespace, err)
 }
 
+func (ctrl Namespace) Clone(ctx context.Context, r *request.NamespaceClone) (interface{}, error) {
+	ns, err := ctrl.namespace.With(ctx).Clone(r.NamespaceID, r.Name, r.Slug, r.WithRecords)
+	return ctrl.makePayload(ctx, ns, err)
+}
+
+func (ctrl Namespace) Template(ctx context.Context, r *request.NamespaceTemplate) (interface{}, error) {
+	tpl, err := ctrl.namespace.With(ctx).Template(r.NamespaceID, r.WithRecords)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Add("Content-Type", "application/zip")
+		w.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=%s.zip", tpl.Namespace.Slug))
+
+		if err := encoder.NamespaceTemplate(w, tpl); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}, nil
+}
+
+func (ctrl Namespace) Install(ctx context.Context, r *request.NamespaceInstall) (interface{}, error) {
+	var (
+		tpl *types.NamespaceTemplate
+	)
+
+	f, err := r.Upload.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	if tpl, err = decoder.NamespaceTemplate(f, r.Upload.Size); err != nil {
+		return nil, err
+	}
+
+	ns, err := ctrl.namespace.With(ctx).Install(tpl, r.Name, r.Slug)
+	return ctrl.makePayload(ctx, ns, err)
+}
+
+func (ctrl Namespace) Trash(ctx context.Context, r *request.NamespaceTrash) (interface{}, error) {
+	f := types.RecordTrashFilter{
+		NamespaceID: r.NamespaceID,
+		ModuleID:    r.ModuleID,
+
+		Sort: r.Sort,
+
+		PageFilter: rh.Paging(r),
+	}
+
+	set, filter, err := ctrl.record.With(ctx).FindDeleted(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return &namespaceTrashPayload{Filter: filter, Set: set}, nil
+}
+
 func (ctrl Namespace) makePayload(ctx context.Context, ns *types.Namespace, err error) (*namespacePayload, error) {
 	if err != nil || ns == nil {
 		return nil, err
@@ -1,12 +1,13 @@
 package rest
 
 import (
+	"bytes"
 	"context"
-	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"github.com/cortezaproject/corteza-server/compose/service/values"
 	"github.com/cortezaproject/corteza-server/pkg/payload"
+	"io/ioutil"
 	"net/http"
 	"path"
 	"strings"
@@ -15,14 +16,13 @@ import (
 
 	"github.com/pkg/errors"
 
-	"github.com/cortezaproject/corteza-server/compose/decoder"
-	"github.com/cortezaproject/corteza-server/compose/encoder"
 	"github.com/cortezaproject/corteza-server/compose/repository"
 	"github.com/cortezaproject/corteza-server/compose/rest/request"
 	"github.com/cortezaproject/corteza-server/compose/service"
 	"github.com/cortezaproject/corteza-server/compose/service/event"
 	"github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/pkg/corredor"
+	"github.com/cortezaproject/corteza-server/pkg/jobs"
 	"github.com/cortezaproject/corteza-server/pkg/mime"
 	"github.com/cortezaproject/corteza-server/pkg/rh"
 )
@@ -35,6 +35,9 @@ type (
 
 		CanUpdateRecord bool `json:"canUpdateRecord"`
 		CanDeleteRecord bool `json:"canDeleteRecord"`
+
+		// Likely duplicates of created or updated record
+		Duplicates types.RecordDuplicateSet `json:"duplicates,omitempty"`
 	}
 
 	recordSetPayload struct {
@@ -72,6 +75,17 @@ func (ctrl *Record) Report(ctx context.Context, r *request.RecordReport) (interf
 	return ctrl.record.With(ctx).Report(r.NamespaceID, r.ModuleID, r.Metrics, r.Dimensions, r.Filter)
 }
 
+func (ctrl *Record) Aggregate(ctx context.Context, r *request.RecordAggregate) (interface{}, error) {
+	return ctrl.record.With(ctx).Aggregate(&types.RecordReport{
+		NamespaceID: r.NamespaceID,
+		ModuleID:    r.ModuleID,
+		Metrics:     r.Metrics,
+		Dimensions:  r.Dimensions,
+		Filter:      r.Filter,
+		Having:      r.Having,
+	})
+}
+
 func (ctrl *Record) List(ctx context.Context, r *request.RecordList) (interface{}, error) {
 	var (
 		m   *types.Module
@@ -126,6 +140,18 @@ func (ctrl *Record) Read(ctx context.Context, r *request.RecordRead) (interface{
 	return ctrl.makePayload(ctx, m, record, err)
 }
 
+func (ctrl *Record) Transitions(ctx context.Context, r *request.RecordTransitions) (interface{}, error) {
+	if _, err := ctrl.module.With(ctx).FindByID(r.NamespaceID, r.ModuleID); err != nil {
+		return nil, err
+	}
+
+	return ctrl.record.With(ctx).FindTransitions(r.NamespaceID, r.ModuleID, r.RecordID)
+}
+
+func (ctrl *Record) Activity(ctx context.Context, r *request.RecordActivity) (interface{}, error) {
+	return ctrl.record.With(ctx).FindActivity(r.NamespaceID, r.ModuleID, r.RecordID)
+}
+
 func (ctrl *Record) Create(ctx context.Context, r *request.RecordCreate) (interface{}, error) {
 	var (
 		m   *types.Module
@@ -146,7 +172,7 @@ func (ctrl *Record) Create(ctx context.Context, r *request.RecordCreate) (interf
 		return ctrl.handleValidationError(rve), nil
 	}
 
-	return ctrl.makePayload(ctx, m, record, err)
+	return ctrl.makeDuplicatesPayload(ctx, m, record, err)
 }
 
 func (ctrl *Record) Update(ctx context.Context, r *request.RecordUpdate) (interface{}, error) {
@@ -170,9 +196,40 @@ func (ctrl *Record) Update(ctx context.Context, r *request.RecordUpdate) (interf
 		return ctrl.handleValidationError(rve), nil
 	}
 
+	return ctrl.makeDuplicatesPayload(ctx, m, record, err)
+}
+
+func (ctrl *Record) Duplicates(ctx context.Context, r *request.RecordDuplicates) (interface{}, error) {
+	return ctrl.record.With(ctx).FindDuplicateClusters(r.NamespaceID, r.ModuleID)
+}
+
+func (ctrl *Record) RecordDuplicates(ctx context.Context, r *request.RecordRecordDuplicates) (interface{}, error) {
+	return ctrl.record.With(ctx).FindDuplicates(r.NamespaceID, r.ModuleID, r.RecordID)
+}
+
+func (ctrl *Record) Merge(ctx context.Context, r *request.RecordMerge) (interface{}, error) {
+	var (
+		m   *types.Module
+		err error
+	)
+
+	if m, err = ctrl.module.With(ctx).FindByID(r.NamespaceID, r.ModuleID); err != nil {
+		return nil, err
+	}
+
+	record, err := ctrl.record.With(ctx).Merge(r.NamespaceID, r.ModuleID, r.RecordID, r.SourceRecordID, r.Fields...)
+
+	if rve, is := err.(*types.RecordValueErrorSet); is && !rve.IsValid() {
+		return ctrl.handleValidationError(rve), nil
+	}
+
 	return ctrl.makePayload(ctx, m, record, err)
 }
 
+func (ctrl *Record) DeleteImpact(ctx context.Context, r *request.RecordDeleteImpact) (interface{}, error) {
+	return ctrl.record.With(ctx).FindDeleteImpact(r.NamespaceID, r.ModuleID, r.RecordID)
+}
+
 func (ctrl *Record) Delete(ctx context.Context, r *request.RecordDelete) (interface{}, error) {
 	return resputil.OK(), ctrl.record.With(ctx).DeleteByID(r.NamespaceID, r.ModuleID, r.RecordID)
 }
@@ -189,6 +246,20 @@ func (ctrl *Record) BulkDelete(ctx context.Context, r *request.RecordBulkDelete)
 	)
 }
 
+func (ctrl *Record) Undelete(ctx context.Context, r *request.RecordUndelete) (interface{}, error) {
+	var (
+		m   *types.Module
+		err error
+	)
+
+	if m, err = ctrl.module.With(ctx).FindByID(r.NamespaceID, r.ModuleID); err != nil {
+		return nil, err
+	}
+
+	record, err := ctrl.record.With(ctx).Undelete(r.NamespaceID, r.ModuleID, r.RecordID)
+	return ctrl.makePayload(ctx, m, record, err)
+}
+
 func (ctrl *Record) Upload(ctx context.Context, r *request.RecordUpload) (interface{}, error) {
 	file, err := r.Upload.Open()
 	if err != nil {
@@ -222,11 +293,19 @@ func (ctrl *Record) ImportInit(ctx context.Context, r *request.RecordImportInit)
 		return nil, err
 	}
 
-	f, err := r.Upload.Open()
+	upload, err := r.Upload.Open()
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
+	defer upload.Close()
+
+	// Upload is kept in the session so that import can run as a job
+	source, err := ioutil.ReadAll(upload)
+	if err != nil {
+		return nil, err
+	}
+
+	f := bytes.NewReader(source)
 
 	_, ext, err := mime.Type(f)
 	if err != nil {
@@ -244,18 +323,10 @@ func (ctrl *Record) ImportInit(ctx context.Context, r *request.RecordImportInit)
 		}
 	}
 
-	// determine decoder
-	switch strings.ToLower(ext) {
-	case "json", "jsonl", "ldjson", "ndjson":
-		recordDecoder = decoder.NewStructuredDecoder(json.NewDecoder(f), f)
-
-	case "csv":
-		recordDecoder = decoder.NewFlatReader(csv.NewReader(f), f)
-
-	default:
-		return nil, service.ErrRecordImportFormatNotSupported
-
+	if recordDecoder, err = service.NewRecordDecoder(ext, f); err != nil {
+		return nil, err
 	}
+
 	entryCount, err = recordDecoder.EntryCount()
 	if err != nil {
 		return nil, err
@@ -267,7 +338,7 @@ func (ctrl *Record) ImportInit(ctx context.Context, r *request.RecordImportInit)
 		hh[h] = ""
 	}
 
-	return ctrl.importSession.SetRecordByID(
+	ses, err := ctrl.importSession.SetRecordByID(
 		ctx,
 		0,
 		r.NamespaceID,
@@ -275,6 +346,13 @@ func (ctrl *Record) ImportInit(ctx context.Context, r *request.RecordImportInit)
 		hh,
 		&service.RecordImportProgress{EntryCount: entryCount},
 		recordDecoder)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ses.Source, ses.Format = source, strings.ToLower(ext)
+	return ses, nil
 }
 
 func (ctrl *Record) ImportRun(ctx context.Context, r *request.RecordImportRun) (interface{}, error) {
@@ -305,8 +383,27 @@ func (ctrl *Record) ImportRun(ctx context.Context, r *request.RecordImportRun) (
 
 	ses.OnError = r.OnError
 
-	// @todo routine
-	ctrl.record.With(ctx).Import(ses, ctrl.importSession)
+	if !jobs.Service().Available() {
+		// No job queue, import right away
+		ctrl.record.With(ctx).Import(ses, ctrl.importSession)
+		return ses, nil
+	}
+
+	job, err := jobs.Service().Enqueue(ctx, service.JobService, service.JobRecordImport, service.RecordImportJobParams{
+		NamespaceID: ses.NamespaceID,
+		ModuleID:    ses.ModuleID,
+		Format:      ses.Format,
+		Fields:      ses.Fields,
+		OnError:     ses.OnError,
+	}, ses.Source)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sa := job.CreatedAt
+	ses.JobID, ses.Source, ses.Decoder = job.ID, nil, nil
+	ses.Progress.StartedAt = &sa
 
 	return ses, nil
 }
@@ -318,23 +415,29 @@ func (ctrl *Record) ImportProgress(ctx context.Context, r *request.RecordImportP
 		return nil, err
 	}
 
+	if ses.JobID > 0 {
+		// Import runs as a job; copy job's progress to the session
+		job, err := jobs.Service().FindByID(ctx, ses.JobID)
+		if err != nil {
+			return nil, err
+		}
+
+		ses.Progress.EntryCount = uint64(job.Total)
+		ses.Progress.Completed = uint64(job.Done)
+		ses.Progress.Failed = uint64(job.Failed)
+		ses.Progress.FailReason = job.Error
+		ses.Progress.FinishedAt = job.FinishedAt
+	}
+
 	return ses, nil
 }
 
 func (ctrl *Record) Export(ctx context.Context, r *request.RecordExport) (interface{}, error) {
-	type (
-		// ad-hoc interface for our encoder
-		Encoder interface {
-			service.Encoder
-			Flush()
-		}
-	)
-
 	var (
 		err error
 
 		// Record encoder
-		recordEncoder Encoder
+		recordEncoder service.RecordEncoder
 
 		filename = fmt.Sprintf("; filename=%s.%s", r.Filename, r.Ext)
 
@@ -356,26 +459,11 @@ func (ctrl *Record) Export(ctx context.Context, r *request.RecordExport) (interf
 	}
 
 	return func(w http.ResponseWriter, req *http.Request) {
-		ff := encoder.MakeFields(r.Fields...)
-
-		if len(ff) == 0 {
+		if len(r.Fields) == 0 {
 			http.Error(w, "no record value fields provided", http.StatusBadRequest)
 		}
 
-		switch strings.ToLower(r.Ext) {
-		case "json", "jsonl", "ldjson", "ndjson":
-			contentType = "application/jsonl"
-			recordEncoder = encoder.NewStructuredEncoder(json.NewEncoder(w), ff...)
-
-		case "csv":
-			contentType = "text/csv"
-			recordEncoder = encoder.NewFlatWriter(csv.NewWriter(w), true, ff...)
-
-		case "xlsx":
-			contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
-			recordEncoder = encoder.NewExcelizeEncoder(w, true, ff...)
-
-		default:
+		if recordEncoder, contentType, err = service.NewRecordEncoder(r.Ext, w, r.Fields...); err != nil {
 			http.Error(w, "unsupported format ("+r.Ext+")", http.StatusBadRequest)
 			return
 		}
@@ -392,6 +480,40 @@ func (ctrl *Record) Export(ctx context.Context, r *request.RecordExport) (interf
 	}, nil
 }
 
+// ExportJob enqueues export of records; exported file can be downloaded when job is finished
+func (ctrl *Record) ExportJob(ctx context.Context, r *request.RecordExportJob) (interface{}, error) {
+	// Access control.
+	if _, err := ctrl.module.With(ctx).FindByID(r.NamespaceID, r.ModuleID); err != nil {
+		return nil, err
+	}
+
+	if len(r.Fields) == 1 {
+		r.Fields = strings.Split(r.Fields[0], ",")
+	}
+
+	if len(r.Fields) == 0 {
+		return nil, errors.New("no record value fields provided")
+	}
+
+	if _, _, err := service.NewRecordEncoder(r.Ext, ioutil.Discard); err != nil {
+		return nil, err
+	}
+
+	filename := r.Filename
+	if filename == "" {
+		filename = "export"
+	}
+
+	return jobs.Service().Enqueue(ctx, service.JobService, service.JobRecordExport, service.RecordExportJobParams{
+		NamespaceID: r.NamespaceID,
+		ModuleID:    r.ModuleID,
+		Filter:      r.Filter,
+		Fields:      r.Fields,
+		Filename:    filename,
+		Ext:         r.Ext,
+	}, nil)
+}
+
 func (ctrl Record) Exec(ctx context.Context, r *request.RecordExec) (interface{}, error) {
 	aa := request.ProcedureArgs(r.Args)
 
@@ -482,6 +604,19 @@ func (ctrl Record) makePayload(ctx context.Context, m *types.Module, r *types.Re
 	}, nil
 }
 
+// makeDuplicatesPayload makes record payload with likely duplicates of the record
+//
+// Duplicates are only a warning, failed lookup does not fail the request
+func (ctrl Record) makeDuplicatesPayload(ctx context.Context, m *types.Module, r *types.Record, err error) (*recordPayload, error) {
+	rp, err := ctrl.makePayload(ctx, m, r, err)
+	if err != nil || rp == nil || m.DuplicateRules() == nil {
+		return rp, err
+	}
+
+	rp.Duplicates, _ = ctrl.record.With(ctx).FindDuplicates(r.NamespaceID, r.ModuleID, r.ID)
+	return rp, nil
+}
+
 func (ctrl Record) makeFilterPayload(ctx context.Context, m *types.Module, rr types.RecordSet, f types.RecordFilter, err error) (*recordSetPayload, error) {
 	if err != nil {
 		return nil, err
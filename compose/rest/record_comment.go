@@ -0,0 +1,77 @@
+package rest
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/titpetric/factory/resputil"
+
+	"github.com/cortezaproject/corteza-server/compose/rest/request"
+	"github.com/cortezaproject/corteza-server/compose/service"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/payload"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+var _ = errors.Wrap
+
+type (
+	recordCommentSetPayload struct {
+		Filter types.RecordCommentFilter `json:"filter"`
+		Set    types.RecordCommentSet    `json:"set"`
+	}
+
+	RecordComment struct {
+		comment service.RecordCommentService
+	}
+)
+
+func (RecordComment) New() *RecordComment {
+	return &RecordComment{
+		comment: service.DefaultRecordComment,
+	}
+}
+
+func (ctrl RecordComment) List(ctx context.Context, r *request.RecordCommentList) (interface{}, error) {
+	f := types.RecordCommentFilter{
+		NamespaceID: r.NamespaceID,
+		ModuleID:    r.ModuleID,
+		RecordID:    r.RecordID,
+
+		Sort: r.Sort,
+
+		PageFilter: rh.Paging(r),
+	}
+
+	set, filter, err := ctrl.comment.With(ctx).Find(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recordCommentSetPayload{Filter: filter, Set: set}, nil
+}
+
+func (ctrl RecordComment) Create(ctx context.Context, r *request.RecordCommentCreate) (interface{}, error) {
+	return ctrl.comment.With(ctx).Create(&types.RecordComment{
+		NamespaceID: r.NamespaceID,
+		ModuleID:    r.ModuleID,
+		RecordID:    r.RecordID,
+		ReplyTo:     r.ReplyTo,
+		Message:     r.Message,
+		Attachments: payload.ParseUInt64s(r.Attachments),
+	})
+}
+
+func (ctrl RecordComment) Update(ctx context.Context, r *request.RecordCommentUpdate) (interface{}, error) {
+	return ctrl.comment.With(ctx).Update(&types.RecordComment{
+		ID:          r.CommentID,
+		NamespaceID: r.NamespaceID,
+		ModuleID:    r.ModuleID,
+		RecordID:    r.RecordID,
+		Message:     r.Message,
+	})
+}
+
+func (ctrl RecordComment) Delete(ctx context.Context, r *request.RecordCommentDelete) (interface{}, error) {
+	return resputil.OK(), ctrl.comment.With(ctx).DeleteByID(r.NamespaceID, r.ModuleID, r.RecordID, r.CommentID)
+}
@@ -0,0 +1,600 @@
+package request
+
+/*
+	Hello! This file is auto-generated from `docs/src/spec.json`.
+
+	For development:
+	In order to update the generated files, edit this file under the location,
+	add your struct fields, imports, API definitions and whatever you want, and:
+
+	1. run [spec](https://github.com/titpetric/spec) in the same folder,
+	2. run `./_gen.php` in this folder.
+
+	You may edit `chartsubscription.go`, `chartsubscription.util.go` or `chartsubscription_test.go` to
+	implement your API calls, helper functions and tests. The file `chartsubscription.go`
+	is only generated the first time, and will not be overwritten if it exists.
+*/
+
+import (
+	"io"
+	"strings"
+
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/pkg/errors"
+
+	"time"
+)
+
+var _ = chi.URLParam
+var _ = multipart.FileHeader{}
+
+// ChartSubscriptionList request parameters
+type ChartSubscriptionList struct {
+	hasLimit bool
+	rawLimit string
+	Limit    uint
+
+	hasOffset bool
+	rawOffset string
+	Offset    uint
+
+	hasPage bool
+	rawPage string
+	Page    uint
+
+	hasPerPage bool
+	rawPerPage string
+	PerPage    uint
+
+	hasSort bool
+	rawSort string
+	Sort    string
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasChartID bool
+	rawChartID string
+	ChartID    uint64 `json:",string"`
+}
+
+// NewChartSubscriptionList request
+func NewChartSubscriptionList() *ChartSubscriptionList {
+	return &ChartSubscriptionList{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r ChartSubscriptionList) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["limit"] = r.Limit
+	out["offset"] = r.Offset
+	out["page"] = r.Page
+	out["perPage"] = r.PerPage
+	out["sort"] = r.Sort
+	out["namespaceID"] = r.NamespaceID
+	out["chartID"] = r.ChartID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *ChartSubscriptionList) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := get["limit"]; ok {
+		r.hasLimit = true
+		r.rawLimit = val
+		r.Limit = parseUint(val)
+	}
+	if val, ok := get["offset"]; ok {
+		r.hasOffset = true
+		r.rawOffset = val
+		r.Offset = parseUint(val)
+	}
+	if val, ok := get["page"]; ok {
+		r.hasPage = true
+		r.rawPage = val
+		r.Page = parseUint(val)
+	}
+	if val, ok := get["perPage"]; ok {
+		r.hasPerPage = true
+		r.rawPerPage = val
+		r.PerPage = parseUint(val)
+	}
+	if val, ok := get["sort"]; ok {
+		r.hasSort = true
+		r.rawSort = val
+		r.Sort = val
+	}
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	r.hasChartID = true
+	r.rawChartID = chi.URLParam(req, "chartID")
+	r.ChartID = parseUInt64(chi.URLParam(req, "chartID"))
+
+	return err
+}
+
+var _ RequestFiller = NewChartSubscriptionList()
+
+// ChartSubscriptionCreate request parameters
+type ChartSubscriptionCreate struct {
+	hasFrequency bool
+	rawFrequency string
+	Frequency    string
+
+	hasFormat bool
+	rawFormat string
+	Format    string
+
+	hasUsers bool
+	rawUsers []string
+	Users    []string
+
+	hasRoles bool
+	rawRoles []string
+	Roles    []string
+
+	hasNextRunAt bool
+	rawNextRunAt string
+	NextRunAt    *time.Time
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasChartID bool
+	rawChartID string
+	ChartID    uint64 `json:",string"`
+}
+
+// NewChartSubscriptionCreate request
+func NewChartSubscriptionCreate() *ChartSubscriptionCreate {
+	return &ChartSubscriptionCreate{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r ChartSubscriptionCreate) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["frequency"] = r.Frequency
+	out["format"] = r.Format
+	out["users"] = r.Users
+	out["roles"] = r.Roles
+	out["nextRunAt"] = r.NextRunAt
+	out["namespaceID"] = r.NamespaceID
+	out["chartID"] = r.ChartID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *ChartSubscriptionCreate) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := post["frequency"]; ok {
+		r.hasFrequency = true
+		r.rawFrequency = val
+		r.Frequency = val
+	}
+	if val, ok := post["format"]; ok {
+		r.hasFormat = true
+		r.rawFormat = val
+		r.Format = val
+	}
+
+	if val, ok := req.Form["users"]; ok {
+		r.hasUsers = true
+		r.rawUsers = val
+		r.Users = parseStrings(val)
+	}
+
+	if val, ok := req.Form["roles"]; ok {
+		r.hasRoles = true
+		r.rawRoles = val
+		r.Roles = parseStrings(val)
+	}
+
+	if val, ok := post["nextRunAt"]; ok {
+		r.hasNextRunAt = true
+		r.rawNextRunAt = val
+
+		if r.NextRunAt, err = parseISODatePtrWithErr(val); err != nil {
+			return err
+		}
+	}
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	r.hasChartID = true
+	r.rawChartID = chi.URLParam(req, "chartID")
+	r.ChartID = parseUInt64(chi.URLParam(req, "chartID"))
+
+	return err
+}
+
+var _ RequestFiller = NewChartSubscriptionCreate()
+
+// ChartSubscriptionDelete request parameters
+type ChartSubscriptionDelete struct {
+	hasSubscriptionID bool
+	rawSubscriptionID string
+	SubscriptionID    uint64 `json:",string"`
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasChartID bool
+	rawChartID string
+	ChartID    uint64 `json:",string"`
+}
+
+// NewChartSubscriptionDelete request
+func NewChartSubscriptionDelete() *ChartSubscriptionDelete {
+	return &ChartSubscriptionDelete{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r ChartSubscriptionDelete) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["subscriptionID"] = r.SubscriptionID
+	out["namespaceID"] = r.NamespaceID
+	out["chartID"] = r.ChartID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *ChartSubscriptionDelete) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasSubscriptionID = true
+	r.rawSubscriptionID = chi.URLParam(req, "subscriptionID")
+	r.SubscriptionID = parseUInt64(chi.URLParam(req, "subscriptionID"))
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	r.hasChartID = true
+	r.rawChartID = chi.URLParam(req, "chartID")
+	r.ChartID = parseUInt64(chi.URLParam(req, "chartID"))
+
+	return err
+}
+
+var _ RequestFiller = NewChartSubscriptionDelete()
+
+// HasLimit returns true if limit was set
+func (r *ChartSubscriptionList) HasLimit() bool {
+	return r.hasLimit
+}
+
+// RawLimit returns raw value of limit parameter
+func (r *ChartSubscriptionList) RawLimit() string {
+	return r.rawLimit
+}
+
+// GetLimit returns casted value of  limit parameter
+func (r *ChartSubscriptionList) GetLimit() uint {
+	return r.Limit
+}
+
+// HasOffset returns true if offset was set
+func (r *ChartSubscriptionList) HasOffset() bool {
+	return r.hasOffset
+}
+
+// RawOffset returns raw value of offset parameter
+func (r *ChartSubscriptionList) RawOffset() string {
+	return r.rawOffset
+}
+
+// GetOffset returns casted value of  offset parameter
+func (r *ChartSubscriptionList) GetOffset() uint {
+	return r.Offset
+}
+
+// HasPage returns true if page was set
+func (r *ChartSubscriptionList) HasPage() bool {
+	return r.hasPage
+}
+
+// RawPage returns raw value of page parameter
+func (r *ChartSubscriptionList) RawPage() string {
+	return r.rawPage
+}
+
+// GetPage returns casted value of  page parameter
+func (r *ChartSubscriptionList) GetPage() uint {
+	return r.Page
+}
+
+// HasPerPage returns true if perPage was set
+func (r *ChartSubscriptionList) HasPerPage() bool {
+	return r.hasPerPage
+}
+
+// RawPerPage returns raw value of perPage parameter
+func (r *ChartSubscriptionList) RawPerPage() string {
+	return r.rawPerPage
+}
+
+// GetPerPage returns casted value of  perPage parameter
+func (r *ChartSubscriptionList) GetPerPage() uint {
+	return r.PerPage
+}
+
+// HasSort returns true if sort was set
+func (r *ChartSubscriptionList) HasSort() bool {
+	return r.hasSort
+}
+
+// RawSort returns raw value of sort parameter
+func (r *ChartSubscriptionList) RawSort() string {
+	return r.rawSort
+}
+
+// GetSort returns casted value of  sort parameter
+func (r *ChartSubscriptionList) GetSort() string {
+	return r.Sort
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *ChartSubscriptionList) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *ChartSubscriptionList) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *ChartSubscriptionList) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasChartID returns true if chartID was set
+func (r *ChartSubscriptionList) HasChartID() bool {
+	return r.hasChartID
+}
+
+// RawChartID returns raw value of chartID parameter
+func (r *ChartSubscriptionList) RawChartID() string {
+	return r.rawChartID
+}
+
+// GetChartID returns casted value of  chartID parameter
+func (r *ChartSubscriptionList) GetChartID() uint64 {
+	return r.ChartID
+}
+
+// HasFrequency returns true if frequency was set
+func (r *ChartSubscriptionCreate) HasFrequency() bool {
+	return r.hasFrequency
+}
+
+// RawFrequency returns raw value of frequency parameter
+func (r *ChartSubscriptionCreate) RawFrequency() string {
+	return r.rawFrequency
+}
+
+// GetFrequency returns casted value of  frequency parameter
+func (r *ChartSubscriptionCreate) GetFrequency() string {
+	return r.Frequency
+}
+
+// HasFormat returns true if format was set
+func (r *ChartSubscriptionCreate) HasFormat() bool {
+	return r.hasFormat
+}
+
+// RawFormat returns raw value of format parameter
+func (r *ChartSubscriptionCreate) RawFormat() string {
+	return r.rawFormat
+}
+
+// GetFormat returns casted value of  format parameter
+func (r *ChartSubscriptionCreate) GetFormat() string {
+	return r.Format
+}
+
+// HasUsers returns true if users was set
+func (r *ChartSubscriptionCreate) HasUsers() bool {
+	return r.hasUsers
+}
+
+// RawUsers returns raw value of users parameter
+func (r *ChartSubscriptionCreate) RawUsers() []string {
+	return r.rawUsers
+}
+
+// GetUsers returns casted value of  users parameter
+func (r *ChartSubscriptionCreate) GetUsers() []string {
+	return r.Users
+}
+
+// HasRoles returns true if roles was set
+func (r *ChartSubscriptionCreate) HasRoles() bool {
+	return r.hasRoles
+}
+
+// RawRoles returns raw value of roles parameter
+func (r *ChartSubscriptionCreate) RawRoles() []string {
+	return r.rawRoles
+}
+
+// GetRoles returns casted value of  roles parameter
+func (r *ChartSubscriptionCreate) GetRoles() []string {
+	return r.Roles
+}
+
+// HasNextRunAt returns true if nextRunAt was set
+func (r *ChartSubscriptionCreate) HasNextRunAt() bool {
+	return r.hasNextRunAt
+}
+
+// RawNextRunAt returns raw value of nextRunAt parameter
+func (r *ChartSubscriptionCreate) RawNextRunAt() string {
+	return r.rawNextRunAt
+}
+
+// GetNextRunAt returns casted value of  nextRunAt parameter
+func (r *ChartSubscriptionCreate) GetNextRunAt() *time.Time {
+	return r.NextRunAt
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *ChartSubscriptionCreate) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *ChartSubscriptionCreate) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *ChartSubscriptionCreate) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasChartID returns true if chartID was set
+func (r *ChartSubscriptionCreate) HasChartID() bool {
+	return r.hasChartID
+}
+
+// RawChartID returns raw value of chartID parameter
+func (r *ChartSubscriptionCreate) RawChartID() string {
+	return r.rawChartID
+}
+
+// GetChartID returns casted value of  chartID parameter
+func (r *ChartSubscriptionCreate) GetChartID() uint64 {
+	return r.ChartID
+}
+
+// HasSubscriptionID returns true if subscriptionID was set
+func (r *ChartSubscriptionDelete) HasSubscriptionID() bool {
+	return r.hasSubscriptionID
+}
+
+// RawSubscriptionID returns raw value of subscriptionID parameter
+func (r *ChartSubscriptionDelete) RawSubscriptionID() string {
+	return r.rawSubscriptionID
+}
+
+// GetSubscriptionID returns casted value of  subscriptionID parameter
+func (r *ChartSubscriptionDelete) GetSubscriptionID() uint64 {
+	return r.SubscriptionID
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *ChartSubscriptionDelete) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *ChartSubscriptionDelete) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *ChartSubscriptionDelete) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasChartID returns true if chartID was set
+func (r *ChartSubscriptionDelete) HasChartID() bool {
+	return r.hasChartID
+}
+
+// RawChartID returns raw value of chartID parameter
+func (r *ChartSubscriptionDelete) RawChartID() string {
+	return r.rawChartID
+}
+
+// GetChartID returns casted value of  chartID parameter
+func (r *ChartSubscriptionDelete) GetChartID() uint64 {
+	return r.ChartID
+}
@@ -363,6 +363,10 @@ type ModuleUpdate struct {
 	hasUpdatedAt bool
 	rawUpdatedAt string
 	UpdatedAt    *time.Time
+
+	hasConfirmMigration bool
+	rawConfirmMigration string
+	ConfirmMigration    bool
 }
 
 // NewModuleUpdate request
@@ -381,6 +385,7 @@ func (r ModuleUpdate) Auditable() map[string]interface{} {
 	out["fields"] = r.Fields
 	out["meta"] = r.Meta
 	out["updatedAt"] = r.UpdatedAt
+	out["confirmMigration"] = r.ConfirmMigration
 
 	return out
 }
@@ -445,12 +450,154 @@ func (r *ModuleUpdate) Fill(req *http.Request) (err error) {
 			return err
 		}
 	}
+	if val, ok := post["confirmMigration"]; ok {
+		r.hasConfirmMigration = true
+		r.rawConfirmMigration = val
+		r.ConfirmMigration = parseBool(val)
+	}
 
 	return err
 }
 
 var _ RequestFiller = NewModuleUpdate()
 
+// ModuleMigrationPlan request parameters
+type ModuleMigrationPlan struct {
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasFields bool
+	rawFields string
+	Fields    types.ModuleFieldSet
+}
+
+// NewModuleMigrationPlan request
+func NewModuleMigrationPlan() *ModuleMigrationPlan {
+	return &ModuleMigrationPlan{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r ModuleMigrationPlan) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["moduleID"] = r.ModuleID
+	out["namespaceID"] = r.NamespaceID
+	out["fields"] = r.Fields
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *ModuleMigrationPlan) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasModuleID = true
+	r.rawModuleID = chi.URLParam(req, "moduleID")
+	r.ModuleID = parseUInt64(chi.URLParam(req, "moduleID"))
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+
+	return err
+}
+
+var _ RequestFiller = NewModuleMigrationPlan()
+
+// ModuleMigrationList request parameters
+type ModuleMigrationList struct {
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+}
+
+// NewModuleMigrationList request
+func NewModuleMigrationList() *ModuleMigrationList {
+	return &ModuleMigrationList{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r ModuleMigrationList) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["moduleID"] = r.ModuleID
+	out["namespaceID"] = r.NamespaceID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *ModuleMigrationList) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasModuleID = true
+	r.rawModuleID = chi.URLParam(req, "moduleID")
+	r.ModuleID = parseUInt64(chi.URLParam(req, "moduleID"))
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+
+	return err
+}
+
+var _ RequestFiller = NewModuleMigrationList()
+
 // ModuleDelete request parameters
 type ModuleDelete struct {
 	hasModuleID bool
@@ -938,6 +1085,96 @@ func (r *ModuleUpdate) GetUpdatedAt() *time.Time {
 	return r.UpdatedAt
 }
 
+// HasConfirmMigration returns true if confirmMigration was set
+func (r *ModuleUpdate) HasConfirmMigration() bool {
+	return r.hasConfirmMigration
+}
+
+// RawConfirmMigration returns raw value of confirmMigration parameter
+func (r *ModuleUpdate) RawConfirmMigration() string {
+	return r.rawConfirmMigration
+}
+
+// GetConfirmMigration returns casted value of  confirmMigration parameter
+func (r *ModuleUpdate) GetConfirmMigration() bool {
+	return r.ConfirmMigration
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *ModuleMigrationPlan) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *ModuleMigrationPlan) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *ModuleMigrationPlan) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *ModuleMigrationPlan) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *ModuleMigrationPlan) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *ModuleMigrationPlan) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasFields returns true if fields was set
+func (r *ModuleMigrationPlan) HasFields() bool {
+	return r.hasFields
+}
+
+// RawFields returns raw value of fields parameter
+func (r *ModuleMigrationPlan) RawFields() string {
+	return r.rawFields
+}
+
+// GetFields returns casted value of  fields parameter
+func (r *ModuleMigrationPlan) GetFields() types.ModuleFieldSet {
+	return r.Fields
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *ModuleMigrationList) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *ModuleMigrationList) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *ModuleMigrationList) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *ModuleMigrationList) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *ModuleMigrationList) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *ModuleMigrationList) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
 // HasModuleID returns true if moduleID was set
 func (r *ModuleDelete) HasModuleID() bool {
 	return r.hasModuleID
@@ -544,6 +544,361 @@ func (r *NamespaceTriggerScript) Fill(req *http.Request) (err error) {
 
 var _ RequestFiller = NewNamespaceTriggerScript()
 
+// NamespaceClone request parameters
+type NamespaceClone struct {
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasName bool
+	rawName string
+	Name    string
+
+	hasSlug bool
+	rawSlug string
+	Slug    string
+
+	hasWithRecords bool
+	rawWithRecords string
+	WithRecords    bool
+}
+
+// NewNamespaceClone request
+func NewNamespaceClone() *NamespaceClone {
+	return &NamespaceClone{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceClone) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["namespaceID"] = r.NamespaceID
+	out["name"] = r.Name
+	out["slug"] = r.Slug
+	out["withRecords"] = r.WithRecords
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *NamespaceClone) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	if val, ok := post["name"]; ok {
+		r.hasName = true
+		r.rawName = val
+		r.Name = val
+	}
+	if val, ok := post["slug"]; ok {
+		r.hasSlug = true
+		r.rawSlug = val
+		r.Slug = val
+	}
+	if val, ok := post["withRecords"]; ok {
+		r.hasWithRecords = true
+		r.rawWithRecords = val
+		r.WithRecords = parseBool(val)
+	}
+
+	return err
+}
+
+var _ RequestFiller = NewNamespaceClone()
+
+// NamespaceTemplate request parameters
+type NamespaceTemplate struct {
+	hasWithRecords bool
+	rawWithRecords string
+	WithRecords    bool
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+}
+
+// NewNamespaceTemplate request
+func NewNamespaceTemplate() *NamespaceTemplate {
+	return &NamespaceTemplate{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceTemplate) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["withRecords"] = r.WithRecords
+	out["namespaceID"] = r.NamespaceID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *NamespaceTemplate) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := get["withRecords"]; ok {
+		r.hasWithRecords = true
+		r.rawWithRecords = val
+		r.WithRecords = parseBool(val)
+	}
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+
+	return err
+}
+
+var _ RequestFiller = NewNamespaceTemplate()
+
+// NamespaceInstall request parameters
+type NamespaceInstall struct {
+	hasUpload bool
+	rawUpload string
+	Upload    *multipart.FileHeader
+
+	hasName bool
+	rawName string
+	Name    string
+
+	hasSlug bool
+	rawSlug string
+	Slug    string
+}
+
+// NewNamespaceInstall request
+func NewNamespaceInstall() *NamespaceInstall {
+	return &NamespaceInstall{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceInstall) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["upload.size"] = r.Upload.Size
+	out["upload.filename"] = r.Upload.Filename
+
+	out["name"] = r.Name
+	out["slug"] = r.Slug
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *NamespaceInstall) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseMultipartForm(32 << 20); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if _, r.Upload, err = req.FormFile("upload"); err != nil {
+		return errors.Wrap(err, "error processing uploaded file")
+	}
+
+	if val, ok := post["name"]; ok {
+		r.hasName = true
+		r.rawName = val
+		r.Name = val
+	}
+	if val, ok := post["slug"]; ok {
+		r.hasSlug = true
+		r.rawSlug = val
+		r.Slug = val
+	}
+
+	return err
+}
+
+var _ RequestFiller = NewNamespaceInstall()
+
+// NamespaceTrash request parameters
+type NamespaceTrash struct {
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
+
+	hasLimit bool
+	rawLimit string
+	Limit    uint
+
+	hasOffset bool
+	rawOffset string
+	Offset    uint
+
+	hasPage bool
+	rawPage string
+	Page    uint
+
+	hasPerPage bool
+	rawPerPage string
+	PerPage    uint
+
+	hasSort bool
+	rawSort string
+	Sort    string
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+}
+
+// NewNamespaceTrash request
+func NewNamespaceTrash() *NamespaceTrash {
+	return &NamespaceTrash{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r NamespaceTrash) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["moduleID"] = r.ModuleID
+	out["limit"] = r.Limit
+	out["offset"] = r.Offset
+	out["page"] = r.Page
+	out["perPage"] = r.PerPage
+	out["sort"] = r.Sort
+	out["namespaceID"] = r.NamespaceID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *NamespaceTrash) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := get["moduleID"]; ok {
+		r.hasModuleID = true
+		r.rawModuleID = val
+		r.ModuleID = parseUInt64(val)
+	}
+	if val, ok := get["limit"]; ok {
+		r.hasLimit = true
+		r.rawLimit = val
+		r.Limit = parseUint(val)
+	}
+	if val, ok := get["offset"]; ok {
+		r.hasOffset = true
+		r.rawOffset = val
+		r.Offset = parseUint(val)
+	}
+	if val, ok := get["page"]; ok {
+		r.hasPage = true
+		r.rawPage = val
+		r.Page = parseUint(val)
+	}
+	if val, ok := get["perPage"]; ok {
+		r.hasPerPage = true
+		r.rawPerPage = val
+		r.PerPage = parseUint(val)
+	}
+	if val, ok := get["sort"]; ok {
+		r.hasSort = true
+		r.rawSort = val
+		r.Sort = val
+	}
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+
+	return err
+}
+
+var _ RequestFiller = NewNamespaceTrash()
+
 // HasQuery returns true if query was set
 func (r *NamespaceList) HasQuery() bool {
 	return r.hasQuery
@@ -858,3 +1213,243 @@ func (r *NamespaceTriggerScript) RawScript() string {
 func (r *NamespaceTriggerScript) GetScript() string {
 	return r.Script
 }
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *NamespaceClone) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *NamespaceClone) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *NamespaceClone) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasName returns true if name was set
+func (r *NamespaceClone) HasName() bool {
+	return r.hasName
+}
+
+// RawName returns raw value of name parameter
+func (r *NamespaceClone) RawName() string {
+	return r.rawName
+}
+
+// GetName returns casted value of  name parameter
+func (r *NamespaceClone) GetName() string {
+	return r.Name
+}
+
+// HasSlug returns true if slug was set
+func (r *NamespaceClone) HasSlug() bool {
+	return r.hasSlug
+}
+
+// RawSlug returns raw value of slug parameter
+func (r *NamespaceClone) RawSlug() string {
+	return r.rawSlug
+}
+
+// GetSlug returns casted value of  slug parameter
+func (r *NamespaceClone) GetSlug() string {
+	return r.Slug
+}
+
+// HasWithRecords returns true if withRecords was set
+func (r *NamespaceClone) HasWithRecords() bool {
+	return r.hasWithRecords
+}
+
+// RawWithRecords returns raw value of withRecords parameter
+func (r *NamespaceClone) RawWithRecords() string {
+	return r.rawWithRecords
+}
+
+// GetWithRecords returns casted value of  withRecords parameter
+func (r *NamespaceClone) GetWithRecords() bool {
+	return r.WithRecords
+}
+
+// HasWithRecords returns true if withRecords was set
+func (r *NamespaceTemplate) HasWithRecords() bool {
+	return r.hasWithRecords
+}
+
+// RawWithRecords returns raw value of withRecords parameter
+func (r *NamespaceTemplate) RawWithRecords() string {
+	return r.rawWithRecords
+}
+
+// GetWithRecords returns casted value of  withRecords parameter
+func (r *NamespaceTemplate) GetWithRecords() bool {
+	return r.WithRecords
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *NamespaceTemplate) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *NamespaceTemplate) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *NamespaceTemplate) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasUpload returns true if upload was set
+func (r *NamespaceInstall) HasUpload() bool {
+	return r.hasUpload
+}
+
+// RawUpload returns raw value of upload parameter
+func (r *NamespaceInstall) RawUpload() string {
+	return r.rawUpload
+}
+
+// GetUpload returns casted value of  upload parameter
+func (r *NamespaceInstall) GetUpload() *multipart.FileHeader {
+	return r.Upload
+}
+
+// HasName returns true if name was set
+func (r *NamespaceInstall) HasName() bool {
+	return r.hasName
+}
+
+// RawName returns raw value of name parameter
+func (r *NamespaceInstall) RawName() string {
+	return r.rawName
+}
+
+// GetName returns casted value of  name parameter
+func (r *NamespaceInstall) GetName() string {
+	return r.Name
+}
+
+// HasSlug returns true if slug was set
+func (r *NamespaceInstall) HasSlug() bool {
+	return r.hasSlug
+}
+
+// RawSlug returns raw value of slug parameter
+func (r *NamespaceInstall) RawSlug() string {
+	return r.rawSlug
+}
+
+// GetSlug returns casted value of  slug parameter
+func (r *NamespaceInstall) GetSlug() string {
+	return r.Slug
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *NamespaceTrash) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *NamespaceTrash) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *NamespaceTrash) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// HasLimit returns true if limit was set
+func (r *NamespaceTrash) HasLimit() bool {
+	return r.hasLimit
+}
+
+// RawLimit returns raw value of limit parameter
+func (r *NamespaceTrash) RawLimit() string {
+	return r.rawLimit
+}
+
+// GetLimit returns casted value of  limit parameter
+func (r *NamespaceTrash) GetLimit() uint {
+	return r.Limit
+}
+
+// HasOffset returns true if offset was set
+func (r *NamespaceTrash) HasOffset() bool {
+	return r.hasOffset
+}
+
+// RawOffset returns raw value of offset parameter
+func (r *NamespaceTrash) RawOffset() string {
+	return r.rawOffset
+}
+
+// GetOffset returns casted value of  offset parameter
+func (r *NamespaceTrash) GetOffset() uint {
+	return r.Offset
+}
+
+// HasPage returns true if page was set
+func (r *NamespaceTrash) HasPage() bool {
+	return r.hasPage
+}
+
+// RawPage returns raw value of page parameter
+func (r *NamespaceTrash) RawPage() string {
+	return r.rawPage
+}
+
+// GetPage returns casted value of  page parameter
+func (r *NamespaceTrash) GetPage() uint {
+	return r.Page
+}
+
+// HasPerPage returns true if perPage was set
+func (r *NamespaceTrash) HasPerPage() bool {
+	return r.hasPerPage
+}
+
+// RawPerPage returns raw value of perPage parameter
+func (r *NamespaceTrash) RawPerPage() string {
+	return r.rawPerPage
+}
+
+// GetPerPage returns casted value of  perPage parameter
+func (r *NamespaceTrash) GetPerPage() uint {
+	return r.PerPage
+}
+
+// HasSort returns true if sort was set
+func (r *NamespaceTrash) HasSort() bool {
+	return r.hasSort
+}
+
+// RawSort returns raw value of sort parameter
+func (r *NamespaceTrash) RawSort() string {
+	return r.rawSort
+}
+
+// GetSort returns casted value of  sort parameter
+func (r *NamespaceTrash) GetSort() string {
+	return r.Sort
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *NamespaceTrash) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *NamespaceTrash) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *NamespaceTrash) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
@@ -128,6 +128,97 @@ func (r *RecordReport) Fill(req *http.Request) (err error) {
 
 var _ RequestFiller = NewRecordReport()
 
+// RecordAggregate request parameters
+type RecordAggregate struct {
+	hasMetrics bool
+	rawMetrics string
+	Metrics    types.RecordReportMetricSet
+
+	hasDimensions bool
+	rawDimensions string
+	Dimensions    types.RecordReportDimensionSet
+
+	hasFilter bool
+	rawFilter string
+	Filter    string
+
+	hasHaving bool
+	rawHaving string
+	Having    types.RecordReportConditionSet
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
+}
+
+// NewRecordAggregate request
+func NewRecordAggregate() *RecordAggregate {
+	return &RecordAggregate{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordAggregate) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["metrics"] = r.Metrics
+	out["dimensions"] = r.Dimensions
+	out["filter"] = r.Filter
+	out["having"] = r.Having
+	out["namespaceID"] = r.NamespaceID
+	out["moduleID"] = r.ModuleID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordAggregate) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := post["filter"]; ok {
+		r.hasFilter = true
+		r.rawFilter = val
+		r.Filter = val
+	}
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	r.hasModuleID = true
+	r.rawModuleID = chi.URLParam(req, "moduleID")
+	r.ModuleID = parseUInt64(chi.URLParam(req, "moduleID"))
+
+	return err
+}
+
+var _ RequestFiller = NewRecordAggregate()
+
 // RecordList request parameters
 type RecordList struct {
 	hasQuery bool
@@ -627,6 +718,109 @@ func (r *RecordExport) Fill(req *http.Request) (err error) {
 
 var _ RequestFiller = NewRecordExport()
 
+// RecordExportJob request parameters
+type RecordExportJob struct {
+	hasFilename bool
+	rawFilename string
+	Filename    string
+
+	hasExt bool
+	rawExt string
+	Ext    string
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
+
+	hasFilter bool
+	rawFilter string
+	Filter    string
+
+	hasFields bool
+	rawFields []string
+	Fields    []string
+}
+
+// NewRecordExportJob request
+func NewRecordExportJob() *RecordExportJob {
+	return &RecordExportJob{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordExportJob) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["filename"] = r.Filename
+	out["ext"] = r.Ext
+	out["namespaceID"] = r.NamespaceID
+	out["moduleID"] = r.ModuleID
+	out["filter"] = r.Filter
+	out["fields"] = r.Fields
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordExportJob) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasFilename = true
+	r.rawFilename = chi.URLParam(req, "filename")
+	r.Filename = chi.URLParam(req, "filename")
+	r.hasExt = true
+	r.rawExt = chi.URLParam(req, "ext")
+	r.Ext = chi.URLParam(req, "ext")
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	r.hasModuleID = true
+	r.rawModuleID = chi.URLParam(req, "moduleID")
+	r.ModuleID = parseUInt64(chi.URLParam(req, "moduleID"))
+	if val, ok := post["filter"]; ok {
+		r.hasFilter = true
+		r.rawFilter = val
+		r.Filter = val
+	}
+
+	if val, ok := req.Form["fields"]; ok {
+		r.hasFields = true
+		r.rawFields = val
+		r.Fields = parseStrings(val)
+	}
+
+	return err
+}
+
+var _ RequestFiller = NewRecordExportJob()
+
 // RecordExec request parameters
 type RecordExec struct {
 	hasProcedure bool
@@ -851,8 +1045,8 @@ func (r *RecordRead) Fill(req *http.Request) (err error) {
 
 var _ RequestFiller = NewRecordRead()
 
-// RecordUpdate request parameters
-type RecordUpdate struct {
+// RecordTransitions request parameters
+type RecordTransitions struct {
 	hasRecordID bool
 	rawRecordID string
 	RecordID    uint64 `json:",string"`
@@ -864,31 +1058,26 @@ type RecordUpdate struct {
 	hasModuleID bool
 	rawModuleID string
 	ModuleID    uint64 `json:",string"`
-
-	hasValues bool
-	rawValues string
-	Values    types.RecordValueSet
 }
 
-// NewRecordUpdate request
-func NewRecordUpdate() *RecordUpdate {
-	return &RecordUpdate{}
+// NewRecordTransitions request
+func NewRecordTransitions() *RecordTransitions {
+	return &RecordTransitions{}
 }
 
 // Auditable returns all auditable/loggable parameters
-func (r RecordUpdate) Auditable() map[string]interface{} {
+func (r RecordTransitions) Auditable() map[string]interface{} {
 	var out = map[string]interface{}{}
 
 	out["recordID"] = r.RecordID
 	out["namespaceID"] = r.NamespaceID
 	out["moduleID"] = r.ModuleID
-	out["values"] = r.Values
 
 	return out
 }
 
 // Fill processes request and fills internal variables
-func (r *RecordUpdate) Fill(req *http.Request) (err error) {
+func (r *RecordTransitions) Fill(req *http.Request) (err error) {
 	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
 		err = json.NewDecoder(req.Body).Decode(r)
 
@@ -928,17 +1117,13 @@ func (r *RecordUpdate) Fill(req *http.Request) (err error) {
 	return err
 }
 
-var _ RequestFiller = NewRecordUpdate()
-
-// RecordBulkDelete request parameters
-type RecordBulkDelete struct {
-	hasRecordIDs bool
-	rawRecordIDs []string
-	RecordIDs    []string
+var _ RequestFiller = NewRecordTransitions()
 
-	hasTruncate bool
-	rawTruncate string
-	Truncate    bool
+// RecordActivity request parameters
+type RecordActivity struct {
+	hasRecordID bool
+	rawRecordID string
+	RecordID    uint64 `json:",string"`
 
 	hasNamespaceID bool
 	rawNamespaceID string
@@ -949,17 +1134,16 @@ type RecordBulkDelete struct {
 	ModuleID    uint64 `json:",string"`
 }
 
-// NewRecordBulkDelete request
-func NewRecordBulkDelete() *RecordBulkDelete {
-	return &RecordBulkDelete{}
+// NewRecordActivity request
+func NewRecordActivity() *RecordActivity {
+	return &RecordActivity{}
 }
 
 // Auditable returns all auditable/loggable parameters
-func (r RecordBulkDelete) Auditable() map[string]interface{} {
+func (r RecordActivity) Auditable() map[string]interface{} {
 	var out = map[string]interface{}{}
 
-	out["recordIDs"] = r.RecordIDs
-	out["truncate"] = r.Truncate
+	out["recordID"] = r.RecordID
 	out["namespaceID"] = r.NamespaceID
 	out["moduleID"] = r.ModuleID
 
@@ -967,7 +1151,7 @@ func (r RecordBulkDelete) Auditable() map[string]interface{} {
 }
 
 // Fill processes request and fills internal variables
-func (r *RecordBulkDelete) Fill(req *http.Request) (err error) {
+func (r *RecordActivity) Fill(req *http.Request) (err error) {
 	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
 		err = json.NewDecoder(req.Body).Decode(r)
 
@@ -994,17 +1178,9 @@ func (r *RecordBulkDelete) Fill(req *http.Request) (err error) {
 		post[name] = string(param[0])
 	}
 
-	if val, ok := req.Form["recordIDs"]; ok {
-		r.hasRecordIDs = true
-		r.rawRecordIDs = val
-		r.RecordIDs = parseStrings(val)
-	}
-
-	if val, ok := post["truncate"]; ok {
-		r.hasTruncate = true
-		r.rawTruncate = val
-		r.Truncate = parseBool(val)
-	}
+	r.hasRecordID = true
+	r.rawRecordID = chi.URLParam(req, "recordID")
+	r.RecordID = parseUInt64(chi.URLParam(req, "recordID"))
 	r.hasNamespaceID = true
 	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
 	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
@@ -1015,14 +1191,10 @@ func (r *RecordBulkDelete) Fill(req *http.Request) (err error) {
 	return err
 }
 
-var _ RequestFiller = NewRecordBulkDelete()
-
-// RecordDelete request parameters
-type RecordDelete struct {
-	hasRecordID bool
-	rawRecordID string
-	RecordID    uint64 `json:",string"`
+var _ RequestFiller = NewRecordActivity()
 
+// RecordDuplicates request parameters
+type RecordDuplicates struct {
 	hasNamespaceID bool
 	rawNamespaceID string
 	NamespaceID    uint64 `json:",string"`
@@ -1032,16 +1204,15 @@ type RecordDelete struct {
 	ModuleID    uint64 `json:",string"`
 }
 
-// NewRecordDelete request
-func NewRecordDelete() *RecordDelete {
-	return &RecordDelete{}
+// NewRecordDuplicates request
+func NewRecordDuplicates() *RecordDuplicates {
+	return &RecordDuplicates{}
 }
 
 // Auditable returns all auditable/loggable parameters
-func (r RecordDelete) Auditable() map[string]interface{} {
+func (r RecordDuplicates) Auditable() map[string]interface{} {
 	var out = map[string]interface{}{}
 
-	out["recordID"] = r.RecordID
 	out["namespaceID"] = r.NamespaceID
 	out["moduleID"] = r.ModuleID
 
@@ -1049,7 +1220,7 @@ func (r RecordDelete) Auditable() map[string]interface{} {
 }
 
 // Fill processes request and fills internal variables
-func (r *RecordDelete) Fill(req *http.Request) (err error) {
+func (r *RecordDuplicates) Fill(req *http.Request) (err error) {
 	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
 		err = json.NewDecoder(req.Body).Decode(r)
 
@@ -1076,9 +1247,6 @@ func (r *RecordDelete) Fill(req *http.Request) (err error) {
 		post[name] = string(param[0])
 	}
 
-	r.hasRecordID = true
-	r.rawRecordID = chi.URLParam(req, "recordID")
-	r.RecordID = parseUInt64(chi.URLParam(req, "recordID"))
 	r.hasNamespaceID = true
 	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
 	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
@@ -1089,22 +1257,14 @@ func (r *RecordDelete) Fill(req *http.Request) (err error) {
 	return err
 }
 
-var _ RequestFiller = NewRecordDelete()
+var _ RequestFiller = NewRecordDuplicates()
 
-// RecordUpload request parameters
-type RecordUpload struct {
+// RecordRecordDuplicates request parameters
+type RecordRecordDuplicates struct {
 	hasRecordID bool
 	rawRecordID string
 	RecordID    uint64 `json:",string"`
 
-	hasFieldName bool
-	rawFieldName string
-	FieldName    string
-
-	hasUpload bool
-	rawUpload string
-	Upload    *multipart.FileHeader
-
 	hasNamespaceID bool
 	rawNamespaceID string
 	NamespaceID    uint64 `json:",string"`
@@ -1114,20 +1274,16 @@ type RecordUpload struct {
 	ModuleID    uint64 `json:",string"`
 }
 
-// NewRecordUpload request
-func NewRecordUpload() *RecordUpload {
-	return &RecordUpload{}
+// NewRecordRecordDuplicates request
+func NewRecordRecordDuplicates() *RecordRecordDuplicates {
+	return &RecordRecordDuplicates{}
 }
 
 // Auditable returns all auditable/loggable parameters
-func (r RecordUpload) Auditable() map[string]interface{} {
+func (r RecordRecordDuplicates) Auditable() map[string]interface{} {
 	var out = map[string]interface{}{}
 
 	out["recordID"] = r.RecordID
-	out["fieldName"] = r.FieldName
-	out["upload.size"] = r.Upload.Size
-	out["upload.filename"] = r.Upload.Filename
-
 	out["namespaceID"] = r.NamespaceID
 	out["moduleID"] = r.ModuleID
 
@@ -1135,7 +1291,7 @@ func (r RecordUpload) Auditable() map[string]interface{} {
 }
 
 // Fill processes request and fills internal variables
-func (r *RecordUpload) Fill(req *http.Request) (err error) {
+func (r *RecordRecordDuplicates) Fill(req *http.Request) (err error) {
 	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
 		err = json.NewDecoder(req.Body).Decode(r)
 
@@ -1147,7 +1303,7 @@ func (r *RecordUpload) Fill(req *http.Request) (err error) {
 		}
 	}
 
-	if err = req.ParseMultipartForm(32 << 20); err != nil {
+	if err = req.ParseForm(); err != nil {
 		return err
 	}
 
@@ -1162,20 +1318,9 @@ func (r *RecordUpload) Fill(req *http.Request) (err error) {
 		post[name] = string(param[0])
 	}
 
-	if val, ok := post["recordID"]; ok {
-		r.hasRecordID = true
-		r.rawRecordID = val
-		r.RecordID = parseUInt64(val)
-	}
-	if val, ok := post["fieldName"]; ok {
-		r.hasFieldName = true
-		r.rawFieldName = val
-		r.FieldName = val
-	}
-	if _, r.Upload, err = req.FormFile("upload"); err != nil {
-		return errors.Wrap(err, "error processing uploaded file")
-	}
-
+	r.hasRecordID = true
+	r.rawRecordID = chi.URLParam(req, "recordID")
+	r.RecordID = parseUInt64(chi.URLParam(req, "recordID"))
 	r.hasNamespaceID = true
 	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
 	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
@@ -1186,10 +1331,10 @@ func (r *RecordUpload) Fill(req *http.Request) (err error) {
 	return err
 }
 
-var _ RequestFiller = NewRecordUpload()
+var _ RequestFiller = NewRecordRecordDuplicates()
 
-// RecordTriggerScript request parameters
-type RecordTriggerScript struct {
+// RecordMerge request parameters
+type RecordMerge struct {
 	hasRecordID bool
 	rawRecordID string
 	RecordID    uint64 `json:",string"`
@@ -1202,35 +1347,35 @@ type RecordTriggerScript struct {
 	rawModuleID string
 	ModuleID    uint64 `json:",string"`
 
-	hasScript bool
-	rawScript string
-	Script    string
+	hasSourceRecordID bool
+	rawSourceRecordID string
+	SourceRecordID    uint64 `json:",string"`
 
-	hasValues bool
-	rawValues string
-	Values    types.RecordValueSet
+	hasFields bool
+	rawFields []string
+	Fields    []string
 }
 
-// NewRecordTriggerScript request
-func NewRecordTriggerScript() *RecordTriggerScript {
-	return &RecordTriggerScript{}
+// NewRecordMerge request
+func NewRecordMerge() *RecordMerge {
+	return &RecordMerge{}
 }
 
 // Auditable returns all auditable/loggable parameters
-func (r RecordTriggerScript) Auditable() map[string]interface{} {
+func (r RecordMerge) Auditable() map[string]interface{} {
 	var out = map[string]interface{}{}
 
 	out["recordID"] = r.RecordID
 	out["namespaceID"] = r.NamespaceID
 	out["moduleID"] = r.ModuleID
-	out["script"] = r.Script
-	out["values"] = r.Values
+	out["sourceRecordID"] = r.SourceRecordID
+	out["fields"] = r.Fields
 
 	return out
 }
 
 // Fill processes request and fills internal variables
-func (r *RecordTriggerScript) Fill(req *http.Request) (err error) {
+func (r *RecordMerge) Fill(req *http.Request) (err error) {
 	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
 		err = json.NewDecoder(req.Body).Decode(r)
 
@@ -1266,22 +1411,28 @@ func (r *RecordTriggerScript) Fill(req *http.Request) (err error) {
 	r.hasModuleID = true
 	r.rawModuleID = chi.URLParam(req, "moduleID")
 	r.ModuleID = parseUInt64(chi.URLParam(req, "moduleID"))
-	if val, ok := post["script"]; ok {
-		r.hasScript = true
-		r.rawScript = val
-		r.Script = val
+	if val, ok := post["sourceRecordID"]; ok {
+		r.hasSourceRecordID = true
+		r.rawSourceRecordID = val
+		r.SourceRecordID = parseUInt64(val)
+	}
+
+	if val, ok := req.Form["fields"]; ok {
+		r.hasFields = true
+		r.rawFields = val
+		r.Fields = parseStrings(val)
 	}
 
 	return err
 }
 
-var _ RequestFiller = NewRecordTriggerScript()
+var _ RequestFiller = NewRecordMerge()
 
-// RecordTriggerScriptOnList request parameters
-type RecordTriggerScriptOnList struct {
-	hasScript bool
-	rawScript string
-	Script    string
+// RecordDeleteImpact request parameters
+type RecordDeleteImpact struct {
+	hasRecordID bool
+	rawRecordID string
+	RecordID    uint64 `json:",string"`
 
 	hasNamespaceID bool
 	rawNamespaceID string
@@ -1292,16 +1443,16 @@ type RecordTriggerScriptOnList struct {
 	ModuleID    uint64 `json:",string"`
 }
 
-// NewRecordTriggerScriptOnList request
-func NewRecordTriggerScriptOnList() *RecordTriggerScriptOnList {
-	return &RecordTriggerScriptOnList{}
+// NewRecordDeleteImpact request
+func NewRecordDeleteImpact() *RecordDeleteImpact {
+	return &RecordDeleteImpact{}
 }
 
 // Auditable returns all auditable/loggable parameters
-func (r RecordTriggerScriptOnList) Auditable() map[string]interface{} {
+func (r RecordDeleteImpact) Auditable() map[string]interface{} {
 	var out = map[string]interface{}{}
 
-	out["script"] = r.Script
+	out["recordID"] = r.RecordID
 	out["namespaceID"] = r.NamespaceID
 	out["moduleID"] = r.ModuleID
 
@@ -1309,7 +1460,7 @@ func (r RecordTriggerScriptOnList) Auditable() map[string]interface{} {
 }
 
 // Fill processes request and fills internal variables
-func (r *RecordTriggerScriptOnList) Fill(req *http.Request) (err error) {
+func (r *RecordDeleteImpact) Fill(req *http.Request) (err error) {
 	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
 		err = json.NewDecoder(req.Body).Decode(r)
 
@@ -1336,11 +1487,9 @@ func (r *RecordTriggerScriptOnList) Fill(req *http.Request) (err error) {
 		post[name] = string(param[0])
 	}
 
-	if val, ok := post["script"]; ok {
-		r.hasScript = true
-		r.rawScript = val
-		r.Script = val
-	}
+	r.hasRecordID = true
+	r.rawRecordID = chi.URLParam(req, "recordID")
+	r.RecordID = parseUInt64(chi.URLParam(req, "recordID"))
 	r.hasNamespaceID = true
 	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
 	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
@@ -1351,635 +1500,1676 @@ func (r *RecordTriggerScriptOnList) Fill(req *http.Request) (err error) {
 	return err
 }
 
-var _ RequestFiller = NewRecordTriggerScriptOnList()
-
-// HasMetrics returns true if metrics was set
-func (r *RecordReport) HasMetrics() bool {
-	return r.hasMetrics
-}
+var _ RequestFiller = NewRecordDeleteImpact()
 
-// RawMetrics returns raw value of metrics parameter
-func (r *RecordReport) RawMetrics() string {
-	return r.rawMetrics
-}
+// RecordUpdate request parameters
+type RecordUpdate struct {
+	hasRecordID bool
+	rawRecordID string
+	RecordID    uint64 `json:",string"`
 
-// GetMetrics returns casted value of  metrics parameter
-func (r *RecordReport) GetMetrics() string {
-	return r.Metrics
-}
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
 
-// HasDimensions returns true if dimensions was set
-func (r *RecordReport) HasDimensions() bool {
-	return r.hasDimensions
-}
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
 
-// RawDimensions returns raw value of dimensions parameter
-func (r *RecordReport) RawDimensions() string {
-	return r.rawDimensions
+	hasValues bool
+	rawValues string
+	Values    types.RecordValueSet
 }
 
-// GetDimensions returns casted value of  dimensions parameter
-func (r *RecordReport) GetDimensions() string {
-	return r.Dimensions
+// NewRecordUpdate request
+func NewRecordUpdate() *RecordUpdate {
+	return &RecordUpdate{}
 }
 
-// HasFilter returns true if filter was set
-func (r *RecordReport) HasFilter() bool {
-	return r.hasFilter
-}
+// Auditable returns all auditable/loggable parameters
+func (r RecordUpdate) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
 
-// RawFilter returns raw value of filter parameter
-func (r *RecordReport) RawFilter() string {
-	return r.rawFilter
-}
+	out["recordID"] = r.RecordID
+	out["namespaceID"] = r.NamespaceID
+	out["moduleID"] = r.ModuleID
+	out["values"] = r.Values
 
-// GetFilter returns casted value of  filter parameter
-func (r *RecordReport) GetFilter() string {
-	return r.Filter
+	return out
 }
 
-// HasNamespaceID returns true if namespaceID was set
-func (r *RecordReport) HasNamespaceID() bool {
-	return r.hasNamespaceID
-}
+// Fill processes request and fills internal variables
+func (r *RecordUpdate) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
 
-// RawNamespaceID returns raw value of namespaceID parameter
-func (r *RecordReport) RawNamespaceID() string {
-	return r.rawNamespaceID
-}
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
 
-// GetNamespaceID returns casted value of  namespaceID parameter
-func (r *RecordReport) GetNamespaceID() uint64 {
-	return r.NamespaceID
-}
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
 
-// HasModuleID returns true if moduleID was set
-func (r *RecordReport) HasModuleID() bool {
-	return r.hasModuleID
-}
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
 
-// RawModuleID returns raw value of moduleID parameter
-func (r *RecordReport) RawModuleID() string {
-	return r.rawModuleID
-}
+	r.hasRecordID = true
+	r.rawRecordID = chi.URLParam(req, "recordID")
+	r.RecordID = parseUInt64(chi.URLParam(req, "recordID"))
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	r.hasModuleID = true
+	r.rawModuleID = chi.URLParam(req, "moduleID")
+	r.ModuleID = parseUInt64(chi.URLParam(req, "moduleID"))
 
-// GetModuleID returns casted value of  moduleID parameter
-func (r *RecordReport) GetModuleID() uint64 {
-	return r.ModuleID
+	return err
 }
 
-// HasQuery returns true if query was set
-func (r *RecordList) HasQuery() bool {
-	return r.hasQuery
-}
+var _ RequestFiller = NewRecordUpdate()
 
-// RawQuery returns raw value of query parameter
-func (r *RecordList) RawQuery() string {
-	return r.rawQuery
-}
+// RecordBulkDelete request parameters
+type RecordBulkDelete struct {
+	hasRecordIDs bool
+	rawRecordIDs []string
+	RecordIDs    []string
 
-// GetQuery returns casted value of  query parameter
-func (r *RecordList) GetQuery() string {
-	return r.Query
-}
+	hasTruncate bool
+	rawTruncate string
+	Truncate    bool
 
-// HasFilter returns true if filter was set
-func (r *RecordList) HasFilter() bool {
-	return r.hasFilter
-}
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
 
-// RawFilter returns raw value of filter parameter
-func (r *RecordList) RawFilter() string {
-	return r.rawFilter
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
 }
 
-// GetFilter returns casted value of  filter parameter
-func (r *RecordList) GetFilter() string {
-	return r.Filter
+// NewRecordBulkDelete request
+func NewRecordBulkDelete() *RecordBulkDelete {
+	return &RecordBulkDelete{}
 }
 
-// HasDeleted returns true if deleted was set
-func (r *RecordList) HasDeleted() bool {
-	return r.hasDeleted
-}
+// Auditable returns all auditable/loggable parameters
+func (r RecordBulkDelete) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
 
-// RawDeleted returns raw value of deleted parameter
-func (r *RecordList) RawDeleted() string {
-	return r.rawDeleted
-}
+	out["recordIDs"] = r.RecordIDs
+	out["truncate"] = r.Truncate
+	out["namespaceID"] = r.NamespaceID
+	out["moduleID"] = r.ModuleID
 
-// GetDeleted returns casted value of  deleted parameter
-func (r *RecordList) GetDeleted() uint {
-	return r.Deleted
+	return out
 }
 
-// HasLimit returns true if limit was set
-func (r *RecordList) HasLimit() bool {
-	return r.hasLimit
-}
+// Fill processes request and fills internal variables
+func (r *RecordBulkDelete) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
 
-// RawLimit returns raw value of limit parameter
-func (r *RecordList) RawLimit() string {
-	return r.rawLimit
-}
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := req.Form["recordIDs"]; ok {
+		r.hasRecordIDs = true
+		r.rawRecordIDs = val
+		r.RecordIDs = parseStrings(val)
+	}
+
+	if val, ok := post["truncate"]; ok {
+		r.hasTruncate = true
+		r.rawTruncate = val
+		r.Truncate = parseBool(val)
+	}
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	r.hasModuleID = true
+	r.rawModuleID = chi.URLParam(req, "moduleID")
+	r.ModuleID = parseUInt64(chi.URLParam(req, "moduleID"))
+
+	return err
+}
+
+var _ RequestFiller = NewRecordBulkDelete()
+
+// RecordDelete request parameters
+type RecordDelete struct {
+	hasRecordID bool
+	rawRecordID string
+	RecordID    uint64 `json:",string"`
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
+}
+
+// NewRecordDelete request
+func NewRecordDelete() *RecordDelete {
+	return &RecordDelete{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordDelete) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["recordID"] = r.RecordID
+	out["namespaceID"] = r.NamespaceID
+	out["moduleID"] = r.ModuleID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordDelete) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasRecordID = true
+	r.rawRecordID = chi.URLParam(req, "recordID")
+	r.RecordID = parseUInt64(chi.URLParam(req, "recordID"))
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	r.hasModuleID = true
+	r.rawModuleID = chi.URLParam(req, "moduleID")
+	r.ModuleID = parseUInt64(chi.URLParam(req, "moduleID"))
+
+	return err
+}
+
+var _ RequestFiller = NewRecordDelete()
+
+// RecordUndelete request parameters
+type RecordUndelete struct {
+	hasRecordID bool
+	rawRecordID string
+	RecordID    uint64 `json:",string"`
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
+}
+
+// NewRecordUndelete request
+func NewRecordUndelete() *RecordUndelete {
+	return &RecordUndelete{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordUndelete) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["recordID"] = r.RecordID
+	out["namespaceID"] = r.NamespaceID
+	out["moduleID"] = r.ModuleID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordUndelete) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasRecordID = true
+	r.rawRecordID = chi.URLParam(req, "recordID")
+	r.RecordID = parseUInt64(chi.URLParam(req, "recordID"))
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	r.hasModuleID = true
+	r.rawModuleID = chi.URLParam(req, "moduleID")
+	r.ModuleID = parseUInt64(chi.URLParam(req, "moduleID"))
+
+	return err
+}
+
+var _ RequestFiller = NewRecordUndelete()
+
+// RecordUpload request parameters
+type RecordUpload struct {
+	hasRecordID bool
+	rawRecordID string
+	RecordID    uint64 `json:",string"`
+
+	hasFieldName bool
+	rawFieldName string
+	FieldName    string
+
+	hasUpload bool
+	rawUpload string
+	Upload    *multipart.FileHeader
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
+}
+
+// NewRecordUpload request
+func NewRecordUpload() *RecordUpload {
+	return &RecordUpload{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordUpload) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["recordID"] = r.RecordID
+	out["fieldName"] = r.FieldName
+	out["upload.size"] = r.Upload.Size
+	out["upload.filename"] = r.Upload.Filename
+
+	out["namespaceID"] = r.NamespaceID
+	out["moduleID"] = r.ModuleID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordUpload) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseMultipartForm(32 << 20); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := post["recordID"]; ok {
+		r.hasRecordID = true
+		r.rawRecordID = val
+		r.RecordID = parseUInt64(val)
+	}
+	if val, ok := post["fieldName"]; ok {
+		r.hasFieldName = true
+		r.rawFieldName = val
+		r.FieldName = val
+	}
+	if _, r.Upload, err = req.FormFile("upload"); err != nil {
+		return errors.Wrap(err, "error processing uploaded file")
+	}
+
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	r.hasModuleID = true
+	r.rawModuleID = chi.URLParam(req, "moduleID")
+	r.ModuleID = parseUInt64(chi.URLParam(req, "moduleID"))
+
+	return err
+}
+
+var _ RequestFiller = NewRecordUpload()
+
+// RecordTriggerScript request parameters
+type RecordTriggerScript struct {
+	hasRecordID bool
+	rawRecordID string
+	RecordID    uint64 `json:",string"`
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
+
+	hasScript bool
+	rawScript string
+	Script    string
+
+	hasValues bool
+	rawValues string
+	Values    types.RecordValueSet
+}
+
+// NewRecordTriggerScript request
+func NewRecordTriggerScript() *RecordTriggerScript {
+	return &RecordTriggerScript{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordTriggerScript) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["recordID"] = r.RecordID
+	out["namespaceID"] = r.NamespaceID
+	out["moduleID"] = r.ModuleID
+	out["script"] = r.Script
+	out["values"] = r.Values
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordTriggerScript) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasRecordID = true
+	r.rawRecordID = chi.URLParam(req, "recordID")
+	r.RecordID = parseUInt64(chi.URLParam(req, "recordID"))
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	r.hasModuleID = true
+	r.rawModuleID = chi.URLParam(req, "moduleID")
+	r.ModuleID = parseUInt64(chi.URLParam(req, "moduleID"))
+	if val, ok := post["script"]; ok {
+		r.hasScript = true
+		r.rawScript = val
+		r.Script = val
+	}
+
+	return err
+}
+
+var _ RequestFiller = NewRecordTriggerScript()
+
+// RecordTriggerScriptOnList request parameters
+type RecordTriggerScriptOnList struct {
+	hasScript bool
+	rawScript string
+	Script    string
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
+}
+
+// NewRecordTriggerScriptOnList request
+func NewRecordTriggerScriptOnList() *RecordTriggerScriptOnList {
+	return &RecordTriggerScriptOnList{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordTriggerScriptOnList) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["script"] = r.Script
+	out["namespaceID"] = r.NamespaceID
+	out["moduleID"] = r.ModuleID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordTriggerScriptOnList) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := post["script"]; ok {
+		r.hasScript = true
+		r.rawScript = val
+		r.Script = val
+	}
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	r.hasModuleID = true
+	r.rawModuleID = chi.URLParam(req, "moduleID")
+	r.ModuleID = parseUInt64(chi.URLParam(req, "moduleID"))
+
+	return err
+}
+
+var _ RequestFiller = NewRecordTriggerScriptOnList()
+
+// HasMetrics returns true if metrics was set
+func (r *RecordReport) HasMetrics() bool {
+	return r.hasMetrics
+}
+
+// RawMetrics returns raw value of metrics parameter
+func (r *RecordReport) RawMetrics() string {
+	return r.rawMetrics
+}
+
+// GetMetrics returns casted value of  metrics parameter
+func (r *RecordReport) GetMetrics() string {
+	return r.Metrics
+}
+
+// HasDimensions returns true if dimensions was set
+func (r *RecordReport) HasDimensions() bool {
+	return r.hasDimensions
+}
+
+// RawDimensions returns raw value of dimensions parameter
+func (r *RecordReport) RawDimensions() string {
+	return r.rawDimensions
+}
+
+// GetDimensions returns casted value of  dimensions parameter
+func (r *RecordReport) GetDimensions() string {
+	return r.Dimensions
+}
+
+// HasFilter returns true if filter was set
+func (r *RecordReport) HasFilter() bool {
+	return r.hasFilter
+}
+
+// RawFilter returns raw value of filter parameter
+func (r *RecordReport) RawFilter() string {
+	return r.rawFilter
+}
+
+// GetFilter returns casted value of  filter parameter
+func (r *RecordReport) GetFilter() string {
+	return r.Filter
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordReport) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordReport) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordReport) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *RecordReport) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *RecordReport) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *RecordReport) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// HasMetrics returns true if metrics was set
+func (r *RecordAggregate) HasMetrics() bool {
+	return r.hasMetrics
+}
+
+// RawMetrics returns raw value of metrics parameter
+func (r *RecordAggregate) RawMetrics() string {
+	return r.rawMetrics
+}
+
+// GetMetrics returns casted value of  metrics parameter
+func (r *RecordAggregate) GetMetrics() types.RecordReportMetricSet {
+	return r.Metrics
+}
+
+// HasDimensions returns true if dimensions was set
+func (r *RecordAggregate) HasDimensions() bool {
+	return r.hasDimensions
+}
+
+// RawDimensions returns raw value of dimensions parameter
+func (r *RecordAggregate) RawDimensions() string {
+	return r.rawDimensions
+}
+
+// GetDimensions returns casted value of  dimensions parameter
+func (r *RecordAggregate) GetDimensions() types.RecordReportDimensionSet {
+	return r.Dimensions
+}
+
+// HasFilter returns true if filter was set
+func (r *RecordAggregate) HasFilter() bool {
+	return r.hasFilter
+}
+
+// RawFilter returns raw value of filter parameter
+func (r *RecordAggregate) RawFilter() string {
+	return r.rawFilter
+}
+
+// GetFilter returns casted value of  filter parameter
+func (r *RecordAggregate) GetFilter() string {
+	return r.Filter
+}
+
+// HasHaving returns true if having was set
+func (r *RecordAggregate) HasHaving() bool {
+	return r.hasHaving
+}
+
+// RawHaving returns raw value of having parameter
+func (r *RecordAggregate) RawHaving() string {
+	return r.rawHaving
+}
+
+// GetHaving returns casted value of  having parameter
+func (r *RecordAggregate) GetHaving() types.RecordReportConditionSet {
+	return r.Having
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordAggregate) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordAggregate) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordAggregate) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *RecordAggregate) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *RecordAggregate) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *RecordAggregate) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// HasQuery returns true if query was set
+func (r *RecordList) HasQuery() bool {
+	return r.hasQuery
+}
+
+// RawQuery returns raw value of query parameter
+func (r *RecordList) RawQuery() string {
+	return r.rawQuery
+}
+
+// GetQuery returns casted value of  query parameter
+func (r *RecordList) GetQuery() string {
+	return r.Query
+}
+
+// HasFilter returns true if filter was set
+func (r *RecordList) HasFilter() bool {
+	return r.hasFilter
+}
+
+// RawFilter returns raw value of filter parameter
+func (r *RecordList) RawFilter() string {
+	return r.rawFilter
+}
+
+// GetFilter returns casted value of  filter parameter
+func (r *RecordList) GetFilter() string {
+	return r.Filter
+}
+
+// HasDeleted returns true if deleted was set
+func (r *RecordList) HasDeleted() bool {
+	return r.hasDeleted
+}
+
+// RawDeleted returns raw value of deleted parameter
+func (r *RecordList) RawDeleted() string {
+	return r.rawDeleted
+}
+
+// GetDeleted returns casted value of  deleted parameter
+func (r *RecordList) GetDeleted() uint {
+	return r.Deleted
+}
+
+// HasLimit returns true if limit was set
+func (r *RecordList) HasLimit() bool {
+	return r.hasLimit
+}
+
+// RawLimit returns raw value of limit parameter
+func (r *RecordList) RawLimit() string {
+	return r.rawLimit
+}
+
+// GetLimit returns casted value of  limit parameter
+func (r *RecordList) GetLimit() uint {
+	return r.Limit
+}
+
+// HasOffset returns true if offset was set
+func (r *RecordList) HasOffset() bool {
+	return r.hasOffset
+}
+
+// RawOffset returns raw value of offset parameter
+func (r *RecordList) RawOffset() string {
+	return r.rawOffset
+}
+
+// GetOffset returns casted value of  offset parameter
+func (r *RecordList) GetOffset() uint {
+	return r.Offset
+}
+
+// HasPage returns true if page was set
+func (r *RecordList) HasPage() bool {
+	return r.hasPage
+}
+
+// RawPage returns raw value of page parameter
+func (r *RecordList) RawPage() string {
+	return r.rawPage
+}
+
+// GetPage returns casted value of  page parameter
+func (r *RecordList) GetPage() uint {
+	return r.Page
+}
+
+// HasPerPage returns true if perPage was set
+func (r *RecordList) HasPerPage() bool {
+	return r.hasPerPage
+}
+
+// RawPerPage returns raw value of perPage parameter
+func (r *RecordList) RawPerPage() string {
+	return r.rawPerPage
+}
+
+// GetPerPage returns casted value of  perPage parameter
+func (r *RecordList) GetPerPage() uint {
+	return r.PerPage
+}
+
+// HasSort returns true if sort was set
+func (r *RecordList) HasSort() bool {
+	return r.hasSort
+}
+
+// RawSort returns raw value of sort parameter
+func (r *RecordList) RawSort() string {
+	return r.rawSort
+}
+
+// GetSort returns casted value of  sort parameter
+func (r *RecordList) GetSort() string {
+	return r.Sort
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordList) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordList) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordList) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *RecordList) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *RecordList) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *RecordList) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// HasUpload returns true if upload was set
+func (r *RecordImportInit) HasUpload() bool {
+	return r.hasUpload
+}
+
+// RawUpload returns raw value of upload parameter
+func (r *RecordImportInit) RawUpload() string {
+	return r.rawUpload
+}
+
+// GetUpload returns casted value of  upload parameter
+func (r *RecordImportInit) GetUpload() *multipart.FileHeader {
+	return r.Upload
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordImportInit) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordImportInit) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordImportInit) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *RecordImportInit) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *RecordImportInit) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *RecordImportInit) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// HasSessionID returns true if sessionID was set
+func (r *RecordImportRun) HasSessionID() bool {
+	return r.hasSessionID
+}
+
+// RawSessionID returns raw value of sessionID parameter
+func (r *RecordImportRun) RawSessionID() string {
+	return r.rawSessionID
+}
+
+// GetSessionID returns casted value of  sessionID parameter
+func (r *RecordImportRun) GetSessionID() uint64 {
+	return r.SessionID
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordImportRun) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordImportRun) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordImportRun) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *RecordImportRun) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *RecordImportRun) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *RecordImportRun) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// HasFields returns true if fields was set
+func (r *RecordImportRun) HasFields() bool {
+	return r.hasFields
+}
+
+// RawFields returns raw value of fields parameter
+func (r *RecordImportRun) RawFields() string {
+	return r.rawFields
+}
+
+// GetFields returns casted value of  fields parameter
+func (r *RecordImportRun) GetFields() json.RawMessage {
+	return r.Fields
+}
+
+// HasOnError returns true if onError was set
+func (r *RecordImportRun) HasOnError() bool {
+	return r.hasOnError
+}
+
+// RawOnError returns raw value of onError parameter
+func (r *RecordImportRun) RawOnError() string {
+	return r.rawOnError
+}
+
+// GetOnError returns casted value of  onError parameter
+func (r *RecordImportRun) GetOnError() string {
+	return r.OnError
+}
+
+// HasSessionID returns true if sessionID was set
+func (r *RecordImportProgress) HasSessionID() bool {
+	return r.hasSessionID
+}
+
+// RawSessionID returns raw value of sessionID parameter
+func (r *RecordImportProgress) RawSessionID() string {
+	return r.rawSessionID
+}
+
+// GetSessionID returns casted value of  sessionID parameter
+func (r *RecordImportProgress) GetSessionID() uint64 {
+	return r.SessionID
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordImportProgress) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordImportProgress) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordImportProgress) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *RecordImportProgress) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *RecordImportProgress) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *RecordImportProgress) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// HasFilter returns true if filter was set
+func (r *RecordExport) HasFilter() bool {
+	return r.hasFilter
+}
 
-// GetLimit returns casted value of  limit parameter
-func (r *RecordList) GetLimit() uint {
-	return r.Limit
+// RawFilter returns raw value of filter parameter
+func (r *RecordExport) RawFilter() string {
+	return r.rawFilter
 }
 
-// HasOffset returns true if offset was set
-func (r *RecordList) HasOffset() bool {
-	return r.hasOffset
+// GetFilter returns casted value of  filter parameter
+func (r *RecordExport) GetFilter() string {
+	return r.Filter
 }
 
-// RawOffset returns raw value of offset parameter
-func (r *RecordList) RawOffset() string {
-	return r.rawOffset
+// HasFields returns true if fields was set
+func (r *RecordExport) HasFields() bool {
+	return r.hasFields
 }
 
-// GetOffset returns casted value of  offset parameter
-func (r *RecordList) GetOffset() uint {
-	return r.Offset
+// RawFields returns raw value of fields parameter
+func (r *RecordExport) RawFields() []string {
+	return r.rawFields
 }
 
-// HasPage returns true if page was set
-func (r *RecordList) HasPage() bool {
-	return r.hasPage
+// GetFields returns casted value of  fields parameter
+func (r *RecordExport) GetFields() []string {
+	return r.Fields
 }
 
-// RawPage returns raw value of page parameter
-func (r *RecordList) RawPage() string {
-	return r.rawPage
+// HasFilename returns true if filename was set
+func (r *RecordExport) HasFilename() bool {
+	return r.hasFilename
 }
 
-// GetPage returns casted value of  page parameter
-func (r *RecordList) GetPage() uint {
-	return r.Page
+// RawFilename returns raw value of filename parameter
+func (r *RecordExport) RawFilename() string {
+	return r.rawFilename
 }
 
-// HasPerPage returns true if perPage was set
-func (r *RecordList) HasPerPage() bool {
-	return r.hasPerPage
+// GetFilename returns casted value of  filename parameter
+func (r *RecordExport) GetFilename() string {
+	return r.Filename
 }
 
-// RawPerPage returns raw value of perPage parameter
-func (r *RecordList) RawPerPage() string {
-	return r.rawPerPage
+// HasExt returns true if ext was set
+func (r *RecordExport) HasExt() bool {
+	return r.hasExt
 }
 
-// GetPerPage returns casted value of  perPage parameter
-func (r *RecordList) GetPerPage() uint {
-	return r.PerPage
+// RawExt returns raw value of ext parameter
+func (r *RecordExport) RawExt() string {
+	return r.rawExt
 }
 
-// HasSort returns true if sort was set
-func (r *RecordList) HasSort() bool {
-	return r.hasSort
+// GetExt returns casted value of  ext parameter
+func (r *RecordExport) GetExt() string {
+	return r.Ext
 }
 
-// RawSort returns raw value of sort parameter
-func (r *RecordList) RawSort() string {
-	return r.rawSort
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordExport) HasNamespaceID() bool {
+	return r.hasNamespaceID
 }
 
-// GetSort returns casted value of  sort parameter
-func (r *RecordList) GetSort() string {
-	return r.Sort
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordExport) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordExport) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *RecordExport) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *RecordExport) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *RecordExport) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// HasFilename returns true if filename was set
+func (r *RecordExportJob) HasFilename() bool {
+	return r.hasFilename
+}
+
+// RawFilename returns raw value of filename parameter
+func (r *RecordExportJob) RawFilename() string {
+	return r.rawFilename
+}
+
+// GetFilename returns casted value of  filename parameter
+func (r *RecordExportJob) GetFilename() string {
+	return r.Filename
+}
+
+// HasExt returns true if ext was set
+func (r *RecordExportJob) HasExt() bool {
+	return r.hasExt
+}
+
+// RawExt returns raw value of ext parameter
+func (r *RecordExportJob) RawExt() string {
+	return r.rawExt
+}
+
+// GetExt returns casted value of  ext parameter
+func (r *RecordExportJob) GetExt() string {
+	return r.Ext
 }
 
 // HasNamespaceID returns true if namespaceID was set
-func (r *RecordList) HasNamespaceID() bool {
+func (r *RecordExportJob) HasNamespaceID() bool {
 	return r.hasNamespaceID
 }
 
 // RawNamespaceID returns raw value of namespaceID parameter
-func (r *RecordList) RawNamespaceID() string {
+func (r *RecordExportJob) RawNamespaceID() string {
 	return r.rawNamespaceID
 }
 
 // GetNamespaceID returns casted value of  namespaceID parameter
-func (r *RecordList) GetNamespaceID() uint64 {
+func (r *RecordExportJob) GetNamespaceID() uint64 {
 	return r.NamespaceID
 }
 
 // HasModuleID returns true if moduleID was set
-func (r *RecordList) HasModuleID() bool {
+func (r *RecordExportJob) HasModuleID() bool {
 	return r.hasModuleID
 }
 
 // RawModuleID returns raw value of moduleID parameter
-func (r *RecordList) RawModuleID() string {
+func (r *RecordExportJob) RawModuleID() string {
 	return r.rawModuleID
 }
 
 // GetModuleID returns casted value of  moduleID parameter
-func (r *RecordList) GetModuleID() uint64 {
+func (r *RecordExportJob) GetModuleID() uint64 {
 	return r.ModuleID
 }
 
-// HasUpload returns true if upload was set
-func (r *RecordImportInit) HasUpload() bool {
-	return r.hasUpload
+// HasFilter returns true if filter was set
+func (r *RecordExportJob) HasFilter() bool {
+	return r.hasFilter
 }
 
-// RawUpload returns raw value of upload parameter
-func (r *RecordImportInit) RawUpload() string {
-	return r.rawUpload
+// RawFilter returns raw value of filter parameter
+func (r *RecordExportJob) RawFilter() string {
+	return r.rawFilter
 }
 
-// GetUpload returns casted value of  upload parameter
-func (r *RecordImportInit) GetUpload() *multipart.FileHeader {
-	return r.Upload
+// GetFilter returns casted value of  filter parameter
+func (r *RecordExportJob) GetFilter() string {
+	return r.Filter
+}
+
+// HasFields returns true if fields was set
+func (r *RecordExportJob) HasFields() bool {
+	return r.hasFields
+}
+
+// RawFields returns raw value of fields parameter
+func (r *RecordExportJob) RawFields() []string {
+	return r.rawFields
+}
+
+// GetFields returns casted value of  fields parameter
+func (r *RecordExportJob) GetFields() []string {
+	return r.Fields
+}
+
+// HasProcedure returns true if procedure was set
+func (r *RecordExec) HasProcedure() bool {
+	return r.hasProcedure
+}
+
+// RawProcedure returns raw value of procedure parameter
+func (r *RecordExec) RawProcedure() string {
+	return r.rawProcedure
+}
+
+// GetProcedure returns casted value of  procedure parameter
+func (r *RecordExec) GetProcedure() string {
+	return r.Procedure
 }
 
 // HasNamespaceID returns true if namespaceID was set
-func (r *RecordImportInit) HasNamespaceID() bool {
+func (r *RecordExec) HasNamespaceID() bool {
 	return r.hasNamespaceID
 }
 
 // RawNamespaceID returns raw value of namespaceID parameter
-func (r *RecordImportInit) RawNamespaceID() string {
+func (r *RecordExec) RawNamespaceID() string {
 	return r.rawNamespaceID
 }
 
 // GetNamespaceID returns casted value of  namespaceID parameter
-func (r *RecordImportInit) GetNamespaceID() uint64 {
+func (r *RecordExec) GetNamespaceID() uint64 {
 	return r.NamespaceID
 }
 
 // HasModuleID returns true if moduleID was set
-func (r *RecordImportInit) HasModuleID() bool {
+func (r *RecordExec) HasModuleID() bool {
 	return r.hasModuleID
 }
 
 // RawModuleID returns raw value of moduleID parameter
-func (r *RecordImportInit) RawModuleID() string {
+func (r *RecordExec) RawModuleID() string {
 	return r.rawModuleID
 }
 
 // GetModuleID returns casted value of  moduleID parameter
-func (r *RecordImportInit) GetModuleID() uint64 {
+func (r *RecordExec) GetModuleID() uint64 {
 	return r.ModuleID
 }
 
-// HasSessionID returns true if sessionID was set
-func (r *RecordImportRun) HasSessionID() bool {
-	return r.hasSessionID
+// HasArgs returns true if args was set
+func (r *RecordExec) HasArgs() bool {
+	return r.hasArgs
 }
 
-// RawSessionID returns raw value of sessionID parameter
-func (r *RecordImportRun) RawSessionID() string {
-	return r.rawSessionID
+// RawArgs returns raw value of args parameter
+func (r *RecordExec) RawArgs() []string {
+	return r.rawArgs
+}
+
+// GetArgs returns casted value of  args parameter
+func (r *RecordExec) GetArgs() []ProcedureArg {
+	return r.Args
+}
+
+// HasValues returns true if values was set
+func (r *RecordCreate) HasValues() bool {
+	return r.hasValues
+}
+
+// RawValues returns raw value of values parameter
+func (r *RecordCreate) RawValues() string {
+	return r.rawValues
+}
+
+// GetValues returns casted value of  values parameter
+func (r *RecordCreate) GetValues() types.RecordValueSet {
+	return r.Values
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordCreate) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordCreate) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordCreate) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *RecordCreate) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *RecordCreate) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *RecordCreate) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// HasRecordID returns true if recordID was set
+func (r *RecordRead) HasRecordID() bool {
+	return r.hasRecordID
+}
+
+// RawRecordID returns raw value of recordID parameter
+func (r *RecordRead) RawRecordID() string {
+	return r.rawRecordID
 }
 
-// GetSessionID returns casted value of  sessionID parameter
-func (r *RecordImportRun) GetSessionID() uint64 {
-	return r.SessionID
+// GetRecordID returns casted value of  recordID parameter
+func (r *RecordRead) GetRecordID() uint64 {
+	return r.RecordID
 }
 
 // HasNamespaceID returns true if namespaceID was set
-func (r *RecordImportRun) HasNamespaceID() bool {
+func (r *RecordRead) HasNamespaceID() bool {
 	return r.hasNamespaceID
 }
 
 // RawNamespaceID returns raw value of namespaceID parameter
-func (r *RecordImportRun) RawNamespaceID() string {
+func (r *RecordRead) RawNamespaceID() string {
 	return r.rawNamespaceID
 }
 
 // GetNamespaceID returns casted value of  namespaceID parameter
-func (r *RecordImportRun) GetNamespaceID() uint64 {
+func (r *RecordRead) GetNamespaceID() uint64 {
 	return r.NamespaceID
 }
 
 // HasModuleID returns true if moduleID was set
-func (r *RecordImportRun) HasModuleID() bool {
+func (r *RecordRead) HasModuleID() bool {
 	return r.hasModuleID
 }
 
 // RawModuleID returns raw value of moduleID parameter
-func (r *RecordImportRun) RawModuleID() string {
+func (r *RecordRead) RawModuleID() string {
 	return r.rawModuleID
 }
 
 // GetModuleID returns casted value of  moduleID parameter
-func (r *RecordImportRun) GetModuleID() uint64 {
+func (r *RecordRead) GetModuleID() uint64 {
 	return r.ModuleID
 }
 
-// HasFields returns true if fields was set
-func (r *RecordImportRun) HasFields() bool {
-	return r.hasFields
-}
-
-// RawFields returns raw value of fields parameter
-func (r *RecordImportRun) RawFields() string {
-	return r.rawFields
-}
-
-// GetFields returns casted value of  fields parameter
-func (r *RecordImportRun) GetFields() json.RawMessage {
-	return r.Fields
-}
-
-// HasOnError returns true if onError was set
-func (r *RecordImportRun) HasOnError() bool {
-	return r.hasOnError
-}
-
-// RawOnError returns raw value of onError parameter
-func (r *RecordImportRun) RawOnError() string {
-	return r.rawOnError
-}
-
-// GetOnError returns casted value of  onError parameter
-func (r *RecordImportRun) GetOnError() string {
-	return r.OnError
-}
-
-// HasSessionID returns true if sessionID was set
-func (r *RecordImportProgress) HasSessionID() bool {
-	return r.hasSessionID
+// HasRecordID returns true if recordID was set
+func (r *RecordTransitions) HasRecordID() bool {
+	return r.hasRecordID
 }
 
-// RawSessionID returns raw value of sessionID parameter
-func (r *RecordImportProgress) RawSessionID() string {
-	return r.rawSessionID
+// RawRecordID returns raw value of recordID parameter
+func (r *RecordTransitions) RawRecordID() string {
+	return r.rawRecordID
 }
 
-// GetSessionID returns casted value of  sessionID parameter
-func (r *RecordImportProgress) GetSessionID() uint64 {
-	return r.SessionID
+// GetRecordID returns casted value of  recordID parameter
+func (r *RecordTransitions) GetRecordID() uint64 {
+	return r.RecordID
 }
 
 // HasNamespaceID returns true if namespaceID was set
-func (r *RecordImportProgress) HasNamespaceID() bool {
+func (r *RecordTransitions) HasNamespaceID() bool {
 	return r.hasNamespaceID
 }
 
 // RawNamespaceID returns raw value of namespaceID parameter
-func (r *RecordImportProgress) RawNamespaceID() string {
+func (r *RecordTransitions) RawNamespaceID() string {
 	return r.rawNamespaceID
 }
 
 // GetNamespaceID returns casted value of  namespaceID parameter
-func (r *RecordImportProgress) GetNamespaceID() uint64 {
+func (r *RecordTransitions) GetNamespaceID() uint64 {
 	return r.NamespaceID
 }
 
 // HasModuleID returns true if moduleID was set
-func (r *RecordImportProgress) HasModuleID() bool {
+func (r *RecordTransitions) HasModuleID() bool {
 	return r.hasModuleID
 }
 
 // RawModuleID returns raw value of moduleID parameter
-func (r *RecordImportProgress) RawModuleID() string {
+func (r *RecordTransitions) RawModuleID() string {
 	return r.rawModuleID
 }
 
 // GetModuleID returns casted value of  moduleID parameter
-func (r *RecordImportProgress) GetModuleID() uint64 {
+func (r *RecordTransitions) GetModuleID() uint64 {
 	return r.ModuleID
 }
 
-// HasFilter returns true if filter was set
-func (r *RecordExport) HasFilter() bool {
-	return r.hasFilter
-}
-
-// RawFilter returns raw value of filter parameter
-func (r *RecordExport) RawFilter() string {
-	return r.rawFilter
-}
-
-// GetFilter returns casted value of  filter parameter
-func (r *RecordExport) GetFilter() string {
-	return r.Filter
-}
-
-// HasFields returns true if fields was set
-func (r *RecordExport) HasFields() bool {
-	return r.hasFields
+// HasRecordID returns true if recordID was set
+func (r *RecordActivity) HasRecordID() bool {
+	return r.hasRecordID
 }
 
-// RawFields returns raw value of fields parameter
-func (r *RecordExport) RawFields() []string {
-	return r.rawFields
+// RawRecordID returns raw value of recordID parameter
+func (r *RecordActivity) RawRecordID() string {
+	return r.rawRecordID
 }
 
-// GetFields returns casted value of  fields parameter
-func (r *RecordExport) GetFields() []string {
-	return r.Fields
+// GetRecordID returns casted value of  recordID parameter
+func (r *RecordActivity) GetRecordID() uint64 {
+	return r.RecordID
 }
 
-// HasFilename returns true if filename was set
-func (r *RecordExport) HasFilename() bool {
-	return r.hasFilename
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordActivity) HasNamespaceID() bool {
+	return r.hasNamespaceID
 }
 
-// RawFilename returns raw value of filename parameter
-func (r *RecordExport) RawFilename() string {
-	return r.rawFilename
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordActivity) RawNamespaceID() string {
+	return r.rawNamespaceID
 }
 
-// GetFilename returns casted value of  filename parameter
-func (r *RecordExport) GetFilename() string {
-	return r.Filename
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordActivity) GetNamespaceID() uint64 {
+	return r.NamespaceID
 }
 
-// HasExt returns true if ext was set
-func (r *RecordExport) HasExt() bool {
-	return r.hasExt
+// HasModuleID returns true if moduleID was set
+func (r *RecordActivity) HasModuleID() bool {
+	return r.hasModuleID
 }
 
-// RawExt returns raw value of ext parameter
-func (r *RecordExport) RawExt() string {
-	return r.rawExt
+// RawModuleID returns raw value of moduleID parameter
+func (r *RecordActivity) RawModuleID() string {
+	return r.rawModuleID
 }
 
-// GetExt returns casted value of  ext parameter
-func (r *RecordExport) GetExt() string {
-	return r.Ext
+// GetModuleID returns casted value of  moduleID parameter
+func (r *RecordActivity) GetModuleID() uint64 {
+	return r.ModuleID
 }
 
 // HasNamespaceID returns true if namespaceID was set
-func (r *RecordExport) HasNamespaceID() bool {
+func (r *RecordDuplicates) HasNamespaceID() bool {
 	return r.hasNamespaceID
 }
 
 // RawNamespaceID returns raw value of namespaceID parameter
-func (r *RecordExport) RawNamespaceID() string {
+func (r *RecordDuplicates) RawNamespaceID() string {
 	return r.rawNamespaceID
 }
 
 // GetNamespaceID returns casted value of  namespaceID parameter
-func (r *RecordExport) GetNamespaceID() uint64 {
+func (r *RecordDuplicates) GetNamespaceID() uint64 {
 	return r.NamespaceID
 }
 
 // HasModuleID returns true if moduleID was set
-func (r *RecordExport) HasModuleID() bool {
+func (r *RecordDuplicates) HasModuleID() bool {
 	return r.hasModuleID
 }
 
 // RawModuleID returns raw value of moduleID parameter
-func (r *RecordExport) RawModuleID() string {
+func (r *RecordDuplicates) RawModuleID() string {
 	return r.rawModuleID
 }
 
 // GetModuleID returns casted value of  moduleID parameter
-func (r *RecordExport) GetModuleID() uint64 {
+func (r *RecordDuplicates) GetModuleID() uint64 {
 	return r.ModuleID
 }
 
-// HasProcedure returns true if procedure was set
-func (r *RecordExec) HasProcedure() bool {
-	return r.hasProcedure
+// HasRecordID returns true if recordID was set
+func (r *RecordRecordDuplicates) HasRecordID() bool {
+	return r.hasRecordID
 }
 
-// RawProcedure returns raw value of procedure parameter
-func (r *RecordExec) RawProcedure() string {
-	return r.rawProcedure
+// RawRecordID returns raw value of recordID parameter
+func (r *RecordRecordDuplicates) RawRecordID() string {
+	return r.rawRecordID
 }
 
-// GetProcedure returns casted value of  procedure parameter
-func (r *RecordExec) GetProcedure() string {
-	return r.Procedure
+// GetRecordID returns casted value of  recordID parameter
+func (r *RecordRecordDuplicates) GetRecordID() uint64 {
+	return r.RecordID
 }
 
 // HasNamespaceID returns true if namespaceID was set
-func (r *RecordExec) HasNamespaceID() bool {
+func (r *RecordRecordDuplicates) HasNamespaceID() bool {
 	return r.hasNamespaceID
 }
 
 // RawNamespaceID returns raw value of namespaceID parameter
-func (r *RecordExec) RawNamespaceID() string {
+func (r *RecordRecordDuplicates) RawNamespaceID() string {
 	return r.rawNamespaceID
 }
 
 // GetNamespaceID returns casted value of  namespaceID parameter
-func (r *RecordExec) GetNamespaceID() uint64 {
+func (r *RecordRecordDuplicates) GetNamespaceID() uint64 {
 	return r.NamespaceID
 }
 
 // HasModuleID returns true if moduleID was set
-func (r *RecordExec) HasModuleID() bool {
+func (r *RecordRecordDuplicates) HasModuleID() bool {
 	return r.hasModuleID
 }
 
 // RawModuleID returns raw value of moduleID parameter
-func (r *RecordExec) RawModuleID() string {
+func (r *RecordRecordDuplicates) RawModuleID() string {
 	return r.rawModuleID
 }
 
 // GetModuleID returns casted value of  moduleID parameter
-func (r *RecordExec) GetModuleID() uint64 {
+func (r *RecordRecordDuplicates) GetModuleID() uint64 {
 	return r.ModuleID
 }
 
-// HasArgs returns true if args was set
-func (r *RecordExec) HasArgs() bool {
-	return r.hasArgs
-}
-
-// RawArgs returns raw value of args parameter
-func (r *RecordExec) RawArgs() []string {
-	return r.rawArgs
-}
-
-// GetArgs returns casted value of  args parameter
-func (r *RecordExec) GetArgs() []ProcedureArg {
-	return r.Args
-}
-
-// HasValues returns true if values was set
-func (r *RecordCreate) HasValues() bool {
-	return r.hasValues
+// HasRecordID returns true if recordID was set
+func (r *RecordMerge) HasRecordID() bool {
+	return r.hasRecordID
 }
 
-// RawValues returns raw value of values parameter
-func (r *RecordCreate) RawValues() string {
-	return r.rawValues
+// RawRecordID returns raw value of recordID parameter
+func (r *RecordMerge) RawRecordID() string {
+	return r.rawRecordID
 }
 
-// GetValues returns casted value of  values parameter
-func (r *RecordCreate) GetValues() types.RecordValueSet {
-	return r.Values
+// GetRecordID returns casted value of  recordID parameter
+func (r *RecordMerge) GetRecordID() uint64 {
+	return r.RecordID
 }
 
 // HasNamespaceID returns true if namespaceID was set
-func (r *RecordCreate) HasNamespaceID() bool {
+func (r *RecordMerge) HasNamespaceID() bool {
 	return r.hasNamespaceID
 }
 
 // RawNamespaceID returns raw value of namespaceID parameter
-func (r *RecordCreate) RawNamespaceID() string {
+func (r *RecordMerge) RawNamespaceID() string {
 	return r.rawNamespaceID
 }
 
 // GetNamespaceID returns casted value of  namespaceID parameter
-func (r *RecordCreate) GetNamespaceID() uint64 {
+func (r *RecordMerge) GetNamespaceID() uint64 {
 	return r.NamespaceID
 }
 
 // HasModuleID returns true if moduleID was set
-func (r *RecordCreate) HasModuleID() bool {
+func (r *RecordMerge) HasModuleID() bool {
 	return r.hasModuleID
 }
 
 // RawModuleID returns raw value of moduleID parameter
-func (r *RecordCreate) RawModuleID() string {
+func (r *RecordMerge) RawModuleID() string {
 	return r.rawModuleID
 }
 
 // GetModuleID returns casted value of  moduleID parameter
-func (r *RecordCreate) GetModuleID() uint64 {
+func (r *RecordMerge) GetModuleID() uint64 {
 	return r.ModuleID
 }
 
+// HasSourceRecordID returns true if sourceRecordID was set
+func (r *RecordMerge) HasSourceRecordID() bool {
+	return r.hasSourceRecordID
+}
+
+// RawSourceRecordID returns raw value of sourceRecordID parameter
+func (r *RecordMerge) RawSourceRecordID() string {
+	return r.rawSourceRecordID
+}
+
+// GetSourceRecordID returns casted value of  sourceRecordID parameter
+func (r *RecordMerge) GetSourceRecordID() uint64 {
+	return r.SourceRecordID
+}
+
+// HasFields returns true if fields was set
+func (r *RecordMerge) HasFields() bool {
+	return r.hasFields
+}
+
+// RawFields returns raw value of fields parameter
+func (r *RecordMerge) RawFields() []string {
+	return r.rawFields
+}
+
+// GetFields returns casted value of  fields parameter
+func (r *RecordMerge) GetFields() []string {
+	return r.Fields
+}
+
 // HasRecordID returns true if recordID was set
-func (r *RecordRead) HasRecordID() bool {
+func (r *RecordDeleteImpact) HasRecordID() bool {
 	return r.hasRecordID
 }
 
 // RawRecordID returns raw value of recordID parameter
-func (r *RecordRead) RawRecordID() string {
+func (r *RecordDeleteImpact) RawRecordID() string {
 	return r.rawRecordID
 }
 
 // GetRecordID returns casted value of  recordID parameter
-func (r *RecordRead) GetRecordID() uint64 {
+func (r *RecordDeleteImpact) GetRecordID() uint64 {
 	return r.RecordID
 }
 
 // HasNamespaceID returns true if namespaceID was set
-func (r *RecordRead) HasNamespaceID() bool {
+func (r *RecordDeleteImpact) HasNamespaceID() bool {
 	return r.hasNamespaceID
 }
 
 // RawNamespaceID returns raw value of namespaceID parameter
-func (r *RecordRead) RawNamespaceID() string {
+func (r *RecordDeleteImpact) RawNamespaceID() string {
 	return r.rawNamespaceID
 }
 
 // GetNamespaceID returns casted value of  namespaceID parameter
-func (r *RecordRead) GetNamespaceID() uint64 {
+func (r *RecordDeleteImpact) GetNamespaceID() uint64 {
 	return r.NamespaceID
 }
 
 // HasModuleID returns true if moduleID was set
-func (r *RecordRead) HasModuleID() bool {
+func (r *RecordDeleteImpact) HasModuleID() bool {
 	return r.hasModuleID
 }
 
 // RawModuleID returns raw value of moduleID parameter
-func (r *RecordRead) RawModuleID() string {
+func (r *RecordDeleteImpact) RawModuleID() string {
 	return r.rawModuleID
 }
 
 // GetModuleID returns casted value of  moduleID parameter
-func (r *RecordRead) GetModuleID() uint64 {
+func (r *RecordDeleteImpact) GetModuleID() uint64 {
 	return r.ModuleID
 }
 
@@ -2148,6 +3338,51 @@ func (r *RecordDelete) GetModuleID() uint64 {
 	return r.ModuleID
 }
 
+// HasRecordID returns true if recordID was set
+func (r *RecordUndelete) HasRecordID() bool {
+	return r.hasRecordID
+}
+
+// RawRecordID returns raw value of recordID parameter
+func (r *RecordUndelete) RawRecordID() string {
+	return r.rawRecordID
+}
+
+// GetRecordID returns casted value of  recordID parameter
+func (r *RecordUndelete) GetRecordID() uint64 {
+	return r.RecordID
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordUndelete) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordUndelete) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordUndelete) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *RecordUndelete) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *RecordUndelete) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *RecordUndelete) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
 // HasRecordID returns true if recordID was set
 func (r *RecordUpload) HasRecordID() bool {
 	return r.hasRecordID
@@ -0,0 +1,780 @@
+package request
+
+/*
+	Hello! This file is auto-generated from `docs/src/spec.json`.
+
+	For development:
+	In order to update the generated files, edit this file under the location,
+	add your struct fields, imports, API definitions and whatever you want, and:
+
+	1. run [spec](https://github.com/titpetric/spec) in the same folder,
+	2. run `./_gen.php` in this folder.
+
+	You may edit `recordcomment.go`, `recordcomment.util.go` or `recordcomment_test.go` to
+	implement your API calls, helper functions and tests. The file `recordcomment.go`
+	is only generated the first time, and will not be overwritten if it exists.
+*/
+
+import (
+	"io"
+	"strings"
+
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/pkg/errors"
+)
+
+var _ = chi.URLParam
+var _ = multipart.FileHeader{}
+
+// RecordCommentList request parameters
+type RecordCommentList struct {
+	hasLimit bool
+	rawLimit string
+	Limit    uint
+
+	hasOffset bool
+	rawOffset string
+	Offset    uint
+
+	hasPage bool
+	rawPage string
+	Page    uint
+
+	hasPerPage bool
+	rawPerPage string
+	PerPage    uint
+
+	hasSort bool
+	rawSort string
+	Sort    string
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
+
+	hasRecordID bool
+	rawRecordID string
+	RecordID    uint64 `json:",string"`
+}
+
+// NewRecordCommentList request
+func NewRecordCommentList() *RecordCommentList {
+	return &RecordCommentList{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordCommentList) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["limit"] = r.Limit
+	out["offset"] = r.Offset
+	out["page"] = r.Page
+	out["perPage"] = r.PerPage
+	out["sort"] = r.Sort
+	out["namespaceID"] = r.NamespaceID
+	out["moduleID"] = r.ModuleID
+	out["recordID"] = r.RecordID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordCommentList) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := get["limit"]; ok {
+		r.hasLimit = true
+		r.rawLimit = val
+		r.Limit = parseUint(val)
+	}
+	if val, ok := get["offset"]; ok {
+		r.hasOffset = true
+		r.rawOffset = val
+		r.Offset = parseUint(val)
+	}
+	if val, ok := get["page"]; ok {
+		r.hasPage = true
+		r.rawPage = val
+		r.Page = parseUint(val)
+	}
+	if val, ok := get["perPage"]; ok {
+		r.hasPerPage = true
+		r.rawPerPage = val
+		r.PerPage = parseUint(val)
+	}
+	if val, ok := get["sort"]; ok {
+		r.hasSort = true
+		r.rawSort = val
+		r.Sort = val
+	}
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	r.hasModuleID = true
+	r.rawModuleID = chi.URLParam(req, "moduleID")
+	r.ModuleID = parseUInt64(chi.URLParam(req, "moduleID"))
+	r.hasRecordID = true
+	r.rawRecordID = chi.URLParam(req, "recordID")
+	r.RecordID = parseUInt64(chi.URLParam(req, "recordID"))
+
+	return err
+}
+
+var _ RequestFiller = NewRecordCommentList()
+
+// RecordCommentCreate request parameters
+type RecordCommentCreate struct {
+	hasMessage bool
+	rawMessage string
+	Message    string
+
+	hasReplyTo bool
+	rawReplyTo string
+	ReplyTo    uint64 `json:",string"`
+
+	hasAttachments bool
+	rawAttachments []string
+	Attachments    []string
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
+
+	hasRecordID bool
+	rawRecordID string
+	RecordID    uint64 `json:",string"`
+}
+
+// NewRecordCommentCreate request
+func NewRecordCommentCreate() *RecordCommentCreate {
+	return &RecordCommentCreate{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordCommentCreate) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["message"] = r.Message
+	out["replyTo"] = r.ReplyTo
+	out["attachments"] = r.Attachments
+	out["namespaceID"] = r.NamespaceID
+	out["moduleID"] = r.ModuleID
+	out["recordID"] = r.RecordID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordCommentCreate) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := post["message"]; ok {
+		r.hasMessage = true
+		r.rawMessage = val
+		r.Message = val
+	}
+	if val, ok := post["replyTo"]; ok {
+		r.hasReplyTo = true
+		r.rawReplyTo = val
+		r.ReplyTo = parseUInt64(val)
+	}
+
+	if val, ok := req.Form["attachments"]; ok {
+		r.hasAttachments = true
+		r.rawAttachments = val
+		r.Attachments = parseStrings(val)
+	}
+
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	r.hasModuleID = true
+	r.rawModuleID = chi.URLParam(req, "moduleID")
+	r.ModuleID = parseUInt64(chi.URLParam(req, "moduleID"))
+	r.hasRecordID = true
+	r.rawRecordID = chi.URLParam(req, "recordID")
+	r.RecordID = parseUInt64(chi.URLParam(req, "recordID"))
+
+	return err
+}
+
+var _ RequestFiller = NewRecordCommentCreate()
+
+// RecordCommentUpdate request parameters
+type RecordCommentUpdate struct {
+	hasCommentID bool
+	rawCommentID string
+	CommentID    uint64 `json:",string"`
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
+
+	hasRecordID bool
+	rawRecordID string
+	RecordID    uint64 `json:",string"`
+
+	hasMessage bool
+	rawMessage string
+	Message    string
+}
+
+// NewRecordCommentUpdate request
+func NewRecordCommentUpdate() *RecordCommentUpdate {
+	return &RecordCommentUpdate{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordCommentUpdate) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["commentID"] = r.CommentID
+	out["namespaceID"] = r.NamespaceID
+	out["moduleID"] = r.ModuleID
+	out["recordID"] = r.RecordID
+	out["message"] = r.Message
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordCommentUpdate) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasCommentID = true
+	r.rawCommentID = chi.URLParam(req, "commentID")
+	r.CommentID = parseUInt64(chi.URLParam(req, "commentID"))
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	r.hasModuleID = true
+	r.rawModuleID = chi.URLParam(req, "moduleID")
+	r.ModuleID = parseUInt64(chi.URLParam(req, "moduleID"))
+	r.hasRecordID = true
+	r.rawRecordID = chi.URLParam(req, "recordID")
+	r.RecordID = parseUInt64(chi.URLParam(req, "recordID"))
+	if val, ok := post["message"]; ok {
+		r.hasMessage = true
+		r.rawMessage = val
+		r.Message = val
+	}
+
+	return err
+}
+
+var _ RequestFiller = NewRecordCommentUpdate()
+
+// RecordCommentDelete request parameters
+type RecordCommentDelete struct {
+	hasCommentID bool
+	rawCommentID string
+	CommentID    uint64 `json:",string"`
+
+	hasNamespaceID bool
+	rawNamespaceID string
+	NamespaceID    uint64 `json:",string"`
+
+	hasModuleID bool
+	rawModuleID string
+	ModuleID    uint64 `json:",string"`
+
+	hasRecordID bool
+	rawRecordID string
+	RecordID    uint64 `json:",string"`
+}
+
+// NewRecordCommentDelete request
+func NewRecordCommentDelete() *RecordCommentDelete {
+	return &RecordCommentDelete{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r RecordCommentDelete) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["commentID"] = r.CommentID
+	out["namespaceID"] = r.NamespaceID
+	out["moduleID"] = r.ModuleID
+	out["recordID"] = r.RecordID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *RecordCommentDelete) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasCommentID = true
+	r.rawCommentID = chi.URLParam(req, "commentID")
+	r.CommentID = parseUInt64(chi.URLParam(req, "commentID"))
+	r.hasNamespaceID = true
+	r.rawNamespaceID = chi.URLParam(req, "namespaceID")
+	r.NamespaceID = parseUInt64(chi.URLParam(req, "namespaceID"))
+	r.hasModuleID = true
+	r.rawModuleID = chi.URLParam(req, "moduleID")
+	r.ModuleID = parseUInt64(chi.URLParam(req, "moduleID"))
+	r.hasRecordID = true
+	r.rawRecordID = chi.URLParam(req, "recordID")
+	r.RecordID = parseUInt64(chi.URLParam(req, "recordID"))
+
+	return err
+}
+
+var _ RequestFiller = NewRecordCommentDelete()
+
+// HasLimit returns true if limit was set
+func (r *RecordCommentList) HasLimit() bool {
+	return r.hasLimit
+}
+
+// RawLimit returns raw value of limit parameter
+func (r *RecordCommentList) RawLimit() string {
+	return r.rawLimit
+}
+
+// GetLimit returns casted value of  limit parameter
+func (r *RecordCommentList) GetLimit() uint {
+	return r.Limit
+}
+
+// HasOffset returns true if offset was set
+func (r *RecordCommentList) HasOffset() bool {
+	return r.hasOffset
+}
+
+// RawOffset returns raw value of offset parameter
+func (r *RecordCommentList) RawOffset() string {
+	return r.rawOffset
+}
+
+// GetOffset returns casted value of  offset parameter
+func (r *RecordCommentList) GetOffset() uint {
+	return r.Offset
+}
+
+// HasPage returns true if page was set
+func (r *RecordCommentList) HasPage() bool {
+	return r.hasPage
+}
+
+// RawPage returns raw value of page parameter
+func (r *RecordCommentList) RawPage() string {
+	return r.rawPage
+}
+
+// GetPage returns casted value of  page parameter
+func (r *RecordCommentList) GetPage() uint {
+	return r.Page
+}
+
+// HasPerPage returns true if perPage was set
+func (r *RecordCommentList) HasPerPage() bool {
+	return r.hasPerPage
+}
+
+// RawPerPage returns raw value of perPage parameter
+func (r *RecordCommentList) RawPerPage() string {
+	return r.rawPerPage
+}
+
+// GetPerPage returns casted value of  perPage parameter
+func (r *RecordCommentList) GetPerPage() uint {
+	return r.PerPage
+}
+
+// HasSort returns true if sort was set
+func (r *RecordCommentList) HasSort() bool {
+	return r.hasSort
+}
+
+// RawSort returns raw value of sort parameter
+func (r *RecordCommentList) RawSort() string {
+	return r.rawSort
+}
+
+// GetSort returns casted value of  sort parameter
+func (r *RecordCommentList) GetSort() string {
+	return r.Sort
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordCommentList) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordCommentList) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordCommentList) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *RecordCommentList) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *RecordCommentList) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *RecordCommentList) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// HasRecordID returns true if recordID was set
+func (r *RecordCommentList) HasRecordID() bool {
+	return r.hasRecordID
+}
+
+// RawRecordID returns raw value of recordID parameter
+func (r *RecordCommentList) RawRecordID() string {
+	return r.rawRecordID
+}
+
+// GetRecordID returns casted value of  recordID parameter
+func (r *RecordCommentList) GetRecordID() uint64 {
+	return r.RecordID
+}
+
+// HasMessage returns true if message was set
+func (r *RecordCommentCreate) HasMessage() bool {
+	return r.hasMessage
+}
+
+// RawMessage returns raw value of message parameter
+func (r *RecordCommentCreate) RawMessage() string {
+	return r.rawMessage
+}
+
+// GetMessage returns casted value of  message parameter
+func (r *RecordCommentCreate) GetMessage() string {
+	return r.Message
+}
+
+// HasReplyTo returns true if replyTo was set
+func (r *RecordCommentCreate) HasReplyTo() bool {
+	return r.hasReplyTo
+}
+
+// RawReplyTo returns raw value of replyTo parameter
+func (r *RecordCommentCreate) RawReplyTo() string {
+	return r.rawReplyTo
+}
+
+// GetReplyTo returns casted value of  replyTo parameter
+func (r *RecordCommentCreate) GetReplyTo() uint64 {
+	return r.ReplyTo
+}
+
+// HasAttachments returns true if attachments was set
+func (r *RecordCommentCreate) HasAttachments() bool {
+	return r.hasAttachments
+}
+
+// RawAttachments returns raw value of attachments parameter
+func (r *RecordCommentCreate) RawAttachments() []string {
+	return r.rawAttachments
+}
+
+// GetAttachments returns casted value of  attachments parameter
+func (r *RecordCommentCreate) GetAttachments() []string {
+	return r.Attachments
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordCommentCreate) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordCommentCreate) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordCommentCreate) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *RecordCommentCreate) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *RecordCommentCreate) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *RecordCommentCreate) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// HasRecordID returns true if recordID was set
+func (r *RecordCommentCreate) HasRecordID() bool {
+	return r.hasRecordID
+}
+
+// RawRecordID returns raw value of recordID parameter
+func (r *RecordCommentCreate) RawRecordID() string {
+	return r.rawRecordID
+}
+
+// GetRecordID returns casted value of  recordID parameter
+func (r *RecordCommentCreate) GetRecordID() uint64 {
+	return r.RecordID
+}
+
+// HasCommentID returns true if commentID was set
+func (r *RecordCommentUpdate) HasCommentID() bool {
+	return r.hasCommentID
+}
+
+// RawCommentID returns raw value of commentID parameter
+func (r *RecordCommentUpdate) RawCommentID() string {
+	return r.rawCommentID
+}
+
+// GetCommentID returns casted value of  commentID parameter
+func (r *RecordCommentUpdate) GetCommentID() uint64 {
+	return r.CommentID
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordCommentUpdate) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordCommentUpdate) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordCommentUpdate) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *RecordCommentUpdate) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *RecordCommentUpdate) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *RecordCommentUpdate) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// HasRecordID returns true if recordID was set
+func (r *RecordCommentUpdate) HasRecordID() bool {
+	return r.hasRecordID
+}
+
+// RawRecordID returns raw value of recordID parameter
+func (r *RecordCommentUpdate) RawRecordID() string {
+	return r.rawRecordID
+}
+
+// GetRecordID returns casted value of  recordID parameter
+func (r *RecordCommentUpdate) GetRecordID() uint64 {
+	return r.RecordID
+}
+
+// HasMessage returns true if message was set
+func (r *RecordCommentUpdate) HasMessage() bool {
+	return r.hasMessage
+}
+
+// RawMessage returns raw value of message parameter
+func (r *RecordCommentUpdate) RawMessage() string {
+	return r.rawMessage
+}
+
+// GetMessage returns casted value of  message parameter
+func (r *RecordCommentUpdate) GetMessage() string {
+	return r.Message
+}
+
+// HasCommentID returns true if commentID was set
+func (r *RecordCommentDelete) HasCommentID() bool {
+	return r.hasCommentID
+}
+
+// RawCommentID returns raw value of commentID parameter
+func (r *RecordCommentDelete) RawCommentID() string {
+	return r.rawCommentID
+}
+
+// GetCommentID returns casted value of  commentID parameter
+func (r *RecordCommentDelete) GetCommentID() uint64 {
+	return r.CommentID
+}
+
+// HasNamespaceID returns true if namespaceID was set
+func (r *RecordCommentDelete) HasNamespaceID() bool {
+	return r.hasNamespaceID
+}
+
+// RawNamespaceID returns raw value of namespaceID parameter
+func (r *RecordCommentDelete) RawNamespaceID() string {
+	return r.rawNamespaceID
+}
+
+// GetNamespaceID returns casted value of  namespaceID parameter
+func (r *RecordCommentDelete) GetNamespaceID() uint64 {
+	return r.NamespaceID
+}
+
+// HasModuleID returns true if moduleID was set
+func (r *RecordCommentDelete) HasModuleID() bool {
+	return r.hasModuleID
+}
+
+// RawModuleID returns raw value of moduleID parameter
+func (r *RecordCommentDelete) RawModuleID() string {
+	return r.rawModuleID
+}
+
+// GetModuleID returns casted value of  moduleID parameter
+func (r *RecordCommentDelete) GetModuleID() uint64 {
+	return r.ModuleID
+}
+
+// HasRecordID returns true if recordID was set
+func (r *RecordCommentDelete) HasRecordID() bool {
+	return r.hasRecordID
+}
+
+// RawRecordID returns raw value of recordID parameter
+func (r *RecordCommentDelete) RawRecordID() string {
+	return r.rawRecordID
+}
+
+// GetRecordID returns casted value of  recordID parameter
+func (r *RecordCommentDelete) GetRecordID() uint64 {
+	return r.RecordID
+}
@@ -35,7 +35,9 @@ func MountRoutes(r chi.Router) {
 		handlers.NewAutomation(automation).MountRoutes(r)
 		handlers.NewModule(module).MountRoutes(r)
 		handlers.NewRecord(record).MountRoutes(r)
+		handlers.NewRecordComment(RecordComment{}.New()).MountRoutes(r)
 		handlers.NewChart(chart).MountRoutes(r)
+		handlers.NewChartSubscription(ChartSubscription{}.New()).MountRoutes(r)
 		handlers.NewNotification(notification).MountRoutes(r)
 		handlers.NewPermissions(Permissions{}.New()).MountRoutes(r)
 		handlers.NewSettings(Settings{}.New()).MountRoutes(r)
@@ -16,6 +16,7 @@ type (
 		Can(context.Context, permissions.Resource, permissions.Operation, ...permissions.CheckAccessFunc) bool
 		Grant(context.Context, permissions.Whitelist, ...*permissions.Rule) error
 		FindRulesByRoleID(roleID uint64) (rr permissions.RuleSet)
+		FindRulesByResource(resources ...permissions.Resource) (rr permissions.RuleSet)
 		ResourceFilter(context.Context, permissions.Resource, permissions.Operation, permissions.Access) *permissions.ResourceFilter
 	}
 
@@ -127,6 +128,13 @@ func (svc accessControl) CanDeleteRecord(ctx context.Context, r *types.Module) b
 	return svc.can(ctx, r, "record.delete")
 }
 
+// FilterRecordDeletableModules filters records by delete permission on their module
+//
+// Used for listing records that can be restored from trash
+func (svc accessControl) FilterRecordDeletableModules(ctx context.Context) *permissions.ResourceFilter {
+	return svc.permissions.ResourceFilter(ctx, types.ModulePermissionResource, "record.delete", permissions.Deny).Build("r.module_id")
+}
+
 func (svc accessControl) CanManageAutomationTriggersOnModule(ctx context.Context, r *types.Module) bool {
 	return svc.can(ctx, r, "automation-trigger.manage")
 }
@@ -192,6 +200,14 @@ func (svc accessControl) FindRulesByRoleID(ctx context.Context, roleID uint64) (
 	return svc.permissions.FindRulesByRoleID(roleID), nil
 }
 
+func (svc accessControl) FindRulesByResource(ctx context.Context, resources ...permissions.Resource) (permissions.RuleSet, error) {
+	if !svc.CanGrant(ctx) {
+		return nil, ErrNoPermissions
+	}
+
+	return svc.permissions.FindRulesByResource(resources...), nil
+}
+
 func (svc accessControl) Whitelist() permissions.Whitelist {
 	var wl = permissions.Whitelist{}
 
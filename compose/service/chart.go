@@ -7,6 +7,7 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
+	"github.com/cortezaproject/corteza-server/compose/renderer"
 	"github.com/cortezaproject/corteza-server/compose/repository"
 	"github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/pkg/handle"
@@ -20,7 +21,8 @@ type (
 		ctx    context.Context
 		logger *zap.Logger
 
-		ac chartAccessController
+		ac     chartAccessController
+		record RecordService
 
 		chartRepo repository.ChartRepository
 		nsRepo    repository.NamespaceRepository
@@ -46,6 +48,8 @@ type (
 		Create(chart *types.Chart) (*types.Chart, error)
 		Update(chart *types.Chart) (*types.Chart, error)
 		DeleteByID(namespaceID, chartID uint64) error
+
+		Dataset(namespaceID, chartID uint64) (*renderer.Dataset, error)
 	}
 )
 
@@ -53,6 +57,7 @@ func Chart() ChartService {
 	return (&chart{
 		logger: DefaultLogger.Named("chart"),
 		ac:     DefaultAccessControl,
+		record: DefaultRecord,
 	}).With(context.Background())
 }
 
@@ -63,7 +68,8 @@ func (svc chart) With(ctx context.Context) ChartService {
 		ctx:    ctx,
 		logger: svc.logger,
 
-		ac: svc.ac,
+		ac:     svc.ac,
+		record: svc.record.With(ctx),
 
 		chartRepo: repository.Chart(ctx, db),
 		nsRepo:    repository.Namespace(ctx, db),
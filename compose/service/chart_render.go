@@ -0,0 +1,43 @@
+package service
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/cortezaproject/corteza-server/compose/renderer"
+	"github.com/cortezaproject/corteza-server/compose/types"
+)
+
+// Dataset runs chart's report and prepares data for rendering
+//
+// Only the first of the configured reports is used
+func (svc chart) Dataset(namespaceID, chartID uint64) (d *renderer.Dataset, err error) {
+	var (
+		c    *types.Chart
+		r    *types.ChartConfigReport
+		out  interface{}
+		rows []map[string]interface{}
+		ok   bool
+	)
+
+	if c, err = svc.FindByID(namespaceID, chartID); err != nil {
+		return
+	}
+
+	if len(c.Config.Reports) == 0 {
+		return renderer.MakeDataset(c.Name, &types.ChartConfigReport{}, nil), nil
+	}
+
+	r = c.Config.Reports[0]
+
+	// Report checks if user can read module
+	out, err = svc.record.Report(namespaceID, r.ModuleID, r.MetricsQuery(), r.DimensionsQuery(), r.Filter)
+	if err != nil {
+		return
+	}
+
+	if rows, ok = out.([]map[string]interface{}); !ok {
+		return nil, errors.Errorf("unexpected report result type %T", out)
+	}
+
+	return renderer.MakeDataset(c.Name, r, rows), nil
+}
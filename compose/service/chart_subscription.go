@@ -0,0 +1,381 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"time"
+
+	"github.com/titpetric/factory"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	gomail "gopkg.in/mail.v2"
+
+	"github.com/cortezaproject/corteza-server/compose/renderer"
+	"github.com/cortezaproject/corteza-server/compose/repository"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/logger"
+	"github.com/cortezaproject/corteza-server/pkg/mail"
+	"github.com/cortezaproject/corteza-server/pkg/sentry"
+)
+
+type (
+	chartSubscription struct {
+		db     *factory.DB
+		ctx    context.Context
+		logger *zap.Logger
+
+		ac           chartSubscriptionAccessController
+		chart        ChartService
+		notification chartSubscriptionNotifier
+		users        chartSubscriptionUserFinder
+		roles        chartSubscriptionRoleFinder
+
+		subscriptionRepo repository.ChartSubscriptionRepository
+	}
+
+	chartSubscriptionAccessController interface {
+		CanUpdateChart(context.Context, *types.Chart) bool
+	}
+
+	chartSubscriptionUserFinder interface {
+		MakeJWT(context.Context, uint64) (string, error)
+	}
+
+	chartSubscriptionRoleFinder interface {
+		FindMembers(context.Context, ...uint64) ([]uint64, error)
+	}
+
+	chartSubscriptionNotifier interface {
+		AttachEmailRecipients(context.Context, *gomail.Message, string, ...string) error
+		SendEmail(context.Context, *gomail.Message) error
+	}
+
+	ChartSubscriptionService interface {
+		With(ctx context.Context) ChartSubscriptionService
+
+		Find(filter types.ChartSubscriptionFilter) (types.ChartSubscriptionSet, types.ChartSubscriptionFilter, error)
+
+		Create(s *types.ChartSubscription) (*types.ChartSubscription, error)
+		DeleteByID(namespaceID, chartID, subscriptionID uint64) error
+
+		SendDue(now time.Time) (uint, error)
+	}
+)
+
+const (
+	// How often are subscriptions checked
+	chartSubscriptionInterval = time.Minute
+
+	// How many subscriptions are sent at once
+	chartSubscriptionBatchSize = 50
+
+	// How long to wait for system service when resolving creator's roles and role members
+	chartSubscriptionSystemTimeout = 5 * time.Second
+)
+
+func ChartSubscription() ChartSubscriptionService {
+	return (&chartSubscription{
+		logger:       DefaultLogger.Named("chart-subscription"),
+		ac:           DefaultAccessControl,
+		chart:        DefaultChart,
+		notification: DefaultNotification,
+		users:        DefaultSystemUser,
+		roles:        DefaultSystemRole,
+	}).With(context.Background())
+}
+
+func (svc chartSubscription) With(ctx context.Context) ChartSubscriptionService {
+	db := repository.DB(ctx)
+
+	return &chartSubscription{
+		db:     db,
+		ctx:    ctx,
+		logger: svc.logger,
+
+		ac:           svc.ac,
+		chart:        svc.chart.With(ctx),
+		notification: svc.notification,
+		users:        svc.users,
+		roles:        svc.roles,
+
+		subscriptionRepo: repository.ChartSubscription(ctx, db),
+	}
+}
+
+// log() returns zap's logger with requestID from current context and fields.
+func (svc chartSubscription) log(ctx context.Context, fields ...zapcore.Field) *zap.Logger {
+	return logger.AddRequestID(ctx, svc.logger).With(fields...)
+}
+
+// Find lists subscriptions of a chart
+func (svc chartSubscription) Find(filter types.ChartSubscriptionFilter) (set types.ChartSubscriptionSet, f types.ChartSubscriptionFilter, err error) {
+	if _, err = svc.chart.FindByID(filter.NamespaceID, filter.ChartID); err != nil {
+		return
+	}
+
+	return svc.subscriptionRepo.Find(filter)
+}
+
+// Create subscribes users and roles to the chart
+//
+// Reports are rendered with permissions of the subscription creator;
+// only users that can update the chart and run its report can create it.
+// Recipients are referenced by user and role IDs
+func (svc chartSubscription) Create(new *types.ChartSubscription) (s *types.ChartSubscription, err error) {
+	var c *types.Chart
+
+	if c, err = svc.chart.FindByID(new.NamespaceID, new.ChartID); err != nil {
+		return
+	}
+
+	if !svc.ac.CanUpdateChart(svc.ctx, c) {
+		return nil, ErrNoUpdatePermissions.withStack()
+	}
+
+	if _, err = svc.chart.Dataset(new.NamespaceID, new.ChartID); err != nil {
+		return
+	}
+
+	if new.Format == "" {
+		new.Format = renderer.FormatPNG
+	}
+
+	if !new.Frequency.IsValid() || !renderer.IsSupported(new.Format) || new.Recipients.IsEmpty() {
+		return nil, ErrChartSubscriptionInvalid.withStack()
+	}
+
+	for _, ID := range append(append([]string{}, new.Recipients.Users...), new.Recipients.Roles...) {
+		if parsed, err := strconv.ParseUint(ID, 10, 64); err != nil || parsed == 0 {
+			return nil, ErrChartSubscriptionInvalid.withStack()
+		}
+	}
+
+	var now = time.Now()
+	if new.NextRunAt.IsZero() {
+		new.NextRunAt = new.Frequency.Next(now)
+	} else {
+		new.Schedule(now)
+	}
+
+	new.ID = 0
+	new.LastRunAt = nil
+	new.CreatedBy = auth.GetIdentityFromContext(svc.ctx).Identity()
+
+	return svc.subscriptionRepo.Create(new)
+}
+
+// DeleteByID removes subscription
+//
+// Subscription can be removed by its creator or by users that can update the chart
+func (svc chartSubscription) DeleteByID(namespaceID, chartID, subscriptionID uint64) (err error) {
+	var (
+		c *types.Chart
+		s *types.ChartSubscription
+	)
+
+	if c, err = svc.chart.FindByID(namespaceID, chartID); err != nil {
+		return
+	}
+
+	if s, err = svc.subscriptionRepo.FindByID(namespaceID, subscriptionID); err != nil {
+		return
+	}
+
+	if s.ChartID != c.ID {
+		return ErrInvalidID.withStack()
+	}
+
+	if s.CreatedBy != auth.GetIdentityFromContext(svc.ctx).Identity() && !svc.ac.CanUpdateChart(svc.ctx, c) {
+		return ErrNoDeletePermissions.withStack()
+	}
+
+	return svc.subscriptionRepo.DeleteByID(namespaceID, subscriptionID)
+}
+
+// SendDue sends all subscriptions that are due and schedules their next run
+//
+// Subscription is rescheduled (claimed) before it is sent; subscriptions claimed
+// by another node are skipped and broken subscriptions do not block others
+//
+// Returns number of sent subscriptions
+func (svc chartSubscription) SendDue(now time.Time) (count uint, err error) {
+	var (
+		ss      types.ChartSubscriptionSet
+		claimed bool
+	)
+
+	if ss, err = svc.subscriptionRepo.FindDue(now, chartSubscriptionBatchSize); err != nil {
+		return
+	}
+
+	for _, s := range ss {
+		due := s.NextRunAt
+
+		s.LastRunAt = &now
+		s.Schedule(now)
+
+		if claimed, err = svc.subscriptionRepo.Claim(s, due); err != nil {
+			return
+		} else if !claimed {
+			continue
+		}
+
+		if err := svc.send(s); err != nil {
+			svc.log(svc.ctx, zap.Uint64("subscriptionID", s.ID)).Error("could not send chart subscription", zap.Error(err))
+		} else {
+			count++
+		}
+	}
+
+	return
+}
+
+// send renders the chart and emails it with report data to all recipients
+func (svc chartSubscription) send(s *types.ChartSubscription) (err error) {
+	var (
+		d          *renderer.Dataset
+		recipients []string
+
+		img = &bytes.Buffer{}
+		csv = &bytes.Buffer{}
+	)
+
+	if d, err = svc.dataset(s); err != nil {
+		return
+	}
+
+	if err = renderer.Render(img, s.Format, d, renderer.Options{}); err != nil {
+		return
+	}
+
+	if err = renderer.CSV(csv, d); err != nil {
+		return
+	}
+
+	if recipients, err = svc.recipients(s.Recipients); err != nil {
+		return
+	}
+
+	// Each recipient gets its own email so addresses are not disclosed to others;
+	// failed delivery to one of the recipients does not stop delivery to others
+	for _, rcpt := range recipients {
+		if rerr := svc.sendTo(s, d, rcpt, img.Bytes(), csv.Bytes()); rerr != nil {
+			svc.log(svc.ctx, zap.Uint64("subscriptionID", s.ID), zap.String("recipient", rcpt)).
+				Error("could not send chart subscription to recipient", zap.Error(rerr))
+		}
+	}
+
+	return
+}
+
+// sendTo emails rendered chart and report data to one recipient
+func (svc chartSubscription) sendTo(s *types.ChartSubscription, d *renderer.Dataset, rcpt string, img, csv []byte) (err error) {
+	msg := mail.New()
+	if err = svc.notification.AttachEmailRecipients(svc.ctx, msg, "To", rcpt); err != nil {
+		return
+	}
+
+	msg.SetHeader("Subject", fmt.Sprintf("Report: %s", d.Title))
+	msg.SetBody("text/plain", fmt.Sprintf("Attached is %s report for %s.", s.Frequency, d.Title))
+	msg.AddAlternative("text/html", fmt.Sprintf("<p>Attached is %s report for <strong>%s</strong>.</p>", s.Frequency, html.EscapeString(d.Title)))
+	msg.AttachReader("chart."+s.Format, bytes.NewReader(img))
+	msg.AttachReader("data.csv", bytes.NewReader(csv))
+
+	return svc.notification.SendEmail(svc.ctx, msg)
+}
+
+// dataset runs chart's report with identity (and roles) of the subscription creator
+//
+// Creator's roles are resolved by the system service (through issued JWT);
+// recipients get only what creator is (still) allowed to read
+func (svc chartSubscription) dataset(s *types.ChartSubscription) (*renderer.Dataset, error) {
+	if s.CreatedBy == 0 {
+		return nil, ErrNoReadPermissions.withStack()
+	}
+
+	ctx, cancel := context.WithTimeout(svc.ctx, chartSubscriptionSystemTimeout)
+	defer cancel()
+
+	jwt, err := svc.users.MakeJWT(ctx, s.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	creator, err := auth.DefaultJwtHandler.Decode(jwt)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = auth.SetIdentityToContext(svc.ctx, auth.NewIdentity(s.CreatedBy, creator.Roles()...))
+	return svc.chart.With(ctx).Dataset(s.NamespaceID, s.ChartID)
+}
+
+// recipients resolves members of all roles (through system service) and combines them with users
+func (svc chartSubscription) recipients(r types.ChartSubscriptionRecipients) (rr []string, err error) {
+	var (
+		roleIDs = make([]uint64, 0, len(r.Roles))
+		userIDs []uint64
+		seen    = map[string]bool{}
+
+		add = func(rcpt string) {
+			if !seen[rcpt] {
+				seen[rcpt] = true
+				rr = append(rr, rcpt)
+			}
+		}
+	)
+
+	for _, u := range r.Users {
+		add(u)
+	}
+
+	for _, roleID := range r.Roles {
+		if ID, err := strconv.ParseUint(roleID, 10, 64); err == nil {
+			roleIDs = append(roleIDs, ID)
+		}
+	}
+
+	if len(roleIDs) > 0 {
+		ctx, cancel := context.WithTimeout(svc.ctx, chartSubscriptionSystemTimeout)
+		defer cancel()
+
+		if userIDs, err = svc.roles.FindMembers(ctx, roleIDs...); err != nil {
+			return
+		}
+	}
+
+	for _, userID := range userIDs {
+		add(strconv.FormatUint(userID, 10))
+	}
+
+	return
+}
+
+// watchChartSubscriptions periodically sends chart subscriptions that are due
+func watchChartSubscriptions(ctx context.Context, log *zap.Logger) {
+	go func() {
+		defer sentry.Recover()
+
+		var ticker = time.NewTicker(chartSubscriptionInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				count, err := DefaultChartSubscription.With(auth.SetSuperUserContext(ctx)).SendDue(time.Now())
+				if err != nil {
+					log.Error("could not send chart subscriptions", zap.Error(err))
+				} else if count > 0 {
+					log.Info("chart subscriptions sent", zap.Uint("count", count))
+				}
+			}
+		}
+	}()
+
+	log.Debug("chart subscription watcher initialized")
+}
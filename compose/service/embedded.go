@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/corredor"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	// record filter as sent by the embedded script
+	//
+	// namespace and module can be given by ID or handle
+	embeddedRecordFilter struct {
+		Namespace string `json:"namespace"`
+		Module    string `json:"module"`
+		Query     string `json:"query"`
+		Sort      string `json:"sort"`
+
+		rh.PageFilter
+	}
+)
+
+// RegisterEmbeddedFuncs exposes record functions to scripts running in corredor's embedded runtime
+//
+//	ctx.compose.findRecords({ namespace, module, query, sort, limit, offset })
+//	ctx.compose.findRecordByID(namespaceID, recordID)
+//	ctx.compose.createRecord(record)
+//	ctx.compose.updateRecord(record)
+//
+// All functions run with the identity of the script runner
+func RegisterEmbeddedFuncs() {
+	corredor.Service().RegisterEmbeddedFunc(
+		"compose.findRecords",
+		func(ctx context.Context, args []json.RawMessage) (interface{}, error) {
+			var (
+				ef = embeddedRecordFilter{}
+				rf = types.RecordFilter{}
+			)
+
+			if len(args) == 0 {
+				return nil, errors.New("record filter not provided")
+			} else if err := json.Unmarshal(args[0], &ef); err != nil {
+				return nil, err
+			}
+
+			rf.Query, rf.Sort, rf.PageFilter = ef.Query, ef.Sort, ef.PageFilter
+
+			if ns, err := DefaultNamespace.With(ctx).FindByAny(ef.Namespace); err != nil {
+				return nil, err
+			} else {
+				rf.NamespaceID = ns.ID
+			}
+
+			if m, err := DefaultModule.With(ctx).FindByAny(rf.NamespaceID, ef.Module); err != nil {
+				return nil, err
+			} else {
+				rf.ModuleID = m.ID
+			}
+
+			set, f, err := DefaultRecord.With(ctx).Find(rf)
+			if err != nil {
+				return nil, err
+			}
+
+			return map[string]interface{}{"set": set, "filter": f}, nil
+		},
+	)
+
+	corredor.Service().RegisterEmbeddedFunc(
+		"compose.findRecordByID",
+		func(ctx context.Context, args []json.RawMessage) (interface{}, error) {
+			var ids = make([]uint64, 2)
+
+			if len(args) < 2 {
+				return nil, errors.New("namespace and record ID not provided")
+			}
+
+			for i := range ids {
+				// IDs can be sent as numbers or as strings (to avoid loss of precision)
+				var id json.Number
+				if err := json.Unmarshal(args[i], &id); err != nil {
+					return nil, err
+				} else if ids[i], err = strconv.ParseUint(id.String(), 10, 64); err != nil {
+					return nil, err
+				}
+			}
+
+			return DefaultRecord.With(ctx).FindByID(ids[0], ids[1])
+		},
+	)
+
+	corredor.Service().RegisterEmbeddedFunc(
+		"compose.createRecord",
+		func(ctx context.Context, args []json.RawMessage) (interface{}, error) {
+			var r = &types.Record{}
+			if len(args) == 0 {
+				return nil, errors.New("record not provided")
+			} else if err := json.Unmarshal(args[0], r); err != nil {
+				return nil, err
+			}
+
+			return DefaultRecord.With(ctx).Create(r)
+		},
+	)
+
+	corredor.Service().RegisterEmbeddedFunc(
+		"compose.updateRecord",
+		func(ctx context.Context, args []json.RawMessage) (interface{}, error) {
+			var r = &types.Record{}
+			if len(args) == 0 {
+				return nil, errors.New("record not provided")
+			} else if err := json.Unmarshal(args[0], r); err != nil {
+				return nil, err
+			}
+
+			return DefaultRecord.With(ctx).Update(r)
+		},
+	)
+}
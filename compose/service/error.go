@@ -9,23 +9,30 @@ type (
 )
 
 const (
-	ErrInvalidID                         serviceError = "InvalidID"
-	ErrInvalidHandle                     serviceError = "InvalidHandle"
-	ErrStaleData                         serviceError = "StaleData"
-	ErrNoPermissions                     serviceError = "NoPermissions"
-	ErrNoGrantPermissions                serviceError = "NoGrantPermissions"
-	ErrNoCreatePermissions               serviceError = "NoCreatePermissions"
-	ErrNoReadPermissions                 serviceError = "NoReadPermissions"
-	ErrNoUpdatePermissions               serviceError = "NoUpdatePermissions"
-	ErrNoDeletePermissions               serviceError = "NoDeletePermissions"
-	ErrNoTriggerManagementPermissions    serviceError = "NoTriggerManagementPermissions"
-	ErrNamespaceRequired                 serviceError = "NamespaceRequired"
-	ErrInvalidModuleID                   serviceError = "InvalidModuleID"
-	ErrModulePageExists                  serviceError = "ModulePageExists"
-	ErrNotImplemented                    serviceError = "NotImplemented"
-	ErrRecordImportSessionNotFound       serviceError = "RecordImportSessionNotFound"
-	ErrRecordImportSessionAlreadyStarted serviceError = "RecordImportSessionAlreadyStarted"
-	ErrRecordImportFormatNotSupported    serviceError = "RecordImportFormatNotSupported"
+	ErrInvalidID                            serviceError = "InvalidID"
+	ErrInvalidHandle                        serviceError = "InvalidHandle"
+	ErrStaleData                            serviceError = "StaleData"
+	ErrNoPermissions                        serviceError = "NoPermissions"
+	ErrNoGrantPermissions                   serviceError = "NoGrantPermissions"
+	ErrNoCreatePermissions                  serviceError = "NoCreatePermissions"
+	ErrNoReadPermissions                    serviceError = "NoReadPermissions"
+	ErrNoUpdatePermissions                  serviceError = "NoUpdatePermissions"
+	ErrNoDeletePermissions                  serviceError = "NoDeletePermissions"
+	ErrNoTriggerManagementPermissions       serviceError = "NoTriggerManagementPermissions"
+	ErrNamespaceRequired                    serviceError = "NamespaceRequired"
+	ErrInvalidModuleID                      serviceError = "InvalidModuleID"
+	ErrModulePageExists                     serviceError = "ModulePageExists"
+	ErrNotImplemented                       serviceError = "NotImplemented"
+	ErrRecordImportSessionNotFound          serviceError = "RecordImportSessionNotFound"
+	ErrRecordImportSessionAlreadyStarted    serviceError = "RecordImportSessionAlreadyStarted"
+	ErrRecordImportFormatNotSupported       serviceError = "RecordImportFormatNotSupported"
+	ErrRecordExportFormatNotSupported       serviceError = "RecordExportFormatNotSupported"
+	ErrModuleMigrationNotConfirmed          serviceError = "ModuleMigrationNotConfirmed"
+	ErrNamespaceTemplateInvalid             serviceError = "NamespaceTemplateInvalid"
+	ErrNamespaceTemplateVersionNotSupported serviceError = "NamespaceTemplateVersionNotSupported"
+	ErrRecordReferenced                     serviceError = "RecordReferenced"
+	ErrRecordCommentEmpty                   serviceError = "RecordCommentEmpty"
+	ErrChartSubscriptionInvalid             serviceError = "ChartSubscriptionInvalid"
 )
 
 func (e serviceError) Error() string {
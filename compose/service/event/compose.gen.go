@@ -44,6 +44,13 @@ type (
 	composeOnTimestamp struct {
 		*composeBase
 	}
+
+	// composeOnCron
+	//
+	// This type is auto-generated.
+	composeOnCron struct {
+		*composeBase
+	}
 )
 
 // ResourceType returns "compose"
@@ -74,6 +81,13 @@ func (composeOnTimestamp) EventType() string {
 	return "onTimestamp"
 }
 
+// EventType on composeOnCron returns "onCron"
+//
+// This function is auto-generated.
+func (composeOnCron) EventType() string {
+	return "onCron"
+}
+
 // ComposeOnManual creates onManual for compose resource
 //
 // This function is auto-generated.
@@ -146,6 +160,30 @@ func ComposeOnTimestampImmutable() *composeOnTimestamp {
 	}
 }
 
+// ComposeOnCron creates onCron for compose resource
+//
+// This function is auto-generated.
+func ComposeOnCron() *composeOnCron {
+	return &composeOnCron{
+		composeBase: &composeBase{
+			immutable: false,
+		},
+	}
+}
+
+// ComposeOnCronImmutable creates onCron for compose resource
+//
+// None of the arguments will be mutable!
+//
+// This function is auto-generated.
+func ComposeOnCronImmutable() *composeOnCron {
+	return &composeOnCron{
+		composeBase: &composeBase{
+			immutable: true,
+		},
+	}
+}
+
 // SetInvoker sets new invoker value
 //
 // This function is auto-generated.
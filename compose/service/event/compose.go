@@ -15,8 +15,13 @@ func (res composeOnTimestamp) Match(c eventbus.ConstraintMatcher) bool {
 	return scheduler.OnTimestamp(c.Values()...)
 }
 
+// Match returns false if given conditions do not match event & resource internals
+func (res composeOnCron) Match(c eventbus.ConstraintMatcher) bool {
+	return scheduler.OnCron(c.Values()...)
+}
+
 // Match returns false if given conditions do not match event & resource internals
 func (res composeBase) Match(c eventbus.ConstraintMatcher) bool {
-	// We do not support any matchers, so if there is a constraint, fail.
-	return false
+	// We do not support any matchers except invoker, so if there is any other constraint, fail.
+	return eventbus.MatchInvoker(res.invoker, c)
 }
@@ -37,3 +37,12 @@ func TestComposeMatching(t *testing.T) {
 
 	a.False(res.Match(eventbus.MustMakeConstraint("foo", "", "bar")))
 }
+
+func TestComposeOnCronMatching(t *testing.T) {
+	var (
+		a   = assert.New(t)
+		res = &composeOnCron{}
+	)
+
+	a.False(res.Match(eventbus.MustMakeConstraint("", "", "CRON_TZ=Europe/Berlin 0 0 1 1 ? 2000")))
+}
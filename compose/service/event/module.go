@@ -10,6 +10,14 @@ func (res moduleBase) Match(c eventbus.ConstraintMatcher) bool {
 	return eventbus.MatchFirst(
 		func() bool { return moduleMatch(res.module, c) },
 		func() bool { return namespaceMatch(res.namespace, c) },
+		func() bool {
+			return res.oldModule != nil && eventbus.MatchChange(
+				c,
+				func(c eventbus.ConstraintMatcher) bool { return moduleMatch(res.oldModule, c) },
+				func(c eventbus.ConstraintMatcher) bool { return moduleMatch(res.module, c) },
+			)
+		},
+		func() bool { return eventbus.MatchInvoker(res.invoker, c) },
 	)
 }
 
@@ -7,7 +7,17 @@ import (
 
 // Match returns false if given conditions do not match event & resource internals
 func (res namespaceBase) Match(c eventbus.ConstraintMatcher) bool {
-	return namespaceMatch(res.namespace, c)
+	return eventbus.MatchFirst(
+		func() bool { return namespaceMatch(res.namespace, c) },
+		func() bool {
+			return res.oldNamespace != nil && eventbus.MatchChange(
+				c,
+				func(c eventbus.ConstraintMatcher) bool { return namespaceMatch(res.oldNamespace, c) },
+				func(c eventbus.ConstraintMatcher) bool { return namespaceMatch(res.namespace, c) },
+			)
+		},
+		func() bool { return eventbus.MatchInvoker(res.invoker, c) },
+	)
 }
 
 // Handles namespace matchers
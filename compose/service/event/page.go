@@ -10,6 +10,14 @@ func (res pageBase) Match(c eventbus.ConstraintMatcher) bool {
 	return eventbus.MatchFirst(
 		func() bool { return pageMatch(res.page, c) },
 		func() bool { return namespaceMatch(res.namespace, c) },
+		func() bool {
+			return res.oldPage != nil && eventbus.MatchChange(
+				c,
+				func(c eventbus.ConstraintMatcher) bool { return pageMatch(res.oldPage, c) },
+				func(c eventbus.ConstraintMatcher) bool { return pageMatch(res.page, c) },
+			)
+		},
+		func() bool { return eventbus.MatchInvoker(res.invoker, c) },
 	)
 }
 
@@ -28,6 +28,8 @@ type (
 		module            *types.Module
 		namespace         *types.Namespace
 		recordValueErrors *types.RecordValueErrorSet
+		transition        *types.RecordTransition
+		comment           *types.RecordComment
 		invoker           auth.Identifiable
 	}
 
@@ -66,6 +68,27 @@ type (
 		*recordBase
 	}
 
+	// recordBeforeTransition
+	//
+	// This type is auto-generated.
+	recordBeforeTransition struct {
+		*recordBase
+	}
+
+	// recordBeforeUndelete
+	//
+	// This type is auto-generated.
+	recordBeforeUndelete struct {
+		*recordBase
+	}
+
+	// recordBeforeComment
+	//
+	// This type is auto-generated.
+	recordBeforeComment struct {
+		*recordBase
+	}
+
 	// recordAfterCreate
 	//
 	// This type is auto-generated.
@@ -86,6 +109,27 @@ type (
 	recordAfterDelete struct {
 		*recordBase
 	}
+
+	// recordAfterTransition
+	//
+	// This type is auto-generated.
+	recordAfterTransition struct {
+		*recordBase
+	}
+
+	// recordAfterUndelete
+	//
+	// This type is auto-generated.
+	recordAfterUndelete struct {
+		*recordBase
+	}
+
+	// recordAfterComment
+	//
+	// This type is auto-generated.
+	recordAfterComment struct {
+		*recordBase
+	}
 )
 
 // ResourceType returns "compose:record"
@@ -130,6 +174,27 @@ func (recordBeforeDelete) EventType() string {
 	return "beforeDelete"
 }
 
+// EventType on recordBeforeTransition returns "beforeTransition"
+//
+// This function is auto-generated.
+func (recordBeforeTransition) EventType() string {
+	return "beforeTransition"
+}
+
+// EventType on recordBeforeUndelete returns "beforeUndelete"
+//
+// This function is auto-generated.
+func (recordBeforeUndelete) EventType() string {
+	return "beforeUndelete"
+}
+
+// EventType on recordBeforeComment returns "beforeComment"
+//
+// This function is auto-generated.
+func (recordBeforeComment) EventType() string {
+	return "beforeComment"
+}
+
 // EventType on recordAfterCreate returns "afterCreate"
 //
 // This function is auto-generated.
@@ -151,6 +216,27 @@ func (recordAfterDelete) EventType() string {
 	return "afterDelete"
 }
 
+// EventType on recordAfterTransition returns "afterTransition"
+//
+// This function is auto-generated.
+func (recordAfterTransition) EventType() string {
+	return "afterTransition"
+}
+
+// EventType on recordAfterUndelete returns "afterUndelete"
+//
+// This function is auto-generated.
+func (recordAfterUndelete) EventType() string {
+	return "afterUndelete"
+}
+
+// EventType on recordAfterComment returns "afterComment"
+//
+// This function is auto-generated.
+func (recordAfterComment) EventType() string {
+	return "afterComment"
+}
+
 // RecordOnManual creates onManual for compose:record resource
 //
 // This function is auto-generated.
@@ -381,6 +467,144 @@ func RecordBeforeDeleteImmutable(
 	}
 }
 
+// RecordBeforeTransition creates beforeTransition for compose:record resource
+//
+// This function is auto-generated.
+func RecordBeforeTransition(
+	argRecord *types.Record,
+	argOldRecord *types.Record,
+	argModule *types.Module,
+	argNamespace *types.Namespace,
+	argRecordValueErrors *types.RecordValueErrorSet,
+) *recordBeforeTransition {
+	return &recordBeforeTransition{
+		recordBase: &recordBase{
+			immutable:         false,
+			record:            argRecord,
+			oldRecord:         argOldRecord,
+			module:            argModule,
+			namespace:         argNamespace,
+			recordValueErrors: argRecordValueErrors,
+		},
+	}
+}
+
+// RecordBeforeTransitionImmutable creates beforeTransition for compose:record resource
+//
+// None of the arguments will be mutable!
+//
+// This function is auto-generated.
+func RecordBeforeTransitionImmutable(
+	argRecord *types.Record,
+	argOldRecord *types.Record,
+	argModule *types.Module,
+	argNamespace *types.Namespace,
+	argRecordValueErrors *types.RecordValueErrorSet,
+) *recordBeforeTransition {
+	return &recordBeforeTransition{
+		recordBase: &recordBase{
+			immutable:         true,
+			record:            argRecord,
+			oldRecord:         argOldRecord,
+			module:            argModule,
+			namespace:         argNamespace,
+			recordValueErrors: argRecordValueErrors,
+		},
+	}
+}
+
+// RecordBeforeUndelete creates beforeUndelete for compose:record resource
+//
+// This function is auto-generated.
+func RecordBeforeUndelete(
+	argRecord *types.Record,
+	argOldRecord *types.Record,
+	argModule *types.Module,
+	argNamespace *types.Namespace,
+	argRecordValueErrors *types.RecordValueErrorSet,
+) *recordBeforeUndelete {
+	return &recordBeforeUndelete{
+		recordBase: &recordBase{
+			immutable:         false,
+			record:            argRecord,
+			oldRecord:         argOldRecord,
+			module:            argModule,
+			namespace:         argNamespace,
+			recordValueErrors: argRecordValueErrors,
+		},
+	}
+}
+
+// RecordBeforeUndeleteImmutable creates beforeUndelete for compose:record resource
+//
+// None of the arguments will be mutable!
+//
+// This function is auto-generated.
+func RecordBeforeUndeleteImmutable(
+	argRecord *types.Record,
+	argOldRecord *types.Record,
+	argModule *types.Module,
+	argNamespace *types.Namespace,
+	argRecordValueErrors *types.RecordValueErrorSet,
+) *recordBeforeUndelete {
+	return &recordBeforeUndelete{
+		recordBase: &recordBase{
+			immutable:         true,
+			record:            argRecord,
+			oldRecord:         argOldRecord,
+			module:            argModule,
+			namespace:         argNamespace,
+			recordValueErrors: argRecordValueErrors,
+		},
+	}
+}
+
+// RecordBeforeComment creates beforeComment for compose:record resource
+//
+// This function is auto-generated.
+func RecordBeforeComment(
+	argRecord *types.Record,
+	argOldRecord *types.Record,
+	argModule *types.Module,
+	argNamespace *types.Namespace,
+	argRecordValueErrors *types.RecordValueErrorSet,
+) *recordBeforeComment {
+	return &recordBeforeComment{
+		recordBase: &recordBase{
+			immutable:         false,
+			record:            argRecord,
+			oldRecord:         argOldRecord,
+			module:            argModule,
+			namespace:         argNamespace,
+			recordValueErrors: argRecordValueErrors,
+		},
+	}
+}
+
+// RecordBeforeCommentImmutable creates beforeComment for compose:record resource
+//
+// None of the arguments will be mutable!
+//
+// This function is auto-generated.
+func RecordBeforeCommentImmutable(
+	argRecord *types.Record,
+	argOldRecord *types.Record,
+	argModule *types.Module,
+	argNamespace *types.Namespace,
+	argRecordValueErrors *types.RecordValueErrorSet,
+) *recordBeforeComment {
+	return &recordBeforeComment{
+		recordBase: &recordBase{
+			immutable:         true,
+			record:            argRecord,
+			oldRecord:         argOldRecord,
+			module:            argModule,
+			namespace:         argNamespace,
+			recordValueErrors: argRecordValueErrors,
+		},
+	}
+}
+
 // RecordAfterCreate creates afterCreate for compose:record resource
 //
 // This function is auto-generated.
@@ -519,6 +743,144 @@ func RecordAfterDeleteImmutable(
 	}
 }
 
+// RecordAfterTransition creates afterTransition for compose:record resource
+//
+// This function is auto-generated.
+func RecordAfterTransition(
+	argRecord *types.Record,
+	argOldRecord *types.Record,
+	argModule *types.Module,
+	argNamespace *types.Namespace,
+	argRecordValueErrors *types.RecordValueErrorSet,
+) *recordAfterTransition {
+	return &recordAfterTransition{
+		recordBase: &recordBase{
+			immutable:         false,
+			record:            argRecord,
+			oldRecord:         argOldRecord,
+			module:            argModule,
+			namespace:         argNamespace,
+			recordValueErrors: argRecordValueErrors,
+		},
+	}
+}
+
+// RecordAfterTransitionImmutable creates afterTransition for compose:record resource
+//
+// None of the arguments will be mutable!
+//
+// This function is auto-generated.
+func RecordAfterTransitionImmutable(
+	argRecord *types.Record,
+	argOldRecord *types.Record,
+	argModule *types.Module,
+	argNamespace *types.Namespace,
+	argRecordValueErrors *types.RecordValueErrorSet,
+) *recordAfterTransition {
+	return &recordAfterTransition{
+		recordBase: &recordBase{
+			immutable:         true,
+			record:            argRecord,
+			oldRecord:         argOldRecord,
+			module:            argModule,
+			namespace:         argNamespace,
+			recordValueErrors: argRecordValueErrors,
+		},
+	}
+}
+
+// RecordAfterUndelete creates afterUndelete for compose:record resource
+//
+// This function is auto-generated.
+func RecordAfterUndelete(
+	argRecord *types.Record,
+	argOldRecord *types.Record,
+	argModule *types.Module,
+	argNamespace *types.Namespace,
+	argRecordValueErrors *types.RecordValueErrorSet,
+) *recordAfterUndelete {
+	return &recordAfterUndelete{
+		recordBase: &recordBase{
+			immutable:         false,
+			record:            argRecord,
+			oldRecord:         argOldRecord,
+			module:            argModule,
+			namespace:         argNamespace,
+			recordValueErrors: argRecordValueErrors,
+		},
+	}
+}
+
+// RecordAfterUndeleteImmutable creates afterUndelete for compose:record resource
+//
+// None of the arguments will be mutable!
+//
+// This function is auto-generated.
+func RecordAfterUndeleteImmutable(
+	argRecord *types.Record,
+	argOldRecord *types.Record,
+	argModule *types.Module,
+	argNamespace *types.Namespace,
+	argRecordValueErrors *types.RecordValueErrorSet,
+) *recordAfterUndelete {
+	return &recordAfterUndelete{
+		recordBase: &recordBase{
+			immutable:         true,
+			record:            argRecord,
+			oldRecord:         argOldRecord,
+			module:            argModule,
+			namespace:         argNamespace,
+			recordValueErrors: argRecordValueErrors,
+		},
+	}
+}
+
+// RecordAfterComment creates afterComment for compose:record resource
+//
+// This function is auto-generated.
+func RecordAfterComment(
+	argRecord *types.Record,
+	argOldRecord *types.Record,
+	argModule *types.Module,
+	argNamespace *types.Namespace,
+	argRecordValueErrors *types.RecordValueErrorSet,
+) *recordAfterComment {
+	return &recordAfterComment{
+		recordBase: &recordBase{
+			immutable:         false,
+			record:            argRecord,
+			oldRecord:         argOldRecord,
+			module:            argModule,
+			namespace:         argNamespace,
+			recordValueErrors: argRecordValueErrors,
+		},
+	}
+}
+
+// RecordAfterCommentImmutable creates afterComment for compose:record resource
+//
+// None of the arguments will be mutable!
+//
+// This function is auto-generated.
+func RecordAfterCommentImmutable(
+	argRecord *types.Record,
+	argOldRecord *types.Record,
+	argModule *types.Module,
+	argNamespace *types.Namespace,
+	argRecordValueErrors *types.RecordValueErrorSet,
+) *recordAfterComment {
+	return &recordAfterComment{
+		recordBase: &recordBase{
+			immutable:         true,
+			record:            argRecord,
+			oldRecord:         argOldRecord,
+			module:            argModule,
+			namespace:         argNamespace,
+			recordValueErrors: argRecordValueErrors,
+		},
+	}
+}
+
 // SetRecord sets new record value
 //
 // This function is auto-generated.
@@ -568,6 +930,34 @@ func (res recordBase) RecordValueErrors() *types.RecordValueErrorSet {
 	return res.recordValueErrors
 }
 
+// SetTransition sets new transition value
+//
+// This function is auto-generated.
+func (res *recordBase) SetTransition(argTransition *types.RecordTransition) {
+	res.transition = argTransition
+}
+
+// Transition returns transition
+//
+// This function is auto-generated.
+func (res recordBase) Transition() *types.RecordTransition {
+	return res.transition
+}
+
+// SetComment sets new comment value
+//
+// This function is auto-generated.
+func (res *recordBase) SetComment(argComment *types.RecordComment) {
+	res.comment = argComment
+}
+
+// Comment returns comment
+//
+// This function is auto-generated.
+func (res recordBase) Comment() *types.RecordComment {
+	return res.comment
+}
+
 // SetInvoker sets new invoker value
 //
 // This function is auto-generated.
@@ -606,6 +996,14 @@ func (res recordBase) Encode() (args map[string][]byte, err error) {
 		return nil, err
 	}
 
+	if args["transition"], err = json.Marshal(res.transition); err != nil {
+		return nil, err
+	}
+
+	if args["comment"], err = json.Marshal(res.comment); err != nil {
+		return nil, err
+	}
+
 	if args["invoker"], err = json.Marshal(res.invoker); err != nil {
 		return nil, err
 	}
@@ -643,6 +1041,22 @@ func (res *recordBase) Decode(results map[string][]byte) (err error) {
 		}
 	}
 
+	if res.transition != nil {
+		if r, ok := results["transition"]; ok {
+			if err = json.Unmarshal(r, res.transition); err != nil {
+				return
+			}
+		}
+	}
+
+	if res.comment != nil {
+		if r, ok := results["comment"]; ok {
+			if err = json.Unmarshal(r, res.comment); err != nil {
+				return
+			}
+		}
+	}
+
 	if res.invoker != nil {
 		if r, ok := results["invoker"]; ok {
 			if err = json.Unmarshal(r, res.invoker); err != nil {
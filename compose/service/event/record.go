@@ -8,15 +8,26 @@ import (
 )
 
 const (
-	recordMatchValues = "record.values."
+	recordMatchValues     = "record.values."
+	recordMatchTransition = "transition"
 )
 
 // Match returns false if given conditions do not match event & resource internals
 func (res recordBase) Match(c eventbus.ConstraintMatcher) bool {
 	return eventbus.MatchFirst(
 		func() bool { return recordMatch(res.record, c) },
+		func() bool { return recordTransitionMatch(res.transition, c) },
 		func() bool { return moduleMatch(res.module, c) },
 		func() bool { return namespaceMatch(res.namespace, c) },
+		func() bool {
+			// Compare (field) values of the old and new record (changed, changedFrom, changedTo)
+			return res.oldRecord != nil && res.record != nil && eventbus.MatchChange(
+				c,
+				func(c eventbus.ConstraintMatcher) bool { return recordMatch(res.oldRecord, c) },
+				func(c eventbus.ConstraintMatcher) bool { return recordMatch(res.record, c) },
+			)
+		},
+		func() bool { return eventbus.MatchInvoker(res.invoker, c) },
 	)
 }
 
@@ -41,3 +52,23 @@ func recordMatch(r *types.Record, c eventbus.ConstraintMatcher) bool {
 
 	return false
 }
+
+// recordTransitionMatch matches workflow transition (only set on before/after transition events)
+func recordTransitionMatch(t *types.RecordTransition, c eventbus.ConstraintMatcher) bool {
+	if t == nil {
+		return false
+	}
+
+	switch c.Name() {
+	case recordMatchTransition, recordMatchTransition + ".name":
+		return c.Match(t.Transition)
+	case recordMatchTransition + ".field":
+		return c.Match(t.Field)
+	case recordMatchTransition + ".from":
+		return c.Match(t.FromState)
+	case recordMatchTransition + ".to":
+		return c.Match(t.ToState)
+	}
+
+	return false
+}
@@ -56,3 +56,45 @@ func TestRecordMatchValues(t *testing.T) {
 		)
 	}
 }
+
+func TestRecordMatchTransition(t *testing.T) {
+	var (
+		a   = assert.New(t)
+		res = &recordBase{}
+	)
+
+	a.False(res.Match(eventbus.MustMakeConstraint("transition", "eq", "submit")))
+
+	res.SetTransition(&types.RecordTransition{Field: "status", Transition: "submit", FromState: "draft", ToState: "submitted"})
+
+	a.True(res.Match(eventbus.MustMakeConstraint("transition", "eq", "submit")))
+	a.True(res.Match(eventbus.MustMakeConstraint("transition.field", "eq", "status")))
+	a.True(res.Match(eventbus.MustMakeConstraint("transition.from", "eq", "draft")))
+	a.True(res.Match(eventbus.MustMakeConstraint("transition.to", "eq", "submitted")))
+	a.False(res.Match(eventbus.MustMakeConstraint("transition.to", "eq", "approved")))
+}
+
+func TestRecordMatchChangesAndComparison(t *testing.T) {
+	var (
+		a = assert.New(t)
+
+		res = &recordBase{
+			oldRecord: &types.Record{Values: types.RecordValueSet{
+				&types.RecordValue{Name: "status", Value: "draft"},
+				&types.RecordValue{Name: "amount", Value: "500"},
+			}},
+			record: &types.Record{Values: types.RecordValueSet{
+				&types.RecordValue{Name: "status", Value: "sent"},
+				&types.RecordValue{Name: "amount", Value: "12000"},
+			}},
+		}
+	)
+
+	a.True(res.Match(eventbus.MustMakeConstraint("record.values.amount", ">", "10000")))
+	a.False(res.Match(eventbus.MustMakeConstraint("record.values.amount", "<=", "10000")))
+	a.True(res.Match(eventbus.MustMakeConstraint("record.values.status", "in", "sent", "paid")))
+	a.True(res.Match(eventbus.MustMakeConstraint("record.values.status", "changedFrom", "draft")))
+	a.True(res.Match(eventbus.MustMakeConstraint("record.values.status", "changedTo", "sent")))
+	a.False(res.Match(eventbus.MustMakeConstraint("record.values.status", "changedTo", "paid")))
+	a.False(res.Match(eventbus.MustMakeConstraint("record.values.other", "changed")))
+}
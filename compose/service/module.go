@@ -10,6 +10,7 @@ import (
 
 	"github.com/cortezaproject/corteza-server/compose/repository"
 	"github.com/cortezaproject/corteza-server/compose/service/event"
+	"github.com/cortezaproject/corteza-server/compose/service/values"
 	"github.com/cortezaproject/corteza-server/compose/types"
 	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 	"github.com/cortezaproject/corteza-server/pkg/handle"
@@ -26,10 +27,17 @@ type (
 		ac       moduleAccessController
 		eventbus eventDispatcher
 
-		moduleRepo repository.ModuleRepository
-		recordRepo repository.RecordRepository
-		pageRepo   repository.PageRepository
-		nsRepo     repository.NamespaceRepository
+		moduleRepo    repository.ModuleRepository
+		recordRepo    repository.RecordRepository
+		pageRepo      repository.PageRepository
+		nsRepo        repository.NamespaceRepository
+		migrationRepo repository.ModuleMigrationRepository
+
+		converter moduleValuesConverter
+	}
+
+	moduleValuesConverter interface {
+		Run(*types.Module, *types.RecordValue) (*types.RecordValue, bool)
 	}
 
 	moduleAccessController interface {
@@ -53,7 +61,11 @@ type (
 
 		Create(module *types.Module) (*types.Module, error)
 		Update(module *types.Module) (*types.Module, error)
+		UpdateConfirmed(module *types.Module) (*types.Module, error)
 		DeleteByID(namespaceID, moduleID uint64) error
+
+		PlanMigration(module *types.Module) (*types.ModuleMigration, error)
+		FindMigrations(namespaceID, moduleID uint64) (types.ModuleMigrationSet, error)
 	}
 )
 
@@ -75,10 +87,13 @@ func (svc module) With(ctx context.Context) ModuleService {
 		ac:       svc.ac,
 		eventbus: svc.eventbus,
 
-		moduleRepo: repository.Module(ctx, db),
-		recordRepo: repository.Record(ctx, db),
-		pageRepo:   repository.Page(ctx, db),
-		nsRepo:     repository.Namespace(ctx, db),
+		moduleRepo:    repository.Module(ctx, db),
+		recordRepo:    repository.Record(ctx, db),
+		pageRepo:      repository.Page(ctx, db),
+		nsRepo:        repository.Namespace(ctx, db),
+		migrationRepo: repository.ModuleMigration(ctx, db),
+
+		converter: values.Converter(recordValuesValidatorFor(ctx, db)),
 	}
 }
 
@@ -207,7 +222,7 @@ func (svc module) Create(new *types.Module) (m *types.Module, err error) {
 		return nil, err
 	}
 
-	err = svc.moduleRepo.UpdateFields(m.ID, m.Fields, false)
+	err = svc.moduleRepo.UpdateFields(m.ID, m.Fields)
 	if err != nil {
 		return nil, err
 	}
@@ -216,9 +231,24 @@ func (svc module) Create(new *types.Module) (m *types.Module, err error) {
 	return
 }
 
+// Update updates module and migrates values of renamed, removed and retyped fields
+//
+// Changes that would archive any of the existing values are refused
+// with ErrModuleMigrationNotConfirmed, see UpdateConfirmed
 func (svc module) Update(upd *types.Module) (m *types.Module, err error) {
+	return svc.update(upd, false)
+}
+
+// UpdateConfirmed updates module and migrates values even if
+// some of them need to be archived
+func (svc module) UpdateConfirmed(upd *types.Module) (m *types.Module, err error) {
+	return svc.update(upd, true)
+}
+
+func (svc module) update(upd *types.Module, confirmed bool) (m *types.Module, err error) {
 	var (
 		ns *types.Namespace
+		mm *types.ModuleMigration
 	)
 
 	if upd.ID == 0 {
@@ -253,23 +283,38 @@ func (svc module) Update(upd *types.Module) (m *types.Module, err error) {
 		return
 	}
 
+	if mm, err = svc.planMigration(m, upd.Fields); err != nil {
+		return
+	}
+
+	if mm.Destructive && !confirmed {
+		return nil, ErrModuleMigrationNotConfirmed.withStack()
+	}
+
 	m.Name = upd.Name
 	m.Handle = upd.Handle
 	m.Meta = upd.Meta
 	m.Fields = upd.Fields
 
-	m, err = svc.moduleRepo.Update(m)
-	if err != nil {
-		return nil, err
-	}
+	err = svc.db.Transaction(func() (err error) {
+		if m, err = svc.moduleRepo.Update(m); err != nil {
+			return
+		}
+
+		if err = svc.moduleRepo.UpdateFields(m.ID, m.Fields); err != nil {
+			return
+		}
+
+		return svc.migrate(m, mm)
+	})
 
-	_, ff, err := svc.recordRepo.Find(m, types.RecordFilter{})
 	if err != nil {
 		return nil, err
 	}
-	err = svc.moduleRepo.UpdateFields(m.ID, m.Fields, ff.Count > 0)
-	if err != nil {
-		return nil, err
+
+	if mm.ID > 0 && mm.Status == types.ModuleMigrationPending {
+		// Too many values to convert within the request
+		go svc.migrateInBackground(m, mm)
 	}
 
 	defer svc.eventbus.Dispatch(svc.ctx, event.ModuleAfterUpdate(upd, m, ns))
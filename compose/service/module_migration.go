@@ -0,0 +1,336 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/sentry"
+)
+
+const (
+	// Modules with more values to convert are migrated in background
+	moduleMigrationSyncLimit = 5000
+
+	// Number of values converted in one go
+	moduleMigrationBatchSize = 500
+
+	// How often unfinished migrations are looked up
+	moduleMigrationResumeInterval = time.Minute
+
+	// Running migrations not updated for this long are considered interrupted
+	moduleMigrationStaleAfter = 10 * time.Minute
+)
+
+// PlanMigration returns migration steps that would be executed when module is updated
+//
+// Nothing is stored or changed
+func (svc module) PlanMigration(upd *types.Module) (mm *types.ModuleMigration, err error) {
+	var (
+		m *types.Module
+	)
+
+	if upd.ID == 0 {
+		return nil, ErrInvalidID.withStack()
+	}
+
+	if m, err = svc.moduleRepo.FindByID(upd.NamespaceID, upd.ID); err != nil {
+		return
+	}
+
+	if _, err = svc.loadNamespace(upd.NamespaceID); err != nil {
+		return nil, err
+	}
+
+	if !svc.ac.CanUpdateModule(svc.ctx, m) {
+		return nil, ErrNoUpdatePermissions.withStack()
+	}
+
+	return svc.planMigration(m, upd.Fields)
+}
+
+// FindMigrations returns all migrations of a module, last one first
+func (svc module) FindMigrations(namespaceID, moduleID uint64) (mm types.ModuleMigrationSet, err error) {
+	var (
+		m *types.Module
+	)
+
+	if m, err = svc.FindByID(namespaceID, moduleID); err != nil {
+		return
+	}
+
+	mm, _, err = svc.migrationRepo.Find(types.ModuleMigrationFilter{
+		NamespaceID: m.NamespaceID,
+		ModuleID:    m.ID,
+	})
+
+	return
+}
+
+// planMigration compares existing fields with the updated ones
+// and counts values that each of the steps would affect
+func (svc module) planMigration(m *types.Module, ff types.ModuleFieldSet) (mm *types.ModuleMigration, err error) {
+	var (
+		existing types.ModuleFieldSet
+		counts   map[string]uint
+	)
+
+	if existing, err = svc.moduleRepo.FindFields(m.ID); err != nil {
+		return
+	}
+
+	mm = &types.ModuleMigration{
+		NamespaceID: m.NamespaceID,
+		ModuleID:    m.ID,
+		Steps:       types.PlanModuleMigration(existing, ff),
+		Status:      types.ModuleMigrationPending,
+	}
+
+	if len(mm.Steps) == 0 {
+		return
+	}
+
+	if counts, err = svc.recordRepo.CountValues(m.ID, existing.Names()...); err != nil {
+		return
+	}
+
+	for _, s := range mm.Steps {
+		s.Values = counts[s.OldName]
+		mm.Total += s.Values
+	}
+
+	mm.Destructive = mm.Steps.IsDestructive()
+	return
+}
+
+// migrate stores migration and moves values of renamed and removed fields
+//
+// Values are converted right away when there are not too many of them,
+// otherwise migration is left in pending state and needs to be finished in background
+func (svc module) migrate(m *types.Module, mm *types.ModuleMigration) (err error) {
+	if mm.Total == 0 {
+		// Nothing to migrate
+		return nil
+	}
+
+	mm.CreatedBy = auth.GetIdentityFromContext(svc.ctx).Identity()
+	mm.StartedAt = moduleMigrationTime()
+
+	if mm, err = svc.migrationRepo.Create(mm); err != nil {
+		return
+	}
+
+	for _, s := range mm.Steps.FilterByAction(types.ModuleMigrationArchive) {
+		if err = svc.recordRepo.ArchiveValues(mm.ID, m.ID, s.OldName); err != nil {
+			return
+		}
+
+		mm.Processed += s.Values
+	}
+
+	// Renaming in two passes to allow fields to swap names
+	renames := mm.Steps.FilterByAction(types.ModuleMigrationRename)
+	for _, s := range renames {
+		if err = svc.recordRepo.RenameValues(m.ID, s.OldName, moduleMigrationTempName(s)); err != nil {
+			return
+		}
+	}
+
+	for _, s := range renames {
+		if err = svc.recordRepo.RenameValues(m.ID, moduleMigrationTempName(s), s.NewName); err != nil {
+			return
+		}
+
+		mm.Processed += s.Values
+	}
+
+	if mm.Total-mm.Processed > moduleMigrationSyncLimit {
+		_, err = svc.migrationRepo.Update(mm)
+		return
+	}
+
+	if err = svc.convert(m, mm); err != nil {
+		return
+	}
+
+	return svc.complete(mm, nil)
+}
+
+// migrateInBackground converts values of a pending migration
+//
+// Migration is marked as failed when any of the batches can not be converted;
+// values converted so far are kept.
+func (svc module) migrateInBackground(m *types.Module, mm *types.ModuleMigration) {
+	defer sentry.Recover()
+
+	var (
+		ctx = context.Background()
+		bg  = svc.With(ctx).(*module)
+		log = svc.log(svc.ctx, zap.Uint64("moduleID", m.ID), zap.Uint64("migrationID", mm.ID))
+	)
+
+	// Claiming migration so that it is not picked up
+	// by the resume watcher (or another node) at the same time
+	if claimed, err := bg.migrationRepo.Claim(mm); err != nil {
+		log.Error("could not update module migration", zap.Error(err))
+		return
+	} else if !claimed {
+		log.Debug("module migration already claimed")
+		return
+	}
+
+	log.Info("running module migration in background", zap.Uint("total", mm.Total))
+
+	err := bg.convert(m, mm)
+	if err != nil {
+		log.Error("module migration failed", zap.Error(err))
+	}
+
+	if err = bg.complete(mm, err); err != nil {
+		log.Error("could not update module migration", zap.Error(err))
+	}
+}
+
+// resumeMigrations continues pending and interrupted migrations
+//
+// Conversion of an interrupted migration is restarted from the beginning;
+// values that were already converted are left as they are
+func (svc module) resumeMigrations() error {
+	set, err := svc.migrationRepo.FindUnfinished(time.Now().Add(-moduleMigrationStaleAfter))
+	if err != nil {
+		return err
+	}
+
+	return set.Walk(func(mm *types.ModuleMigration) error {
+		m, err := svc.moduleRepo.FindByID(mm.NamespaceID, mm.ModuleID)
+		if err != nil {
+			return err
+		}
+
+		if m.Fields, err = svc.moduleRepo.FindFields(m.ID); err != nil {
+			return err
+		}
+
+		// Values of archived and renamed fields were moved before migration was stored
+		mm.Processed = 0
+		for _, s := range mm.Steps {
+			if s.Action != types.ModuleMigrationConvert {
+				mm.Processed += s.Values
+			}
+		}
+
+		svc.migrateInBackground(m, mm)
+		return nil
+	})
+}
+
+// watchModuleMigrations periodically resumes pending and interrupted migrations
+//
+// Migrations are left unfinished when server is restarted while they are running
+func watchModuleMigrations(ctx context.Context, log *zap.Logger) {
+	go func() {
+		defer sentry.Recover()
+
+		var (
+			ticker = time.NewTicker(moduleMigrationResumeInterval)
+			svc    = DefaultModule.With(auth.SetSuperUserContext(ctx)).(*module)
+		)
+
+		defer ticker.Stop()
+		for {
+			if err := svc.resumeMigrations(); err != nil {
+				log.Error("could not resume module migrations", zap.Error(err))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	log.Debug("module migration watcher initialized")
+}
+
+// convert sanitizes values of all fields with changed kind
+//
+// Values that can not be converted are archived
+func (svc module) convert(m *types.Module, mm *types.ModuleMigration) (err error) {
+	var (
+		rvs types.RecordValueSet
+	)
+
+	for _, s := range mm.Steps.FilterByAction(types.ModuleMigrationConvert) {
+		var (
+			recordID uint64
+			place    uint
+		)
+
+		for {
+			rvs, err = svc.recordRepo.FindValuesAfter(m.ID, s.NewName, recordID, place, moduleMigrationBatchSize)
+			if err != nil || len(rvs) == 0 {
+				break
+			}
+
+			err = svc.db.Transaction(func() (err error) {
+				return rvs.Walk(func(v *types.RecordValue) error {
+					recordID, place = v.RecordID, v.Place
+
+					if c, ok := svc.converter.Run(m, v); !ok {
+						return svc.recordRepo.ArchiveValue(mm.ID, v)
+					} else if c.Value != v.Value || c.Ref != v.Ref {
+						return svc.recordRepo.PartialUpdateValues(c)
+					}
+
+					return nil
+				})
+			})
+
+			if err != nil {
+				return errors.Wrapf(err, "could not convert values of field %q", s.NewName)
+			}
+
+			mm.Processed += uint(len(rvs))
+			if mm.Status == types.ModuleMigrationRunning {
+				if _, err = svc.migrationRepo.Update(mm); err != nil {
+					return
+				}
+			}
+		}
+
+		if err != nil {
+			return
+		}
+	}
+
+	return nil
+}
+
+func (svc module) complete(mm *types.ModuleMigration, err error) error {
+	mm.CompletedAt = moduleMigrationTime()
+	mm.Status = types.ModuleMigrationCompleted
+
+	if err != nil {
+		mm.Status = types.ModuleMigrationFailed
+		mm.Error = err.Error()
+	}
+
+	_, err = svc.migrationRepo.Update(mm)
+	return err
+}
+
+// moduleMigrationTempName is used for values while fields are being renamed
+func moduleMigrationTempName(s *types.ModuleMigrationStep) string {
+	return fmt.Sprintf("__migration_%d", s.FieldID)
+}
+
+func moduleMigrationTime() *time.Time {
+	n := time.Now()
+	return &n
+}
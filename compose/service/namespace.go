@@ -15,6 +15,7 @@ import (
 	"github.com/cortezaproject/corteza-server/pkg/handle"
 	"github.com/cortezaproject/corteza-server/pkg/logger"
 	"github.com/cortezaproject/corteza-server/pkg/permissions"
+	"github.com/cortezaproject/corteza-server/pkg/store"
 )
 
 type (
@@ -26,7 +27,14 @@ type (
 		ac       namespaceAccessController
 		eventbus eventDispatcher
 
-		namespaceRepo repository.NamespaceRepository
+		namespaceRepo  repository.NamespaceRepository
+		moduleRepo     repository.ModuleRepository
+		pageRepo       repository.PageRepository
+		chartRepo      repository.ChartRepository
+		recordRepo     repository.RecordRepository
+		attachmentRepo repository.AttachmentRepository
+
+		store store.Store
 	}
 
 	namespaceAccessController interface {
@@ -34,8 +42,15 @@ type (
 		CanReadNamespace(context.Context, *types.Namespace) bool
 		CanUpdateNamespace(context.Context, *types.Namespace) bool
 		CanDeleteNamespace(context.Context, *types.Namespace) bool
+		CanReadModule(context.Context, *types.Module) bool
+		CanReadRecord(context.Context, *types.Module) bool
+		CanReadRecordValue(context.Context, *types.ModuleField) bool
+		CanReadPage(context.Context, *types.Page) bool
+		CanReadChart(context.Context, *types.Chart) bool
+		CanGrant(context.Context) bool
 
 		Grant(ctx context.Context, rr ...*permissions.Rule) error
+		FindRulesByResource(ctx context.Context, resources ...permissions.Resource) (permissions.RuleSet, error)
 
 		FilterReadableNamespaces(ctx context.Context) *permissions.ResourceFilter
 	}
@@ -51,6 +66,10 @@ type (
 		Create(namespace *types.Namespace) (*types.Namespace, error)
 		Update(namespace *types.Namespace) (*types.Namespace, error)
 		DeleteByID(namespaceID uint64) error
+
+		Clone(namespaceID uint64, name, slug string, withRecords bool) (*types.Namespace, error)
+		Template(namespaceID uint64, withRecords bool) (*types.NamespaceTemplate, error)
+		Install(tpl *types.NamespaceTemplate, name, slug string) (*types.Namespace, error)
 	}
 )
 
@@ -59,6 +78,7 @@ func Namespace() NamespaceService {
 		logger:   DefaultLogger.Named("namespace"),
 		ac:       DefaultAccessControl,
 		eventbus: eventbus.Service(),
+		store:    DefaultStore,
 	}).With(context.Background())
 }
 
@@ -71,8 +91,14 @@ func (svc namespace) With(ctx context.Context) NamespaceService {
 
 		ac:       svc.ac,
 		eventbus: svc.eventbus,
-
-		namespaceRepo: repository.Namespace(ctx, db),
+		store:    svc.store,
+
+		namespaceRepo:  repository.Namespace(ctx, db),
+		moduleRepo:     repository.Module(ctx, db),
+		pageRepo:       repository.Page(ctx, db),
+		chartRepo:      repository.Chart(ctx, db),
+		recordRepo:     repository.Record(ctx, db),
+		attachmentRepo: repository.Attachment(ctx, db),
 	}
 }
 
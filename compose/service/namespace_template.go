@@ -0,0 +1,576 @@
+package service
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/titpetric/factory"
+
+	"github.com/cortezaproject/corteza-server/compose/repository"
+	"github.com/cortezaproject/corteza-server/compose/service/event"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/handle"
+	"github.com/cortezaproject/corteza-server/pkg/permissions"
+	"github.com/cortezaproject/corteza-server/pkg/store"
+)
+
+type (
+	// namespaceTemplateStoreFiles opens attachment files from the store
+	namespaceTemplateStoreFiles struct {
+		store store.Store
+	}
+)
+
+// Clone copies namespace with all modules, pages, charts and access rules (and optionally records)
+func (svc namespace) Clone(namespaceID uint64, name, slug string, withRecords bool) (ns *types.Namespace, err error) {
+	var (
+		tpl *types.NamespaceTemplate
+	)
+
+	if tpl, err = svc.Template(namespaceID, withRecords); err != nil {
+		return
+	}
+
+	return svc.Install(tpl, name, slug)
+}
+
+// Template collects namespace structure into a template that can be installed as a new namespace
+//
+// Only modules, pages, charts, records and values that user can read are collected
+func (svc namespace) Template(namespaceID uint64, withRecords bool) (tpl *types.NamespaceTemplate, err error) {
+	var (
+		ns *types.Namespace
+		ff types.ModuleFieldSet
+	)
+
+	if ns, err = svc.FindByID(namespaceID); err != nil {
+		return
+	}
+
+	if !svc.ac.CanUpdateNamespace(svc.ctx, ns) {
+		return nil, ErrNoUpdatePermissions.withStack()
+	}
+
+	tpl = &types.NamespaceTemplate{
+		Version:   types.NamespaceTemplateVersion,
+		Namespace: ns,
+	}
+
+	if svc.store != nil {
+		tpl.Files = namespaceTemplateStoreFiles{store: svc.store}
+	}
+
+	if tpl.Modules, _, err = svc.moduleRepo.Find(types.ModuleFilter{NamespaceID: ns.ID}); err != nil {
+		return
+	}
+
+	tpl.Modules, _ = tpl.Modules.Filter(func(m *types.Module) (bool, error) {
+		return svc.ac.CanReadModule(svc.ctx, m), nil
+	})
+
+	if ff, err = svc.moduleRepo.FindFields(tpl.Modules.IDs()...); err != nil {
+		return
+	}
+
+	_ = ff.Walk(func(f *types.ModuleField) error {
+		m := tpl.Modules.FindByID(f.ModuleID)
+		m.Fields = append(m.Fields, f)
+		return nil
+	})
+
+	if tpl.Pages, _, err = svc.pageRepo.Find(types.PageFilter{NamespaceID: ns.ID}); err != nil {
+		return
+	}
+
+	tpl.Pages, _ = tpl.Pages.Filter(func(p *types.Page) (bool, error) {
+		return svc.ac.CanReadPage(svc.ctx, p), nil
+	})
+
+	if tpl.Charts, _, err = svc.chartRepo.Find(types.ChartFilter{NamespaceID: ns.ID}); err != nil {
+		return
+	}
+
+	tpl.Charts, _ = tpl.Charts.Filter(func(c *types.Chart) (bool, error) {
+		return svc.ac.CanReadChart(svc.ctx, c), nil
+	})
+
+	if withRecords {
+		if tpl.Records, err = svc.templateRecords(tpl.Modules); err != nil {
+			return
+		}
+
+		if tpl.Attachments, err = svc.templateAttachments(tpl.Modules, tpl.Records); err != nil {
+			return
+		}
+	}
+
+	if svc.ac.CanGrant(svc.ctx) {
+		// Access rules are copied only when user is allowed to manage them
+		if tpl.Rules, err = svc.ac.FindRulesByResource(svc.ctx, templateResources(tpl)...); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// templateRecords collects records of modules that user can read records from
+//
+// Values of fields that user can not read are left out
+func (svc namespace) templateRecords(mm types.ModuleSet) (rr types.RecordSet, err error) {
+	err = mm.Walk(func(m *types.Module) error {
+		if !svc.ac.CanReadRecord(svc.ctx, m) {
+			return nil
+		}
+
+		set, err := svc.recordRepo.Export(m, types.RecordFilter{NamespaceID: m.NamespaceID, ModuleID: m.ID})
+		if err != nil || len(set) == 0 {
+			return err
+		}
+
+		var readable []string
+		for _, f := range m.Fields {
+			if svc.ac.CanReadRecordValue(svc.ctx, f) {
+				readable = append(readable, f.Name)
+			}
+		}
+
+		if len(readable) == 0 {
+			rr = append(rr, set...)
+			return nil
+		}
+
+		vv, err := svc.recordRepo.LoadValues(readable, set.IDs())
+		if err != nil {
+			return err
+		}
+
+		_ = set.Walk(func(r *types.Record) error {
+			r.Values = vv.FilterByRecordID(r.ID)
+			return nil
+		})
+
+		rr = append(rr, set...)
+		return nil
+	})
+
+	return
+}
+
+// templateAttachments collects attachments referenced from file fields of the records
+func (svc namespace) templateAttachments(mm types.ModuleSet, rr types.RecordSet) (aa types.AttachmentSet, err error) {
+	var (
+		att  *types.Attachment
+		seen = make(map[uint64]bool)
+	)
+
+	for _, r := range rr {
+		m := mm.FindByID(r.ModuleID)
+		if m == nil {
+			continue
+		}
+
+		for _, v := range r.Values {
+			f := m.Fields.FindByName(v.Name)
+			if f == nil || strings.ToLower(f.Kind) != "file" {
+				continue
+			}
+
+			ID, _ := strconv.ParseUint(v.Value, 10, 64)
+			if ID == 0 || seen[ID] {
+				continue
+			}
+
+			seen[ID] = true
+
+			if att, err = svc.attachmentRepo.FindByID(r.NamespaceID, ID); err == repository.ErrAttachmentNotFound {
+				// Value points to a missing attachment, nothing to copy
+				err = nil
+				continue
+			} else if err != nil {
+				return
+			}
+
+			aa = append(aa, att)
+		}
+	}
+
+	return
+}
+
+// Install creates a new namespace from the template
+//
+// All resources get new IDs and references between them are rewritten.
+// Resources in the template are modified in the process.
+func (svc namespace) Install(tpl *types.NamespaceTemplate, name, slug string) (ns *types.Namespace, err error) {
+	if tpl == nil || tpl.Namespace == nil {
+		return nil, ErrNamespaceTemplateInvalid.withStack()
+	}
+
+	if tpl.Version > types.NamespaceTemplateVersion {
+		return nil, ErrNamespaceTemplateVersionNotSupported.withStack()
+	}
+
+	var (
+		srcID = tpl.Namespace.ID
+	)
+
+	ns = tpl.Namespace
+	if name != "" {
+		ns.Name = name
+	}
+
+	if slug != "" {
+		ns.Slug = slug
+	}
+
+	ns.ID = 0
+	ns.UpdatedAt = nil
+	ns.DeletedAt = nil
+
+	if !handle.IsValid(ns.Slug) {
+		return nil, ErrInvalidHandle
+	}
+
+	if !svc.ac.CanCreateNamespace(svc.ctx) {
+		return nil, ErrNoCreatePermissions.withStack()
+	}
+
+	if err = svc.eventbus.WaitFor(svc.ctx, event.NamespaceBeforeCreate(ns, nil)); err != nil {
+		return
+	}
+
+	if err = svc.UniqueCheck(ns); err != nil {
+		return
+	}
+
+	err = svc.db.Transaction(func() (err error) {
+		var (
+			ids = types.IDMap{}
+		)
+
+		if ns, err = svc.namespaceRepo.Create(ns); err != nil {
+			return
+		}
+
+		ids[srcID] = ns.ID
+
+		if err = svc.installModules(ns, tpl.Modules, ids); err != nil {
+			return
+		}
+
+		if err = svc.installCharts(ns, tpl.Charts, ids); err != nil {
+			return
+		}
+
+		if err = svc.installPages(ns, tpl.Pages, ids); err != nil {
+			return
+		}
+
+		if err = svc.installAttachments(ns, tpl.Attachments, tpl.Files, ids); err != nil {
+			return
+		}
+
+		if err = svc.installRecords(ns, tpl.Modules, tpl.Records, ids); err != nil {
+			return
+		}
+
+		return svc.installRules(tpl.Rules, ids)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer svc.eventbus.Dispatch(svc.ctx, event.NamespaceAfterCreate(ns, nil))
+	return
+}
+
+func (svc namespace) installModules(ns *types.Namespace, mm types.ModuleSet, ids types.IDMap) (err error) {
+	// Modules first, fields can reference any of them
+	for _, m := range mm {
+		old := m.ID
+		m.NamespaceID = ns.ID
+
+		if _, err = svc.moduleRepo.Create(m); err != nil {
+			return
+		}
+
+		ids[old] = m.ID
+	}
+
+	for _, m := range mm {
+		var (
+			oldFieldIDs = make([]uint64, len(m.Fields))
+		)
+
+		for i, f := range m.Fields {
+			oldFieldIDs[i] = f.ID
+			f.ID = 0
+			ids.Options(f.Options)
+		}
+
+		if err = svc.moduleRepo.UpdateFields(m.ID, m.Fields); err != nil {
+			return
+		}
+
+		for i, f := range m.Fields {
+			ids[oldFieldIDs[i]] = f.ID
+		}
+	}
+
+	return nil
+}
+
+func (svc namespace) installCharts(ns *types.Namespace, cc types.ChartSet, ids types.IDMap) (err error) {
+	for _, c := range cc {
+		old := c.ID
+		c.NamespaceID = ns.ID
+
+		for _, r := range c.Config.Reports {
+			r.ModuleID = ids.Get(r.ModuleID)
+		}
+
+		if _, err = svc.chartRepo.Create(c); err != nil {
+			return
+		}
+
+		ids[old] = c.ID
+	}
+
+	return nil
+}
+
+func (svc namespace) installPages(ns *types.Namespace, pp types.PageSet, ids types.IDMap) (err error) {
+	var (
+		remaining = pp
+		inTpl     = make(map[uint64]bool)
+		created   = make(map[uint64]bool)
+	)
+
+	for _, p := range pp {
+		inTpl[p.ID] = true
+	}
+
+	// Pages are created one tree level at the time so that
+	// parent page ID is known before children are created
+	for len(remaining) > 0 {
+		var level, next types.PageSet
+
+		for _, p := range remaining {
+			if p.SelfID == 0 || !inTpl[p.SelfID] || created[p.SelfID] {
+				level = append(level, p)
+			} else {
+				next = append(next, p)
+			}
+		}
+
+		if len(level) == 0 {
+			return errors.New("could not resolve page tree")
+		}
+
+		for _, p := range level {
+			old := p.ID
+			p.NamespaceID = ns.ID
+			p.ModuleID = ids.Get(p.ModuleID)
+
+			if !inTpl[p.SelfID] {
+				// Parent page is not part of the namespace
+				p.SelfID = 0
+			}
+
+			p.SelfID = ids.Get(p.SelfID)
+
+			if _, err = svc.pageRepo.Create(p); err != nil {
+				return
+			}
+
+			ids[old] = p.ID
+			created[old] = true
+		}
+
+		remaining = next
+	}
+
+	// Blocks can reference any of the pages
+	for _, p := range pp {
+		for _, b := range p.Blocks {
+			ids.Options(b.Options)
+		}
+
+		if _, err = svc.pageRepo.Update(p); err != nil {
+			return
+		}
+	}
+
+	return nil
+}
+
+func (svc namespace) installRecords(ns *types.Namespace, mm types.ModuleSet, rr types.RecordSet, ids types.IDMap) (err error) {
+	for _, r := range rr {
+		old := r.ID
+		r.NamespaceID = ns.ID
+		r.ModuleID = ids.Get(r.ModuleID)
+
+		if _, err = svc.recordRepo.Create(r); err != nil {
+			return
+		}
+
+		ids[old] = r.ID
+	}
+
+	// Values are stored when all records are created and
+	// references between records can be resolved
+	for _, r := range rr {
+		var (
+			m      = mm.FindByID(r.ModuleID)
+			places = make(map[string]uint)
+		)
+
+		if m == nil {
+			return errors.Errorf("could not find module for record %d", r.ID)
+		}
+
+		for _, v := range r.Values {
+			f := m.Fields.FindByName(v.Name)
+			if f == nil {
+				continue
+			}
+
+			if f.IsRef() {
+				// References to records and attachments from the template are rewritten,
+				// all other (users) are kept as they are
+				v.Ref, _ = strconv.ParseUint(v.Value, 10, 64)
+				v.Ref = ids.Get(v.Ref)
+				v.Value = strconv.FormatUint(v.Ref, 10)
+			}
+
+			v.Place = places[v.Name]
+			places[v.Name]++
+		}
+
+		if err = svc.recordRepo.UpdateValues(r.ID, r.Values); err != nil {
+			return
+		}
+	}
+
+	return nil
+}
+
+// installAttachments stores copies of attachment files and creates new attachments
+//
+// Stored files are not removed if installation fails.
+func (svc namespace) installAttachments(ns *types.Namespace, aa types.AttachmentSet, files types.NamespaceTemplateFiles, ids types.IDMap) (err error) {
+	var (
+		fh io.ReadSeeker
+	)
+
+	if len(aa) == 0 {
+		return nil
+	}
+
+	if files == nil || svc.store == nil {
+		return errors.New("can not install attachments: files or store not available")
+	}
+
+	for _, a := range aa {
+		var (
+			old = a.ID
+			src = *a
+		)
+
+		a.ID = factory.Sonyflake.NextID()
+		a.NamespaceID = ns.ID
+		a.Kind = types.RecordAttachment
+		a.Url = svc.store.Original(a.ID, a.Meta.Original.Extension)
+
+		if fh, err = files.Original(&src); err != nil {
+			return errors.Wrapf(err, "could not open attachment %d", old)
+		}
+
+		if err = svc.store.Save(a.Url, fh); err != nil {
+			return errors.Wrapf(err, "could not store attachment %d", old)
+		}
+
+		if a.PreviewUrl != "" && a.Meta.Preview != nil {
+			a.PreviewUrl = svc.store.Preview(a.ID, a.Meta.Preview.Extension)
+
+			if fh, err = files.Preview(&src); err != nil {
+				return errors.Wrapf(err, "could not open attachment %d preview", old)
+			}
+
+			if err = svc.store.Save(a.PreviewUrl, fh); err != nil {
+				return errors.Wrapf(err, "could not store attachment %d preview", old)
+			}
+		} else {
+			a.PreviewUrl = ""
+		}
+
+		if _, err = svc.attachmentRepo.Create(a); err != nil {
+			return
+		}
+
+		ids[old] = a.ID
+	}
+
+	return nil
+}
+
+// installRules copies access rules of all resources from the template
+//
+// Rules are only copied when user is allowed to manage them
+func (svc namespace) installRules(rr permissions.RuleSet, ids types.IDMap) (err error) {
+	var (
+		copies = permissions.RuleSet{}
+	)
+
+	if len(rr) == 0 || !svc.ac.CanGrant(svc.ctx) {
+		return nil
+	}
+
+	for _, r := range rr {
+		if res, ok := ids.Resource(r.Resource); ok {
+			copies = append(copies, &permissions.Rule{
+				RoleID:    r.RoleID,
+				Resource:  res,
+				Operation: r.Operation,
+				Access:    r.Access,
+			})
+		}
+	}
+
+	return svc.ac.Grant(svc.ctx, copies...)
+}
+
+// templateResources returns permission resources of all template resources
+func templateResources(tpl *types.NamespaceTemplate) (rr []permissions.Resource) {
+	rr = append(rr, tpl.Namespace.PermissionResource())
+
+	for _, m := range tpl.Modules {
+		rr = append(rr, m.PermissionResource())
+
+		for _, f := range m.Fields {
+			rr = append(rr, f.PermissionResource())
+		}
+	}
+
+	for _, p := range tpl.Pages {
+		rr = append(rr, p.PermissionResource())
+	}
+
+	for _, c := range tpl.Charts {
+		rr = append(rr, c.PermissionResource())
+	}
+
+	return
+}
+
+func (f namespaceTemplateStoreFiles) Original(a *types.Attachment) (io.ReadSeeker, error) {
+	return f.store.Open(a.Url)
+}
+
+func (f namespaceTemplateStoreFiles) Preview(a *types.Attachment) (io.ReadSeeker, error) {
+	return f.store.Open(a.PreviewUrl)
+}
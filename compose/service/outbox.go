@@ -0,0 +1,45 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cortezaproject/corteza-server/compose/service/event"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/outbox"
+)
+
+func registerOutboxDecoders() {
+	outbox.RegisterDecoder("compose:record", decodeRecordOutboxEvent)
+}
+
+// decodeRecordOutboxEvent restores record event from the outbox
+// so that webhook constraints can be matched against it
+func decodeRecordOutboxEvent(eventType string, args map[string][]byte) (outbox.Event, error) {
+	var (
+		r, old *types.Record
+		m      *types.Module
+		ns     *types.Namespace
+	)
+
+	for name, dst := range map[string]interface{}{"record": &r, "oldRecord": &old, "module": &m, "namespace": &ns} {
+		if len(args[name]) == 0 {
+			continue
+		}
+
+		if err := json.Unmarshal(args[name], dst); err != nil {
+			return nil, fmt.Errorf("could not decode %s: %v", name, err)
+		}
+	}
+
+	switch eventType {
+	case "afterCreate":
+		return event.RecordAfterCreateImmutable(r, old, m, ns, nil), nil
+	case "afterUpdate":
+		return event.RecordAfterUpdateImmutable(r, old, m, ns, nil), nil
+	case "afterDelete":
+		return event.RecordAfterDeleteImmutable(r, old, m, ns, nil), nil
+	}
+
+	return nil, fmt.Errorf("unsupported compose:record event %q", eventType)
+}
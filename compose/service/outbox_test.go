@@ -0,0 +1,44 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortezaproject/corteza-server/compose/service/event"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+)
+
+func TestDecodeRecordOutboxEvent(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		m   = &types.Module{ID: 1, Handle: "lead"}
+		ns  = &types.Namespace{ID: 2, Slug: "crm"}
+		old = &types.Record{ID: 3, ModuleID: 1, Values: types.RecordValueSet{{Name: "status", Value: "new"}}}
+		upd = &types.Record{ID: 3, ModuleID: 1, Values: types.RecordValueSet{{Name: "status", Value: "won"}}}
+
+		match = func(ev eventbus.Event, name, op string, values ...string) bool {
+			c, err := eventbus.ConstraintMaker(name, op, values...)
+			req.NoError(err)
+			return ev.Match(c)
+		}
+	)
+
+	args, err := event.RecordAfterUpdateImmutable(upd, old, m, ns, nil).Encode()
+	req.NoError(err)
+
+	ev, err := decodeRecordOutboxEvent("afterUpdate", args)
+	req.NoError(err)
+	req.Equal("compose:record", ev.ResourceType())
+	req.Equal("afterUpdate", ev.EventType())
+
+	req.True(match(ev, "module.handle", "eq", "lead"))
+	req.False(match(ev, "module.handle", "eq", "contact"))
+	req.True(match(ev, "namespace.slug", "eq", "crm"))
+	req.True(match(ev, "record.values.status", "eq", "won"))
+
+	_, err = decodeRecordOutboxEvent("beforeUpdate", args)
+	req.Error(err)
+}
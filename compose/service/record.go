@@ -21,6 +21,9 @@ import (
 	"github.com/cortezaproject/corteza-server/pkg/auth"
 	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 	"github.com/cortezaproject/corteza-server/pkg/logger"
+	"github.com/cortezaproject/corteza-server/pkg/permissions"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+	"github.com/cortezaproject/corteza-server/pkg/store"
 )
 
 const (
@@ -37,9 +40,15 @@ type (
 		ac       recordAccessController
 		eventbus eventDispatcher
 
-		recordRepo repository.RecordRepository
-		moduleRepo repository.ModuleRepository
-		nsRepo     repository.NamespaceRepository
+		recordRepo     repository.RecordRepository
+		moduleRepo     repository.ModuleRepository
+		nsRepo         repository.NamespaceRepository
+		transitionRepo repository.RecordTransitionRepository
+		attachmentRepo repository.AttachmentRepository
+		commentRepo    repository.RecordCommentRepository
+		changeRepo     repository.RecordChangeRepository
+
+		store store.Store
 
 		formatter recordValuesFormatter
 		sanitizer recordValuesSanitizer
@@ -58,6 +67,7 @@ type (
 
 	recordValuesValidator interface {
 		Run(*types.Module, *types.Record) *types.RecordValueErrorSet
+		Value(*types.RecordValue, *types.ModuleField, *types.Module) []types.RecordValueError
 		UniqueChecker(fn values.UniqueChecker)
 		RecordRefChecker(fn values.ReferenceChecker)
 		UserRefChecker(fn values.ReferenceChecker)
@@ -70,6 +80,7 @@ type (
 		CanReadRecord(context.Context, *types.Module) bool
 		CanUpdateRecord(context.Context, *types.Module) bool
 		CanDeleteRecord(context.Context, *types.Module) bool
+		FilterRecordDeletableModules(context.Context) *permissions.ResourceFilter
 		CanReadRecordValue(context.Context, *types.ModuleField) bool
 		CanUpdateRecordValue(context.Context, *types.ModuleField) bool
 	}
@@ -80,6 +91,7 @@ type (
 		FindByID(namespaceID, recordID uint64) (*types.Record, error)
 
 		Report(namespaceID, moduleID uint64, metrics, dimensions, filter string) (interface{}, error)
+		Aggregate(report *types.RecordReport) (*types.RecordReportResult, error)
 		Find(filter types.RecordFilter) (set types.RecordSet, f types.RecordFilter, err error)
 		Export(types.RecordFilter, Encoder) error
 		Import(*RecordImportSession, ImportSessionService) error
@@ -92,6 +104,21 @@ type (
 		Organize(namespaceID, moduleID, recordID uint64, sortingField, sortingValue, sortingFilter, valueField, value string) error
 
 		Iterator(f types.RecordFilter, fn eventbus.HandlerFn, action string) (err error)
+		IteratorChunk(f types.RecordFilter, fn eventbus.HandlerFn, action string, afterID, beforeID uint64, size uint) (lastID uint64, n, left uint, err error)
+
+		FindTransitions(namespaceID, moduleID, recordID uint64) (types.RecordTransitionSet, error)
+
+		FindDuplicates(namespaceID, moduleID, recordID uint64) (types.RecordDuplicateSet, error)
+		FindDuplicateClusters(namespaceID, moduleID uint64) ([]*types.RecordDuplicateCluster, error)
+		Merge(namespaceID, moduleID, targetID, sourceID uint64, fromSource ...string) (*types.Record, error)
+
+		FindDeleteImpact(namespaceID, moduleID uint64, recordIDs ...uint64) (types.RecordReferenceSet, error)
+
+		FindDeleted(filter types.RecordTrashFilter) (types.RecordSet, types.RecordTrashFilter, error)
+		Undelete(namespaceID, moduleID, recordID uint64) (*types.Record, error)
+		Purge(deletedBefore time.Time) (uint, error)
+
+		FindActivity(namespaceID, moduleID, recordID uint64) (types.RecordActivitySet, error)
 
 		EventEmitting(enable bool)
 	}
@@ -117,6 +144,13 @@ type (
 		ModuleID    uint64               `json:"moduleID,string"`
 		Fields      map[string]string    `json:"fields"`
 		Progress    RecordImportProgress `json:"progress"`
+
+		// Uploaded file and its format; used when import runs as a job
+		Source []byte `json:"-"`
+		Format string `json:"-"`
+
+		// Job that runs the import
+		JobID uint64 `json:"jobID,string,omitempty"`
 	}
 
 	RecordImportProgress struct {
@@ -134,6 +168,7 @@ func Record() RecordService {
 		logger:        DefaultLogger.Named("record"),
 		ac:            DefaultAccessControl,
 		eventbus:      eventbus.Service(),
+		store:         DefaultStore,
 		optEmitEvents: true,
 	}).With(context.Background())
 }
@@ -141,7 +176,34 @@ func Record() RecordService {
 func (svc record) With(ctx context.Context) RecordService {
 	db := repository.DB(ctx)
 
-	// Initialize validator and setup all checkers it needs
+	return &record{
+		db:     db,
+		ctx:    ctx,
+		logger: svc.logger,
+
+		ac:       svc.ac,
+		eventbus: svc.eventbus,
+
+		recordRepo:     repository.Record(ctx, db),
+		moduleRepo:     repository.Module(ctx, db),
+		nsRepo:         repository.Namespace(ctx, db),
+		transitionRepo: repository.RecordTransition(ctx, db),
+		attachmentRepo: repository.Attachment(ctx, db),
+		commentRepo:    repository.RecordComment(ctx, db),
+		changeRepo:     repository.RecordChange(ctx, db),
+
+		store: svc.store,
+
+		formatter: values.Formatter(),
+		sanitizer: values.Sanitizer(),
+		validator: recordValuesValidatorFor(ctx, db),
+
+		optEmitEvents: svc.optEmitEvents,
+	}
+}
+
+// recordValuesValidatorFor initializes validator and setups all checkers it needs
+func recordValuesValidatorFor(ctx context.Context, db *factory.DB) recordValuesValidator {
 	validator := values.Validator()
 
 	validator.UniqueChecker(func(v *types.RecordValue, f *types.ModuleField, m *types.Module) (uint64, error) {
@@ -175,24 +237,7 @@ func (svc record) With(ctx context.Context) RecordService {
 		return r != nil, err
 	})
 
-	return &record{
-		db:     db,
-		ctx:    ctx,
-		logger: svc.logger,
-
-		ac:       svc.ac,
-		eventbus: svc.eventbus,
-
-		recordRepo: repository.Record(ctx, db),
-		moduleRepo: repository.Module(ctx, db),
-		nsRepo:     repository.Namespace(ctx, db),
-
-		formatter: values.Formatter(),
-		sanitizer: values.Sanitizer(),
-		validator: validator,
-
-		optEmitEvents: svc.optEmitEvents,
-	}
+	return validator
 }
 
 func (svc *record) EventEmitting(enable bool) {
@@ -271,6 +316,46 @@ func (svc record) Report(namespaceID, moduleID uint64, metrics, dimensions, filt
 		Report(m, metrics, dimensions, filter)
 }
 
+// Aggregate runs typed report over module's records
+//
+// Modules referenced from report's field paths are loaded on demand; user must be
+// allowed to read their records and only readable fields can be used
+func (svc record) Aggregate(report *types.RecordReport) (res *types.RecordReportResult, err error) {
+	var m *types.Module
+	if m, err = svc.loadReportModule(report.NamespaceID, report.ModuleID); err != nil {
+		return
+	}
+
+	if err = report.Validate(); err != nil {
+		return
+	}
+
+	return svc.recordRepo.Aggregate(m, report, func(moduleID uint64) (*types.Module, error) {
+		return svc.loadReportModule(report.NamespaceID, moduleID)
+	})
+}
+
+// loadReportModule loads module with fields that current user can read
+func (svc record) loadReportModule(namespaceID, moduleID uint64) (m *types.Module, err error) {
+	if m, err = svc.loadModule(namespaceID, moduleID); err != nil {
+		return
+	}
+
+	if !svc.ac.CanReadRecord(svc.ctx, m) {
+		return nil, ErrNoReadPermissions.withStack()
+	}
+
+	var ff = make(types.ModuleFieldSet, 0, len(m.Fields))
+	for _, f := range m.Fields {
+		if svc.ac.CanReadRecordValue(svc.ctx, f) {
+			ff = append(ff, f)
+		}
+	}
+
+	m.Fields = ff
+	return
+}
+
 func (svc record) Find(filter types.RecordFilter) (set types.RecordSet, f types.RecordFilter, err error) {
 	var m *types.Module
 	if m, err = svc.loadModule(filter.NamespaceID, filter.ModuleID); err != nil {
@@ -304,6 +389,11 @@ func (svc record) Import(ses *RecordImportSession, ssvc ImportSessionService) er
 
 	return svc.db.Transaction(func() (err error) {
 		err = ses.Decoder.Records(ses.Fields, func(mod *types.Record) error {
+			if err := svc.ctx.Err(); err != nil {
+				// Import was cancelled
+				return err
+			}
+
 			mod.NamespaceID = ses.NamespaceID
 			mod.ModuleID = ses.ModuleID
 			mod.OwnedBy = ses.UserID
@@ -322,6 +412,8 @@ func (svc record) Import(ses *RecordImportSession, ssvc ImportSessionService) er
 			} else {
 				ses.Progress.Completed++
 			}
+
+			ssvc.SetRecordByID(svc.ctx, ses.SessionID, 0, 0, nil, &ses.Progress, nil)
 			return nil
 		})
 
@@ -397,6 +489,11 @@ func (svc record) Create(new *types.Record) (rec *types.Record, err error) {
 		// Assign defaults (only on missing values)
 		new.Values = svc.setDefaultValues(m, new.Values)
 
+		// Records start in initial state of the workflow
+		if rve = svc.setInitialStates(m, new); !rve.IsValid() {
+			return rve
+		}
+
 		// Handle payload from automation scripts
 		if rve = svc.procCreate(invokerID, m, new); !rve.IsValid() {
 			return rve
@@ -414,6 +511,10 @@ func (svc record) Create(new *types.Record) (rec *types.Record, err error) {
 		rec = new
 
 		if svc.optEmitEvents {
+			if err = DefaultOutbox.Record(svc.ctx, svc.db, event.RecordAfterCreateImmutable(new, nil, m, ns, nil)); err != nil {
+				return
+			}
+
 			defer func() {
 				new.Values = svc.formatter.Run(m, new.Values)
 				svc.eventbus.Dispatch(svc.ctx, event.RecordAfterCreateImmutable(new, nil, m, ns, nil))
@@ -522,11 +623,33 @@ func (svc record) Update(upd *types.Record) (rec *types.Record, err error) {
 			}
 		}
 
+		// Workflow transitions are checked before values are validated
+		var tt types.RecordTransitionSet
+		if tt, rve, err = svc.procTransitions(m, upd, old); err != nil {
+			return
+		} else if !rve.IsValid() {
+			return rve
+		}
+
+		if svc.optEmitEvents && len(tt) > 0 {
+			if err = svc.beforeTransitions(upd, old, m, ns, rve, tt); err != nil {
+				return
+			} else if !rve.IsValid() {
+				return rve
+			}
+		}
+
 		// Handle payload from automation scripts
 		if rve = svc.procUpdate(invokerID, m, upd, old); !rve.IsValid() {
 			return rve
 		}
 
+		// Raw (unformatted, all fields) values for change history
+		var stored types.RecordValueSet
+		if stored, err = svc.recordRepo.LoadValues(m.Fields.Names(), []uint64{upd.ID}); err != nil {
+			return
+		}
+
 		if upd, err = svc.recordRepo.Update(upd); err != nil {
 			return
 		}
@@ -535,6 +658,25 @@ func (svc record) Update(upd *types.Record) (rec *types.Record, err error) {
 			return
 		}
 
+		if err = svc.recordChanges(invokerID, upd, stored.Changes(upd.Values.GetClean(), m.Fields.Names()...)); err != nil {
+			return
+		}
+
+		if len(tt) > 0 {
+			// Guards are checked against stored values, failed guard rolls back the update
+			if rve, err = svc.checkTransitionGuards(m, tt); err != nil {
+				return
+			} else if !rve.IsValid() {
+				return rve
+			}
+
+			for _, t := range tt {
+				if _, err = svc.transitionRepo.Create(t); err != nil {
+					return
+				}
+			}
+		}
+
 		// Final value cleanup
 		// These (clean) values are returned (and sent to after-update handler)
 		upd.Values = upd.Values.GetClean()
@@ -543,10 +685,15 @@ func (svc record) Update(upd *types.Record) (rec *types.Record, err error) {
 		rec = upd
 
 		if svc.optEmitEvents {
+			if err = DefaultOutbox.Record(svc.ctx, svc.db, event.RecordAfterUpdateImmutable(upd, old, m, ns, nil)); err != nil {
+				return
+			}
+
 			defer func() {
 				// Before we pass values to automation scripts, they should be formatted
 				upd.Values = svc.formatter.Run(m, upd.Values)
 				svc.eventbus.Dispatch(svc.ctx, event.RecordAfterUpdateImmutable(upd, old, m, ns, nil))
+				svc.afterTransitions(upd, old, m, ns, tt)
 			}()
 		}
 		return
@@ -656,40 +803,17 @@ func (svc record) DeleteByID(namespaceID, moduleID uint64, recordIDs ...uint64)
 				return err
 			}
 
-			if svc.optEmitEvents {
-				// Preload old record values so we can send it together with event
-				if err = svc.preloadValues(m, del); err != nil {
+			if err = svc.beforeDelete(ns, m, del); err != nil {
+				if isBulkDelete {
+					// Not considered fatal,
+					// continue with next record
+					return nil
+				} else {
 					return err
 				}
-
-				// Calling before-record-delete scripts
-				if err = svc.eventbus.WaitFor(svc.ctx, event.RecordBeforeDelete(nil, del, m, ns, nil)); err != nil {
-					if isBulkDelete {
-						// Not considered fatal,
-						// continue with next record
-						return nil
-					} else {
-						return err
-					}
-				}
-			}
-
-			del.DeletedAt = &now
-			del.DeletedBy = auth.GetIdentityFromContext(svc.ctx).Identity()
-
-			if err = svc.recordRepo.Delete(del); err != nil {
-				return err
-			}
-
-			if err = svc.recordRepo.DeleteValues(del); err != nil {
-				return err
-			}
-
-			if svc.optEmitEvents {
-				defer svc.eventbus.Dispatch(svc.ctx, event.RecordAfterDeleteImmutable(nil, del, m, ns, nil))
 			}
 
-			return err
+			return svc.delete(ns, m, del, now)
 		})
 
 		if err != nil {
@@ -700,6 +824,53 @@ func (svc record) DeleteByID(namespaceID, moduleID uint64, recordIDs ...uint64)
 	return nil
 }
 
+// beforeDelete emits beforeDelete event for the record
+//
+// Returned error means that the deletion was aborted
+func (svc record) beforeDelete(ns *types.Namespace, m *types.Module, del *types.Record) (err error) {
+	if !svc.optEmitEvents {
+		return nil
+	}
+
+	// Preload old record values so we can send it together with event
+	if err = svc.preloadValues(m, del); err != nil {
+		return err
+	}
+
+	// Calling before-record-delete scripts
+	return svc.eventbus.WaitFor(svc.ctx, event.RecordBeforeDelete(nil, del, m, ns, nil))
+}
+
+// delete removes the record and applies on-delete policies of references that point to it
+//
+// Expected to be called inside delete transaction, after beforeDelete
+func (svc record) delete(ns *types.Namespace, m *types.Module, del *types.Record, now time.Time) (err error) {
+	del.DeletedAt = &now
+	del.DeletedBy = auth.GetIdentityFromContext(svc.ctx).Identity()
+
+	if err = svc.recordRepo.Delete(del); err != nil {
+		return err
+	}
+
+	if err = svc.recordRepo.DeleteValues(del); err != nil {
+		return err
+	}
+
+	if err = svc.procRefsOnDelete(ns, m, del, now); err != nil {
+		return err
+	}
+
+	if svc.optEmitEvents {
+		if err = DefaultOutbox.Record(svc.ctx, svc.db, event.RecordAfterDeleteImmutable(nil, del, m, ns, nil)); err != nil {
+			return err
+		}
+
+		defer svc.eventbus.Dispatch(svc.ctx, event.RecordAfterDeleteImmutable(nil, del, m, ns, nil))
+	}
+
+	return err
+}
+
 // Organize - Record organizer
 //
 // Reorders records & sets field value
@@ -890,8 +1061,6 @@ func (svc record) Organize(namespaceID, moduleID, recordID uint64, posField, pos
 // }
 func (svc record) Iterator(f types.RecordFilter, fn eventbus.HandlerFn, action string) (err error) {
 	var (
-		invokerID = auth.GetIdentityFromContext(svc.ctx).Identity()
-
 		ns  *types.Namespace
 		m   *types.Module
 		set types.RecordSet
@@ -906,65 +1075,111 @@ func (svc record) Iterator(f types.RecordFilter, fn eventbus.HandlerFn, action s
 			return ErrNoUpdatePermissions.withStack()
 		}
 
-		// @todo might be good to split set into smaller chunks
 		set, f, err = svc.recordRepo.Find(m, f)
 		if err != nil {
 			return
 		}
 
-		if err = svc.preloadValues(m, set...); err != nil {
+		return svc.iterate(ns, m, set, fn, action)
+	})
+}
+
+// IteratorChunk iterates over one chunk of records (ordered by ID) that come after the given record
+//
+// Records with ID greater or equal to beforeID (when set) are skipped;
+// this prevents clone iterator from iterating over its own clones.
+//
+// Returns ID of the last record in the chunk, number of records in the chunk
+// and number of records left after the chunk.
+// Each chunk runs in its own transaction; sort and paging from the filter are ignored.
+func (svc record) IteratorChunk(f types.RecordFilter, fn eventbus.HandlerFn, action string, afterID, beforeID uint64, size uint) (lastID uint64, n, left uint, err error) {
+	var (
+		ns  *types.Namespace
+		m   *types.Module
+		set types.RecordSet
+	)
+
+	f.AfterID, f.BeforeID, f.Sort, f.PageFilter = afterID, beforeID, "id", rh.Limit(size)
+
+	return lastID, n, left, svc.db.Transaction(func() (err error) {
+		if ns, m, _, err = svc.loadCombo(f.NamespaceID, f.ModuleID, 0); err != nil {
 			return
 		}
 
-		for _, rec := range set {
-			if err = fn(svc.ctx, event.RecordOnIteration(rec, nil, m, ns, nil)); err != nil {
-				if err.Error() != "Aborted" {
-					// When script was  softly aborted (return false),
-					// proceed with iteration but do not clone, update or delete
-					// current record!
-					return
-				}
+		if !svc.ac.CanUpdateRecord(svc.ctx, m) {
+			return ErrNoUpdatePermissions.withStack()
+		}
+
+		if set, f, err = svc.recordRepo.Find(m, f); err != nil || len(set) == 0 {
+			return
+		}
+
+		// Position is taken before the iteration;
+		// clone action assigns new IDs to the records in the set
+		lastID, n, left = set[len(set)-1].ID, uint(len(set)), f.Count-uint(len(set))
+
+		return svc.iterate(ns, m, set, fn, action)
+	})
+}
+
+// Calls iteration handler and runs action for each of the records
+func (svc record) iterate(ns *types.Namespace, m *types.Module, set types.RecordSet, fn eventbus.HandlerFn, action string) (err error) {
+	var (
+		invokerID = auth.GetIdentityFromContext(svc.ctx).Identity()
+	)
+
+	if err = svc.preloadValues(m, set...); err != nil {
+		return
+	}
+
+	for _, rec := range set {
+		if err = fn(svc.ctx, event.RecordOnIteration(rec, nil, m, ns, nil)); err != nil {
+			if err.Error() != "Aborted" {
+				// When script was  softly aborted (return false),
+				// proceed with iteration but do not clone, update or delete
+				// current record!
+				return
 			}
+		}
 
-			switch action {
-			case "clone":
-				var cln *types.Record
+		switch action {
+		case "clone":
+			var cln *types.Record
 
-				// Assign defaults (only on missing values)
-				rec.Values = svc.setDefaultValues(m, rec.Values)
+			// Assign defaults (only on missing values)
+			rec.Values = svc.setDefaultValues(m, rec.Values)
 
-				// Handle payload from automation scripts
-				if rve := svc.procCreate(invokerID, m, rec); !rve.IsValid() {
-					return rve
-				}
+			// Handle payload from automation scripts
+			if rve := svc.procCreate(invokerID, m, rec); !rve.IsValid() {
+				return rve
+			}
 
-				if cln, err = svc.recordRepo.Create(rec); err != nil {
-					return
-				} else if err = svc.recordRepo.UpdateValues(cln.ID, cln.Values); err != nil {
-					return
-				}
-			case "update":
-				// Handle input payload
-				if rve := svc.procUpdate(invokerID, m, rec, rec); !rve.IsValid() {
-					return rve
-				}
+			if cln, err = svc.recordRepo.Create(rec); err != nil {
+				return
+			} else if err = svc.recordRepo.UpdateValues(cln.ID, cln.Values); err != nil {
+				return
+			}
+		case "update":
+			// Handle input payload
+			if rve := svc.procUpdate(invokerID, m, rec, rec); !rve.IsValid() {
+				return rve
+			}
 
-				if rec, err = svc.recordRepo.Update(rec); err != nil {
-					return
-				} else if err = svc.recordRepo.UpdateValues(rec.ID, rec.Values); err != nil {
-					return
-				}
-			case "delete":
-				if err = svc.recordRepo.Delete(rec); err != nil {
-					return err
-				} else if err = svc.recordRepo.DeleteValues(rec); err != nil {
-					return err
-				}
+			if rec, err = svc.recordRepo.Update(rec); err != nil {
+				return
+			} else if err = svc.recordRepo.UpdateValues(rec.ID, rec.Values); err != nil {
+				return
+			}
+		case "delete":
+			if err = svc.recordRepo.Delete(rec); err != nil {
+				return err
+			} else if err = svc.recordRepo.DeleteValues(rec); err != nil {
+				return err
 			}
 		}
+	}
 
-		return
-	})
+	return
 }
 
 // loadCombo Loads everything we need for record manipulation
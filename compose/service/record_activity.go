@@ -0,0 +1,91 @@
+package service
+
+import (
+	"github.com/cortezaproject/corteza-server/compose/types"
+)
+
+// FindActivity returns record's activity feed: comments, value changes and workflow transitions
+//
+// Changes of fields that user can not read are omitted
+func (svc record) FindActivity(namespaceID, moduleID, recordID uint64) (aa types.RecordActivitySet, err error) {
+	var (
+		m  *types.Module
+		cc types.RecordCommentSet
+		ch types.RecordChangeSet
+		tt types.RecordTransitionSet
+
+		rec *types.Record
+	)
+
+	if rec, err = svc.FindByID(namespaceID, recordID); err != nil {
+		return
+	}
+
+	if rec.ModuleID != moduleID {
+		return nil, ErrInvalidModuleID.withStack()
+	}
+
+	if m, err = svc.loadModule(namespaceID, moduleID); err != nil {
+		return
+	}
+
+	cc, _, err = svc.commentRepo.Find(types.RecordCommentFilter{
+		NamespaceID: namespaceID,
+		ModuleID:    moduleID,
+		RecordID:    recordID,
+	})
+
+	if err != nil {
+		return
+	}
+
+	ch, _, err = svc.changeRepo.Find(types.RecordChangeFilter{
+		NamespaceID: namespaceID,
+		ModuleID:    moduleID,
+		RecordID:    recordID,
+	})
+
+	if err != nil {
+		return
+	}
+
+	tt, _, err = svc.transitionRepo.Find(types.RecordTransitionFilter{
+		NamespaceID: namespaceID,
+		ModuleID:    moduleID,
+		RecordID:    recordID,
+	})
+
+	if err != nil {
+		return
+	}
+
+	var (
+		readable = svc.readableFields(m)
+		visible  = types.RecordChangeSet{}
+	)
+
+	for _, c := range ch {
+		if c.Fields = c.Fields.FilterByField(readable...); len(c.Fields) > 0 {
+			visible = append(visible, c)
+		}
+	}
+
+	return types.MakeRecordActivity(cc, visible, tt), nil
+}
+
+// recordChanges stores changed field values of the updated record
+func (svc record) recordChanges(invokerID uint64, rec *types.Record, ff types.RecordFieldChangeSet) (err error) {
+	if len(ff) == 0 {
+		return
+	}
+
+	_, err = svc.changeRepo.Create(&types.RecordChange{
+		NamespaceID: rec.NamespaceID,
+		ModuleID:    rec.ModuleID,
+		RecordID:    rec.ID,
+		Fields:      ff,
+		CreatedBy:   invokerID,
+	})
+
+	return
+}
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/titpetric/factory"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/cortezaproject/corteza-server/compose/repository"
+	"github.com/cortezaproject/corteza-server/compose/service/event"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/pkg/logger"
+	systemTypes "github.com/cortezaproject/corteza-server/system/types"
+)
+
+type (
+	recordComment struct {
+		db     *factory.DB
+		ctx    context.Context
+		logger *zap.Logger
+
+		ac       recordCommentAccessController
+		eventbus eventDispatcher
+		users    recordCommentUserFinder
+		record   RecordService
+
+		commentRepo    repository.RecordCommentRepository
+		moduleRepo     repository.ModuleRepository
+		nsRepo         repository.NamespaceRepository
+		attachmentRepo repository.AttachmentRepository
+	}
+
+	recordCommentAccessController interface {
+		CanReadNamespace(context.Context, *types.Namespace) bool
+		CanReadModule(context.Context, *types.Module) bool
+	}
+
+	recordCommentUserFinder interface {
+		FindByID(context.Context, uint64) (*systemTypes.User, error)
+	}
+
+	RecordCommentService interface {
+		With(ctx context.Context) RecordCommentService
+
+		Find(filter types.RecordCommentFilter) (types.RecordCommentSet, types.RecordCommentFilter, error)
+
+		Create(c *types.RecordComment) (*types.RecordComment, error)
+		Update(c *types.RecordComment) (*types.RecordComment, error)
+		DeleteByID(namespaceID, moduleID, recordID, commentID uint64) error
+	}
+)
+
+var (
+	// Mentions are encoded in the same way as in messaging: <@userID optional label>
+	recordCommentMentionRE = regexp.MustCompile(`<@(\d+)(?:\s[^>]*)?>`)
+)
+
+func RecordComment() RecordCommentService {
+	return (&recordComment{
+		logger:   DefaultLogger.Named("record-comment"),
+		ac:       DefaultAccessControl,
+		eventbus: eventbus.Service(),
+		users:    DefaultSystemUser,
+		record:   DefaultRecord,
+	}).With(context.Background())
+}
+
+func (svc recordComment) With(ctx context.Context) RecordCommentService {
+	db := repository.DB(ctx)
+
+	return &recordComment{
+		db:     db,
+		ctx:    ctx,
+		logger: svc.logger,
+
+		ac:       svc.ac,
+		eventbus: svc.eventbus,
+		users:    svc.users,
+		record:   svc.record.With(ctx),
+
+		commentRepo:    repository.RecordComment(ctx, db),
+		moduleRepo:     repository.Module(ctx, db),
+		nsRepo:         repository.Namespace(ctx, db),
+		attachmentRepo: repository.Attachment(ctx, db),
+	}
+}
+
+// log() returns zap's logger with requestID from current context and fields.
+func (svc recordComment) log(ctx context.Context, fields ...zapcore.Field) *zap.Logger {
+	return logger.AddRequestID(ctx, svc.logger).With(fields...)
+}
+
+// Find lists comments of a record
+func (svc recordComment) Find(filter types.RecordCommentFilter) (set types.RecordCommentSet, f types.RecordCommentFilter, err error) {
+	if _, _, _, err = svc.loadCombo(filter.NamespaceID, filter.ModuleID, filter.RecordID); err != nil {
+		return
+	}
+
+	return svc.commentRepo.Find(filter)
+}
+
+// Create adds a new comment to the record
+//
+// Mentioned users are extracted from the message and resolved; unknown users are ignored
+func (svc recordComment) Create(new *types.RecordComment) (c *types.RecordComment, err error) {
+	var invokerID = auth.GetIdentityFromContext(svc.ctx).Identity()
+
+	return c, svc.db.Transaction(func() (err error) {
+		ns, m, r, err := svc.loadCombo(new.NamespaceID, new.ModuleID, new.RecordID)
+		if err != nil {
+			return
+		}
+
+		if err = svc.validate(new); err != nil {
+			return
+		}
+
+		if new.ReplyTo > 0 {
+			var parent *types.RecordComment
+			if parent, err = svc.commentRepo.FindByID(new.NamespaceID, new.ReplyTo); err != nil {
+				return
+			}
+
+			if parent.RecordID != new.RecordID {
+				return ErrInvalidID.withStack()
+			}
+		}
+
+		new.ID = 0
+		new.CreatedBy = invokerID
+		new.Mentions = svc.resolveMentions(new.Message)
+
+		before := event.RecordBeforeComment(r, nil, m, ns, nil)
+		before.SetComment(new)
+		if err = svc.eventbus.WaitFor(svc.ctx, before); err != nil {
+			return
+		}
+
+		if c, err = svc.commentRepo.Create(new); err != nil {
+			return
+		}
+
+		after := event.RecordAfterCommentImmutable(r, nil, m, ns, nil)
+		after.SetComment(c)
+		defer svc.eventbus.Dispatch(svc.ctx, after)
+		return
+	})
+}
+
+// Update changes message of an existing comment
+//
+// Only author can update the comment
+func (svc recordComment) Update(upd *types.RecordComment) (c *types.RecordComment, err error) {
+	return c, svc.db.Transaction(func() (err error) {
+		if c, err = svc.loadComment(upd.NamespaceID, upd.ModuleID, upd.RecordID, upd.ID); err != nil {
+			return
+		}
+
+		if !svc.isAuthor(c) {
+			return ErrNoUpdatePermissions.withStack()
+		}
+
+		c.Message = upd.Message
+		if err = svc.validate(c); err != nil {
+			return
+		}
+
+		c.Mentions = svc.resolveMentions(c.Message)
+
+		c, err = svc.commentRepo.Update(c)
+		return
+	})
+}
+
+// DeleteByID removes the comment
+//
+// Only author can delete the comment, replies are kept
+func (svc recordComment) DeleteByID(namespaceID, moduleID, recordID, commentID uint64) error {
+	return svc.db.Transaction(func() (err error) {
+		var c *types.RecordComment
+		if c, err = svc.loadComment(namespaceID, moduleID, recordID, commentID); err != nil {
+			return
+		}
+
+		if !svc.isAuthor(c) {
+			return ErrNoDeletePermissions.withStack()
+		}
+
+		return svc.commentRepo.DeleteByID(namespaceID, commentID)
+	})
+}
+
+// loadCombo loads namespace, module and record (with values) and checks if user can read the record
+func (svc recordComment) loadCombo(namespaceID, moduleID, recordID uint64) (ns *types.Namespace, m *types.Module, r *types.Record, err error) {
+	if namespaceID == 0 {
+		err = ErrNamespaceRequired.withStack()
+		return
+	}
+
+	if ns, err = svc.nsRepo.FindByID(namespaceID); err != nil {
+		return
+	}
+
+	if !svc.ac.CanReadNamespace(svc.ctx, ns) {
+		err = ErrNoReadPermissions.withStack()
+		return
+	}
+
+	if m, err = svc.moduleRepo.FindByID(namespaceID, moduleID); err != nil {
+		return
+	}
+
+	if !svc.ac.CanReadModule(svc.ctx, m) {
+		err = ErrNoReadPermissions.withStack()
+		return
+	}
+
+	if r, err = svc.record.FindByID(namespaceID, recordID); err != nil {
+		return
+	}
+
+	if r.ModuleID != m.ID {
+		err = ErrInvalidModuleID.withStack()
+		return
+	}
+
+	return
+}
+
+func (svc recordComment) loadComment(namespaceID, moduleID, recordID, commentID uint64) (c *types.RecordComment, err error) {
+	if commentID == 0 {
+		return nil, ErrInvalidID.withStack()
+	}
+
+	if _, _, _, err = svc.loadCombo(namespaceID, moduleID, recordID); err != nil {
+		return
+	}
+
+	if c, err = svc.commentRepo.FindByID(namespaceID, commentID); err != nil {
+		return
+	}
+
+	if c.RecordID != recordID {
+		return nil, ErrInvalidID.withStack()
+	}
+
+	return
+}
+
+// validate checks comment's message and attachments
+//
+// Attachments must be record attachments from the same namespace
+func (svc recordComment) validate(c *types.RecordComment) error {
+	if strings.TrimSpace(c.Message) == "" && len(c.Attachments) == 0 {
+		return ErrRecordCommentEmpty.withStack()
+	}
+
+	for _, attachmentID := range c.Attachments {
+		a, err := svc.attachmentRepo.FindByID(c.NamespaceID, attachmentID)
+		if err != nil {
+			return err
+		}
+
+		if a.Kind != types.RecordAttachment {
+			return ErrInvalidID.withStack()
+		}
+	}
+
+	return nil
+}
+
+func (svc recordComment) isAuthor(c *types.RecordComment) bool {
+	i := auth.GetIdentityFromContext(svc.ctx)
+	return auth.IsSuperUser(i) || c.CreatedBy == i.Identity()
+}
+
+// resolveMentions returns IDs of existing users that are mentioned in the message
+func (svc recordComment) resolveMentions(message string) (mentions types.RecordCommentRefs) {
+	mentions = types.RecordCommentRefs{}
+
+	for _, userID := range extractMentions(message) {
+		if _, err := svc.users.FindByID(svc.ctx, userID); err != nil {
+			svc.log(svc.ctx, zap.Uint64("userID", userID)).Debug("could not resolve mentioned user", zap.Error(err))
+			continue
+		}
+
+		mentions = append(mentions, userID)
+	}
+
+	return
+}
+
+// extractMentions returns unique IDs of users mentioned in the message
+func extractMentions(message string) (IDs []uint64) {
+	var seen = map[uint64]bool{}
+
+	for _, match := range recordCommentMentionRE.FindAllStringSubmatch(message, -1) {
+		ID, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil || ID == 0 || seen[ID] {
+			continue
+		}
+
+		seen[ID] = true
+		IDs = append(IDs, ID)
+	}
+
+	return
+}
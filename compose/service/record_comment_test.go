@@ -0,0 +1,28 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractMentions(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		tcc = []struct {
+			message  string
+			mentions []uint64
+		}{
+			{"no mentions", nil},
+			{"hey <@42>", []uint64{42}},
+			{"<@42 John Doe> and <@43 Jane>", []uint64{42, 43}},
+			{"<@42> <@42 again>", []uint64{42}},
+			{"<#42 channel> <@0> <@abc>", nil},
+		}
+	)
+
+	for _, tc := range tcc {
+		req.Equal(tc.mentions, extractMentions(tc.message), tc.message)
+	}
+}
@@ -0,0 +1,353 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+)
+
+// FindDuplicates returns likely duplicates of a record
+//
+// Records are compared by duplicate rules configured on the module;
+// fields with values user can not read are not listed as matching
+func (svc record) FindDuplicates(namespaceID, moduleID, recordID uint64) (dd types.RecordDuplicateSet, err error) {
+	var (
+		m *types.Module
+		r *types.Record
+	)
+
+	if _, m, r, err = svc.loadCombo(namespaceID, moduleID, recordID); err != nil {
+		return
+	}
+
+	if !svc.ac.CanReadRecord(svc.ctx, m) {
+		return nil, ErrNoReadPermissions.withStack()
+	}
+
+	if r == nil {
+		return nil, ErrInvalidID.withStack()
+	}
+
+	if err = svc.preloadValues(m, r); err != nil {
+		return
+	}
+
+	return svc.findDuplicates(m, r)
+}
+
+// findDuplicates compares record values against other records in the module
+//
+// Only records with values that might share a blocking key (candidates) are loaded
+func (svc record) findDuplicates(m *types.Module, r *types.Record) (dd types.RecordDuplicateSet, err error) {
+	var (
+		dr   = m.DuplicateRules()
+		rvs  types.RecordValueSet
+		keys = map[string]bool{}
+	)
+
+	if dr == nil {
+		return nil, nil
+	}
+
+	if rvs, err = svc.recordRepo.LoadDuplicateCandidates(m.ID, r.ID, dr.Fields(), dr.Patterns(r.Values)); err != nil {
+		return
+	}
+
+	for _, k := range dr.Keys(r.Values) {
+		keys[k] = true
+	}
+
+	for recordID, vv := range groupValuesByRecord(rvs) {
+		if recordID == r.ID || !hasAnyKey(keys, dr.Keys(vv)) {
+			continue
+		}
+
+		if score, fields := dr.Compare(r.Values, vv); score >= dr.Threshold {
+			dd = append(dd, &types.RecordDuplicate{RecordID: recordID, Score: score, Fields: svc.readableDuplicateFields(m, fields)})
+		}
+	}
+
+	sortDuplicates(dd)
+	return
+}
+
+// FindDuplicateClusters groups all records of the module that are likely duplicates of each other
+func (svc record) FindDuplicateClusters(namespaceID, moduleID uint64) (cc []*types.RecordDuplicateCluster, err error) {
+	var (
+		m   *types.Module
+		rvs types.RecordValueSet
+	)
+
+	if _, m, _, err = svc.loadCombo(namespaceID, moduleID, 0); err != nil {
+		return
+	}
+
+	if !svc.ac.CanReadRecord(svc.ctx, m) {
+		return nil, ErrNoReadPermissions.withStack()
+	}
+
+	dr := m.DuplicateRules()
+	if dr == nil {
+		return nil, nil
+	}
+
+	if rvs, err = svc.recordRepo.LoadModuleValues(m.ID, dr.Fields()...); err != nil {
+		return
+	}
+
+	var (
+		values = groupValuesByRecord(rvs)
+
+		// records that share the blocking key
+		blocks = map[string][]uint64{}
+
+		// best score of each of the records in a cluster
+		scores = map[uint64]*types.RecordDuplicate{}
+
+		// (union-find) parent of each record
+		parent = map[uint64]uint64{}
+
+		compared = map[[2]uint64]bool{}
+	)
+
+	var find func(uint64) uint64
+	find = func(ID uint64) uint64 {
+		if p, ok := parent[ID]; ok && p != ID {
+			parent[ID] = find(p)
+			return parent[ID]
+		}
+
+		return ID
+	}
+
+	for _, recordID := range sortedRecordIDs(values) {
+		for _, k := range dr.Keys(values[recordID]) {
+			blocks[k] = append(blocks[k], recordID)
+		}
+	}
+
+	for _, block := range blocks {
+		for i := range block {
+			for j := i + 1; j < len(block); j++ {
+				a, b := block[i], block[j]
+				if a == b || compared[[2]uint64{a, b}] {
+					continue
+				}
+
+				compared[[2]uint64{a, b}] = true
+
+				score, fields := dr.Compare(values[a], values[b])
+				if score < dr.Threshold {
+					continue
+				}
+
+				for _, ID := range []uint64{a, b} {
+					if scores[ID] == nil || scores[ID].Score < score {
+						scores[ID] = &types.RecordDuplicate{RecordID: ID, Score: score, Fields: svc.readableDuplicateFields(m, fields)}
+					}
+				}
+
+				parent[find(b)] = find(a)
+			}
+		}
+	}
+
+	var (
+		clusters = map[uint64]*types.RecordDuplicateCluster{}
+	)
+
+	for _, recordID := range sortedRecordIDs(values) {
+		d := scores[recordID]
+		if d == nil {
+			continue
+		}
+
+		root := find(recordID)
+		if clusters[root] == nil {
+			clusters[root] = &types.RecordDuplicateCluster{}
+			cc = append(cc, clusters[root])
+		}
+
+		clusters[root].Records = append(clusters[root].Records, d)
+		if d.Score > clusters[root].Score {
+			clusters[root].Score = d.Score
+		}
+	}
+
+	for _, c := range cc {
+		sortDuplicates(c.Records)
+	}
+
+	sort.SliceStable(cc, func(i, j int) bool {
+		return cc[i].Score > cc[j].Score
+	})
+
+	return
+}
+
+// Merge combines source record into the target record
+//
+// Target record values are kept, empty fields are filled with source's values.
+// Values of fields listed in fromSource are always taken from the source record.
+//
+// References to source record from other modules are changed to point to the target
+// and source record is deleted.
+func (svc record) Merge(namespaceID, moduleID, targetID, sourceID uint64, fromSource ...string) (rec *types.Record, err error) {
+	if targetID == 0 || sourceID == 0 || targetID == sourceID {
+		return nil, ErrInvalidID.withStack()
+	}
+
+	return rec, svc.db.Transaction(func() (err error) {
+		var (
+			m              *types.Module
+			target, source *types.Record
+		)
+
+		if _, m, target, err = svc.loadCombo(namespaceID, moduleID, targetID); err != nil {
+			return
+		}
+
+		if _, _, source, err = svc.loadCombo(namespaceID, moduleID, sourceID); err != nil {
+			return
+		}
+
+		if !svc.ac.CanUpdateRecord(svc.ctx, m) {
+			return ErrNoUpdatePermissions.withStack()
+		}
+
+		if !svc.ac.CanDeleteRecord(svc.ctx, m) {
+			return ErrNoDeletePermissions.withStack()
+		}
+
+		if err = svc.preloadValues(m, target, source); err != nil {
+			return
+		}
+
+		merged := &types.Record{
+			ID:          target.ID,
+			ModuleID:    target.ModuleID,
+			NamespaceID: target.NamespaceID,
+			OwnedBy:     target.OwnedBy,
+			Values:      mergeRecordValues(m, target.Values, source.Values, fromSource...),
+		}
+
+		if rec, err = svc.Update(merged); err != nil {
+			return
+		}
+
+		if err = svc.repointRefs(m, source.ID, target.ID); err != nil {
+			return
+		}
+
+		return svc.DeleteByID(namespaceID, moduleID, source.ID)
+	})
+}
+
+// repointRefs changes all record-reference values in the namespace from one record to another
+//
+// User needs permission to update records of all modules with references to the record
+func (svc record) repointRefs(m *types.Module, from, to uint64) error {
+	mm, ff, err := svc.refFields(m)
+	if err != nil {
+		return err
+	}
+
+	for _, refModule := range mm {
+		var (
+			names = ff.FilterByModule(refModule.ID).Names()
+			refs  types.RecordValueSet
+		)
+
+		if refs, err = svc.recordRepo.FindRefs(refModule.ID, names, from); err != nil {
+			return err
+		}
+
+		if len(refs) > 0 && !svc.ac.CanUpdateRecord(svc.ctx, refModule) {
+			return ErrNoUpdatePermissions.withStack()
+		}
+
+		if err = svc.recordRepo.RepointRefs(refModule.ID, names, from, to); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mergeRecordValues combines values of two records field by field
+func mergeRecordValues(m *types.Module, target, source types.RecordValueSet, fromSource ...string) (out types.RecordValueSet) {
+	var (
+		useSource = map[string]bool{}
+	)
+
+	for _, name := range fromSource {
+		useSource[name] = true
+	}
+
+	for _, f := range m.Fields {
+		var (
+			tvv = target.FilterByName(f.Name)
+			svv = source.FilterByName(f.Name)
+		)
+
+		if useSource[f.Name] || len(tvv) == 0 {
+			tvv = svv
+		}
+
+		for _, v := range tvv {
+			out = append(out, &types.RecordValue{Name: v.Name, Value: v.Value, Ref: v.Ref, Place: v.Place})
+		}
+	}
+
+	return
+}
+
+// readableDuplicateFields returns names of matching fields with values user can read
+func (svc record) readableDuplicateFields(m *types.Module, names []string) (out []string) {
+	for _, name := range names {
+		if f := m.Fields.FindByName(name); f != nil && svc.ac.CanReadRecordValue(svc.ctx, f) {
+			out = append(out, name)
+		}
+	}
+
+	return
+}
+
+func groupValuesByRecord(rvs types.RecordValueSet) map[uint64]types.RecordValueSet {
+	out := map[uint64]types.RecordValueSet{}
+	for _, v := range rvs {
+		out[v.RecordID] = append(out[v.RecordID], v)
+	}
+
+	return out
+}
+
+func sortedRecordIDs(values map[uint64]types.RecordValueSet) []uint64 {
+	IDs := make([]uint64, 0, len(values))
+	for ID := range values {
+		IDs = append(IDs, ID)
+	}
+
+	sort.Slice(IDs, func(i, j int) bool { return IDs[i] < IDs[j] })
+	return IDs
+}
+
+func hasAnyKey(keys map[string]bool, kk []string) bool {
+	for _, k := range kk {
+		if keys[k] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortDuplicates(dd types.RecordDuplicateSet) {
+	sort.SliceStable(dd, func(i, j int) bool {
+		if dd[i].Score == dd[j].Score {
+			return dd[i].RecordID < dd[j].RecordID
+		}
+
+		return dd[i].Score > dd[j].Score
+	})
+}
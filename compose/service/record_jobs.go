@@ -0,0 +1,209 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/compose/decoder"
+	"github.com/cortezaproject/corteza-server/compose/encoder"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/jobs"
+	"github.com/cortezaproject/corteza-server/pkg/sentry"
+)
+
+const (
+	JobService      = "compose"
+	JobRecordImport = "record.import"
+	JobRecordExport = "record.export"
+)
+
+type (
+	// RecordEncoder is record encoder that needs to be flushed at the end
+	RecordEncoder interface {
+		Encoder
+		Flush()
+	}
+
+	RecordImportJobParams struct {
+		NamespaceID uint64            `json:"namespaceID,string"`
+		ModuleID    uint64            `json:"moduleID,string"`
+		Format      string            `json:"format"`
+		Fields      map[string]string `json:"fields"`
+		OnError     string            `json:"onError"`
+	}
+
+	RecordExportJobParams struct {
+		NamespaceID uint64   `json:"namespaceID,string"`
+		ModuleID    uint64   `json:"moduleID,string"`
+		Filter      string   `json:"filter"`
+		Fields      []string `json:"fields"`
+		Filename    string   `json:"filename"`
+		Ext         string   `json:"ext"`
+	}
+
+	// Reports import progress to the job
+	jobImportSession struct {
+		r jobs.Reporter
+	}
+
+	// Counts exported records and stops export when job is cancelled
+	jobExportEncoder struct {
+		RecordEncoder
+		ctx   context.Context
+		r     jobs.Reporter
+		count uint
+	}
+)
+
+// RegisterJobWorkers registers workers for record import & export jobs
+func RegisterJobWorkers() {
+	jobs.Service().Register(JobService, JobRecordImport, recordImportWorker)
+	jobs.Service().Register(JobService, JobRecordExport, recordExportWorker)
+}
+
+// NewRecordDecoder returns record decoder for the given format (json, jsonl, csv)
+func NewRecordDecoder(format string, f io.ReadSeeker) (Decoder, error) {
+	switch strings.ToLower(format) {
+	case "json", "jsonl", "ldjson", "ndjson":
+		return decoder.NewStructuredDecoder(json.NewDecoder(f), f), nil
+
+	case "csv":
+		return decoder.NewFlatReader(csv.NewReader(f), f), nil
+
+	default:
+		return nil, ErrRecordImportFormatNotSupported
+	}
+}
+
+// NewRecordEncoder returns record encoder and content type for the given format (json, jsonl, csv, xlsx)
+func NewRecordEncoder(format string, w io.Writer, fields ...string) (RecordEncoder, string, error) {
+	ff := encoder.MakeFields(fields...)
+
+	switch strings.ToLower(format) {
+	case "json", "jsonl", "ldjson", "ndjson":
+		return encoder.NewStructuredEncoder(json.NewEncoder(w), ff...), "application/jsonl", nil
+
+	case "csv":
+		return encoder.NewFlatWriter(csv.NewWriter(w), true, ff...), "text/csv", nil
+
+	case "xlsx":
+		return encoder.NewExcelizeEncoder(w, true, ff...), "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", nil
+
+	default:
+		return nil, "", ErrRecordExportFormatNotSupported
+	}
+}
+
+// Imports records from the job's input
+func recordImportWorker(ctx context.Context, job *jobs.Job, r jobs.Reporter) error {
+	var p = RecordImportJobParams{}
+	if err := job.DecodeParams(&p); err != nil {
+		return err
+	}
+
+	input, err := r.Input()
+	if err != nil {
+		return err
+	}
+
+	dec, err := NewRecordDecoder(p.Format, bytes.NewReader(input))
+	if err != nil {
+		return err
+	}
+
+	count, err := dec.EntryCount()
+	if err != nil {
+		return err
+	}
+
+	var ses = &RecordImportSession{
+		Decoder:     dec,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   time.Now(),
+		OnError:     p.OnError,
+		SessionID:   job.ID,
+		UserID:      job.OwnedBy,
+		NamespaceID: p.NamespaceID,
+		ModuleID:    p.ModuleID,
+		Fields:      p.Fields,
+		Progress:    RecordImportProgress{EntryCount: count},
+	}
+
+	r.Progress(uint(count), 0, 0)
+	return DefaultRecord.With(ctx).Import(ses, &jobImportSession{r: r})
+}
+
+// Exports records into the job's result
+//
+// Encoded records are streamed directly to the result file
+func recordExportWorker(ctx context.Context, job *jobs.Job, r jobs.Reporter) error {
+	var p = RecordExportJobParams{}
+	if err := job.DecodeParams(&p); err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+
+	enc, contentType, err := NewRecordEncoder(p.Ext, pw, p.Fields...)
+	if err != nil {
+		return err
+	}
+
+	var (
+		jenc = &jobExportEncoder{RecordEncoder: enc, ctx: ctx, r: r}
+		f    = types.RecordFilter{
+			NamespaceID: p.NamespaceID,
+			ModuleID:    p.ModuleID,
+			Query:       p.Filter,
+		}
+	)
+
+	go func() {
+		defer sentry.Recover()
+
+		err := DefaultRecord.With(ctx).Export(f, jenc)
+		if err == nil {
+			enc.Flush()
+		}
+
+		// Failed export fails storing of the result
+		_ = pw.CloseWithError(err)
+	}()
+
+	err = r.Result(p.Filename+"."+p.Ext, contentType, pr)
+
+	// Unblocks the export when result could not be stored
+	_ = pr.CloseWithError(err)
+	return err
+}
+
+func (s *jobImportSession) FindRecordByID(context.Context, uint64) (*RecordImportSession, error) {
+	return nil, ErrRecordImportSessionNotFound
+}
+
+func (s *jobImportSession) SetRecordByID(_ context.Context, _, _, _ uint64, _ map[string]string, progress *RecordImportProgress, _ Decoder) (*RecordImportSession, error) {
+	if progress != nil {
+		s.r.Progress(uint(progress.EntryCount), uint(progress.Completed), uint(progress.Failed))
+	}
+
+	return nil, nil
+}
+
+func (s *jobImportSession) DeleteRecordByID(context.Context, uint64) error {
+	return nil
+}
+
+func (enc *jobExportEncoder) Record(r *types.Record) error {
+	if err := enc.ctx.Err(); err != nil {
+		return err
+	}
+
+	enc.count++
+	enc.r.Progress(0, enc.count, 0)
+	return enc.RecordEncoder.Record(r)
+}
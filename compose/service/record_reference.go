@@ -0,0 +1,365 @@
+package service
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cortezaproject/corteza-server/compose/service/event"
+	"github.com/cortezaproject/corteza-server/compose/types"
+)
+
+// FindDeleteImpact returns references that are affected when records are deleted
+//
+// References of records that would be deleted by cascading are included as well
+func (svc record) FindDeleteImpact(namespaceID, moduleID uint64, recordIDs ...uint64) (rr types.RecordReferenceSet, err error) {
+	var (
+		m *types.Module
+	)
+
+	if _, m, _, err = svc.loadCombo(namespaceID, moduleID, 0); err != nil {
+		return
+	}
+
+	if !svc.ac.CanReadRecord(svc.ctx, m) {
+		return nil, ErrNoReadPermissions.withStack()
+	}
+
+	return svc.deleteImpact(m, map[uint64]bool{}, recordIDs...)
+}
+
+func (svc record) deleteImpact(m *types.Module, visited map[uint64]bool, recordIDs ...uint64) (rr types.RecordReferenceSet, err error) {
+	var (
+		refs types.RecordReferenceSet
+		mm   types.ModuleSet
+	)
+
+	for _, ID := range recordIDs {
+		visited[ID] = true
+	}
+
+	if refs, mm, err = svc.findRefs(m, recordIDs...); err != nil {
+		return
+	}
+
+	for _, refModule := range mm {
+		var (
+			cascade  []uint64
+			readable = svc.ac.CanReadRecord(svc.ctx, refModule)
+		)
+
+		for _, r := range refs {
+			if r.ModuleID != refModule.ID || visited[r.RecordID] {
+				continue
+			}
+
+			// References from unreadable modules are not disclosed
+			// (but records deleted by cascading are still followed)
+			if readable {
+				rr = append(rr, r)
+			}
+
+			if r.OnDelete == types.RecordRefOnDeleteCascade {
+				cascade = append(cascade, r.RecordID)
+			}
+		}
+
+		if len(cascade) == 0 {
+			continue
+		}
+
+		if refs, err := svc.deleteImpact(refModule, visited, uniqueIDs(cascade)...); err != nil {
+			return nil, err
+		} else {
+			rr = append(rr, refs...)
+		}
+	}
+
+	return
+}
+
+// procRefsOnDelete applies on-delete policies of all record-reference fields
+// that point to the deleted record
+//
+// Expected to be called inside delete transaction, after the record is deleted;
+// records that reference the deleted one are always in the same namespace
+func (svc record) procRefsOnDelete(ns *types.Namespace, m *types.Module, del *types.Record, now time.Time) error {
+	refs, mm, err := svc.findRefs(m, del.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(refs.FilterByOnDelete(types.RecordRefOnDeleteRestrict)) > 0 {
+		return ErrRecordReferenced.withStack()
+	}
+
+	for _, refModule := range mm {
+		var (
+			clear   = map[string]bool{}
+			cleared []uint64
+			cascade []uint64
+		)
+
+		for _, r := range refs {
+			if r.ModuleID != refModule.ID {
+				continue
+			}
+
+			switch r.OnDelete {
+			case types.RecordRefOnDeleteSetNull:
+				clear[r.Field] = true
+				cleared = append(cleared, r.RecordID)
+			case types.RecordRefOnDeleteCascade:
+				cascade = append(cascade, r.RecordID)
+			}
+		}
+
+		if len(clear) > 0 {
+			if err = svc.clearRefs(ns, refModule, del, clear, uniqueIDs(cleared)); err != nil {
+				return err
+			}
+		}
+
+		if len(cascade) > 0 {
+			if err = svc.cascadeDelete(ns, refModule, uniqueIDs(cascade), now); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// clearRefs removes values of the given fields that reference the deleted record
+//
+// Each of the affected records is updated (with beforeUpdate and afterUpdate events);
+// if any of the updates is aborted, so is the deletion
+func (svc record) clearRefs(ns *types.Namespace, m *types.Module, del *types.Record, clear map[string]bool, recordIDs []uint64) (err error) {
+	if !svc.ac.CanUpdateRecord(svc.ctx, m) {
+		return ErrNoUpdatePermissions.withStack()
+	}
+
+	var (
+		names = make([]string, 0, len(clear))
+		uu    = make(types.RecordSet, 0, len(recordIDs))
+		oo    = make(types.RecordSet, 0, len(recordIDs))
+	)
+
+	for name := range clear {
+		if !svc.ac.CanUpdateRecordValue(svc.ctx, m.Fields.FindByName(name)) {
+			return ErrNoUpdatePermissions.withStack()
+		}
+
+		names = append(names, name)
+	}
+
+	for _, recordID := range recordIDs {
+		var old *types.Record
+		if old, err = svc.recordRepo.FindByID(ns.ID, recordID); err != nil {
+			return err
+		}
+
+		if err = svc.preloadValues(m, old); err != nil {
+			return err
+		}
+
+		upd := *old
+		upd.Values, _ = old.Values.Filter(func(v *types.RecordValue) (bool, error) {
+			return !clear[v.Name] || v.Ref != del.ID, nil
+		})
+
+		if svc.optEmitEvents {
+			if err = svc.eventbus.WaitFor(svc.ctx, event.RecordBeforeUpdate(&upd, old, m, ns, nil)); err != nil {
+				return err
+			}
+		}
+
+		svc.recordInfoUpdate(&upd)
+		if _, err = svc.recordRepo.Update(&upd); err != nil {
+			return err
+		}
+
+		uu = append(uu, &upd)
+		oo = append(oo, old)
+	}
+
+	if err = svc.recordRepo.ClearRefs(m.ID, names, *del.DeletedAt, del.ID); err != nil {
+		return err
+	}
+
+	if svc.optEmitEvents {
+		for i := range uu {
+			if err = DefaultOutbox.Record(svc.ctx, svc.db, event.RecordAfterUpdateImmutable(uu[i], oo[i], m, ns, nil)); err != nil {
+				return err
+			}
+
+			defer svc.eventbus.Dispatch(svc.ctx, event.RecordAfterUpdateImmutable(uu[i], oo[i], m, ns, nil))
+		}
+	}
+
+	return nil
+}
+
+// cascadeDelete deletes records that reference the deleted record
+//
+// Unlike bulk delete, aborted deletion of any of the records aborts the deletion of the referenced record
+func (svc record) cascadeDelete(ns *types.Namespace, m *types.Module, recordIDs []uint64, now time.Time) (err error) {
+	if !svc.ac.CanDeleteRecord(svc.ctx, m) {
+		return ErrNoDeletePermissions.withStack()
+	}
+
+	for _, recordID := range recordIDs {
+		var del *types.Record
+		if del, err = svc.recordRepo.FindByID(ns.ID, recordID); err != nil {
+			return err
+		}
+
+		if err = svc.beforeDelete(ns, m, del); err != nil {
+			return errors.Wrapf(err, "deletion of referencing record %d aborted", recordID)
+		}
+
+		if err = svc.delete(ns, m, del, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// procRefsOnUndelete restores what on-delete policies changed when the record was deleted
+//
+// Cleared references and records deleted by cascading are restored when they were
+// deleted at the same time as the record
+//
+// Expected to be called inside undelete transaction, after the record is restored
+func (svc record) procRefsOnUndelete(ns *types.Namespace, m *types.Module, recordID uint64, deletedAt time.Time) error {
+	mm, ff, err := svc.refFields(m)
+	if err != nil {
+		return err
+	}
+
+	for _, refModule := range mm {
+		var (
+			cleared  []string
+			cascaded []string
+			count    uint
+			rr       types.RecordSet
+		)
+
+		for _, f := range ff.FilterByModule(refModule.ID) {
+			switch f.Options.OnDelete() {
+			case types.RecordRefOnDeleteSetNull:
+				cleared = append(cleared, f.Name)
+			case types.RecordRefOnDeleteCascade:
+				cascaded = append(cascaded, f.Name)
+			}
+		}
+
+		if count, err = svc.recordRepo.RestoreRefs(refModule.ID, cleared, recordID, deletedAt); err != nil {
+			return err
+		} else if count > 0 && !svc.ac.CanUpdateRecord(svc.ctx, refModule) {
+			return ErrNoUpdatePermissions.withStack()
+		}
+
+		if rr, err = svc.recordRepo.FindDeletedRefs(refModule.ID, cascaded, recordID, deletedAt); err != nil {
+			return err
+		} else if len(rr) > 0 && !svc.ac.CanDeleteRecord(svc.ctx, refModule) {
+			return ErrNoDeletePermissions.withStack()
+		}
+
+		for _, r := range rr {
+			if err = svc.undelete(ns, refModule, r); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// findRefs returns all record-reference values that point to any of the records
+// together with modules these values belong to
+func (svc record) findRefs(m *types.Module, recordIDs ...uint64) (rr types.RecordReferenceSet, mm types.ModuleSet, err error) {
+	var (
+		ff types.ModuleFieldSet
+		vv types.RecordValueSet
+	)
+
+	if mm, ff, err = svc.refFields(m); err != nil {
+		return
+	}
+
+	for _, refModule := range mm {
+		fields := ff.FilterByModule(refModule.ID)
+
+		if vv, err = svc.recordRepo.FindRefs(refModule.ID, fields.Names(), recordIDs...); err != nil {
+			return
+		}
+
+		for _, v := range vv {
+			rr = append(rr, &types.RecordReference{
+				NamespaceID: refModule.NamespaceID,
+				ModuleID:    refModule.ID,
+				RecordID:    v.RecordID,
+				Field:       v.Name,
+				Ref:         v.Ref,
+				OnDelete:    fields.FindByName(v.Name).Options.OnDelete(),
+			})
+		}
+	}
+
+	return
+}
+
+// refFields returns record-reference fields in the namespace that can point to records of the module
+//
+// Only modules with at least one such field are returned
+func (svc record) refFields(m *types.Module) (mm types.ModuleSet, ff types.ModuleFieldSet, err error) {
+	var (
+		all types.ModuleSet
+		aff types.ModuleFieldSet
+	)
+
+	if all, _, err = svc.moduleRepo.Find(types.ModuleFilter{NamespaceID: m.NamespaceID}); err != nil {
+		return
+	}
+
+	if aff, err = svc.moduleRepo.FindFields(all.IDs()...); err != nil {
+		return
+	}
+
+	for _, f := range aff {
+		if f.Kind != "Record" {
+			continue
+		}
+
+		// Fields without module option can reference records from any module
+		if ID := f.Options.ModuleID(); ID > 0 && ID != m.ID {
+			continue
+		}
+
+		ff = append(ff, f)
+	}
+
+	for _, refModule := range all {
+		if len(ff.FilterByModule(refModule.ID)) > 0 {
+			refModule.Fields = aff.FilterByModule(refModule.ID)
+			mm = append(mm, refModule)
+		}
+	}
+
+	return
+}
+
+func uniqueIDs(IDs []uint64) (out []uint64) {
+	var seen = map[uint64]bool{}
+
+	for _, ID := range IDs {
+		if !seen[ID] {
+			seen[ID] = true
+			out = append(out, ID)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/cortezaproject/corteza-server/compose/service/event"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/app/options"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/sentry"
+)
+
+const (
+	// How many records are purged at once
+	recordPurgeBatchSize = 100
+)
+
+// FindDeleted lists deleted records of a namespace
+//
+// Only records from modules where user can delete records are listed
+func (svc record) FindDeleted(filter types.RecordTrashFilter) (set types.RecordSet, f types.RecordTrashFilter, err error) {
+	if _, err = svc.loadNamespace(filter.NamespaceID); err != nil {
+		return
+	}
+
+	filter.IsUndeletable = svc.ac.FilterRecordDeletableModules(svc.ctx)
+
+	return svc.recordRepo.FindDeleted(filter)
+}
+
+// Undelete restores deleted record and its values together with references
+// cleared and records deleted by cascading when the record was deleted
+//
+// Record is restored before beforeUndelete event is dispatched so that handlers
+// get complete record; when any of the handlers aborts, restoration is rolled back
+func (svc record) Undelete(namespaceID, moduleID, recordID uint64) (rec *types.Record, err error) {
+	if recordID == 0 {
+		return nil, ErrInvalidID.withStack()
+	}
+
+	return rec, svc.db.Transaction(func() (err error) {
+		var (
+			ns *types.Namespace
+			m  *types.Module
+		)
+
+		if ns, m, _, err = svc.loadCombo(namespaceID, moduleID, 0); err != nil {
+			return
+		}
+
+		if !svc.ac.CanDeleteRecord(svc.ctx, m) {
+			return ErrNoDeletePermissions.withStack()
+		}
+
+		if rec, err = svc.recordRepo.FindDeletedByID(namespaceID, recordID); err != nil {
+			return
+		}
+
+		if rec.ModuleID != m.ID {
+			return ErrInvalidModuleID.withStack()
+		}
+
+		return svc.undelete(ns, m, rec)
+	})
+}
+
+// undelete restores deleted record with its values and everything
+// that was changed by on-delete policies when it was deleted
+func (svc record) undelete(ns *types.Namespace, m *types.Module, rec *types.Record) (err error) {
+	var deletedAt = *rec.DeletedAt
+
+	if err = svc.recordRepo.Undelete(rec); err != nil {
+		return
+	}
+
+	rec.DeletedAt = nil
+	rec.DeletedBy = 0
+
+	if err = svc.preloadValues(m, rec); err != nil {
+		return
+	}
+
+	if svc.optEmitEvents {
+		if err = svc.eventbus.WaitFor(svc.ctx, event.RecordBeforeUndelete(rec, nil, m, ns, nil)); err != nil {
+			return
+		}
+
+		defer svc.eventbus.Dispatch(svc.ctx, event.RecordAfterUndeleteImmutable(rec, nil, m, ns, nil))
+	}
+
+	return svc.procRefsOnUndelete(ns, m, rec.ID, deletedAt)
+}
+
+// Purge permanently removes records deleted before the given time
+// together with their values and attachments
+//
+// Returns number of purged records
+func (svc record) Purge(deletedBefore time.Time) (count uint, err error) {
+	for {
+		var (
+			rr types.RecordSet
+			aa types.AttachmentSet
+		)
+
+		if rr, err = svc.recordRepo.FindPurgeable(deletedBefore, recordPurgeBatchSize); err != nil || len(rr) == 0 {
+			return
+		}
+
+		if aa, err = svc.attachmentRepo.FindByRecords(rr.IDs()...); err != nil {
+			return
+		}
+
+		err = svc.db.Transaction(func() (err error) {
+			if err = svc.recordRepo.Purge(rr.IDs()...); err != nil {
+				return
+			}
+
+			return svc.attachmentRepo.Purge(aa.IDs()...)
+		})
+
+		if err != nil {
+			return
+		}
+
+		svc.removeAttachmentFiles(aa)
+		count += uint(len(rr))
+	}
+}
+
+// removeAttachmentFiles removes stored files of purged attachments
+//
+// Failures are only logged; attachments are already gone
+func (svc record) removeAttachmentFiles(aa types.AttachmentSet) {
+	if svc.store == nil {
+		return
+	}
+
+	for _, a := range aa {
+		for _, name := range []string{a.Url, a.PreviewUrl} {
+			if name == "" {
+				continue
+			}
+
+			if err := svc.store.Remove(name); err != nil {
+				svc.log(svc.ctx, zap.Uint64("attachmentID", a.ID)).Warn("could not remove attachment file", zap.Error(err))
+			}
+		}
+	}
+}
+
+// watchTrash periodically purges records that were deleted longer than retention period ago
+func watchTrash(ctx context.Context, log *zap.Logger, opt options.TrashOpt) {
+	if opt.Retention <= 0 || opt.PurgeInterval <= 0 {
+		return
+	}
+
+	go func() {
+		defer sentry.Recover()
+
+		var ticker = time.NewTicker(opt.PurgeInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				count, err := DefaultRecord.With(auth.SetSuperUserContext(ctx)).Purge(time.Now().Add(-opt.Retention))
+				if err != nil {
+					log.Error("could not purge deleted records", zap.Error(err))
+				} else if count > 0 {
+					log.Info("deleted records purged", zap.Uint("count", count))
+				}
+			}
+		}
+	}()
+
+	log.Debug("trash watcher initialized", zap.Duration("retention", opt.Retention))
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cortezaproject/corteza-server/compose/service/event"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+)
+
+// FindTransitions returns history of workflow transitions made on a record
+func (svc record) FindTransitions(namespaceID, moduleID, recordID uint64) (tt types.RecordTransitionSet, err error) {
+	if _, err = svc.FindByID(namespaceID, recordID); err != nil {
+		return
+	}
+
+	tt, _, err = svc.transitionRepo.Find(types.RecordTransitionFilter{
+		NamespaceID: namespaceID,
+		ModuleID:    moduleID,
+		RecordID:    recordID,
+	})
+
+	return
+}
+
+// setInitialStates sets initial workflow state on new records
+//
+// Records can only be created with initial state (or any state when workflow does not define one)
+func (svc record) setInitialStates(m *types.Module, new *types.Record) *types.RecordValueErrorSet {
+	var (
+		rve = &types.RecordValueErrorSet{}
+	)
+
+	for _, f := range m.Fields {
+		wf := f.Workflow()
+		if wf == nil {
+			continue
+		}
+
+		state := firstValue(new.Values, f.Name)
+		switch {
+		case state == "" && wf.Initial != "":
+			new.Values = append(new.Values, &types.RecordValue{Name: f.Name, Value: wf.Initial})
+		case state == "":
+			// No state and no initial state
+		case wf.Initial != "" && state != wf.Initial, !wf.HasState(state):
+			rve.Push(makeWorkflowErr("invalidState", f, state, nil))
+		}
+	}
+
+	return rve
+}
+
+// procTransitions checks state changes of all workflow fields
+//
+// Returns list of transitions that are made with the update
+// or errors when transitions are not allowed or required values are missing
+func (svc record) procTransitions(m *types.Module, upd, old *types.Record) (tt types.RecordTransitionSet, rve *types.RecordValueErrorSet, err error) {
+	var (
+		roles    = auth.GetIdentityFromContext(svc.ctx).Roles()
+		invoker  = auth.GetIdentityFromContext(svc.ctx).Identity()
+		isSuper  = auth.IsSuperUser(auth.GetIdentityFromContext(svc.ctx))
+		oldState string
+	)
+
+	rve = &types.RecordValueErrorSet{}
+
+	for _, f := range m.Fields {
+		wf := f.Workflow()
+		if wf == nil {
+			continue
+		}
+
+		// Old state is loaded directly; user might not be allowed to read it
+		if vv, err := svc.recordRepo.LoadValues([]string{f.Name}, []uint64{old.ID}); err != nil {
+			return nil, nil, err
+		} else {
+			oldState = firstValue(vv, f.Name)
+		}
+
+		newState := firstValue(upd.Values, f.Name)
+		if oldState == newState {
+			continue
+		}
+
+		if !wf.HasState(newState) {
+			rve.Push(makeWorkflowErr("invalidState", f, newState, nil))
+			continue
+		}
+
+		t := wf.FindTransition(oldState, newState)
+		if t == nil {
+			rve.Push(makeWorkflowErr("transitionNotAllowed", f, newState, map[string]interface{}{"from": oldState}))
+			continue
+		}
+
+		if !isSuper && !t.AllowedFor(roles...) {
+			rve.Push(makeWorkflowErr("transitionForbidden", f, newState, map[string]interface{}{"transition": t.Name}))
+			continue
+		}
+
+		for _, name := range t.Required {
+			if firstValue(upd.Values, name) == "" {
+				rve.Push(types.RecordValueError{
+					Kind: "empty",
+					Meta: map[string]interface{}{"field": name, "transition": t.Name},
+				})
+			}
+		}
+
+		tt = append(tt, &types.RecordTransition{
+			NamespaceID: upd.NamespaceID,
+			ModuleID:    upd.ModuleID,
+			RecordID:    upd.ID,
+			Field:       f.Name,
+			Transition:  t.Name,
+			FromState:   oldState,
+			ToState:     newState,
+			CreatedBy:   invoker,
+		})
+	}
+
+	return
+}
+
+// checkTransitionGuards makes sure stored record matches guards of all transitions
+func (svc record) checkTransitionGuards(m *types.Module, tt types.RecordTransitionSet) (rve *types.RecordValueErrorSet, err error) {
+	rve = &types.RecordValueErrorSet{}
+
+	for _, tr := range tt {
+		var (
+			f = m.Fields.FindByName(tr.Field)
+			t = f.Workflow().FindTransition(tr.FromState, tr.ToState)
+		)
+
+		if strings.TrimSpace(t.Guard) == "" {
+			continue
+		}
+
+		_, filter, err := svc.recordRepo.Find(m, types.RecordFilter{
+			NamespaceID: m.NamespaceID,
+			ModuleID:    m.ID,
+			Query:       fmt.Sprintf("id = %d AND (%s)", tr.RecordID, t.Guard),
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		if filter.Count == 0 {
+			rve.Push(makeWorkflowErr("transitionGuard", f, tr.ToState, map[string]interface{}{"transition": t.Name}))
+		}
+	}
+
+	return
+}
+
+// beforeTransitions dispatches beforeTransition event for each of the transitions
+//
+// Any of the handlers can abort the update
+func (svc record) beforeTransitions(upd, old *types.Record, m *types.Module, ns *types.Namespace, rve *types.RecordValueErrorSet, tt types.RecordTransitionSet) (err error) {
+	for _, t := range tt {
+		ev := event.RecordBeforeTransition(upd, old, m, ns, rve)
+		ev.SetTransition(t)
+
+		if err = svc.eventbus.WaitFor(svc.ctx, ev); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// afterTransitions dispatches afterTransition event for each of the transitions
+func (svc record) afterTransitions(upd, old *types.Record, m *types.Module, ns *types.Namespace, tt types.RecordTransitionSet) {
+	for _, t := range tt {
+		ev := event.RecordAfterTransitionImmutable(upd, old, m, ns, nil)
+		ev.SetTransition(t)
+
+		svc.eventbus.Dispatch(svc.ctx, ev)
+	}
+}
+
+// firstValue returns first (non deleted) value of the field
+func firstValue(vv types.RecordValueSet, name string) string {
+	for _, v := range vv.FilterByName(name) {
+		if !v.IsDeleted() {
+			return strings.TrimSpace(v.Value)
+		}
+	}
+
+	return ""
+}
+
+func makeWorkflowErr(kind string, f *types.ModuleField, state string, meta map[string]interface{}) types.RecordValueError {
+	if meta == nil {
+		meta = map[string]interface{}{}
+	}
+
+	meta["field"] = f.Name
+	meta["state"] = state
+
+	return types.RecordValueError{Kind: kind, Meta: meta}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/cortezaproject/corteza-server/pkg/app/options"
 	"github.com/cortezaproject/corteza-server/pkg/auth"
 	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/pkg/outbox"
 	"github.com/cortezaproject/corteza-server/pkg/permissions"
 	"github.com/cortezaproject/corteza-server/pkg/settings"
 	"github.com/cortezaproject/corteza-server/pkg/store"
@@ -30,6 +31,7 @@ type (
 	Config struct {
 		Storage          options.StorageOpt
 		GRPCClientSystem options.GRPCServerOpt
+		Trash            options.TrashOpt
 	}
 
 	eventDispatcher interface {
@@ -54,17 +56,25 @@ var (
 	// CurrentSettings represents current compose settings
 	CurrentSettings = &types.Settings{}
 
-	DefaultNamespace     NamespaceService
-	DefaultImportSession ImportSessionService
-	DefaultRecord        RecordService
-	DefaultModule        ModuleService
-	DefaultChart         ChartService
-	DefaultPage          PageService
-	DefaultAttachment    AttachmentService
-	DefaultNotification  *notification
+	DefaultNamespace         NamespaceService
+	DefaultImportSession     ImportSessionService
+	DefaultRecord            RecordService
+	DefaultRecordComment     RecordCommentService
+	DefaultModule            ModuleService
+	DefaultChart             ChartService
+	DefaultChartSubscription ChartSubscriptionService
+	DefaultPage              PageService
+	DefaultAttachment        AttachmentService
+	DefaultNotification      *notification
 
 	DefaultSystemUser *systemUser
 	DefaultSystemRole *systemRole
+
+	// DefaultOutbox records compose events (see pkg/outbox)
+	DefaultOutbox = outbox.New("compose_outbox")
+
+	// Deleted records purging options
+	trashOpt options.TrashOpt
 )
 
 // Initializes compose-only services
@@ -72,6 +82,7 @@ func Initialize(ctx context.Context, log *zap.Logger, c Config) (err error) {
 	var db = repository.DB(ctx)
 
 	DefaultLogger = log.Named("service")
+	trashOpt = c.Trash
 
 	if DefaultPermissions == nil {
 		// Do not override permissions service stored under DefaultPermissions
@@ -138,12 +149,20 @@ func Initialize(ctx context.Context, log *zap.Logger, c Config) (err error) {
 
 	DefaultImportSession = ImportSession()
 	DefaultRecord = Record()
+	DefaultRecordComment = RecordComment()
 	DefaultPage = Page()
 	DefaultChart = Chart()
 	DefaultNotification = Notification()
+	DefaultChartSubscription = ChartSubscription()
 	DefaultAttachment = Attachment(DefaultStore)
 
 	RegisterIteratorProviders()
+	RegisterEmbeddedFuncs()
+	RegisterJobWorkers()
+
+	// Recorded events are relayed to webhooks by the system service
+	outbox.Register(DefaultOutbox, repository.DB(ctx))
+	registerOutboxDecoders()
 
 	return nil
 }
@@ -161,6 +180,17 @@ func Activate(ctx context.Context) (err error) {
 func Watchers(ctx context.Context) {
 	// Reloading permissions on change
 	DefaultPermissions.Watch(ctx)
+
+	// Purging old deleted records
+	watchTrash(ctx, DefaultLogger.Named("trash"), trashOpt)
+	watchChartSubscriptions(ctx, DefaultLogger.Named("chart-subscription"))
+	watchModuleMigrations(ctx, DefaultLogger.Named("module-migration"))
+
+	if !outbox.HasHandler() {
+		// Standalone compose; events are kept in the outbox
+		DefaultLogger.Warn("compose events are recorded in compose_outbox but no outbox handler " +
+			"(system service) runs in this process; they are not relayed to webhooks")
+	}
 }
 
 func RegisterIteratorProviders() {
@@ -168,34 +198,57 @@ func RegisterIteratorProviders() {
 	corredor.Service().RegisterIteratorProvider(
 		"compose:record",
 		func(ctx context.Context, f map[string]string, h eventbus.HandlerFn, action string) error {
-			rf := types.RecordFilter{
-				Query: f["query"],
-				Sort:  f["sort"],
+			rf, err := makeIteratorRecordFilter(ctx, f)
+			if err != nil {
+				return err
 			}
 
 			rf.ParsePagination(f)
 
-			if nsLookup, has := f["namespace"]; !has {
-				return errors.New("namespace for record iteration filter not defined")
-			} else if ns, err := DefaultNamespace.With(ctx).FindByAny(nsLookup); err != nil {
-				return err
-			} else {
-				rf.NamespaceID = ns.ID
-			}
+			return DefaultRecord.With(ctx).Iterator(rf, h, action)
+		},
+	)
 
-			if mLookup, has := f["module"]; !has {
-				return errors.New("module for record iteration filter not defined")
-			} else if m, err := DefaultModule.With(ctx).FindByAny(rf.NamespaceID, mLookup); err != nil {
-				return err
-			} else {
-				rf.ModuleID = m.ID
+	// Register chunked resource finders for deferred (resumable) iterators
+	corredor.Service().RegisterIteratorChunkProvider(
+		"compose:record",
+		func(ctx context.Context, f map[string]string, h eventbus.HandlerFn, action string, afterID, beforeID uint64, size uint) (uint64, uint, uint, error) {
+			rf, err := makeIteratorRecordFilter(ctx, f)
+			if err != nil {
+				return 0, 0, 0, err
 			}
 
-			return DefaultRecord.With(ctx).Iterator(rf, h, action)
+			return DefaultRecord.With(ctx).IteratorChunk(rf, h, action, afterID, beforeID, size)
 		},
 	)
 }
 
+// Converts iterator filter into record filter
+func makeIteratorRecordFilter(ctx context.Context, f map[string]string) (rf types.RecordFilter, err error) {
+	rf = types.RecordFilter{
+		Query: f["query"],
+		Sort:  f["sort"],
+	}
+
+	if nsLookup, has := f["namespace"]; !has {
+		return rf, errors.New("namespace for record iteration filter not defined")
+	} else if ns, err := DefaultNamespace.With(ctx).FindByAny(nsLookup); err != nil {
+		return rf, err
+	} else {
+		rf.NamespaceID = ns.ID
+	}
+
+	if mLookup, has := f["module"]; !has {
+		return rf, errors.New("module for record iteration filter not defined")
+	} else if m, err := DefaultModule.With(ctx).FindByAny(rf.NamespaceID, mLookup); err != nil {
+		return rf, err
+	} else {
+		rf.ModuleID = m.ID
+	}
+
+	return rf, nil
+}
+
 // Data is stale when new date does not match updatedAt or createdAt (before first update)
 func isStale(new *time.Time, updatedAt *time.Time, createdAt time.Time) bool {
 	if new == nil {
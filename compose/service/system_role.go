@@ -46,3 +46,17 @@ func (svc systemRole) Find(ctx context.Context) (rr types.RoleSet, err error) {
 
 	return rr, nil
 }
+
+// FindMembers returns IDs of users that are members of any of the given roles
+func (svc systemRole) FindMembers(ctx context.Context, roleIDs ...uint64) ([]uint64, error) {
+	ctx = metadata.NewOutgoingContext(ctx, metadata.MD{
+		"jwt": []string{auth.GetJwtFromContext(ctx)},
+	})
+
+	rsp, err := svc.client.FindMembers(ctx, &proto.FindMembersRoleRequest{RoleID: roleIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.UserID, nil
+}
@@ -0,0 +1,82 @@
+package values
+
+import (
+	"strings"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+)
+
+type (
+	valueValidator interface {
+		Value(*types.RecordValue, *types.ModuleField, *types.Module) []types.RecordValueError
+	}
+
+	converter struct {
+		sanitizer *sanitizer
+		validator valueValidator
+	}
+)
+
+// Converter initializes converter that prepares existing values
+// for a field that changed its kind
+func Converter(vldtr valueValidator) *converter {
+	return &converter{
+		sanitizer: Sanitizer(),
+		validator: vldtr,
+	}
+}
+
+// Run sanitizes existing value for the (new) field kind and validates it
+//
+// Returns false when value can not be converted without loosing its content
+func (c converter) Run(m *types.Module, v *types.RecordValue) (*types.RecordValue, bool) {
+	var (
+		f = m.Fields.FindByName(v.Name)
+	)
+
+	if f == nil {
+		return v, false
+	}
+
+	if v.Value == "" {
+		// Nothing to convert
+		return v, true
+	}
+
+	// Sanitizer expects values that are updated and not deleted
+	in := v.Clone()
+	in.Updated = true
+	in.DeletedAt = nil
+
+	if !f.IsRef() {
+		in.Ref = 0
+	}
+
+	out := c.sanitizer.Run(&types.Module{ID: m.ID, NamespaceID: m.NamespaceID, Fields: types.ModuleFieldSet{f}}, types.RecordValueSet{in})
+	if len(out) == 0 {
+		return v, false
+	}
+
+	cv := out[0]
+
+	// Sanitizer nullifies or falsifies values it can not parse
+	if cv.Value == "" {
+		return v, false
+	}
+
+	if strings.ToLower(f.Kind) == "bool" {
+		val := strings.ToLower(strings.TrimSpace(v.Value))
+		if !truthy.MatchString(val) && !falsy.MatchString(val) {
+			return v, false
+		}
+	}
+
+	if len(c.validator.Value(cv, f, m)) > 0 {
+		return v, false
+	}
+
+	cv.Place = v.Place
+	cv.DeletedAt = v.DeletedAt
+	cv.Updated = false
+	return cv, true
+}
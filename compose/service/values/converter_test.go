@@ -0,0 +1,121 @@
+package values
+
+import (
+	"testing"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+)
+
+func Test_converter_Run(t *testing.T) {
+	var (
+		vldtr = Validator()
+
+		tests = []struct {
+			name   string
+			kind   string
+			input  string
+			output string
+			outref uint64
+			ok     bool
+		}{
+			{
+				name:   "numeric strings are converted to numbers",
+				kind:   "Number",
+				input:  " 42 ",
+				output: "42",
+				ok:     true,
+			},
+			{
+				name:  "text can not be converted to number",
+				kind:  "Number",
+				input: "forty two",
+			},
+			{
+				name:   "truthy values are converted to booleans",
+				kind:   "Bool",
+				input:  "yes",
+				output: "1",
+				ok:     true,
+			},
+			{
+				name:   "falsy values are converted to booleans",
+				kind:   "Bool",
+				input:  "no",
+				output: "0",
+				ok:     true,
+			},
+			{
+				name:  "garbage can not be converted to boolean",
+				kind:  "Bool",
+				input: "maybe",
+			},
+			{
+				name:   "dates are converted to ISO",
+				kind:   "DateTime",
+				input:  "Mon Jan 2 15:04:05 2006",
+				output: "2006-01-02T15:04:05Z",
+				ok:     true,
+			},
+			{
+				name:  "text can not be converted to date",
+				kind:  "DateTime",
+				input: "yesterday",
+			},
+			{
+				name:   "numeric strings are converted to user references",
+				kind:   "User",
+				input:  "133569629112020995",
+				output: "133569629112020995",
+				outref: 133569629112020995,
+				ok:     true,
+			},
+			{
+				name:  "text can not be converted to user reference",
+				kind:  "User",
+				input: "john",
+			},
+			{
+				name:   "anything can be converted to string",
+				kind:   "String",
+				input:  " The answer ",
+				output: " The answer ",
+				ok:     true,
+			},
+			{
+				name: "empty values are kept",
+				kind: "Number",
+				ok:   true,
+			},
+		}
+	)
+
+	vldtr.UserRefChecker(func(*types.RecordValue, *types.ModuleField, *types.Module) (bool, error) {
+		return true, nil
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				m = &types.Module{Fields: types.ModuleFieldSet{&types.ModuleField{Name: "testField", Kind: tt.kind}}}
+				v = &types.RecordValue{RecordID: 1, Name: "testField", Value: tt.input, Place: 2}
+			)
+
+			out, ok := Converter(vldtr).Run(m, v)
+			if ok != tt.ok {
+				t.Fatalf("expecting conversion of %q to %s to be %v", tt.input, tt.kind, tt.ok)
+			}
+
+			if !ok {
+				return
+			}
+
+			if out.Value != tt.output || out.Ref != tt.outref {
+				t.Errorf("expecting %q (ref %d), got %q (ref %d)", tt.output, tt.outref, out.Value, out.Ref)
+			}
+
+			if out.Place != v.Place || out.RecordID != v.RecordID || out.Updated {
+				t.Errorf("expecting record ID and place to be kept, got %+v", out)
+			}
+		})
+	}
+}
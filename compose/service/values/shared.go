@@ -27,6 +27,9 @@ var (
 	// value resembles something that can be true
 	truthy = regexp.MustCompile(`^(t(rue)?|y(es)?|1)$`)
 
+	// value resembles something that can be false
+	falsy = regexp.MustCompile(`^(f(alse)?|no?|0)$`)
+
 	// value resembles something that can be a reference
 	refy = regexp.MustCompile(`^[1-9](\d*)$`)
 
@@ -144,29 +144,7 @@ fields:
 			return nil
 		}
 
-		// Per field type validators
-		switch strings.ToLower(f.Kind) {
-		case "bool":
-			out.Push(vldtr.vBool(v, f, r, m)...)
-		case "datetime":
-			out.Push(vldtr.vDatetime(v, f, r, m)...)
-		case "email":
-			out.Push(vldtr.vEmail(v, f, r, m)...)
-		case "file":
-			out.Push(vldtr.vFile(v, f, r, m)...)
-		case "number":
-			out.Push(vldtr.vNumber(v, f, r, m)...)
-		case "record":
-			out.Push(vldtr.vRecord(v, f, r, m)...)
-		case "select":
-			out.Push(vldtr.vSelect(v, f, r, m)...)
-		//case "string":
-		//	out.Push(vldtr.vString(v, f, r, m)...)
-		case "url":
-			out.Push(vldtr.vUrl(v, f, r, m)...)
-		case "user":
-			out.Push(vldtr.vUser(v, f, r, m)...)
-		}
+		out.Push(vldtr.Value(v, f, m)...)
 	}
 
 	// This is the most resource-heavy operation
@@ -196,6 +174,36 @@ fields:
 	return out
 }
 
+// Value runs field-kind specific validation of a single value
+func (vldtr validator) Value(v *types.RecordValue, f *types.ModuleField, m *types.Module) []types.RecordValueError {
+	var r *types.Record
+
+	switch strings.ToLower(f.Kind) {
+	case "bool":
+		return vldtr.vBool(v, f, r, m)
+	case "datetime":
+		return vldtr.vDatetime(v, f, r, m)
+	case "email":
+		return vldtr.vEmail(v, f, r, m)
+	case "file":
+		return vldtr.vFile(v, f, r, m)
+	case "number":
+		return vldtr.vNumber(v, f, r, m)
+	case "record":
+		return vldtr.vRecord(v, f, r, m)
+	case "select":
+		return vldtr.vSelect(v, f, r, m)
+	//case "string":
+	//	return vldtr.vString(v, f, r, m)
+	case "url":
+		return vldtr.vUrl(v, f, r, m)
+	case "user":
+		return vldtr.vUser(v, f, r, m)
+	}
+
+	return nil
+}
+
 func (vldtr validator) vBool(v *types.RecordValue, f *types.ModuleField, r *types.Record, m *types.Module) []types.RecordValueError {
 	if v.Value == "" {
 		return nil
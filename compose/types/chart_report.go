@@ -0,0 +1,152 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	// ChartReportMetric is a typed view on one of the report's metrics
+	ChartReportMetric struct {
+		// Module field or "count"
+		Field     string
+		Aggregate string
+		Label     string
+
+		// Chart type (bar, line, pie...)
+		Type  string
+		Color string
+	}
+
+	// ChartReportDimension is a typed view on one of the report's dimensions
+	ChartReportDimension struct {
+		Field    string
+		Modifier string
+		Default  string
+	}
+)
+
+const (
+	chartReportMetricCount = "count"
+	chartReportNoModifier  = "(no grouping / buckets)"
+)
+
+// Alias returns column name under which metric's value is returned from record report
+func (m ChartReportMetric) Alias(i int) string {
+	if m.IsCount() {
+		return chartReportMetricCount
+	}
+
+	return fmt.Sprintf("metric_%d", i)
+}
+
+// IsCount is true for metrics that count records
+//
+// Record reports always include count so these do not need to be queried
+func (m ChartReportMetric) IsCount() bool {
+	return m.Field == "" || m.Field == chartReportMetricCount
+}
+
+func (m ChartReportMetric) expr() string {
+	var aggregate = strings.ToUpper(m.Aggregate)
+	if aggregate == "" {
+		aggregate = "SUM"
+	}
+
+	return fmt.Sprintf("%s(%s)", aggregate, m.Field)
+}
+
+// Alias returns column name under which dimension's value is returned from record report
+func (d ChartReportDimension) Alias(i int) string {
+	return fmt.Sprintf("dimension_%d", i)
+}
+
+func (d ChartReportDimension) expr() string {
+	switch strings.ToUpper(d.Modifier) {
+	case "DATE":
+		return fmt.Sprintf("DATE(%s)", d.Field)
+	case "WEEK":
+		return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%u')", d.Field)
+	case "MONTH":
+		return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m')", d.Field)
+	case "QUARTER":
+		return fmt.Sprintf("QUARTER(%s)", d.Field)
+	case "YEAR":
+		return fmt.Sprintf("YEAR(%s)", d.Field)
+	default:
+		return d.Field
+	}
+}
+
+// ReportMetrics converts configured metrics
+func (r ChartConfigReport) ReportMetrics() (mm []*ChartReportMetric) {
+	mm = make([]*ChartReportMetric, len(r.Metrics))
+	for i, m := range r.Metrics {
+		mm[i] = &ChartReportMetric{
+			Field:     chartConfigString(m, "field"),
+			Aggregate: chartConfigString(m, "aggregate"),
+			Label:     chartConfigString(m, "label"),
+			Type:      chartConfigString(m, "type"),
+			Color:     chartConfigString(m, "backgroundColor"),
+		}
+
+		if mm[i].Label == "" {
+			mm[i].Label = mm[i].Field
+		}
+	}
+
+	return
+}
+
+// ReportDimensions converts configured dimensions
+func (r ChartConfigReport) ReportDimensions() (dd []*ChartReportDimension) {
+	dd = make([]*ChartReportDimension, len(r.Dimensions))
+	for i, d := range r.Dimensions {
+		dd[i] = &ChartReportDimension{
+			Field:    chartConfigString(d, "field"),
+			Modifier: chartConfigString(d, "modifier"),
+			Default:  chartConfigString(d, "default"),
+		}
+
+		if dd[i].Modifier == chartReportNoModifier {
+			dd[i].Modifier = ""
+		}
+	}
+
+	return
+}
+
+// MetricsQuery builds metrics expression for record report
+func (r ChartConfigReport) MetricsQuery() string {
+	var cols = make([]string, 0, len(r.Metrics))
+	for i, m := range r.ReportMetrics() {
+		if m.IsCount() {
+			continue
+		}
+
+		cols = append(cols, m.expr()+" AS "+m.Alias(i))
+	}
+
+	return strings.Join(cols, ", ")
+}
+
+// DimensionsQuery builds dimensions expression for record report
+func (r ChartConfigReport) DimensionsQuery() string {
+	var cols = make([]string, 0, len(r.Dimensions))
+	for i, d := range r.ReportDimensions() {
+		cols = append(cols, d.expr()+" AS "+d.Alias(i))
+	}
+
+	return strings.Join(cols, ", ")
+}
+
+func chartConfigString(m map[string]interface{}, key string) string {
+	switch v := m[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
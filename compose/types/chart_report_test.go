@@ -0,0 +1,34 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChartConfigReport_Queries(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		r = ChartConfigReport{
+			Metrics: []map[string]interface{}{
+				{"field": "count"},
+				{"field": "amount", "aggregate": "max", "label": "Biggest deal"},
+				{"field": "amount"},
+			},
+			Dimensions: []map[string]interface{}{
+				{"field": "closeDate", "modifier": "MONTH"},
+				{"field": "stage", "modifier": "(no grouping / buckets)"},
+			},
+		}
+	)
+
+	req.Equal("MAX(amount) AS metric_1, SUM(amount) AS metric_2", r.MetricsQuery())
+	req.Equal("DATE_FORMAT(closeDate, '%Y-%m') AS dimension_0, stage AS dimension_1", r.DimensionsQuery())
+
+	mm := r.ReportMetrics()
+	req.Len(mm, 3)
+	req.Equal("count", mm[0].Alias(0))
+	req.Equal("Biggest deal", mm[1].Label)
+	req.Equal("amount", mm[2].Label)
+}
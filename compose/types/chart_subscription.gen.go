@@ -0,0 +1,67 @@
+package types
+
+// 	Hello! This file is auto-generated.
+
+type (
+
+	// ChartSubscriptionSet slice of ChartSubscription
+	//
+	// This type is auto-generated.
+	ChartSubscriptionSet []*ChartSubscription
+)
+
+// Walk iterates through every slice item and calls w(ChartSubscription) err
+//
+// This function is auto-generated.
+func (set ChartSubscriptionSet) Walk(w func(*ChartSubscription) error) (err error) {
+	for i := range set {
+		if err = w(set[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Filter iterates through every slice item, calls f(ChartSubscription) (bool, err) and return filtered slice
+//
+// This function is auto-generated.
+func (set ChartSubscriptionSet) Filter(f func(*ChartSubscription) (bool, error)) (out ChartSubscriptionSet, err error) {
+	var ok bool
+	out = ChartSubscriptionSet{}
+	for i := range set {
+		if ok, err = f(set[i]); err != nil {
+			return
+		} else if ok {
+			out = append(out, set[i])
+		}
+	}
+
+	return
+}
+
+// FindByID finds items from slice by its ID property
+//
+// This function is auto-generated.
+func (set ChartSubscriptionSet) FindByID(ID uint64) *ChartSubscription {
+	for i := range set {
+		if set[i].ID == ID {
+			return set[i]
+		}
+	}
+
+	return nil
+}
+
+// IDs returns a slice of uint64s from all items in the set
+//
+// This function is auto-generated.
+func (set ChartSubscriptionSet) IDs() (IDs []uint64) {
+	IDs = make([]uint64, len(set))
+
+	for i := range set {
+		IDs[i] = set[i].ID
+	}
+
+	return
+}
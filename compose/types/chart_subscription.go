@@ -0,0 +1,116 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	// ChartSubscription periodically emails rendered chart and its report data
+	ChartSubscription struct {
+		ID          uint64 `json:"subscriptionID,string" db:"id"`
+		NamespaceID uint64 `json:"namespaceID,string" db:"rel_namespace"`
+		ChartID     uint64 `json:"chartID,string" db:"rel_chart"`
+
+		Frequency ChartSubscriptionFrequency `json:"frequency" db:"frequency"`
+
+		// Image format (png, svg)
+		Format string `json:"format" db:"format"`
+
+		Recipients ChartSubscriptionRecipients `json:"recipients" db:"recipients"`
+
+		NextRunAt time.Time  `json:"nextRunAt" db:"next_run_at"`
+		LastRunAt *time.Time `json:"lastRunAt,omitempty" db:"last_run_at"`
+
+		CreatedAt time.Time  `json:"createdAt,omitempty" db:"created_at"`
+		CreatedBy uint64     `json:"createdBy,string" db:"created_by"`
+		UpdatedAt *time.Time `json:"updatedAt,omitempty" db:"updated_at"`
+		DeletedAt *time.Time `json:"deletedAt,omitempty" db:"deleted_at"`
+	}
+
+	ChartSubscriptionFilter struct {
+		NamespaceID uint64 `json:"namespaceID,string"`
+		ChartID     uint64 `json:"chartID,string"`
+
+		Sort string `json:"sort"`
+
+		// Standard paging fields & helpers
+		rh.PageFilter
+	}
+
+	ChartSubscriptionRecipients struct {
+		// User IDs
+		Users []string `json:"users,omitempty"`
+
+		// Role IDs, all role members receive the email
+		Roles []string `json:"roles,omitempty"`
+	}
+
+	ChartSubscriptionFrequency string
+)
+
+const (
+	ChartSubscriptionDaily   ChartSubscriptionFrequency = "daily"
+	ChartSubscriptionWeekly  ChartSubscriptionFrequency = "weekly"
+	ChartSubscriptionMonthly ChartSubscriptionFrequency = "monthly"
+)
+
+// IsValid checks if frequency is one of the supported values
+func (f ChartSubscriptionFrequency) IsValid() bool {
+	switch f {
+	case ChartSubscriptionDaily, ChartSubscriptionWeekly, ChartSubscriptionMonthly:
+		return true
+	}
+
+	return false
+}
+
+// Next returns time of the next run after t
+func (f ChartSubscriptionFrequency) Next(t time.Time) time.Time {
+	switch f {
+	case ChartSubscriptionWeekly:
+		return t.AddDate(0, 0, 7)
+	case ChartSubscriptionMonthly:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+// Schedule moves next run after the given time
+//
+// Missed runs are skipped
+func (s *ChartSubscription) Schedule(now time.Time) {
+	for !s.NextRunAt.After(now) {
+		s.NextRunAt = s.Frequency.Next(s.NextRunAt)
+	}
+}
+
+// IsEmpty returns true when there are no recipients
+func (r ChartSubscriptionRecipients) IsEmpty() bool {
+	return len(r.Users) == 0 && len(r.Roles) == 0
+}
+
+func (r *ChartSubscriptionRecipients) Scan(value interface{}) error {
+	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
+	switch value.(type) {
+	case nil:
+		*r = ChartSubscriptionRecipients{}
+	case []uint8:
+		b := value.([]byte)
+		if err := json.Unmarshal(b, r); err != nil {
+			return errors.Wrapf(err, "Can not scan '%v' into ChartSubscriptionRecipients", string(b))
+		}
+	}
+
+	return nil
+}
+
+func (r ChartSubscriptionRecipients) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
@@ -0,0 +1,30 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChartSubscription_Schedule(t *testing.T) {
+	var (
+		req = require.New(t)
+		now = time.Date(2020, 6, 10, 12, 0, 0, 0, time.UTC)
+
+		s = &ChartSubscription{
+			Frequency: ChartSubscriptionWeekly,
+			NextRunAt: time.Date(2020, 5, 25, 8, 0, 0, 0, time.UTC),
+		}
+	)
+
+	s.Schedule(now)
+	req.Equal(time.Date(2020, 6, 15, 8, 0, 0, 0, time.UTC), s.NextRunAt)
+
+	s.Frequency = ChartSubscriptionMonthly
+	s.Schedule(s.NextRunAt)
+	req.Equal(time.Date(2020, 7, 15, 8, 0, 0, 0, time.UTC), s.NextRunAt)
+
+	req.True(ChartSubscriptionDaily.IsValid())
+	req.False(ChartSubscriptionFrequency("hourly").IsValid())
+}
@@ -3,6 +3,8 @@ package types
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"strconv"
+
 	"github.com/pkg/errors"
 )
 
@@ -13,6 +15,8 @@ type (
 const (
 	moduleFieldOptionIsUnique           = "isUnique"
 	moduleFieldOptionIsUniqueMultiValue = "isUniqueMultiValue"
+	moduleFieldOptionModuleID           = "moduleID"
+	moduleFieldOptionOnDelete           = "onDelete"
 )
 
 func (opt *ModuleFieldOptions) Scan(value interface{}) error {
@@ -93,3 +97,30 @@ func (opt ModuleFieldOptions) SetIsUniqueMultiValue(value bool) {
 	// SetIsUniqueMultiValue - should value in this field be unique in the multi-value set?
 	opt[moduleFieldOptionIsUniqueMultiValue] = value
 }
+
+// ModuleID - ID of the module record-reference field points to
+//
+// Returns 0 when not set
+func (opt ModuleFieldOptions) ModuleID() uint64 {
+	switch v := opt[moduleFieldOptionModuleID].(type) {
+	case string:
+		ID, _ := strconv.ParseUint(v, 10, 64)
+		return ID
+	case uint64:
+		return v
+	}
+
+	return 0
+}
+
+// OnDelete - what happens with record-reference value when referenced record is deleted
+func (opt ModuleFieldOptions) OnDelete() RecordRefOnDelete {
+	if v, ok := opt[moduleFieldOptionOnDelete].(string); ok {
+		switch p := RecordRefOnDelete(v); p {
+		case RecordRefOnDeleteRestrict, RecordRefOnDeleteSetNull, RecordRefOnDeleteCascade:
+			return p
+		}
+	}
+
+	return RecordRefOnDeleteNone
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModuleFieldOptions_ModuleID(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal(uint64(0), ModuleFieldOptions{}.ModuleID())
+	a.Equal(uint64(0), ModuleFieldOptions{"moduleID": "foo"}.ModuleID())
+	a.Equal(uint64(42), ModuleFieldOptions{"moduleID": "42"}.ModuleID())
+	a.Equal(uint64(42), ModuleFieldOptions{"moduleID": uint64(42)}.ModuleID())
+}
+
+func TestModuleFieldOptions_OnDelete(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal(RecordRefOnDeleteNone, ModuleFieldOptions{}.OnDelete())
+	a.Equal(RecordRefOnDeleteNone, ModuleFieldOptions{"onDelete": "explode"}.OnDelete())
+	a.Equal(RecordRefOnDeleteRestrict, ModuleFieldOptions{"onDelete": "restrict"}.OnDelete())
+	a.Equal(RecordRefOnDeleteSetNull, ModuleFieldOptions{"onDelete": "setNull"}.OnDelete())
+	a.Equal(RecordRefOnDeleteCascade, ModuleFieldOptions{"onDelete": "cascade"}.OnDelete())
+}
@@ -0,0 +1,67 @@
+package types
+
+// 	Hello! This file is auto-generated.
+
+type (
+
+	// ModuleMigrationSet slice of ModuleMigration
+	//
+	// This type is auto-generated.
+	ModuleMigrationSet []*ModuleMigration
+)
+
+// Walk iterates through every slice item and calls w(ModuleMigration) err
+//
+// This function is auto-generated.
+func (set ModuleMigrationSet) Walk(w func(*ModuleMigration) error) (err error) {
+	for i := range set {
+		if err = w(set[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Filter iterates through every slice item, calls f(ModuleMigration) (bool, err) and return filtered slice
+//
+// This function is auto-generated.
+func (set ModuleMigrationSet) Filter(f func(*ModuleMigration) (bool, error)) (out ModuleMigrationSet, err error) {
+	var ok bool
+	out = ModuleMigrationSet{}
+	for i := range set {
+		if ok, err = f(set[i]); err != nil {
+			return
+		} else if ok {
+			out = append(out, set[i])
+		}
+	}
+
+	return
+}
+
+// FindByID finds items from slice by its ID property
+//
+// This function is auto-generated.
+func (set ModuleMigrationSet) FindByID(ID uint64) *ModuleMigration {
+	for i := range set {
+		if set[i].ID == ID {
+			return set[i]
+		}
+	}
+
+	return nil
+}
+
+// IDs returns a slice of uint64s from all items in the set
+//
+// This function is auto-generated.
+func (set ModuleMigrationSet) IDs() (IDs []uint64) {
+	IDs = make([]uint64, len(set))
+
+	for i := range set {
+		IDs[i] = set[i].ID
+	}
+
+	return
+}
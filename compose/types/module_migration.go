@@ -0,0 +1,193 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	// ModuleMigration keeps track of record values migrated
+	// after module fields were renamed, removed or had their kind changed
+	ModuleMigration struct {
+		ID          uint64                 `json:"migrationID,string" db:"id"`
+		NamespaceID uint64                 `json:"namespaceID,string" db:"rel_namespace"`
+		ModuleID    uint64                 `json:"moduleID,string" db:"rel_module"`
+		Steps       ModuleMigrationStepSet `json:"steps" db:"steps"`
+
+		// Are there any steps that might loose (archive) existing values
+		Destructive bool `json:"destructive" db:"-"`
+
+		Status    ModuleMigrationStatus `json:"status" db:"status"`
+		Total     uint                  `json:"total" db:"total"`
+		Processed uint                  `json:"processed" db:"processed"`
+		Error     string                `json:"error,omitempty" db:"error"`
+
+		CreatedAt   time.Time  `json:"createdAt,omitempty" db:"created_at"`
+		CreatedBy   uint64     `json:"createdBy,string" db:"created_by"`
+		StartedAt   *time.Time `json:"startedAt,omitempty" db:"started_at"`
+		CompletedAt *time.Time `json:"completedAt,omitempty" db:"completed_at"`
+
+		// Updated after each converted batch; migration that is running
+		// but not updated for a while was interrupted
+		UpdatedAt *time.Time `json:"updatedAt,omitempty" db:"updated_at"`
+	}
+
+	ModuleMigrationStep struct {
+		FieldID uint64                    `json:"fieldID,string"`
+		Action  ModuleMigrationStepAction `json:"action"`
+
+		OldName string `json:"oldName"`
+		NewName string `json:"newName,omitempty"`
+		OldKind string `json:"oldKind,omitempty"`
+		NewKind string `json:"newKind,omitempty"`
+
+		// Number of stored values affected by this step
+		Values uint `json:"values"`
+	}
+
+	ModuleMigrationStepSet []*ModuleMigrationStep
+
+	ModuleMigrationStatus     string
+	ModuleMigrationStepAction string
+
+	ModuleMigrationFilter struct {
+		NamespaceID uint64 `json:"namespaceID,string"`
+		ModuleID    uint64 `json:"moduleID,string"`
+
+		Sort string `json:"sort"`
+
+		// Standard paging fields & helpers
+		rh.PageFilter
+	}
+)
+
+const (
+	ModuleMigrationPending   ModuleMigrationStatus = "pending"
+	ModuleMigrationRunning   ModuleMigrationStatus = "running"
+	ModuleMigrationCompleted ModuleMigrationStatus = "completed"
+	ModuleMigrationFailed    ModuleMigrationStatus = "failed"
+
+	// Values are moved to a field with a new name
+	ModuleMigrationRename ModuleMigrationStepAction = "rename"
+
+	// Values are sanitized for the new field kind,
+	// ones that can not be converted are archived
+	ModuleMigrationConvert ModuleMigrationStepAction = "convert"
+
+	// Field was removed, all values are archived
+	ModuleMigrationArchive ModuleMigrationStepAction = "archive"
+)
+
+// PlanModuleMigration compares existing and updated module fields
+// and returns steps needed to keep stored record values in sync
+//
+// Fields are matched by ID; steps are ordered so that they can be executed one after another:
+// values of removed fields are archived first, then renamed and converted.
+func PlanModuleMigration(existing, updated ModuleFieldSet) (ss ModuleMigrationStepSet) {
+	var (
+		archive, rename, convert ModuleMigrationStepSet
+	)
+
+	for _, e := range existing {
+		u := updated.FindByID(e.ID)
+		if u == nil {
+			archive = append(archive, &ModuleMigrationStep{
+				FieldID: e.ID,
+				Action:  ModuleMigrationArchive,
+				OldName: e.Name,
+				OldKind: e.Kind,
+			})
+
+			continue
+		}
+
+		if u.Name != e.Name {
+			rename = append(rename, &ModuleMigrationStep{
+				FieldID: e.ID,
+				Action:  ModuleMigrationRename,
+				OldName: e.Name,
+				NewName: u.Name,
+			})
+		}
+
+		if !strings.EqualFold(u.Kind, e.Kind) {
+			convert = append(convert, &ModuleMigrationStep{
+				FieldID: e.ID,
+				Action:  ModuleMigrationConvert,
+				OldName: e.Name,
+				NewName: u.Name,
+				OldKind: e.Kind,
+				NewKind: u.Kind,
+			})
+		}
+	}
+
+	ss = append(ss, archive...)
+	ss = append(ss, rename...)
+	return append(ss, convert...)
+}
+
+// IsDestructive returns true if step might archive any of the existing values
+//
+// Anything can be converted to a string without loosing data
+func (s ModuleMigrationStep) IsDestructive() bool {
+	if s.Values == 0 {
+		return false
+	}
+
+	switch s.Action {
+	case ModuleMigrationArchive:
+		return true
+	case ModuleMigrationConvert:
+		return !strings.EqualFold(s.NewKind, "string")
+	}
+
+	return false
+}
+
+// IsDestructive returns true if any of the steps is destructive
+func (set ModuleMigrationStepSet) IsDestructive() bool {
+	for i := range set {
+		if set[i].IsDestructive() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FilterByAction returns steps with the given action
+func (set ModuleMigrationStepSet) FilterByAction(action ModuleMigrationStepAction) (out ModuleMigrationStepSet) {
+	for i := range set {
+		if set[i].Action == action {
+			out = append(out, set[i])
+		}
+	}
+
+	return
+}
+
+func (set *ModuleMigrationStepSet) Scan(value interface{}) error {
+	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
+	switch value.(type) {
+	case nil:
+		*set = ModuleMigrationStepSet{}
+	case []uint8:
+		b := value.([]byte)
+		if err := json.Unmarshal(b, set); err != nil {
+			return errors.Wrapf(err, "Can not scan '%v' into ModuleMigrationStepSet", string(b))
+		}
+	}
+
+	return nil
+}
+
+func (set ModuleMigrationStepSet) Value() (driver.Value, error) {
+	return json.Marshal(set)
+}
@@ -0,0 +1,81 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlanModuleMigration(t *testing.T) {
+	var (
+		existing = ModuleFieldSet{
+			{ID: 1, Name: "kept", Kind: "String"},
+			{ID: 2, Name: "renamed", Kind: "String"},
+			{ID: 3, Name: "retyped", Kind: "String"},
+			{ID: 4, Name: "removed", Kind: "Number"},
+			{ID: 5, Name: "both", Kind: "String"},
+		}
+
+		updated = ModuleFieldSet{
+			{ID: 1, Name: "kept", Kind: "string"},
+			{ID: 2, Name: "renamed_new", Kind: "String"},
+			{ID: 3, Name: "retyped", Kind: "Number"},
+			{ID: 5, Name: "both_new", Kind: "Bool"},
+			{Name: "added", Kind: "String"},
+		}
+
+		want = ModuleMigrationStepSet{
+			{FieldID: 4, Action: ModuleMigrationArchive, OldName: "removed", OldKind: "Number"},
+			{FieldID: 2, Action: ModuleMigrationRename, OldName: "renamed", NewName: "renamed_new"},
+			{FieldID: 5, Action: ModuleMigrationRename, OldName: "both", NewName: "both_new"},
+			{FieldID: 3, Action: ModuleMigrationConvert, OldName: "retyped", NewName: "retyped", OldKind: "String", NewKind: "Number"},
+			{FieldID: 5, Action: ModuleMigrationConvert, OldName: "both", NewName: "both_new", OldKind: "String", NewKind: "Bool"},
+		}
+	)
+
+	if got := PlanModuleMigration(existing, updated); !reflect.DeepEqual(got, want) {
+		t.Errorf("PlanModuleMigration() = %v, want %v", got, want)
+	}
+
+	if got := PlanModuleMigration(existing, existing); len(got) != 0 {
+		t.Errorf("PlanModuleMigration() = %v, want no steps", got)
+	}
+}
+
+func TestModuleMigrationStep_IsDestructive(t *testing.T) {
+	tests := []struct {
+		name string
+		step ModuleMigrationStep
+		want bool
+	}{
+		{
+			name: "rename",
+			step: ModuleMigrationStep{Action: ModuleMigrationRename, Values: 10},
+		},
+		{
+			name: "archive",
+			step: ModuleMigrationStep{Action: ModuleMigrationArchive, Values: 10},
+			want: true,
+		},
+		{
+			name: "archive without values",
+			step: ModuleMigrationStep{Action: ModuleMigrationArchive},
+		},
+		{
+			name: "convert",
+			step: ModuleMigrationStep{Action: ModuleMigrationConvert, NewKind: "Number", Values: 10},
+			want: true,
+		},
+		{
+			name: "convert to string",
+			step: ModuleMigrationStep{Action: ModuleMigrationConvert, NewKind: "String", Values: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.step.IsDestructive(); got != tt.want {
+				t.Errorf("IsDestructive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
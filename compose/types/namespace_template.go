@@ -0,0 +1,125 @@
+package types
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cortezaproject/corteza-server/pkg/permissions"
+)
+
+type (
+	// NamespaceTemplate is a self-contained copy of namespace structure
+	// (and optionally records) that can be installed as a new namespace
+	//
+	// Resources keep IDs from the source namespace; they are only used to
+	// resolve references between resources. Everything gets new IDs when template is installed.
+	NamespaceTemplate struct {
+		Version int `json:"version"`
+
+		Namespace *Namespace `json:"namespace"`
+		Modules   ModuleSet  `json:"modules"`
+		Pages     PageSet    `json:"pages"`
+		Charts    ChartSet   `json:"charts"`
+		Records   RecordSet  `json:"records,omitempty"`
+
+		// Attachments of the records in the template
+		Attachments AttachmentSet `json:"attachments,omitempty"`
+
+		// Access rules for namespace and all its resources
+		Rules permissions.RuleSet `json:"rules,omitempty"`
+
+		// Files gives access to the stored attachment files
+		Files NamespaceTemplateFiles `json:"-"`
+	}
+
+	// NamespaceTemplateFiles opens files of the template attachments
+	//
+	// Depending on where template comes from, files are read from the
+	// store or from the template package
+	NamespaceTemplateFiles interface {
+		Original(*Attachment) (io.ReadSeeker, error)
+		Preview(*Attachment) (io.ReadSeeker, error)
+	}
+
+	// IDMap keeps track of IDs of resources in the source namespace
+	// and their copies
+	IDMap map[uint64]uint64
+)
+
+const (
+	NamespaceTemplateVersion = 1
+)
+
+// Get returns new ID or the given one when resource was not copied
+//
+// References to resources outside of the namespace are kept as they are
+func (m IDMap) Get(ID uint64) uint64 {
+	if n, ok := m[ID]; ok {
+		return n
+	}
+
+	return ID
+}
+
+// Resource rewrites ID of the permission resource
+//
+// Returns false if resource does not point to any of the copied resources
+func (m IDMap) Resource(r permissions.Resource) (permissions.Resource, bool) {
+	if r.HasWildcard() {
+		return r, false
+	}
+
+	var (
+		prefix = r.TrimID()
+		ID, _  = strconv.ParseUint(strings.TrimPrefix(r.String(), prefix.String()), 10, 64)
+	)
+
+	if n, ok := m[ID]; ok && prefix != r {
+		return prefix.AppendID(n), true
+	}
+
+	return r, false
+}
+
+// Options rewrites IDs (keys with "ID" suffix) in block or field options, nested values included
+//
+// IDs are always stored as strings
+func (m IDMap) Options(oo map[string]interface{}) {
+	for k, v := range oo {
+		switch val := v.(type) {
+		case map[string]interface{}:
+			m.Options(val)
+		case []interface{}:
+			for _, i := range val {
+				if nested, ok := i.(map[string]interface{}); ok {
+					m.Options(nested)
+				}
+			}
+		default:
+			if !strings.HasSuffix(k, "ID") {
+				continue
+			}
+
+			if ID := m.parse(v); ID > 0 {
+				if n, ok := m[ID]; ok {
+					oo[k] = strconv.FormatUint(n, 10)
+				}
+			}
+		}
+	}
+}
+
+func (IDMap) parse(v interface{}) uint64 {
+	switch val := v.(type) {
+	case string:
+		ID, _ := strconv.ParseUint(val, 10, 64)
+		return ID
+	case uint64:
+		return val
+	case float64:
+		return uint64(val)
+	}
+
+	return 0
+}
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cortezaproject/corteza-server/pkg/permissions"
+)
+
+func TestIDMapOptions(t *testing.T) {
+	var (
+		ids = IDMap{1: 10, 2: 20}
+
+		oo = map[string]interface{}{
+			"moduleID": "1",
+			"pageID":   float64(2),
+			"otherID":  "3",
+			"label":    "1",
+			"nested": map[string]interface{}{
+				"moduleID": "2",
+			},
+			"list": []interface{}{
+				map[string]interface{}{"recordID": "1"},
+			},
+		}
+
+		want = map[string]interface{}{
+			"moduleID": "10",
+			"pageID":   "20",
+			"otherID":  "3",
+			"label":    "1",
+			"nested": map[string]interface{}{
+				"moduleID": "20",
+			},
+			"list": []interface{}{
+				map[string]interface{}{"recordID": "10"},
+			},
+		}
+	)
+
+	ids.Options(oo)
+
+	if !reflect.DeepEqual(oo, want) {
+		t.Errorf("IDMap.Options() = %v, want %v", oo, want)
+	}
+}
+
+func TestIDMapResource(t *testing.T) {
+	var (
+		ids = IDMap{1: 10}
+	)
+
+	tests := []struct {
+		name string
+		res  permissions.Resource
+		want permissions.Resource
+		ok   bool
+	}{
+		{"copied", ModulePermissionResource.AppendID(1), ModulePermissionResource.AppendID(10), true},
+		{"not copied", ModulePermissionResource.AppendID(2), ModulePermissionResource.AppendID(2), false},
+		{"wildcard", ModulePermissionResource.AppendWildcard(), ModulePermissionResource.AppendWildcard(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ids.Resource(tt.res)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("IDMap.Resource() = %v, %v, want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -37,6 +37,12 @@ type (
 		rh.PageFilter
 
 		Deleted rh.FilterState `json:"deleted"`
+
+		// Only records with greater ID (keyset paging for chunked iteration)
+		AfterID uint64 `json:"-"`
+
+		// Only records with lower ID (upper bound for chunked iteration)
+		BeforeID uint64 `json:"-"`
 	}
 )
 
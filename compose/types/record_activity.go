@@ -0,0 +1,50 @@
+package types
+
+import (
+	"sort"
+	"time"
+)
+
+type (
+	// RecordActivity is an entry in record's activity feed
+	RecordActivity struct {
+		Kind      RecordActivityKind `json:"kind"`
+		Timestamp time.Time          `json:"timestamp"`
+		UserID    uint64             `json:"userID,string"`
+
+		Comment    *RecordComment    `json:"comment,omitempty"`
+		Change     *RecordChange     `json:"change,omitempty"`
+		Transition *RecordTransition `json:"transition,omitempty"`
+	}
+
+	RecordActivitySet []*RecordActivity
+
+	RecordActivityKind string
+)
+
+const (
+	RecordActivityComment    RecordActivityKind = "comment"
+	RecordActivityChange     RecordActivityKind = "change"
+	RecordActivityTransition RecordActivityKind = "transition"
+)
+
+// MakeRecordActivity combines comments, changes and transitions into a single, chronologically ordered set
+func MakeRecordActivity(cc RecordCommentSet, ch RecordChangeSet, tt RecordTransitionSet) (set RecordActivitySet) {
+	for _, c := range cc {
+		set = append(set, &RecordActivity{Kind: RecordActivityComment, Timestamp: c.CreatedAt, UserID: c.CreatedBy, Comment: c})
+	}
+
+	for _, c := range ch {
+		set = append(set, &RecordActivity{Kind: RecordActivityChange, Timestamp: c.CreatedAt, UserID: c.CreatedBy, Change: c})
+	}
+
+	for _, t := range tt {
+		set = append(set, &RecordActivity{Kind: RecordActivityTransition, Timestamp: t.CreatedAt, UserID: t.CreatedBy, Transition: t})
+	}
+
+	sort.SliceStable(set, func(i, j int) bool {
+		return set[i].Timestamp.Before(set[j].Timestamp)
+	})
+
+	return
+}
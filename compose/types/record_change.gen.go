@@ -0,0 +1,67 @@
+package types
+
+// 	Hello! This file is auto-generated.
+
+type (
+
+	// RecordChangeSet slice of RecordChange
+	//
+	// This type is auto-generated.
+	RecordChangeSet []*RecordChange
+)
+
+// Walk iterates through every slice item and calls w(RecordChange) err
+//
+// This function is auto-generated.
+func (set RecordChangeSet) Walk(w func(*RecordChange) error) (err error) {
+	for i := range set {
+		if err = w(set[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Filter iterates through every slice item, calls f(RecordChange) (bool, err) and return filtered slice
+//
+// This function is auto-generated.
+func (set RecordChangeSet) Filter(f func(*RecordChange) (bool, error)) (out RecordChangeSet, err error) {
+	var ok bool
+	out = RecordChangeSet{}
+	for i := range set {
+		if ok, err = f(set[i]); err != nil {
+			return
+		} else if ok {
+			out = append(out, set[i])
+		}
+	}
+
+	return
+}
+
+// FindByID finds items from slice by its ID property
+//
+// This function is auto-generated.
+func (set RecordChangeSet) FindByID(ID uint64) *RecordChange {
+	for i := range set {
+		if set[i].ID == ID {
+			return set[i]
+		}
+	}
+
+	return nil
+}
+
+// IDs returns a slice of uint64s from all items in the set
+//
+// This function is auto-generated.
+func (set RecordChangeSet) IDs() (IDs []uint64) {
+	IDs = make([]uint64, len(set))
+
+	for i := range set {
+		IDs[i] = set[i].ID
+	}
+
+	return
+}
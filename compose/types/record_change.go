@@ -0,0 +1,129 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	// RecordChange holds changed field values of a single record update
+	RecordChange struct {
+		ID          uint64 `json:"changeID,string" db:"id"`
+		NamespaceID uint64 `json:"namespaceID,string" db:"rel_namespace"`
+		ModuleID    uint64 `json:"moduleID,string" db:"rel_module"`
+		RecordID    uint64 `json:"recordID,string" db:"rel_record"`
+
+		Fields RecordFieldChangeSet `json:"fields" db:"fields"`
+
+		CreatedAt time.Time `json:"createdAt,omitempty" db:"created_at"`
+		CreatedBy uint64    `json:"createdBy,string" db:"created_by"`
+	}
+
+	RecordChangeFilter struct {
+		NamespaceID uint64 `json:"namespaceID,string"`
+		ModuleID    uint64 `json:"moduleID,string"`
+		RecordID    uint64 `json:"recordID,string"`
+
+		Sort string `json:"sort"`
+
+		// Standard paging fields & helpers
+		rh.PageFilter
+	}
+
+	// RecordFieldChange holds old and new values of a (multi-value) field
+	RecordFieldChange struct {
+		Field string   `json:"field"`
+		Old   []string `json:"old"`
+		New   []string `json:"new"`
+	}
+
+	RecordFieldChangeSet []*RecordFieldChange
+)
+
+// Changes compares values of all given fields and returns the ones that differ
+func (set RecordValueSet) Changes(new RecordValueSet, fields ...string) (cc RecordFieldChangeSet) {
+	for _, name := range fields {
+		var (
+			o = set.FilterByName(name).values()
+			n = new.FilterByName(name).values()
+		)
+
+		if !equalStrings(o, n) {
+			cc = append(cc, &RecordFieldChange{Field: name, Old: o, New: n})
+		}
+	}
+
+	return
+}
+
+// FilterByField returns changes of the given fields only
+func (set RecordFieldChangeSet) FilterByField(fields ...string) (out RecordFieldChangeSet) {
+	var ff = make(map[string]bool, len(fields))
+	for _, f := range fields {
+		ff[f] = true
+	}
+
+	for _, c := range set {
+		if ff[c.Field] {
+			out = append(out, c)
+		}
+	}
+
+	return
+}
+
+func (set *RecordFieldChangeSet) Scan(value interface{}) error {
+	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
+	switch value.(type) {
+	case nil:
+		*set = RecordFieldChangeSet{}
+	case []uint8:
+		b := value.([]byte)
+		if err := json.Unmarshal(b, set); err != nil {
+			return errors.Wrapf(err, "Can not scan '%v' into RecordFieldChangeSet", string(b))
+		}
+	}
+
+	return nil
+}
+
+func (set RecordFieldChangeSet) Value() (driver.Value, error) {
+	return json.Marshal(set)
+}
+
+// values returns non-deleted values, ordered by place
+func (set RecordValueSet) values() (vv []string) {
+	var sorted = append(RecordValueSet{}, set...)
+	sort.Stable(sorted)
+
+	vv = []string{}
+	for _, v := range sorted {
+		if v.IsDeleted() {
+			continue
+		}
+
+		vv = append(vv, v.Value)
+	}
+
+	return
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
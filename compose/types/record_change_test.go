@@ -0,0 +1,55 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordValueSet_Changes(t *testing.T) {
+	var (
+		a = assert.New(t)
+
+		old = RecordValueSet{
+			{Name: "title", Value: "foo"},
+			{Name: "tags", Value: "a", Place: 0},
+			{Name: "tags", Value: "b", Place: 1},
+			{Name: "note", Value: "same"},
+		}
+
+		new = RecordValueSet{
+			{Name: "title", Value: "bar"},
+			{Name: "tags", Value: "a", Place: 0},
+			{Name: "note", Value: "same"},
+			{Name: "status", Value: "draft"},
+		}
+
+		cc = old.Changes(new, "title", "tags", "note", "status")
+	)
+
+	a.Len(cc, 3)
+	a.Equal(&RecordFieldChange{Field: "title", Old: []string{"foo"}, New: []string{"bar"}}, cc[0])
+	a.Equal(&RecordFieldChange{Field: "tags", Old: []string{"a", "b"}, New: []string{"a"}}, cc[1])
+	a.Equal(&RecordFieldChange{Field: "status", Old: []string{}, New: []string{"draft"}}, cc[2])
+
+	a.Len(cc.FilterByField("title", "status"), 2)
+}
+
+func TestMakeRecordActivity(t *testing.T) {
+	var (
+		a   = assert.New(t)
+		now = time.Now()
+
+		set = MakeRecordActivity(
+			RecordCommentSet{{ID: 1, CreatedAt: now.Add(2 * time.Minute)}},
+			RecordChangeSet{{ID: 2, CreatedAt: now}},
+			RecordTransitionSet{{ID: 3, CreatedAt: now.Add(time.Minute)}},
+		)
+	)
+
+	a.Len(set, 3)
+	a.Equal(RecordActivityChange, set[0].Kind)
+	a.Equal(RecordActivityTransition, set[1].Kind)
+	a.Equal(RecordActivityComment, set[2].Kind)
+}
@@ -0,0 +1,67 @@
+package types
+
+// 	Hello! This file is auto-generated.
+
+type (
+
+	// RecordCommentSet slice of RecordComment
+	//
+	// This type is auto-generated.
+	RecordCommentSet []*RecordComment
+)
+
+// Walk iterates through every slice item and calls w(RecordComment) err
+//
+// This function is auto-generated.
+func (set RecordCommentSet) Walk(w func(*RecordComment) error) (err error) {
+	for i := range set {
+		if err = w(set[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Filter iterates through every slice item, calls f(RecordComment) (bool, err) and return filtered slice
+//
+// This function is auto-generated.
+func (set RecordCommentSet) Filter(f func(*RecordComment) (bool, error)) (out RecordCommentSet, err error) {
+	var ok bool
+	out = RecordCommentSet{}
+	for i := range set {
+		if ok, err = f(set[i]); err != nil {
+			return
+		} else if ok {
+			out = append(out, set[i])
+		}
+	}
+
+	return
+}
+
+// FindByID finds items from slice by its ID property
+//
+// This function is auto-generated.
+func (set RecordCommentSet) FindByID(ID uint64) *RecordComment {
+	for i := range set {
+		if set[i].ID == ID {
+			return set[i]
+		}
+	}
+
+	return nil
+}
+
+// IDs returns a slice of uint64s from all items in the set
+//
+// This function is auto-generated.
+func (set RecordCommentSet) IDs() (IDs []uint64) {
+	IDs = make([]uint64, len(set))
+
+	for i := range set {
+		IDs[i] = set[i].ID
+	}
+
+	return
+}
@@ -0,0 +1,101 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	// RecordComment is a comment on a record
+	RecordComment struct {
+		ID          uint64 `json:"commentID,string" db:"id"`
+		NamespaceID uint64 `json:"namespaceID,string" db:"rel_namespace"`
+		ModuleID    uint64 `json:"moduleID,string" db:"rel_module"`
+		RecordID    uint64 `json:"recordID,string" db:"rel_record"`
+
+		// Comment this one is a reply to
+		ReplyTo uint64 `json:"replyTo,string,omitempty" db:"reply_to"`
+
+		Message string `json:"message" db:"message"`
+
+		// Users mentioned in the message
+		Mentions RecordCommentRefs `json:"mentions" db:"mentions"`
+
+		// Record attachments added to the comment
+		Attachments RecordCommentRefs `json:"attachments" db:"attachments"`
+
+		CreatedAt time.Time  `json:"createdAt,omitempty" db:"created_at"`
+		CreatedBy uint64     `json:"createdBy,string" db:"created_by"`
+		UpdatedAt *time.Time `json:"updatedAt,omitempty" db:"updated_at"`
+		DeletedAt *time.Time `json:"deletedAt,omitempty" db:"deleted_at"`
+	}
+
+	RecordCommentFilter struct {
+		NamespaceID uint64 `json:"namespaceID,string"`
+		ModuleID    uint64 `json:"moduleID,string"`
+		RecordID    uint64 `json:"recordID,string"`
+
+		Sort string `json:"sort"`
+
+		// Standard paging fields & helpers
+		rh.PageFilter
+	}
+
+	// RecordCommentRefs is a list of IDs (users, attachments) referenced from the comment
+	//
+	// IDs are encoded as strings
+	RecordCommentRefs []uint64
+)
+
+func (refs RecordCommentRefs) MarshalJSON() ([]byte, error) {
+	ss := make([]string, len(refs))
+	for i, ID := range refs {
+		ss[i] = strconv.FormatUint(ID, 10)
+	}
+
+	return json.Marshal(ss)
+}
+
+func (refs *RecordCommentRefs) UnmarshalJSON(data []byte) error {
+	var ss []string
+	if err := json.Unmarshal(data, &ss); err != nil {
+		return err
+	}
+
+	*refs = make(RecordCommentRefs, 0, len(ss))
+	for _, s := range ss {
+		ID, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		*refs = append(*refs, ID)
+	}
+
+	return nil
+}
+
+func (refs *RecordCommentRefs) Scan(value interface{}) error {
+	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
+	switch value.(type) {
+	case nil:
+		*refs = RecordCommentRefs{}
+	case []uint8:
+		b := value.([]byte)
+		if err := json.Unmarshal(b, refs); err != nil {
+			return errors.Wrapf(err, "Can not scan '%v' into RecordCommentRefs", string(b))
+		}
+	}
+
+	return nil
+}
+
+func (refs RecordCommentRefs) Value() (driver.Value, error) {
+	return json.Marshal(refs)
+}
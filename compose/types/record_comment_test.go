@@ -0,0 +1,25 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordCommentRefs_JSON(t *testing.T) {
+	var (
+		a    = assert.New(t)
+		refs = RecordCommentRefs{1, 18446744073709551615}
+		out  RecordCommentRefs
+	)
+
+	b, err := json.Marshal(refs)
+	a.NoError(err)
+	a.Equal(`["1","18446744073709551615"]`, string(b))
+
+	a.NoError(json.Unmarshal(b, &out))
+	a.Equal(refs, out)
+
+	a.Error(json.Unmarshal([]byte(`["foo"]`), &out))
+}
@@ -0,0 +1,314 @@
+package types
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+type (
+	// RecordDuplicateRules configure how duplicate records are detected
+	//
+	// Rules are stored under "duplicates" key in module meta
+	RecordDuplicateRules struct {
+		// Records with score equal or above threshold are considered duplicates
+		Threshold float64 `json:"threshold"`
+
+		Rules []*RecordDuplicateRule `json:"rules"`
+	}
+
+	RecordDuplicateRule struct {
+		Field  string               `json:"field"`
+		Match  RecordDuplicateMatch `json:"match"`
+		Weight float64              `json:"weight,omitempty"`
+	}
+
+	RecordDuplicateMatch string
+
+	// RecordDuplicate is a record that is likely a duplicate of another record
+	RecordDuplicate struct {
+		RecordID uint64  `json:"recordID,string"`
+		Score    float64 `json:"score"`
+
+		// Fields with matching values
+		Fields []string `json:"fields"`
+	}
+
+	RecordDuplicateSet []*RecordDuplicate
+
+	// RecordDuplicateCluster is a group of records that are likely duplicates of each other
+	RecordDuplicateCluster struct {
+		// Highest score between any two of the records
+		Score   float64            `json:"score"`
+		Records RecordDuplicateSet `json:"records"`
+	}
+)
+
+const (
+	// Normalized (trimmed, lowercase) values must be equal
+	RecordDuplicateMatchExact RecordDuplicateMatch = "exact"
+
+	// Emails are compared without sub-address (+tag) part
+	RecordDuplicateMatchEmail RecordDuplicateMatch = "email"
+
+	// Phone numbers are compared by digits only, ignoring leading zeros
+	RecordDuplicateMatchPhone RecordDuplicateMatch = "phone"
+
+	// Names are compared regardless of word order, case and punctuation
+	// with score depending on edit distance
+	RecordDuplicateMatchName RecordDuplicateMatch = "name"
+
+	recordDuplicatesMetaKey = "duplicates"
+
+	recordDuplicateDefaultThreshold = 0.8
+)
+
+// DuplicateRules returns duplicate detection rules from module meta or nil
+func (m Module) DuplicateRules() *RecordDuplicateRules {
+	var (
+		meta = struct {
+			Duplicates *RecordDuplicateRules `json:"duplicates"`
+		}{}
+	)
+
+	if len(m.Meta) == 0 || json.Unmarshal(m.Meta, &meta) != nil {
+		return nil
+	}
+
+	if meta.Duplicates == nil || len(meta.Duplicates.Rules) == 0 {
+		return nil
+	}
+
+	if meta.Duplicates.Threshold <= 0 {
+		meta.Duplicates.Threshold = recordDuplicateDefaultThreshold
+	}
+
+	for _, r := range meta.Duplicates.Rules {
+		if r.Weight <= 0 {
+			r.Weight = 1
+		}
+	}
+
+	return meta.Duplicates
+}
+
+// Fields returns names of all fields used by the rules
+func (dr RecordDuplicateRules) Fields() []string {
+	ff := make([]string, len(dr.Rules))
+	for i, r := range dr.Rules {
+		ff[i] = r.Field
+	}
+
+	return ff
+}
+
+// Compare scores similarity of two records' values
+//
+// Score is weighted average of similarity of all rule fields
+func (dr RecordDuplicateRules) Compare(a, b RecordValueSet) (score float64, fields []string) {
+	var total float64
+
+	for _, r := range dr.Rules {
+		total += r.Weight
+
+		var best float64
+		for _, av := range a.FilterByName(r.Field) {
+			for _, bv := range b.FilterByName(r.Field) {
+				if s := r.similarity(av.Value, bv.Value); s > best {
+					best = s
+				}
+			}
+		}
+
+		if best > 0 {
+			score += best * r.Weight
+			fields = append(fields, r.Field)
+		}
+	}
+
+	if total == 0 {
+		return 0, nil
+	}
+
+	return score / total, fields
+}
+
+// Keys returns blocking keys for the values
+//
+// Only records that share at least one key are compared
+func (dr RecordDuplicateRules) Keys(vv RecordValueSet) (kk []string) {
+	for _, r := range dr.Rules {
+		for _, v := range vv.FilterByName(r.Field) {
+			n := r.normalize(v.Value)
+			if n == "" {
+				continue
+			}
+
+			if r.Match == RecordDuplicateMatchName {
+				for _, token := range strings.Fields(n) {
+					kk = append(kk, r.Field+":"+token)
+				}
+			} else {
+				kk = append(kk, r.Field+":"+n)
+			}
+		}
+	}
+
+	return
+}
+
+// Patterns returns (SQL LIKE) patterns for values that might share a blocking key with any of the values
+//
+// Patterns are lowercase and should be matched against trimmed, lowercase values;
+// they match every value with a shared key (and possibly some others)
+func (dr RecordDuplicateRules) Patterns(vv RecordValueSet) map[string][]string {
+	var (
+		pp   = map[string][]string{}
+		seen = map[string]bool{}
+	)
+
+	for _, r := range dr.Rules {
+		for _, v := range vv.FilterByName(r.Field) {
+			for _, p := range r.patterns(r.normalize(v.Value)) {
+				if !seen[r.Field+":"+p] {
+					seen[r.Field+":"+p] = true
+					pp[r.Field] = append(pp[r.Field], p)
+				}
+			}
+		}
+	}
+
+	return pp
+}
+
+func (r RecordDuplicateRule) patterns(n string) []string {
+	if n == "" {
+		return nil
+	}
+
+	switch r.Match {
+	case RecordDuplicateMatchEmail:
+		if at := strings.LastIndex(n, "@"); at > 0 {
+			// with or without sub-address
+			return []string{escapeLike(n), escapeLike(n[:at]) + "+%@" + escapeLike(n[at+1:])}
+		}
+
+	case RecordDuplicateMatchPhone:
+		// same digits in the same order, formatting and leading zeros are ignored
+		return []string{"%" + strings.Join(strings.Split(n, ""), "%") + "%"}
+
+	case RecordDuplicateMatchName:
+		var pp []string
+		for _, token := range strings.Fields(n) {
+			pp = append(pp, "%"+escapeLike(token)+"%")
+		}
+
+		return pp
+	}
+
+	return []string{escapeLike(n)}
+}
+
+func (r RecordDuplicateRule) similarity(a, b string) float64 {
+	a, b = r.normalize(a), r.normalize(b)
+
+	if a == "" || b == "" {
+		return 0
+	}
+
+	if a == b {
+		return 1
+	}
+
+	if r.Match != RecordDuplicateMatchName {
+		return 0
+	}
+
+	var (
+		ra, rb = []rune(a), []rune(b)
+		max    = len(ra)
+	)
+
+	if len(rb) > max {
+		max = len(rb)
+	}
+
+	return 1 - float64(levenshtein(ra, rb))/float64(max)
+}
+
+func (r RecordDuplicateRule) normalize(v string) string {
+	v = strings.ToLower(strings.TrimSpace(v))
+
+	switch r.Match {
+	case RecordDuplicateMatchEmail:
+		if at := strings.LastIndex(v, "@"); at > 0 {
+			local, domain := v[:at], v[at+1:]
+			if plus := strings.Index(local, "+"); plus > 0 {
+				local = local[:plus]
+			}
+
+			v = local + "@" + domain
+		}
+
+	case RecordDuplicateMatchPhone:
+		v = strings.TrimLeft(strings.Map(func(r rune) rune {
+			if unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, v), "0")
+
+	case RecordDuplicateMatchName:
+		tokens := strings.FieldsFunc(v, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+
+		sort.Strings(tokens)
+		v = strings.Join(tokens, " ")
+	}
+
+	return v
+}
+
+func escapeLike(v string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(v)
+}
+
+// levenshtein returns edit distance between two strings
+func levenshtein(a, b []rune) int {
+	var (
+		prev = make([]int, len(b)+1)
+		curr = make([]int, len(b)+1)
+	)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func minInt(first int, rest ...int) int {
+	for _, v := range rest {
+		if v < first {
+			first = v
+		}
+	}
+
+	return first
+}
@@ -0,0 +1,107 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestModuleDuplicateRules(t *testing.T) {
+	var (
+		req = require.New(t)
+		m   = Module{Meta: []byte(`{"duplicates":{"rules":[{"field":"email","match":"email","weight":2},{"field":"name","match":"name"}]}}`)}
+	)
+
+	dr := m.DuplicateRules()
+	req.NotNil(dr)
+	req.Equal(recordDuplicateDefaultThreshold, dr.Threshold)
+	req.Equal([]string{"email", "name"}, dr.Fields())
+	req.Equal(float64(1), dr.Rules[1].Weight)
+
+	req.Nil(Module{}.DuplicateRules())
+	req.Nil(Module{Meta: []byte(`{"duplicates":{"rules":[]}}`)}.DuplicateRules())
+}
+
+func TestRecordDuplicateRulesCompare(t *testing.T) {
+	var (
+		dr = RecordDuplicateRules{
+			Rules: []*RecordDuplicateRule{
+				{Field: "email", Match: RecordDuplicateMatchEmail, Weight: 2},
+				{Field: "phone", Match: RecordDuplicateMatchPhone, Weight: 1},
+				{Field: "name", Match: RecordDuplicateMatchName, Weight: 1},
+			},
+		}
+
+		vv = func(email, phone, name string) RecordValueSet {
+			return RecordValueSet{
+				{Name: "email", Value: email},
+				{Name: "phone", Value: phone},
+				{Name: "name", Value: name},
+			}
+		}
+	)
+
+	tests := []struct {
+		name  string
+		a, b  RecordValueSet
+		score float64
+	}{
+		{"same", vv("john@example.tld", "+386 40 123 456", "John Doe"), vv("John+crm@Example.tld ", "38640123456", "doe, john"), 1},
+		{"different", vv("john@example.tld", "111", "John Doe"), vv("jane@example.tld", "222", "Jane Roe"), 0.0625},
+		{"email only", vv("john@example.tld", "111", "John"), vv("john@example.tld", "222", "Mike"), 0.5},
+		{"empty", vv("", "", ""), vv("", "", ""), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, _ := dr.Compare(tt.a, tt.b)
+			require.InDelta(t, tt.score, score, 0.01)
+		})
+	}
+}
+
+func TestRecordDuplicateRulesKeys(t *testing.T) {
+	var (
+		dr = RecordDuplicateRules{
+			Rules: []*RecordDuplicateRule{
+				{Field: "email", Match: RecordDuplicateMatchEmail},
+				{Field: "name", Match: RecordDuplicateMatchName},
+			},
+		}
+	)
+
+	require.Equal(t,
+		[]string{"email:john@example.tld", "name:doe", "name:john"},
+		dr.Keys(RecordValueSet{{Name: "email", Value: "John+x@example.tld"}, {Name: "name", Value: "John Doe"}}),
+	)
+}
+
+func TestRecordDuplicateRulesPatterns(t *testing.T) {
+	var (
+		dr = RecordDuplicateRules{
+			Rules: []*RecordDuplicateRule{
+				{Field: "email", Match: RecordDuplicateMatchEmail},
+				{Field: "phone", Match: RecordDuplicateMatchPhone},
+				{Field: "name", Match: RecordDuplicateMatchName},
+				{Field: "code", Match: RecordDuplicateMatchExact},
+			},
+		}
+	)
+
+	require.Equal(t,
+		map[string][]string{
+			"email": {"john@example.tld", "john+%@example.tld"},
+			"phone": {"%4%0%1%2%3%"},
+			"name":  {"%doe%", "%john%"},
+			"code":  {`a\_1\%`},
+		},
+		dr.Patterns(RecordValueSet{
+			{Name: "email", Value: "John+x@example.tld"},
+			{Name: "phone", Value: "040 123"},
+			{Name: "name", Value: "John Doe"},
+			{Name: "name", Value: "Doe"},
+			{Name: "code", Value: " A_1% "},
+			{Name: "other", Value: "foo"},
+		}),
+	)
+}
@@ -0,0 +1,49 @@
+package types
+
+type (
+	// RecordRefOnDelete is a policy of record-reference field
+	// that is applied when referenced record is deleted
+	//
+	// Policy is stored under "onDelete" key in field options
+	RecordRefOnDelete string
+
+	// RecordReference is a value of record-reference field that points to another record
+	RecordReference struct {
+		NamespaceID uint64 `json:"namespaceID,string"`
+		ModuleID    uint64 `json:"moduleID,string"`
+		RecordID    uint64 `json:"recordID,string"`
+		Field       string `json:"field"`
+
+		// Referenced record
+		Ref uint64 `json:"ref,string"`
+
+		OnDelete RecordRefOnDelete `json:"onDelete"`
+	}
+
+	RecordReferenceSet []*RecordReference
+)
+
+const (
+	// References are kept as they are
+	RecordRefOnDeleteNone RecordRefOnDelete = ""
+
+	// Referenced record can not be deleted
+	RecordRefOnDeleteRestrict RecordRefOnDelete = "restrict"
+
+	// Reference value is removed
+	RecordRefOnDeleteSetNull RecordRefOnDelete = "setNull"
+
+	// Referencing record is deleted as well
+	RecordRefOnDeleteCascade RecordRefOnDelete = "cascade"
+)
+
+// FilterByOnDelete returns references with the given on-delete policy
+func (set RecordReferenceSet) FilterByOnDelete(p RecordRefOnDelete) (out RecordReferenceSet) {
+	for _, r := range set {
+		if r.OnDelete == p {
+			out = append(out, r)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,315 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// RecordReport describes aggregation over module's records
+	//
+	// Metrics and dimensions can refer to fields of the module or, using dot notation,
+	// to fields of modules referenced via record fields (eg: "account.industry")
+	RecordReport struct {
+		NamespaceID uint64 `json:"namespaceID,string"`
+		ModuleID    uint64 `json:"moduleID,string"`
+
+		Metrics    RecordReportMetricSet    `json:"metrics"`
+		Dimensions RecordReportDimensionSet `json:"dimensions"`
+
+		// Record filter, same syntax as with record reports
+		Filter string `json:"filter,omitempty"`
+
+		// Conditions on metric values, applied after aggregation
+		Having RecordReportConditionSet `json:"having,omitempty"`
+	}
+
+	RecordReportMetric struct {
+		Alias string `json:"alias,omitempty"`
+
+		// Field or path to the field of the referenced module
+		// Can be omitted when records are counted
+		Field     string                `json:"field,omitempty"`
+		Aggregate RecordReportAggregate `json:"aggregate"`
+
+		// Percentile (0-100) used with PERCENTILE aggregate
+		Percentile float64 `json:"percentile,omitempty"`
+
+		// Alias of the metric this metric's value is divided by
+		DivideBy string `json:"divideBy,omitempty"`
+
+		// Replace values with running totals along the first dimension
+		Cumulative bool `json:"cumulative,omitempty"`
+	}
+
+	RecordReportMetricSet []*RecordReportMetric
+
+	RecordReportDimension struct {
+		Alias string `json:"alias,omitempty"`
+
+		// Field or path to the field of the referenced module
+		Field string `json:"field"`
+
+		// Groups date & time values into buckets
+		Bucket RecordReportBucket `json:"bucket,omitempty"`
+
+		// Adds empty (zero) rows for buckets without records
+		// Only supported on the first dimension
+		FillGaps bool `json:"fillGaps,omitempty"`
+	}
+
+	RecordReportDimensionSet []*RecordReportDimension
+
+	// RecordReportCondition filters out rows with metric values that do not match
+	RecordReportCondition struct {
+		Metric   string  `json:"metric"`
+		Operator string  `json:"operator"`
+		Value    float64 `json:"value"`
+	}
+
+	RecordReportConditionSet []*RecordReportCondition
+
+	RecordReportAggregate string
+	RecordReportBucket    string
+
+	// RecordReportResult holds aggregated data
+	//
+	// Each row holds values for all dimensions and metrics
+	// in the same order as they are listed in the columns
+	RecordReportResult struct {
+		Dimensions []*RecordReportColumn `json:"dimensions"`
+		Metrics    []*RecordReportColumn `json:"metrics"`
+		Rows       []*RecordReportRow    `json:"rows"`
+	}
+
+	RecordReportColumn struct {
+		Name   string             `json:"name"`
+		Bucket RecordReportBucket `json:"bucket,omitempty"`
+	}
+
+	RecordReportRow struct {
+		// Dimension values, nil when value is not set
+		Dimensions []*string `json:"dimensions"`
+		Metrics    []float64 `json:"metrics"`
+	}
+)
+
+const (
+	RecordReportCount      RecordReportAggregate = "COUNT"
+	RecordReportCountD     RecordReportAggregate = "COUNTD"
+	RecordReportSum        RecordReportAggregate = "SUM"
+	RecordReportMin        RecordReportAggregate = "MIN"
+	RecordReportMax        RecordReportAggregate = "MAX"
+	RecordReportAvg        RecordReportAggregate = "AVG"
+	RecordReportStd        RecordReportAggregate = "STD"
+	RecordReportPercentile RecordReportAggregate = "PERCENTILE"
+
+	RecordReportBucketNone    RecordReportBucket = ""
+	RecordReportBucketDay     RecordReportBucket = "day"
+	RecordReportBucketWeek    RecordReportBucket = "week"
+	RecordReportBucketMonth   RecordReportBucket = "month"
+	RecordReportBucketQuarter RecordReportBucket = "quarter"
+	RecordReportBucketYear    RecordReportBucket = "year"
+)
+
+var (
+	recordReportAliasRx = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	recordReportWeekRx  = regexp.MustCompile(`^(\d{4})-W(\d{2})$`)
+	recordReportQtrRx   = regexp.MustCompile(`^(\d{4})-Q([1-4])$`)
+)
+
+// Validate checks report definition and sets default aliases
+func (r *RecordReport) Validate() error {
+	var aliases = map[string]bool{}
+
+	checkAlias := func(alias string) error {
+		if !recordReportAliasRx.MatchString(alias) {
+			return errors.Errorf("invalid alias %q", alias)
+		}
+
+		if aliases[alias] {
+			return errors.Errorf("duplicate alias %q", alias)
+		}
+
+		aliases[alias] = true
+		return nil
+	}
+
+	if len(r.Metrics) == 0 {
+		r.Metrics = RecordReportMetricSet{{Aggregate: RecordReportCount}}
+	}
+
+	for i, d := range r.Dimensions {
+		if d.Alias == "" {
+			d.Alias = fmt.Sprintf("dimension_%d", i)
+		}
+
+		if err := checkAlias(d.Alias); err != nil {
+			return err
+		}
+
+		if d.Field == "" {
+			return errors.Errorf("field of dimension %q is not set", d.Alias)
+		}
+
+		if !d.Bucket.IsValid() {
+			return errors.Errorf("unsupported bucket %q of dimension %q", d.Bucket, d.Alias)
+		}
+
+		if d.FillGaps && (i > 0 || d.Bucket == RecordReportBucketNone) {
+			return errors.Errorf("gaps can only be filled on the first dimension with a bucket")
+		}
+	}
+
+	for i, m := range r.Metrics {
+		m.Aggregate = RecordReportAggregate(strings.ToUpper(string(m.Aggregate)))
+		if m.Aggregate == "" {
+			m.Aggregate = RecordReportCount
+		}
+
+		if m.Alias == "" {
+			if m.Aggregate == RecordReportCount && m.Field == "" {
+				m.Alias = "count"
+			} else {
+				m.Alias = fmt.Sprintf("metric_%d", i)
+			}
+		}
+
+		if err := checkAlias(m.Alias); err != nil {
+			return err
+		}
+
+		switch m.Aggregate {
+		case RecordReportCount:
+		case RecordReportCountD, RecordReportSum, RecordReportMin, RecordReportMax, RecordReportAvg, RecordReportStd:
+			if m.Field == "" {
+				return errors.Errorf("field of metric %q is not set", m.Alias)
+			}
+		case RecordReportPercentile:
+			if m.Field == "" {
+				return errors.Errorf("field of metric %q is not set", m.Alias)
+			}
+
+			if m.Percentile < 0 || m.Percentile > 100 {
+				return errors.Errorf("percentile of metric %q out of range", m.Alias)
+			}
+		default:
+			return errors.Errorf("unsupported aggregate function %q", m.Aggregate)
+		}
+	}
+
+	for _, m := range r.Metrics {
+		if m.DivideBy != "" && r.Metrics.IndexOf(m.DivideBy) < 0 {
+			return errors.Errorf("metric %q is divided by unknown metric %q", m.Alias, m.DivideBy)
+		}
+	}
+
+	for _, c := range r.Having {
+		if r.Metrics.IndexOf(c.Metric) < 0 {
+			return errors.Errorf("condition on unknown metric %q", c.Metric)
+		}
+
+		if _, ok := recordReportOperators[c.Operator]; !ok {
+			return errors.Errorf("unsupported condition operator %q", c.Operator)
+		}
+	}
+
+	return nil
+}
+
+// IndexOf returns index of the metric with the given alias or -1
+func (set RecordReportMetricSet) IndexOf(alias string) int {
+	for i := range set {
+		if set[i].Alias == alias {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (b RecordReportBucket) IsValid() bool {
+	switch b {
+	case RecordReportBucketNone,
+		RecordReportBucketDay,
+		RecordReportBucketWeek,
+		RecordReportBucketMonth,
+		RecordReportBucketQuarter,
+		RecordReportBucketYear:
+		return true
+	}
+
+	return false
+}
+
+// Format returns bucket label for the given time
+//
+// Format must match the labels that are produced by the report query
+func (b RecordReportBucket) Format(t time.Time) string {
+	switch b {
+	case RecordReportBucketDay:
+		return t.Format("2006-01-02")
+	case RecordReportBucketWeek:
+		y, w := t.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", y, w)
+	case RecordReportBucketMonth:
+		return t.Format("2006-01")
+	case RecordReportBucketQuarter:
+		return fmt.Sprintf("%04d-Q%d", t.Year(), (int(t.Month())-1)/3+1)
+	case RecordReportBucketYear:
+		return t.Format("2006")
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+// Parse returns start of the bucket from its label
+func (b RecordReportBucket) Parse(label string) (time.Time, error) {
+	switch b {
+	case RecordReportBucketDay:
+		return time.Parse("2006-01-02", label)
+	case RecordReportBucketWeek:
+		if m := recordReportWeekRx.FindStringSubmatch(label); m != nil {
+			y, _ := strconv.Atoi(m[1])
+			w, _ := strconv.Atoi(m[2])
+
+			// Jan 4th is always in the first ISO week
+			jan4 := time.Date(y, time.January, 4, 0, 0, 0, 0, time.UTC)
+			monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+			return monday.AddDate(0, 0, (w-1)*7), nil
+		}
+	case RecordReportBucketMonth:
+		return time.Parse("2006-01", label)
+	case RecordReportBucketQuarter:
+		if m := recordReportQtrRx.FindStringSubmatch(label); m != nil {
+			y, _ := strconv.Atoi(m[1])
+			q, _ := strconv.Atoi(m[2])
+			return time.Date(y, time.Month((q-1)*3+1), 1, 0, 0, 0, 0, time.UTC), nil
+		}
+	case RecordReportBucketYear:
+		return time.Parse("2006", label)
+	}
+
+	return time.Time{}, errors.Errorf("invalid %s bucket %q", b, label)
+}
+
+// Next returns start of the bucket that follows the one t is in
+func (b RecordReportBucket) Next(t time.Time) time.Time {
+	switch b {
+	case RecordReportBucketWeek:
+		return t.AddDate(0, 0, 7)
+	case RecordReportBucketMonth:
+		return t.AddDate(0, 1, 0)
+	case RecordReportBucketQuarter:
+		return t.AddDate(0, 3, 0)
+	case RecordReportBucketYear:
+		return t.AddDate(1, 0, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
@@ -0,0 +1,213 @@
+package types
+
+import (
+	"strings"
+)
+
+var (
+	recordReportOperators = map[string]func(a, b float64) bool{
+		"=":  func(a, b float64) bool { return a == b },
+		"!=": func(a, b float64) bool { return a != b },
+		"<":  func(a, b float64) bool { return a < b },
+		"<=": func(a, b float64) bool { return a <= b },
+		">":  func(a, b float64) bool { return a > b },
+		">=": func(a, b float64) bool { return a >= b },
+	}
+)
+
+// MakeRecordReportResult prepares empty result for the report
+func MakeRecordReportResult(r *RecordReport) *RecordReportResult {
+	var res = &RecordReportResult{
+		Dimensions: make([]*RecordReportColumn, len(r.Dimensions)),
+		Metrics:    make([]*RecordReportColumn, len(r.Metrics)),
+		Rows:       make([]*RecordReportRow, 0),
+	}
+
+	for i, d := range r.Dimensions {
+		res.Dimensions[i] = &RecordReportColumn{Name: d.Alias, Bucket: d.Bucket}
+	}
+
+	for i, m := range r.Metrics {
+		res.Metrics[i] = &RecordReportColumn{Name: m.Alias}
+	}
+
+	return res
+}
+
+// PostProcess applies steps that are done on aggregated values
+//
+// Ratios are calculated first, conditions are applied to the ratios,
+// gaps are filled with empty rows and running totals are calculated at the end
+func (res *RecordReportResult) PostProcess(r *RecordReport) error {
+	res.divide(r.Metrics)
+	res.filter(r.Metrics, r.Having)
+
+	if len(r.Dimensions) > 0 && r.Dimensions[0].FillGaps {
+		if err := res.fillGaps(r.Dimensions[0].Bucket); err != nil {
+			return err
+		}
+	}
+
+	res.accumulate(r.Metrics)
+	return nil
+}
+
+func (res *RecordReportResult) divide(mm RecordReportMetricSet) {
+	var (
+		raw = make([]float64, len(mm))
+	)
+
+	for _, row := range res.Rows {
+		copy(raw, row.Metrics)
+		for i, m := range mm {
+			if m.DivideBy == "" {
+				continue
+			}
+
+			if d := raw[mm.IndexOf(m.DivideBy)]; d != 0 {
+				row.Metrics[i] = raw[i] / d
+			} else {
+				row.Metrics[i] = 0
+			}
+		}
+	}
+}
+
+func (res *RecordReportResult) filter(mm RecordReportMetricSet, cc RecordReportConditionSet) {
+	if len(cc) == 0 {
+		return
+	}
+
+	var rows = res.Rows[:0]
+
+rows:
+	for _, row := range res.Rows {
+		for _, c := range cc {
+			if !recordReportOperators[c.Operator](row.Metrics[mm.IndexOf(c.Metric)], c.Value) {
+				continue rows
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	res.Rows = rows
+}
+
+// fillGaps adds rows for all missing buckets of the first dimension
+//
+// Rows are added for each combination of the remaining dimensions values
+func (res *RecordReportResult) fillGaps(b RecordReportBucket) error {
+	if len(res.Rows) == 0 {
+		return nil
+	}
+
+	var (
+		rows   = make([]*RecordReportRow, 0, len(res.Rows))
+		index  = map[string]*RecordReportRow{}
+		rest   = make([]*RecordReportRow, 0)
+		seen   = map[string]bool{}
+		labels = make([]string, 0)
+	)
+
+	for _, row := range res.Rows {
+		if row.Dimensions[0] == nil {
+			// rows without value are kept as they are
+			rows = append(rows, row)
+			continue
+		}
+
+		var key = row.key(1)
+		if !seen[key] {
+			seen[key] = true
+			rest = append(rest, row)
+		}
+
+		index[*row.Dimensions[0]+"\x00"+key] = row
+		labels = append(labels, *row.Dimensions[0])
+	}
+
+	if len(labels) == 0 {
+		return nil
+	}
+
+	var min, max = labels[0], labels[0]
+	for _, l := range labels {
+		if l < min {
+			min = l
+		}
+
+		if l > max {
+			max = l
+		}
+	}
+
+	from, err := b.Parse(min)
+	if err != nil {
+		return err
+	}
+
+	to, err := b.Parse(max)
+	if err != nil {
+		return err
+	}
+
+	for t := from; !t.After(to); t = b.Next(t) {
+		var label = b.Format(t)
+		for _, r := range rest {
+			var key = r.key(1)
+			if row, ok := index[label+"\x00"+key]; ok {
+				rows = append(rows, row)
+				continue
+			}
+
+			var empty = &RecordReportRow{
+				Dimensions: make([]*string, len(r.Dimensions)),
+				Metrics:    make([]float64, len(r.Metrics)),
+			}
+
+			empty.Dimensions[0] = &label
+			copy(empty.Dimensions[1:], r.Dimensions[1:])
+			rows = append(rows, empty)
+		}
+	}
+
+	res.Rows = rows
+	return nil
+}
+
+// accumulate replaces values of cumulative metrics with running totals
+//
+// Totals are calculated along the first dimension, separately
+// for each combination of the remaining dimensions values
+func (res *RecordReportResult) accumulate(mm RecordReportMetricSet) {
+	var totals = map[string][]float64{}
+
+	for _, row := range res.Rows {
+		var key = row.key(1)
+		if totals[key] == nil {
+			totals[key] = make([]float64, len(mm))
+		}
+
+		for i, m := range mm {
+			if m.Cumulative {
+				totals[key][i] += row.Metrics[i]
+				row.Metrics[i] = totals[key][i]
+			}
+		}
+	}
+}
+
+// key joins dimension values, starting with the given one
+func (row RecordReportRow) key(from int) string {
+	var kk = make([]string, 0, len(row.Dimensions))
+	for _, d := range row.Dimensions[from:] {
+		if d == nil {
+			kk = append(kk, "\x01")
+		} else {
+			kk = append(kk, *d)
+		}
+	}
+
+	return strings.Join(kk, "\x00")
+}
@@ -0,0 +1,153 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordReportValidate(t *testing.T) {
+	var (
+		req = require.New(t)
+		r   = &RecordReport{
+			Metrics: RecordReportMetricSet{
+				{},
+				{Field: "amount", Aggregate: "sum"},
+				{Field: "amount", Aggregate: "percentile", Percentile: 90, Alias: "p90"},
+			},
+			Dimensions: RecordReportDimensionSet{
+				{Field: "createdAt", Bucket: RecordReportBucketMonth, FillGaps: true},
+			},
+		}
+	)
+
+	req.NoError(r.Validate())
+	req.Equal("count", r.Metrics[0].Alias)
+	req.Equal(RecordReportCount, r.Metrics[0].Aggregate)
+	req.Equal("metric_1", r.Metrics[1].Alias)
+	req.Equal(RecordReportSum, r.Metrics[1].Aggregate)
+	req.Equal("dimension_0", r.Dimensions[0].Alias)
+
+	tcc := []struct {
+		name string
+		r    *RecordReport
+		err  string
+	}{
+		{
+			"missing field",
+			&RecordReport{Metrics: RecordReportMetricSet{{Aggregate: "SUM"}}},
+			`field of metric "metric_0" is not set`,
+		},
+		{
+			"unknown aggregate",
+			&RecordReport{Metrics: RecordReportMetricSet{{Field: "a", Aggregate: "MEDIAN"}}},
+			`unsupported aggregate function "MEDIAN"`,
+		},
+		{
+			"duplicate alias",
+			&RecordReport{Metrics: RecordReportMetricSet{{Alias: "a"}, {Alias: "a"}}},
+			`duplicate alias "a"`,
+		},
+		{
+			"invalid alias",
+			&RecordReport{Metrics: RecordReportMetricSet{{Alias: "a b"}}},
+			`invalid alias "a b"`,
+		},
+		{
+			"unknown divisor",
+			&RecordReport{Metrics: RecordReportMetricSet{{DivideBy: "foo"}}},
+			`metric "count" is divided by unknown metric "foo"`,
+		},
+		{
+			"gaps on second dimension",
+			&RecordReport{Dimensions: RecordReportDimensionSet{{Field: "a"}, {Field: "b", Bucket: "day", FillGaps: true}}},
+			`gaps can only be filled on the first dimension with a bucket`,
+		},
+		{
+			"invalid operator",
+			&RecordReport{Having: RecordReportConditionSet{{Metric: "count", Operator: "~"}}},
+			`unsupported condition operator "~"`,
+		},
+	}
+
+	for _, tc := range tcc {
+		t.Run(tc.name, func(t *testing.T) {
+			require.EqualError(t, tc.r.Validate(), tc.err)
+		})
+	}
+}
+
+func TestRecordReportBucket(t *testing.T) {
+	tcc := []struct {
+		bucket RecordReportBucket
+		time   time.Time
+		label  string
+		start  time.Time
+		next   string
+	}{
+		{RecordReportBucketDay, time.Date(2020, 2, 29, 15, 0, 0, 0, time.UTC), "2020-02-29", time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC), "2020-03-01"},
+		{RecordReportBucketWeek, time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC), "2020-W53", time.Date(2020, 12, 28, 0, 0, 0, 0, time.UTC), "2021-W01"},
+		{RecordReportBucketWeek, time.Date(2020, 6, 3, 0, 0, 0, 0, time.UTC), "2020-W23", time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), "2020-W24"},
+		{RecordReportBucketMonth, time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC), "2020-12", time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC), "2021-01"},
+		{RecordReportBucketQuarter, time.Date(2020, 5, 10, 0, 0, 0, 0, time.UTC), "2020-Q2", time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC), "2020-Q3"},
+		{RecordReportBucketYear, time.Date(2020, 5, 10, 0, 0, 0, 0, time.UTC), "2020", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), "2021"},
+	}
+
+	for _, tc := range tcc {
+		t.Run(tc.label, func(t *testing.T) {
+			req := require.New(t)
+			req.Equal(tc.label, tc.bucket.Format(tc.time))
+
+			start, err := tc.bucket.Parse(tc.label)
+			req.NoError(err)
+			req.Equal(tc.start, start)
+			req.Equal(tc.next, tc.bucket.Format(tc.bucket.Next(start)))
+		})
+	}
+}
+
+func TestRecordReportResultPostProcess(t *testing.T) {
+	var (
+		req = require.New(t)
+		s   = func(s string) *string { return &s }
+
+		r = &RecordReport{
+			Metrics: RecordReportMetricSet{
+				{Alias: "won", Cumulative: true},
+				{Alias: "total"},
+				{Alias: "rate", DivideBy: "total"},
+			},
+			Dimensions: RecordReportDimensionSet{
+				{Alias: "month", Field: "createdAt", Bucket: RecordReportBucketMonth, FillGaps: true},
+			},
+			Having: RecordReportConditionSet{
+				{Metric: "total", Operator: ">", Value: 1},
+			},
+		}
+
+		res = &RecordReportResult{
+			Rows: []*RecordReportRow{
+				{Dimensions: []*string{nil}, Metrics: []float64{1, 1, 1}},
+				{Dimensions: []*string{s("2020-01")}, Metrics: []float64{1, 4, 1}},
+				{Dimensions: []*string{s("2020-02")}, Metrics: []float64{1, 1, 1}},
+				{Dimensions: []*string{s("2020-04")}, Metrics: []float64{3, 6, 3}},
+			},
+		}
+	)
+
+	req.NoError(res.PostProcess(r))
+	req.Len(res.Rows, 4)
+
+	req.Equal("2020-01", *res.Rows[0].Dimensions[0])
+	req.Equal([]float64{1, 4, 0.25}, res.Rows[0].Metrics)
+
+	// filtered out and gaps are filled with zeros, running total is carried over
+	req.Equal("2020-02", *res.Rows[1].Dimensions[0])
+	req.Equal([]float64{1, 0, 0}, res.Rows[1].Metrics)
+	req.Equal("2020-03", *res.Rows[2].Dimensions[0])
+	req.Equal([]float64{1, 0, 0}, res.Rows[2].Metrics)
+
+	req.Equal("2020-04", *res.Rows[3].Dimensions[0])
+	req.Equal([]float64{4, 6, 0.5}, res.Rows[3].Metrics)
+}
@@ -0,0 +1,22 @@
+package types
+
+import (
+	"github.com/cortezaproject/corteza-server/pkg/permissions"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	// RecordTrashFilter is used to list deleted records of a namespace
+	RecordTrashFilter struct {
+		NamespaceID uint64 `json:"namespaceID,string"`
+		ModuleID    uint64 `json:"moduleID,string"`
+
+		Sort string `json:"sort"`
+
+		// Standard paging fields & helpers
+		rh.PageFilter
+
+		// Resource permission check filter
+		IsUndeletable *permissions.ResourceFilter `json:"-"`
+	}
+)
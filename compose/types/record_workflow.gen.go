@@ -0,0 +1,67 @@
+package types
+
+// 	Hello! This file is auto-generated.
+
+type (
+
+	// RecordTransitionSet slice of RecordTransition
+	//
+	// This type is auto-generated.
+	RecordTransitionSet []*RecordTransition
+)
+
+// Walk iterates through every slice item and calls w(RecordTransition) err
+//
+// This function is auto-generated.
+func (set RecordTransitionSet) Walk(w func(*RecordTransition) error) (err error) {
+	for i := range set {
+		if err = w(set[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Filter iterates through every slice item, calls f(RecordTransition) (bool, err) and return filtered slice
+//
+// This function is auto-generated.
+func (set RecordTransitionSet) Filter(f func(*RecordTransition) (bool, error)) (out RecordTransitionSet, err error) {
+	var ok bool
+	out = RecordTransitionSet{}
+	for i := range set {
+		if ok, err = f(set[i]); err != nil {
+			return
+		} else if ok {
+			out = append(out, set[i])
+		}
+	}
+
+	return
+}
+
+// FindByID finds items from slice by its ID property
+//
+// This function is auto-generated.
+func (set RecordTransitionSet) FindByID(ID uint64) *RecordTransition {
+	for i := range set {
+		if set[i].ID == ID {
+			return set[i]
+		}
+	}
+
+	return nil
+}
+
+// IDs returns a slice of uint64s from all items in the set
+//
+// This function is auto-generated.
+func (set RecordTransitionSet) IDs() (IDs []uint64) {
+	IDs = make([]uint64, len(set))
+
+	for i := range set {
+		IDs[i] = set[i].ID
+	}
+
+	return
+}
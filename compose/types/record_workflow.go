@@ -0,0 +1,151 @@
+package types
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	// RecordWorkflow declares allowed states of a (select) field and transitions between them
+	//
+	// Workflow is stored under "workflow" key in field options
+	RecordWorkflow struct {
+		States []string `json:"states"`
+
+		// State used when record is created without one
+		Initial string `json:"initial,omitempty"`
+
+		Transitions []*RecordWorkflowTransition `json:"transitions"`
+	}
+
+	RecordWorkflowTransition struct {
+		Name string `json:"name"`
+
+		// States transition can be made from, any state when empty
+		From []string `json:"from,omitempty"`
+		To   string   `json:"to"`
+
+		// IDs of roles allowed to make the transition, everyone when empty
+		Roles []string `json:"roles,omitempty"`
+
+		// Record query (ql) updated record must match
+		Guard string `json:"guard,omitempty"`
+
+		// Fields that need to have a value
+		Required []string `json:"required,omitempty"`
+	}
+
+	// RecordTransition is a record of the transition made on a record
+	RecordTransition struct {
+		ID          uint64 `json:"transitionID,string" db:"id"`
+		NamespaceID uint64 `json:"namespaceID,string" db:"rel_namespace"`
+		ModuleID    uint64 `json:"moduleID,string" db:"rel_module"`
+		RecordID    uint64 `json:"recordID,string" db:"rel_record"`
+
+		Field      string `json:"field" db:"field"`
+		Transition string `json:"transition" db:"transition"`
+		FromState  string `json:"from" db:"from_state"`
+		ToState    string `json:"to" db:"to_state"`
+
+		CreatedAt time.Time `json:"createdAt,omitempty" db:"created_at"`
+		CreatedBy uint64    `json:"createdBy,string" db:"created_by"`
+	}
+
+	RecordTransitionFilter struct {
+		NamespaceID uint64 `json:"namespaceID,string"`
+		ModuleID    uint64 `json:"moduleID,string"`
+		RecordID    uint64 `json:"recordID,string"`
+
+		Sort string `json:"sort"`
+
+		// Standard paging fields & helpers
+		rh.PageFilter
+	}
+)
+
+const (
+	recordWorkflowOptionKey = "workflow"
+)
+
+// Workflow returns workflow declared on the field or nil
+//
+// Invalid workflow definitions are ignored
+func (f ModuleField) Workflow() *RecordWorkflow {
+	raw, ok := f.Options[recordWorkflowOptionKey]
+	if !ok || raw == nil {
+		return nil
+	}
+
+	var (
+		wf  = &RecordWorkflow{}
+		buf []byte
+		err error
+	)
+
+	if buf, err = json.Marshal(raw); err != nil {
+		return nil
+	}
+
+	if err = json.Unmarshal(buf, wf); err != nil || len(wf.States) == 0 {
+		return nil
+	}
+
+	return wf
+}
+
+// HasState checks if state is one of the workflow states
+func (wf RecordWorkflow) HasState(state string) bool {
+	for _, s := range wf.States {
+		if s == state {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FindTransition returns first transition that leads from one state to another
+func (wf RecordWorkflow) FindTransition(from, to string) *RecordWorkflowTransition {
+	for _, t := range wf.Transitions {
+		if t.To == to && t.IsFrom(from) {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// IsFrom checks if transition can be made from the given state
+func (t RecordWorkflowTransition) IsFrom(state string) bool {
+	if len(t.From) == 0 {
+		return true
+	}
+
+	for _, s := range t.From {
+		if s == state {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowedFor checks if any of the given roles can make the transition
+func (t RecordWorkflowTransition) AllowedFor(roles ...uint64) bool {
+	if len(t.Roles) == 0 {
+		return true
+	}
+
+	for _, r := range roles {
+		for _, allowed := range t.Roles {
+			if allowed == strconv.FormatUint(r, 10) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
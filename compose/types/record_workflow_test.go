@@ -0,0 +1,47 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestModuleFieldWorkflow(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		f = ModuleField{
+			Name: "status",
+			Kind: "Select",
+			Options: ModuleFieldOptions{
+				"workflow": map[string]interface{}{
+					"states":  []interface{}{"draft", "submitted", "approved"},
+					"initial": "draft",
+					"transitions": []interface{}{
+						map[string]interface{}{"name": "submit", "from": []interface{}{"draft"}, "to": "submitted"},
+						map[string]interface{}{"name": "approve", "from": []interface{}{"submitted"}, "to": "approved", "roles": []interface{}{"42"}},
+						map[string]interface{}{"name": "reset", "to": "draft"},
+					},
+				},
+			},
+		}
+	)
+
+	wf := f.Workflow()
+	req.NotNil(wf)
+	req.Equal("draft", wf.Initial)
+	req.True(wf.HasState("approved"))
+	req.False(wf.HasState("rejected"))
+
+	req.Equal("submit", wf.FindTransition("draft", "submitted").Name)
+	req.Nil(wf.FindTransition("draft", "approved"))
+	req.Equal("reset", wf.FindTransition("approved", "draft").Name)
+
+	approve := wf.FindTransition("submitted", "approved")
+	req.True(approve.AllowedFor(1, 42))
+	req.False(approve.AllowedFor(1))
+	req.True(wf.FindTransition("draft", "submitted").AllowedFor())
+
+	req.Nil(ModuleField{Options: ModuleFieldOptions{}}.Workflow())
+	req.Nil(ModuleField{Options: ModuleFieldOptions{"workflow": "invalid"}}.Workflow())
+}
@@ -8,16 +8,21 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/cortezaproject/corteza-server/pkg/app"
+	"github.com/cortezaproject/corteza-server/pkg/app/options"
 	"github.com/cortezaproject/corteza-server/pkg/auth"
 	"github.com/cortezaproject/corteza-server/pkg/corredor"
 	"github.com/cortezaproject/corteza-server/pkg/db"
 	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 	"github.com/cortezaproject/corteza-server/pkg/http"
+	"github.com/cortezaproject/corteza-server/pkg/jobs"
 	"github.com/cortezaproject/corteza-server/pkg/logger"
 	"github.com/cortezaproject/corteza-server/pkg/mail"
 	"github.com/cortezaproject/corteza-server/pkg/monitor"
 	"github.com/cortezaproject/corteza-server/pkg/scheduler"
 	"github.com/cortezaproject/corteza-server/pkg/sentry"
+	"github.com/cortezaproject/corteza-server/pkg/store"
+	"github.com/cortezaproject/corteza-server/pkg/store/minio"
+	"github.com/cortezaproject/corteza-server/pkg/store/plain"
 )
 
 type (
@@ -53,7 +58,16 @@ func (app *App) Setup(log *zap.Logger, opts *app.Options) (err error) {
 
 	monitor.Setup(app.log, opts.Monitor)
 
-	scheduler.Setup(log, eventbus.Service(), 0)
+	eventbus.Service().SetDefaults(
+		opts.Eventbus.HandlerTimeout,
+		opts.Eventbus.BreakerThreshold,
+		opts.Eventbus.BreakerCooldown,
+	)
+
+	scheduler.Setup(log, eventbus.Service(), opts.Scheduler.Interval)
+
+	// Job service needs to be ready before corredor registers iterator worker
+	jobs.Setup(log, opts.Jobs)
 
 	if err = corredor.Setup(log, opts.Corredor); err != nil {
 		return err
@@ -65,11 +79,38 @@ func (app *App) Setup(log *zap.Logger, opts *app.Options) (err error) {
 func (app *App) Initialize(ctx context.Context) (err error) {
 	defer sentry.Recover()
 
-	_, err = db.TryToConnect(ctx, app.log, app.opt.DB)
+	conn, err := db.TryToConnect(ctx, app.log, app.opt.DB)
 	if err != nil {
 		return errors.Wrap(err, "could not connect to database")
 	}
 
+	if app.opt.Scheduler.Coordinated {
+		// Only one of the nodes dispatches scheduled events
+		scheduler.Service().Coordinate(
+			scheduler.NewStore(conn, app.opt.Scheduler.Lease),
+			app.opt.Scheduler.LeaseTTL,
+			app.opt.Scheduler.CatchUp,
+		)
+	}
+
+	// Queue for long-running jobs (imports, exports...)
+	jobs.Service().SetStore(jobs.NewStore(conn))
+
+	// Files produced by jobs (exports...)
+	if files, err := jobsFileStore(app.log, app.opt.Storage); err != nil {
+		return err
+	} else {
+		jobs.Service().SetFileStore(files)
+	}
+
+	// History of script executions
+	corredor.Service().SetRunStore(corredor.NewRunStore(conn))
+
+	if app.opt.Corredor.Embedded {
+		// Server scripts stored in the database
+		corredor.Service().AddEmbeddedSource(corredor.NewEmbeddedStore(conn))
+	}
+
 	if err = corredor.Service().Connect(ctx); err != nil {
 		return
 	}
@@ -86,6 +127,9 @@ func (app *App) Activate(ctx context.Context) (err error) {
 	// Start scheduler
 	scheduler.Service().Start(ctx)
 
+	// Start job workers on this node
+	jobs.Service().Start(ctx)
+
 	// Load corredor scripts & init watcher (script reloader)
 	corredor.Service().Load(ctx)
 	corredor.Service().Watch(ctx)
@@ -96,3 +140,37 @@ func (app *App) Activate(ctx context.Context) (err error) {
 func (app *App) Provision(ctx context.Context) error {
 	return nil
 }
+
+// Initializes file store for job results, next to stores of other services
+func jobsFileStore(log *zap.Logger, opt options.StorageOpt) (s store.Store, err error) {
+	const svcPath = "jobs"
+	if opt.MinioEndpoint != "" {
+		var bucket = svcPath
+		if opt.MinioBucket != "" {
+			bucket = opt.MinioBucket + "/" + svcPath
+		}
+
+		s, err = minio.New(bucket, minio.Options{
+			Endpoint:        opt.MinioEndpoint,
+			Secure:          opt.MinioSecure,
+			Strict:          opt.MinioStrict,
+			AccessKeyID:     opt.MinioAccessKey,
+			SecretAccessKey: opt.MinioSecretKey,
+
+			ServerSideEncryptKey: []byte(opt.MinioSSECKey),
+		})
+
+		log.Info("initializing minio for jobs",
+			zap.String("bucket", bucket),
+			zap.String("endpoint", opt.MinioEndpoint),
+			zap.Error(err))
+	} else {
+		path := opt.Path + "/" + svcPath
+		s, err = plain.New(path)
+		log.Info("initializing store for jobs",
+			zap.String("path", path),
+			zap.Error(err))
+	}
+
+	return
+}
@@ -15,12 +15,15 @@ require (
 	github.com/davecgh/go-spew v1.1.1
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
 	github.com/disintegration/imaging v1.6.0
+	github.com/dlclark/regexp2 v1.2.0 // indirect
+	github.com/dop251/goja v0.0.0-20200219165308-d1232e640a87
 	github.com/edwvee/exiffix v0.0.0-20180602190213-b57537c92a6b
 	github.com/gabriel-vasile/mimetype v0.3.17
 	github.com/getsentry/sentry-go v0.1.1
 	github.com/go-chi/chi v3.3.4+incompatible
 	github.com/go-chi/cors v1.0.0
 	github.com/go-chi/jwtauth v0.0.0-20190109153619-47840abb19b3
+	github.com/go-sourcemap/sourcemap v2.1.2+incompatible // indirect
 	github.com/golang/mock v1.3.1
 	github.com/golang/protobuf v1.3.2
 	github.com/gomodule/redigo v2.0.0+incompatible
@@ -36,6 +39,7 @@ require (
 	github.com/lib/pq v1.1.0 // indirect
 	github.com/markbates/goth v1.50.0
 	github.com/minio/minio-go/v6 v6.0.39
+	github.com/nats-io/nats.go v1.9.2
 	github.com/niemeyer/pretty v0.0.0-20200227124842-a10e7caefd8e // indirect
 	github.com/pkg/errors v0.8.1
 	github.com/pquerna/cachecontrol v0.0.0-20180517163645-1555304b9b35 // indirect
@@ -53,7 +57,8 @@ require (
 	github.com/titpetric/factory v0.0.0-20190806200833-ae4b02b9e034
 	go.uber.org/atomic v1.5.0
 	go.uber.org/zap v1.13.0
-	golang.org/x/crypto v0.0.0-20190605123033-f99c8df09eb5
+	golang.org/x/crypto v0.0.0-20200323165209-0ec3e9974c59
+	golang.org/x/image v0.0.0-20190910094157-69e4b8554b2a
 	google.golang.org/grpc v1.22.1
 	gopkg.in/alexcesaro/quotedprintable.v3 v3.0.0-20150716171945-2caba252f4dc // indirect
 	gopkg.in/check.v1 v1.0.0-20200227125254-8fa46927fb4f // indirect
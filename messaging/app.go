@@ -16,6 +16,7 @@ import (
 	"github.com/cortezaproject/corteza-server/messaging/service/event"
 	"github.com/cortezaproject/corteza-server/messaging/websocket"
 	"github.com/cortezaproject/corteza-server/pkg/app"
+	"github.com/cortezaproject/corteza-server/pkg/app/options"
 	"github.com/cortezaproject/corteza-server/pkg/auth"
 	"github.com/cortezaproject/corteza-server/pkg/corredor"
 	"github.com/cortezaproject/corteza-server/pkg/scheduler"
@@ -45,6 +46,9 @@ func (app *App) Setup(log *zap.Logger, opts *app.Options) (err error) {
 		Timeout:     opts.Websocket.Timeout,
 		PingTimeout: opts.Websocket.PingTimeout,
 		PingPeriod:  opts.Websocket.PingPeriod,
+
+		ResumeTimeout: opts.Websocket.ResumeTimeout,
+		ResumeBacklog: opts.Websocket.ResumeBacklog,
 	})
 
 	// @todo Wire in cross-service JWT maker for Corredor
@@ -67,7 +71,9 @@ func (app *App) Upgrade(ctx context.Context) (err error) {
 func (app *App) Initialize(ctx context.Context) (err error) {
 	// Connects to all services it needs to
 	err = service.Initialize(ctx, app.Log, service.Config{
-		Storage: app.Opts.Storage,
+		Storage:          app.Opts.Storage,
+		PubSub:           app.Opts.PubSub,
+		GRPCClientSystem: *options.GRPCServer("system"),
 	})
 
 	if err != nil {
@@ -0,0 +1,27 @@
+CREATE TABLE `messaging_scheduled_message` (
+ `id` bigint(20) unsigned NOT NULL,
+ `rel_user` bigint(20) unsigned NOT NULL COMMENT 'Message author User ID',
+ `rel_channel` bigint(20) unsigned NOT NULL COMMENT 'Channel ID',
+ `reply_to` bigint(20) unsigned NOT NULL DEFAULT 0 COMMENT 'Thread (original message) ID',
+ `message` text NOT NULL,
+ `scheduled_at` datetime NULL COMMENT 'One-off delivery time',
+ `cron` varchar(64) NOT NULL DEFAULT '' COMMENT 'Recurring delivery (crontab expression)',
+ `timezone` varchar(64) NOT NULL DEFAULT '' COMMENT 'Timezone for cron expression',
+ `next_run_at` datetime NULL COMMENT 'Next delivery, NULL when done',
+ `last_run_at` datetime NULL,
+ `last_message_id` bigint(20) unsigned NOT NULL DEFAULT 0 COMMENT 'Last delivered message ID',
+ `last_error` text NOT NULL,
+ `created_at` datetime NOT NULL,
+ `updated_at` datetime     NULL,
+ `deleted_at` datetime     NULL,
+ PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- find scheduled messages that are due
+ALTER TABLE `messaging_scheduled_message` ADD INDEX(`next_run_at`);
+
+-- list scheduled messages on a channel
+ALTER TABLE `messaging_scheduled_message` ADD INDEX(`rel_channel`);
+
+-- list scheduled messages by author
+ALTER TABLE `messaging_scheduled_message` ADD INDEX(`rel_user`);
@@ -141,7 +141,8 @@ func (r message) Find(filter types.MessageFilter) (set types.MessageSet, f types
 
 	if len(f.ThreadID) > 0 {
 		query = query.Where(squirrel.Eq{"m.reply_to": f.ThreadID})
-	} else {
+	} else if !f.PinnedOnly && !f.BookmarkedOnly {
+		// Pinned & bookmarked messages are listed regardless of their position in threads
 		query = query.Where(squirrel.Eq{"m.reply_to": 0})
 	}
 
@@ -181,14 +182,13 @@ func (r message) Find(filter types.MessageFilter) (set types.MessageSet, f types
 		query = query.Where(squirrel.LtOrEq{"m.id": f.ToID})
 	}
 
-	if f.BookmarkedOnly || f.PinnedOnly {
-		flag := types.MessageFlagBookmarkedMessage
-		if f.PinnedOnly {
-			flag = types.MessageFlagPinnedToChannel
-		}
-
+	if f.PinnedOnly {
+		query = query.
+			Where(squirrel.ConcatExpr("m.id IN(", (messageFlag{}).queryMessagesWithFlags(types.MessageFlagPinnedToChannel), ")"))
+	} else if f.BookmarkedOnly {
+		// Bookmarks are private, only ones made by the current user are considered
 		query = query.
-			Where(squirrel.ConcatExpr("m.id IN(", (messageFlag{}).queryMessagesWithFlags(flag), ")"))
+			Where(squirrel.ConcatExpr("m.id IN(", (messageFlag{}).queryMessagesWithUserFlags(f.CurrentUserID, types.MessageFlagBookmarkedMessage), ")"))
 	}
 
 	query = query.
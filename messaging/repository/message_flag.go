@@ -63,6 +63,12 @@ func (r messageFlag) queryMessagesWithFlags(flags ...string) squirrel.SelectBuil
 		Where(squirrel.Eq{"flag": flags})
 }
 
+// queryMessagesWithUserFlags narrows query down to flags set by the user (ie: bookmarks)
+func (r messageFlag) queryMessagesWithUserFlags(userID uint64, flags ...string) squirrel.SelectBuilder {
+	return r.queryMessagesWithFlags(flags...).
+		Where(squirrel.Eq{"rel_user": userID})
+}
+
 func (r messageFlag) With(ctx context.Context, db *factory.DB) MessageFlagRepository {
 	return &messageFlag{
 		repository: r.repository.With(ctx, db),
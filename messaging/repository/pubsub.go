@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+
+	"github.com/cortezaproject/corteza-server/pkg/app/options"
 )
 
 type (
@@ -25,27 +27,32 @@ type (
 	}
 )
 
-// var pubsub *PubSub
-
-func (PubSub) New() *PubSub {
-	panic("pending reimplementation")
-	// @todo should be configured much earlier, do not depend on flags here
-	// // return singleton client
-	// if pubsub != nil {
-	// 	return pubsub
-	// }
-	//
-	// // store the singleton instance
-	// save := func(client pubSubModule) *PubSub {
-	// 	pubsub = &PubSub{client}
-	// 	return pubsub
-	// }
-
-	// // create isntances based on mode
-	// if flags != nil && flags.PubSub.Mode == "redis" {
-	// 	return save(PubSubRedis{}.New(flags.PubSub))
-	// }
-	// return save(PubSubMemory{}.New(flags.PubSub))
+var pubsub *PubSub
+
+// New returns pubsub client for the configured mode
+//
+// Client is a singleton; all subsequent calls return the first configured instance
+func (PubSub) New(opt options.PubSubOpt) *PubSub {
+	// return singleton client
+	if pubsub != nil {
+		return pubsub
+	}
+
+	// store the singleton instance
+	save := func(client pubSubModule) *PubSub {
+		pubsub = &PubSub{client}
+		return pubsub
+	}
+
+	// create instances based on mode
+	switch opt.Mode {
+	case "redis":
+		return save(PubSubRedis{}.New(opt.RedisAddr, opt.RedisTimeout, opt.RedisPingTimeout, opt.RedisPingPeriod))
+	case "nats":
+		return save(PubSubNats{}.New(opt.NatsAddr, opt.NatsPrefix, opt.NatsTimeout, opt.NatsReconnectWait))
+	}
+
+	return save(PubSubMemory{}.New(opt.PollingInterval))
 }
 
 func (ps *PubSub) Subscribe(ctx context.Context, channel string, onStart func() error, onMessage func(channel string, message []byte) error) error {
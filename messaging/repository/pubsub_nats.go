@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+)
+
+type PubSubNats struct {
+	addr string
+
+	// Prepended to all subjects, allows multiple deployments
+	// to share the same NATS cluster
+	prefix string
+
+	timeout       time.Duration
+	reconnectWait time.Duration
+
+	mux  *sync.Mutex
+	conn *nats.Conn
+}
+
+const (
+	pubSubNatsBacklog = 512
+)
+
+func (PubSubNats) New(addr, prefix string, to, rw time.Duration) *PubSubNats {
+	return &PubSubNats{
+		addr:          addr,
+		prefix:        strings.Trim(prefix, "."),
+		timeout:       to,
+		reconnectWait: rw,
+		mux:           &sync.Mutex{},
+	}
+}
+
+func (ps *PubSubNats) dial(oo ...nats.Option) (*nats.Conn, error) {
+	return nats.Connect(ps.addr, append([]nats.Option{
+		nats.Name("corteza-messaging"),
+		nats.Timeout(ps.timeout),
+		nats.ReconnectWait(ps.reconnectWait),
+
+		// Keep reconnecting for as long as the connection is in use
+		nats.MaxReconnects(-1),
+	}, oo...)...)
+}
+
+func (ps *PubSubNats) subject(channel string) string {
+	if ps.prefix == "" {
+		return channel
+	}
+
+	return ps.prefix + "." + channel
+}
+
+func (ps *PubSubNats) Subscribe(ctx context.Context, channel string, onStart func() error, onMessage func(channel string, payload []byte) error) error {
+	var (
+		reconnected = make(chan struct{}, 1)
+		closed      = make(chan struct{})
+		msgs        = make(chan *nats.Msg, pubSubNatsBacklog)
+	)
+
+	conn, err := ps.dial(
+		nats.ReconnectHandler(func(*nats.Conn) {
+			select {
+			case reconnected <- struct{}{}:
+			default:
+			}
+		}),
+		nats.ClosedHandler(func(*nats.Conn) {
+			close(closed)
+		}),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	sub, err := conn.ChanSubscribe(ps.subject(channel), msgs)
+	if err != nil {
+		return err
+	}
+
+	defer sub.Unsubscribe()
+
+	// Make sure server registered the subscription before we notify the application
+	if err = conn.FlushTimeout(ps.timeout); err != nil {
+		return err
+	}
+
+	if err = onStart(); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-closed:
+			if err = conn.LastError(); err != nil {
+				return err
+			}
+
+			return errors.New("PubSubNats.Subscribe: connection closed")
+
+		case <-reconnected:
+			// Client restores subscriptions on its own; we let the application know
+			// that it might have missed messages while disconnected
+			if err = onStart(); err != nil {
+				return err
+			}
+
+		case msg := <-msgs:
+			if err = onMessage(channel, msg.Data); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (ps *PubSubNats) Publish(ctx context.Context, channel, message string) error {
+	conn, err := ps.publisher()
+	if err != nil {
+		return err
+	}
+
+	// While reconnecting, client buffers published messages
+	return conn.Publish(ps.subject(channel), []byte(message))
+}
+
+// publisher returns shared connection for publishing
+//
+// Connection is (re)established on demand
+func (ps *PubSubNats) publisher() (*nats.Conn, error) {
+	ps.mux.Lock()
+	defer ps.mux.Unlock()
+
+	if ps.conn == nil || ps.conn.IsClosed() {
+		conn, err := ps.dial()
+		if err != nil {
+			return nil, err
+		}
+
+		ps.conn = conn
+	}
+
+	return ps.conn, nil
+}
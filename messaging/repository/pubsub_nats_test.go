@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+// NATS tests need a running server:
+//   nats-server -p 4222
+// or a custom address via PUBSUB_NATS_TEST_ADDR
+func natsTestPubSub(t *testing.T, prefix string) *PubSubNats {
+	addr := os.Getenv("PUBSUB_NATS_TEST_ADDR")
+	if addr == "" {
+		addr = "nats://127.0.0.1:4222"
+	}
+
+	ps := PubSubNats{}.New(addr, prefix, time.Second, 100*time.Millisecond)
+	if conn, err := ps.dial(); err != nil {
+		t.Skipf("NATS server not available on %s: %v", addr, err)
+	} else {
+		conn.Close()
+	}
+
+	return ps
+}
+
+// natsTestSubscribe subscribes to a channel and returns received messages
+func natsTestSubscribe(t *testing.T, ps *PubSubNats, channel string) (<-chan string, context.CancelFunc, <-chan error) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		started     = make(chan struct{}, 1)
+		received    = make(chan string, 100)
+		done        = make(chan error, 1)
+	)
+
+	go func() {
+		done <- ps.Subscribe(ctx, channel,
+			func() error {
+				started <- struct{}{}
+				return nil
+			},
+			func(_ string, message []byte) error {
+				received <- string(message)
+				return nil
+			},
+		)
+	}()
+
+	select {
+	case <-started:
+	case err := <-done:
+		t.Fatalf("Subscribe failed: %v", err)
+	case <-time.After(time.Second):
+		t.Fatalf("Expected initial call to 'onStart'")
+	}
+
+	return received, cancel, done
+}
+
+func natsTestReceive(t *testing.T, received <-chan string, expected ...string) {
+	for _, e := range expected {
+		select {
+		case m := <-received:
+			if m != e {
+				t.Fatalf("Expected message %q, got %q", e, m)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected message %q, got nothing", e)
+		}
+	}
+
+	select {
+	case m := <-received:
+		t.Fatalf("Unexpected message %q", m)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestPubSubNats(t *testing.T) {
+	var (
+		ps      = natsTestPubSub(t, fmt.Sprintf("test%d", time.Now().UnixNano()))
+		channel = "events"
+	)
+
+	r1, cancel1, done1 := natsTestSubscribe(t, ps, channel)
+	r2, cancel2, _ := natsTestSubscribe(t, ps, channel)
+	defer cancel2()
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if err := ps.Publish(ctx, channel, fmt.Sprintf("message #%d", i)); err != nil {
+			t.Fatalf("Publish failed: %v", err)
+		}
+	}
+
+	// Publishing to a different channel
+	if err := ps.Publish(ctx, "other", "other message"); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	// Every subscriber receives all messages, in order
+	natsTestReceive(t, r1, "message #0", "message #1", "message #2")
+	natsTestReceive(t, r2, "message #0", "message #1", "message #2")
+
+	cancel1()
+
+	select {
+	case err := <-done1:
+		if err != context.Canceled {
+			t.Fatalf("Expected context cancellation, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected PubSub channel exit after context cancellation")
+	}
+
+	// Remaining subscriber still receives messages
+	if err := ps.Publish(ctx, channel, "after cancel"); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	natsTestReceive(t, r2, "after cancel")
+	natsTestReceive(t, r1)
+}
+
+func TestPubSubNats_prefix(t *testing.T) {
+	var (
+		prefix = fmt.Sprintf("test%d", time.Now().UnixNano())
+		ps1    = natsTestPubSub(t, prefix+".a")
+		ps2    = natsTestPubSub(t, prefix+".b")
+	)
+
+	r1, cancel1, _ := natsTestSubscribe(t, ps1, "events")
+	defer cancel1()
+	r2, cancel2, _ := natsTestSubscribe(t, ps2, "events")
+	defer cancel2()
+
+	if err := ps1.Publish(context.Background(), "events", "deployment a"); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	// Deployments with different prefixes do not see each others messages
+	natsTestReceive(t, r1, "deployment a")
+	natsTestReceive(t, r2)
+
+	if ps1.subject("events") != prefix+".a.events" {
+		t.Fatalf("Unexpected subject %q", ps1.subject("events"))
+	}
+
+	if (PubSubNats{}).New("", "", 0, 0).subject("events") != "events" {
+		t.Fatalf("Expected subject without prefix")
+	}
+}
+
+func TestPubSubNats_onMessageError(t *testing.T) {
+	var (
+		ps  = natsTestPubSub(t, fmt.Sprintf("test%d", time.Now().UnixNano()))
+		ctx = context.Background()
+
+		started = make(chan struct{}, 1)
+		done    = make(chan error, 1)
+	)
+
+	go func() {
+		done <- ps.Subscribe(ctx, "events",
+			func() error {
+				started <- struct{}{}
+				return nil
+			},
+			func(_ string, message []byte) error {
+				return fmt.Errorf("failed on %s", message)
+			},
+		)
+	}()
+
+	<-started
+	_ = ps.Publish(ctx, "events", "poison")
+
+	select {
+	case err := <-done:
+		if err == nil || err.Error() != "failed on poison" {
+			t.Fatalf("Expected error from onMessage, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected PubSub channel exit after onMessage error")
+	}
+}
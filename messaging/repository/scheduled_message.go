@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/titpetric/factory"
+
+	"github.com/cortezaproject/corteza-server/messaging/types"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	ScheduledMessageRepository interface {
+		With(ctx context.Context, db *factory.DB) ScheduledMessageRepository
+
+		FindByID(ID uint64) (*types.ScheduledMessage, error)
+		Find(filter types.ScheduledMessageFilter) (types.ScheduledMessageSet, error)
+		FindDue(now time.Time) (types.ScheduledMessageSet, error)
+
+		Create(mod *types.ScheduledMessage) (*types.ScheduledMessage, error)
+		Update(mod *types.ScheduledMessage) (*types.ScheduledMessage, error)
+		UpdateSchedule(mod *types.ScheduledMessage) (*types.ScheduledMessage, error)
+		UpdateDelivery(mod *types.ScheduledMessage) error
+		Claim(mod *types.ScheduledMessage, next *time.Time) (bool, error)
+		DeleteByID(ID uint64) error
+	}
+
+	scheduledMessage struct {
+		*repository
+	}
+)
+
+const (
+	ErrScheduledMessageNotFound = repositoryError("ScheduledMessageNotFound")
+)
+
+func ScheduledMessage(ctx context.Context, db *factory.DB) ScheduledMessageRepository {
+	return (&scheduledMessage{}).With(ctx, db)
+}
+
+func (r scheduledMessage) With(ctx context.Context, db *factory.DB) ScheduledMessageRepository {
+	return &scheduledMessage{
+		repository: r.repository.With(ctx, db),
+	}
+}
+
+func (r scheduledMessage) table() string {
+	return "messaging_scheduled_message"
+}
+
+func (r scheduledMessage) columns() []string {
+	return []string{
+		"sm.id",
+		"sm.rel_user",
+		"sm.rel_channel",
+		"sm.reply_to",
+		"sm.message",
+		"sm.scheduled_at",
+		"sm.cron",
+		"sm.timezone",
+		"sm.next_run_at",
+		"sm.last_run_at",
+		"sm.last_message_id",
+		"sm.last_error",
+		"sm.created_at",
+		"sm.updated_at",
+		"sm.deleted_at",
+	}
+}
+
+func (r scheduledMessage) query() squirrel.SelectBuilder {
+	return squirrel.
+		Select(r.columns()...).
+		From(r.table() + " AS sm").
+		Where(squirrel.Eq{"sm.deleted_at": nil})
+}
+
+func (r scheduledMessage) FindByID(ID uint64) (*types.ScheduledMessage, error) {
+	return r.findOneBy(squirrel.Eq{"sm.id": ID})
+}
+
+func (r scheduledMessage) findOneBy(cnd squirrel.Sqlizer) (*types.ScheduledMessage, error) {
+	var (
+		sm = &types.ScheduledMessage{}
+
+		q = r.query().
+			Where(cnd)
+
+		err = rh.FetchOne(r.db(), q, sm)
+	)
+
+	if err != nil {
+		return nil, err
+	} else if sm.ID == 0 {
+		return nil, ErrScheduledMessageNotFound
+	}
+
+	return sm, nil
+}
+
+func (r scheduledMessage) Find(filter types.ScheduledMessageFilter) (set types.ScheduledMessageSet, err error) {
+	query := r.query()
+
+	if filter.ChannelID > 0 {
+		query = query.Where(squirrel.Eq{"sm.rel_channel": filter.ChannelID})
+	}
+
+	if filter.UserID > 0 {
+		query = query.Where(squirrel.Eq{"sm.rel_user": filter.UserID})
+	}
+
+	if !filter.IncDelivered {
+		query = query.Where(squirrel.NotEq{"sm.next_run_at": nil})
+	}
+
+	return set, rh.FetchAll(r.db(), query.OrderBy("sm.next_run_at"), &set)
+}
+
+// FindDue returns all scheduled messages that should be delivered by now
+func (r scheduledMessage) FindDue(now time.Time) (set types.ScheduledMessageSet, err error) {
+	query := r.query().
+		Where(squirrel.LtOrEq{"sm.next_run_at": now}).
+		OrderBy("sm.next_run_at")
+
+	return set, rh.FetchAll(r.db(), query, &set)
+}
+
+func (r scheduledMessage) Create(mod *types.ScheduledMessage) (*types.ScheduledMessage, error) {
+	mod.ID = factory.Sonyflake.NextID()
+	rh.SetCurrentTimeRounded(&mod.CreatedAt)
+
+	return mod, r.db().Insert(r.table(), mod)
+}
+
+func (r scheduledMessage) Update(mod *types.ScheduledMessage) (*types.ScheduledMessage, error) {
+	rh.SetCurrentTimeRounded(&mod.UpdatedAt)
+
+	return mod, r.db().Replace(r.table(), mod)
+}
+
+// UpdateSchedule stores message contents and schedule (and resets delivery state)
+//
+// Other columns are not changed so that updates do not overwrite
+// concurrent delivery or restore cancelled message
+func (r scheduledMessage) UpdateSchedule(mod *types.ScheduledMessage) (*types.ScheduledMessage, error) {
+	rh.SetCurrentTimeRounded(&mod.UpdatedAt)
+
+	return mod, rh.UpdateColumns(r.db(), r.table(), rh.Set{
+		"reply_to":     mod.ReplyTo,
+		"message":      mod.Message,
+		"scheduled_at": mod.ScheduledAt,
+		"cron":         mod.Cron,
+		"timezone":     mod.Timezone,
+		"next_run_at":  mod.NextRunAt,
+		"last_run_at":  mod.LastRunAt,
+		"last_error":   mod.LastError,
+		"updated_at":   mod.UpdatedAt,
+	}, squirrel.Eq{"id": mod.ID, "deleted_at": nil})
+}
+
+// UpdateDelivery stores state of the last delivery
+//
+// Next run is moved by Claim, other columns are not changed
+func (r scheduledMessage) UpdateDelivery(mod *types.ScheduledMessage) error {
+	return rh.UpdateColumns(r.db(), r.table(), rh.Set{
+		"last_run_at":     mod.LastRunAt,
+		"last_message_id": mod.LastMessageID,
+		"last_error":      mod.LastError,
+	}, squirrel.Eq{"id": mod.ID})
+}
+
+// Claim moves next run of the scheduled message
+//
+// Update is conditional on the next run we've read;
+// when multiple nodes pick up the same message only one of them can claim it
+func (r scheduledMessage) Claim(mod *types.ScheduledMessage, next *time.Time) (bool, error) {
+	var (
+		q = squirrel.
+			Update(r.table()).
+			Set("next_run_at", next).
+			Where(squirrel.Eq{"id": mod.ID, "next_run_at": mod.NextRunAt})
+	)
+
+	res, err := squirrel.ExecWith(r.db(), q)
+	if err != nil {
+		return false, err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return false, err
+	} else if n == 0 {
+		return false, nil
+	}
+
+	mod.NextRunAt = next
+	return true, nil
+}
+
+func (r scheduledMessage) DeleteByID(ID uint64) error {
+	return rh.UpdateColumns(r.db(), r.table(), rh.Set{"deleted_at": time.Now()}, squirrel.Eq{"id": ID})
+}
@@ -41,6 +41,7 @@ type MessageAPI interface {
 	BookmarkRemove(context.Context, *request.MessageBookmarkRemove) (interface{}, error)
 	ReactionCreate(context.Context, *request.MessageReactionCreate) (interface{}, error)
 	ReactionRemove(context.Context, *request.MessageReactionRemove) (interface{}, error)
+	Pinned(context.Context, *request.MessagePinned) (interface{}, error)
 }
 
 // HTTP API interface
@@ -57,6 +58,7 @@ type Message struct {
 	BookmarkRemove func(http.ResponseWriter, *http.Request)
 	ReactionCreate func(http.ResponseWriter, *http.Request)
 	ReactionRemove func(http.ResponseWriter, *http.Request)
+	Pinned         func(http.ResponseWriter, *http.Request)
 }
 
 func NewMessage(h MessageAPI) *Message {
@@ -301,6 +303,26 @@ func NewMessage(h MessageAPI) *Message {
 				resputil.JSON(w, value)
 			}
 		},
+		Pinned: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewMessagePinned()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Message.Pinned", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Pinned(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Message.Pinned", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Message.Pinned", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
 	}
 }
 
@@ -319,5 +341,6 @@ func (h Message) MountRoutes(r chi.Router, middlewares ...func(http.Handler) htt
 		r.Delete("/channels/{channelID}/messages/{messageID}/bookmark", h.BookmarkRemove)
 		r.Post("/channels/{channelID}/messages/{messageID}/reaction/{reaction}", h.ReactionCreate)
 		r.Delete("/channels/{channelID}/messages/{messageID}/reaction/{reaction}", h.ReactionRemove)
+		r.Get("/channels/{channelID}/messages/pinned", h.Pinned)
 	})
 }
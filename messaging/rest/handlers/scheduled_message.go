@@ -0,0 +1,162 @@
+package handlers
+
+/*
+	Hello! This file is auto-generated from `docs/src/spec.json`.
+
+	For development:
+	In order to update the generated files, edit this file under the location,
+	add your struct fields, imports, API definitions and whatever you want, and:
+
+	1. run [spec](https://github.com/titpetric/spec) in the same folder,
+	2. run `./_gen.php` in this folder.
+
+	You may edit `scheduled_message.go`, `scheduled_message.util.go` or `scheduled_message_test.go` to
+	implement your API calls, helper functions and tests. The file `scheduled_message.go`
+	is only generated the first time, and will not be overwritten if it exists.
+*/
+
+import (
+	"context"
+
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/titpetric/factory/resputil"
+
+	"github.com/cortezaproject/corteza-server/messaging/rest/request"
+	"github.com/cortezaproject/corteza-server/pkg/logger"
+)
+
+// Internal API interface
+type ScheduledMessageAPI interface {
+	List(context.Context, *request.ScheduledMessageList) (interface{}, error)
+	Create(context.Context, *request.ScheduledMessageCreate) (interface{}, error)
+	Read(context.Context, *request.ScheduledMessageRead) (interface{}, error)
+	Update(context.Context, *request.ScheduledMessageUpdate) (interface{}, error)
+	Cancel(context.Context, *request.ScheduledMessageCancel) (interface{}, error)
+}
+
+// HTTP API interface
+type ScheduledMessage struct {
+	List   func(http.ResponseWriter, *http.Request)
+	Create func(http.ResponseWriter, *http.Request)
+	Read   func(http.ResponseWriter, *http.Request)
+	Update func(http.ResponseWriter, *http.Request)
+	Cancel func(http.ResponseWriter, *http.Request)
+}
+
+func NewScheduledMessage(h ScheduledMessageAPI) *ScheduledMessage {
+	return &ScheduledMessage{
+		List: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewScheduledMessageList()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("ScheduledMessage.List", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.List(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("ScheduledMessage.List", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("ScheduledMessage.List", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Create: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewScheduledMessageCreate()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("ScheduledMessage.Create", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Create(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("ScheduledMessage.Create", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("ScheduledMessage.Create", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Read: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewScheduledMessageRead()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("ScheduledMessage.Read", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Read(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("ScheduledMessage.Read", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("ScheduledMessage.Read", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Update: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewScheduledMessageUpdate()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("ScheduledMessage.Update", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Update(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("ScheduledMessage.Update", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("ScheduledMessage.Update", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Cancel: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewScheduledMessageCancel()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("ScheduledMessage.Cancel", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Cancel(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("ScheduledMessage.Cancel", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("ScheduledMessage.Cancel", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+	}
+}
+
+func (h ScheduledMessage) MountRoutes(r chi.Router, middlewares ...func(http.Handler) http.Handler) {
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares...)
+		r.Get("/scheduled-messages/", h.List)
+		r.Post("/scheduled-messages/", h.Create)
+		r.Get("/scheduled-messages/{scheduledMessageID}", h.Read)
+		r.Put("/scheduled-messages/{scheduledMessageID}", h.Update)
+		r.Delete("/scheduled-messages/{scheduledMessageID}", h.Cancel)
+	})
+}
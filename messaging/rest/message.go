@@ -80,6 +80,15 @@ func (ctrl *Message) PinRemove(ctx context.Context, r *request.MessagePinRemove)
 	return resputil.OK(), ctrl.svc.msg.With(ctx).RemovePin(r.MessageID)
 }
 
+func (ctrl *Message) Pinned(ctx context.Context, r *request.MessagePinned) (interface{}, error) {
+	mm, err := ctrl.svc.msg.With(ctx).FindPinned(r.ChannelID)
+	if err != nil {
+		return nil, err
+	}
+
+	return payload.PinnedMessages(ctx, mm), nil
+}
+
 func (ctrl *Message) BookmarkCreate(ctx context.Context, r *request.MessageBookmarkCreate) (interface{}, error) {
 	return resputil.OK(), ctrl.svc.msg.With(ctx).Bookmark(r.MessageID)
 }
@@ -894,6 +894,64 @@ func (r *MessageReactionRemove) Fill(req *http.Request) (err error) {
 
 var _ RequestFiller = NewMessageReactionRemove()
 
+// MessagePinned request parameters
+type MessagePinned struct {
+	hasChannelID bool
+	rawChannelID string
+	ChannelID    uint64 `json:",string"`
+}
+
+// NewMessagePinned request
+func NewMessagePinned() *MessagePinned {
+	return &MessagePinned{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r MessagePinned) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["channelID"] = r.ChannelID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *MessagePinned) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasChannelID = true
+	r.rawChannelID = chi.URLParam(req, "channelID")
+	r.ChannelID = parseUInt64(chi.URLParam(req, "channelID"))
+
+	return err
+}
+
+var _ RequestFiller = NewMessagePinned()
+
 // HasMessage returns true if message was set
 func (r *MessageCreate) HasMessage() bool {
 	return r.hasMessage
@@ -1358,3 +1416,18 @@ func (r *MessageReactionRemove) RawChannelID() string {
 func (r *MessageReactionRemove) GetChannelID() uint64 {
 	return r.ChannelID
 }
+
+// HasChannelID returns true if channelID was set
+func (r *MessagePinned) HasChannelID() bool {
+	return r.hasChannelID
+}
+
+// RawChannelID returns raw value of channelID parameter
+func (r *MessagePinned) RawChannelID() string {
+	return r.rawChannelID
+}
+
+// GetChannelID returns casted value of  channelID parameter
+func (r *MessagePinned) GetChannelID() uint64 {
+	return r.ChannelID
+}
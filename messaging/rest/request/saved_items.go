@@ -0,0 +1,136 @@
+package request
+
+/*
+	Hello! This file is auto-generated from `docs/src/spec.json`.
+
+	For development:
+	In order to update the generated files, edit this file under the location,
+	add your struct fields, imports, API definitions and whatever you want, and:
+
+	1. run [spec](https://github.com/titpetric/spec) in the same folder,
+	2. run `./_gen.php` in this folder.
+
+	You may edit `saved_items.go`, `saved_items.util.go` or `saved_items_test.go` to
+	implement your API calls, helper functions and tests. The file `saved_items.go`
+	is only generated the first time, and will not be overwritten if it exists.
+*/
+
+import (
+	"io"
+	"strings"
+
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/pkg/errors"
+)
+
+var _ = chi.URLParam
+var _ = multipart.FileHeader{}
+
+// SavedItemsList request parameters
+type SavedItemsList struct {
+	hasChannelID bool
+	rawChannelID []string
+	ChannelID    []string
+
+	hasLimit bool
+	rawLimit string
+	Limit    uint
+}
+
+// NewSavedItemsList request
+func NewSavedItemsList() *SavedItemsList {
+	return &SavedItemsList{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r SavedItemsList) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["channelID"] = r.ChannelID
+	out["limit"] = r.Limit
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *SavedItemsList) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := urlQuery["channelID[]"]; ok {
+		r.hasChannelID = true
+		r.rawChannelID = val
+		r.ChannelID = parseStrings(val)
+	} else if val, ok = urlQuery["channelID"]; ok {
+		r.hasChannelID = true
+		r.rawChannelID = val
+		r.ChannelID = parseStrings(val)
+	}
+
+	if val, ok := get["limit"]; ok {
+		r.hasLimit = true
+		r.rawLimit = val
+		r.Limit = parseUint(val)
+	}
+
+	return err
+}
+
+var _ RequestFiller = NewSavedItemsList()
+
+// HasChannelID returns true if channelID was set
+func (r *SavedItemsList) HasChannelID() bool {
+	return r.hasChannelID
+}
+
+// RawChannelID returns raw value of channelID parameter
+func (r *SavedItemsList) RawChannelID() []string {
+	return r.rawChannelID
+}
+
+// GetChannelID returns casted value of  channelID parameter
+func (r *SavedItemsList) GetChannelID() []string {
+	return r.ChannelID
+}
+
+// HasLimit returns true if limit was set
+func (r *SavedItemsList) HasLimit() bool {
+	return r.hasLimit
+}
+
+// RawLimit returns raw value of limit parameter
+func (r *SavedItemsList) RawLimit() string {
+	return r.rawLimit
+}
+
+// GetLimit returns casted value of  limit parameter
+func (r *SavedItemsList) GetLimit() uint {
+	return r.Limit
+}
@@ -0,0 +1,685 @@
+package request
+
+/*
+	Hello! This file is auto-generated from `docs/src/spec.json`.
+
+	For development:
+	In order to update the generated files, edit this file under the location,
+	add your struct fields, imports, API definitions and whatever you want, and:
+
+	1. run [spec](https://github.com/titpetric/spec) in the same folder,
+	2. run `./_gen.php` in this folder.
+
+	You may edit `scheduled_message.go`, `scheduled_message.util.go` or `scheduled_message_test.go` to
+	implement your API calls, helper functions and tests. The file `scheduled_message.go`
+	is only generated the first time, and will not be overwritten if it exists.
+*/
+
+import (
+	"io"
+	"strings"
+
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/pkg/errors"
+
+	"time"
+)
+
+var _ = chi.URLParam
+var _ = multipart.FileHeader{}
+
+// ScheduledMessageList request parameters
+type ScheduledMessageList struct {
+	hasChannelID bool
+	rawChannelID string
+	ChannelID    uint64 `json:",string"`
+
+	hasIncDelivered bool
+	rawIncDelivered string
+	IncDelivered    bool
+}
+
+// NewScheduledMessageList request
+func NewScheduledMessageList() *ScheduledMessageList {
+	return &ScheduledMessageList{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r ScheduledMessageList) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["channelID"] = r.ChannelID
+	out["incDelivered"] = r.IncDelivered
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *ScheduledMessageList) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := get["channelID"]; ok {
+		r.hasChannelID = true
+		r.rawChannelID = val
+		r.ChannelID = parseUInt64(val)
+	}
+	if val, ok := get["incDelivered"]; ok {
+		r.hasIncDelivered = true
+		r.rawIncDelivered = val
+		r.IncDelivered = parseBool(val)
+	}
+
+	return err
+}
+
+var _ RequestFiller = NewScheduledMessageList()
+
+// ScheduledMessageCreate request parameters
+type ScheduledMessageCreate struct {
+	hasChannelID bool
+	rawChannelID string
+	ChannelID    uint64 `json:",string"`
+
+	hasReplyTo bool
+	rawReplyTo string
+	ReplyTo    uint64 `json:",string"`
+
+	hasMessage bool
+	rawMessage string
+	Message    string
+
+	hasScheduledAt bool
+	rawScheduledAt string
+	ScheduledAt    *time.Time
+
+	hasCron bool
+	rawCron string
+	Cron    string
+
+	hasTimezone bool
+	rawTimezone string
+	Timezone    string
+}
+
+// NewScheduledMessageCreate request
+func NewScheduledMessageCreate() *ScheduledMessageCreate {
+	return &ScheduledMessageCreate{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r ScheduledMessageCreate) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["channelID"] = r.ChannelID
+	out["replyTo"] = r.ReplyTo
+	out["message"] = "*masked*sensitive*data*"
+
+	out["scheduledAt"] = r.ScheduledAt
+	out["cron"] = r.Cron
+	out["timezone"] = r.Timezone
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *ScheduledMessageCreate) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := post["channelID"]; ok {
+		r.hasChannelID = true
+		r.rawChannelID = val
+		r.ChannelID = parseUInt64(val)
+	}
+	if val, ok := post["replyTo"]; ok {
+		r.hasReplyTo = true
+		r.rawReplyTo = val
+		r.ReplyTo = parseUInt64(val)
+	}
+	if val, ok := post["message"]; ok {
+		r.hasMessage = true
+		r.rawMessage = val
+		r.Message = val
+	}
+	if val, ok := post["scheduledAt"]; ok {
+		r.hasScheduledAt = true
+		r.rawScheduledAt = val
+
+		if r.ScheduledAt, err = parseISODatePtrWithErr(val); err != nil {
+			return err
+		}
+	}
+	if val, ok := post["cron"]; ok {
+		r.hasCron = true
+		r.rawCron = val
+		r.Cron = val
+	}
+	if val, ok := post["timezone"]; ok {
+		r.hasTimezone = true
+		r.rawTimezone = val
+		r.Timezone = val
+	}
+
+	return err
+}
+
+var _ RequestFiller = NewScheduledMessageCreate()
+
+// ScheduledMessageRead request parameters
+type ScheduledMessageRead struct {
+	hasScheduledMessageID bool
+	rawScheduledMessageID string
+	ScheduledMessageID    uint64 `json:",string"`
+}
+
+// NewScheduledMessageRead request
+func NewScheduledMessageRead() *ScheduledMessageRead {
+	return &ScheduledMessageRead{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r ScheduledMessageRead) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["scheduledMessageID"] = r.ScheduledMessageID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *ScheduledMessageRead) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasScheduledMessageID = true
+	r.rawScheduledMessageID = chi.URLParam(req, "scheduledMessageID")
+	r.ScheduledMessageID = parseUInt64(chi.URLParam(req, "scheduledMessageID"))
+
+	return err
+}
+
+var _ RequestFiller = NewScheduledMessageRead()
+
+// ScheduledMessageUpdate request parameters
+type ScheduledMessageUpdate struct {
+	hasScheduledMessageID bool
+	rawScheduledMessageID string
+	ScheduledMessageID    uint64 `json:",string"`
+
+	hasReplyTo bool
+	rawReplyTo string
+	ReplyTo    uint64 `json:",string"`
+
+	hasMessage bool
+	rawMessage string
+	Message    string
+
+	hasScheduledAt bool
+	rawScheduledAt string
+	ScheduledAt    *time.Time
+
+	hasCron bool
+	rawCron string
+	Cron    string
+
+	hasTimezone bool
+	rawTimezone string
+	Timezone    string
+}
+
+// NewScheduledMessageUpdate request
+func NewScheduledMessageUpdate() *ScheduledMessageUpdate {
+	return &ScheduledMessageUpdate{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r ScheduledMessageUpdate) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["scheduledMessageID"] = r.ScheduledMessageID
+	out["replyTo"] = r.ReplyTo
+	out["message"] = "*masked*sensitive*data*"
+
+	out["scheduledAt"] = r.ScheduledAt
+	out["cron"] = r.Cron
+	out["timezone"] = r.Timezone
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *ScheduledMessageUpdate) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasScheduledMessageID = true
+	r.rawScheduledMessageID = chi.URLParam(req, "scheduledMessageID")
+	r.ScheduledMessageID = parseUInt64(chi.URLParam(req, "scheduledMessageID"))
+	if val, ok := post["replyTo"]; ok {
+		r.hasReplyTo = true
+		r.rawReplyTo = val
+		r.ReplyTo = parseUInt64(val)
+	}
+	if val, ok := post["message"]; ok {
+		r.hasMessage = true
+		r.rawMessage = val
+		r.Message = val
+	}
+	if val, ok := post["scheduledAt"]; ok {
+		r.hasScheduledAt = true
+		r.rawScheduledAt = val
+
+		if r.ScheduledAt, err = parseISODatePtrWithErr(val); err != nil {
+			return err
+		}
+	}
+	if val, ok := post["cron"]; ok {
+		r.hasCron = true
+		r.rawCron = val
+		r.Cron = val
+	}
+	if val, ok := post["timezone"]; ok {
+		r.hasTimezone = true
+		r.rawTimezone = val
+		r.Timezone = val
+	}
+
+	return err
+}
+
+var _ RequestFiller = NewScheduledMessageUpdate()
+
+// ScheduledMessageCancel request parameters
+type ScheduledMessageCancel struct {
+	hasScheduledMessageID bool
+	rawScheduledMessageID string
+	ScheduledMessageID    uint64 `json:",string"`
+}
+
+// NewScheduledMessageCancel request
+func NewScheduledMessageCancel() *ScheduledMessageCancel {
+	return &ScheduledMessageCancel{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r ScheduledMessageCancel) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["scheduledMessageID"] = r.ScheduledMessageID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *ScheduledMessageCancel) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasScheduledMessageID = true
+	r.rawScheduledMessageID = chi.URLParam(req, "scheduledMessageID")
+	r.ScheduledMessageID = parseUInt64(chi.URLParam(req, "scheduledMessageID"))
+
+	return err
+}
+
+var _ RequestFiller = NewScheduledMessageCancel()
+
+// HasChannelID returns true if channelID was set
+func (r *ScheduledMessageList) HasChannelID() bool {
+	return r.hasChannelID
+}
+
+// RawChannelID returns raw value of channelID parameter
+func (r *ScheduledMessageList) RawChannelID() string {
+	return r.rawChannelID
+}
+
+// GetChannelID returns casted value of  channelID parameter
+func (r *ScheduledMessageList) GetChannelID() uint64 {
+	return r.ChannelID
+}
+
+// HasIncDelivered returns true if incDelivered was set
+func (r *ScheduledMessageList) HasIncDelivered() bool {
+	return r.hasIncDelivered
+}
+
+// RawIncDelivered returns raw value of incDelivered parameter
+func (r *ScheduledMessageList) RawIncDelivered() string {
+	return r.rawIncDelivered
+}
+
+// GetIncDelivered returns casted value of  incDelivered parameter
+func (r *ScheduledMessageList) GetIncDelivered() bool {
+	return r.IncDelivered
+}
+
+// HasChannelID returns true if channelID was set
+func (r *ScheduledMessageCreate) HasChannelID() bool {
+	return r.hasChannelID
+}
+
+// RawChannelID returns raw value of channelID parameter
+func (r *ScheduledMessageCreate) RawChannelID() string {
+	return r.rawChannelID
+}
+
+// GetChannelID returns casted value of  channelID parameter
+func (r *ScheduledMessageCreate) GetChannelID() uint64 {
+	return r.ChannelID
+}
+
+// HasReplyTo returns true if replyTo was set
+func (r *ScheduledMessageCreate) HasReplyTo() bool {
+	return r.hasReplyTo
+}
+
+// RawReplyTo returns raw value of replyTo parameter
+func (r *ScheduledMessageCreate) RawReplyTo() string {
+	return r.rawReplyTo
+}
+
+// GetReplyTo returns casted value of  replyTo parameter
+func (r *ScheduledMessageCreate) GetReplyTo() uint64 {
+	return r.ReplyTo
+}
+
+// HasMessage returns true if message was set
+func (r *ScheduledMessageCreate) HasMessage() bool {
+	return r.hasMessage
+}
+
+// RawMessage returns raw value of message parameter
+func (r *ScheduledMessageCreate) RawMessage() string {
+	return r.rawMessage
+}
+
+// GetMessage returns casted value of  message parameter
+func (r *ScheduledMessageCreate) GetMessage() string {
+	return r.Message
+}
+
+// HasScheduledAt returns true if scheduledAt was set
+func (r *ScheduledMessageCreate) HasScheduledAt() bool {
+	return r.hasScheduledAt
+}
+
+// RawScheduledAt returns raw value of scheduledAt parameter
+func (r *ScheduledMessageCreate) RawScheduledAt() string {
+	return r.rawScheduledAt
+}
+
+// GetScheduledAt returns casted value of  scheduledAt parameter
+func (r *ScheduledMessageCreate) GetScheduledAt() *time.Time {
+	return r.ScheduledAt
+}
+
+// HasCron returns true if cron was set
+func (r *ScheduledMessageCreate) HasCron() bool {
+	return r.hasCron
+}
+
+// RawCron returns raw value of cron parameter
+func (r *ScheduledMessageCreate) RawCron() string {
+	return r.rawCron
+}
+
+// GetCron returns casted value of  cron parameter
+func (r *ScheduledMessageCreate) GetCron() string {
+	return r.Cron
+}
+
+// HasTimezone returns true if timezone was set
+func (r *ScheduledMessageCreate) HasTimezone() bool {
+	return r.hasTimezone
+}
+
+// RawTimezone returns raw value of timezone parameter
+func (r *ScheduledMessageCreate) RawTimezone() string {
+	return r.rawTimezone
+}
+
+// GetTimezone returns casted value of  timezone parameter
+func (r *ScheduledMessageCreate) GetTimezone() string {
+	return r.Timezone
+}
+
+// HasScheduledMessageID returns true if scheduledMessageID was set
+func (r *ScheduledMessageRead) HasScheduledMessageID() bool {
+	return r.hasScheduledMessageID
+}
+
+// RawScheduledMessageID returns raw value of scheduledMessageID parameter
+func (r *ScheduledMessageRead) RawScheduledMessageID() string {
+	return r.rawScheduledMessageID
+}
+
+// GetScheduledMessageID returns casted value of  scheduledMessageID parameter
+func (r *ScheduledMessageRead) GetScheduledMessageID() uint64 {
+	return r.ScheduledMessageID
+}
+
+// HasScheduledMessageID returns true if scheduledMessageID was set
+func (r *ScheduledMessageUpdate) HasScheduledMessageID() bool {
+	return r.hasScheduledMessageID
+}
+
+// RawScheduledMessageID returns raw value of scheduledMessageID parameter
+func (r *ScheduledMessageUpdate) RawScheduledMessageID() string {
+	return r.rawScheduledMessageID
+}
+
+// GetScheduledMessageID returns casted value of  scheduledMessageID parameter
+func (r *ScheduledMessageUpdate) GetScheduledMessageID() uint64 {
+	return r.ScheduledMessageID
+}
+
+// HasReplyTo returns true if replyTo was set
+func (r *ScheduledMessageUpdate) HasReplyTo() bool {
+	return r.hasReplyTo
+}
+
+// RawReplyTo returns raw value of replyTo parameter
+func (r *ScheduledMessageUpdate) RawReplyTo() string {
+	return r.rawReplyTo
+}
+
+// GetReplyTo returns casted value of  replyTo parameter
+func (r *ScheduledMessageUpdate) GetReplyTo() uint64 {
+	return r.ReplyTo
+}
+
+// HasMessage returns true if message was set
+func (r *ScheduledMessageUpdate) HasMessage() bool {
+	return r.hasMessage
+}
+
+// RawMessage returns raw value of message parameter
+func (r *ScheduledMessageUpdate) RawMessage() string {
+	return r.rawMessage
+}
+
+// GetMessage returns casted value of  message parameter
+func (r *ScheduledMessageUpdate) GetMessage() string {
+	return r.Message
+}
+
+// HasScheduledAt returns true if scheduledAt was set
+func (r *ScheduledMessageUpdate) HasScheduledAt() bool {
+	return r.hasScheduledAt
+}
+
+// RawScheduledAt returns raw value of scheduledAt parameter
+func (r *ScheduledMessageUpdate) RawScheduledAt() string {
+	return r.rawScheduledAt
+}
+
+// GetScheduledAt returns casted value of  scheduledAt parameter
+func (r *ScheduledMessageUpdate) GetScheduledAt() *time.Time {
+	return r.ScheduledAt
+}
+
+// HasCron returns true if cron was set
+func (r *ScheduledMessageUpdate) HasCron() bool {
+	return r.hasCron
+}
+
+// RawCron returns raw value of cron parameter
+func (r *ScheduledMessageUpdate) RawCron() string {
+	return r.rawCron
+}
+
+// GetCron returns casted value of  cron parameter
+func (r *ScheduledMessageUpdate) GetCron() string {
+	return r.Cron
+}
+
+// HasTimezone returns true if timezone was set
+func (r *ScheduledMessageUpdate) HasTimezone() bool {
+	return r.hasTimezone
+}
+
+// RawTimezone returns raw value of timezone parameter
+func (r *ScheduledMessageUpdate) RawTimezone() string {
+	return r.rawTimezone
+}
+
+// GetTimezone returns casted value of  timezone parameter
+func (r *ScheduledMessageUpdate) GetTimezone() string {
+	return r.Timezone
+}
+
+// HasScheduledMessageID returns true if scheduledMessageID was set
+func (r *ScheduledMessageCancel) HasScheduledMessageID() bool {
+	return r.hasScheduledMessageID
+}
+
+// RawScheduledMessageID returns raw value of scheduledMessageID parameter
+func (r *ScheduledMessageCancel) RawScheduledMessageID() string {
+	return r.rawScheduledMessageID
+}
+
+// GetScheduledMessageID returns casted value of  scheduledMessageID parameter
+func (r *ScheduledMessageCancel) GetScheduledMessageID() uint64 {
+	return r.ScheduledMessageID
+}
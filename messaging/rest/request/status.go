@@ -32,6 +32,9 @@ var _ = multipart.FileHeader{}
 
 // StatusList request parameters
 type StatusList struct {
+	hasUserID bool
+	rawUserID []string
+	UserID    []string
 }
 
 // NewStatusList request
@@ -43,6 +46,8 @@ func NewStatusList() *StatusList {
 func (r StatusList) Auditable() map[string]interface{} {
 	var out = map[string]interface{}{}
 
+	out["userID"] = r.UserID
+
 	return out
 }
 
@@ -74,6 +79,16 @@ func (r *StatusList) Fill(req *http.Request) (err error) {
 		post[name] = string(param[0])
 	}
 
+	if val, ok := urlQuery["userID[]"]; ok {
+		r.hasUserID = true
+		r.rawUserID = val
+		r.UserID = parseStrings(val)
+	} else if val, ok = urlQuery["userID"]; ok {
+		r.hasUserID = true
+		r.rawUserID = val
+		r.UserID = parseStrings(val)
+	}
+
 	return err
 }
 
@@ -81,6 +96,10 @@ var _ RequestFiller = NewStatusList()
 
 // StatusSet request parameters
 type StatusSet struct {
+	hasStatus bool
+	rawStatus string
+	Status    string
+
 	hasIcon bool
 	rawIcon string
 	Icon    string
@@ -103,6 +122,7 @@ func NewStatusSet() *StatusSet {
 func (r StatusSet) Auditable() map[string]interface{} {
 	var out = map[string]interface{}{}
 
+	out["status"] = r.Status
 	out["icon"] = r.Icon
 	out["message"] = r.Message
 	out["expires"] = r.Expires
@@ -138,6 +158,11 @@ func (r *StatusSet) Fill(req *http.Request) (err error) {
 		post[name] = string(param[0])
 	}
 
+	if val, ok := post["status"]; ok {
+		r.hasStatus = true
+		r.rawStatus = val
+		r.Status = val
+	}
 	if val, ok := post["icon"]; ok {
 		r.hasIcon = true
 		r.rawIcon = val
@@ -208,6 +233,36 @@ func (r *StatusDelete) Fill(req *http.Request) (err error) {
 
 var _ RequestFiller = NewStatusDelete()
 
+// HasUserID returns true if userID was set
+func (r *StatusList) HasUserID() bool {
+	return r.hasUserID
+}
+
+// RawUserID returns raw value of userID parameter
+func (r *StatusList) RawUserID() []string {
+	return r.rawUserID
+}
+
+// GetUserID returns casted value of  userID parameter
+func (r *StatusList) GetUserID() []string {
+	return r.UserID
+}
+
+// HasStatus returns true if status was set
+func (r *StatusSet) HasStatus() bool {
+	return r.hasStatus
+}
+
+// RawStatus returns raw value of status parameter
+func (r *StatusSet) RawStatus() string {
+	return r.rawStatus
+}
+
+// GetStatus returns casted value of  status parameter
+func (r *StatusSet) GetStatus() string {
+	return r.Status
+}
+
 // HasIcon returns true if icon was set
 func (r *StatusSet) HasIcon() bool {
 	return r.hasIcon
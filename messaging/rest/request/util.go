@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx/types"
 	"github.com/pkg/errors"
@@ -25,6 +26,19 @@ func parseJSONText(s string) (types.JSONText, error) {
 	return *result, err
 }
 
+func parseISODateWithErr(s string) (time.Time, error) {
+	return time.Parse(time.RFC3339, s)
+}
+
+func parseISODatePtrWithErr(s string) (*time.Time, error) {
+	t, err := parseISODateWithErr(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
 // parseInt parses a string to int
 func parseInt(s string) int {
 	if s == "" {
@@ -23,9 +23,11 @@ func MountRoutes(r chi.Router) {
 		handlers.NewChannel(Channel{}.New()).MountRoutes(r)
 		handlers.NewMessage(Message{}.New()).MountRoutes(r)
 		handlers.NewSearch(Search{}.New()).MountRoutes(r)
+		handlers.NewSavedItems(SavedItems{}.New()).MountRoutes(r)
 		handlers.NewStatus(Status{}.New()).MountRoutes(r)
 		handlers.NewCommands(Commands{}.New()).MountRoutes(r)
 		handlers.NewWebhooks(Webhooks{}.New()).MountRoutes(r)
+		handlers.NewScheduledMessage(ScheduledMessage{}.New()).MountRoutes(r)
 		handlers.NewPermissions(Permissions{}.New()).MountRoutes(r)
 		handlers.NewSettings(Settings{}.New()).MountRoutes(r)
 	})
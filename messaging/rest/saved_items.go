@@ -0,0 +1,39 @@
+package rest
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/cortezaproject/corteza-server/messaging/rest/request"
+	"github.com/cortezaproject/corteza-server/messaging/service"
+	"github.com/cortezaproject/corteza-server/messaging/types"
+	"github.com/cortezaproject/corteza-server/pkg/payload"
+)
+
+var _ = errors.Wrap
+
+type SavedItems struct {
+	svc struct {
+		msg service.MessageService
+	}
+}
+
+func (SavedItems) New() *SavedItems {
+	ctrl := &SavedItems{}
+	ctrl.svc.msg = service.DefaultMessage
+	return ctrl
+}
+
+func (ctrl *SavedItems) List(ctx context.Context, r *request.SavedItemsList) (interface{}, error) {
+	mm, err := ctrl.svc.msg.With(ctx).FindBookmarked(types.MessageFilter{
+		ChannelID: payload.ParseUInt64s(r.ChannelID),
+		Limit:     r.Limit,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return payload.SavedItems(ctx, mm), nil
+}
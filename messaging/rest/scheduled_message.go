@@ -0,0 +1,81 @@
+package rest
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/titpetric/factory/resputil"
+
+	"github.com/cortezaproject/corteza-server/messaging/rest/request"
+	"github.com/cortezaproject/corteza-server/messaging/service"
+	"github.com/cortezaproject/corteza-server/messaging/types"
+	"github.com/cortezaproject/corteza-server/pkg/payload"
+	"github.com/cortezaproject/corteza-server/pkg/payload/outgoing"
+)
+
+var _ = errors.Wrap
+
+type (
+	ScheduledMessage struct {
+		svc struct {
+			scheduled service.ScheduledMessageService
+		}
+	}
+)
+
+func (ScheduledMessage) New() *ScheduledMessage {
+	ctrl := &ScheduledMessage{}
+	ctrl.svc.scheduled = service.DefaultScheduledMessage
+	return ctrl
+}
+
+func (ctrl *ScheduledMessage) List(ctx context.Context, r *request.ScheduledMessageList) (interface{}, error) {
+	set, err := ctrl.svc.scheduled.With(ctx).Find(types.ScheduledMessageFilter{
+		ChannelID:    r.ChannelID,
+		IncDelivered: r.IncDelivered,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return payload.ScheduledMessages(set), nil
+}
+
+func (ctrl *ScheduledMessage) Create(ctx context.Context, r *request.ScheduledMessageCreate) (interface{}, error) {
+	return ctrl.wrap(ctrl.svc.scheduled.With(ctx).Create(&types.ScheduledMessage{
+		ChannelID:   r.ChannelID,
+		ReplyTo:     r.ReplyTo,
+		Message:     r.Message,
+		ScheduledAt: r.ScheduledAt,
+		Cron:        r.Cron,
+		Timezone:    r.Timezone,
+	}))
+}
+
+func (ctrl *ScheduledMessage) Read(ctx context.Context, r *request.ScheduledMessageRead) (interface{}, error) {
+	return ctrl.wrap(ctrl.svc.scheduled.With(ctx).FindByID(r.ScheduledMessageID))
+}
+
+func (ctrl *ScheduledMessage) Update(ctx context.Context, r *request.ScheduledMessageUpdate) (interface{}, error) {
+	return ctrl.wrap(ctrl.svc.scheduled.With(ctx).Update(&types.ScheduledMessage{
+		ID:          r.ScheduledMessageID,
+		ReplyTo:     r.ReplyTo,
+		Message:     r.Message,
+		ScheduledAt: r.ScheduledAt,
+		Cron:        r.Cron,
+		Timezone:    r.Timezone,
+	}))
+}
+
+func (ctrl *ScheduledMessage) Cancel(ctx context.Context, r *request.ScheduledMessageCancel) (interface{}, error) {
+	return resputil.OK(), ctrl.svc.scheduled.With(ctx).Cancel(r.ScheduledMessageID)
+}
+
+func (ctrl *ScheduledMessage) wrap(sm *types.ScheduledMessage, err error) (*outgoing.ScheduledMessage, error) {
+	if err != nil || sm == nil {
+		return nil, err
+	}
+
+	return payload.ScheduledMessage(sm), nil
+}
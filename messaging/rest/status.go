@@ -2,46 +2,80 @@ package rest
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/cortezaproject/corteza-server/messaging/rest/request"
-	"github.com/cortezaproject/corteza-server/messaging/websocket"
+	"github.com/cortezaproject/corteza-server/messaging/service"
+	"github.com/cortezaproject/corteza-server/messaging/types"
+	"github.com/cortezaproject/corteza-server/pkg/payload"
 )
 
 var _ = errors.Wrap
 
 type Status struct {
-	// xxx service.XXXService
+	svc struct {
+		presence service.PresenceService
+	}
 }
 
 func (Status) New() *Status {
-	return &Status{}
+	ctrl := &Status{}
+	ctrl.svc.presence = service.DefaultPresence
+	return ctrl
 }
 
 func (ctrl *Status) List(ctx context.Context, r *request.StatusList) (interface{}, error) {
-	type userStatus struct {
-		UserID  uint64 `json:"userID,string"`
-		Status  string `json:"present"`
-		Icon    string `json:"icon"`
-		Message string `json:"message"`
-	}
-
-	out := []userStatus{}
-	for _, userID := range websocket.GetConnectedUsers() {
-		out = append(out, userStatus{
-			UserID: userID,
-			Status: "online",
-		})
+	pp, err := ctrl.svc.presence.With(ctx).Find(payload.ParseUInt64s(r.UserID)...)
+	if err != nil {
+		return nil, err
 	}
 
-	return out, nil
+	return payload.Presences(pp), nil
 }
 
 func (ctrl *Status) Set(ctx context.Context, r *request.StatusSet) (interface{}, error) {
-	return nil, errors.New("Not implemented: Status.set")
+	expiresAt, err := parseStatusExpiration(r.Expires, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := ctrl.svc.presence.With(ctx).SetStatus(types.PresenceStatus(r.Status), r.Icon, r.Message, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return payload.Presence(p), nil
 }
 
 func (ctrl *Status) Delete(ctx context.Context, r *request.StatusDelete) (interface{}, error) {
-	return nil, errors.New("Not implemented: Status.delete")
+	p, err := ctrl.svc.presence.With(ctx).ClearStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	return payload.Presence(p), nil
+}
+
+// parseStatusExpiration accepts duration (30m, 1h30m), RFC3339 timestamp or a date (2019-05-20)
+func parseStatusExpiration(expires string, now time.Time) (*time.Time, error) {
+	expires = strings.TrimSpace(expires)
+	if expires == "" {
+		return nil, nil
+	}
+
+	if d, err := time.ParseDuration(expires); err == nil {
+		t := now.Add(d)
+		return &t, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, expires); err == nil {
+			return &t, nil
+		}
+	}
+
+	return nil, errors.Errorf("could not parse status expiration %q", expires)
 }
@@ -12,6 +12,8 @@ const (
 	ErrInvalidID          serviceError = "InvalidID"
 	ErrNoPermissions      serviceError = "NoPermissions"
 	ErrNoGrantPermissions serviceError = "NoGrantPermissions"
+	ErrInvalidReaction    serviceError = "InvalidReaction"
+	ErrTooManyReactions   serviceError = "TooManyReactions"
 )
 
 func (e serviceError) Error() string {
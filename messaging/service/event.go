@@ -24,8 +24,10 @@ type (
 	EventService interface {
 		With(ctx context.Context) EventService
 		Activity(a *types.Activity) error
+		Presence(p *types.Presence) error
+		Typing(t *types.Typing) error
 		Message(m *types.Message) error
-		MessageFlag(m *types.MessageFlag) error
+		MessageFlag(m *types.MessageFlag, rr types.MessageReactionSumSet) error
 		UnreadCounters(uu types.UnreadSet) error
 		Channel(m *types.Channel) error
 		Join(userID, channelID uint64) error
@@ -61,11 +63,23 @@ func (svc event) Message(m *types.Message) error {
 
 // Activity sends activity event to subscribers
 func (svc event) Activity(a *types.Activity) error {
-	return svc.push(payload.Activity(a), types.EventQueueItemSubTypeChannel, a.ChannelID)
+	return svc.pushVolatile(payload.Activity(a), types.EventQueueItemSubTypeChannel, a.ChannelID)
+}
+
+// Presence sends presence change event to all connected users
+func (svc event) Presence(p *types.Presence) error {
+	return svc.push(payload.Presence(p), types.EventQueueItemSubTypeChannel, 0)
+}
+
+// Typing sends typing indicator to channel subscribers
+func (svc event) Typing(t *types.Typing) error {
+	return svc.pushVolatile(payload.Typing(t), types.EventQueueItemSubTypeChannel, t.ChannelID)
 }
 
 // MessageFlag sends message flag events to subscribers
-func (svc event) MessageFlag(f *types.MessageFlag) error {
+//
+// Reaction events are sent with all aggregated reactions on the message
+func (svc event) MessageFlag(f *types.MessageFlag, rr types.MessageReactionSumSet) error {
 	var p outgoing.MessageEncoder
 
 	switch {
@@ -75,11 +89,11 @@ func (svc event) MessageFlag(f *types.MessageFlag) error {
 	case f.IsPin() && f.DeletedAt != nil:
 		p = payload.MessagePinRemoved(f)
 	case f.IsReaction() && f.DeletedAt != nil:
-		p = payload.MessageReactionRemoved(f)
+		p = payload.MessageReactionRemoved(f, rr)
 	case f.IsPin():
 		p = payload.MessagePin(f)
 	case f.IsReaction():
-		p = payload.MessageReaction(f)
+		p = payload.MessageReaction(f, rr)
 	default:
 		return nil
 	}
@@ -148,12 +162,21 @@ func (svc event) Part(userID, channelID uint64) (err error) {
 }
 
 func (svc event) push(m outgoing.MessageEncoder, subType types.EventQueueItemSubType, sub uint64) error {
+	return svc.enqueue(m, subType, sub, false)
+}
+
+// pushVolatile pushes events that are not worth replaying when session is resumed
+func (svc event) pushVolatile(m outgoing.MessageEncoder, subType types.EventQueueItemSubType, sub uint64) error {
+	return svc.enqueue(m, subType, sub, true)
+}
+
+func (svc event) enqueue(m outgoing.MessageEncoder, subType types.EventQueueItemSubType, sub uint64, volatile bool) error {
 	var enc, err = m.EncodeMessage()
 	if err != nil {
 		return err
 	}
 
-	item := &types.EventQueueItem{Payload: enc, SubType: subType}
+	item := &types.EventQueueItem{Payload: enc, SubType: subType, Volatile: volatile}
 
 	if sub > 0 {
 		item.Subscriber = payload.Uint64toa(sub)
@@ -4,7 +4,9 @@ import (
 	"context"
 	"io"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -50,6 +52,8 @@ type (
 
 		Find(types.MessageFilter) (types.MessageSet, types.MessageFilter, error)
 		FindThreads(types.MessageFilter) (types.MessageSet, types.MessageFilter, error)
+		FindPinned(channelID uint64) (types.MessageSet, error)
+		FindBookmarked(types.MessageFilter) (types.MessageSet, error)
 
 		Create(messages *types.Message) (*types.Message, error)
 		Update(messages *types.Message) (*types.Message, error)
@@ -74,6 +78,9 @@ type (
 const (
 	settingsMessageBodyLength = 0
 	mentionRE                 = `<([@#])(\d+)((?:\s)([^>]+))?>`
+
+	// Max number of distinct reactions on a message when not explicitly set
+	defaultMaxDistinctReactions = 20
 )
 
 var (
@@ -145,6 +152,48 @@ func (svc message) FindThreads(filter types.MessageFilter) (mm types.MessageSet,
 	return mm, f, svc.preload(mm)
 }
 
+// FindPinned returns all messages pinned to a channel
+//
+// Messages are ordered by time of pinning, latest first
+func (svc message) FindPinned(channelID uint64) (mm types.MessageSet, err error) {
+	if channelID == 0 {
+		return nil, ErrInvalidID.withStack()
+	}
+
+	mm, _, err = svc.Find(types.MessageFilter{
+		ChannelID:  []uint64{channelID},
+		PinnedOnly: true,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	sortByFlagTime(mm, func(m *types.Message) *types.MessageFlag {
+		return m.Flags.Pin()
+	})
+
+	return mm, nil
+}
+
+// FindBookmarked returns messages bookmarked by the current user across all readable channels
+//
+// Messages are ordered by time of bookmarking, latest first
+func (svc message) FindBookmarked(filter types.MessageFilter) (mm types.MessageSet, err error) {
+	filter.BookmarkedOnly = true
+	filter.PinnedOnly = false
+
+	if mm, filter, err = svc.Find(filter); err != nil {
+		return nil, err
+	}
+
+	sortByFlagTime(mm, func(m *types.Message) *types.MessageFlag {
+		return m.Flags.Bookmark(filter.CurrentUserID)
+	})
+
+	return mm, nil
+}
+
 func (svc message) CreateWithAvatar(in *types.Message, avatar io.Reader) (*types.Message, error) {
 	// @todo: avatar
 	return svc.Create(in)
@@ -504,11 +553,19 @@ func (svc message) MarkAsRead(channelID, threadID, lastReadMessageID uint64) (ui
 
 // React on a message with an emoji
 func (svc message) React(messageID uint64, reaction string) error {
+	if !types.IsValidReaction(reaction) {
+		return ErrInvalidReaction.withStack()
+	}
+
 	return svc.flag(messageID, reaction, false)
 }
 
 // Remove reaction on a message
 func (svc message) RemoveReaction(messageID uint64, reaction string) error {
+	if !types.IsValidReaction(reaction) {
+		return ErrInvalidReaction.withStack()
+	}
+
 	return svc.flag(messageID, reaction, true)
 }
 
@@ -543,13 +600,13 @@ func (svc message) flag(messageID uint64, flag string, remove bool) error {
 		flag = types.MessageFlagPinnedToChannel
 	}
 
-	// @todo validate flags beyond empty string
-
 	err := svc.db.Transaction(func() (err error) {
 		var flagOwnerId = currentUserID
 		var f *types.MessageFlag
+		var ff types.MessageFlagSet
 		var msg *types.Message
 		var ch *types.Channel
+		var isReaction = types.MessageFlag{Flag: flag}.IsReaction()
 
 		if flag == types.MessageFlagPinnedToChannel {
 			// It does not matter how is the owner of the pin,
@@ -584,10 +641,21 @@ func (svc message) flag(messageID uint64, flag string, remove bool) error {
 			return ErrNoPermissions.withStack()
 		}
 
-		if f != nil && f.IsReaction() && !svc.ac.CanReactMessage(svc.ctx, ch) {
+		if isReaction && !svc.ac.CanReactMessage(svc.ctx, ch) {
 			return ErrNoPermissions.withStack()
 		}
 
+		if ff, err = svc.mflag.FindByMessageIDs(msg.ID); err != nil {
+			return
+		}
+
+		if isReaction && !remove && ff.Reactions().FindByReaction(flag) == nil {
+			// Adding new kind of reaction, check if we're within limits
+			if uint(len(ff.Reactions())) >= svc.maxDistinctReactions() {
+				return ErrTooManyReactions.withStack()
+			}
+		}
+
 		if remove {
 			err = svc.mflag.DeleteByID(f.ID)
 			f.DeletedAt = timeNowPtr()
@@ -604,7 +672,17 @@ func (svc message) flag(messageID uint64, flag string, remove bool) error {
 			return
 		}
 
-		_ = svc.sendFlagEvent(f)
+		if isReaction {
+			// Reflect the change on the loaded set of flags so that
+			// we can send aggregated reactions with the event
+			if remove {
+				ff, _ = ff.Filter(func(mf *types.MessageFlag) (bool, error) { return mf.ID != f.ID, nil })
+			} else {
+				ff = append(ff, f)
+			}
+		}
+
+		_ = svc.sendFlagEvent(f, ff.Reactions())
 		return
 	})
 
@@ -819,14 +897,8 @@ func (svc message) countUnreads(ch *types.Channel, m *types.Message, userID uint
 }
 
 // Sends message to event loop
-func (svc message) sendFlagEvent(ff ...*types.MessageFlag) (err error) {
-	for _, f := range ff {
-		if err = svc.event.MessageFlag(f); err != nil {
-			return
-		}
-	}
-
-	return
+func (svc message) sendFlagEvent(f *types.MessageFlag, rr types.MessageReactionSumSet) (err error) {
+	return svc.event.MessageFlag(f, rr)
 }
 
 func (svc message) extractMentions(m *types.Message) (mm types.MentionSet) {
@@ -904,3 +976,26 @@ func (svc message) findChannelByID(channelID uint64) (ch *types.Channel, err err
 }
 
 var _ MessageService = &message{}
+
+func (svc message) maxDistinctReactions() uint {
+	if CurrentSettings.Message.Reactions.MaxDistinct > 0 {
+		return CurrentSettings.Message.Reactions.MaxDistinct
+	}
+
+	return defaultMaxDistinctReactions
+}
+
+// sortByFlagTime orders messages by creation time of the (pin, bookmark) flag, latest first
+func sortByFlagTime(mm types.MessageSet, flag func(*types.Message) *types.MessageFlag) {
+	var at = func(m *types.Message) (t time.Time) {
+		if f := flag(m); f != nil {
+			t = f.CreatedAt
+		}
+
+		return
+	}
+
+	sort.SliceStable(mm, func(i, j int) bool {
+		return at(mm[i]).After(at(mm[j]))
+	})
+}
@@ -0,0 +1,582 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/cortezaproject/corteza-server/messaging/repository"
+	"github.com/cortezaproject/corteza-server/messaging/types"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/logger"
+	"github.com/cortezaproject/corteza-server/pkg/sentry"
+)
+
+type (
+	presence struct {
+		ctx    context.Context
+		logger *zap.Logger
+		ac     presenceAccessController
+
+		channel ChannelService
+		cmember repository.ChannelMemberRepository
+		event   EventService
+
+		pubsub presencePubSub
+		reg    *presenceRegistry
+	}
+
+	presenceAccessController interface {
+		CanSendMessage(context.Context, *types.Channel) bool
+		CanReplyMessage(context.Context, *types.Channel) bool
+	}
+
+	presencePubSub interface {
+		Subscribe(ctx context.Context, channel string, onStart func() error, onMessage func(channel string, message []byte) error) error
+		Publish(ctx context.Context, channel, message string) error
+	}
+
+	PresenceService interface {
+		With(ctx context.Context) PresenceService
+
+		Find(userIDs ...uint64) (types.PresenceSet, error)
+		SetStatus(status types.PresenceStatus, icon, message string, expiresAt *time.Time) (*types.Presence, error)
+		ClearStatus() (*types.Presence, error)
+
+		Connected(userID uint64)
+		Disconnected(userID uint64)
+
+		Typing(channelID, threadID uint64, stopped bool) error
+
+		Watch(ctx context.Context)
+	}
+
+	// presenceRegistry keeps track of user connections and custom statuses on all nodes
+	//
+	// Local connections are reported by websocket sessions,
+	// remote connections are announced by other nodes over pubsub
+	presenceRegistry struct {
+		sync.RWMutex
+
+		node uint64
+
+		// Number of connections per user on this node
+		local map[uint64]uint
+
+		// Number of connections per user on other nodes
+		remote map[uint64]*presenceNode
+
+		// Custom statuses as set by users
+		statuses map[uint64]*types.Presence
+
+		// Last presence, reported to the users
+		reported map[uint64]*types.Presence
+	}
+
+	presenceNode struct {
+		seen        time.Time
+		connections map[uint64]uint
+	}
+
+	// presenceAnnouncement is exchanged between nodes
+	presenceAnnouncement struct {
+		Node uint64 `json:"node,string"`
+
+		// Full announcement holds connections of all users on the node,
+		// partial only of those that changed
+		Full        bool            `json:"full,omitempty"`
+		Connections map[uint64]uint `json:"connections,omitempty"`
+
+		Statuses types.PresenceSet `json:"statuses,omitempty"`
+	}
+)
+
+const (
+	presencePubSubChannel = "messaging.presence"
+
+	// How often do nodes announce their connections
+	presenceHeartbeat = 30 * time.Second
+
+	// Remote node is considered gone when it does not announce itself for this long
+	presenceNodeTimeout = 3 * presenceHeartbeat
+
+	presenceMessageMaxLength = 128
+)
+
+func Presence(ctx context.Context, node uint64, ps presencePubSub) PresenceService {
+	return (&presence{
+		logger: DefaultLogger.Named("presence"),
+
+		ac:      DefaultAccessControl,
+		channel: DefaultChannel,
+
+		pubsub: ps,
+		reg:    newPresenceRegistry(node),
+	}).With(ctx)
+}
+
+func (svc presence) With(ctx context.Context) PresenceService {
+	return &presence{
+		ctx:    ctx,
+		logger: svc.logger,
+
+		ac:      svc.ac,
+		channel: svc.channel,
+		cmember: repository.ChannelMember(ctx, repository.DB(ctx)),
+		event:   Event(ctx),
+
+		pubsub: svc.pubsub,
+		reg:    svc.reg,
+	}
+}
+
+// log() returns zap's logger with requestID from current context and fields.
+func (svc presence) log(ctx context.Context, fields ...zapcore.Field) *zap.Logger {
+	return logger.AddRequestID(ctx, svc.logger).With(fields...)
+}
+
+// Find returns presence of given users
+//
+// When no users are given, presence of all connected users is returned
+func (svc presence) Find(userIDs ...uint64) (types.PresenceSet, error) {
+	var now = time.Now()
+
+	if len(userIDs) == 0 {
+		userIDs = svc.reg.connected()
+	}
+
+	pp := make(types.PresenceSet, len(userIDs))
+	for i, userID := range userIDs {
+		pp[i] = svc.reg.derive(userID, now)
+	}
+
+	return pp, nil
+}
+
+// SetStatus sets custom status for the current user
+func (svc presence) SetStatus(status types.PresenceStatus, icon, message string, expiresAt *time.Time) (*types.Presence, error) {
+	var (
+		userID = auth.GetIdentityFromContext(svc.ctx).Identity()
+		now    = time.Now()
+	)
+
+	if status == "" {
+		status = types.PresenceOnline
+	}
+
+	if !status.IsSettable() {
+		return nil, errors.Errorf("invalid status %q", status)
+	}
+
+	message = strings.TrimSpace(message)
+	if len(message) > presenceMessageMaxLength {
+		return nil, errors.Errorf("status message too long (max: %d)", presenceMessageMaxLength)
+	}
+
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, errors.New("status expiration must be in the future")
+	}
+
+	return svc.setStatus(&types.Presence{
+		UserID:    userID,
+		Status:    status,
+		Icon:      strings.TrimSpace(icon),
+		Message:   message,
+		ExpiresAt: expiresAt,
+		UpdatedAt: now,
+	})
+}
+
+// ClearStatus resets the current user's custom status
+func (svc presence) ClearStatus() (*types.Presence, error) {
+	return svc.setStatus(&types.Presence{
+		UserID:    auth.GetIdentityFromContext(svc.ctx).Identity(),
+		Status:    types.PresenceOnline,
+		UpdatedAt: time.Now(),
+	})
+}
+
+func (svc presence) setStatus(p *types.Presence) (*types.Presence, error) {
+	svc.reg.status(p)
+	svc.publish(presenceAnnouncement{Node: svc.reg.node, Statuses: types.PresenceSet{p}})
+	svc.notify(p.UserID)
+
+	return svc.reg.derive(p.UserID, time.Now()), nil
+}
+
+// Connected registers new local connection (websocket session) of a user
+func (svc presence) Connected(userID uint64) {
+	svc.connection(userID, 1)
+}
+
+// Disconnected unregisters local connection (websocket session) of a user
+func (svc presence) Disconnected(userID uint64) {
+	svc.connection(userID, -1)
+}
+
+func (svc presence) connection(userID uint64, delta int) {
+	var n = svc.reg.connect(userID, delta)
+
+	svc.publish(presenceAnnouncement{Node: svc.reg.node, Connections: map[uint64]uint{userID: n}})
+	svc.notify(userID)
+}
+
+// Typing sends typing indicator to all members of the channel
+//
+// Indicator is published once, to channel subscribers (including the user that is typing)
+func (svc presence) Typing(channelID, threadID uint64, stopped bool) (err error) {
+	var (
+		userID = auth.GetIdentityFromContext(svc.ctx).Identity()
+		ch     *types.Channel
+		mm     types.ChannelMemberSet
+	)
+
+	if ch, err = svc.channel.With(svc.ctx).FindByID(channelID); err != nil {
+		return
+	} else if mm, err = svc.cmember.Find(types.ChannelMemberFilterChannels(ch.ID)); err != nil {
+		return
+	}
+
+	ch.Members = mm.AllMemberIDs()
+	ch.Member = mm.FindByUserID(userID)
+
+	if threadID > 0 && !svc.ac.CanReplyMessage(svc.ctx, ch) {
+		return ErrNoPermissions.withStack()
+	} else if !svc.ac.CanSendMessage(svc.ctx, ch) {
+		return ErrNoPermissions.withStack()
+	}
+
+	return svc.event.Typing(&types.Typing{
+		UserID:    userID,
+		ChannelID: ch.ID,
+		ThreadID:  threadID,
+		Stopped:   stopped,
+	})
+}
+
+// Watch subscribes to presence announcements from other nodes and periodically
+// announces connections on this node
+func (svc presence) Watch(ctx context.Context) {
+	if svc.pubsub != nil {
+		go func() {
+			defer sentry.Recover()
+			for {
+				err := svc.pubsub.Subscribe(ctx, presencePubSubChannel, svc.announce, svc.receive)
+				if ctx.Err() != nil {
+					return
+				}
+
+				svc.log(ctx, zap.Error(err)).Warn("presence subscription interrupted, reconnecting")
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(time.Second):
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer sentry.Recover()
+		var ticker = time.NewTicker(presenceHeartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_ = svc.announce()
+				svc.notify(svc.reg.expire(time.Now())...)
+			}
+		}
+	}()
+
+	svc.log(ctx).Debug("watcher initialized")
+}
+
+// announce publishes all connections and statuses on this node
+func (svc presence) announce() error {
+	svc.publish(svc.reg.snapshot())
+	return nil
+}
+
+// receive handles announcements from other nodes
+func (svc presence) receive(_ string, msg []byte) error {
+	var a = presenceAnnouncement{}
+
+	if err := json.Unmarshal(msg, &a); err != nil || a.Node == 0 {
+		// Ignore anything we do not understand (ie: polling ticks)
+		return nil
+	}
+
+	if a.Node == svc.reg.node {
+		// Our own announcement
+		return nil
+	}
+
+	svc.notify(svc.reg.merge(a, time.Now())...)
+	return nil
+}
+
+func (svc presence) publish(a presenceAnnouncement) {
+	if svc.pubsub == nil {
+		return
+	}
+
+	if enc, err := json.Marshal(a); err != nil {
+		svc.log(svc.ctx, zap.Error(err)).Error("could not encode presence announcement")
+	} else if err = svc.pubsub.Publish(svc.ctx, presencePubSubChannel, string(enc)); err != nil {
+		svc.log(svc.ctx, zap.Error(err)).Warn("could not publish presence announcement")
+	}
+}
+
+// notify sends presence events for all users whose presence changed
+func (svc presence) notify(userIDs ...uint64) {
+	for _, p := range svc.reg.changes(time.Now(), userIDs...) {
+		if err := svc.event.Presence(p); err != nil {
+			svc.log(svc.ctx, zap.Error(err)).Error("could not send presence event")
+		}
+	}
+}
+
+func newPresenceRegistry(node uint64) *presenceRegistry {
+	return &presenceRegistry{
+		node:     node,
+		local:    make(map[uint64]uint),
+		remote:   make(map[uint64]*presenceNode),
+		statuses: make(map[uint64]*types.Presence),
+		reported: make(map[uint64]*types.Presence),
+	}
+}
+
+// connect adds (or removes) local connections and returns the new count
+func (r *presenceRegistry) connect(userID uint64, delta int) uint {
+	r.Lock()
+	defer r.Unlock()
+
+	var n = int(r.local[userID]) + delta
+	if n <= 0 {
+		delete(r.local, userID)
+		return 0
+	}
+
+	r.local[userID] = uint(n)
+	return uint(n)
+}
+
+// status stores custom status if it is newer than the one we know of
+func (r *presenceRegistry) status(p *types.Presence) bool {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.setStatus(p)
+}
+
+func (r *presenceRegistry) setStatus(p *types.Presence) bool {
+	if c, has := r.statuses[p.UserID]; has && !p.UpdatedAt.After(c.UpdatedAt) {
+		return false
+	}
+
+	r.statuses[p.UserID] = p
+	return true
+}
+
+// merge applies announcement from a remote node and returns IDs of affected users
+func (r *presenceRegistry) merge(a presenceAnnouncement, now time.Time) (userIDs []uint64) {
+	r.Lock()
+	defer r.Unlock()
+
+	n, has := r.remote[a.Node]
+	if !has {
+		n = &presenceNode{connections: make(map[uint64]uint)}
+		r.remote[a.Node] = n
+	}
+
+	n.seen = now
+
+	if a.Full {
+		// Full announcement replaces everything we know about the node
+		for userID := range n.connections {
+			userIDs = append(userIDs, userID)
+		}
+
+		n.connections = make(map[uint64]uint)
+	}
+
+	for userID, count := range a.Connections {
+		userIDs = append(userIDs, userID)
+
+		if count == 0 {
+			delete(n.connections, userID)
+		} else {
+			n.connections[userID] = count
+		}
+	}
+
+	for _, p := range a.Statuses {
+		if r.setStatus(p) {
+			userIDs = append(userIDs, p.UserID)
+		}
+	}
+
+	return
+}
+
+// expire removes remote nodes that did not announce themselves in time
+// and returns IDs of affected users (including ones with expired custom statuses)
+//
+// Expired custom statuses are removed as well as cleared ones,
+// after all nodes had enough time to hear about them
+func (r *presenceRegistry) expire(now time.Time) (userIDs []uint64) {
+	r.Lock()
+	defer r.Unlock()
+
+	for node, n := range r.remote {
+		if now.Sub(n.seen) < presenceNodeTimeout {
+			continue
+		}
+
+		for userID := range n.connections {
+			userIDs = append(userIDs, userID)
+		}
+
+		delete(r.remote, node)
+	}
+
+	for userID, p := range r.statuses {
+		switch {
+		case p.IsExpired(now):
+			userIDs = append(userIDs, userID)
+			delete(r.statuses, userID)
+		case p.IsCleared() && now.Sub(p.UpdatedAt) >= presenceNodeTimeout:
+			delete(r.statuses, userID)
+		}
+	}
+
+	return
+}
+
+// snapshot returns full announcement of this node
+func (r *presenceRegistry) snapshot() presenceAnnouncement {
+	r.RLock()
+	defer r.RUnlock()
+
+	var a = presenceAnnouncement{
+		Node:        r.node,
+		Full:        true,
+		Connections: make(map[uint64]uint, len(r.local)),
+		Statuses:    make(types.PresenceSet, 0, len(r.statuses)),
+	}
+
+	for userID, count := range r.local {
+		a.Connections[userID] = count
+	}
+
+	for _, p := range r.statuses {
+		a.Statuses = append(a.Statuses, p)
+	}
+
+	return a
+}
+
+// connected returns IDs of all users with at least one connection on any node
+func (r *presenceRegistry) connected() []uint64 {
+	r.RLock()
+	defer r.RUnlock()
+
+	var (
+		chk = map[uint64]bool{}
+		out = make([]uint64, 0)
+	)
+
+	add := func(cc map[uint64]uint) {
+		for userID := range cc {
+			if !chk[userID] {
+				chk[userID] = true
+				out = append(out, userID)
+			}
+		}
+	}
+
+	add(r.local)
+	for _, n := range r.remote {
+		add(n.connections)
+	}
+
+	return out
+}
+
+// derive calculates user's presence from connections and custom status
+func (r *presenceRegistry) derive(userID uint64, now time.Time) *types.Presence {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.presence(userID, now)
+}
+
+func (r *presenceRegistry) presence(userID uint64, now time.Time) *types.Presence {
+	var (
+		p = &types.Presence{UserID: userID, Status: types.PresenceOffline}
+
+		connections = r.local[userID]
+	)
+
+	for _, n := range r.remote {
+		connections += n.connections[userID]
+	}
+
+	if s, has := r.statuses[userID]; has && !s.IsExpired(now) {
+		p.Icon = s.Icon
+		p.Message = s.Message
+		p.ExpiresAt = s.ExpiresAt
+		p.UpdatedAt = s.UpdatedAt
+
+		if connections > 0 {
+			p.Status = s.Status
+		}
+	} else if connections > 0 {
+		p.Status = types.PresenceOnline
+	}
+
+	return p
+}
+
+// changes returns presences of given users that differ from the last reported ones
+func (r *presenceRegistry) changes(now time.Time, userIDs ...uint64) (pp types.PresenceSet) {
+	r.Lock()
+	defer r.Unlock()
+
+	pp = types.PresenceSet{}
+
+	for _, userID := range userIDs {
+		p := r.presence(userID, now)
+
+		if c, has := r.reported[userID]; has && p.Equal(c) {
+			continue
+		} else if !has && p.Status == types.PresenceOffline && p.Message == "" {
+			// Never reported, still offline
+			continue
+		}
+
+		if p.UpdatedAt.IsZero() {
+			p.UpdatedAt = now
+		}
+
+		if p.Status == types.PresenceOffline && p.Message == "" {
+			delete(r.reported, userID)
+		} else {
+			r.reported[userID] = p
+		}
+
+		pp = append(pp, p)
+	}
+
+	return
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortezaproject/corteza-server/messaging/types"
+)
+
+func TestPresenceRegistry(t *testing.T) {
+	var (
+		req = require.New(t)
+		now = time.Now()
+		reg = newPresenceRegistry(1)
+
+		status = func(userID uint64) types.PresenceStatus {
+			return reg.derive(userID, now).Status
+		}
+	)
+
+	req.Equal(types.PresenceOffline, status(10))
+
+	// Local connections
+	req.Equal(uint(1), reg.connect(10, 1))
+	req.Equal(uint(2), reg.connect(10, 1))
+	req.Equal(types.PresenceOnline, status(10))
+	req.Equal(uint(1), reg.connect(10, -1))
+	req.Equal(uint(0), reg.connect(10, -1))
+	req.Equal(uint(0), reg.connect(10, -1))
+	req.Equal(types.PresenceOffline, status(10))
+
+	// Remote connections
+	req.ElementsMatch([]uint64{10, 20}, reg.merge(presenceAnnouncement{Node: 2, Connections: map[uint64]uint{10: 1, 20: 1}}, now))
+	req.Equal(types.PresenceOnline, status(10))
+	req.ElementsMatch([]uint64{10, 20}, reg.connected())
+
+	// Full announcement replaces all connections of a node
+	req.ElementsMatch([]uint64{10, 20, 20}, reg.merge(presenceAnnouncement{Node: 2, Full: true, Connections: map[uint64]uint{20: 1}}, now))
+	req.Equal(types.PresenceOffline, status(10))
+	req.Equal(types.PresenceOnline, status(20))
+
+	// Custom status is used only while connected
+	reg.status(&types.Presence{UserID: 20, Status: types.PresenceAway, Message: "lunch", UpdatedAt: now})
+	req.Equal(types.PresenceAway, status(20))
+	reg.status(&types.Presence{UserID: 10, Status: types.PresenceAway, Message: "lunch", UpdatedAt: now})
+	req.Equal(types.PresenceOffline, status(10))
+	req.Equal("lunch", reg.derive(10, now).Message)
+
+	// Older status does not override newer
+	reg.status(&types.Presence{UserID: 20, Status: types.PresenceDoNotDisturb, UpdatedAt: now.Add(-time.Minute)})
+	req.Equal(types.PresenceAway, status(20))
+
+	// Expired status
+	var expires = now.Add(time.Minute)
+	reg.status(&types.Presence{UserID: 20, Status: types.PresenceDoNotDisturb, ExpiresAt: &expires, UpdatedAt: now.Add(time.Second)})
+	req.Equal(types.PresenceDoNotDisturb, status(20))
+	req.Equal(types.PresenceOnline, reg.derive(20, expires).Status)
+
+	// Stale remote node and expired status are removed
+	req.ElementsMatch([]uint64{20, 20}, reg.expire(now.Add(presenceNodeTimeout+time.Minute)))
+	req.Equal(types.PresenceOffline, status(20))
+	req.NotContains(reg.statuses, uint64(20))
+	req.Contains(reg.statuses, uint64(10))
+
+	// Cleared status is removed after all nodes could hear about it
+	reg.status(&types.Presence{UserID: 10, Status: types.PresenceOnline, UpdatedAt: now.Add(time.Second)})
+	req.Empty(reg.expire(now.Add(time.Minute)))
+	req.Contains(reg.statuses, uint64(10))
+	req.Empty(reg.expire(now.Add(presenceNodeTimeout + time.Minute)))
+	req.NotContains(reg.statuses, uint64(10))
+}
+
+func TestPresenceRegistryChanges(t *testing.T) {
+	var (
+		req = require.New(t)
+		now = time.Now()
+		reg = newPresenceRegistry(1)
+	)
+
+	// Offline users without status are not reported
+	req.Empty(reg.changes(now, 10))
+
+	reg.connect(10, 1)
+	req.Len(reg.changes(now, 10), 1)
+
+	// Nothing changed
+	req.Empty(reg.changes(now, 10))
+
+	reg.connect(10, 1)
+	req.Empty(reg.changes(now, 10))
+
+	reg.status(&types.Presence{UserID: 10, Status: types.PresenceAway, UpdatedAt: now})
+	pp := reg.changes(now, 10)
+	req.Len(pp, 1)
+	req.Equal(types.PresenceAway, pp[0].Status)
+
+	reg.connect(10, -2)
+	pp = reg.changes(now, 10)
+	req.Len(pp, 1)
+	req.Equal(types.PresenceOffline, pp[0].Status)
+}
+
+func TestPresenceSnapshot(t *testing.T) {
+	var (
+		req = require.New(t)
+		now = time.Now()
+		reg = newPresenceRegistry(1)
+	)
+
+	reg.connect(10, 1)
+	reg.status(&types.Presence{UserID: 10, Status: types.PresenceAway, UpdatedAt: now})
+
+	a := reg.snapshot()
+	req.Equal(uint64(1), a.Node)
+	req.True(a.Full)
+	req.Equal(map[uint64]uint{10: 1}, a.Connections)
+	req.Len(a.Statuses, 1)
+
+	// Merge snapshot into registry of another node
+	other := newPresenceRegistry(2)
+	other.merge(a, now)
+	req.Equal(types.PresenceAway, other.derive(10, now).Status)
+}
@@ -0,0 +1,426 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/cortezaproject/corteza-server/messaging/repository"
+	"github.com/cortezaproject/corteza-server/messaging/types"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/logger"
+	"github.com/cortezaproject/corteza-server/pkg/sentry"
+)
+
+type (
+	scheduledMessage struct {
+		db     db
+		ctx    context.Context
+		logger *zap.Logger
+		ac     scheduledMessageAccessController
+
+		channel ChannelService
+		users   scheduledMessageUserFinder
+
+		cmember   repository.ChannelMemberRepository
+		scheduled repository.ScheduledMessageRepository
+	}
+
+	scheduledMessageAccessController interface {
+		CanReadChannel(context.Context, *types.Channel) bool
+		CanReplyMessage(context.Context, *types.Channel) bool
+		CanSendMessage(context.Context, *types.Channel) bool
+		CanUpdateMessages(context.Context, *types.Channel) bool
+	}
+
+	scheduledMessageUserFinder interface {
+		MakeJWT(context.Context, uint64) (string, error)
+	}
+
+	ScheduledMessageService interface {
+		With(ctx context.Context) ScheduledMessageService
+
+		FindByID(scheduledMessageID uint64) (*types.ScheduledMessage, error)
+		Find(filter types.ScheduledMessageFilter) (types.ScheduledMessageSet, error)
+
+		Create(*types.ScheduledMessage) (*types.ScheduledMessage, error)
+		Update(*types.ScheduledMessage) (*types.ScheduledMessage, error)
+		Cancel(scheduledMessageID uint64) error
+
+		Deliver() error
+		Watch(ctx context.Context)
+	}
+)
+
+const (
+	// How often do we check for scheduled messages that are due
+	scheduledMessageInterval = time.Minute
+
+	// How long to wait for system service when resolving user's roles
+	scheduledMessageSystemTimeout = 5 * time.Second
+)
+
+func ScheduledMessage(ctx context.Context) ScheduledMessageService {
+	return (&scheduledMessage{
+		logger: DefaultLogger.Named("scheduledMessage"),
+
+		ac:      DefaultAccessControl,
+		channel: DefaultChannel,
+		users:   DefaultSystemUser,
+	}).With(ctx)
+}
+
+func (svc scheduledMessage) With(ctx context.Context) ScheduledMessageService {
+	db := repository.DB(ctx)
+	return &scheduledMessage{
+		db:     db,
+		ctx:    ctx,
+		logger: svc.logger,
+
+		ac:      svc.ac,
+		channel: svc.channel,
+		users:   svc.users,
+
+		cmember:   repository.ChannelMember(ctx, db),
+		scheduled: repository.ScheduledMessage(ctx, db),
+	}
+}
+
+// log() returns zap's logger with requestID from current context and fields.
+func (svc scheduledMessage) log(ctx context.Context, fields ...zapcore.Field) *zap.Logger {
+	return logger.AddRequestID(ctx, svc.logger).With(fields...)
+}
+
+func (svc scheduledMessage) FindByID(ID uint64) (sm *types.ScheduledMessage, err error) {
+	if ID == 0 {
+		return nil, ErrInvalidID.withStack()
+	}
+
+	if sm, err = svc.scheduled.FindByID(ID); err != nil {
+		return nil, err
+	}
+
+	if _, err = svc.manageable(sm); err != nil {
+		return nil, err
+	}
+
+	return sm, nil
+}
+
+// Find returns scheduled messages
+//
+// Without channel filter (or without permissions to manage messages on the filtered channel)
+// only current user's scheduled messages are returned
+func (svc scheduledMessage) Find(filter types.ScheduledMessageFilter) (types.ScheduledMessageSet, error) {
+	var currentUserID = auth.GetIdentityFromContext(svc.ctx).Identity()
+
+	if filter.ChannelID > 0 {
+		if ch, err := svc.findChannelByID(filter.ChannelID); err != nil {
+			return nil, err
+		} else if !svc.ac.CanReadChannel(svc.ctx, ch) {
+			return nil, ErrNoPermissions.withStack()
+		} else if !svc.ac.CanUpdateMessages(svc.ctx, ch) {
+			filter.UserID = currentUserID
+		}
+	} else {
+		filter.UserID = currentUserID
+	}
+
+	return svc.scheduled.Find(filter)
+}
+
+func (svc scheduledMessage) Create(in *types.ScheduledMessage) (sm *types.ScheduledMessage, err error) {
+	if in == nil {
+		in = &types.ScheduledMessage{}
+	}
+
+	return sm, svc.db.Transaction(func() (err error) {
+		var ch *types.Channel
+
+		in.UserID = auth.GetIdentityFromContext(svc.ctx).Identity()
+
+		if err = svc.prepare(in); err != nil {
+			return
+		}
+
+		if ch, err = svc.findChannelByID(in.ChannelID); err != nil {
+			return
+		}
+
+		if err = svc.canSend(ch, in); err != nil {
+			return
+		}
+
+		if sm, err = svc.scheduled.Create(in); err != nil {
+			return
+		}
+
+		svc.log(svc.ctx,
+			zap.Uint64("scheduledMessageID", sm.ID),
+			zap.Uint64("channelID", sm.ChannelID),
+		).Info("message scheduled")
+
+		return nil
+	})
+}
+
+func (svc scheduledMessage) Update(in *types.ScheduledMessage) (sm *types.ScheduledMessage, err error) {
+	if in == nil || in.ID == 0 {
+		return nil, ErrInvalidID.withStack()
+	}
+
+	return sm, svc.db.Transaction(func() (err error) {
+		var ch *types.Channel
+
+		if sm, err = svc.scheduled.FindByID(in.ID); err != nil {
+			return
+		}
+
+		if ch, err = svc.manageable(sm); err != nil {
+			return
+		}
+
+		if in.ChannelID > 0 && in.ChannelID != sm.ChannelID {
+			return errors.New("can not move scheduled message to another channel")
+		}
+
+		sm.ReplyTo = in.ReplyTo
+		sm.Message = in.Message
+		sm.ScheduledAt = in.ScheduledAt
+		sm.Cron = in.Cron
+		sm.Timezone = in.Timezone
+
+		// Changed schedule, clear state from previous delivery
+		sm.LastRunAt = nil
+		sm.LastError = ""
+
+		if err = svc.prepare(sm); err != nil {
+			return
+		}
+
+		if err = svc.canSend(ch, sm); err != nil {
+			return
+		}
+
+		sm, err = svc.scheduled.UpdateSchedule(sm)
+		return
+	})
+}
+
+func (svc scheduledMessage) Cancel(ID uint64) error {
+	if ID == 0 {
+		return ErrInvalidID.withStack()
+	}
+
+	return svc.db.Transaction(func() (err error) {
+		var sm *types.ScheduledMessage
+
+		if sm, err = svc.scheduled.FindByID(ID); err != nil {
+			return
+		}
+
+		if _, err = svc.manageable(sm); err != nil {
+			return
+		}
+
+		return svc.scheduled.DeleteByID(sm.ID)
+	})
+}
+
+// Deliver sends all scheduled messages that are due
+//
+// Messages are created on behalf of the user that scheduled them,
+// with that user's permissions at the time of the delivery
+func (svc scheduledMessage) Deliver() error {
+	var now = time.Now().UTC()
+
+	ss, err := svc.scheduled.FindDue(now)
+	if err != nil {
+		return err
+	}
+
+	for _, sm := range ss {
+		if err = svc.deliver(sm, now); err != nil {
+			svc.log(svc.ctx,
+				zap.Uint64("scheduledMessageID", sm.ID),
+				zap.Error(err),
+			).Warn("could not deliver scheduled message")
+		}
+	}
+
+	return nil
+}
+
+func (svc scheduledMessage) deliver(sm *types.ScheduledMessage, now time.Time) (err error) {
+	var (
+		next *time.Time
+		msg  *types.Message
+
+		claimed bool
+	)
+
+	if sm.IsRecurring() {
+		if next, err = sm.Next(now); err != nil {
+			// Broken schedule, do not try again
+			next = nil
+		}
+	}
+
+	// Move next run before delivery so that no other node picks it up
+	if claimed, err = svc.scheduled.Claim(sm, next); err != nil || !claimed {
+		return
+	}
+
+	ctx, err := svc.identity(sm.UserID)
+	if err == nil {
+		msg, err = Message(ctx).Create(&types.Message{
+			ChannelID: sm.ChannelID,
+			ReplyTo:   sm.ReplyTo,
+			UserID:    sm.UserID,
+			Message:   sm.Message,
+		})
+	}
+
+	sm.LastRunAt = &now
+	if err != nil {
+		sm.LastError = err.Error()
+	} else {
+		sm.LastError = ""
+		sm.LastMessageID = msg.ID
+	}
+
+	if uErr := svc.scheduled.UpdateDelivery(sm); uErr != nil {
+		return uErr
+	}
+
+	return err
+}
+
+// Watch periodically delivers due scheduled messages
+func (svc scheduledMessage) Watch(ctx context.Context) {
+	go func() {
+		defer sentry.Recover()
+		var ticker = time.NewTicker(scheduledMessageInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := svc.With(ctx).Deliver(); err != nil {
+					svc.log(ctx, zap.Error(err)).Error("could not deliver scheduled messages")
+				}
+			}
+		}
+	}()
+
+	svc.log(ctx).Debug("watcher initialized")
+}
+
+// identity prepares context with identity of the user that scheduled the message
+//
+// User's roles are resolved by the system service (through issued JWT);
+// message is not delivered when roles can not be resolved
+func (svc scheduledMessage) identity(userID uint64) (context.Context, error) {
+	if svc.users == nil {
+		return nil, errors.New("could not load role membership: system service not connected")
+	}
+
+	ctx, cancel := context.WithTimeout(auth.SetSuperUserContext(svc.ctx), scheduledMessageSystemTimeout)
+	defer cancel()
+
+	jwt, err := svc.users.MakeJWT(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load role membership")
+	}
+
+	identity, err := auth.DefaultJwtHandler.Decode(jwt)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load role membership")
+	}
+
+	return auth.SetIdentityToContext(svc.ctx, auth.NewIdentity(userID, identity.Roles()...)), nil
+}
+
+// prepare validates schedule and calculates next delivery
+func (svc scheduledMessage) prepare(sm *types.ScheduledMessage) (err error) {
+	var now = time.Now().UTC()
+
+	sm.Message = strings.TrimSpace(sm.Message)
+	sm.Cron = strings.TrimSpace(sm.Cron)
+
+	if len(sm.Message) == 0 {
+		return errors.New("refusing to schedule message without contents")
+	}
+
+	if sm.ChannelID == 0 {
+		return errors.New("channelID missing")
+	}
+
+	if sm.IsRecurring() {
+		sm.ScheduledAt = nil
+	} else if sm.ScheduledAt == nil {
+		return errors.New("scheduled time or cron expression required")
+	} else if !sm.ScheduledAt.After(now) {
+		return errors.New("scheduled time must be in the future")
+	}
+
+	if sm.NextRunAt, err = sm.Next(now); err != nil {
+		return err
+	} else if sm.NextRunAt == nil {
+		return errors.New("cron expression does not produce any future times")
+	}
+
+	return nil
+}
+
+// canSend verifies if current user can (still) send the message to a channel
+func (svc scheduledMessage) canSend(ch *types.Channel, sm *types.ScheduledMessage) error {
+	if sm.ReplyTo > 0 && !svc.ac.CanReplyMessage(svc.ctx, ch) {
+		return ErrNoPermissions.withStack()
+	}
+
+	if !svc.ac.CanSendMessage(svc.ctx, ch) {
+		return ErrNoPermissions.withStack()
+	}
+
+	return nil
+}
+
+// manageable verifies if current user is the author of the scheduled message
+// or has permissions to update messages on the channel
+func (svc scheduledMessage) manageable(sm *types.ScheduledMessage) (ch *types.Channel, err error) {
+	var currentUserID = auth.GetIdentityFromContext(svc.ctx).Identity()
+
+	if ch, err = svc.findChannelByID(sm.ChannelID); err != nil {
+		return nil, err
+	}
+
+	if sm.UserID != currentUserID && !svc.ac.CanUpdateMessages(svc.ctx, ch) {
+		return nil, ErrNoPermissions.withStack()
+	}
+
+	return ch, nil
+}
+
+func (svc scheduledMessage) findChannelByID(channelID uint64) (ch *types.Channel, err error) {
+	var (
+		currentUserID = auth.GetIdentityFromContext(svc.ctx).Identity()
+		mm            types.ChannelMemberSet
+	)
+
+	if ch, err = svc.channel.With(svc.ctx).FindByID(channelID); err != nil {
+		return nil, err
+	} else if mm, err = svc.cmember.Find(types.ChannelMemberFilterChannels(ch.ID)); err != nil {
+		return nil, err
+	} else {
+		ch.Members = mm.AllMemberIDs()
+		ch.Member = mm.FindByUserID(currentUserID)
+	}
+
+	return
+}
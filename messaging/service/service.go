@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/titpetric/factory"
 	"go.uber.org/zap"
 
 	"github.com/cortezaproject/corteza-server/messaging/repository"
@@ -16,6 +17,7 @@ import (
 	"github.com/cortezaproject/corteza-server/pkg/store"
 	"github.com/cortezaproject/corteza-server/pkg/store/minio"
 	"github.com/cortezaproject/corteza-server/pkg/store/plain"
+	systemProto "github.com/cortezaproject/corteza-server/system/proto"
 )
 
 type (
@@ -29,7 +31,9 @@ type (
 	}
 
 	Config struct {
-		Storage options.StorageOpt
+		Storage          options.StorageOpt
+		PubSub           options.PubSubOpt
+		GRPCClientSystem options.GRPCServerOpt
 	}
 )
 
@@ -37,6 +41,9 @@ var (
 	DefaultStore       store.Store
 	DefaultPermissions permissionServicer
 
+	// Issues JWTs (with roles) for users that scheduled messages are delivered for
+	DefaultSystemUser scheduledMessageUserFinder
+
 	DefaultLogger *zap.Logger
 
 	DefaultSettings      settings.Service
@@ -51,6 +58,9 @@ var (
 	DefaultEvent      EventService
 	DefaultCommand    CommandService
 	DefaultWebhook    WebhookService
+
+	DefaultScheduledMessage ScheduledMessageService
+	DefaultPresence         PresenceService
 )
 
 func Initialize(ctx context.Context, log *zap.Logger, c Config) (err error) {
@@ -114,12 +124,25 @@ func Initialize(ctx context.Context, log *zap.Logger, c Config) (err error) {
 		return err
 	}
 
+	if DefaultSystemUser == nil {
+		// Do not override system user client stored under DefaultSystemUser
+		// to allow integration tests to inject their own
+		systemClientConn, err := NewSystemGRPCClient(ctx, c.GRPCClientSystem, DefaultLogger)
+		if err != nil {
+			return err
+		}
+
+		DefaultSystemUser = SystemUser(systemProto.NewUsersClient(systemClientConn))
+	}
+
 	DefaultEvent = Event(ctx)
 	DefaultChannel = Channel(ctx)
 	DefaultAttachment = Attachment(ctx, DefaultStore)
 	DefaultMessage = Message(ctx)
 	DefaultCommand = Command(ctx)
 	DefaultWebhook = Webhook(ctx, client)
+	DefaultScheduledMessage = ScheduledMessage(ctx)
+	DefaultPresence = Presence(ctx, factory.Sonyflake.NextID(), repository.PubSub{}.New(c.PubSub))
 
 	return nil
 }
@@ -136,6 +159,12 @@ func Activate(ctx context.Context) (err error) {
 
 func Watchers(ctx context.Context) {
 	DefaultPermissions.Watch(ctx)
+
+	// Delivering scheduled messages
+	DefaultScheduledMessage.Watch(ctx)
+
+	// Exchanging presence with other nodes
+	DefaultPresence.Watch(ctx)
 }
 
 func timeNowPtr() *time.Time {
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/system/proto"
+)
+
+// gRPC client for
+
+type (
+	systemUser struct {
+		client proto.UsersClient
+	}
+)
+
+func SystemUser(c proto.UsersClient) *systemUser {
+	return &systemUser{
+		client: c,
+	}
+}
+
+func (svc systemUser) MakeJWT(ctx context.Context, ID uint64) (string, error) {
+	ctx = metadata.NewOutgoingContext(ctx, metadata.MD{
+		"jwt": []string{auth.GetJwtFromContext(ctx)},
+	})
+
+	rsp, err := svc.client.MakeJWT(ctx, &proto.MakeJWTUserRequest{UserID: ID}, grpc.WaitForReady(true))
+	if err != nil {
+		return "", err
+	}
+
+	return rsp.JWT, nil
+}
@@ -11,6 +11,9 @@ type (
 		SubType    EventQueueItemSubType `db:"subtype"`
 		Subscriber string                `db:"subscriber"`
 		Payload    json.RawMessage       `db:"payload"`
+
+		// Volatile events (activity, typing) are not replayed to resumed sessions
+		Volatile bool `db:"-"`
 	}
 
 	EventQueueItemSubType string
@@ -1,7 +1,10 @@
 package types
 
 import (
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 type (
@@ -16,11 +19,22 @@ type (
 		// Internal only
 		DeletedAt *time.Time `json:"-" db:"-"`
 	}
+
+	// MessageReactionSum aggregates all reactions with the same identifier on a message
+	MessageReactionSum struct {
+		Reaction string
+		UserIDs  []uint64
+	}
+
+	MessageReactionSumSet []*MessageReactionSum
 )
 
 const (
 	MessageFlagPinnedToChannel   string = "pin"
 	MessageFlagBookmarkedMessage string = "bookmark"
+
+	// Max length of reaction identifier (emoji or shortcode)
+	MessageReactionMaxLength = 64
 )
 
 func (f MessageFlag) IsReaction() bool {
@@ -34,3 +48,36 @@ func (f MessageFlag) IsPin() bool {
 func (f MessageFlag) IsBookmark() bool {
 	return f.Flag == MessageFlagBookmarkedMessage
 }
+
+// IsValidReaction checks if reaction identifier can be used as a message flag
+//
+// Valid reactions are either emojis or shortcodes (ie: "thumbsup", "+1", ":smile:");
+// they should not contain any whitespace or control characters and
+// they can not collide with any of the reserved flags (pin, bookmark)
+func IsValidReaction(r string) bool {
+	if r == "" || r == MessageFlagPinnedToChannel || r == MessageFlagBookmarkedMessage {
+		return false
+	}
+
+	if !utf8.ValidString(r) || utf8.RuneCountInString(r) > MessageReactionMaxLength {
+		return false
+	}
+
+	return strings.IndexFunc(r, func(c rune) bool {
+		return unicode.IsSpace(c) || unicode.IsControl(c) || c == '/'
+	}) == -1
+}
+
+func (r MessageReactionSum) Count() uint {
+	return uint(len(r.UserIDs))
+}
+
+func (r MessageReactionSum) HasUser(userID uint64) bool {
+	for _, ID := range r.UserIDs {
+		if ID == userID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidReaction(t *testing.T) {
+	tests := []struct {
+		name  string
+		r     string
+		valid bool
+	}{
+		{"shortcode", "thumbsup", true},
+		{"shortcode with colons", ":smile:", true},
+		{"plus one", "+1", true},
+		{"emoji", "👍", true},
+		{"empty", "", false},
+		{"pin", MessageFlagPinnedToChannel, false},
+		{"bookmark", MessageFlagBookmarkedMessage, false},
+		{"whitespace", "thumbs up", false},
+		{"newline", "foo\nbar", false},
+		{"slash", "foo/bar", false},
+		{"too long", strings.Repeat("x", MessageReactionMaxLength+1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.valid, IsValidReaction(tt.r))
+		})
+	}
+}
+
+func TestMessageFlagSet_Reactions(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		ff = MessageFlagSet{
+			&MessageFlag{UserID: 1, Flag: "foo"},
+			&MessageFlag{UserID: 1, Flag: MessageFlagPinnedToChannel},
+			&MessageFlag{UserID: 2, Flag: "bar"},
+			&MessageFlag{UserID: 2, Flag: "foo"},
+			&MessageFlag{UserID: 3, Flag: MessageFlagBookmarkedMessage},
+			&MessageFlag{UserID: 3, Flag: "foo", DeletedAt: &time.Time{}},
+		}
+
+		rr = ff.Reactions()
+	)
+
+	req.Len(rr, 2)
+	req.Equal("foo", rr[0].Reaction)
+	req.Equal([]uint64{1, 2}, rr[0].UserIDs)
+	req.Equal(uint(2), rr[0].Count())
+	req.True(rr[0].HasUser(2))
+	req.False(rr[0].HasUser(3))
+
+	req.NotNil(rr.FindByReaction("bar"))
+	req.Equal(uint(1), rr.FindByReaction("bar").Count())
+	req.Nil(rr.FindByReaction(MessageFlagPinnedToChannel))
+}
@@ -0,0 +1,41 @@
+package types
+
+// 	Hello! This file is auto-generated.
+
+type (
+
+	// PresenceSet slice of Presence
+	//
+	// This type is auto-generated.
+	PresenceSet []*Presence
+)
+
+// Walk iterates through every slice item and calls w(Presence) err
+//
+// This function is auto-generated.
+func (set PresenceSet) Walk(w func(*Presence) error) (err error) {
+	for i := range set {
+		if err = w(set[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Filter iterates through every slice item, calls f(Presence) (bool, err) and return filtered slice
+//
+// This function is auto-generated.
+func (set PresenceSet) Filter(f func(*Presence) (bool, error)) (out PresenceSet, err error) {
+	var ok bool
+	out = PresenceSet{}
+	for i := range set {
+		if ok, err = f(set[i]); err != nil {
+			return
+		} else if ok {
+			out = append(out, set[i])
+		}
+	}
+
+	return
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"time"
+)
+
+type (
+	// Presence is user's current (derived) presence with custom status
+	Presence struct {
+		UserID uint64         `json:"userID,string"`
+		Status PresenceStatus `json:"status"`
+
+		// Custom status
+		Icon      string     `json:"icon,omitempty"`
+		Message   string     `json:"message,omitempty"`
+		ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+		UpdatedAt time.Time `json:"updatedAt"`
+	}
+
+	PresenceStatus string
+
+	// Typing indicator for a channel or a thread
+	Typing struct {
+		UserID    uint64
+		ChannelID uint64
+		ThreadID  uint64
+		Stopped   bool
+	}
+)
+
+const (
+	PresenceOnline       PresenceStatus = "online"
+	PresenceAway         PresenceStatus = "away"
+	PresenceDoNotDisturb PresenceStatus = "dnd"
+	PresenceOffline      PresenceStatus = "offline"
+)
+
+// IsSettable returns true if status can be set by the user
+//
+// Offline status is always derived from (lack of) connections
+func (s PresenceStatus) IsSettable() bool {
+	switch s {
+	case PresenceOnline, PresenceAway, PresenceDoNotDisturb:
+		return true
+	}
+
+	return false
+}
+
+// IsExpired returns true when custom status expired
+func (p Presence) IsExpired(now time.Time) bool {
+	return p.ExpiresAt != nil && !p.ExpiresAt.After(now)
+}
+
+// IsCleared returns true when custom status was reset to default
+func (p Presence) IsCleared() bool {
+	return p.Status == PresenceOnline && p.Icon == "" && p.Message == "" && p.ExpiresAt == nil
+}
+
+// Equal compares status and custom status of two presences
+func (p Presence) Equal(c *Presence) bool {
+	return c != nil &&
+		p.UserID == c.UserID &&
+		p.Status == c.Status &&
+		p.Icon == c.Icon &&
+		p.Message == c.Message
+}
@@ -0,0 +1,67 @@
+package types
+
+// 	Hello! This file is auto-generated.
+
+type (
+
+	// ScheduledMessageSet slice of ScheduledMessage
+	//
+	// This type is auto-generated.
+	ScheduledMessageSet []*ScheduledMessage
+)
+
+// Walk iterates through every slice item and calls w(ScheduledMessage) err
+//
+// This function is auto-generated.
+func (set ScheduledMessageSet) Walk(w func(*ScheduledMessage) error) (err error) {
+	for i := range set {
+		if err = w(set[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Filter iterates through every slice item, calls f(ScheduledMessage) (bool, err) and return filtered slice
+//
+// This function is auto-generated.
+func (set ScheduledMessageSet) Filter(f func(*ScheduledMessage) (bool, error)) (out ScheduledMessageSet, err error) {
+	var ok bool
+	out = ScheduledMessageSet{}
+	for i := range set {
+		if ok, err = f(set[i]); err != nil {
+			return
+		} else if ok {
+			out = append(out, set[i])
+		}
+	}
+
+	return
+}
+
+// FindByID finds items from slice by its ID property
+//
+// This function is auto-generated.
+func (set ScheduledMessageSet) FindByID(ID uint64) *ScheduledMessage {
+	for i := range set {
+		if set[i].ID == ID {
+			return set[i]
+		}
+	}
+
+	return nil
+}
+
+// IDs returns a slice of uint64s from all items in the set
+//
+// This function is auto-generated.
+func (set ScheduledMessageSet) IDs() (IDs []uint64) {
+	IDs = make([]uint64, len(set))
+
+	for i := range set {
+		IDs[i] = set[i].ID
+	}
+
+	return
+}
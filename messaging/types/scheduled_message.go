@@ -0,0 +1,95 @@
+package types
+
+import (
+	"time"
+
+	"github.com/gorhill/cronexpr"
+	"github.com/pkg/errors"
+)
+
+type (
+	// ScheduledMessage holds a message that is delivered to a channel (or thread)
+	// at a specific time (one-off) or repeatedly by a cron expression (recurring)
+	ScheduledMessage struct {
+		ID        uint64 `json:"id" db:"id"`
+		UserID    uint64 `json:"userId" db:"rel_user"`
+		ChannelID uint64 `json:"channelId" db:"rel_channel"`
+		ReplyTo   uint64 `json:"replyTo" db:"reply_to"`
+		Message   string `json:"message" db:"message"`
+
+		// One-off delivery time
+		ScheduledAt *time.Time `json:"scheduledAt,omitempty" db:"scheduled_at"`
+
+		// Recurring delivery (crontab expression) & timezone it is evaluated in
+		Cron     string `json:"cron,omitempty" db:"cron"`
+		Timezone string `json:"timezone,omitempty" db:"timezone"`
+
+		NextRunAt     *time.Time `json:"nextRunAt,omitempty" db:"next_run_at"`
+		LastRunAt     *time.Time `json:"lastRunAt,omitempty" db:"last_run_at"`
+		LastMessageID uint64     `json:"lastMessageId,omitempty" db:"last_message_id"`
+		LastError     string     `json:"lastError,omitempty" db:"last_error"`
+
+		CreatedAt time.Time  `json:"createdAt,omitempty" db:"created_at"`
+		UpdatedAt *time.Time `json:"updatedAt,omitempty" db:"updated_at"`
+		DeletedAt *time.Time `json:"deletedAt,omitempty" db:"deleted_at"`
+	}
+
+	ScheduledMessageFilter struct {
+		ChannelID uint64
+		UserID    uint64
+
+		// Include delivered one-off messages
+		IncDelivered bool
+	}
+)
+
+// IsRecurring returns true when message is delivered by a cron expression
+func (m ScheduledMessage) IsRecurring() bool {
+	return m.Cron != ""
+}
+
+// Location returns time location the message schedule is evaluated in
+func (m ScheduledMessage) Location() (*time.Location, error) {
+	if m.Timezone == "" {
+		return time.UTC, nil
+	}
+
+	loc, err := time.LoadLocation(m.Timezone)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid timezone %q", m.Timezone)
+	}
+
+	return loc, nil
+}
+
+// Next calculates the next delivery time after the given time
+//
+// Returns nil for one-off messages that were already delivered
+// or when cron expression does not produce any future times
+func (m ScheduledMessage) Next(after time.Time) (*time.Time, error) {
+	if !m.IsRecurring() {
+		if m.ScheduledAt == nil || m.LastRunAt != nil {
+			return nil, nil
+		}
+
+		return m.ScheduledAt, nil
+	}
+
+	exp, err := cronexpr.Parse(m.Cron)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid cron expression %q", m.Cron)
+	}
+
+	loc, err := m.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	next := exp.Next(after.In(loc))
+	if next.IsZero() {
+		return nil, nil
+	}
+
+	next = next.UTC()
+	return &next, nil
+}
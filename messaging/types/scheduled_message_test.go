@@ -0,0 +1,68 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduledMessage_Next(t *testing.T) {
+	var (
+		parse = func(s string) *time.Time {
+			t, _ := time.Parse(time.RFC3339, s)
+			return &t
+		}
+
+		after = *parse("2020-05-10T10:10:10Z")
+	)
+
+	tests := []struct {
+		name string
+		m    ScheduledMessage
+		want *time.Time
+		err  bool
+	}{
+		{
+			name: "one-off",
+			m:    ScheduledMessage{ScheduledAt: parse("2020-05-11T08:00:00Z")},
+			want: parse("2020-05-11T08:00:00Z"),
+		},
+		{
+			name: "one-off, delivered",
+			m:    ScheduledMessage{ScheduledAt: parse("2020-05-11T08:00:00Z"), LastRunAt: parse("2020-05-11T08:00:00Z")},
+			want: nil,
+		},
+		{
+			name: "every hour",
+			m:    ScheduledMessage{Cron: "0 * * * *"},
+			want: parse("2020-05-10T11:00:00Z"),
+		},
+		{
+			name: "every day at 9 in Ljubljana",
+			m:    ScheduledMessage{Cron: "0 9 * * *", Timezone: "Europe/Ljubljana"},
+			want: parse("2020-05-11T07:00:00Z"),
+		},
+		{
+			name: "invalid cron",
+			m:    ScheduledMessage{Cron: "foo"},
+			err:  true,
+		},
+		{
+			name: "invalid timezone",
+			m:    ScheduledMessage{Cron: "0 * * * *", Timezone: "Foo/Bar"},
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.Next(after)
+			if (err != nil) != tt.err {
+				t.Fatalf("Next() error = %v, wantErr %v", err, tt.err)
+			}
+
+			if tt.want == nil && got != nil || tt.want != nil && (got == nil || !got.Equal(*tt.want)) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -84,13 +84,68 @@ func (set MessageFlagSet) IsBookmarked(UserID uint64) bool {
 }
 
 func (set MessageFlagSet) IsPinned() bool {
+	return set.Pin() != nil
+}
+
+// Pin returns pin flag if message is pinned to the channel
+func (set MessageFlagSet) Pin() *MessageFlag {
 	for i := range set {
 		if set[i].IsPin() {
-			return true
+			return set[i]
 		}
 	}
 
-	return false
+	return nil
+}
+
+// Bookmark returns user's bookmark flag if message is bookmarked
+func (set MessageFlagSet) Bookmark(userID uint64) *MessageFlag {
+	for i := range set {
+		if set[i].UserID == userID && set[i].IsBookmark() {
+			return set[i]
+		}
+	}
+
+	return nil
+}
+
+// Reactions aggregates reaction flags per reaction identifier
+//
+// Order of reactions is preserved: first reaction of the kind defines its position
+func (set MessageFlagSet) Reactions() (rr MessageReactionSumSet) {
+	var (
+		rIndex = map[string]int{}
+		i      int
+		has    bool
+	)
+
+	rr = MessageReactionSumSet{}
+
+	for _, f := range set {
+		if !f.IsReaction() || f.DeletedAt != nil {
+			continue
+		}
+
+		if i, has = rIndex[f.Flag]; !has {
+			i, rIndex[f.Flag] = len(rr), len(rr)
+			rr = append(rr, &MessageReactionSum{Reaction: f.Flag, UserIDs: []uint64{}})
+		}
+
+		rr[i].UserIDs = append(rr[i].UserIDs, f.UserID)
+	}
+
+	return
+}
+
+// FindByReaction returns aggregated reaction by identifier
+func (set MessageReactionSumSet) FindByReaction(r string) *MessageReactionSum {
+	for i := range set {
+		if set[i].Reaction == r {
+			return set[i]
+		}
+	}
+
+	return nil
 }
 
 func (set MentionSet) FindByUserID(ID uint64) (out MentionSet) {
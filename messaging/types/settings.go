@@ -43,6 +43,12 @@ type (
 					Camera  struct{ Enabled bool }
 				}
 			}
+
+			Reactions struct {
+				// Max number of distinct reactions on a single message
+				// (0 for default)
+				MaxDistinct uint `kv:"max-distinct"`
+			}
 		}
 	}
 )
@@ -9,5 +9,8 @@ type (
 		Timeout     time.Duration
 		PingTimeout time.Duration
 		PingPeriod  time.Duration
+
+		ResumeTimeout time.Duration
+		ResumeBacklog int
 	}
 )
@@ -103,7 +103,7 @@ func (eq *eventQueue) feedSessions(ctx context.Context, qp repository.EventsRepo
 			} else {
 				store.Walk(func(s *Session) {
 					if s.user.Identity() == userID {
-						_ = s.sendBytes(item.Payload)
+						s.deliver(item.ID, item.Payload, item.Volatile)
 					}
 				})
 			}
@@ -111,13 +111,13 @@ func (eq *eventQueue) feedSessions(ctx context.Context, qp repository.EventsRepo
 		} else if item.Subscriber == "" {
 			// Distribute payload to all connected sessions
 			store.Walk(func(s *Session) {
-				_ = s.sendBytes(item.Payload)
+				s.deliver(item.ID, item.Payload, item.Volatile)
 			})
 		} else {
 			// Distribute payload to specific subscribers
 			store.Walk(func(s *Session) {
 				if s.subs.Get(item.Subscriber) != nil {
-					_ = s.sendBytes(item.Payload)
+					s.deliver(item.ID, item.Payload, item.Volatile)
 				}
 			})
 		}
@@ -3,6 +3,7 @@ package websocket
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi"
 	"go.uber.org/zap"
@@ -41,6 +42,22 @@ func Watch(ctx context.Context) {
 		}
 	}()
 	eq.store(ctx, events)
+
+	// Cleanup sessions that were not resumed in time
+	go func() {
+		defer sentry.Recover()
+
+		t := time.NewTicker(resumeCleanupInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-t.C:
+				store.Expire(now)
+			}
+		}
+	}()
 }
 
 func (ws Websocket) ApiServerRoutes(r chi.Router) {
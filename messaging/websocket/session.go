@@ -42,9 +42,17 @@ type (
 
 		user auth.Identifiable
 
+		// Token for resuming the session after reconnect
+		token string
+
+		// Set when connection is closed, session is kept for a while
+		// (buffering events) so that client can resume it
+		suspendedAt *time.Time
+
 		svc struct {
-			ch  service.ChannelService
-			msg service.MessageService
+			ch       service.ChannelService
+			msg      service.MessageService
+			presence service.PresenceService
 		}
 	}
 )
@@ -63,6 +71,7 @@ func (Session) New(ctx context.Context, config *Config, conn *websocket.Conn) *S
 
 	s.svc.ch = service.DefaultChannel
 	s.svc.msg = service.DefaultMessage
+	s.svc.presence = service.DefaultPresence
 
 	s.logger = logger.AddRequestID(s.ctx, logger.Default().Named("websocket"))
 
@@ -82,6 +91,9 @@ func (sess *Session) connected() (err error) {
 		cc types.ChannelSet
 	)
 
+	// Count this session towards user's presence (on all nodes)
+	sess.svc.presence.With(sess.ctx).Connected(sess.user.Identity())
+
 	// Push user info about all channels he has access to...
 	// @todo filter out all muted/non-joined channels
 	if cc, _, err = sess.svc.ch.With(sess.ctx).Find(types.ChannelFilter{}); err != nil {
@@ -130,6 +142,7 @@ func (sess *Session) connected() (err error) {
 func (sess *Session) disconnected() {
 	// Tell everyone that user has disconnected
 	_ = sess.sendPresence("disconnected")
+	sess.svc.presence.With(sess.ctx).Disconnected(sess.user.Identity())
 
 	// Cancel context
 	sess.ctxCancel()
@@ -149,7 +162,7 @@ func (sess *Session) sendPresence(kind string) error {
 	}
 
 	// Tell everyone that user has disconnected
-	return sess.sendToAll(&outgoing.Activity{
+	return sess.sendVolatileToAll(&outgoing.Activity{
 		UserID:  sess.user.Identity(),
 		Kind:    kind,
 		Present: connections > 0,
@@ -169,7 +182,7 @@ func (sess *Session) Handle() (err error) {
 func (sess *Session) Close() {
 	sess.once.Do(func() {
 		sess.disconnected()
-		store.Delete(sess.id)
+		store.Suspend(sess.id)
 	})
 }
 
@@ -35,6 +35,9 @@ func (s *Session) dispatch(raw []byte) error {
 	case p.ChannelUpdate != nil:
 		return s.channelUpdate(ctx, p.ChannelUpdate)
 
+	// activity
+	case p.Typing != nil:
+		return s.typing(ctx, p.Typing)
 	}
 
 	return nil
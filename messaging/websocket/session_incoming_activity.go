@@ -0,0 +1,16 @@
+package websocket
+
+import (
+	"context"
+
+	"github.com/cortezaproject/corteza-server/pkg/payload"
+	"github.com/cortezaproject/corteza-server/pkg/payload/incoming"
+)
+
+func (s *Session) typing(ctx context.Context, p *incoming.Typing) error {
+	return s.svc.presence.With(ctx).Typing(
+		payload.ParseUInt64(p.ChannelID),
+		payload.ParseUInt64(p.ThreadID),
+		p.Stopped,
+	)
+}
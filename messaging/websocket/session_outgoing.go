@@ -18,14 +18,14 @@ func (s *Session) sendToAllSubscribers(p MessageEncoder, channelID string) error
 	return repository.Events().Push(s.ctx, &types.EventQueueItem{Payload: pb, Subscriber: channelID})
 }
 
-// Sends message to all connected clients
-func (s *Session) sendToAll(p MessageEncoder) error {
+// Sends volatile message (not replayed on resume) to all connected clients
+func (s *Session) sendVolatileToAll(p MessageEncoder) error {
 	pb, err := p.EncodeMessage()
 	if err != nil {
 		return err
 	}
 
-	return repository.Events().Push(s.ctx, &types.EventQueueItem{Payload: pb})
+	return repository.Events().Push(s.ctx, &types.EventQueueItem{Payload: pb, Volatile: true})
 }
 
 // Sends message only on this session, no need to enqueue item
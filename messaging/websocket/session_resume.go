@@ -0,0 +1,289 @@
+package websocket
+
+import (
+	"bytes"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/titpetric/factory"
+
+	"github.com/cortezaproject/corteza-server/pkg/payload/outgoing"
+	"github.com/cortezaproject/corteza-server/pkg/rand"
+)
+
+type (
+	// eventBuffer keeps recent events for each user
+	//
+	// Every event sent to user's sessions gets a sequence number; when session
+	// is resumed, we replay all events after the last sequence client has seen
+	eventBuffer struct {
+		sync.Mutex
+
+		backlog int
+		users   map[uint64]*userEvents
+	}
+
+	userEvents struct {
+		// Last assigned sequence
+		seq uint64
+
+		// ID of the last buffered event queue item;
+		// same item is delivered to all user's sessions but buffered only once
+		lastItemID uint64
+
+		// Buffered events, oldest first
+		events []bufferedEvent
+	}
+
+	bufferedEvent struct {
+		seq     uint64
+		payload []byte
+	}
+)
+
+const (
+	defaultResumeBacklog = 256
+	resumeTokenLength    = 32
+
+	// How often are expired sessions removed
+	resumeCleanupInterval = 30 * time.Second
+)
+
+var buffer *eventBuffer
+
+func init() {
+	buffer = newEventBuffer(defaultResumeBacklog)
+}
+
+func newEventBuffer(backlog int) *eventBuffer {
+	return &eventBuffer{
+		backlog: backlog,
+		users:   make(map[uint64]*userEvents),
+	}
+}
+
+func (b *eventBuffer) setBacklog(backlog int) {
+	b.Lock()
+	defer b.Unlock()
+
+	if backlog > 0 {
+		b.backlog = backlog
+	}
+}
+
+// add buffers event for the user and returns its sequence
+//
+// Repeated calls with the same item (ie: for each of user's sessions) return the same sequence
+func (b *eventBuffer) add(userID, itemID uint64, payload []byte) uint64 {
+	b.Lock()
+	defer b.Unlock()
+
+	u, has := b.users[userID]
+	if !has {
+		u = &userEvents{}
+		b.users[userID] = u
+	}
+
+	if itemID > 0 && u.lastItemID == itemID {
+		return u.seq
+	}
+
+	u.seq++
+	u.lastItemID = itemID
+	u.events = append(u.events, bufferedEvent{seq: u.seq, payload: payload})
+
+	if len(u.events) > b.backlog {
+		u.events = u.events[len(u.events)-b.backlog:]
+	}
+
+	return u.seq
+}
+
+// since returns all events after the given sequence
+//
+// Returns false when some of the events are no longer buffered
+// and the client needs to do a full resync
+func (b *eventBuffer) since(userID, seq uint64) ([]bufferedEvent, bool) {
+	b.Lock()
+	defer b.Unlock()
+
+	u, has := b.users[userID]
+	if !has {
+		return nil, seq == 0
+	}
+
+	switch {
+	case seq > u.seq:
+		// Client claims it has seen more than we've sent
+		return nil, false
+	case seq == u.seq:
+		return nil, true
+	case len(u.events) == 0 || u.events[0].seq > seq+1:
+		// Gap is too large
+		return nil, false
+	}
+
+	var out = make([]bufferedEvent, 0, u.seq-seq)
+	for _, e := range u.events {
+		if e.seq > seq {
+			out = append(out, e)
+		}
+	}
+
+	return out, true
+}
+
+// last returns sequence of the last event sent to the user
+func (b *eventBuffer) last(userID uint64) uint64 {
+	b.Lock()
+	defer b.Unlock()
+
+	if u, has := b.users[userID]; has {
+		return u.seq
+	}
+
+	return 0
+}
+
+// retain removes events of all users that are not given
+func (b *eventBuffer) retain(userIDs map[uint64]bool) {
+	b.Lock()
+	defer b.Unlock()
+
+	for userID := range b.users {
+		if !userIDs[userID] {
+			delete(b.users, userID)
+		}
+	}
+}
+
+// withSequence adds sequence to the encoded payload
+func withSequence(payload []byte, seq uint64) []byte {
+	if seq == 0 || len(payload) < 2 || payload[0] != '{' {
+		return payload
+	}
+
+	var out = bytes.NewBufferString(`{"seq":`)
+	out.WriteString(strconv.FormatUint(seq, 10))
+
+	if !bytes.Equal(payload, []byte("{}")) {
+		out.WriteByte(',')
+	}
+
+	out.Write(payload[1:])
+	return out.Bytes()
+}
+
+// Attach registers new session
+//
+// When resume token of a suspended session is given, session takes over its subscriptions
+// and all events after the given sequence are replayed. Client is asked to do a full resync
+// when session can not be resumed.
+//
+// Store is locked for the whole time so that no events are delivered in between.
+func (s *Store) Attach(sess *Session, token string, seq uint64) *Session {
+	var (
+		userID = sess.user.Identity()
+
+		replay  []bufferedEvent
+		resumed bool
+	)
+
+	s.Lock()
+	defer s.Unlock()
+
+	if token != "" {
+		for id, old := range s.Sessions {
+			if old.token != token || old.suspendedAt == nil || old.user.Identity() != userID {
+				continue
+			}
+
+			// Take over subscriptions of the suspended session
+			for channelID := range old.subs.Subscriptions {
+				sess.subs.Add(channelID)
+			}
+
+			delete(s.Sessions, id)
+
+			replay, resumed = buffer.since(userID, seq)
+			if resumed && len(replay) >= cap(sess.send) {
+				// Would not fit into send buffer
+				replay, resumed = nil, false
+			}
+
+			if resumed {
+				sess.token = token
+			}
+
+			break
+		}
+	}
+
+	if sess.token == "" {
+		sess.token = string(rand.Bytes(resumeTokenLength))
+	}
+
+	_ = sess.sendReply(&outgoing.Session{
+		Token:   sess.token,
+		Seq:     buffer.last(userID),
+		Resumed: resumed,
+		Resync:  token != "" && !resumed,
+	})
+
+	for _, e := range replay {
+		_ = sess.sendBytes(withSequence(e.payload, e.seq))
+	}
+
+	sess.id = factory.Sonyflake.NextID()
+	s.Sessions[sess.id] = sess
+	return sess
+}
+
+// Suspend keeps closed session (and its subscriptions) around so that it can be resumed
+func (s *Store) Suspend(id uint64) {
+	s.Lock()
+	defer s.Unlock()
+
+	if sess, has := s.Sessions[id]; has {
+		now := time.Now()
+		sess.suspendedAt = &now
+	}
+}
+
+// Expire removes sessions that were suspended for too long and
+// events of users without any sessions
+func (s *Store) Expire(now time.Time) {
+	var active = map[uint64]bool{}
+
+	s.Lock()
+	for id, sess := range s.Sessions {
+		if sess.suspendedAt != nil && now.Sub(*sess.suspendedAt) > sess.config.ResumeTimeout {
+			delete(s.Sessions, id)
+			continue
+		}
+
+		active[sess.user.Identity()] = true
+	}
+	s.Unlock()
+
+	buffer.retain(active)
+}
+
+// deliver buffers and sends event queue item to the session
+//
+// Must be called from within store.Walk
+func (sess *Session) deliver(itemID uint64, payload []byte, volatile bool) {
+	var seq uint64
+
+	if !volatile {
+		seq = buffer.add(sess.user.Identity(), itemID, payload)
+	}
+
+	if sess.suspendedAt != nil {
+		// Buffered for when (if) session is resumed
+		return
+	}
+
+	_ = sess.sendBytes(withSequence(payload, seq))
+}
@@ -0,0 +1,235 @@
+package websocket
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/payload/outgoing"
+)
+
+func makeResumeTestSession(userID uint64, sendBuffer int) *Session {
+	return &Session{
+		user:   auth.NewIdentity(userID),
+		subs:   NewSubscriptions(),
+		send:   make(chan []byte, sendBuffer),
+		config: &Config{ResumeTimeout: time.Minute},
+	}
+}
+
+// Returns all payloads sent to the session so far
+func sentToResumeTestSession(sess *Session) (out []string) {
+	for {
+		select {
+		case p := <-sess.send:
+			out = append(out, string(p))
+		default:
+			return
+		}
+	}
+}
+
+func decodeResumeTestReply(t *testing.T, p string) *outgoing.Session {
+	var reply = outgoing.Payload{}
+	require.NoError(t, json.Unmarshal([]byte(p), &reply))
+	require.NotNil(t, reply.Session)
+	return reply.Session
+}
+
+func TestEventBuffer_sequence(t *testing.T) {
+	var (
+		req = require.New(t)
+		b   = newEventBuffer(10)
+	)
+
+	req.Equal(uint64(1), b.add(1, 100, []byte(`{"a":1}`)))
+
+	// Same item delivered to another session of the same user
+	req.Equal(uint64(1), b.add(1, 100, []byte(`{"a":1}`)))
+	req.Equal(uint64(2), b.add(1, 101, []byte(`{"a":2}`)))
+
+	// Items without ID are never merged
+	req.Equal(uint64(3), b.add(1, 0, []byte(`{"a":3}`)))
+	req.Equal(uint64(4), b.add(1, 0, []byte(`{"a":4}`)))
+
+	// Each user has its own sequence
+	req.Equal(uint64(1), b.add(2, 101, []byte(`{"b":1}`)))
+	req.Equal(uint64(4), b.last(1))
+	req.Equal(uint64(1), b.last(2))
+	req.Equal(uint64(0), b.last(3))
+
+	ee, ok := b.since(1, 0)
+	req.True(ok)
+	req.Len(ee, 4)
+
+	ee, ok = b.since(1, 2)
+	req.True(ok)
+	req.Len(ee, 2)
+	req.Equal(uint64(3), ee[0].seq)
+	req.Equal(`{"a":3}`, string(ee[0].payload))
+	req.Equal(uint64(4), ee[1].seq)
+
+	ee, ok = b.since(1, 4)
+	req.True(ok)
+	req.Empty(ee)
+
+	// Client has seen more than was sent
+	_, ok = b.since(1, 5)
+	req.False(ok)
+
+	// User without any events
+	ee, ok = b.since(3, 0)
+	req.True(ok)
+	req.Empty(ee)
+
+	_, ok = b.since(3, 1)
+	req.False(ok)
+
+	b.retain(map[uint64]bool{2: true})
+	req.Equal(uint64(0), b.last(1))
+	req.Equal(uint64(1), b.last(2))
+}
+
+func TestEventBuffer_overflow(t *testing.T) {
+	var (
+		req = require.New(t)
+		b   = newEventBuffer(3)
+	)
+
+	for i := uint64(1); i <= 5; i++ {
+		req.Equal(i, b.add(1, i, []byte(`{}`)))
+	}
+
+	// Events 1 and 2 are no longer buffered
+	_, ok := b.since(1, 0)
+	req.False(ok)
+
+	_, ok = b.since(1, 1)
+	req.False(ok)
+
+	ee, ok := b.since(1, 2)
+	req.True(ok)
+	req.Len(ee, 3)
+	req.Equal(uint64(3), ee[0].seq)
+	req.Equal(uint64(5), ee[2].seq)
+}
+
+func TestWithSequence(t *testing.T) {
+	var req = require.New(t)
+
+	req.Equal(`{"seq":5,"message":{"id":"1"}}`, string(withSequence([]byte(`{"message":{"id":"1"}}`), 5)))
+	req.Equal(`{"seq":5}`, string(withSequence([]byte(`{}`), 5)))
+
+	// Volatile events have no sequence
+	req.Equal(`{"message":{}}`, string(withSequence([]byte(`{"message":{}}`), 0)))
+
+	// Not an object
+	req.Equal(`[1]`, string(withSequence([]byte(`[1]`), 5)))
+	req.Equal(`{`, string(withSequence([]byte(`{`), 5)))
+}
+
+func TestStore_Attach(t *testing.T) {
+	var (
+		req = require.New(t)
+		s   = NewStore()
+
+		suspend = func(userID uint64, token string) *Session {
+			old := s.Save(makeResumeTestSession(userID, 1))
+			old.token = token
+			old.subs.Add("42")
+			s.Suspend(old.id)
+			return old
+		}
+	)
+
+	defer func(b *eventBuffer) { buffer = b }(buffer)
+	buffer = newEventBuffer(3)
+
+	buffer.add(1, 100, []byte(`{"a":1}`))
+	buffer.add(1, 101, []byte(`{"a":2}`))
+
+	t.Run("resumed", func(t *testing.T) {
+		var (
+			old  = suspend(1, "valid")
+			sess = s.Attach(makeResumeTestSession(1, 10), "valid", 1)
+			sent = sentToResumeTestSession(sess)
+		)
+
+		req.Equal("valid", sess.token)
+		req.NotNil(sess.subs.Get("42"))
+		req.Nil(s.Get(old.id))
+		req.Equal(sess, s.Get(sess.id))
+
+		req.Len(sent, 2)
+		reply := decodeResumeTestReply(t, sent[0])
+		req.Equal("valid", reply.Token)
+		req.Equal(uint64(2), reply.Seq)
+		req.True(reply.Resumed)
+		req.False(reply.Resync)
+		req.Equal(`{"seq":2,"a":2}`, sent[1])
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		var (
+			sess = s.Attach(makeResumeTestSession(1, 10), "unknown", 1)
+			sent = sentToResumeTestSession(sess)
+		)
+
+		req.NotEmpty(sess.token)
+		req.NotEqual("unknown", sess.token)
+		req.Nil(sess.subs.Get("42"))
+
+		req.Len(sent, 1)
+		reply := decodeResumeTestReply(t, sent[0])
+		req.False(reply.Resumed)
+		req.True(reply.Resync)
+	})
+
+	t.Run("token of another user", func(t *testing.T) {
+		var (
+			old  = suspend(2, "other")
+			sess = s.Attach(makeResumeTestSession(1, 10), "other", 0)
+			sent = sentToResumeTestSession(sess)
+		)
+
+		req.NotEqual("other", sess.token)
+		req.NotNil(s.Get(old.id))
+		req.True(decodeResumeTestReply(t, sent[0]).Resync)
+	})
+
+	t.Run("stale sequence", func(t *testing.T) {
+		buffer.add(1, 102, []byte(`{"a":3}`))
+		buffer.add(1, 103, []byte(`{"a":4}`))
+
+		var (
+			old  = suspend(1, "stale")
+			sess = s.Attach(makeResumeTestSession(1, 10), "stale", 0)
+			sent = sentToResumeTestSession(sess)
+		)
+
+		// Subscriptions are taken over even when events can not be replayed
+		req.NotEqual("stale", sess.token)
+		req.NotNil(sess.subs.Get("42"))
+		req.Nil(s.Get(old.id))
+
+		req.Len(sent, 1)
+		reply := decodeResumeTestReply(t, sent[0])
+		req.Equal(uint64(4), reply.Seq)
+		req.False(reply.Resumed)
+		req.True(reply.Resync)
+	})
+
+	t.Run("replay does not fit into send buffer", func(t *testing.T) {
+		var (
+			_    = suspend(1, "full")
+			sess = s.Attach(makeResumeTestSession(1, 3), "full", 1)
+			sent = sentToResumeTestSession(sess)
+		)
+
+		req.Len(sent, 1)
+		req.True(decodeResumeTestReply(t, sent[0]).Resync)
+	})
+}
@@ -42,7 +42,7 @@ func (s *Store) Walk(callback func(*Session)) {
 
 func (s *Store) CountConnections(userID uint64) (count uint) {
 	s.Walk(func(session *Session) {
-		if session.user.Identity() == userID {
+		if session.suspendedAt == nil && session.user.Identity() == userID {
 			count++
 		}
 	})
@@ -66,7 +66,9 @@ func GetConnectedUsers() []uint64 {
 	var chk = map[uint64]bool{}
 
 	store.Walk(func(session *Session) {
-		chk[session.user.Identity()] = true
+		if session.suspendedAt == nil {
+			chk[session.user.Identity()] = true
+		}
 	})
 
 	var out = make([]uint64, 0)
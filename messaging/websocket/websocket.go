@@ -10,6 +10,7 @@ import (
 
 	"github.com/cortezaproject/corteza-server/pkg/auth"
 	"github.com/cortezaproject/corteza-server/pkg/logger"
+	"github.com/cortezaproject/corteza-server/pkg/payload"
 )
 
 type (
@@ -23,6 +24,8 @@ func New(config *Config) *Websocket {
 		config: config,
 	}
 
+	buffer.setBacklog(config.ResumeBacklog)
+
 	return ws
 }
 
@@ -54,9 +57,12 @@ func (ws Websocket) Open(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session := store.Save((&Session{}).New(ctx, ws.config, conn))
+	session := (&Session{}).New(ctx, ws.config, conn)
 	session.user = identity
 
+	// Resume previous session (if token and sequence of the last seen event are given)
+	store.Attach(session, r.URL.Query().Get("resume"), payload.ParseUInt64(r.URL.Query().Get("seq")))
+
 	if err := session.Handle(); err != nil {
 		logger.Default().Error("websocket session handler error", zap.Error(err))
 	}
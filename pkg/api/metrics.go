@@ -2,11 +2,65 @@ package api
 
 import (
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/766b/chi-prometheus"
 	"github.com/99designs/basicauth-go"
 	"github.com/go-chi/chi"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/cortezaproject/corteza-server/pkg/corredor"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+)
+
+var (
+	scriptRuns = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "corredor_script_runs_total",
+			Help: "How many times was the script executed",
+		},
+		[]string{"script"},
+	)
+
+	scriptFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "corredor_script_failures_total",
+			Help: "How many script executions failed or were aborted",
+		},
+		[]string{"script", "status"},
+	)
+
+	scriptLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "corredor_script_duration_milliseconds",
+			Help:    "How long it took to execute the script",
+			Buckets: []float64{10, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000},
+		},
+		[]string{"script"},
+	)
+
+	scriptMetricsOnce sync.Once
+
+	handlerErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "eventbus_handler_errors_total",
+			Help: "How many event handler calls failed (aborts not included)",
+		},
+		[]string{"resource", "event", "handler"},
+	)
+
+	handlerLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "eventbus_handler_duration_milliseconds",
+			Help:    "How long it took to handle the event",
+			Buckets: []float64{10, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000},
+		},
+		[]string{"resource", "event", "handler"},
+	)
+
+	handlerMetricsOnce sync.Once
 )
 
 // MetricsMiddleware is the request logger that provides metrics to prometheus
@@ -21,4 +75,44 @@ func metricsMount(r chi.Router, username, password string) {
 		}))
 		r.Handle("/metrics", promhttp.Handler())
 	})
+
+	metricsCorredor()
+	metricsEventbus()
+}
+
+// Registers script run metrics and observes all script executions
+func metricsCorredor() {
+	if corredor.Service() == nil {
+		return
+	}
+
+	scriptMetricsOnce.Do(func() {
+		prometheus.MustRegister(scriptRuns, scriptFailures, scriptLatency)
+
+		corredor.Service().AddRunObserver(func(run *corredor.Run) {
+			scriptRuns.WithLabelValues(run.Script).Inc()
+			scriptLatency.WithLabelValues(run.Script).Observe(float64(run.Duration))
+
+			if run.Status != corredor.RunSucceeded {
+				scriptFailures.WithLabelValues(run.Script, string(run.Status)).Inc()
+			}
+		})
+	})
+}
+
+// Registers event handler metrics and observes all handler calls
+func metricsEventbus() {
+	handlerMetricsOnce.Do(func() {
+		prometheus.MustRegister(handlerErrors, handlerLatency)
+
+		eventbus.Service().AddObserver(func(name string, ev eventbus.Event, d time.Duration, err error) {
+			var lv = []string{ev.ResourceType(), ev.EventType(), name}
+
+			handlerLatency.WithLabelValues(lv...).Observe(float64(d / time.Millisecond))
+
+			if err != nil && !eventbus.IsAbort(err) {
+				handlerErrors.WithLabelValues(lv...).Inc()
+			}
+		})
+	})
 }
@@ -20,6 +20,11 @@ type (
 		HTTPServer options.HTTPServerOpt
 		GRPCServer options.GRPCServerOpt
 		Websocket  options.WebsocketOpt
+		PubSub     options.PubSubOpt
+		Trash      options.TrashOpt
+		Scheduler  options.SchedulerOpt
+		Eventbus   options.EventbusOpt
+		Jobs       options.JobsOpt
 	}
 )
 
@@ -44,5 +49,10 @@ func NewOptions(prefix ...string) *Options {
 		HTTPServer: *options.HTTP(p),
 		GRPCServer: *options.GRPCServer(p),
 		Websocket:  *options.Websocket(p),
+		PubSub:     *options.PubSub(p),
+		Trash:      *options.Trash(p),
+		Scheduler:  *options.Scheduler(p),
+		Eventbus:   *options.Eventbus(p),
+		Jobs:       *options.Jobs(p),
 	}
 }
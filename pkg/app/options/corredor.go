@@ -27,6 +27,34 @@ type (
 		// Allow scripts to have runner explicitly defined
 		RunAsEnabled bool `env:"CORREDOR_RUN_AS_ENABLED"`
 
+		// Run server scripts in embedded JavaScript runtime instead of Corredor server
+		Embedded bool `env:"CORREDOR_EMBEDDED"`
+
+		// Directory with server scripts for embedded runtime
+		// (scripts stored in the database are always loaded)
+		EmbeddedPath string `env:"CORREDOR_EMBEDDED_PATH"`
+
+		// Heap size of the whole process over which running embedded scripts are interrupted
+		// (longest running first); 0 disables the limit
+		EmbeddedMaxMemory int `env:"CORREDOR_EMBEDDED_MAX_MEMORY"`
+
+		// Run iterators as background jobs that process resources in chunks
+		// and continue where they stopped when interrupted
+		IteratorDeferred bool `env:"CORREDOR_ITERATOR_DEFERRED"`
+
+		// Number of resources processed (and checkpointed) at once by deferred iterators
+		IteratorChunkSize int `env:"CORREDOR_ITERATOR_CHUNK_SIZE"`
+
+		// Max number of resources processed per second by deferred iterators (0 = unlimited)
+		IteratorRateLimit int `env:"CORREDOR_ITERATOR_RATE_LIMIT"`
+
+		// Record every script execution into run history
+		RunLogEnabled bool `env:"CORREDOR_RUN_LOG_ENABLED"`
+
+		// How long (and how many) script runs are kept in the history
+		RunLogRetention  time.Duration `env:"CORREDOR_RUN_LOG_RETENTION"`
+		RunLogMaxEntries int           `env:"CORREDOR_RUN_LOG_MAX_ENTRIES"`
+
 		TlsCertEnabled bool   `env:"CORREDOR_CLIENT_CERTIFICATES_ENABLED"`
 		TlsCertPath    string `env:"CORREDOR_CLIENT_CERTIFICATES_PATH"`
 		TlsCertCA      string `env:"CORREDOR_CLIENT_CERTIFICATES_CA"`
@@ -40,6 +68,11 @@ func Corredor() (o *CorredorOpt) {
 	o = &CorredorOpt{
 		Enabled:               true,
 		RunAsEnabled:          true,
+		EmbeddedMaxMemory:     1 << 30, // 1GB
+		IteratorChunkSize:     100,
+		RunLogEnabled:         true,
+		RunLogRetention:       time.Hour * 24 * 30,
+		RunLogMaxEntries:      100000,
 		Addr:                  "localhost:50051",
 		MaxBackoffDelay:       time.Minute,
 		MaxReceiveMessageSize: 2 << 23, // 16MB
@@ -0,0 +1,32 @@
+package options
+
+import (
+	"time"
+)
+
+type (
+	EventbusOpt struct {
+		// How long can event handler run before it's cancelled
+		// (handlers can set their own timeout on registration)
+		HandlerTimeout time.Duration `env:"EVENTBUS_HANDLER_TIMEOUT"`
+
+		// How many consecutive failures disable the handler
+		// Circuit breaker is disabled when set to 0
+		BreakerThreshold int `env:"EVENTBUS_BREAKER_THRESHOLD"`
+
+		// How long is the failing handler disabled
+		BreakerCooldown time.Duration `env:"EVENTBUS_BREAKER_COOLDOWN"`
+	}
+)
+
+func Eventbus(pfix string) (o *EventbusOpt) {
+	o = &EventbusOpt{
+		HandlerTimeout:   time.Minute,
+		BreakerThreshold: 5,
+		BreakerCooldown:  time.Minute,
+	}
+
+	fill(o, pfix)
+
+	return
+}
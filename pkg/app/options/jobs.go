@@ -0,0 +1,45 @@
+package options
+
+import (
+	"time"
+)
+
+type (
+	JobsOpt struct {
+		// Run job workers on this node
+		Enabled bool `env:"JOBS_ENABLED"`
+
+		// How many jobs can run at the same time (per service) on this node
+		Concurrency int `env:"JOBS_CONCURRENCY"`
+
+		// How often are queued jobs checked
+		PollInterval time.Duration `env:"JOBS_POLL_INTERVAL"`
+
+		// How many times is failed job tried and how long to wait between tries
+		MaxAttempts int           `env:"JOBS_MAX_ATTEMPTS"`
+		RetryDelay  time.Duration `env:"JOBS_RETRY_DELAY"`
+
+		// Running jobs that are not updated for this long are put back to queue
+		// (node that was running them is gone)
+		StaleAfter time.Duration `env:"JOBS_STALE_AFTER"`
+
+		// How long are finished jobs (and their results) kept
+		Retention time.Duration `env:"JOBS_RETENTION"`
+	}
+)
+
+func Jobs(pfix string) (o *JobsOpt) {
+	o = &JobsOpt{
+		Enabled:      true,
+		Concurrency:  2,
+		PollInterval: 5 * time.Second,
+		MaxAttempts:  3,
+		RetryDelay:   time.Minute,
+		StaleAfter:   5 * time.Minute,
+		Retention:    7 * 24 * time.Hour,
+	}
+
+	fill(o, pfix)
+
+	return
+}
@@ -16,6 +16,12 @@ type (
 		RedisTimeout     time.Duration `env:"PUBSUB_REDIS_TIMEOUT"`
 		RedisPingTimeout time.Duration `env:"PUBSUB_REDIS_PING_TIMEOUT"`
 		RedisPingPeriod  time.Duration `env:"PUBSUB_REDIS_PING_PERIOD"`
+
+		// NATS
+		NatsAddr          string        `env:"PUBSUB_NATS_ADDR"`
+		NatsPrefix        string        `env:"PUBSUB_NATS_PREFIX"`
+		NatsTimeout       time.Duration `env:"PUBSUB_NATS_TIMEOUT"`
+		NatsReconnectWait time.Duration `env:"PUBSUB_NATS_RECONNECT_WAIT"`
 	}
 )
 
@@ -24,6 +30,8 @@ func PubSub(pfix string) (o *PubSubOpt) {
 		timeout     = 15 * time.Second
 		pingTimeout = 120 * time.Second
 		pingPeriod  = (pingTimeout * 9) / 10
+
+		reconnectWait = 2 * time.Second
 	)
 
 	o = &PubSubOpt{
@@ -33,6 +41,11 @@ func PubSub(pfix string) (o *PubSubOpt) {
 		RedisTimeout:     timeout,
 		RedisPingTimeout: pingTimeout,
 		RedisPingPeriod:  pingPeriod,
+
+		NatsAddr:          "nats://nats:4222",
+		NatsPrefix:        "corteza",
+		NatsTimeout:       timeout,
+		NatsReconnectWait: reconnectWait,
 	}
 
 	fill(o, pfix)
@@ -0,0 +1,45 @@
+package options
+
+import (
+	"time"
+)
+
+type (
+	SchedulerOpt struct {
+		// How often are interval & timestamp events dispatched
+		Interval time.Duration `env:"SCHEDULER_INTERVAL"`
+
+		// Coordinate scheduling through the database so that only
+		// one of the nodes (lease holder) dispatches events
+		Coordinated bool `env:"SCHEDULER_COORDINATED"`
+
+		// How long is the lease held by the node that dispatches events
+		// Another node takes over when lease holder does not renew it in time
+		LeaseTTL time.Duration `env:"SCHEDULER_LEASE_TTL"`
+
+		// How far back are missed ticks dispatched (on startup or when another node takes over)
+		// Missed ticks are dropped when set to 0
+		CatchUp time.Duration `env:"SCHEDULER_CATCH_UP"`
+
+		// Name of the lease; nodes of the same service share one lease
+		// Defaults to the service name (or "monolith")
+		Lease string `env:"SCHEDULER_LEASE"`
+	}
+)
+
+func Scheduler(pfix string) (o *SchedulerOpt) {
+	o = &SchedulerOpt{
+		Interval:    time.Minute,
+		Coordinated: true,
+		LeaseTTL:    3 * time.Minute,
+		Lease:       pfix,
+	}
+
+	if o.Lease == "" {
+		o.Lease = "monolith"
+	}
+
+	fill(o, pfix)
+
+	return
+}
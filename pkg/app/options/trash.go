@@ -0,0 +1,27 @@
+package options
+
+import (
+	"time"
+)
+
+type (
+	TrashOpt struct {
+		// How long are deleted records kept before they are purged
+		// Purging is disabled when set to 0
+		Retention time.Duration `env:"TRASH_RETENTION"`
+
+		// How often are deleted records purged
+		PurgeInterval time.Duration `env:"TRASH_PURGE_INTERVAL"`
+	}
+)
+
+func Trash(pfix string) (o *TrashOpt) {
+	o = &TrashOpt{
+		Retention:     30 * 24 * time.Hour,
+		PurgeInterval: time.Hour,
+	}
+
+	fill(o, pfix)
+
+	return
+}
@@ -9,6 +9,12 @@ type (
 		Timeout     time.Duration `env:"WEBSOCKET_TIMEOUT"`
 		PingTimeout time.Duration `env:"WEBSOCKET_PING_TIMEOUT"`
 		PingPeriod  time.Duration `env:"WEBSOCKET_PING_PERIOD"`
+
+		// How long are events kept for disconnected sessions to resume
+		ResumeTimeout time.Duration `env:"WEBSOCKET_RESUME_TIMEOUT"`
+
+		// Max number of events kept per user
+		ResumeBacklog int `env:"WEBSOCKET_RESUME_BACKLOG"`
 	}
 )
 
@@ -17,12 +23,18 @@ func Websocket(pfix string) (o *WebsocketOpt) {
 		timeout     = 15 * time.Second
 		pingTimeout = 120 * time.Second
 		pingPeriod  = (pingTimeout * 9) / 10
+
+		resumeTimeout = 2 * time.Minute
+		resumeBacklog = 256
 	)
 
 	o = &WebsocketOpt{
 		Timeout:     timeout,
 		PingTimeout: pingTimeout,
 		PingPeriod:  pingPeriod,
+
+		ResumeTimeout: resumeTimeout,
+		ResumeBacklog: resumeBacklog,
 	}
 
 	fill(o, pfix)
@@ -0,0 +1,622 @@
+package corredor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/cortezaproject/corteza-server/pkg/app/options"
+)
+
+type (
+	// EmbeddedFunc is a Go function exposed to scripts running in the embedded runtime
+	//
+	// Arguments are passed as JSON encoded values, returned value
+	// is JSON encoded before it is handed back to the script
+	EmbeddedFunc func(ctx context.Context, args []json.RawMessage) (interface{}, error)
+
+	// EmbeddedScript holds source of the server script for the embedded runtime
+	EmbeddedScript struct {
+		Name      string
+		Source    string
+		UpdatedAt time.Time
+	}
+
+	// EmbeddedSource provides server scripts for the embedded runtime
+	EmbeddedSource interface {
+		Scripts(ctx context.Context) ([]*EmbeddedScript, error)
+	}
+
+	// embeddedClient runs server scripts in-process
+	//
+	// It implements ServerScriptsClient so that the rest of the service
+	// (registration, security, exec) does not know the difference
+	// between Corredor server and embedded runtime
+	embeddedClient struct {
+		log *zap.Logger
+		opt options.CorredorOpt
+
+		sources []EmbeddedSource
+		funcs   *embeddedFuncs
+
+		// nil when memory limit is disabled
+		guard *embeddedMemoryGuard
+
+		l sync.RWMutex
+
+		// compiled scripts, map[<script-name>]
+		programs map[string]*embeddedProgram
+	}
+
+	embeddedProgram struct {
+		prg       *goja.Program
+		updatedAt time.Time
+	}
+
+	embeddedFuncs struct {
+		l sync.RWMutex
+
+		// map[<namespace.function>]
+		ff map[string]EmbeddedFunc
+	}
+
+	embeddedDir struct {
+		path string
+	}
+)
+
+const (
+	// Scripts are wrapped into CommonJS-like module function
+	embeddedModuleWrapper = "(function (module, exports) {\n%s\n})"
+
+	// Trigger builder, passed to script's triggers() function
+	//
+	// Follows Corredor's builder:
+	//   triggers: function (t) { return [t.before('create').for('compose:record').where('module', 'Lead')] }
+	embeddedTriggerBuilder = `(function () {
+	function Trigger (eventTypes) {
+		this.eventTypes = eventTypes
+		this.resourceTypes = []
+		this.constraints = []
+		this.uiProps = []
+	}
+
+	Trigger.prototype.for = function () {
+		this.resourceTypes = this.resourceTypes.concat(Array.prototype.slice.call(arguments))
+		return this
+	}
+
+	Trigger.prototype.where = function (name, value, op) {
+		// value can be omitted for operators like 'changed'
+		value = value === undefined || value === null ? [] : [].concat(value)
+		this.constraints.push({ name: name, op: op || '', value: value.map(String) })
+		return this
+	}
+
+	Trigger.prototype.uiProp = function (name, value) {
+		this.uiProps.push({ name: name, value: String(value) })
+		return this
+	}
+
+	function prefixed (prefix) {
+		return function () {
+			return new Trigger(Array.prototype.slice.call(arguments).map(function (e) {
+				return prefix + e.charAt(0).toUpperCase() + e.slice(1)
+			}))
+		}
+	}
+
+	function constrained (eventType) {
+		return function () {
+			var t = new Trigger([eventType])
+			t.constraints.push({ name: '', op: '', value: Array.prototype.slice.call(arguments).map(String) })
+			return t
+		}
+	}
+
+	return {
+		before: prefixed('before'),
+		after: prefixed('after'),
+		on: prefixed('on'),
+		at: constrained('onTimestamp'),
+		every: constrained('onInterval'),
+		cron: constrained('onCron')
+	}
+})()`
+)
+
+// NewEmbeddedDirectory returns source that reads *.js scripts from the directory
+//
+// Script name is its path, relative to the directory
+func NewEmbeddedDirectory(path string) EmbeddedSource {
+	return &embeddedDir{path: path}
+}
+
+func (d embeddedDir) Scripts(ctx context.Context) (ss []*EmbeddedScript, err error) {
+	err = filepath.Walk(d.path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() || filepath.Ext(path) != ".js" {
+			return nil
+		}
+
+		src, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		name, _ := filepath.Rel(d.path, path)
+		ss = append(ss, &EmbeddedScript{
+			Name:      "/" + filepath.ToSlash(name),
+			Source:    string(src),
+			UpdatedAt: info.ModTime(),
+		})
+
+		return nil
+	})
+
+	return ss, errors.Wrap(err, "could not read embedded scripts")
+}
+
+func newEmbeddedClient(log *zap.Logger, opt options.CorredorOpt, funcs *embeddedFuncs, ss ...EmbeddedSource) *embeddedClient {
+	c := &embeddedClient{
+		log:      log.Named("embedded"),
+		opt:      opt,
+		sources:  ss,
+		funcs:    funcs,
+		programs: make(map[string]*embeddedProgram),
+	}
+
+	if opt.EmbeddedMaxMemory > 0 {
+		c.guard = newEmbeddedMemoryGuard(uint64(opt.EmbeddedMaxMemory))
+	}
+
+	return c
+}
+
+// List loads scripts from all sources, compiles them and extracts script definitions
+//
+// Respects if-modified-since metadata and returns empty response if none of the scripts
+// was added, modified or removed since then.
+func (c *embeddedClient) List(ctx context.Context, _ *ServerScriptListRequest, _ ...grpc.CallOption) (*ServerScriptListResponse, error) {
+	var (
+		rsp      = &ServerScriptListResponse{}
+		programs = make(map[string]*embeddedProgram)
+		modified = false
+		since    time.Time
+	)
+
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get("if-modified-since")) > 0 {
+		since, _ = time.Parse(time.RFC3339, md.Get("if-modified-since")[0])
+	}
+
+	for _, src := range c.sources {
+		ss, err := src.Scripts(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range ss {
+			if _, has := programs[s.Name]; has {
+				// First source wins
+				continue
+			}
+
+			script := &ServerScript{
+				Name:      s.Name,
+				UpdatedAt: s.UpdatedAt.Format(time.RFC3339),
+			}
+
+			p, err := c.compile(s)
+			if err == nil {
+				err = c.describe(ctx, p, script)
+			}
+
+			if err != nil {
+				script.Errors = append(script.Errors, err.Error())
+			}
+
+			programs[s.Name] = p
+			rsp.Scripts = append(rsp.Scripts, script)
+
+			// Timestamps are compared with the precision we get in the metadata
+			if !s.UpdatedAt.Truncate(time.Second).Before(since) {
+				modified = true
+			}
+		}
+	}
+
+	c.l.Lock()
+	defer c.l.Unlock()
+
+	if len(programs) != len(c.programs) {
+		// Script was added or removed
+		modified = true
+	}
+
+	c.programs = programs
+
+	if !since.IsZero() && !modified {
+		rsp.Scripts = nil
+	}
+
+	sort.Slice(rsp.Scripts, func(i, j int) bool {
+		return rsp.Scripts[i].Name < rsp.Scripts[j].Name
+	})
+
+	return rsp, nil
+}
+
+// Exec runs script's exec() function with the decoded arguments
+//
+// Script's return value is sent back as "result" (same as Corredor does);
+// false aborts the operation
+func (c *embeddedClient) Exec(ctx context.Context, req *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error) {
+	c.l.RLock()
+	p, has := c.programs[req.Name]
+	c.l.RUnlock()
+
+	if !has || p == nil {
+		return nil, status.Errorf(codes.NotFound, "nonexistent script (%q)", req.Name)
+	}
+
+	var (
+		rsp  = &ExecResponse{Result: make(map[string]string)}
+		rval goja.Value
+		logs []string
+	)
+
+	defer func() {
+		// Logs are sent back in the trailer, same as Corredor does
+		for _, o := range opts {
+			if t, ok := o.(grpc.TrailerCallOption); ok && t.TrailerAddr != nil {
+				*t.TrailerAddr = metadata.MD{runLogsMetadataKey: logs}
+			}
+		}
+	}()
+
+	err := c.run(ctx, p, &logs, func(vm *goja.Runtime, exports *goja.Object) (err error) {
+		fn, ok := goja.AssertFunction(exports.Get("exec"))
+		if !ok {
+			return errors.New("script does not export exec function")
+		}
+
+		var (
+			args     = vm.NewObject()
+			parse, _ = goja.AssertFunction(vm.Get("JSON").ToObject(vm).Get("parse"))
+			v        goja.Value
+		)
+
+		// Arguments are parsed inside the runtime so that
+		// script gets native (and modifiable) objects
+		for key, val := range req.Args {
+			if v, err = parse(goja.Undefined(), vm.ToValue(val)); err != nil {
+				return errors.Wrapf(err, "could not decode argument %q", key)
+			}
+
+			if err = args.Set(key, v); err != nil {
+				return
+			}
+		}
+
+		rval, err = fn(goja.Undefined(), args, c.api(ctx, vm))
+		return
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rval == nil || goja.IsUndefined(rval) || goja.IsNull(rval) {
+		return rsp, nil
+	}
+
+	if b, ok := rval.Export().(bool); ok {
+		if !b {
+			return nil, status.Error(codes.Aborted, "Aborted")
+		}
+
+		return rsp, nil
+	}
+
+	enc, err := json.Marshal(rval.Export())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode script result")
+	}
+
+	rsp.Result["result"] = string(enc)
+	return rsp, nil
+}
+
+// compiles script source into module function
+func (c *embeddedClient) compile(s *EmbeddedScript) (*embeddedProgram, error) {
+	c.l.RLock()
+	p, has := c.programs[s.Name]
+	c.l.RUnlock()
+
+	if has && p != nil && p.updatedAt.Equal(s.UpdatedAt) {
+		return p, nil
+	}
+
+	prg, err := goja.Compile(s.Name, fmt.Sprintf(embeddedModuleWrapper, s.Source), false)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compile script")
+	}
+
+	return &embeddedProgram{prg: prg, updatedAt: s.UpdatedAt}, nil
+}
+
+// describe copies script's definition (label, security, triggers...) from its exports
+func (c *embeddedClient) describe(ctx context.Context, p *embeddedProgram, script *ServerScript) error {
+	return c.run(ctx, p, nil, func(vm *goja.Runtime, exports *goja.Object) (err error) {
+		var (
+			tt        goja.Value
+			triggers  = exports.Get("triggers")
+			security  = exports.Get("security")
+			stringify = func(v goja.Value) (string, error) {
+				var (
+					fn, _ = goja.AssertFunction(vm.Get("JSON").ToObject(vm).Get("stringify"))
+					enc   goja.Value
+				)
+
+				if enc, err = fn(goja.Undefined(), v); err != nil {
+					return "", err
+				}
+
+				return enc.String(), nil
+			}
+		)
+
+		if v := exports.Get("label"); v != nil && !goja.IsUndefined(v) {
+			script.Label = v.String()
+		}
+
+		if v := exports.Get("description"); v != nil && !goja.IsUndefined(v) {
+			script.Description = v.String()
+		}
+
+		if security != nil && !goja.IsUndefined(security) && !goja.IsNull(security) {
+			script.Security = &Security{}
+
+			if runAs, ok := security.Export().(string); ok {
+				// Shorthand for run-as
+				script.Security.RunAs = runAs
+			} else if enc, err := stringify(security); err != nil {
+				return err
+			} else if err = json.Unmarshal([]byte(enc), script.Security); err != nil {
+				return errors.Wrap(err, "invalid security definition")
+			}
+		}
+
+		if triggers == nil || goja.IsUndefined(triggers) {
+			return nil
+		}
+
+		if fn, ok := goja.AssertFunction(triggers); ok {
+			if tt, err = vm.RunString(embeddedTriggerBuilder); err != nil {
+				return
+			}
+
+			if triggers, err = fn(exports, tt); err != nil {
+				return
+			}
+		}
+
+		if enc, err := stringify(triggers); err != nil {
+			return err
+		} else if err = json.Unmarshal([]byte(enc), &script.Triggers); err != nil {
+			return errors.Wrap(err, "invalid triggers definition")
+		}
+
+		return nil
+	})
+}
+
+// run executes the module in a new runtime and calls fn with module's exports
+//
+// Execution is interrupted when context is done or when
+// heap of the process grows over the configured limit. Console output is appended to logs (when set)
+func (c *embeddedClient) run(ctx context.Context, p *embeddedProgram, logs *[]string, fn func(*goja.Runtime, *goja.Object) error) (err error) {
+	var (
+		vm   = goja.New()
+		done = make(chan struct{})
+
+		module  = vm.NewObject()
+		exports = vm.NewObject()
+	)
+
+	defer close(done)
+	go c.watch(ctx, vm, done)
+
+	if c.guard != nil {
+		defer c.guard.watch(vm)()
+	}
+
+	defer func() {
+		// goja panics on some runtime errors (eg: stack overflow)
+		if r := recover(); r != nil {
+			err = errors.Errorf("script panicked: %v", r)
+		}
+	}()
+
+	vm.Set("console", map[string]interface{}{
+		"log":   c.console(zap.DebugLevel, logs),
+		"info":  c.console(zap.InfoLevel, logs),
+		"warn":  c.console(zap.WarnLevel, logs),
+		"error": c.console(zap.ErrorLevel, logs),
+	})
+
+	err = func() error {
+		wrapper, err := vm.RunProgram(p.prg)
+		if err != nil {
+			return err
+		}
+
+		mfn, ok := goja.AssertFunction(wrapper)
+		if !ok {
+			return errors.New("invalid script module")
+		}
+
+		if err = module.Set("exports", exports); err != nil {
+			return err
+		}
+
+		if _, err = mfn(goja.Undefined(), module, exports); err != nil {
+			return err
+		}
+
+		return fn(vm, module.Get("exports").ToObject(vm))
+	}()
+
+	switch e := err.(type) {
+	case *goja.InterruptedError:
+		return errors.Errorf("script interrupted: %v", e.Value())
+	case *goja.Exception:
+		var msg = e.Value().String()
+		if obj, ok := e.Value().(*goja.Object); ok && obj.Get("message") != nil {
+			msg = obj.Get("message").String()
+		}
+
+		if msg == "Aborted" {
+			// Same as returning false
+			return status.Error(codes.Aborted, msg)
+		}
+
+		return errors.New(msg)
+	}
+
+	return err
+}
+
+// watch interrupts the runtime when the context is done
+func (c *embeddedClient) watch(ctx context.Context, vm *goja.Runtime, done chan struct{}) {
+	select {
+	case <-done:
+	case <-ctx.Done():
+		vm.Interrupt("execution timeout")
+	}
+}
+
+// api builds object with all registered functions, grouped by namespace
+//
+// Function registered as "compose.findRecords" is available to the script
+// as ctx.compose.findRecords()
+func (c *embeddedClient) api(ctx context.Context, vm *goja.Runtime) *goja.Object {
+	var (
+		api    = vm.NewObject()
+		parse  = vm.Get("JSON").ToObject(vm).Get("parse")
+		encode = vm.Get("JSON").ToObject(vm).Get("stringify")
+	)
+
+	parseFn, _ := goja.AssertFunction(parse)
+	encodeFn, _ := goja.AssertFunction(encode)
+
+	for name, ef := range c.funcs.all() {
+		var (
+			obj   = api
+			parts = strings.Split(name, ".")
+			ef    = ef
+		)
+
+		for _, p := range parts[:len(parts)-1] {
+			if sub := obj.Get(p); sub != nil && !goja.IsUndefined(sub) {
+				obj = sub.ToObject(vm)
+				continue
+			}
+
+			sub := vm.NewObject()
+			_ = obj.Set(p, sub)
+			obj = sub
+		}
+
+		_ = obj.Set(parts[len(parts)-1], func(call goja.FunctionCall) goja.Value {
+			var args = make([]json.RawMessage, len(call.Arguments))
+			for i, a := range call.Arguments {
+				enc, err := encodeFn(goja.Undefined(), a)
+				if err != nil {
+					panic(vm.NewGoError(err))
+				}
+
+				if goja.IsUndefined(enc) {
+					args[i] = json.RawMessage("null")
+				} else {
+					args[i] = json.RawMessage(enc.String())
+				}
+			}
+
+			out, err := ef(ctx, args)
+			if err != nil {
+				panic(vm.NewGoError(err))
+			}
+
+			enc, err := json.Marshal(out)
+			if err != nil {
+				panic(vm.NewGoError(err))
+			}
+
+			rval, err := parseFn(goja.Undefined(), vm.ToValue(string(enc)))
+			if err != nil {
+				panic(vm.NewGoError(err))
+			}
+
+			return rval
+		})
+	}
+
+	return api
+}
+
+func (c *embeddedClient) console(lvl zapcore.Level, logs *[]string) func(call goja.FunctionCall) goja.Value {
+	return func(call goja.FunctionCall) goja.Value {
+		var msg = make([]string, len(call.Arguments))
+		for i, a := range call.Arguments {
+			msg[i] = a.String()
+		}
+
+		if logs != nil {
+			*logs = append(*logs, lvl.CapitalString()+" "+strings.Join(msg, " "))
+		}
+
+		if ce := c.log.Check(lvl, strings.Join(msg, " ")); ce != nil {
+			ce.Write()
+		}
+
+		return goja.Undefined()
+	}
+}
+
+func (ef *embeddedFuncs) set(name string, fn EmbeddedFunc) {
+	ef.l.Lock()
+	defer ef.l.Unlock()
+	ef.ff[name] = fn
+}
+
+func (ef *embeddedFuncs) all() map[string]EmbeddedFunc {
+	ef.l.RLock()
+	defer ef.l.RUnlock()
+
+	var ff = make(map[string]EmbeddedFunc, len(ef.ff))
+	for name, fn := range ef.ff {
+		ff[name] = fn
+	}
+
+	return ff
+}
@@ -0,0 +1,150 @@
+package corredor
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/cortezaproject/corteza-server/pkg/http"
+	"github.com/cortezaproject/corteza-server/pkg/mail"
+)
+
+type (
+	embeddedMail struct {
+		To      []string `json:"to"`
+		Cc      []string `json:"cc"`
+		Subject string   `json:"subject"`
+		HTML    string   `json:"html"`
+		Text    string   `json:"text"`
+	}
+
+	embeddedHttpRequest struct {
+		Method  string            `json:"method"`
+		URL     string            `json:"url"`
+		Headers map[string]string `json:"headers"`
+		Body    interface{}       `json:"body"`
+	}
+
+	embeddedHttpResponse struct {
+		Status  int               `json:"status"`
+		Headers map[string]string `json:"headers"`
+		Body    string            `json:"body"`
+	}
+)
+
+const (
+	// max size of the response body passed to the script
+	embeddedHttpMaxBody = 2 << 20 // 2MB
+)
+
+// Functions that are always available to embedded scripts
+//
+// Scripts call them as ctx.mail.send({ to: [...], subject, html })
+// and ctx.http.request({ method, url, headers, body })
+func embeddedBuiltins() map[string]EmbeddedFunc {
+	return map[string]EmbeddedFunc{
+		"mail.send":    embeddedMailSend,
+		"http.request": embeddedHttpRequestFn,
+	}
+}
+
+func embeddedMailSend(_ context.Context, args []json.RawMessage) (interface{}, error) {
+	var m = &embeddedMail{}
+	if err := embeddedArg(args, 0, m); err != nil {
+		return nil, err
+	}
+
+	if len(m.To) == 0 {
+		return nil, errors.New("mail recipients not provided")
+	}
+
+	for _, addr := range append(m.To, m.Cc...) {
+		if !mail.IsValidAddress(addr) {
+			return nil, errors.Errorf("invalid mail address %q", addr)
+		}
+	}
+
+	msg := mail.New()
+	msg.SetHeader("To", m.To...)
+	if len(m.Cc) > 0 {
+		msg.SetHeader("Cc", m.Cc...)
+	}
+
+	msg.SetHeader("Subject", m.Subject)
+
+	if m.Text != "" {
+		msg.SetBody("text/plain", m.Text)
+	}
+
+	if m.HTML != "" {
+		if m.Text != "" {
+			msg.AddAlternative("text/html", m.HTML)
+		} else {
+			msg.SetBody("text/html", m.HTML)
+		}
+	}
+
+	return true, mail.Send(msg)
+}
+
+func embeddedHttpRequestFn(ctx context.Context, args []json.RawMessage) (interface{}, error) {
+	var r = &embeddedHttpRequest{Method: "GET"}
+	if err := embeddedArg(args, 0, r); err != nil {
+		return nil, err
+	}
+
+	if r.URL == "" {
+		return nil, errors.New("request URL not provided")
+	}
+
+	c, err := http.New(&http.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.Request(strings.ToUpper(r.Method), r.URL, r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range r.Headers {
+		req.Header.Set(k, v)
+	}
+
+	rsp, err := c.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rsp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(rsp.Body, embeddedHttpMaxBody))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read response body")
+	}
+
+	out := &embeddedHttpResponse{
+		Status:  rsp.StatusCode,
+		Headers: make(map[string]string),
+		Body:    string(body),
+	}
+
+	for k := range rsp.Header {
+		out.Headers[k] = rsp.Header.Get(k)
+	}
+
+	return out, nil
+}
+
+// embeddedArg decodes n-th argument
+func embeddedArg(args []json.RawMessage, n int, dst interface{}) error {
+	if len(args) <= n {
+		return errors.Errorf("missing argument #%d", n+1)
+	}
+
+	return errors.Wrapf(json.Unmarshal(args[n], dst), "invalid argument #%d", n+1)
+}
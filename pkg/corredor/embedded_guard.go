@@ -0,0 +1,116 @@
+package corredor
+
+import (
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+type (
+	// embeddedMemoryGuard interrupts running scripts when heap of the process
+	// grows over the limit
+	//
+	// Goja does not account memory per runtime; guard samples heap of the whole process
+	// (once for all running scripts) and interrupts the longest running script,
+	// one per sample, until heap is back under the limit.
+	embeddedMemoryGuard struct {
+		l sync.Mutex
+
+		limit    uint64
+		interval time.Duration
+
+		// running scripts and when they were started
+		running map[*goja.Runtime]time.Time
+
+		// is sampling goroutine running?
+		active bool
+
+		// reads heap size; replaceable for tests
+		heap func() uint64
+	}
+)
+
+const (
+	// how often is heap size checked while scripts are running
+	embeddedMemoryCheckInterval = time.Second
+)
+
+func newEmbeddedMemoryGuard(limit uint64) *embeddedMemoryGuard {
+	return &embeddedMemoryGuard{
+		limit:    limit,
+		interval: embeddedMemoryCheckInterval,
+		running:  make(map[*goja.Runtime]time.Time),
+		heap:     heapAlloc,
+	}
+}
+
+// watch adds runtime to the guard and returns function that removes it
+func (g *embeddedMemoryGuard) watch(vm *goja.Runtime) func() {
+	g.l.Lock()
+	defer g.l.Unlock()
+
+	g.running[vm] = time.Now()
+
+	if !g.active {
+		g.active = true
+		go g.sample()
+	}
+
+	return func() {
+		g.l.Lock()
+		defer g.l.Unlock()
+		delete(g.running, vm)
+	}
+}
+
+// sample checks heap size until there are no running scripts
+func (g *embeddedMemoryGuard) sample() {
+	var ticker = time.NewTicker(g.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !g.check() {
+			return
+		}
+	}
+}
+
+// check interrupts the longest running script when over the limit
+//
+// Returns false (and stops sampling) when there are no running scripts
+func (g *embeddedMemoryGuard) check() bool {
+	g.l.Lock()
+	defer g.l.Unlock()
+
+	if len(g.running) == 0 {
+		g.active = false
+		return false
+	}
+
+	if g.heap() <= g.limit {
+		return true
+	}
+
+	var (
+		oldest    *goja.Runtime
+		startedAt time.Time
+	)
+
+	for vm, t := range g.running {
+		if oldest == nil || t.Before(startedAt) {
+			oldest, startedAt = vm, t
+		}
+	}
+
+	oldest.Interrupt("memory limit exceeded")
+	delete(g.running, oldest)
+	return true
+}
+
+func heapAlloc() uint64 {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return ms.HeapAlloc
+}
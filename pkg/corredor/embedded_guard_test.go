@@ -0,0 +1,48 @@
+package corredor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedMemoryGuard(t *testing.T) {
+	var (
+		req  = require.New(t)
+		heap uint64
+
+		g = newEmbeddedMemoryGuard(100)
+
+		old   = goja.New()
+		young = goja.New()
+	)
+
+	g.interval = time.Hour
+	g.heap = func() uint64 { return heap }
+
+	g.watch(old)
+	g.running[old] = time.Now().Add(-time.Minute)
+	doneYoung := g.watch(young)
+
+	// under the limit
+	heap = 50
+	req.True(g.check())
+	req.Len(g.running, 2)
+
+	// over the limit, longest running script is interrupted
+	heap = 150
+	req.True(g.check())
+	req.Len(g.running, 1)
+	_, err := old.RunString(`1`)
+	req.IsType(&goja.InterruptedError{}, err)
+
+	_, err = young.RunString(`1`)
+	req.NoError(err)
+
+	// sampling stops when nothing is running
+	doneYoung()
+	req.False(g.check())
+	req.False(g.active)
+}
@@ -0,0 +1,53 @@
+package corredor
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/titpetric/factory"
+)
+
+type (
+	embeddedStore struct {
+		db *factory.DB
+	}
+)
+
+const (
+	embeddedStoreTable = "sys_corredor_script"
+)
+
+// NewEmbeddedStore returns database backed source of server scripts for the embedded runtime
+func NewEmbeddedStore(db *factory.DB) EmbeddedSource {
+	return &embeddedStore{db: db}
+}
+
+func (s embeddedStore) Scripts(ctx context.Context) ([]*EmbeddedScript, error) {
+	var (
+		rr = []struct {
+			Name      string     `db:"name"`
+			Source    string     `db:"source"`
+			CreatedAt time.Time  `db:"created_at"`
+			UpdatedAt *time.Time `db:"updated_at"`
+		}{}
+
+		ss = make([]*EmbeddedScript, 0)
+	)
+
+	const query = "SELECT name, source, created_at, updated_at FROM " + embeddedStoreTable + " WHERE enabled"
+	if err := s.db.With(ctx).Select(&rr, query); err != nil {
+		return nil, errors.Wrap(err, "could not load embedded scripts")
+	}
+
+	for _, r := range rr {
+		s := &EmbeddedScript{Name: r.Name, Source: r.Source, UpdatedAt: r.CreatedAt}
+		if r.UpdatedAt != nil {
+			s.UpdatedAt = *r.UpdatedAt
+		}
+
+		ss = append(ss, s)
+	}
+
+	return ss, nil
+}
@@ -0,0 +1,133 @@
+package corredor
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/cortezaproject/corteza-server/pkg/app/options"
+)
+
+type (
+	mockEmbeddedSource []*EmbeddedScript
+)
+
+func (s mockEmbeddedSource) Scripts(context.Context) ([]*EmbeddedScript, error) {
+	return s, nil
+}
+
+func TestEmbeddedList(t *testing.T) {
+	var (
+		r   = require.New(t)
+		ctx = context.Background()
+		ts  = time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+
+		src = mockEmbeddedSource{
+			{Name: "/valid.js", UpdatedAt: ts, Source: `
+module.exports = {
+	label: 'Valid',
+	security: 'some-user',
+	triggers: function (t) {
+		return [
+			t.before('create', 'update').for('compose:record').where('module', ['A', 'B']),
+			t.cron('0 0 * * *').for('compose')
+		]
+	},
+	exec: function () {}
+}`},
+			{Name: "/broken.js", UpdatedAt: ts, Source: `module.exports = {`},
+		}
+
+		c = newEmbeddedClient(zap.NewNop(), options.CorredorOpt{}, &embeddedFuncs{}, src)
+	)
+
+	rsp, err := c.List(ctx, &ServerScriptListRequest{})
+	r.NoError(err)
+	r.Len(rsp.Scripts, 2)
+
+	r.Equal("/broken.js", rsp.Scripts[0].Name)
+	r.NotEmpty(rsp.Scripts[0].Errors)
+
+	s := rsp.Scripts[1]
+	r.Equal("Valid", s.Label)
+	r.Empty(s.Errors)
+	r.Equal("some-user", s.Security.RunAs)
+	r.Len(s.Triggers, 2)
+	r.Equal([]string{"beforeCreate", "beforeUpdate"}, s.Triggers[0].EventTypes)
+	r.Equal([]string{"compose:record"}, s.Triggers[0].ResourceTypes)
+	r.Equal("module", s.Triggers[0].Constraints[0].Name)
+	r.Equal([]string{"A", "B"}, s.Triggers[0].Constraints[0].Value)
+	r.Equal([]string{"onCron"}, s.Triggers[1].EventTypes)
+	r.Equal([]string{"0 0 * * *"}, s.Triggers[1].Constraints[0].Value)
+
+	// Nothing changed since the last list
+	ctx = metadata.NewOutgoingContext(ctx, metadata.MD{
+		"if-modified-since": []string{ts.Add(time.Minute).Format(time.RFC3339)},
+	})
+
+	rsp, err = c.List(ctx, &ServerScriptListRequest{})
+	r.NoError(err)
+	r.Empty(rsp.Scripts)
+}
+
+func TestEmbeddedExec(t *testing.T) {
+	var (
+		r   = require.New(t)
+		ctx = context.Background()
+
+		src = mockEmbeddedSource{
+			{Name: "/result.js", Source: `
+module.exports = {
+	exec: function (args, ctx) {
+		args.record.values.push({ name: 'total', value: String(ctx.math.sum(1, 2)) })
+		return args.record
+	}
+}`},
+			{Name: "/abort.js", Source: `module.exports = { exec: function () { return false } }`},
+			{Name: "/throw.js", Source: `module.exports = { exec: function () { throw new Error('failed') } }`},
+			{Name: "/loop.js", Source: `module.exports = { exec: function () { while (true) {} } }`},
+		}
+
+		funcs = &embeddedFuncs{ff: map[string]EmbeddedFunc{
+			"math.sum": func(_ context.Context, args []json.RawMessage) (interface{}, error) {
+				var a, b int
+				_ = json.Unmarshal(args[0], &a)
+				_ = json.Unmarshal(args[1], &b)
+				return a + b, nil
+			},
+		}}
+
+		c = newEmbeddedClient(zap.NewNop(), options.CorredorOpt{}, funcs, src)
+	)
+
+	_, err := c.List(ctx, &ServerScriptListRequest{})
+	r.NoError(err)
+
+	rsp, err := c.Exec(ctx, &ExecRequest{Name: "/result.js", Args: map[string]string{
+		"record": `{"recordID":"1","values":[]}`,
+	}})
+	r.NoError(err)
+	r.JSONEq(`{"recordID":"1","values":[{"name":"total","value":"3"}]}`, rsp.Result["result"])
+
+	_, err = c.Exec(ctx, &ExecRequest{Name: "/abort.js"})
+	r.Equal(codes.Aborted, status.Code(err))
+
+	_, err = c.Exec(ctx, &ExecRequest{Name: "/throw.js"})
+	r.EqualError(err, "failed")
+
+	_, err = c.Exec(ctx, &ExecRequest{Name: "/missing.js"})
+	r.Equal(codes.NotFound, status.Code(err))
+
+	tCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+
+	_, err = c.Exec(tCtx, &ExecRequest{Name: "/loop.js"})
+	r.EqualError(err, "script interrupted: execution timeout")
+}
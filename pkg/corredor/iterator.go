@@ -0,0 +1,195 @@
+package corredor
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/titpetric/factory"
+	"go.uber.org/zap"
+
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/pkg/jobs"
+)
+
+type (
+	// IteratorChunkFinder iterates over one chunk of resources (ordered by ID)
+	// that come after the given resource and before the upper bound (when set)
+	//
+	// Returns ID of the last resource in the chunk, number of resources in the chunk
+	// and number of resources left after the chunk
+	IteratorChunkFinder func(ctx context.Context, f map[string]string, h eventbus.HandlerFn, action string, afterID, beforeID uint64, size uint) (lastID uint64, n, left uint, err error)
+
+	iteratorJobParams struct {
+		Script string `json:"script"`
+	}
+
+	// Position of the deferred iterator, stored after each chunk
+	iteratorCheckpoint struct {
+		LastID uint64 `json:"lastID,string"`
+		Done   uint   `json:"done"`
+
+		// Upper bound for resource IDs, set when the iteration starts;
+		// resources created while iterating (eg: clones) are not iterated over
+		BeforeID uint64 `json:"beforeID,string"`
+	}
+)
+
+var (
+	// Upper bound for IDs of the iterated resources;
+	// IDs are time based, all resources created from now on will have greater ID
+	iteratorBoundID = func() uint64 { return factory.Sonyflake.NextID() }
+)
+
+const (
+	iteratorJobService = "corredor"
+	iteratorJobKind    = "iterator"
+)
+
+func (svc *service) RegisterIteratorChunkProvider(resourceType string, icf IteratorChunkFinder) {
+	svc.iteratorChunkProviders[resourceType] = icf
+}
+
+// Registers worker for deferred iterators on job service
+func (svc *service) registerIteratorWorker() {
+	if jobs.Service() == nil {
+		return
+	}
+
+	jobs.Service().Register(iteratorJobService, iteratorJobKind, svc.iteratorWorker)
+}
+
+// Can iterator run as a job?
+func (svc service) canDeferIterator(script *Script) bool {
+	if !svc.opt.IteratorDeferred || !jobs.Service().Available() {
+		return false
+	}
+
+	_, ok := svc.iteratorChunkProviders[script.Iterator.ResourceType]
+	return ok
+}
+
+// Enqueues iterator job
+//
+// Job runs with identity from the context (invoker or run-as user)
+func (svc service) deferIterator(ctx context.Context, script *Script) (*jobs.Job, error) {
+	svc.log.Debug("deferring iterator", zap.String("script", script.Name))
+	return jobs.Service().Enqueue(ctx, iteratorJobService, iteratorJobKind, iteratorJobParams{Script: script.Name}, nil)
+}
+
+// Runs deferred iterator in chunks
+//
+// Checkpoint is stored after each chunk so that iteration can continue
+// where it stopped if the job is interrupted
+func (svc *service) iteratorWorker(ctx context.Context, job *jobs.Job, r jobs.Reporter) error {
+	var (
+		p  = iteratorJobParams{}
+		cp = iteratorCheckpoint{}
+
+		script *Script
+		runAs  string
+		limit  uint64
+
+		chunk = uint(svc.opt.IteratorChunkSize)
+	)
+
+	if err := job.DecodeParams(&p); err != nil {
+		return err
+	}
+
+	if err := job.DecodeCheckpoint(&cp); err != nil {
+		return err
+	}
+
+	if script = svc.sScripts.FindByName(p.Script); script == nil || script.Iterator == nil {
+		return errors.Errorf("nonexistent iterator script (%q)", p.Script)
+	}
+
+	finder, ok := svc.iteratorChunkProviders[script.Iterator.ResourceType]
+	if !ok {
+		return errors.Errorf("unknown resource finder: %s", script.Iterator.ResourceType)
+	}
+
+	if script.Security != nil {
+		runAs = script.Security.RunAs
+	}
+
+	if l, has := script.Iterator.Filter["limit"]; has {
+		limit, _ = strconv.ParseUint(l, 10, 64)
+	}
+
+	if chunk == 0 {
+		chunk = 100
+	}
+
+	if cp.BeforeID == 0 {
+		cp.BeforeID = iteratorBoundID()
+	}
+
+	var (
+		h = svc.iterationHandler(script.Name, runAs)
+
+		lastID  uint64
+		n, left uint
+		err     error
+	)
+
+	if svc.opt.IteratorRateLimit > 0 {
+		t := time.NewTicker(time.Second / time.Duration(svc.opt.IteratorRateLimit))
+		defer t.Stop()
+
+		h = rateLimited(t.C, h)
+	}
+
+	for limit == 0 || uint64(cp.Done) < limit {
+		size := chunk
+		if limit > 0 && uint64(cp.Done+size) > limit {
+			size = uint(limit) - cp.Done
+		}
+
+		lastID, n, left, err = finder(ctx, script.Iterator.Filter, h, script.Iterator.Action, cp.LastID, cp.BeforeID, size)
+		if err != nil {
+			return err
+		}
+
+		if n == 0 {
+			break
+		}
+
+		cp.LastID, cp.Done = lastID, cp.Done+n
+
+		r.Progress(cp.Done+left, cp.Done, 0)
+		if err = r.Checkpoint(cp); err != nil {
+			return err
+		}
+
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// iteration handler/callback
+//
+// this function is called on every iteration, for
+// every resource found by iterator
+func (svc service) iterationHandler(scriptName, runAs string) eventbus.HandlerFn {
+	return func(ctx context.Context, ev eventbus.Event) error {
+		return svc.exec(ctx, scriptName, runAs, ev.(ScriptArgs))
+	}
+}
+
+// Waits for the limiter before each call of the handler
+func rateLimited(limiter <-chan time.Time, h eventbus.HandlerFn) eventbus.HandlerFn {
+	return func(ctx context.Context, ev eventbus.Event) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-limiter:
+			return h(ctx, ev)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package corredor
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/cortezaproject/corteza-server/pkg/app/options"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/pkg/jobs"
+)
+
+type (
+	mockReporter struct {
+		total, done uint
+		checkpoints []iteratorCheckpoint
+	}
+)
+
+func (r *mockReporter) Progress(total, done, _ uint) { r.total, r.done = total, done }
+func (r *mockReporter) Input() ([]byte, error)       { return nil, nil }
+func (r *mockReporter) Result(string, string, io.Reader) error {
+	return nil
+}
+
+func (r *mockReporter) Checkpoint(v interface{}) error {
+	r.checkpoints = append(r.checkpoints, v.(iteratorCheckpoint))
+	return nil
+}
+
+// Finder over resources with IDs from 1 to count
+func mockChunkFinder(count uint64, seen *[]uint64) IteratorChunkFinder {
+	return func(_ context.Context, _ map[string]string, _ eventbus.HandlerFn, _ string, afterID, _ uint64, size uint) (lastID uint64, n, left uint, err error) {
+		for ID := afterID + 1; ID <= count && n < size; ID++ {
+			*seen = append(*seen, ID)
+			lastID = ID
+			n++
+		}
+
+		return lastID, n, uint(count - afterID - uint64(n)), nil
+	}
+}
+
+func init() {
+	var lastID uint64 = 1000
+	iteratorBoundID = func() uint64 {
+		lastID++
+		return lastID
+	}
+}
+
+func TestService_iteratorWorker(t *testing.T) {
+	var (
+		req    = require.New(t)
+		ctx    = context.Background()
+		seen   []uint64
+		script = &Script{Name: "/iter.js", Iterator: &Iterator{ResourceType: "compose:record", Filter: map[string]string{}}}
+
+		svc = NewService(zap.NewNop(), options.CorredorOpt{IteratorChunkSize: 10})
+		job = &jobs.Job{Params: []byte(`{"script":"/iter.js"}`)}
+		rep = &mockReporter{}
+	)
+
+	svc.sScripts = ScriptSet{script}
+	svc.RegisterIteratorChunkProvider("compose:record", mockChunkFinder(25, &seen))
+
+	req.NoError(svc.iteratorWorker(ctx, job, rep))
+	req.Len(seen, 25)
+	req.Len(rep.checkpoints, 3)
+	req.Equal(uint64(25), rep.checkpoints[2].LastID)
+	req.Equal(uint(25), rep.done)
+	req.Equal(uint(25), rep.total)
+
+	// Continue from checkpoint
+	seen, rep = nil, &mockReporter{}
+	job.Checkpoint, _ = json.Marshal(iteratorCheckpoint{LastID: 20, Done: 20})
+	req.NoError(svc.iteratorWorker(ctx, job, rep))
+	req.Equal([]uint64{21, 22, 23, 24, 25}, seen)
+	req.Equal(uint(25), rep.done)
+
+	// Limit from the filter
+	seen, rep, job.Checkpoint = nil, &mockReporter{}, nil
+	script.Iterator.Filter["limit"] = "15"
+	req.NoError(svc.iteratorWorker(ctx, job, rep))
+	req.Len(seen, 15)
+}
+
+func TestService_iteratorWorkerClone(t *testing.T) {
+	var (
+		req    = require.New(t)
+		ctx    = context.Background()
+		script = &Script{Name: "/clone.js", Iterator: &Iterator{ResourceType: "compose:record", Action: "clone"}}
+
+		svc = NewService(zap.NewNop(), options.CorredorOpt{IteratorChunkSize: 2})
+		job = &jobs.Job{Params: []byte(`{"script":"/clone.js"}`)}
+		rep = &mockReporter{}
+
+		// existing resources
+		ids = []uint64{1, 2, 3, 4, 5}
+
+		cloned []uint64
+	)
+
+	// Finder that clones each resource in the chunk (clones get IDs after the bound)
+	svc.RegisterIteratorChunkProvider("compose:record", func(_ context.Context, _ map[string]string, _ eventbus.HandlerFn, _ string, afterID, beforeID uint64, size uint) (lastID uint64, n, left uint, err error) {
+		var chunk []uint64
+		for _, ID := range ids {
+			if ID > afterID && ID < beforeID {
+				if n < size {
+					chunk = append(chunk, ID)
+					lastID = ID
+					n++
+				} else {
+					left++
+				}
+			}
+		}
+
+		for range chunk {
+			ids = append(ids, iteratorBoundID())
+		}
+
+		cloned = append(cloned, chunk...)
+		return
+	})
+
+	svc.sScripts = ScriptSet{script}
+
+	req.NoError(svc.iteratorWorker(ctx, job, rep))
+	req.Equal([]uint64{1, 2, 3, 4, 5}, cloned)
+	req.Len(ids, 10)
+	req.Len(rep.checkpoints, 3)
+	req.NotZero(rep.checkpoints[0].BeforeID)
+	req.Equal(rep.checkpoints[0].BeforeID, rep.checkpoints[2].BeforeID)
+}
+
+func TestService_iteratorWorkerMissingScript(t *testing.T) {
+	var (
+		svc = NewService(zap.NewNop(), options.CorredorOpt{})
+		job = &jobs.Job{Params: []byte(`{"script":"/missing.js"}`)}
+	)
+
+	require.Error(t, svc.iteratorWorker(context.Background(), job, &mockReporter{}))
+}
+
+func TestRateLimited(t *testing.T) {
+	var (
+		req     = require.New(t)
+		limiter = make(chan time.Time, 1)
+		calls   = 0
+		h       = rateLimited(limiter, func(context.Context, eventbus.Event) error { calls++; return nil })
+	)
+
+	limiter <- time.Now()
+	req.NoError(h(context.Background(), nil))
+	req.Equal(1, calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req.Error(h(ctx, nil))
+	req.Equal(1, calls)
+}
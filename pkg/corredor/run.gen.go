@@ -0,0 +1,67 @@
+package corredor
+
+// 	Hello! This file is auto-generated.
+
+type (
+
+	// RunSet slice of Run
+	//
+	// This type is auto-generated.
+	RunSet []*Run
+)
+
+// Walk iterates through every slice item and calls w(Run) err
+//
+// This function is auto-generated.
+func (set RunSet) Walk(w func(*Run) error) (err error) {
+	for i := range set {
+		if err = w(set[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Filter iterates through every slice item, calls f(Run) (bool, err) and return filtered slice
+//
+// This function is auto-generated.
+func (set RunSet) Filter(f func(*Run) (bool, error)) (out RunSet, err error) {
+	var ok bool
+	out = RunSet{}
+	for i := range set {
+		if ok, err = f(set[i]); err != nil {
+			return
+		} else if ok {
+			out = append(out, set[i])
+		}
+	}
+
+	return
+}
+
+// FindByID finds items from slice by its ID property
+//
+// This function is auto-generated.
+func (set RunSet) FindByID(ID uint64) *Run {
+	for i := range set {
+		if set[i].ID == ID {
+			return set[i]
+		}
+	}
+
+	return nil
+}
+
+// IDs returns a slice of uint64s from all items in the set
+//
+// This function is auto-generated.
+func (set RunSet) IDs() (IDs []uint64) {
+	IDs = make([]uint64, len(set))
+
+	for i := range set {
+		IDs[i] = set[i].ID
+	}
+
+	return
+}
@@ -0,0 +1,100 @@
+package corredor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	// Run is a record of a single script execution
+	Run struct {
+		ID uint64 `json:"runID,string" db:"id"`
+
+		Script       string `json:"script"       db:"script"`
+		ResourceType string `json:"resourceType" db:"resource_type"`
+		EventType    string `json:"eventType"    db:"event_type"`
+		ResourceID   uint64 `json:"resourceID,string,omitempty" db:"resource_id"`
+
+		// User that triggered the script and user that script was running as
+		InvokerID uint64 `json:"invokerID,string,omitempty" db:"invoker_id"`
+		RunAsID   uint64 `json:"runAsID,string,omitempty"   db:"run_as_id"`
+
+		Status   RunStatus `json:"status"   db:"status"`
+		Error    string    `json:"error"    db:"error"`
+		Logs     string    `json:"logs"     db:"logs"`
+		Duration uint      `json:"duration" db:"duration"` // in milliseconds
+
+		CreatedAt time.Time `json:"createdAt" db:"created_at"`
+	}
+
+	RunFilter struct {
+		Script       string     `json:"script"`
+		ResourceType string     `json:"resourceType"`
+		EventType    string     `json:"eventType"`
+		ResourceID   uint64     `json:"resourceID,string"`
+		Status       RunStatus  `json:"status"`
+		From         *time.Time `json:"from"`
+		To           *time.Time `json:"to"`
+
+		Sort string `json:"sort"`
+
+		// Standard paging fields & helpers
+		rh.PageFilter
+	}
+
+	RunStatus string
+
+	// RunObserver is notified about every recorded script execution
+	RunObserver func(*Run)
+)
+
+const (
+	RunSucceeded RunStatus = "succeeded"
+	RunAborted   RunStatus = "aborted"
+	RunFailed    RunStatus = "failed"
+
+	// Max length of logs kept with the run
+	runLogsMaxLength = 4096
+
+	// Metadata key (header or trailer) with script logs
+	runLogsMetadataKey = "logs"
+)
+
+// runResourceID extracts ID of the resource from encoded script arguments
+//
+// Resource is expected under the key that matches the last part of
+// the resource type (compose:record => record) with <key>ID property
+func runResourceID(resourceType string, args map[string][]byte) uint64 {
+	var (
+		key = resourceType[strings.LastIndex(resourceType, ":")+1:]
+		res map[string]interface{}
+		dec = json.NewDecoder(bytes.NewReader(args[key]))
+	)
+
+	// IDs are usually encoded as strings, numbers
+	// are decoded w/o loss of precision
+	dec.UseNumber()
+
+	if len(args[key]) == 0 || dec.Decode(&res) != nil {
+		return 0
+	}
+
+	id, _ := strconv.ParseUint(fmt.Sprintf("%v", res[key+"ID"]), 10, 64)
+	return id
+}
+
+// truncates logs to max length
+func runLogs(ll []string) string {
+	logs := strings.Join(ll, "\n")
+	if len(logs) > runLogsMaxLength {
+		logs = logs[:runLogsMaxLength]
+	}
+
+	return logs
+}
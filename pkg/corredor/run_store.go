@@ -0,0 +1,137 @@
+package corredor
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+	"github.com/titpetric/factory"
+
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	// RunStore keeps history of script executions
+	RunStore interface {
+		Create(ctx context.Context, run *Run) error
+		Find(ctx context.Context, filter RunFilter) (RunSet, RunFilter, error)
+
+		// Purge removes runs older than given time and all but the last max runs
+		Purge(ctx context.Context, before time.Time, max uint) error
+	}
+
+	runStore struct {
+		db *factory.DB
+	}
+)
+
+const (
+	runStoreTable = "sys_automation_run"
+)
+
+// NewRunStore returns database backed store for script run history
+func NewRunStore(db *factory.DB) RunStore {
+	return &runStore{db: db}
+}
+
+func (s runStore) columns() []string {
+	return []string{
+		"r.id",
+		"r.script",
+		"r.resource_type",
+		"r.event_type",
+		"r.resource_id",
+		"r.invoker_id",
+		"r.run_as_id",
+		"r.status",
+		"r.error",
+		"r.logs",
+		"r.duration",
+		"r.created_at",
+	}
+}
+
+func (s runStore) Create(ctx context.Context, run *Run) error {
+	run.ID = factory.Sonyflake.NextID()
+	return errors.Wrap(s.db.With(ctx).Insert(runStoreTable, run), "could not store script run")
+}
+
+func (s runStore) Find(ctx context.Context, filter RunFilter) (set RunSet, f RunFilter, err error) {
+	var (
+		db    = s.db.With(ctx)
+		query = squirrel.Select(s.columns()...).From(runStoreTable + " AS r")
+	)
+
+	f = filter
+
+	if f.Sort == "" {
+		f.Sort = "r.id DESC"
+	}
+
+	if f.Script != "" {
+		query = query.Where(squirrel.Eq{"r.script": f.Script})
+	}
+
+	if f.ResourceType != "" {
+		query = query.Where(squirrel.Eq{"r.resource_type": f.ResourceType})
+	}
+
+	if f.EventType != "" {
+		query = query.Where(squirrel.Eq{"r.event_type": f.EventType})
+	}
+
+	if f.ResourceID > 0 {
+		query = query.Where(squirrel.Eq{"r.resource_id": f.ResourceID})
+	}
+
+	if f.Status != "" {
+		query = query.Where(squirrel.Eq{"r.status": f.Status})
+	}
+
+	if f.From != nil {
+		query = query.Where(squirrel.GtOrEq{"r.created_at": f.From})
+	}
+
+	if f.To != nil {
+		query = query.Where(squirrel.Lt{"r.created_at": f.To})
+	}
+
+	var orderBy []string
+	if orderBy, err = rh.ParseOrder(f.Sort, s.columns()...); err != nil {
+		return
+	} else {
+		query = query.OrderBy(orderBy...)
+	}
+
+	if f.Count, err = rh.Count(db, query); err != nil || f.Count == 0 {
+		return
+	}
+
+	return set, f, rh.FetchPaged(db, query, f.PageFilter, &set)
+}
+
+func (s runStore) Purge(ctx context.Context, before time.Time, max uint) (err error) {
+	var db = s.db.With(ctx)
+
+	if err = rh.Delete(db, runStoreTable, squirrel.Lt{"created_at": before}); err != nil {
+		return errors.Wrap(err, "could not purge script runs")
+	}
+
+	if max == 0 {
+		return
+	}
+
+	// Find ID of the oldest run we keep
+	var oldest uint64
+	err = db.Get(&oldest, "SELECT id FROM "+runStoreTable+" ORDER BY id DESC LIMIT 1 OFFSET ?", max-1)
+	if err != nil || oldest == 0 {
+		// No rows (less than max) is not an error
+		return nil
+	}
+
+	return errors.Wrap(
+		rh.Delete(db, runStoreTable, squirrel.Lt{"id": oldest}),
+		"could not purge script runs",
+	)
+}
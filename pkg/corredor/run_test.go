@@ -0,0 +1,111 @@
+package corredor
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/cortezaproject/corteza-server/pkg/app/options"
+)
+
+type (
+	mockRunArgs struct {
+		mockEvent
+		record string
+	}
+
+	mockRunStore struct {
+		runs RunSet
+	}
+)
+
+func (e mockRunArgs) Encode() (map[string][]byte, error) {
+	return map[string][]byte{"record": []byte(e.record)}, nil
+}
+
+func (s *mockRunStore) Create(_ context.Context, run *Run) error {
+	s.runs = append(s.runs, run)
+	return nil
+}
+
+func (s *mockRunStore) Find(context.Context, RunFilter) (RunSet, RunFilter, error) {
+	return s.runs, RunFilter{}, nil
+}
+
+func (s *mockRunStore) Purge(context.Context, time.Time, uint) error {
+	return nil
+}
+
+func TestRunResourceID(t *testing.T) {
+	var r = require.New(t)
+
+	r.Equal(uint64(154277310484250626), runResourceID("compose:record", map[string][]byte{
+		"record": []byte(`{"recordID":"154277310484250626"}`),
+	}))
+
+	r.Equal(uint64(154277310484250626), runResourceID("compose:record", map[string][]byte{
+		"record": []byte(`{"recordID":154277310484250626}`),
+	}))
+
+	r.Equal(uint64(42), runResourceID("system:user", map[string][]byte{"user": []byte(`{"userID":"42"}`)}))
+	r.Zero(runResourceID("compose", map[string][]byte{}))
+	r.Zero(runResourceID("compose:record", map[string][]byte{"record": []byte(`{}`)}))
+}
+
+func TestRunLogs(t *testing.T) {
+	var r = require.New(t)
+
+	r.Equal("a\nb", runLogs([]string{"a", "b"}))
+	r.Len(runLogs([]string{strings.Repeat("x", runLogsMaxLength*2)}), runLogsMaxLength)
+}
+
+func TestService_execRecordsRun(t *testing.T) {
+	var (
+		r        = require.New(t)
+		ctx      = context.Background()
+		store    = &mockRunStore{}
+		observed = 0
+
+		opt = options.CorredorOpt{RunLogEnabled: true, DefaultExecTimeout: time.Second}
+		svc = NewService(zap.NewNop(), opt)
+
+		src = mockEmbeddedSource{
+			{Name: "/ok.js", Source: `module.exports = { exec: function () { console.log('all good') } }`},
+			{Name: "/abort.js", Source: `module.exports = { exec: function () { return false } }`},
+			{Name: "/fail.js", Source: `module.exports = { exec: function () { throw new Error('failed') } }`},
+		}
+
+		args = &mockRunArgs{
+			mockEvent: mockEvent{rType: "compose:record", eType: "beforeUpdate"},
+			record:    `{"recordID":"42"}`,
+		}
+	)
+
+	svc.ssClient = newEmbeddedClient(zap.NewNop(), opt, svc.embeddedFuncs, src)
+	svc.SetRunStore(store)
+	svc.AddRunObserver(func(*Run) { observed++ })
+
+	_, err := svc.ssClient.List(ctx, &ServerScriptListRequest{})
+	r.NoError(err)
+
+	r.NoError(svc.exec(ctx, "/ok.js", "", args))
+	r.Error(svc.exec(ctx, "/abort.js", "", args))
+	r.Error(svc.exec(ctx, "/fail.js", "", args))
+
+	r.Equal(3, observed)
+	r.Len(store.runs, 3)
+
+	r.Equal(RunSucceeded, store.runs[0].Status)
+	r.Equal(uint64(42), store.runs[0].ResourceID)
+	r.Equal("beforeUpdate", store.runs[0].EventType)
+	r.Equal("DEBUG all good", store.runs[0].Logs)
+
+	r.Equal(RunAborted, store.runs[1].Status)
+
+	r.Equal(RunFailed, store.runs[2].Status)
+	r.Contains(store.runs[2].Error, "failed")
+}
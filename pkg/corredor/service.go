@@ -49,13 +49,18 @@ type (
 		ssClient ServerScriptsClient
 		csClient ClientScriptsClient
 
+		// Sources & functions for embedded runtime
+		embeddedSources []EmbeddedSource
+		embeddedFuncs   *embeddedFuncs
+
 		log *zap.Logger
 
 		eventRegistry  eventRegistry
 		authTokenMaker authTokenMaker
 
 		// map[resource-type]
-		iteratorProviders map[string]IteratorResourceFinder
+		iteratorProviders      map[string]IteratorResourceFinder
+		iteratorChunkProviders map[string]IteratorChunkFinder
 
 		// Services to help with script security
 		// we'll find users (runAs) and roles (allow, deny) for
@@ -64,6 +69,10 @@ type (
 
 		// set of permission rules, generated from security info of each script
 		permissions permissions.RuleSet
+
+		// history of script executions
+		runStore     RunStore
+		runObservers []RunObserver
 	}
 
 	ScriptArgs interface {
@@ -108,6 +117,7 @@ const (
 	onIterationEventType = "onIteration"
 	onIntervalEventType  = "onInterval"
 	onTimestampEventType = "onTimestamp"
+	onCronEventType      = "onCron"
 )
 
 var (
@@ -124,6 +134,14 @@ var (
 		onManualEventType:    true,
 		onIntervalEventType:  true,
 		onTimestampEventType: true,
+		onCronEventType:      true,
+	}
+
+	// List of event types that are dispatched by the scheduler
+	scheduledEventTypes = []string{
+		onIntervalEventType,
+		onTimestampEventType,
+		onCronEventType,
 	}
 
 	explicitEventTypes = []string{
@@ -148,6 +166,7 @@ func Setup(logger *zap.Logger, opt options.CorredorOpt) (err error) {
 	}
 
 	gCorredor = NewService(logger, opt)
+	gCorredor.registerIteratorWorker()
 	return
 }
 
@@ -159,7 +178,9 @@ func NewService(logger *zap.Logger, opt options.CorredorOpt) *service {
 		registered: make(map[string][]uintptr),
 		explicit:   make(map[string]map[string]bool),
 
-		iteratorProviders: make(map[string]IteratorResourceFinder),
+		iteratorProviders:      make(map[string]IteratorResourceFinder),
+		iteratorChunkProviders: make(map[string]IteratorChunkFinder),
+		embeddedFuncs:          &embeddedFuncs{ff: embeddedBuiltins()},
 
 		authTokenMaker: auth.DefaultJwtHandler,
 		eventRegistry:  eventbus.Service(),
@@ -172,6 +193,18 @@ func (svc *service) Connect(ctx context.Context) (err error) {
 		return
 	}
 
+	if svc.opt.Embedded {
+		// Server scripts are executed in-process,
+		// client scripts are not supported w/o Corredor server
+		var ss = svc.embeddedSources
+		if svc.opt.EmbeddedPath != "" {
+			ss = append([]EmbeddedSource{NewEmbeddedDirectory(svc.opt.EmbeddedPath)}, ss...)
+		}
+
+		svc.ssClient = newEmbeddedClient(svc.log, svc.opt, svc.embeddedFuncs, ss...)
+		return
+	}
+
 	if err = svc.connect(ctx); err != nil {
 		return
 	}
@@ -206,6 +239,22 @@ func (svc *service) Watch(ctx context.Context) {
 		}
 	}()
 
+	if svc.runStore != nil {
+		go func() {
+			defer sentry.Recover()
+			var ticker = time.NewTicker(time.Hour)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					svc.purgeRuns(ctx)
+				}
+			}
+		}()
+	}
+
 	svc.log.Debug("watcher initialized")
 }
 
@@ -225,13 +274,49 @@ func (svc *service) SetRoleFinder(rf roleFinder) {
 	svc.roles = rf
 }
 
+func (svc *service) SetRunStore(rs RunStore) {
+	svc.runStore = rs
+}
+
+// AddRunObserver adds function that is called after each script execution
+func (svc *service) AddRunObserver(o RunObserver) {
+	svc.runObservers = append(svc.runObservers, o)
+}
+
+// FindRuns returns history of script executions
+func (svc service) FindRuns(ctx context.Context, f RunFilter) (RunSet, RunFilter, error) {
+	if svc.runStore == nil {
+		return nil, f, errors.New("script run history not available")
+	}
+
+	return svc.runStore.Find(ctx, f)
+}
+
+// AddEmbeddedSource adds source of server scripts for the embedded runtime
+//
+// Sources must be added before service connects
+func (svc *service) AddEmbeddedSource(s EmbeddedSource) {
+	svc.embeddedSources = append(svc.embeddedSources, s)
+}
+
+// RegisterEmbeddedFunc makes function available to scripts in the embedded runtime
+//
+// Dot in the name groups functions: "compose.findRecords" is
+// available to the script as ctx.compose.findRecords()
+func (svc *service) RegisterEmbeddedFunc(name string, fn EmbeddedFunc) {
+	svc.embeddedFuncs.set(name, fn)
+}
+
 func (svc *service) Load(ctx context.Context) {
 	if !svc.opt.Enabled {
 		return
 	}
 
 	go svc.loadServerScripts(ctx)
-	go svc.loadClientScripts(ctx)
+
+	if svc.csClient != nil {
+		go svc.loadClientScripts(ctx)
+	}
 }
 
 // Find returns filtered list of scripts that can be manually triggered
@@ -314,16 +399,16 @@ func (svc service) ExecIterator(ctx context.Context, scriptName string) error {
 			ctx = auth.SetIdentityToContext(ctx, definer)
 		}
 
+		if svc.canDeferIterator(script) {
+			// Iterate in chunks in the background
+			_, err := svc.deferIterator(ctx, script)
+			return err
+		}
+
 		return finder(
 			ctx,
 			script.Iterator.Filter,
-			func(ctx context.Context, ev eventbus.Event) error {
-				// iteration handler/callback
-				//
-				// this function is called on every iteration, for
-				// every resource found by iterator
-				return svc.exec(ctx, scriptName, runAs, ev.(ScriptArgs))
-			},
+			svc.iterationHandler(scriptName, runAs),
 			script.Iterator.Action,
 		)
 	}
@@ -458,6 +543,14 @@ func (svc *service) registerServerScripts(ss ...*ServerScript) {
 			}
 		}
 
+		if len(s.Errors) == 0 {
+			if ss, err := scriptSchedules(s); err != nil {
+				s.Errors = append(s.Errors, err.Error())
+			} else {
+				s.Schedules = ss
+			}
+		}
+
 		// Corredor can (by design) serve us script with errors (load, parse time) and
 		// they need to be ignored by security, trigger, iterator handlers
 		if len(s.Errors) == 0 {
@@ -501,7 +594,7 @@ func (svc *service) registerServerScripts(ss ...*ServerScript) {
 // Registers scheduled and manual iterators
 //
 // scheduled iterators
-//   registered on eventbus as onInterval or onTimestamp
+//   registered on eventbus as onInterval, onTimestamp or onCron
 //   and triggered by/from scheduler
 //
 // manual iterators
@@ -535,9 +628,9 @@ func (svc *service) processIterator(script *Script) (ptr uintptr, err error) {
 	case onManualEventType:
 		// nothing special here with manual iterators...
 		return
-	case onIntervalEventType, onTimestampEventType:
+	case onIntervalEventType, onTimestampEventType, onCronEventType:
 		if len(i.Deferred) == 0 {
-			return 0, errors.Errorf("missing specification for interval/timestamp events")
+			return 0, errors.Errorf("missing specification for interval/timestamp/cron events")
 		}
 
 		if script.Security == nil {
@@ -605,7 +698,7 @@ func (svc *service) registerTriggers(script *ServerScript) []uintptr {
 			}
 
 			return nil
-		}, ops...)
+		}, append(ops, eventbus.Name(script.Name), eventbus.Timeout(svc.opt.DefaultExecTimeout))...)
 
 		ptrs = append(ptrs, ptr)
 	}
@@ -625,6 +718,9 @@ func (svc service) exec(ctx context.Context, script string, runAs string, args S
 
 		invoker auth.Identifiable
 
+		// user that script is running as
+		runner auth.Identifiable
+
 		encodedEvent   map[string][]byte
 		encodedResults = make(map[string][]byte)
 
@@ -634,14 +730,27 @@ func (svc service) exec(ctx context.Context, script string, runAs string, args S
 			zap.String("args", args.EventType()),
 			zap.String("resource", args.ResourceType()),
 		)
+
+		run = &Run{
+			Script:       script,
+			ResourceType: args.ResourceType(),
+			EventType:    args.EventType(),
+			CreatedAt:    time.Now(),
+		}
 	)
 
 	log.Debug("triggered")
 
+	defer func() {
+		svc.recordRun(run, err)
+	}()
+
 	if encodedEvent, err = args.Encode(); err != nil {
 		return
 	}
 
+	run.ResourceID = runResourceID(args.ResourceType(), encodedEvent)
+
 	// //// //// //// //// //// //// //// //// //// //// //// //// //// //// //// //// //// //// //// //// //// ////
 	// Additional ([]byte) arguments
 
@@ -663,6 +772,7 @@ func (svc service) exec(ctx context.Context, script string, runAs string, args S
 		}
 
 		log = log.With(zap.Stringer("invoker", invoker))
+		run.InvokerID = invoker.Identity()
 
 		if err = encodeArguments(req.Args, "invoker", invoker); err != nil {
 			return
@@ -687,6 +797,8 @@ func (svc service) exec(ctx context.Context, script string, runAs string, args S
 		}
 
 		log = log.With(zap.Stringer("run-as", definer))
+		runner = definer
+		run.RunAsID = definer.Identity()
 
 		// current (authenticated) user
 		if err = encodeArguments(req.Args, "authUser", definer); err != nil {
@@ -699,6 +811,8 @@ func (svc service) exec(ctx context.Context, script string, runAs string, args S
 
 	} else if invoker != nil {
 		// Run script with the same user that invoked it
+		runner = invoker
+		run.RunAsID = invoker.Identity()
 
 		// current (authenticated) user
 		if err = encodeArguments(req.Args, "authUser", invoker); err != nil {
@@ -723,15 +837,27 @@ func (svc service) exec(ctx context.Context, script string, runAs string, args S
 
 	// //// //// //// //// //// //// //// //// //// //// //// //// //// //// //// //// //// //// //// //// //// ////
 
-	ctx, cancel := context.WithTimeout(
+	var deadline = time.Now().Add(svc.opt.DefaultExecTimeout)
+	if dl, ok := ctx.Deadline(); ok && dl.Before(deadline) {
+		// Caller (eventbus handler timeout) will not wait for us longer than this
+		deadline = dl
+	}
+
+	ctx, cancel := context.WithDeadline(
 		// We need a new, independent context here
 		// to be sure this is executed safely & fully
-		// without any outside interfeance (cancellation, timeouts)
+		// without any outside interfeance (cancellation)
 		context.Background(),
-		svc.opt.DefaultExecTimeout,
+		deadline,
 	)
 	defer cancel()
 
+	if runner != nil {
+		// Embedded runtime uses identity from the context
+		// when script calls any of the registered functions
+		ctx = auth.SetIdentityToContext(ctx, runner)
+	}
+
 	// //// //// //// //// //// //// //// //// //// //// //// //// //// //// //// //// //// //// //// //// //// ////
 
 	ctx = metadata.NewOutgoingContext(ctx, metadata.MD{
@@ -749,6 +875,8 @@ func (svc service) exec(ctx context.Context, script string, runAs string, args S
 		grpc.Trailer(&trailer),
 	)
 
+	run.Logs = runLogs(append(header.Get(runLogsMetadataKey), trailer.Get(runLogsMetadataKey)...))
+
 	if err != nil {
 		// See if this was a "soft abort"
 		//
@@ -764,7 +892,13 @@ func (svc service) exec(ctx context.Context, script string, runAs string, args S
 				msg = "Aborted"
 			}
 
-			return errors.New(msg)
+			run.Status = RunAborted
+			return eventbus.Abort(msg)
+		}
+
+		run.Error = err.Error()
+		if s != nil {
+			run.Error = s.Message()
 		}
 
 		log.Warn("corredor responded with error", zap.Error(err))
@@ -804,6 +938,51 @@ func (svc service) exec(ctx context.Context, script string, runAs string, args S
 	return
 }
 
+// recordRun stores script run and notifies observers
+func (svc service) recordRun(run *Run, err error) {
+	run.Duration = uint(time.Since(run.CreatedAt) / time.Millisecond)
+
+	if run.Status == "" {
+		run.Status = RunSucceeded
+		if err != nil {
+			run.Status = RunFailed
+		}
+	}
+
+	if run.Error == "" && err != nil {
+		run.Error = err.Error()
+	}
+
+	for _, o := range svc.runObservers {
+		o(run)
+	}
+
+	if svc.runStore == nil || !svc.opt.RunLogEnabled {
+		return
+	}
+
+	// New context; execution context could be already canceled
+	if err = svc.runStore.Create(context.Background(), run); err != nil {
+		svc.log.Warn("could not record script run", zap.Error(err))
+	}
+}
+
+// purgeRuns removes script runs that are over retention limits
+func (svc service) purgeRuns(ctx context.Context) {
+	var before time.Time
+	if svc.opt.RunLogRetention > 0 {
+		before = time.Now().Add(-svc.opt.RunLogRetention)
+	}
+
+	if svc.opt.RunLogMaxEntries < 0 {
+		svc.opt.RunLogMaxEntries = 0
+	}
+
+	if err := svc.runStore.Purge(ctx, before, uint(svc.opt.RunLogMaxEntries)); err != nil {
+		svc.log.Warn("could not purge script runs", zap.Error(err))
+	}
+}
+
 func (svc *service) loadClientScripts(ctx context.Context) {
 	var (
 		err error
@@ -908,7 +1087,7 @@ func (svc *service) serverScriptSecurity(script *ServerScript) (sec *ScriptSecur
 }
 
 func (svc *service) GetBundle(ctx context.Context, name, bType string) *Bundle {
-	if !svc.opt.Enabled {
+	if !svc.opt.Enabled || svc.csClient == nil {
 		return nil
 	}
 
@@ -3,6 +3,9 @@ package corredor
 import (
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/scheduler"
 )
 
 type (
@@ -28,11 +31,26 @@ type (
 		Iterator    *Iterator       `json:"iterator"`
 		Security    *ScriptSecurity `json:"security"`
 
+		// Schedules of interval, timestamp and cron triggers (or iterator)
+		Schedules []*Schedule `json:"schedules,omitempty"`
+
 		// If bundle or type is set, consider
 		// this a frontend script
 		Bundle string `json:"bundle,omitempty"`
 		Type   string `json:"type,omitempty"`
 	}
+
+	// Schedule describes when scheduled script is executed
+	Schedule struct {
+		ResourceType string `json:"resourceType"`
+		EventType    string `json:"eventType"`
+
+		// Cron expressions or timestamps
+		Deferred []string `json:"deferred"`
+
+		// Set when scripts are listed
+		NextRunAt *time.Time `json:"nextRunAt,omitempty"`
+	}
 )
 
 // FindByName returns script from the set if it exists
@@ -59,3 +77,41 @@ func (ss *ScriptSecurity) String() (o string) {
 		strings.Join(ss.Deny, ","),
 	)
 }
+
+// withNextRuns returns copy of the script with next run time set on all schedules
+func (s Script) withNextRuns(now time.Time) *Script {
+	if len(s.Schedules) == 0 {
+		return &s
+	}
+
+	var ss = make([]*Schedule, len(s.Schedules))
+	for i := range s.Schedules {
+		sch := *s.Schedules[i]
+		sch.NextRunAt = sch.next(now)
+		ss[i] = &sch
+	}
+
+	s.Schedules = ss
+	return &s
+}
+
+// next returns time of the next run or nil if schedule does not fire again
+func (s Schedule) next(now time.Time) *time.Time {
+	var (
+		next time.Time
+		err  error
+	)
+
+	switch s.EventType {
+	case onIntervalEventType, onCronEventType:
+		next, err = scheduler.NextCron(now, s.Deferred...)
+	case onTimestampEventType:
+		next, err = scheduler.NextTimestamp(now, s.Deferred...)
+	}
+
+	if err != nil || next.IsZero() {
+		return nil
+	}
+
+	return &next
+}
@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/pkg/scheduler"
 	"github.com/cortezaproject/corteza-server/pkg/slice"
 	"github.com/pkg/errors"
 	"net/http"
+	"strings"
+	"time"
 )
 
 type (
@@ -82,6 +85,58 @@ func constraintsToHandlerOps(cc []*TConstraint) (oo []eventbus.HandlerRegOp, err
 	return
 }
 
+// scriptSchedules collects schedules from script's scheduled triggers or iterator
+//
+// Cron expressions are validated so that invalid schedules
+// are reported when script is registered
+func scriptSchedules(s *Script) (ss []*Schedule, err error) {
+	var add = func(resourceType, eventType string, deferred []string) error {
+		if eventType == onCronEventType {
+			if err := scheduler.ValidateCron(deferred...); err != nil {
+				return err
+			}
+		}
+
+		ss = append(ss, &Schedule{
+			ResourceType: resourceType,
+			EventType:    eventType,
+			Deferred:     deferred,
+		})
+
+		return nil
+	}
+
+	if i := s.Iterator; i != nil {
+		if slice.HasString(scheduledEventTypes, i.EventType) {
+			var service = i.ResourceType
+			if p := strings.Index(service, ":"); p > 0 {
+				service = service[0:p]
+			}
+
+			return ss, add(service, i.EventType, i.Deferred)
+		}
+
+		return
+	}
+
+	for _, t := range s.Triggers {
+		var deferred = make([]string, 0)
+		for _, c := range t.Constraints {
+			deferred = append(deferred, c.Value...)
+		}
+
+		for _, eventType := range slice.IntersectStrings(t.EventTypes, scheduledEventTypes) {
+			for _, resourceType := range t.ResourceTypes {
+				if err = add(resourceType, eventType, deferred); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return
+}
+
 // encode adds entry (with json encoded value) to hash map
 // used to prepare data for transmission
 func encodeArguments(args map[string]string, key string, val interface{}) (err error) {
@@ -100,8 +155,16 @@ func GenericListHandler(ctx context.Context, svc *service, f Filter, resourcePre
 	f.procRTPrefixes(resourcePrefix)
 
 	p = &automationListSetPayload{}
-	p.Set, p.Filter, err = svc.Find(ctx, f)
-	return p, err
+	if p.Set, p.Filter, err = svc.Find(ctx, f); err != nil {
+		return
+	}
+
+	var now = time.Now()
+	for i := range p.Set {
+		p.Set[i] = p.Set[i].withNextRuns(now)
+	}
+
+	return p, nil
 }
 
 func GenericBundleHandler(ctx context.Context, svc *service, bundleName, bundleType, ext string) (interface{}, error) {
@@ -3,6 +3,7 @@ package corredor
 import (
 	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -62,6 +63,18 @@ func TestTriggerOptsMaking(t *testing.T) {
 
 	oo, err = triggerToHandlerOps(&Trigger{EventTypes: []string{"foo"}})
 	a.Error(err, "expecting to fail on trigger w/o resources")
+
+	oo, err = constraintsToHandlerOps([]*TConstraint{
+		{Name: "record.values.amount", Op: ">", Value: []string{"10000"}},
+		{Name: "record.values.status", Op: "changedFrom", Value: []string{"draft"}},
+		{Name: "record.values.status", Op: "changed"},
+		{Name: "invoker", Op: "member of", Value: []string{"42"}},
+	})
+	a.NoError(err)
+	a.Len(oo, 4)
+
+	_, err = constraintsToHandlerOps([]*TConstraint{{Name: "record.values.amount", Op: ">", Value: []string{"many"}}})
+	a.Error(err, "expecting to fail on non-numeric comparison")
 }
 
 func TestArgEncoding(t *testing.T) {
@@ -84,3 +97,45 @@ func TestArgEncoding(t *testing.T) {
 		"obj": `{"A":"A"}`,
 	}, args)
 }
+
+func TestScriptSchedules(t *testing.T) {
+	var (
+		a = assert.New(t)
+
+		now, _ = time.Parse(time.RFC3339, "2020-06-01T10:00:00Z")
+	)
+
+	ss, err := scriptSchedules(&Script{
+		Triggers: []*Trigger{
+			{
+				ResourceTypes: []string{"compose"},
+				EventTypes:    []string{onCronEventType},
+				Constraints:   []*TConstraint{{Value: []string{"CRON_TZ=Europe/Berlin 0 14 * * *"}}},
+			},
+			{
+				ResourceTypes: []string{"compose:record"},
+				EventTypes:    []string{"afterCreate"},
+			},
+		},
+	})
+
+	a.NoError(err)
+	a.Len(ss, 1)
+	a.Equal(onCronEventType, ss[0].EventType)
+
+	s := (&Script{Schedules: ss}).withNextRuns(now)
+	a.Nil(ss[0].NextRunAt, "original script should not be modified")
+	a.NotNil(s.Schedules[0].NextRunAt)
+	a.Equal("2020-06-01T12:00:00Z", s.Schedules[0].NextRunAt.UTC().Format(time.RFC3339))
+
+	ss, err = scriptSchedules(&Script{
+		Iterator: &Iterator{
+			ResourceType: "compose:record",
+			EventType:    onCronEventType,
+			Deferred:     []string{"TZ=Mars/Olympus 0 14 * * *"},
+		},
+	})
+
+	a.Error(err)
+	a.Nil(ss)
+}
@@ -4,7 +4,12 @@ import (
 	"errors"
 	"path"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/auth"
 )
 
 type (
@@ -26,24 +31,70 @@ type (
 		values []*regexp.Regexp
 	}
 
+	// numeric (or RFC3339 time) comparison
+	mustCompare struct {
+		op     string
+		name   string
+		values []string
+	}
+
+	// compares values of the old and the new resource
+	mustChange struct {
+		not    bool
+		op     string
+		name   string
+		values []string
+	}
+
+	mustBeMemberOf struct {
+		not    bool
+		name   string
+		values []string
+	}
+
 	ConstraintMatcher interface {
 		Name() string
 		Values() []string
 		Match(value string) bool
 	}
 
+	// ChangeMatcher is implemented by constraints that match
+	// changes between old and new values (changed, changedFrom, changedTo)
+	ChangeMatcher interface {
+		ConstraintMatcher
+		MatchChange(old, new []string) bool
+	}
+
+	// collects all values passed to Match()
+	valueCapture struct {
+		name   string
+		values []string
+	}
+
 	constraintSet []ConstraintMatcher
 )
 
 var ErrUnsupportedOp = errors.New("operator not supported")
 var ErrUnsupportedName = errors.New("constraint name not supported")
+var ErrUnsupportedValue = errors.New("constraint value not supported by operator")
+
+const (
+	// Name of the constraint that matches user that triggered the event
+	invokerConstraint = "invoker"
+)
 
-func (c mustBeEqual) Name() string     { return c.name }
-func (c mustBeLike) Name() string      { return c.name }
-func (c mustMatch) Name() string       { return c.name }
-func (c mustBeEqual) Values() []string { return c.values }
-func (c mustBeLike) Values() []string  { return c.values }
-func (c mustMatch) Values() []string   { return nil }
+func (c mustBeEqual) Name() string        { return c.name }
+func (c mustBeLike) Name() string         { return c.name }
+func (c mustMatch) Name() string          { return c.name }
+func (c mustBeEqual) Values() []string    { return c.values }
+func (c mustBeLike) Values() []string     { return c.values }
+func (c mustMatch) Values() []string      { return nil }
+func (c mustCompare) Name() string        { return c.name }
+func (c mustChange) Name() string         { return c.name }
+func (c mustBeMemberOf) Name() string     { return c.name }
+func (c mustCompare) Values() []string    { return c.values }
+func (c mustChange) Values() []string     { return c.values }
+func (c mustBeMemberOf) Values() []string { return c.values }
 
 // Converts raw ConstraintMatcher into one of the
 // ConstraintMatcher handlers
@@ -61,6 +112,18 @@ func ConstraintMaker(name, op string, vv ...string) (ConstraintMatcher, error) {
 		return MustMatch(name, vv...)
 	case "!~":
 		return MustNotMatch(name, vv...)
+	case "in":
+		return MustBeEqual(name, vv...)
+	case "not in":
+		return MustNotBeEqual(name, vv...)
+	case ">", "gt", ">=", "gte", "<", "lt", "<=", "lte":
+		return MustCompare(name, op, vv...)
+	case "changed", "not changed", "changedfrom", "changed from", "changedto", "changed to":
+		return MustChange(name, op, vv...)
+	case "member of", "memberof":
+		return MustBeMemberOf(name, vv...)
+	case "not member of":
+		return MustNotBeMemberOf(name, vv...)
 	default:
 		return nil, ErrUnsupportedOp
 	}
@@ -167,6 +230,246 @@ func (c mustMatch) Match(value string) bool {
 	return c.not
 }
 
+// MustCompare compares (numeric or RFC3339 time) values with >, >=, < or <= operator
+func MustCompare(name, op string, vv ...string) (ConstraintMatcher, error) {
+	var c = &mustCompare{name: name, values: vv}
+
+	switch strings.ToLower(op) {
+	case ">", "gt":
+		c.op = ">"
+	case ">=", "gte":
+		c.op = ">="
+	case "<", "lt":
+		c.op = "<"
+	case "<=", "lte":
+		c.op = "<="
+	default:
+		return nil, ErrUnsupportedOp
+	}
+
+	if len(vv) == 0 {
+		return nil, ErrUnsupportedValue
+	}
+
+	for _, v := range vv {
+		if _, ok := compare(v, v); !ok {
+			return nil, ErrUnsupportedValue
+		}
+	}
+
+	return c, nil
+}
+
+// Matches if value compares to any of the constraint values
+func (c mustCompare) Match(value string) bool {
+	for _, v := range c.values {
+		cmp, ok := compare(value, v)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case c.op == ">" && cmp > 0,
+			c.op == ">=" && cmp >= 0,
+			c.op == "<" && cmp < 0,
+			c.op == "<=" && cmp <= 0:
+			return true
+		}
+	}
+
+	return false
+}
+
+// Compares two numbers or two RFC3339 timestamps
+//
+// Returns false when values can not be compared
+func compare(a, b string) (int, bool) {
+	if fa, err := strconv.ParseFloat(a, 64); err == nil {
+		if fb, err := strconv.ParseFloat(b, 64); err == nil {
+			switch {
+			case fa < fb:
+				return -1, true
+			case fa > fb:
+				return 1, true
+			}
+
+			return 0, true
+		}
+	}
+
+	if ta, err := time.Parse(time.RFC3339, a); err == nil {
+		if tb, err := time.Parse(time.RFC3339, b); err == nil {
+			switch {
+			case ta.Before(tb):
+				return -1, true
+			case ta.After(tb):
+				return 1, true
+			}
+
+			return 0, true
+		}
+	}
+
+	return 0, false
+}
+
+// MustChange matches changes between old & new values
+//
+// Operators:
+//   - changed, not changed (values are ignored)
+//   - changedFrom (old value was one of the values)
+//   - changedTo (new value is one of the values)
+func MustChange(name, op string, vv ...string) (ConstraintMatcher, error) {
+	var c = &mustChange{name: name, values: vv}
+
+	switch strings.ToLower(op) {
+	case "changed":
+		c.op = "changed"
+	case "not changed":
+		c.op, c.not = "changed", true
+	case "changedfrom", "changed from":
+		c.op = "changedFrom"
+	case "changedto", "changed to":
+		c.op = "changedTo"
+	default:
+		return nil, ErrUnsupportedOp
+	}
+
+	return c, nil
+}
+
+// Match always fails; change can only be matched with MatchChange()
+func (c mustChange) Match(string) bool {
+	return false
+}
+
+func (c mustChange) MatchChange(old, new []string) bool {
+	var (
+		changed = !sameValues(old, new)
+		in      = func(vv []string) bool {
+			if len(vv) == 0 {
+				// no value is matched as an empty value
+				vv = []string{""}
+			}
+
+			for _, v := range vv {
+				for _, cv := range c.values {
+					if v == cv {
+						return true
+					}
+				}
+			}
+
+			return false
+		}
+	)
+
+	switch c.op {
+	case "changedFrom":
+		return changed && in(old)
+	case "changedTo":
+		return changed && in(new)
+	}
+
+	return changed != c.not
+}
+
+func sameValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	a, b = append([]string{}, a...), append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MustBeMemberOf matches if one of the roles (IDs) is in the values
+func MustBeMemberOf(name string, vv ...string) (ConstraintMatcher, error) {
+	return &mustBeMemberOf{name: name, values: vv}, nil
+}
+
+// MustNotBeMemberOf matches if none of the roles (IDs) is in the values
+func MustNotBeMemberOf(name string, vv ...string) (ConstraintMatcher, error) {
+	return &mustBeMemberOf{name: name, values: vv, not: true}, nil
+}
+
+func (c mustBeMemberOf) Match(value string) bool {
+	return c.matchAny(value)
+}
+
+func (c mustBeMemberOf) matchAny(vv ...string) bool {
+	for _, v := range vv {
+		for _, cv := range c.values {
+			if v == cv {
+				return !c.not
+			}
+		}
+	}
+
+	return c.not
+}
+
+func (c *valueCapture) Name() string     { return c.name }
+func (c *valueCapture) Values() []string { return nil }
+func (c *valueCapture) Match(value string) bool {
+	c.values = append(c.values, value)
+
+	// Never match so that all values are collected
+	return false
+}
+
+// MatchChange matches change constraints
+//
+// Old and new values are collected by calling old and new functions
+// (resource matchers of the event) with the constraint's name
+//
+// Returns false for constraints that do not match changes
+func MatchChange(c ConstraintMatcher, old, new func(ConstraintMatcher) bool) bool {
+	cm, ok := c.(ChangeMatcher)
+	if !ok {
+		return false
+	}
+
+	var (
+		o = &valueCapture{name: c.Name()}
+		n = &valueCapture{name: c.Name()}
+	)
+
+	old(o)
+	new(n)
+
+	return cm.MatchChange(o.values, n.values)
+}
+
+// MatchInvoker matches "invoker" constraint against user that triggered the event
+//
+// Invoker is matched by user ID or, with member of operators, by role IDs
+func MatchInvoker(i auth.Identifiable, c ConstraintMatcher) bool {
+	if c.Name() != invokerConstraint || i == nil {
+		return false
+	}
+
+	if m, ok := c.(*mustBeMemberOf); ok {
+		var rr = make([]string, 0, len(i.Roles()))
+		for _, r := range i.Roles() {
+			rr = append(rr, strconv.FormatUint(r, 10))
+		}
+
+		return m.matchAny(rr...)
+	}
+
+	return c.Match(strconv.FormatUint(i.Identity(), 10))
+}
+
 func MatchFirst(checks ...func() bool) bool {
 	for _, check := range checks {
 		if check() {
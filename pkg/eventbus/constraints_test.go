@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/cortezaproject/corteza-server/pkg/auth"
 )
 
 func TestConstraintMaking(t *testing.T) {
@@ -96,3 +98,92 @@ func TestMustNotMatch(t *testing.T) {
 	a.False(c.Match("fooBAZ"))
 	a.True(c.Match("barBAZ"))
 }
+
+func TestMustCompare(t *testing.T) {
+	var (
+		a = assert.New(t)
+	)
+
+	_, err := MustCompare("", ">")
+	a.Error(err)
+
+	_, err = MustCompare("", ">", "foo")
+	a.Error(err)
+
+	a.True(MustMakeConstraint("", ">", "10000").Match("10000.5"))
+	a.False(MustMakeConstraint("", ">", "10000").Match("10000"))
+	a.True(MustMakeConstraint("", ">=", "10000").Match("10000"))
+	a.True(MustMakeConstraint("", "lt", "-1").Match("-2"))
+	a.False(MustMakeConstraint("", "<=", "5").Match("not a number"))
+	a.True(MustMakeConstraint("", "<", "2020-06-01T00:00:00Z").Match("2020-05-31T23:59:59Z"))
+	a.False(MustMakeConstraint("", "<", "2020-06-01T00:00:00Z").Match("10"))
+}
+
+func TestMustBeIn(t *testing.T) {
+	var (
+		a = assert.New(t)
+	)
+
+	a.True(MustMakeConstraint("", "in", "a", "b").Match("b"))
+	a.False(MustMakeConstraint("", "in", "a", "b").Match("c"))
+	a.True(MustMakeConstraint("", "not in", "a", "b").Match("c"))
+}
+
+func TestMustChange(t *testing.T) {
+	var (
+		a = assert.New(t)
+
+		match = func(op string, old, new []string, vv ...string) bool {
+			return MustMakeConstraint("", op, vv...).(ChangeMatcher).MatchChange(old, new)
+		}
+	)
+
+	a.True(match("changed", []string{"a"}, []string{"b"}))
+	a.False(match("changed", []string{"a", "b"}, []string{"b", "a"}))
+	a.True(match("not changed", []string{"a"}, []string{"a"}))
+	a.True(match("changedFrom", []string{"draft"}, []string{"sent"}, "draft"))
+	a.False(match("changedFrom", []string{"draft"}, []string{"draft"}, "draft"))
+	a.True(match("changed from", nil, []string{"sent"}, ""))
+	a.True(match("changedTo", []string{"draft"}, []string{"sent"}, "sent"))
+	a.False(match("changedTo", []string{"draft"}, []string{"sent"}, "draft"))
+
+	a.False(MustMakeConstraint("", "changed").Match("a"))
+}
+
+func TestMatchChange(t *testing.T) {
+	var (
+		a = assert.New(t)
+
+		values = func(vv ...string) func(ConstraintMatcher) bool {
+			return func(c ConstraintMatcher) bool {
+				for _, v := range vv {
+					if c.Match(v) {
+						return true
+					}
+				}
+
+				return false
+			}
+		}
+	)
+
+	a.True(MatchChange(MustMakeConstraint("status", "changed"), values("a"), values("a", "b")))
+	a.False(MatchChange(MustMakeConstraint("status", "changed"), values("a", "b"), values("a", "b")))
+	a.False(MatchChange(MustMakeConstraint("status", "="), values("a"), values("b")))
+}
+
+func TestMatchInvoker(t *testing.T) {
+	var (
+		a       = assert.New(t)
+		invoker = auth.NewIdentity(42, 1, 2)
+	)
+
+	a.True(MatchInvoker(invoker, MustMakeConstraint("invoker", "=", "42")))
+	a.False(MatchInvoker(invoker, MustMakeConstraint("invoker", "=", "43")))
+	a.True(MatchInvoker(invoker, MustMakeConstraint("invoker", "member of", "2", "3")))
+	a.False(MatchInvoker(invoker, MustMakeConstraint("invoker", "member of", "3")))
+	a.True(MatchInvoker(invoker, MustMakeConstraint("invoker", "not member of", "3")))
+	a.False(MatchInvoker(invoker, MustMakeConstraint("invoker", "not member of", "1")))
+	a.False(MatchInvoker(invoker, MustMakeConstraint("user", "=", "42")))
+	a.False(MatchInvoker(nil, MustMakeConstraint("invoker", "=", "42")))
+}
@@ -1,14 +1,149 @@
 package eventbus
 
+import (
+	"sort"
+	"time"
+	"unsafe"
+)
+
+type (
+	// HandlerInfo describes registered handler, it's options and stats
+	HandlerInfo struct {
+		ID            uint64           `json:"handlerID,string"`
+		Name          string           `json:"name"`
+		ResourceTypes []string         `json:"resourceTypes"`
+		EventTypes    []string         `json:"eventTypes"`
+		Constraints   []ConstraintInfo `json:"constraints"`
+		Weight        int              `json:"weight"`
+
+		// Effective options (handler's own or bus defaults)
+		Timeout          uint `json:"timeout"` // in milliseconds
+		BreakerThreshold int  `json:"breakerThreshold"`
+		BreakerCooldown  uint `json:"breakerCooldown"` // in milliseconds
+
+		Calls   uint `json:"calls"`
+		Errors  uint `json:"errors"`
+		Latency uint `json:"latency"` // average, in milliseconds
+
+		// Consecutive failures and time until handler is disabled by the circuit breaker
+		Failures      int        `json:"failures"`
+		DisabledUntil *time.Time `json:"disabledUntil,omitempty"`
+	}
+
+	ConstraintInfo struct {
+		Name   string   `json:"name"`
+		Op     string   `json:"op"`
+		Values []string `json:"values"`
+	}
+)
+
 // Debug returns structured debug data
 func (b *eventbus) Debug() interface{} {
+	return b.Handlers()
+}
+
+// Handlers returns info on all registered handlers, ordered by weight
+func (b *eventbus) Handlers() []HandlerInfo {
+	b.l.RLock()
+	defer b.l.RUnlock()
+
 	var (
-		o = make([]*handler, 0, len(b.handlers))
+		hh = make(HandlerSet, 0, len(b.handlers))
+		ii = make([]HandlerInfo, 0, len(b.handlers))
 	)
 
 	for _, h := range b.handlers {
-		o = append(o, h)
+		hh = append(hh, h)
+	}
+
+	sort.Stable(hh)
+
+	for _, h := range hh {
+		ii = append(ii, b.opt.info(h))
+	}
+
+	return ii
+}
+
+func (opt busOptions) info(h *handler) HandlerInfo {
+	var (
+		timeout, threshold, cooldown = opt.effective(h)
+
+		i = HandlerInfo{
+			ID:               uint64(uintptr(unsafe.Pointer(h))),
+			Name:             h.name,
+			ResourceTypes:    setKeys(h.resourceTypes),
+			EventTypes:       setKeys(h.eventTypes),
+			Constraints:      make([]ConstraintInfo, 0, len(h.constraints)),
+			Weight:           h.weight,
+			Timeout:          uint(timeout / time.Millisecond),
+			BreakerThreshold: threshold,
+			BreakerCooldown:  uint(cooldown / time.Millisecond),
+		}
+	)
+
+	for _, c := range h.constraints {
+		i.Constraints = append(i.Constraints, constraintInfo(c))
+	}
+
+	if h.state != nil {
+		h.state.l.Lock()
+		defer h.state.l.Unlock()
+
+		i.Calls, i.Errors, i.Failures = h.state.calls, h.state.errors, h.state.failures
+		if i.Calls > 0 {
+			i.Latency = uint(h.state.latency / time.Duration(i.Calls) / time.Millisecond)
+		}
+
+		if time.Now().Before(h.state.disabledUntil) {
+			du := h.state.disabledUntil
+			i.DisabledUntil = &du
+		}
+	}
+
+	return i
+}
+
+// Converts constraint back into name, operator & values
+func constraintInfo(c ConstraintMatcher) ConstraintInfo {
+	var (
+		i = ConstraintInfo{Name: c.Name(), Values: c.Values()}
+
+		not = func(n bool, op, neg string) string {
+			if n {
+				return neg
+			}
+			return op
+		}
+	)
+
+	switch c := c.(type) {
+	case *mustBeEqual:
+		i.Op = not(c.not, "=", "!=")
+	case *mustBeLike:
+		i.Op = not(c.not, "like", "not like")
+	case *mustMatch:
+		i.Op = not(c.not, "~", "!~")
+		for _, r := range c.values {
+			i.Values = append(i.Values, r.String())
+		}
+	case *mustCompare:
+		i.Op = c.op
+	case *mustChange:
+		i.Op = not(c.not, c.op, "not "+c.op)
+	case *mustBeMemberOf:
+		i.Op = not(c.not, "member of", "not member of")
+	}
+
+	return i
+}
+
+func setKeys(m map[string]bool) []string {
+	var kk = make([]string, 0, len(m))
+	for k := range m {
+		kk = append(kk, k)
 	}
 
-	return o
+	sort.Strings(kk)
+	return kk
 }
@@ -2,8 +2,11 @@ package eventbus
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sort"
 	"sync"
+	"time"
 	"unsafe"
 )
 
@@ -29,7 +32,34 @@ type (
 
 		// list of registered handlers
 		handlers map[uintptr]*handler
+
+		opt busOptions
 	}
+
+	busOptions struct {
+		// Defaults for handlers registered w/o timeout or circuit breaker options
+		timeout   time.Duration
+		threshold int
+		cooldown  time.Duration
+
+		observers []HandlerObserver
+	}
+
+	// HandlerObserver is notified after each handler call
+	HandlerObserver func(name string, ev Event, d time.Duration, err error)
+
+	// handler abort (soft error); it does not count as a handler failure
+	abort struct {
+		msg string
+	}
+)
+
+var (
+	ErrHandlerTimeout = errors.New("event handler timed out")
+
+	// Returned (by WaitFor) for handlers disabled by the circuit breaker;
+	// caller must not proceed as if (eg validation) handler succeeded
+	ErrHandlerDisabled = errors.New("event handler disabled by circuit breaker")
 )
 
 var (
@@ -58,17 +88,57 @@ func New() *eventbus {
 	}
 }
 
+// Abort returns error that handlers use to stop the event (eg: prevent record from being saved)
+//
+// Aborts are not counted as handler failures
+func Abort(msg string) error {
+	return &abort{msg: msg}
+}
+
+// IsAbort checks if error was returned via Abort()
+func IsAbort(err error) bool {
+	_, ok := err.(*abort)
+	return ok
+}
+
+func (a abort) Error() string {
+	return a.msg
+}
+
+// SetDefaults sets timeout and circuit breaker options for handlers registered without them
+//
+// Zero or negative timeout or threshold disables timeouts or circuit breaker
+func (b *eventbus) SetDefaults(timeout time.Duration, threshold int, cooldown time.Duration) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.opt.timeout, b.opt.threshold, b.opt.cooldown = timeout, threshold, cooldown
+}
+
+// AddObserver registers function that is called after each handler call
+func (b *eventbus) AddObserver(o HandlerObserver) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.opt.observers = append(b.opt.observers, o)
+}
+
 // WaitFor is synchronous event dispatcher
 //
 // It waits for all handlers and fails on first error
 func (b *eventbus) WaitFor(ctx context.Context, ev Event) (err error) {
 	b.l.RLock()
 	defer b.l.RUnlock()
+
+	// Invoker is set before handlers are matched (constraints on invoker)
+	// and not in (possibly abandoned) handlers
+	setInvoker(ctx, ev)
+
 	for _, t := range b.find(ev) {
 		err = func(ctx context.Context, t *handler) error {
 			b.wg.Add(1)
 			defer b.wg.Done()
-			return t.Handle(ctx, ev)
+			return b.opt.handle(ctx, t, ev)
 
 		}(ctx, t)
 
@@ -84,15 +154,99 @@ func (b *eventbus) WaitFor(ctx context.Context, ev Event) (err error) {
 func (b *eventbus) Dispatch(ctx context.Context, ev Event) {
 	b.l.RLock()
 	defer b.l.RUnlock()
+
+	setInvoker(ctx, ev)
+
 	for _, t := range b.find(ev) {
 		b.wg.Add(1)
-		go func(ctx context.Context, t *handler) {
+		go func(ctx context.Context, t *handler, opt busOptions) {
 			defer b.wg.Done()
-			_ = t.Handle(ctx, ev)
-		}(ctx, t)
+			_ = opt.handle(ctx, t, ev)
+		}(ctx, t, b.opt)
 	}
 }
 
+// Calls handler with timeout and circuit breaker
+//
+// Handlers disabled by the circuit breaker are not called, ErrHandlerDisabled is returned instead
+func (opt busOptions) handle(ctx context.Context, t *handler, ev Event) (err error) {
+	var (
+		timeout, threshold, cooldown = opt.effective(t)
+
+		started = time.Now()
+	)
+
+	if t.state.disabled(started) {
+		return ErrHandlerDisabled
+	}
+
+	err = t.exec(ctx, ev, timeout)
+
+	d := time.Since(started)
+	t.state.done(d, err != nil && !IsAbort(err), threshold, cooldown)
+
+	for _, o := range opt.observers {
+		o(t.name, ev, d, err)
+	}
+
+	return
+}
+
+// Returns timeout & circuit breaker options for the handler
+// with fallback to bus defaults
+func (opt busOptions) effective(t *handler) (timeout time.Duration, threshold int, cooldown time.Duration) {
+	timeout, threshold, cooldown = opt.timeout, opt.threshold, opt.cooldown
+
+	if t.timeout != 0 {
+		timeout = t.timeout
+	}
+
+	if t.threshold != 0 {
+		threshold, cooldown = t.threshold, t.cooldown
+	}
+
+	return
+}
+
+// Runs handler isolated from the caller
+//
+// Panics are converted into errors and handler is abandoned
+// (with cancelled context) when it does not finish in time
+func (t handler) exec(ctx context.Context, ev Event, timeout time.Duration) error {
+	if timeout <= 0 {
+		return t.safeHandle(ctx, ev)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var done = make(chan error, 1)
+	go func() {
+		done <- t.safeHandle(ctx, ev)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			return ErrHandlerTimeout
+		}
+
+		return ctx.Err()
+	}
+}
+
+func (t handler) safeHandle(ctx context.Context, ev Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("event handler panicked: %v", r)
+		}
+	}()
+
+	return t.handler(ctx, ev)
+}
+
 // Waits for all dispatched events
 //
 // Should only be used for testing
@@ -137,6 +291,8 @@ func (b *eventbus) Register(h HandlerFn, ops ...HandlerRegOp) uintptr {
 		ptr      = uintptr(unsafe.Pointer(handlers))
 	)
 
+	handlers.state = &handlerState{}
+
 	b.handlers[ptr] = handlers
 	return ptr
 }
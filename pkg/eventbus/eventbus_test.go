@@ -107,3 +107,179 @@ func TestEventFiring(t *testing.T) {
 	bus.wait()
 	a.Equal(int32(2), i.Load())
 }
+
+func TestEventbusHandlerTimeout(t *testing.T) {
+	var (
+		a   = assert.New(t)
+		ctx = context.Background()
+		ev  = &mockEvent{rType: "resource", eType: "slow"}
+		bus = New()
+	)
+
+	bus.Register(func(ctx context.Context, ev Event) error {
+		<-ctx.Done()
+		return nil
+	}, On("slow"), For("resource"), Timeout(10*time.Millisecond))
+
+	a.Equal(ErrHandlerTimeout, bus.WaitFor(ctx, ev))
+
+	// Bus default applies to handlers w/o their own timeout
+	bus = New()
+	bus.SetDefaults(10*time.Millisecond, 0, 0)
+	bus.Register(func(ctx context.Context, ev Event) error {
+		<-ctx.Done()
+		return nil
+	}, On("slow"), For("resource"))
+
+	a.Equal(ErrHandlerTimeout, bus.WaitFor(ctx, ev))
+}
+
+func TestEventbusHandlerIsolation(t *testing.T) {
+	var (
+		a   = assert.New(t)
+		ctx = context.Background()
+		bus = New()
+	)
+
+	bus.Register(func(ctx context.Context, ev Event) error {
+		panic("oops")
+	}, On("panic"), For("resource"), Timeout(time.Second))
+
+	bus.Register(func(ctx context.Context, ev Event) error {
+		panic("oops")
+	}, On("panic"), For("resource"), Timeout(-1))
+
+	a.EqualError(bus.WaitFor(ctx, &mockEvent{rType: "resource", eType: "panic"}), "event handler panicked: oops")
+}
+
+func TestEventbusCircuitBreaker(t *testing.T) {
+	var (
+		a     = assert.New(t)
+		ctx   = context.Background()
+		ev    = &mockEvent{rType: "resource", eType: "err"}
+		bus   = New()
+		calls = 0
+		fail  = true
+	)
+
+	bus.Register(func(ctx context.Context, ev Event) error {
+		calls++
+		if fail {
+			return fmt.Errorf("handl-err")
+		}
+
+		return Abort("aborted")
+	}, On("err"), For("resource"), CircuitBreaker(2, time.Hour))
+
+	a.Error(bus.WaitFor(ctx, ev))
+	a.Error(bus.WaitFor(ctx, ev))
+
+	// Handler is now disabled
+	a.Equal(ErrHandlerDisabled, bus.WaitFor(ctx, ev))
+	a.Equal(2, calls)
+
+	hh := bus.Handlers()
+	a.Len(hh, 1)
+	a.Equal(uint(2), hh[0].Calls)
+	a.Equal(uint(2), hh[0].Errors)
+	a.NotNil(hh[0].DisabledUntil)
+
+	// Aborts do not count as failures
+	for _, h := range bus.handlers {
+		h.state.disabledUntil = time.Time{}
+	}
+
+	fail = false
+	for i := 0; i < 3; i++ {
+		err := bus.WaitFor(ctx, ev)
+		a.True(IsAbort(err))
+		a.EqualError(err, "aborted")
+	}
+
+	a.Equal(5, calls)
+}
+
+func TestEventbusCircuitBreaker_failClosed(t *testing.T) {
+	var (
+		a     = assert.New(t)
+		ctx   = context.Background()
+		ev    = &mockEvent{rType: "compose:record", eType: "beforeCreate"}
+		bus   = New()
+		calls = atomic.NewInt32(0)
+	)
+
+	// validation script that can not be reached
+	bus.Register(func(ctx context.Context, ev Event) error {
+		calls.Inc()
+		return fmt.Errorf("corredor unavailable")
+	}, On("beforeCreate"), For("compose:record"), CircuitBreaker(2, time.Hour))
+
+	a.Error(bus.WaitFor(ctx, ev))
+	a.Error(bus.WaitFor(ctx, ev))
+
+	// Tripped handler must not let the record through
+	a.Equal(ErrHandlerDisabled, bus.WaitFor(ctx, ev))
+
+	// Async dispatch skips it
+	bus.Dispatch(ctx, ev)
+	bus.wg.Wait()
+
+	a.Equal(int32(2), calls.Load())
+}
+
+func TestEventbusObserver(t *testing.T) {
+	var (
+		a        = assert.New(t)
+		ctx      = context.Background()
+		bus      = New()
+		observed []string
+	)
+
+	bus.AddObserver(func(name string, ev Event, d time.Duration, err error) {
+		observed = append(observed, fmt.Sprintf("%s:%s:%v", name, ev.EventType(), err))
+	})
+
+	bus.Register(func(ctx context.Context, ev Event) error { return nil }, On("ok"), For("resource"), Name("/ok.js"))
+	bus.Register(func(ctx context.Context, ev Event) error { return fmt.Errorf("handl-err") }, On("err"), For("resource"), Name("/err.js"))
+
+	_ = bus.WaitFor(ctx, &mockEvent{rType: "resource", eType: "ok"})
+	_ = bus.WaitFor(ctx, &mockEvent{rType: "resource", eType: "err"})
+
+	a.Equal([]string{"/ok.js:ok:<nil>", "/err.js:err:handl-err"}, observed)
+}
+
+func TestEventbusHandlers(t *testing.T) {
+	var (
+		a   = assert.New(t)
+		bus = New()
+	)
+
+	bus.SetDefaults(time.Minute, 5, time.Second)
+	bus.Register(nil, Name("second"), Weight(2), For("b", "a"), On("x"))
+	bus.Register(nil, Name("first"), Weight(1), For("a"), On("y", "x"),
+		Timeout(time.Second),
+		Constraint(MustMakeConstraint("module", "!=", "foo")),
+		Constraint(MustMakeConstraint("handle", "~", "^ba[rz]$")),
+		Constraint(MustMakeConstraint("name", "not like", "foo%")),
+	)
+
+	hh := bus.Handlers()
+	a.Len(hh, 2)
+
+	a.Equal("first", hh[0].Name)
+	a.Equal([]string{"a"}, hh[0].ResourceTypes)
+	a.Equal([]string{"x", "y"}, hh[0].EventTypes)
+	a.Equal(uint(1000), hh[0].Timeout)
+	a.Equal(5, hh[0].BreakerThreshold)
+	a.Equal([]ConstraintInfo{
+		{Name: "module", Op: "!=", Values: []string{"foo"}},
+		{Name: "handle", Op: "~", Values: []string{"^ba[rz]$"}},
+		{Name: "name", Op: "not like", Values: []string{"foo*"}},
+	}, hh[0].Constraints)
+	a.NotZero(hh[0].ID)
+
+	a.Equal("second", hh[1].Name)
+	a.Equal([]string{"a", "b"}, hh[1].ResourceTypes)
+	a.Equal(uint(60000), hh[1].Timeout)
+	a.Nil(hh[1].DisabledUntil)
+}
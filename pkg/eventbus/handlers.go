@@ -2,6 +2,8 @@ package eventbus
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/cortezaproject/corteza-server/pkg/auth"
 	"github.com/cortezaproject/corteza-server/pkg/sentry"
@@ -16,6 +18,33 @@ type (
 		eventTypes    map[string]bool
 		constraints   constraintSet
 		weight        int
+
+		// Name of the handler (script name, service, ...) used for debugging & metrics
+		name string
+
+		// How long can handler run before it's context is cancelled
+		// bus default is used when zero, negative value disables timeout
+		timeout time.Duration
+
+		// How many consecutive failures disable the handler and for how long
+		// bus defaults are used when zero, negative threshold disables the breaker
+		threshold int
+		cooldown  time.Duration
+
+		// Runtime state (stats, circuit breaker); set when handler is registered
+		state *handlerState
+	}
+
+	handlerState struct {
+		l sync.Mutex
+
+		// consecutive failures and time until handler is disabled
+		failures      int
+		disabledUntil time.Time
+
+		calls   uint
+		errors  uint
+		latency time.Duration
 	}
 
 	// @todo add sorting interface
@@ -59,11 +88,18 @@ func (t handler) Match(re Event) bool {
 func (t handler) Handle(ctx context.Context, ev Event) error {
 	defer sentry.Recover()
 
+	setInvoker(ctx, ev)
+	return t.handler(ctx, ev)
+}
+
+func setInvoker(ctx context.Context, ev Event) {
+	if ctx == nil {
+		return
+	}
+
 	if eis, ok := ev.(eventInvokerSettable); ok {
 		eis.SetInvoker(auth.GetIdentityFromContext(ctx))
 	}
-
-	return t.handler(ctx, ev)
 }
 
 func NewHandler(h HandlerFn, ops ...HandlerRegOp) *handler {
@@ -108,6 +144,55 @@ func Weight(weight int) HandlerRegOp {
 	}
 }
 
+func Name(name string) HandlerRegOp {
+	return func(t *handler) {
+		t.name = name
+	}
+}
+
+func Timeout(timeout time.Duration) HandlerRegOp {
+	return func(t *handler) {
+		t.timeout = timeout
+	}
+}
+
+// CircuitBreaker disables handler for cooldown duration after threshold consecutive failures
+func CircuitBreaker(threshold int, cooldown time.Duration) HandlerRegOp {
+	return func(t *handler) {
+		t.threshold = threshold
+		t.cooldown = cooldown
+	}
+}
+
+// disabled returns true while circuit breaker is open
+func (s *handlerState) disabled(now time.Time) bool {
+	s.l.Lock()
+	defer s.l.Unlock()
+	return now.Before(s.disabledUntil)
+}
+
+// done updates stats and opens the breaker after threshold consecutive failures
+func (s *handlerState) done(d time.Duration, failed bool, threshold int, cooldown time.Duration) {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	s.calls++
+	s.latency += d
+
+	if !failed {
+		s.failures = 0
+		return
+	}
+
+	s.errors++
+	s.failures++
+
+	if threshold > 0 && s.failures >= threshold {
+		s.failures = 0
+		s.disabledUntil = time.Now().Add(cooldown)
+	}
+}
+
 // handler sorting:
 
 func (set HandlerSet) Len() int           { return len(set) }
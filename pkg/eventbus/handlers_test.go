@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -142,6 +143,11 @@ func TestHandler_RegOps(t *testing.T) {
 			makeTestHandler(&handler{weight: 42}),
 			[]HandlerRegOp{Weight(42)},
 		},
+		{
+			"name, timeout & circuit breaker",
+			makeTestHandler(&handler{name: "foo", timeout: time.Second, threshold: 3, cooldown: time.Minute}),
+			[]HandlerRegOp{Name("foo"), Timeout(time.Second), CircuitBreaker(3, time.Minute)},
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
@@ -0,0 +1,67 @@
+package jobs
+
+// 	Hello! This file is auto-generated.
+
+type (
+
+	// JobSet slice of Job
+	//
+	// This type is auto-generated.
+	JobSet []*Job
+)
+
+// Walk iterates through every slice item and calls w(Job) err
+//
+// This function is auto-generated.
+func (set JobSet) Walk(w func(*Job) error) (err error) {
+	for i := range set {
+		if err = w(set[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Filter iterates through every slice item, calls f(Job) (bool, err) and return filtered slice
+//
+// This function is auto-generated.
+func (set JobSet) Filter(f func(*Job) (bool, error)) (out JobSet, err error) {
+	var ok bool
+	out = JobSet{}
+	for i := range set {
+		if ok, err = f(set[i]); err != nil {
+			return
+		} else if ok {
+			out = append(out, set[i])
+		}
+	}
+
+	return
+}
+
+// FindByID finds items from slice by its ID property
+//
+// This function is auto-generated.
+func (set JobSet) FindByID(ID uint64) *Job {
+	for i := range set {
+		if set[i].ID == ID {
+			return set[i]
+		}
+	}
+
+	return nil
+}
+
+// IDs returns a slice of uint64s from all items in the set
+//
+// This function is auto-generated.
+func (set JobSet) IDs() (IDs []uint64) {
+	IDs = make([]uint64, len(set))
+
+	for i := range set {
+		IDs[i] = set[i].ID
+	}
+
+	return
+}
@@ -0,0 +1,138 @@
+package jobs
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/pkg/errors"
+
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	// Job is a single long-running operation (import, export...) executed by one of the workers
+	Job struct {
+		ID uint64 `json:"jobID,string" db:"id"`
+
+		// Service (compose, system...) and kind of the job (record.import, record.export...)
+		Service string `json:"service" db:"service"`
+		Kind    string `json:"kind"    db:"kind"`
+		Status  Status `json:"status"  db:"status"`
+
+		// Parameters as passed on enqueue
+		Params types.JSONText `json:"params" db:"params"`
+
+		// Position where interrupted job can continue (as stored by the worker)
+		Checkpoint types.JSONText `json:"checkpoint,omitempty" db:"checkpoint"`
+
+		// Progress as reported by the worker
+		Total  uint   `json:"total"  db:"total"`
+		Done   uint   `json:"done"   db:"done"`
+		Failed uint   `json:"failed" db:"failed"`
+		Error  string `json:"error"  db:"error"`
+
+		Attempts        uint `json:"attempts"        db:"attempts"`
+		MaxAttempts     uint `json:"maxAttempts"     db:"max_attempts"`
+		CancelRequested bool `json:"cancelRequested" db:"cancel_requested"`
+
+		// Result (file) can be downloaded when job is finished
+		ResultName string `json:"resultName,omitempty" db:"result_name"`
+		ResultType string `json:"resultType,omitempty" db:"result_type"`
+		ResultSize uint   `json:"resultSize,omitempty" db:"result_size"`
+
+		// Node that is running (or was the last to run) the job;
+		// cleared when stale job is put back to queue
+		Node string `json:"node,omitempty" db:"node"`
+
+		// Owner of the job; worker runs with owner's identity
+		OwnedBy    uint64   `json:"ownedBy,string" db:"owned_by"`
+		OwnerRoles JobRoles `json:"-"              db:"owner_roles"`
+
+		RunAfter   time.Time  `json:"runAfter"             db:"run_after"`
+		CreatedAt  time.Time  `json:"createdAt"            db:"created_at"`
+		StartedAt  *time.Time `json:"startedAt,omitempty"  db:"started_at"`
+		FinishedAt *time.Time `json:"finishedAt,omitempty" db:"finished_at"`
+		UpdatedAt  *time.Time `json:"updatedAt,omitempty"  db:"updated_at"`
+	}
+
+	JobFilter struct {
+		Service string `json:"service"`
+		Kind    string `json:"kind"`
+		Status  Status `json:"status"`
+		OwnedBy uint64 `json:"ownedBy,string"`
+
+		Sort string `json:"sort"`
+
+		// Standard paging fields & helpers
+		rh.PageFilter
+	}
+
+	Status string
+
+	JobRoles []uint64
+)
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+// Finished returns true when job will not run (again)
+func (j Job) Finished() bool {
+	switch j.Status {
+	case StatusSucceeded, StatusFailed, StatusCancelled:
+		return true
+	}
+
+	return false
+}
+
+// Identity returns identity of the job owner
+func (j Job) Identity() auth.Identifiable {
+	return auth.NewIdentity(j.OwnedBy, j.OwnerRoles...)
+}
+
+// DecodeParams unmarshals job parameters into given value
+func (j Job) DecodeParams(v interface{}) error {
+	return errors.Wrap(json.Unmarshal(j.Params, v), "could not decode job parameters")
+}
+
+// DecodeCheckpoint unmarshals checkpoint into given value
+//
+// Value is left untouched when job has no checkpoint
+func (j Job) DecodeCheckpoint(v interface{}) error {
+	if len(j.Checkpoint) == 0 {
+		return nil
+	}
+
+	return errors.Wrap(json.Unmarshal(j.Checkpoint, v), "could not decode job checkpoint")
+}
+
+func (rr *JobRoles) Scan(value interface{}) error {
+	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
+	switch value.(type) {
+	case nil:
+		*rr = JobRoles{}
+	case []uint8:
+		b := value.([]byte)
+		if err := json.Unmarshal(b, rr); err != nil {
+			return errors.Wrapf(err, "Can not scan '%v' into JobRoles", string(b))
+		}
+	}
+
+	return nil
+}
+
+func (rr JobRoles) Value() (driver.Value, error) {
+	if rr == nil {
+		rr = JobRoles{}
+	}
+
+	return json.Marshal(rr)
+}
@@ -0,0 +1,636 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/cortezaproject/corteza-server/pkg/app/options"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/sentry"
+	filestore "github.com/cortezaproject/corteza-server/pkg/store"
+)
+
+type (
+	// Worker runs the job
+	//
+	// Context is cancelled when job is cancelled or when service is stopped.
+	// Worker reports progress and stores results through the reporter
+	Worker func(ctx context.Context, job *Job, r Reporter) error
+
+	Reporter interface {
+		// Progress sets number of all, done and failed items
+		Progress(total, done, failed uint)
+
+		// Input returns input data of the job (eg: uploaded file)
+		Input() ([]byte, error)
+
+		// Result stores a file that can be downloaded when job is finished
+		//
+		// File is streamed to the file store; it is not kept in memory
+		Result(name, contentType string, data io.Reader) error
+
+		// Checkpoint stores position where job can continue if it's interrupted
+		//
+		// Job's progress is stored together with the checkpoint
+		Checkpoint(v interface{}) error
+	}
+
+	service struct {
+		log  *zap.Logger
+		opt  options.JobsOpt
+		node string
+
+		// Read & write locking
+		l *sync.RWMutex
+
+		store Store
+
+		// Keeps job results (files)
+		files filestore.Store
+
+		// Registered workers, indexed by service and job kind
+		workers map[string]map[string]Worker
+
+		// Jobs running on this node
+		running map[uint64]*reporter
+
+		wg       *sync.WaitGroup
+		started  bool
+		purgedAt time.Time
+	}
+
+	reporter struct {
+		l   sync.Mutex
+		svc *service
+		job *Job
+
+		cancel    context.CancelFunc
+		cancelled bool
+	}
+
+	// Counts bytes of the stored result
+	countingReader struct {
+		io.Reader
+		size uint
+	}
+)
+
+const (
+	// How often are finished jobs purged
+	purgeInterval = time.Hour
+)
+
+var (
+	now = func() time.Time { return time.Now() }
+
+	// Global job service
+	gJobs *service
+
+	ErrNotAvailable   = errors.New("job queue not available")
+	ErrNoWorker       = errors.New("no worker registered for this kind of job")
+	ErrJobFinished    = errors.New("job already finished")
+	ErrJobNotFinished = errors.New("job not finished")
+	ErrJobSucceeded   = errors.New("job already succeeded")
+	ErrNoFileStore    = errors.New("job result store not available")
+)
+
+// Setup configures global job service
+func Setup(log *zap.Logger, opt options.JobsOpt) {
+	if gJobs != nil {
+		// Prevent multiple initializations
+		return
+	}
+
+	gJobs = NewService(log, opt)
+}
+
+func Service() *service {
+	return gJobs
+}
+
+func NewService(log *zap.Logger, opt options.JobsOpt) *service {
+	if opt.Concurrency < 1 {
+		opt.Concurrency = 1
+	}
+
+	if opt.PollInterval <= 0 {
+		opt.PollInterval = 5 * time.Second
+	}
+
+	if opt.MaxAttempts < 1 {
+		opt.MaxAttempts = 1
+	}
+
+	return &service{
+		log:     log.Named("jobs"),
+		opt:     opt,
+		node:    nodeID(),
+		l:       &sync.RWMutex{},
+		workers: make(map[string]map[string]Worker),
+		running: make(map[uint64]*reporter),
+		wg:      &sync.WaitGroup{},
+	}
+}
+
+func (svc *service) SetStore(s Store) {
+	svc.l.Lock()
+	defer svc.l.Unlock()
+	svc.store = s
+}
+
+func (svc *service) SetFileStore(s filestore.Store) {
+	svc.l.Lock()
+	defer svc.l.Unlock()
+	svc.files = s
+}
+
+// Register adds worker for jobs of the given service and kind
+func (svc *service) Register(service, kind string, w Worker) {
+	svc.l.Lock()
+	defer svc.l.Unlock()
+
+	if svc.workers[service] == nil {
+		svc.workers[service] = make(map[string]Worker)
+	}
+
+	svc.workers[service][kind] = w
+}
+
+// Available returns true when jobs can be enqueued
+func (svc *service) Available() bool {
+	if svc == nil {
+		return false
+	}
+
+	svc.l.RLock()
+	defer svc.l.RUnlock()
+	return svc.store != nil
+}
+
+// Enqueue stores a new job
+//
+// Job is owned by (and runs with identity of) the user from the context
+func (svc *service) Enqueue(ctx context.Context, service, kind string, params interface{}, input []byte) (*Job, error) {
+	svc.l.RLock()
+	var (
+		s     = svc.store
+		_, ok = svc.workers[service][kind]
+	)
+	svc.l.RUnlock()
+
+	if s == nil {
+		return nil, ErrNotAvailable
+	}
+
+	if !ok {
+		return nil, ErrNoWorker
+	}
+
+	var (
+		identity = auth.GetIdentityFromContext(ctx)
+		job      = &Job{
+			Service:     service,
+			Kind:        kind,
+			Status:      StatusQueued,
+			MaxAttempts: uint(svc.opt.MaxAttempts),
+			OwnedBy:     identity.Identity(),
+			OwnerRoles:  identity.Roles(),
+			RunAfter:    now(),
+			CreatedAt:   now(),
+		}
+	)
+
+	if enc, err := json.Marshal(params); err != nil {
+		return nil, errors.Wrap(err, "could not encode job parameters")
+	} else {
+		job.Params = enc
+	}
+
+	if err := s.Create(ctx, job, input); err != nil {
+		return nil, err
+	}
+
+	svc.log.Debug("enqueued", zap.Uint64("jobID", job.ID), zap.String("service", service), zap.String("kind", kind))
+	return job, nil
+}
+
+func (svc *service) Find(ctx context.Context, f JobFilter) (JobSet, JobFilter, error) {
+	if !svc.Available() {
+		return nil, f, ErrNotAvailable
+	}
+
+	return svc.store.Find(ctx, f)
+}
+
+func (svc *service) FindByID(ctx context.Context, ID uint64) (*Job, error) {
+	if !svc.Available() {
+		return nil, ErrNotAvailable
+	}
+
+	return svc.store.FindByID(ctx, ID)
+}
+
+// Cancel cancels queued job or requests cancellation of the running job
+//
+// Running job is cancelled right away when it runs on this node,
+// other nodes check for cancellation requests periodically
+func (svc *service) Cancel(ctx context.Context, ID uint64) (*Job, error) {
+	job, err := svc.FindByID(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Finished() {
+		return nil, ErrJobFinished
+	}
+
+	if err = svc.store.RequestCancel(ctx, ID, now()); err != nil {
+		return nil, err
+	}
+
+	svc.l.RLock()
+	r := svc.running[ID]
+	svc.l.RUnlock()
+
+	if r != nil {
+		r.cancelJob()
+	}
+
+	return svc.store.FindByID(ctx, ID)
+}
+
+// Retry puts failed or cancelled job back to queue
+func (svc *service) Retry(ctx context.Context, ID uint64) (*Job, error) {
+	job, err := svc.FindByID(ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !job.Finished() {
+		return nil, ErrJobNotFinished
+	}
+
+	if job.Status == StatusSucceeded {
+		return nil, ErrJobSucceeded
+	}
+
+	if err = svc.store.Retry(ctx, ID, now()); err != nil {
+		return nil, err
+	}
+
+	return svc.store.FindByID(ctx, ID)
+}
+
+// Result returns job and its result
+func (svc *service) Result(ctx context.Context, ID uint64) (*Job, io.ReadSeeker, error) {
+	job, err := svc.FindByID(ctx, ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if job.Status != StatusSucceeded {
+		return nil, nil, ErrJobNotFinished
+	}
+
+	if svc.files == nil {
+		return nil, nil, ErrNoFileStore
+	}
+
+	data, err := svc.files.Open(svc.resultFile(job.ID, job.ResultName))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not open job result")
+	}
+
+	return job, data, nil
+}
+
+// Location of the job's result in the file store
+func (svc *service) resultFile(ID uint64, name string) string {
+	return svc.files.Original(ID, strings.TrimPrefix(path.Ext(name), "."))
+}
+
+// Start runs workers on this node
+//
+// Queued jobs are claimed in intervals (as long as there are free slots)
+func (svc *service) Start(ctx context.Context) {
+	svc.l.Lock()
+	defer svc.l.Unlock()
+
+	if !svc.opt.Enabled || svc.store == nil || svc.started {
+		return
+	}
+
+	svc.started = true
+
+	go func() {
+		defer sentry.Recover()
+
+		var ticker = time.NewTicker(svc.opt.PollInterval)
+		defer ticker.Stop()
+
+		svc.log.Debug("started",
+			zap.String("node", svc.node),
+			zap.Int("concurrency", svc.opt.Concurrency),
+		)
+
+		for {
+			select {
+			case <-ctx.Done():
+				svc.log.Debug("stopped")
+				return
+			case <-ticker.C:
+				svc.tick(ctx)
+			}
+		}
+	}()
+}
+
+// Claims and runs queued jobs for each of the services with registered workers
+func (svc *service) tick(ctx context.Context) {
+	svc.maintain(ctx)
+
+	svc.l.RLock()
+	var (
+		ww    = make(map[string]map[string]Worker, len(svc.workers))
+		slots = make(map[string]int, len(svc.workers))
+	)
+
+	for service, kk := range svc.workers {
+		ww[service] = make(map[string]Worker, len(kk))
+		for kind, w := range kk {
+			ww[service][kind] = w
+		}
+
+		slots[service] = svc.opt.Concurrency
+	}
+
+	for _, r := range svc.running {
+		slots[r.job.Service]--
+	}
+	svc.l.RUnlock()
+
+	for service, kk := range ww {
+		var kinds = make([]string, 0, len(kk))
+		for kind := range kk {
+			kinds = append(kinds, kind)
+		}
+
+		for ; slots[service] > 0; slots[service]-- {
+			job, err := svc.store.Claim(ctx, service, kinds, svc.node, now())
+			if err != nil {
+				svc.log.Error("could not claim job", zap.Error(err))
+				break
+			}
+
+			if job == nil {
+				break
+			}
+
+			svc.run(ctx, kk[job.Kind], job)
+		}
+	}
+}
+
+// Requeues stale jobs and purges old ones
+func (svc *service) maintain(ctx context.Context) {
+	if svc.opt.StaleAfter > 0 {
+		if err := svc.store.Requeue(ctx, now().Add(-svc.opt.StaleAfter)); err != nil {
+			svc.log.Error("could not requeue stale jobs", zap.Error(err))
+		}
+	}
+
+	if svc.opt.Retention > 0 && now().Sub(svc.purgedAt) > purgeInterval {
+		svc.purgedAt = now()
+		set, err := svc.store.Purge(ctx, now().Add(-svc.opt.Retention))
+		if err != nil {
+			svc.log.Error("could not purge jobs", zap.Error(err))
+		}
+
+		svc.removeResults(set)
+	}
+}
+
+// Removes result files of purged jobs
+//
+// Failures are only logged; jobs are already gone
+func (svc *service) removeResults(set JobSet) {
+	if svc.files == nil {
+		return
+	}
+
+	for _, job := range set {
+		if job.ResultName == "" {
+			continue
+		}
+
+		if err := svc.files.Remove(svc.resultFile(job.ID, job.ResultName)); err != nil {
+			svc.log.Warn("could not remove job result", zap.Uint64("jobID", job.ID), zap.Error(err))
+		}
+	}
+}
+
+// Runs claimed job in a separate routine
+func (svc *service) run(ctx context.Context, w Worker, job *Job) {
+	var (
+		log    = svc.log.With(zap.Uint64("jobID", job.ID), zap.String("kind", job.Kind))
+		r      = &reporter{svc: svc, job: job}
+		parent = ctx
+	)
+
+	ctx, r.cancel = context.WithCancel(parent)
+
+	svc.l.Lock()
+	svc.running[job.ID] = r
+	svc.l.Unlock()
+
+	svc.wg.Add(1)
+	go func() {
+		defer svc.wg.Done()
+		defer sentry.Recover()
+
+		var done = make(chan struct{})
+		go svc.heartbeat(r, done)
+
+		log.Debug("running", zap.Uint("attempt", job.Attempts))
+		err := work(auth.SetIdentityToContext(ctx, job.Identity()), w, job, r)
+		close(done)
+
+		svc.l.Lock()
+		delete(svc.running, job.ID)
+		svc.l.Unlock()
+
+		r.cancel()
+		r.finish(err, parent.Err() != nil)
+
+		if err != nil {
+			log.Warn("job failed", zap.Error(err), zap.String("status", string(job.Status)))
+		} else {
+			log.Debug("job succeeded")
+		}
+	}()
+}
+
+// Calls worker and converts panics into errors
+func work(ctx context.Context, w Worker, job *Job, r Reporter) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("job worker panicked: %v", p)
+		}
+	}()
+
+	if w == nil {
+		return ErrNoWorker
+	}
+
+	return w(ctx, job, r)
+}
+
+// Periodically stores progress of the running job and checks for cancellation requests
+func (svc *service) heartbeat(r *reporter, done chan struct{}) {
+	defer sentry.Recover()
+
+	var ticker = time.NewTicker(svc.opt.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := r.flush(); err != nil {
+				svc.log.Error("could not update job", zap.Uint64("jobID", r.job.ID), zap.Error(err))
+			}
+
+			if job, err := svc.store.FindByID(context.Background(), r.job.ID); err == nil && job.CancelRequested {
+				r.cancelJob()
+			}
+		}
+	}
+}
+
+func (r *reporter) Progress(total, done, failed uint) {
+	r.l.Lock()
+	defer r.l.Unlock()
+	r.job.Total, r.job.Done, r.job.Failed = total, done, failed
+}
+
+func (r *reporter) Input() ([]byte, error) {
+	return r.svc.store.Input(context.Background(), r.job.ID)
+}
+
+func (r *reporter) Result(name, contentType string, data io.Reader) error {
+	if r.svc.files == nil {
+		return ErrNoFileStore
+	}
+
+	var (
+		filename = r.svc.resultFile(r.job.ID, name)
+		counter  = &countingReader{Reader: data}
+	)
+
+	if err := r.svc.files.Save(filename, counter); err != nil {
+		// Do not leave partially stored results behind
+		_ = r.svc.files.Remove(filename)
+		return errors.Wrap(err, "could not store job result")
+	}
+
+	r.l.Lock()
+	defer r.l.Unlock()
+	r.job.ResultName, r.job.ResultType, r.job.ResultSize = name, contentType, counter.size
+	return nil
+}
+
+func (c *countingReader) Read(p []byte) (n int, err error) {
+	n, err = c.Reader.Read(p)
+	c.size += uint(n)
+	return
+}
+
+func (r *reporter) Checkpoint(v interface{}) error {
+	enc, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "could not encode job checkpoint")
+	}
+
+	r.l.Lock()
+	r.job.Checkpoint = enc
+	r.l.Unlock()
+
+	return r.flush()
+}
+
+func (r *reporter) cancelJob() {
+	r.l.Lock()
+	r.cancelled = true
+	r.l.Unlock()
+
+	r.cancel()
+}
+
+// Stores job's progress
+func (r *reporter) flush() error {
+	r.l.Lock()
+	defer r.l.Unlock()
+
+	var n = now()
+	r.job.UpdatedAt = &n
+
+	return r.svc.store.Update(context.Background(), r.job)
+}
+
+// Sets final status of the job (or puts it back to queue when it can be retried)
+//
+// Interrupted jobs (service stopped) are put back to queue without counting the attempt
+func (r *reporter) finish(err error, interrupted bool) {
+	r.l.Lock()
+	var (
+		job = r.job
+		n   = now()
+	)
+
+	switch {
+	case err == nil:
+		job.Status, job.Error = StatusSucceeded, ""
+	case r.cancelled:
+		job.Status = StatusCancelled
+	case interrupted:
+		job.Status, job.RunAfter = StatusQueued, n
+		job.Attempts--
+	case job.Attempts < job.MaxAttempts:
+		job.Status, job.Error = StatusQueued, err.Error()
+		job.RunAfter = n.Add(r.svc.opt.RetryDelay * time.Duration(job.Attempts))
+	default:
+		job.Status, job.Error = StatusFailed, err.Error()
+	}
+
+	if job.Finished() {
+		job.FinishedAt = &n
+	}
+	r.l.Unlock()
+
+	if err = r.flush(); err != nil {
+		r.svc.log.Error("could not update job", zap.Uint64("jobID", job.ID), zap.Error(err))
+	}
+}
+
+// Waits for all running jobs
+//
+// Should only be used for testing
+func (svc *service) wait() {
+	svc.wg.Wait()
+}
+
+func nodeID() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d", host, os.Getpid())
+}
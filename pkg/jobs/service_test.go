@@ -0,0 +1,380 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/cortezaproject/corteza-server/pkg/app/options"
+	"github.com/cortezaproject/corteza-server/pkg/store/plain"
+)
+
+type (
+	mockStore struct {
+		l      sync.Mutex
+		jobs   map[uint64]*Job
+		input  map[uint64][]byte
+		nextID uint64
+	}
+)
+
+func newMockStore() *mockStore {
+	return &mockStore{
+		jobs:  make(map[uint64]*Job),
+		input: make(map[uint64][]byte),
+	}
+}
+
+func (s *mockStore) Create(_ context.Context, job *Job, input []byte) error {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.nextID++
+	job.ID = s.nextID
+	c := *job
+	s.jobs[job.ID] = &c
+	s.input[job.ID] = input
+	return nil
+}
+
+func (s *mockStore) Find(context.Context, JobFilter) (set JobSet, f JobFilter, err error) {
+	s.l.Lock()
+	defer s.l.Unlock()
+	for _, j := range s.jobs {
+		c := *j
+		set = append(set, &c)
+	}
+	return
+}
+
+func (s *mockStore) FindByID(_ context.Context, ID uint64) (*Job, error) {
+	s.l.Lock()
+	defer s.l.Unlock()
+	if j, ok := s.jobs[ID]; ok {
+		c := *j
+		return &c, nil
+	}
+	return nil, ErrJobNotFound
+}
+
+func (s *mockStore) Claim(_ context.Context, service string, kinds []string, node string, now time.Time) (*Job, error) {
+	s.l.Lock()
+	defer s.l.Unlock()
+	for ID := uint64(1); ID <= s.nextID; ID++ {
+		j := s.jobs[ID]
+		if j == nil || j.Service != service || j.Status != StatusQueued || j.RunAfter.After(now) {
+			continue
+		}
+
+		j.Status, j.Node, j.StartedAt = StatusRunning, node, &now
+		j.Attempts++
+		c := *j
+		return &c, nil
+	}
+
+	return nil, nil
+}
+
+func (s *mockStore) Update(_ context.Context, job *Job) error {
+	s.l.Lock()
+	defer s.l.Unlock()
+	if s.jobs[job.ID].Node != job.Node {
+		return nil
+	}
+
+	c := *job
+	c.CancelRequested = s.jobs[job.ID].CancelRequested
+	s.jobs[job.ID] = &c
+	return nil
+}
+
+func (s *mockStore) RequestCancel(_ context.Context, ID uint64, now time.Time) error {
+	s.l.Lock()
+	defer s.l.Unlock()
+	j := s.jobs[ID]
+	j.CancelRequested = true
+	if j.Status == StatusQueued {
+		j.Status, j.FinishedAt = StatusCancelled, &now
+	}
+	return nil
+}
+
+func (s *mockStore) Retry(_ context.Context, ID uint64, now time.Time) error {
+	s.l.Lock()
+	defer s.l.Unlock()
+	j := s.jobs[ID]
+	j.Status, j.Attempts, j.Error, j.CancelRequested, j.RunAfter, j.FinishedAt = StatusQueued, 0, "", false, now, nil
+	return nil
+}
+
+func (s *mockStore) Input(_ context.Context, ID uint64) ([]byte, error) {
+	s.l.Lock()
+	defer s.l.Unlock()
+	return s.input[ID], nil
+}
+
+func (s *mockStore) Requeue(context.Context, time.Time) error         { return nil }
+func (s *mockStore) Purge(context.Context, time.Time) (JobSet, error) { return nil, nil }
+
+func testService(opt options.JobsOpt) (*service, *mockStore) {
+	var (
+		s   = newMockStore()
+		svc = NewService(zap.NewNop(), opt)
+	)
+
+	files, _ := plain.NewWithAfero(afero.NewMemMapFs(), "test")
+
+	svc.SetStore(s)
+	svc.SetFileStore(files)
+	return svc, s
+}
+
+func TestService_Enqueue(t *testing.T) {
+	var (
+		req    = require.New(t)
+		ctx    = context.Background()
+		svc, _ = testService(options.JobsOpt{MaxAttempts: 3})
+	)
+
+	_, err := svc.Enqueue(ctx, "test", "unknown", nil, nil)
+	req.True(errors.Is(err, ErrNoWorker))
+
+	svc.Register("test", "kind", func(context.Context, *Job, Reporter) error { return nil })
+
+	job, err := svc.Enqueue(ctx, "test", "kind", map[string]int{"foo": 42}, []byte("input"))
+	req.NoError(err)
+	req.Equal(StatusQueued, job.Status)
+	req.Equal(uint(3), job.MaxAttempts)
+
+	p := map[string]int{}
+	req.NoError(job.DecodeParams(&p))
+	req.Equal(42, p["foo"])
+}
+
+func TestService_run(t *testing.T) {
+	var (
+		req    = require.New(t)
+		ctx    = context.Background()
+		svc, s = testService(options.JobsOpt{MaxAttempts: 1})
+	)
+
+	svc.Register("test", "kind", func(ctx context.Context, job *Job, r Reporter) error {
+		in, err := r.Input()
+		if err != nil {
+			return err
+		}
+
+		r.Progress(2, 1, 1)
+		return r.Result("out.txt", "text/plain", bytes.NewReader(append(in, '!')))
+	})
+
+	job, err := svc.Enqueue(ctx, "test", "kind", nil, []byte("input"))
+	req.NoError(err)
+
+	svc.tick(ctx)
+	svc.wait()
+
+	job, data, err := svc.Result(ctx, job.ID)
+	req.NoError(err)
+	req.Equal(StatusSucceeded, job.Status)
+	req.Equal(uint(1), job.Attempts)
+	req.Equal(uint(2), job.Total)
+	req.Equal(uint(1), job.Done)
+	req.Equal("out.txt", job.ResultName)
+	req.NotNil(job.FinishedAt)
+	req.Equal(uint(6), job.ResultSize)
+
+	out, err := ioutil.ReadAll(data)
+	req.NoError(err)
+	req.Equal("input!", string(out))
+
+	req.Empty(s.jobs[job.ID].Error)
+}
+
+func TestService_retries(t *testing.T) {
+	var (
+		req    = require.New(t)
+		ctx    = context.Background()
+		svc, _ = testService(options.JobsOpt{MaxAttempts: 2})
+	)
+
+	svc.Register("test", "kind", func(context.Context, *Job, Reporter) error { return errors.New("nope") })
+
+	job, err := svc.Enqueue(ctx, "test", "kind", nil, nil)
+	req.NoError(err)
+
+	svc.tick(ctx)
+	svc.wait()
+
+	job, err = svc.FindByID(ctx, job.ID)
+	req.NoError(err)
+	req.Equal(StatusQueued, job.Status)
+	req.Equal("nope", job.Error)
+
+	svc.tick(ctx)
+	svc.wait()
+
+	job, err = svc.FindByID(ctx, job.ID)
+	req.NoError(err)
+	req.Equal(StatusFailed, job.Status)
+	req.Equal(uint(2), job.Attempts)
+
+	job, err = svc.Retry(ctx, job.ID)
+	req.NoError(err)
+	req.Equal(StatusQueued, job.Status)
+}
+
+func TestService_panic(t *testing.T) {
+	var (
+		req    = require.New(t)
+		ctx    = context.Background()
+		svc, _ = testService(options.JobsOpt{MaxAttempts: 1})
+	)
+
+	svc.Register("test", "kind", func(context.Context, *Job, Reporter) error { panic("oops") })
+
+	job, err := svc.Enqueue(ctx, "test", "kind", nil, nil)
+	req.NoError(err)
+
+	svc.tick(ctx)
+	svc.wait()
+
+	job, err = svc.FindByID(ctx, job.ID)
+	req.NoError(err)
+	req.Equal(StatusFailed, job.Status)
+	req.Contains(job.Error, "oops")
+}
+
+func TestService_Cancel(t *testing.T) {
+	var (
+		req     = require.New(t)
+		ctx     = context.Background()
+		svc, _  = testService(options.JobsOpt{MaxAttempts: 3})
+		started = make(chan struct{})
+	)
+
+	svc.Register("test", "kind", func(ctx context.Context, _ *Job, _ Reporter) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	queued, err := svc.Enqueue(ctx, "test", "kind", nil, nil)
+	req.NoError(err)
+
+	svc.tick(ctx)
+	<-started
+
+	job, err := svc.Cancel(ctx, queued.ID)
+	req.NoError(err)
+	req.True(job.CancelRequested)
+	svc.wait()
+
+	job, err = svc.FindByID(ctx, queued.ID)
+	req.NoError(err)
+	req.Equal(StatusCancelled, job.Status)
+
+	_, err = svc.Cancel(ctx, queued.ID)
+	req.True(errors.Is(err, ErrJobFinished))
+
+	// Queued jobs are cancelled right away
+	queued, err = svc.Enqueue(ctx, "test", "kind", nil, nil)
+	req.NoError(err)
+
+	job, err = svc.Cancel(ctx, queued.ID)
+	req.NoError(err)
+	req.Equal(StatusCancelled, job.Status)
+}
+
+func TestService_concurrency(t *testing.T) {
+	var (
+		req    = require.New(t)
+		ctx    = context.Background()
+		svc, s = testService(options.JobsOpt{MaxAttempts: 1, Concurrency: 2})
+		block  = make(chan struct{})
+	)
+
+	svc.Register("test", "kind", func(context.Context, *Job, Reporter) error {
+		<-block
+		return nil
+	})
+
+	for i := 0; i < 3; i++ {
+		_, err := svc.Enqueue(ctx, "test", "kind", nil, nil)
+		req.NoError(err)
+	}
+
+	svc.tick(ctx)
+
+	running := 0
+	set, _, _ := s.Find(ctx, JobFilter{})
+	for _, j := range set {
+		if j.Status == StatusRunning {
+			running++
+		}
+	}
+
+	req.Equal(2, running)
+
+	close(block)
+	svc.wait()
+}
+
+func TestService_interrupted(t *testing.T) {
+	var (
+		req         = require.New(t)
+		ctx, cancel = context.WithCancel(context.Background())
+		svc, _      = testService(options.JobsOpt{MaxAttempts: 1})
+		started     = make(chan struct{})
+	)
+
+	svc.Register("test", "kind", func(ctx context.Context, job *Job, r Reporter) error {
+		cp := map[string]int{}
+		if err := job.DecodeCheckpoint(&cp); err != nil {
+			return err
+		}
+
+		if cp["at"] > 0 {
+			// Continue where it stopped
+			return nil
+		}
+
+		if err := r.Checkpoint(map[string]int{"at": 42}); err != nil {
+			return err
+		}
+
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	job, err := svc.Enqueue(ctx, "test", "kind", nil, nil)
+	req.NoError(err)
+
+	svc.tick(ctx)
+	<-started
+
+	// Stop the service
+	cancel()
+	svc.wait()
+
+	job, err = svc.FindByID(context.Background(), job.ID)
+	req.NoError(err)
+	req.Equal(StatusQueued, job.Status)
+	req.Zero(job.Attempts)
+	req.JSONEq(`{"at":42}`, string(job.Checkpoint))
+
+	svc.tick(context.Background())
+	svc.wait()
+
+	job, err = svc.FindByID(context.Background(), job.ID)
+	req.NoError(err)
+	req.Equal(StatusSucceeded, job.Status)
+}
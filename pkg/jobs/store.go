@@ -0,0 +1,297 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+	"github.com/titpetric/factory"
+
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	// Store keeps jobs, their inputs and results
+	Store interface {
+		Create(ctx context.Context, job *Job, input []byte) error
+		Find(ctx context.Context, filter JobFilter) (JobSet, JobFilter, error)
+		FindByID(ctx context.Context, ID uint64) (*Job, error)
+
+		// Claim marks the oldest queued job (of the service and one of the kinds) as running on the node
+		//
+		// Returns nil when there are no queued jobs
+		Claim(ctx context.Context, service string, kinds []string, node string, now time.Time) (*Job, error)
+
+		// Update stores job's status, progress and result info
+		//
+		// It does not modify cancellation request; job is not updated
+		// when it is no longer claimed by the job's node (it was requeued)
+		Update(ctx context.Context, job *Job) error
+
+		// RequestCancel flags the job for cancellation; queued job is cancelled right away
+		RequestCancel(ctx context.Context, ID uint64, now time.Time) error
+
+		// Retry puts finished job back to queue
+		//
+		// Progress and checkpoint are kept so that job can continue where it stopped
+		Retry(ctx context.Context, ID uint64, now time.Time) error
+
+		Input(ctx context.Context, ID uint64) ([]byte, error)
+
+		// Requeue puts back running jobs that were not updated since given time
+		// (node that was running them is gone) and releases them from that node
+		Requeue(ctx context.Context, before time.Time) error
+
+		// Purge removes finished jobs older than given time
+		//
+		// Returns removed jobs so that their results can be removed as well
+		Purge(ctx context.Context, before time.Time) (JobSet, error)
+	}
+
+	store struct {
+		db *factory.DB
+	}
+)
+
+const (
+	storeTable = "sys_job"
+
+	// How many queued jobs are tried when claiming
+	// (other nodes might claim them first)
+	storeClaimCandidates = 5
+)
+
+var (
+	ErrJobNotFound = errors.New("job not found")
+)
+
+// NewStore returns database backed job store
+func NewStore(db *factory.DB) Store {
+	return &store{db: db}
+}
+
+// Job columns w/o input & result
+func (s store) columns() []string {
+	return []string{
+		"j.id",
+		"j.service",
+		"j.kind",
+		"j.status",
+		"j.params",
+		"j.checkpoint",
+		"j.total",
+		"j.done",
+		"j.failed",
+		"j.error",
+		"j.attempts",
+		"j.max_attempts",
+		"j.cancel_requested",
+		"j.result_name",
+		"j.result_type",
+		"j.result_size",
+		"j.node",
+		"j.owned_by",
+		"j.owner_roles",
+		"j.run_after",
+		"j.created_at",
+		"j.started_at",
+		"j.finished_at",
+		"j.updated_at",
+	}
+}
+
+func (s store) query() squirrel.SelectBuilder {
+	return squirrel.Select(s.columns()...).From(storeTable + " AS j")
+}
+
+func (s store) Create(ctx context.Context, job *Job, input []byte) error {
+	job.ID = factory.Sonyflake.NextID()
+
+	_, err := squirrel.ExecWith(s.db.With(ctx), squirrel.Insert(storeTable).SetMap(squirrel.Eq{
+		"id":           job.ID,
+		"service":      job.Service,
+		"kind":         job.Kind,
+		"status":       job.Status,
+		"params":       job.Params,
+		"error":        "",
+		"max_attempts": job.MaxAttempts,
+		"owned_by":     job.OwnedBy,
+		"owner_roles":  job.OwnerRoles,
+		"run_after":    job.RunAfter,
+		"created_at":   job.CreatedAt,
+		"input":        input,
+	}))
+
+	return errors.Wrap(err, "could not store job")
+}
+
+func (s store) Find(ctx context.Context, filter JobFilter) (set JobSet, f JobFilter, err error) {
+	var (
+		db    = s.db.With(ctx)
+		query = s.query()
+	)
+
+	f = filter
+
+	if f.Sort == "" {
+		f.Sort = "j.id DESC"
+	}
+
+	if f.Service != "" {
+		query = query.Where(squirrel.Eq{"j.service": f.Service})
+	}
+
+	if f.Kind != "" {
+		query = query.Where(squirrel.Eq{"j.kind": f.Kind})
+	}
+
+	if f.Status != "" {
+		query = query.Where(squirrel.Eq{"j.status": f.Status})
+	}
+
+	if f.OwnedBy > 0 {
+		query = query.Where(squirrel.Eq{"j.owned_by": f.OwnedBy})
+	}
+
+	var orderBy []string
+	if orderBy, err = rh.ParseOrder(f.Sort, s.columns()...); err != nil {
+		return
+	} else {
+		query = query.OrderBy(orderBy...)
+	}
+
+	if f.Count, err = rh.Count(db, query); err != nil || f.Count == 0 {
+		return
+	}
+
+	return set, f, rh.FetchPaged(db, query, f.PageFilter, &set)
+}
+
+func (s store) FindByID(ctx context.Context, ID uint64) (*Job, error) {
+	var job = &Job{}
+
+	err := rh.FetchOne(s.db.With(ctx), s.query().Where(squirrel.Eq{"j.id": ID}), job)
+	if err == sql.ErrNoRows {
+		return nil, ErrJobNotFound
+	}
+
+	return job, errors.Wrap(err, "could not load job")
+}
+
+func (s store) Claim(ctx context.Context, service string, kinds []string, node string, now time.Time) (*Job, error) {
+	var (
+		db  = s.db.With(ctx)
+		ids []uint64
+
+		query = squirrel.Select("id").From(storeTable).
+			Where(squirrel.Eq{"service": service, "kind": kinds, "status": StatusQueued}).
+			Where(squirrel.LtOrEq{"run_after": now}).
+			OrderBy("run_after", "id").
+			Limit(storeClaimCandidates)
+	)
+
+	if err := rh.FetchAll(db, query, &ids); err != nil {
+		return nil, errors.Wrap(err, "could not find queued jobs")
+	}
+
+	for _, ID := range ids {
+		// Status check makes sure only one of the nodes claims the job
+		rsp, err := squirrel.ExecWith(db, squirrel.Update(storeTable).
+			SetMap(squirrel.Eq{
+				"status":     StatusRunning,
+				"node":       node,
+				"attempts":   squirrel.Expr("attempts + 1"),
+				"started_at": now,
+				"updated_at": now,
+			}).
+			Where(squirrel.Eq{"id": ID, "status": StatusQueued}))
+
+		if err != nil {
+			return nil, errors.Wrap(err, "could not claim job")
+		}
+
+		if n, _ := rsp.RowsAffected(); n == 1 {
+			return s.FindByID(ctx, ID)
+		}
+	}
+
+	return nil, nil
+}
+
+func (s store) Update(ctx context.Context, job *Job) error {
+	return errors.Wrap(rh.UpdateColumns(s.db.With(ctx), storeTable, rh.Set{
+		"status":      job.Status,
+		"total":       job.Total,
+		"done":        job.Done,
+		"failed":      job.Failed,
+		"error":       job.Error,
+		"attempts":    job.Attempts,
+		"checkpoint":  job.Checkpoint,
+		"result_name": job.ResultName,
+		"result_type": job.ResultType,
+		"result_size": job.ResultSize,
+		"run_after":   job.RunAfter,
+		"finished_at": job.FinishedAt,
+		"updated_at":  job.UpdatedAt,
+	}, squirrel.Eq{"id": job.ID, "node": job.Node}), "could not update job")
+}
+
+func (s store) RequestCancel(ctx context.Context, ID uint64, now time.Time) error {
+	// Assignments are evaluated from left to right so
+	// finished_at is set before status is changed
+	const update = "UPDATE " + storeTable + " SET cancel_requested = TRUE, " +
+		"finished_at = IF(status = ?, ?, finished_at), " +
+		"status = IF(status = ?, ?, status) " +
+		"WHERE id = ?"
+
+	_, err := s.db.With(ctx).Exec(update, StatusQueued, now, StatusQueued, StatusCancelled, ID)
+	return errors.Wrap(err, "could not cancel job")
+}
+
+func (s store) Retry(ctx context.Context, ID uint64, now time.Time) error {
+	return errors.Wrap(rh.UpdateColumns(s.db.With(ctx), storeTable, rh.Set{
+		"status":           StatusQueued,
+		"error":            "",
+		"result_name":      "",
+		"result_type":      "",
+		"result_size":      0,
+		"attempts":         0,
+		"cancel_requested": false,
+		"run_after":        now,
+		"finished_at":      nil,
+		"updated_at":       now,
+	}, squirrel.Eq{"id": ID}), "could not retry job")
+}
+
+func (s store) Input(ctx context.Context, ID uint64) (data []byte, err error) {
+	err = s.db.With(ctx).Get(&data, "SELECT input FROM "+storeTable+" WHERE id = ?", ID)
+	return data, errors.Wrap(err, "could not load job input")
+}
+
+func (s store) Requeue(ctx context.Context, before time.Time) error {
+	return errors.Wrap(rh.UpdateColumns(s.db.With(ctx), storeTable, rh.Set{
+		"status": StatusQueued,
+		"node":   "",
+	}, squirrel.And{
+		squirrel.Eq{"status": StatusRunning},
+		squirrel.Lt{"updated_at": before},
+	}), "could not requeue jobs")
+}
+
+func (s store) Purge(ctx context.Context, before time.Time) (set JobSet, err error) {
+	var (
+		db    = s.db.With(ctx)
+		query = s.query().Where(squirrel.And{
+			squirrel.Eq{"j.status": []Status{StatusSucceeded, StatusFailed, StatusCancelled}},
+			squirrel.Lt{"j.created_at": before},
+		})
+	)
+
+	if err = rh.FetchAll(db, query, &set); err != nil || len(set) == 0 {
+		return nil, errors.Wrap(err, "could not find purgeable jobs")
+	}
+
+	return set, errors.Wrap(rh.Delete(db, storeTable, squirrel.Eq{"id": set.IDs()}), "could not purge jobs")
+}
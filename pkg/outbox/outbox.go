@@ -0,0 +1,245 @@
+package outbox
+
+// Outbox records events that need to be delivered to external systems
+//
+// Events are recorded into the outbox table of the service (compose_outbox, sys_outbox)
+// with the same database handle (and therefore in the same transaction) as the change
+// that caused them; if transaction is rolled back, nothing is delivered and if process
+// dies after the commit, event is still there to be relayed by one of the next runs.
+//
+// Recorded events are relayed to the handler (set by the system service) and removed
+// from the outbox once they are handled. Events stay in the outbox when there is no handler.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+	"github.com/titpetric/factory"
+
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	// Event that can be recorded
+	//
+	// All events, generated from events.yaml definitions satisfy this interface
+	Event interface {
+		eventbus.Event
+
+		// Encode returns JSON encoded event arguments
+		Encode() (map[string][]byte, error)
+	}
+
+	// Handler processes events relayed from the outbox
+	Handler interface {
+		Handle(ctx context.Context, ev Event) error
+	}
+
+	// Decoder restores event (of one resource type) from the recorded arguments
+	Decoder func(eventType string, args map[string][]byte) (Event, error)
+
+	// Outbox stores events into the given table
+	Outbox struct {
+		table string
+	}
+
+	source struct {
+		outbox *Outbox
+		db     *factory.DB
+	}
+
+	entry struct {
+		ID           uint64 `db:"id"`
+		ResourceType string `db:"resource_type"`
+		EventType    string `db:"event_type"`
+		Args         []byte `db:"args"`
+	}
+)
+
+const (
+	// How many events are relayed from one outbox at once
+	relayBatchSize = 100
+
+	// How long is claimed event reserved for the node that is relaying it
+	relayClaimDuration = time.Minute
+)
+
+var (
+	l        sync.RWMutex
+	handler  Handler
+	decoders = map[string]Decoder{}
+	sources  []source
+
+	ErrNoHandler = errors.New("outbox handler not set")
+)
+
+// New returns outbox that records events into the given table
+func New(table string) *Outbox {
+	return &Outbox{table: table}
+}
+
+// Record stores event into the outbox
+//
+// Provided database handle should be the one that is used for the transaction
+func (o *Outbox) Record(ctx context.Context, db *factory.DB, ev Event) error {
+	args, err := ev.Encode()
+	if err != nil {
+		return errors.Wrap(err, "could not encode event")
+	}
+
+	enc, err := json.Marshal(args)
+	if err != nil {
+		return errors.Wrap(err, "could not encode event")
+	}
+
+	_, err = squirrel.ExecWith(db.With(ctx), squirrel.Insert(o.table).SetMap(squirrel.Eq{
+		"id":            factory.Sonyflake.NextID(),
+		"resource_type": ev.ResourceType(),
+		"event_type":    ev.EventType(),
+		"args":          enc,
+		"created_at":    time.Now(),
+	}))
+
+	return errors.Wrap(err, "could not record event")
+}
+
+// relay passes recorded events to the handler and removes them from the outbox
+//
+// Each event is claimed before it is handled so that it is relayed by one node only.
+// Events that can not be decoded or handled are kept and retried when their claim expires.
+func (o *Outbox) relay(ctx context.Context, db *factory.DB, h Handler, now time.Time) (count uint, err error) {
+	var (
+		ee []*entry
+
+		available = squirrel.Or{
+			squirrel.Expr("claimed_until IS NULL"),
+			squirrel.Lt{"claimed_until": now},
+		}
+	)
+
+	db = db.With(ctx)
+
+	err = rh.FetchAll(db, squirrel.
+		Select("id", "resource_type", "event_type", "args").
+		From(o.table).
+		Where(available).
+		OrderBy("id").
+		Limit(relayBatchSize), &ee)
+
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not load events from %s", o.table)
+	}
+
+	for _, e := range ee {
+		rsp, cerr := squirrel.ExecWith(db, squirrel.Update(o.table).
+			Set("claimed_until", now.Add(relayClaimDuration)).
+			Where(squirrel.Eq{"id": e.ID}).
+			Where(available))
+
+		if cerr != nil {
+			return count, errors.Wrapf(cerr, "could not claim event %d", e.ID)
+		}
+
+		if n, _ := rsp.RowsAffected(); n == 0 {
+			// relayed by another node
+			continue
+		}
+
+		if rerr := relayEntry(ctx, h, e); rerr != nil {
+			// Other events are still relayed
+			err = errors.Wrapf(rerr, "could not relay event %d from %s", e.ID, o.table)
+			continue
+		}
+
+		if derr := rh.Delete(db, o.table, squirrel.Eq{"id": e.ID}); derr != nil {
+			return count, errors.Wrapf(derr, "could not remove relayed event %d", e.ID)
+		}
+
+		count++
+	}
+
+	return count, err
+}
+
+func relayEntry(ctx context.Context, h Handler, e *entry) error {
+	var args = map[string][]byte{}
+
+	if err := json.Unmarshal(e.Args, &args); err != nil {
+		return err
+	}
+
+	l.RLock()
+	decode, has := decoders[e.ResourceType]
+	l.RUnlock()
+
+	if !has {
+		return fmt.Errorf("no decoder for %s events", e.ResourceType)
+	}
+
+	ev, err := decode(e.EventType, args)
+	if err != nil {
+		return err
+	}
+
+	return h.Handle(ctx, ev)
+}
+
+// SetHandler sets global handler for the relayed events
+func SetHandler(h Handler) {
+	l.Lock()
+	defer l.Unlock()
+	handler = h
+}
+
+// HasHandler returns true when events can be relayed in this process
+func HasHandler() bool {
+	l.RLock()
+	defer l.RUnlock()
+	return handler != nil
+}
+
+// RegisterDecoder registers decoder for events of the resource type
+func RegisterDecoder(resourceType string, d Decoder) {
+	l.Lock()
+	defer l.Unlock()
+	decoders[resourceType] = d
+}
+
+// Register adds outbox (and the database it is stored in) to the list of outboxes that are relayed
+func Register(o *Outbox, db *factory.DB) {
+	l.Lock()
+	defer l.Unlock()
+	sources = append(sources, source{outbox: o, db: db})
+}
+
+// Relay relays events from all registered outboxes
+func Relay(ctx context.Context, now time.Time) (count uint, err error) {
+	l.RLock()
+	var (
+		h  = handler
+		ss = sources
+	)
+	l.RUnlock()
+
+	if h == nil {
+		return 0, ErrNoHandler
+	}
+
+	for _, s := range ss {
+		n, serr := s.outbox.relay(ctx, s.db, h, now)
+		count += n
+
+		if serr != nil {
+			// Other outboxes are still relayed
+			err = serr
+		}
+	}
+
+	return count, err
+}
@@ -0,0 +1,11 @@
+package incoming
+
+type (
+	Typing struct {
+		ChannelID string `json:"channelID"`
+		ThreadID  string `json:"threadID"`
+
+		// Client stopped typing (ie: cleared or sent the message)
+		Stopped bool `json:"stopped"`
+	}
+)
@@ -17,4 +17,7 @@ type Payload struct {
 	*MessageDelete `json:"deleteMessage"`
 
 	*Users `json:"getUsers"`
+
+	// Activity
+	*Typing `json:"typing"`
 }
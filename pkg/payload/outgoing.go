@@ -25,6 +25,35 @@ func Activity(a *messagingTypes.Activity) *outgoing.Activity {
 	}
 }
 
+func Presence(p *messagingTypes.Presence) *outgoing.Presence {
+	return &outgoing.Presence{
+		UserID:    p.UserID,
+		Status:    string(p.Status),
+		Icon:      p.Icon,
+		Message:   p.Message,
+		ExpiresAt: p.ExpiresAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+func Presences(pp messagingTypes.PresenceSet) *outgoing.PresenceSet {
+	out := make([]*outgoing.Presence, len(pp))
+	for k, p := range pp {
+		out[k] = Presence(p)
+	}
+	retval := outgoing.PresenceSet(out)
+	return &retval
+}
+
+func Typing(t *messagingTypes.Typing) *outgoing.Typing {
+	return &outgoing.Typing{
+		UserID:    t.UserID,
+		ChannelID: t.ChannelID,
+		ThreadID:  t.ThreadID,
+		Stopped:   t.Stopped,
+	}
+}
+
 func Message(ctx context.Context, msg *messagingTypes.Message) *outgoing.Message {
 	var currentUserID = auth.GetIdentityFromContext(ctx).Identity()
 	var canEdit = msg.Type.IsEditable() && msg.UserID == currentUserID
@@ -68,30 +97,22 @@ func Messages(ctx context.Context, msg messagingTypes.MessageSet) *outgoing.Mess
 }
 
 func messageReactionSumSet(flags messagingTypes.MessageFlagSet) outgoing.MessageReactionSumSet {
-	var (
-		rr     = make([]*outgoing.MessageReactionSum, 0)
-		rIndex = map[string]int{}
-		has    bool
-		i      int
-	)
-
-	_ = flags.Walk(func(flag *messagingTypes.MessageFlag) error {
-		if flag.IsReaction() {
-			r := &outgoing.MessageReactionSum{Reaction: flag.Flag, UserIDs: []string{}, Count: 0}
+	return MessageReactionSums(flags.Reactions())
+}
 
-			if i, has = rIndex[flag.Flag]; !has {
-				i, rIndex[flag.Flag] = len(rr), len(rr)
-				rr = append(rr, r)
-			}
+// MessageReactionSums converts aggregated reactions
+func MessageReactionSums(rr messagingTypes.MessageReactionSumSet) outgoing.MessageReactionSumSet {
+	var out = make([]*outgoing.MessageReactionSum, len(rr))
 
-			rr[i].UserIDs = append(rr[i].UserIDs, Uint64toa(flag.UserID))
-			rr[i].Count++
+	for i, r := range rr {
+		out[i] = &outgoing.MessageReactionSum{
+			Reaction: r.Reaction,
+			UserIDs:  Uint64stoa(r.UserIDs),
+			Count:    r.Count(),
 		}
+	}
 
-		return nil
-	})
-
-	return rr
+	return out
 }
 
 // Converts slice of mentions into slice of strings containing all user IDs
@@ -100,19 +121,21 @@ func messageMentionSet(mm messagingTypes.MentionSet) outgoing.MessageMentionSet
 	return Uint64stoa(mm.UserIDs())
 }
 
-func MessageReaction(f *messagingTypes.MessageFlag) *outgoing.MessageReaction {
+func MessageReaction(f *messagingTypes.MessageFlag, rr messagingTypes.MessageReactionSumSet) *outgoing.MessageReaction {
 	return &outgoing.MessageReaction{
 		UserID:    f.UserID,
 		MessageID: f.MessageID,
 		Reaction:  f.Flag,
+		Reactions: MessageReactionSums(rr),
 	}
 }
 
-func MessageReactionRemoved(f *messagingTypes.MessageFlag) *outgoing.MessageReactionRemoved {
+func MessageReactionRemoved(f *messagingTypes.MessageFlag, rr messagingTypes.MessageReactionSumSet) *outgoing.MessageReactionRemoved {
 	return &outgoing.MessageReactionRemoved{
 		UserID:    f.UserID,
 		MessageID: f.MessageID,
 		Reaction:  f.Flag,
+		Reactions: MessageReactionSums(rr),
 	}
 }
 
@@ -130,6 +153,76 @@ func MessagePinRemoved(f *messagingTypes.MessageFlag) *outgoing.MessagePinRemove
 	}
 }
 
+// PinnedMessages converts pinned messages with details about the pin
+func PinnedMessages(ctx context.Context, mm messagingTypes.MessageSet) *outgoing.PinnedMessageSet {
+	var out = make([]*outgoing.PinnedMessage, len(mm))
+
+	for i, m := range mm {
+		out[i] = &outgoing.PinnedMessage{Message: Message(ctx, m)}
+
+		if f := m.Flags.Pin(); f != nil {
+			out[i].PinnedBy = f.UserID
+			out[i].PinnedAt = f.CreatedAt
+		}
+	}
+
+	retval := outgoing.PinnedMessageSet(out)
+	return &retval
+}
+
+// SavedItems converts messages bookmarked by the current user
+func SavedItems(ctx context.Context, mm messagingTypes.MessageSet) *outgoing.SavedItemSet {
+	var (
+		currentUserID = auth.GetIdentityFromContext(ctx).Identity()
+		out           = make([]*outgoing.SavedItem, len(mm))
+	)
+
+	for i, m := range mm {
+		out[i] = &outgoing.SavedItem{
+			ChannelID: m.ChannelID,
+			Message:   Message(ctx, m),
+		}
+
+		if f := m.Flags.Bookmark(currentUserID); f != nil {
+			out[i].SavedAt = f.CreatedAt
+		}
+	}
+
+	retval := outgoing.SavedItemSet(out)
+	return &retval
+}
+
+func ScheduledMessage(sm *messagingTypes.ScheduledMessage) *outgoing.ScheduledMessage {
+	return &outgoing.ScheduledMessage{
+		ID:        sm.ID,
+		UserID:    sm.UserID,
+		ChannelID: sm.ChannelID,
+		ReplyTo:   sm.ReplyTo,
+		Message:   sm.Message,
+
+		ScheduledAt: sm.ScheduledAt,
+		Cron:        sm.Cron,
+		Timezone:    sm.Timezone,
+
+		NextRunAt:     sm.NextRunAt,
+		LastRunAt:     sm.LastRunAt,
+		LastMessageID: sm.LastMessageID,
+		LastError:     sm.LastError,
+
+		CreatedAt: sm.CreatedAt,
+		UpdatedAt: sm.UpdatedAt,
+	}
+}
+
+func ScheduledMessages(set messagingTypes.ScheduledMessageSet) *outgoing.ScheduledMessageSet {
+	ss := make([]*outgoing.ScheduledMessage, len(set))
+	for k, sm := range set {
+		ss[k] = ScheduledMessage(sm)
+	}
+	retval := outgoing.ScheduledMessageSet(ss)
+	return &retval
+}
+
 func Channel(ch *messagingTypes.Channel) *outgoing.Channel {
 	var flag = messagingTypes.ChannelMembershipFlagNone
 
@@ -51,6 +51,9 @@ type (
 		MessageID uint64 `json:"messageID,string"`
 		UserID    uint64 `json:"userID,string"`
 		Reaction  string `json:"reaction"`
+
+		// All reactions on the message, after the change
+		Reactions MessageReactionSumSet `json:"reactions"`
 	}
 
 	MessageReactionRemoved MessageReaction
@@ -62,6 +65,24 @@ type (
 	}
 
 	MessagePinRemoved MessagePin
+
+	// Message pinned to the channel with pin details
+	PinnedMessage struct {
+		Message  *Message  `json:"message"`
+		PinnedBy uint64    `json:"pinnedBy,string"`
+		PinnedAt time.Time `json:"pinnedAt"`
+	}
+
+	PinnedMessageSet []*PinnedMessage
+
+	// Message bookmarked by the user
+	SavedItem struct {
+		ChannelID uint64    `json:"channelID,string"`
+		Message   *Message  `json:"message"`
+		SavedAt   time.Time `json:"savedAt"`
+	}
+
+	SavedItemSet []*SavedItem
 )
 
 func (p *Message) EncodeMessage() ([]byte, error) {
@@ -4,11 +4,17 @@ type (
 	Payload struct {
 		*Error `json:"error,omitempty"`
 
+		*Session `json:"session,omitempty"`
+
 		*Message    `json:"message,omitempty"`
 		*MessageSet `json:"messages,omitempty"`
 
 		*Activity `json:"activity,omitempty"`
 
+		*Presence    `json:"presence,omitempty"`
+		*PresenceSet `json:"presences,omitempty"`
+		*Typing      `json:"typing,omitempty"`
+
 		*MessageReaction        `json:"messageReaction,omitempty"`
 		*MessageReactionRemoved `json:"messageReactionRemoved,omitempty"`
 		*MessagePin             `json:"messagePin,omitempty"`
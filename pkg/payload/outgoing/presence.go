@@ -0,0 +1,40 @@
+package outgoing
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type (
+	// User's presence (online, away, dnd, offline) with custom status
+	Presence struct {
+		UserID    uint64     `json:"userID,string"`
+		Status    string     `json:"status"`
+		Icon      string     `json:"icon,omitempty"`
+		Message   string     `json:"message,omitempty"`
+		ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+		UpdatedAt time.Time  `json:"updatedAt"`
+	}
+
+	PresenceSet []*Presence
+
+	// Typing indicator, sent to channel members
+	Typing struct {
+		UserID    uint64 `json:"userID,string"`
+		ChannelID uint64 `json:"channelID,string"`
+		ThreadID  uint64 `json:"threadID,string,omitempty"`
+		Stopped   bool   `json:"stopped,omitempty"`
+	}
+)
+
+func (p *Presence) EncodeMessage() ([]byte, error) {
+	return json.Marshal(Payload{Presence: p})
+}
+
+func (p *PresenceSet) EncodeMessage() ([]byte, error) {
+	return json.Marshal(Payload{PresenceSet: p})
+}
+
+func (p *Typing) EncodeMessage() ([]byte, error) {
+	return json.Marshal(Payload{Typing: p})
+}
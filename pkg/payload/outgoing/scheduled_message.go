@@ -0,0 +1,29 @@
+package outgoing
+
+import (
+	"time"
+)
+
+type (
+	ScheduledMessage struct {
+		ID        uint64 `json:"scheduledMessageID,string"`
+		UserID    uint64 `json:"userID,string"`
+		ChannelID uint64 `json:"channelID,string"`
+		ReplyTo   uint64 `json:"replyTo,omitempty,string"`
+		Message   string `json:"message"`
+
+		ScheduledAt *time.Time `json:"scheduledAt,omitempty"`
+		Cron        string     `json:"cron,omitempty"`
+		Timezone    string     `json:"timezone,omitempty"`
+
+		NextRunAt     *time.Time `json:"nextRunAt,omitempty"`
+		LastRunAt     *time.Time `json:"lastRunAt,omitempty"`
+		LastMessageID uint64     `json:"lastMessageID,omitempty,string"`
+		LastError     string     `json:"lastError,omitempty"`
+
+		CreatedAt time.Time  `json:"createdAt"`
+		UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+	}
+
+	ScheduledMessageSet []*ScheduledMessage
+)
@@ -0,0 +1,26 @@
+package outgoing
+
+import (
+	"encoding/json"
+)
+
+type (
+	// Session is sent when websocket session is (re)established
+	Session struct {
+		// Token to resume the session with after reconnect
+		Token string `json:"token"`
+
+		// Sequence of the last event sent to the user
+		Seq uint64 `json:"seq"`
+
+		// Missed events were replayed
+		Resumed bool `json:"resumed,omitempty"`
+
+		// Session could not be resumed, client should reload everything
+		Resync bool `json:"resync,omitempty"`
+	}
+)
+
+func (p *Session) EncodeMessage() ([]byte, error) {
+	return json.Marshal(Payload{Session: p})
+}
@@ -167,6 +167,23 @@ func (svc service) FindRulesByRoleID(roleID uint64) (rr RuleSet) {
 	return
 }
 
+// FindRulesByResource returns all rules for the given resources
+func (svc service) FindRulesByResource(resources ...Resource) (rr RuleSet) {
+	var rm = make(map[Resource]bool, len(resources))
+	for _, r := range resources {
+		rm[r] = true
+	}
+
+	svc.l.Lock()
+	defer svc.l.Unlock()
+
+	rr, _ = svc.rules.Filter(func(rule *Rule) (b bool, e error) {
+		return rm[rule.Resource], nil
+	})
+
+	return
+}
+
 func (svc *service) Reload(ctx context.Context) {
 	svc.l.Lock()
 	defer svc.l.Unlock()
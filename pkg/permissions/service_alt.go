@@ -34,6 +34,10 @@ func (ServiceAllowAll) FindRulesByRoleID(roleID uint64) (rr RuleSet) {
 	return
 }
 
+func (ServiceAllowAll) FindRulesByResource(...Resource) (rr RuleSet) {
+	return
+}
+
 func (ServiceAllowAll) ResourceFilter(context.Context, Resource, Operation, Access) *ResourceFilter {
 	return &ResourceFilter{superuser: true}
 }
@@ -54,6 +58,10 @@ func (ServiceDenyAll) FindRulesByRoleID(roleID uint64) (rr RuleSet) {
 	return
 }
 
+func (ServiceDenyAll) FindRulesByResource(...Resource) (rr RuleSet) {
+	return
+}
+
 func (svc *TestService) ClearGrants() {
 	svc.repository.Purge()
 	svc.rules = RuleSet{}
@@ -232,7 +232,7 @@ func (p *Parser) parseIdent(t Token) (list ASTNode, err error) {
 
 	i := Ident{Value: t.literal}
 
-	if p.peekToken(1).Is(DOT) {
+	for p.peekToken(1).Is(DOT) {
 		p.nextToken()
 		i.Value += "."
 		l2 := p.nextToken()
@@ -0,0 +1,122 @@
+package scheduler
+
+import (
+	"strings"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gorhill/cronexpr"
+	"github.com/pkg/errors"
+)
+
+type (
+	// cronSchedule is a cron expression evaluated in a specific time zone
+	cronSchedule struct {
+		exp *cronexpr.Expression
+		loc *time.Location
+	}
+)
+
+// Prefixes for setting time zone of the cron expression
+// eg: "CRON_TZ=Europe/Berlin 0 9 * * 1-5"
+var cronTimeZonePrefixes = []string{"CRON_TZ=", "TZ="}
+
+// parseCron parses cron expression with optional time zone prefix
+//
+// Expressions without time zone are evaluated in UTC
+func parseCron(expr string) (s *cronSchedule, err error) {
+	s = &cronSchedule{loc: time.UTC}
+	expr = strings.TrimSpace(expr)
+
+	for _, p := range cronTimeZonePrefixes {
+		if !strings.HasPrefix(expr, p) {
+			continue
+		}
+
+		var (
+			tz   = expr[len(p):]
+			rest string
+		)
+
+		if i := strings.IndexByte(tz, ' '); i > 0 {
+			tz, rest = tz[:i], tz[i+1:]
+		}
+
+		if s.loc, err = time.LoadLocation(tz); err != nil {
+			return nil, errors.Wrapf(err, "invalid time zone in cron expression %q", expr)
+		}
+
+		expr = strings.TrimSpace(rest)
+		break
+	}
+
+	if s.exp, err = cronexpr.Parse(expr); err != nil {
+		return nil, errors.Wrapf(err, "invalid cron expression %q", expr)
+	}
+
+	return s, nil
+}
+
+// next returns first time after t that matches the schedule
+func (s cronSchedule) next(t time.Time) time.Time {
+	return s.exp.Next(t.In(s.loc))
+}
+
+// ValidateCron checks all given cron expressions
+func ValidateCron(ee ...string) error {
+	for _, e := range ee {
+		if _, err := parseCron(e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// OnCron parses all given strings as cron expressions (with optional time zone) and returns
+// true if any of them matches time of the tick that is being dispatched (or current time)
+func OnCron(ee ...string) bool {
+	match, err := onCron(current(), ee...)
+	if err != nil {
+		sentry.CaptureException(err)
+	}
+	return match
+}
+
+func onCron(now time.Time, ee ...string) (bool, error) {
+	var (
+		currTime = now.Truncate(time.Second)
+		cronRef  = currTime.Add(-time.Nanosecond)
+	)
+
+	for _, e := range ee {
+		s, err := parseCron(e)
+		if err != nil {
+			return false, err
+		}
+
+		if currTime.Equal(s.next(cronRef)) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// NextCron returns the earliest time after t when any of the given cron expressions fires
+//
+// Zero time is returned when none of the expressions fires again
+func NextCron(t time.Time, ee ...string) (next time.Time, err error) {
+	for _, e := range ee {
+		s, err := parseCron(e)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if n := s.next(t); !n.IsZero() && (next.IsZero() || n.Before(next)) {
+			next = n
+		}
+	}
+
+	return next, nil
+}
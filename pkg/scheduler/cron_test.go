@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnCron(t *testing.T) {
+	cases := []struct {
+		name  string
+		now   string
+		ee    []string
+		match bool
+		err   bool
+	}{
+		{"empty", "2020-06-01T09:00:00Z", []string{}, false, false},
+		{"utc", "2020-06-01T09:00:00Z", []string{"0 9 * * *"}, true, false},
+		{"time zone", "2020-06-01T07:00:00Z", []string{"CRON_TZ=Europe/Berlin 0 9 * * *"}, true, false},
+		{"time zone mismatch", "2020-06-01T09:00:00Z", []string{"TZ=Europe/Berlin 0 9 * * *"}, false, false},
+		{"any of them", "2020-06-01T09:30:00Z", []string{"0 9 * * *", "30 9 * * 1"}, true, false},
+		{"invalid time zone", "2020-06-01T09:00:00Z", []string{"TZ=Mars/Olympus 0 9 * * *"}, false, true},
+		{"invalid expression", "2020-06-01T09:00:00Z", []string{":P"}, false, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			now, err := time.Parse(time.RFC3339, c.now)
+			assert.NoError(t, err)
+
+			match, err := onCron(now, c.ee...)
+			assert.Equal(t, c.err, err != nil)
+			assert.Equal(t, c.match, match)
+			assert.Equal(t, c.err, ValidateCron(c.ee...) != nil)
+		})
+	}
+
+	// touch exported func
+	OnCron("* * * * *")
+}
+
+func TestNextCron(t *testing.T) {
+	var (
+		a       = assert.New(t)
+		from, _ = time.Parse(time.RFC3339, "2020-06-01T10:00:00Z")
+	)
+
+	next, err := NextCron(from, "CRON_TZ=Europe/Berlin 0 9 * * *", "0 12 * * *")
+	a.NoError(err)
+	a.Equal("2020-06-01T12:00:00Z", next.UTC().Format(time.RFC3339))
+
+	next, err = NextCron(from, "0 9 * * *")
+	a.NoError(err)
+	a.Equal("2020-06-02T09:00:00Z", next.UTC().Format(time.RFC3339))
+
+	_, err = NextCron(from, ":P")
+	a.Error(err)
+}
@@ -7,9 +7,10 @@ import (
 	"github.com/gorhill/cronexpr"
 )
 
-// OnInterval parses all given strings as crontab expressions (ii) and returns true if any of them matches current time
+// OnInterval parses all given strings as crontab expressions (ii) and returns true if any of them matches
+// time of the tick that is being dispatched (or current time)
 func OnInterval(ii ...string) bool {
-	match, err := onInterval(now(), ii...)
+	match, err := onInterval(current(), ii...)
 	if err != nil {
 		sentry.CaptureException(err)
 	}
@@ -40,9 +41,10 @@ func onInterval(now time.Time, ii ...string) (bool, error) {
 	return false, nil
 }
 
-// OnTimestamp parses all given strings as RFC3339 timestamps and returns true if any of them matches current time
+// OnTimestamp parses all given strings as RFC3339 timestamps and returns true if any of them matches
+// time of the tick that is being dispatched (or current time)
 func OnTimestamp(tt ...string) bool {
-	match, err := onTimestamp(now(), tt...)
+	match, err := onTimestamp(current(), tt...)
 	if err != nil {
 		sentry.CaptureException(err)
 	}
@@ -67,3 +69,21 @@ func onTimestamp(now time.Time, tt ...string) (bool, error) {
 
 	return false, nil
 }
+
+// NextTimestamp parses all given strings as RFC3339 timestamps and returns the earliest one after t
+//
+// Zero time is returned when all timestamps are in the past
+func NextTimestamp(t time.Time, tt ...string) (next time.Time, err error) {
+	for _, s := range tt {
+		ts, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if ts.After(t) && (next.IsZero() || ts.Before(next)) {
+			next = ts
+		}
+	}
+
+	return next, nil
+}
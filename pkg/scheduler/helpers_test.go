@@ -139,3 +139,21 @@ func TestOnTimestamp(t *testing.T) {
 	OnTimestamp("2019-10-10T10:15:00Z")
 	OnTimestamp(":P")
 }
+
+func TestNextTimestamp(t *testing.T) {
+	var (
+		a       = assert.New(t)
+		from, _ = time.Parse(time.RFC3339, "2020-06-01T10:00:00Z")
+	)
+
+	next, err := NextTimestamp(from, "2020-06-01T09:00:00Z", "2020-06-03T10:00:00Z", "2020-06-02T10:00:00Z")
+	a.NoError(err)
+	a.Equal("2020-06-02T10:00:00Z", next.Format(time.RFC3339))
+
+	next, err = NextTimestamp(from, "2020-06-01T09:00:00Z")
+	a.NoError(err)
+	a.True(next.IsZero())
+
+	_, err = NextTimestamp(from, ":P")
+	a.Error(err)
+}
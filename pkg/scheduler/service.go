@@ -2,6 +2,8 @@ package scheduler
 
 import (
 	"context"
+	"fmt"
+	"os"
 	"sync"
 	"time"
 
@@ -23,11 +25,43 @@ type (
 
 		// Simple chan to control if service is running or not
 		ticker *time.Ticker
+
+		// Node identifier, used as lease owner
+		node string
+
+		// When set, events are dispatched only while holding the lease
+		store    Store
+		leaseTTL time.Duration
+		catchUp  time.Duration
+
+		// Last known lease holder
+		leader     string
+		leaseUntil time.Time
+
+		// Last dispatched tick for each of the events
+		// used when scheduler is not coordinated through the store
+		lastRuns map[string]time.Time
 	}
 
+	// dispatcher must match handlers synchronously, handlers can be executed asynchronously
 	dispatcher interface {
 		Dispatch(ctx context.Context, ev eventbus.Event)
 	}
+
+	Status struct {
+		Node           string         `json:"node"`
+		Leader         string         `json:"leader,omitempty"`
+		LeaseExpiresAt *time.Time     `json:"leaseExpiresAt,omitempty"`
+		Started        bool           `json:"started"`
+		Events         []*EventStatus `json:"events"`
+	}
+
+	EventStatus struct {
+		ResourceType string     `json:"resourceType"`
+		EventType    string     `json:"eventType"`
+		LastRunAt    *time.Time `json:"lastRunAt,omitempty"`
+		NextRunAt    *time.Time `json:"nextRunAt,omitempty"`
+	}
 )
 
 const (
@@ -42,6 +76,14 @@ var (
 
 	// Global scheduler
 	gScheduler *service
+
+	// Tick that is currently dispatched; interval & timestamp
+	// constraints are matched against it instead of the current time
+	tick  *time.Time
+	tickL = &sync.RWMutex{}
+
+	// Serializes dispatching so that only one tick is matched at the time
+	dispatchL = &sync.Mutex{}
 )
 
 // Setup configures global scheduling service
@@ -70,6 +112,8 @@ func NewService(log *zap.Logger, d dispatcher, interval time.Duration) *service
 		interval:   interval,
 		dispatcher: d,
 		events:     make([]eventbus.Event, 0, maxEvents),
+		node:       nodeID(),
+		lastRuns:   make(map[string]time.Time),
 	}
 
 	return svc
@@ -82,6 +126,24 @@ func (svc *service) OnTick(events ...eventbus.Event) {
 	svc.events = append(svc.events, events...)
 }
 
+// Coordinate makes scheduler dispatch events only while it holds the lease
+//
+// Last dispatched tick of each event is kept in the store; when catchUp is set,
+// ticks missed (while none of the nodes was dispatching) are dispatched
+// on the next tick, up to catchUp in the past
+func (svc *service) Coordinate(s Store, leaseTTL, catchUp time.Duration) {
+	svc.l.Lock()
+	defer svc.l.Unlock()
+
+	if leaseTTL < svc.interval {
+		leaseTTL = svc.interval * 3
+	}
+
+	svc.store = s
+	svc.leaseTTL = leaseTTL
+	svc.catchUp = catchUp
+}
+
 func (svc *service) Stop() {
 
 	if svc.ticker == nil {
@@ -117,6 +179,7 @@ func (svc *service) Start(ctx context.Context) {
 			"starting",
 			zap.Duration("delay", delay),
 			zap.Duration("interval", svc.interval),
+			zap.String("node", svc.node),
 		)
 
 		// Wait until start of the next interval
@@ -130,7 +193,7 @@ func (svc *service) Start(ctx context.Context) {
 	}()
 }
 
-func (svc service) watch(ctx context.Context) {
+func (svc *service) watch(ctx context.Context) {
 	defer sentry.Recover()
 	defer func() {
 		defer svc.log.Debug("stopped")
@@ -141,12 +204,12 @@ func (svc service) watch(ctx context.Context) {
 	}()
 
 	// start with first interval
-	svc.dispatch(ctx)
+	svc.dispatch(ctx, now())
 
 	for {
 		select {
 		case <-svc.ticker.C:
-			svc.dispatch(ctx)
+			svc.dispatch(ctx, now())
 
 		case <-ctx.Done():
 			svc.log.Debug("done")
@@ -162,17 +225,186 @@ func (svc service) Started() (started bool) {
 	return svc.ticker != nil
 }
 
-func (svc service) dispatch(ctx context.Context) {
+// Status returns info about the lease and last & next run of each event
+func (svc *service) Status(ctx context.Context) (s *Status, err error) {
 	svc.l.RLock()
+	var (
+		ee    = make([]eventbus.Event, len(svc.events))
+		runs  = make(map[string]time.Time, len(svc.lastRuns))
+		store = svc.store
+	)
+
+	copy(ee, svc.events)
+	for k, t := range svc.lastRuns {
+		runs[k] = t
+	}
 
-	ee := make([]eventbus.Event, len(svc.events))
-	for e := range svc.events {
-		ee[e] = svc.events[e]
+	s = &Status{
+		Node:    svc.node,
+		Leader:  svc.leader,
+		Started: svc.ticker != nil,
+		Events:  make([]*EventStatus, len(ee)),
 	}
 
-	defer svc.l.RUnlock()
+	if !svc.leaseUntil.IsZero() {
+		until := svc.leaseUntil
+		s.LeaseExpiresAt = &until
+	}
+
+	svc.l.RUnlock()
+
+	if store != nil {
+		if runs, err = store.LastRuns(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var next = now().Truncate(svc.interval).Add(svc.interval)
+	for i, ev := range ee {
+		s.Events[i] = &EventStatus{
+			ResourceType: ev.ResourceType(),
+			EventType:    ev.EventType(),
+		}
+
+		if last, ok := runs[eventKey(ev)]; ok {
+			s.Events[i].LastRunAt = &last
+		}
+
+		if s.Started {
+			s.Events[i].NextRunAt = &next
+		}
+	}
+
+	return s, nil
+}
+
+// dispatch dispatches all events on the tick
+//
+// Ticker fires at (or a few ms after) the start of the interval
+// so the given time is rounded to the closest tick
+func (svc *service) dispatch(ctx context.Context, t time.Time) {
+	svc.l.RLock()
+
+	var (
+		at = t.Round(svc.interval)
+
+		ee    = make([]eventbus.Event, len(svc.events))
+		store = svc.store
+		runs  = svc.lastRuns
+		err   error
+	)
+
+	copy(ee, svc.events)
+	svc.l.RUnlock()
+
+	if store != nil {
+		if !svc.lease(ctx, at) {
+			return
+		}
+
+		if runs, err = store.LastRuns(ctx); err != nil {
+			svc.log.Error("could not load last runs", zap.Error(err))
+			return
+		}
+	}
 
 	for _, ev := range ee {
-		go svc.dispatcher.Dispatch(ctx, ev)
+		var key = eventKey(ev)
+
+		for _, t := range svc.ticks(runs[key], at) {
+			svc.dispatchAt(ctx, ev, t)
+		}
+
+		if store == nil {
+			svc.l.Lock()
+			svc.lastRuns[key] = at
+			svc.l.Unlock()
+		} else if err = store.SetLastRun(ctx, key, at); err != nil {
+			svc.log.Error("could not store last run", zap.String("event", key), zap.Error(err))
+		}
 	}
 }
+
+// lease acquires or renews the lease and returns true if this node holds it
+func (svc *service) lease(ctx context.Context, at time.Time) bool {
+	owner, until, err := svc.store.Lease(ctx, svc.node, at, at.Add(svc.leaseTTL))
+	if err != nil {
+		svc.log.Error("could not acquire lease", zap.Error(err))
+		return false
+	}
+
+	svc.l.Lock()
+	defer svc.l.Unlock()
+
+	if owner == svc.node && svc.leader != svc.node {
+		svc.log.Info("lease acquired, dispatching events", zap.String("node", svc.node))
+	}
+
+	svc.leader, svc.leaseUntil = owner, until
+	return owner == svc.node
+}
+
+// ticks returns all ticks that need to be dispatched, oldest first
+//
+// Nothing is returned when event was already dispatched on this tick;
+// missed ticks are included when catch-up is enabled
+func (svc *service) ticks(last, at time.Time) []time.Time {
+	if !last.Before(at) {
+		return nil
+	}
+
+	var missed int64
+	if svc.catchUp > 0 && !last.IsZero() {
+		missed = int64(at.Sub(last)/svc.interval) - 1
+		if max := int64(svc.catchUp / svc.interval); missed > max {
+			missed = max
+		}
+	}
+
+	var tt = make([]time.Time, 0, missed+1)
+	for k := missed; k >= 0; k-- {
+		tt = append(tt, at.Add(-time.Duration(k)*svc.interval))
+	}
+
+	return tt
+}
+
+// dispatchAt dispatches event with constraints matched against the given tick
+func (svc *service) dispatchAt(ctx context.Context, ev eventbus.Event, at time.Time) {
+	dispatchL.Lock()
+	defer dispatchL.Unlock()
+
+	tickL.Lock()
+	tick = &at
+	tickL.Unlock()
+
+	defer func() {
+		tickL.Lock()
+		tick = nil
+		tickL.Unlock()
+	}()
+
+	svc.dispatcher.Dispatch(ctx, ev)
+}
+
+// current returns time of the dispatched tick or current time
+func current() time.Time {
+	tickL.RLock()
+	defer tickL.RUnlock()
+
+	if tick != nil {
+		return *tick
+	}
+
+	return now()
+}
+
+func eventKey(ev eventbus.Event) string {
+	return ev.ResourceType() + "." + ev.EventType()
+}
+
+// nodeID identifies this process among all nodes
+func nodeID() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d", host, os.Getpid())
+}
@@ -56,3 +56,133 @@ func TestMainServiceFunctions(t *testing.T) {
 	time.Sleep(actionWait)
 	r.False(gScheduler.Started())
 }
+
+type (
+	mockStore struct {
+		owner string
+		until time.Time
+		runs  map[string]time.Time
+	}
+
+	// mockDispatcher records ticks of all dispatched events that match the schedule
+	mockDispatcher struct {
+		schedule string
+		ticks    []time.Time
+	}
+)
+
+func (s *mockStore) Lease(_ context.Context, node string, now, until time.Time) (string, time.Time, error) {
+	if s.owner == "" || s.owner == node || s.until.Before(now) {
+		s.owner, s.until = node, until
+	}
+
+	return s.owner, s.until, nil
+}
+
+func (s *mockStore) LastRuns(context.Context) (map[string]time.Time, error) {
+	var runs = make(map[string]time.Time, len(s.runs))
+	for k, t := range s.runs {
+		runs[k] = t
+	}
+
+	return runs, nil
+}
+
+func (s *mockStore) SetLastRun(_ context.Context, event string, at time.Time) error {
+	if s.runs == nil {
+		s.runs = map[string]time.Time{}
+	}
+
+	if at.After(s.runs[event]) {
+		s.runs[event] = at
+	}
+
+	return nil
+}
+
+func (d *mockDispatcher) Dispatch(_ context.Context, ev eventbus.Event) {
+	if OnInterval(d.schedule) {
+		d.ticks = append(d.ticks, current())
+	}
+}
+
+func mustParseTime(t string) time.Time {
+	tt, _ := time.Parse(time.RFC3339, t)
+	return tt
+}
+
+func TestCoordinatedDispatch(t *testing.T) {
+	var (
+		r     = require.New(t)
+		store = &mockStore{}
+		ev    = &mockEvent{rType: "compose", eType: "onInterval"}
+
+		d1 = &mockDispatcher{schedule: "* * * * *"}
+		d2 = &mockDispatcher{schedule: "* * * * *"}
+
+		n1 = NewService(zap.NewNop(), d1, time.Minute)
+		n2 = NewService(zap.NewNop(), d2, time.Minute)
+
+		tick time.Time
+	)
+
+	n1.node, n2.node = "n1", "n2"
+	n1.Coordinate(store, 3*time.Minute, 0)
+	n2.Coordinate(store, 3*time.Minute, 0)
+	n1.OnTick(ev)
+	n2.OnTick(ev)
+
+	// Only lease holder dispatches
+	tick = mustParseTime("2020-06-01T10:00:00Z")
+	n1.dispatch(context.Background(), tick)
+	n2.dispatch(context.Background(), tick)
+	r.Len(d1.ticks, 1)
+	r.Len(d2.ticks, 0)
+
+	// Ticker fired a bit late; matching is done against the tick
+	tick = mustParseTime("2020-06-01T10:01:02Z")
+	n1.dispatch(context.Background(), tick)
+	n2.dispatch(context.Background(), tick)
+	r.Len(d1.ticks, 2)
+	r.Len(d2.ticks, 0)
+	r.Equal("2020-06-01T10:01:00Z", d1.ticks[1].Format(time.RFC3339))
+
+	// Lease expired, second node takes over
+	tick = mustParseTime("2020-06-01T10:05:00Z")
+	n2.dispatch(context.Background(), tick)
+	n1.dispatch(context.Background(), tick)
+	r.Len(d1.ticks, 2)
+	r.Len(d2.ticks, 1)
+
+	s, err := n1.Status(context.Background())
+	r.NoError(err)
+	r.Equal("n2", s.Leader)
+	r.Len(s.Events, 1)
+	r.Equal("2020-06-01T10:05:00Z", s.Events[0].LastRunAt.Format(time.RFC3339))
+}
+
+func TestCatchUp(t *testing.T) {
+	var (
+		r     = require.New(t)
+		store = &mockStore{runs: map[string]time.Time{}}
+		ev    = &mockEvent{rType: "compose", eType: "onInterval"}
+		d     = &mockDispatcher{schedule: "*/5 * * * *"}
+		svc   = NewService(zap.NewNop(), d, time.Minute)
+		tick  = mustParseTime("2020-06-01T10:30:00Z")
+	)
+
+	store.runs[eventKey(ev)] = mustParseTime("2020-06-01T09:00:00Z")
+
+	svc.Coordinate(store, 0, time.Hour)
+	svc.OnTick(ev)
+
+	// Missed ticks are dispatched only up to one hour back
+	svc.dispatch(context.Background(), tick)
+	r.Len(d.ticks, 13)
+	r.Equal("2020-06-01T09:30:00Z", d.ticks[0].Format(time.RFC3339))
+	r.Equal("2020-06-01T10:30:00Z", d.ticks[12].Format(time.RFC3339))
+
+	// Nothing is dispatched twice
+	svc.dispatch(context.Background(), tick)
+	r.Len(d.ticks, 13)
+}
@@ -0,0 +1,100 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/titpetric/factory"
+)
+
+type (
+	// Store keeps scheduler's lease and last-run state shared by all nodes
+	Store interface {
+		// Lease acquires the lease (when it is free or expired) or renews it (when held by the node)
+		//
+		// Returns current lease holder and expiration of the lease
+		Lease(ctx context.Context, node string, now, until time.Time) (string, time.Time, error)
+
+		// LastRuns returns last dispatched tick of each event
+		LastRuns(ctx context.Context) (map[string]time.Time, error)
+
+		// SetLastRun stores last dispatched tick of the event
+		SetLastRun(ctx context.Context, event string, at time.Time) error
+	}
+
+	store struct {
+		db    *factory.DB
+		lease string
+	}
+)
+
+const (
+	storeLeaseTable = "sys_scheduler_lease"
+	storeStateTable = "sys_scheduler_state"
+)
+
+// NewStore returns database backed scheduler store
+//
+// Nodes share the lease with the given name; each service
+// (or monolith) should use its own lease
+func NewStore(db *factory.DB, lease string) Store {
+	return &store{db: db, lease: lease}
+}
+
+func (s store) Lease(ctx context.Context, node string, now, until time.Time) (owner string, expiresAt time.Time, err error) {
+	var (
+		db = s.db.With(ctx)
+
+		// Assignments are evaluated from left to right so owner is
+		// already updated when new expiration is evaluated
+		upsert = "INSERT INTO " + storeLeaseTable + " (name, owner, expires_at) VALUES (?, ?, ?) " +
+			"ON DUPLICATE KEY UPDATE " +
+			"owner = IF(expires_at < ? OR owner = VALUES(owner), VALUES(owner), owner), " +
+			"expires_at = IF(owner = VALUES(owner), VALUES(expires_at), expires_at)"
+
+		lease = struct {
+			Owner     string    `db:"owner"`
+			ExpiresAt time.Time `db:"expires_at"`
+		}{}
+	)
+
+	if _, err = db.Exec(upsert, s.lease, node, until, now); err != nil {
+		return "", time.Time{}, errors.Wrap(err, "could not update scheduler lease")
+	}
+
+	if err = db.Get(&lease, "SELECT owner, expires_at FROM "+storeLeaseTable+" WHERE name = ?", s.lease); err != nil {
+		return "", time.Time{}, errors.Wrap(err, "could not load scheduler lease")
+	}
+
+	return lease.Owner, lease.ExpiresAt, nil
+}
+
+func (s store) LastRuns(ctx context.Context) (map[string]time.Time, error) {
+	var (
+		rr = []struct {
+			Event     string    `db:"event"`
+			LastRunAt time.Time `db:"last_run_at"`
+		}{}
+
+		runs = map[string]time.Time{}
+	)
+
+	if err := s.db.With(ctx).Select(&rr, "SELECT event, last_run_at FROM "+storeStateTable); err != nil {
+		return nil, errors.Wrap(err, "could not load scheduler state")
+	}
+
+	for _, r := range rr {
+		runs[r.Event] = r.LastRunAt
+	}
+
+	return runs, nil
+}
+
+func (s store) SetLastRun(ctx context.Context, event string, at time.Time) error {
+	const upsert = "INSERT INTO " + storeStateTable + " (event, last_run_at) VALUES (?, ?) " +
+		"ON DUPLICATE KEY UPDATE last_run_at = GREATEST(last_run_at, VALUES(last_run_at))"
+
+	_, err := s.db.With(ctx).Exec(upsert, event, at)
+	return errors.Wrap(err, "could not store scheduler state")
+}
@@ -41,6 +41,7 @@ func (app *App) Setup(log *zap.Logger, opts *app.Options) (err error) {
 	scheduler.Service().OnTick(
 		event.SystemOnInterval(),
 		event.SystemOnTimestamp(),
+		event.SystemOnCron(),
 	)
 
 	corredor.Service().SetUserFinder(service.DefaultUser)
@@ -144,6 +145,7 @@ func (app *App) RegisterCliCommands(p *cobra.Command) {
 		commands.Users(),
 		commands.Roles(),
 		commands.Sink(),
+		commands.Jobs(),
 		// temp command, will be removed in 2020.6
 		automation.ScriptExporter(SERVICE),
 	)
@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/titpetric/factory"
+
+	"github.com/cortezaproject/corteza-server/pkg/app/options"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/cli"
+	"github.com/cortezaproject/corteza-server/pkg/jobs"
+	"github.com/cortezaproject/corteza-server/pkg/logger"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+func Jobs() *cobra.Command {
+	// Job queue management commands.
+	cmd := &cobra.Command{
+		Use:   "jobs",
+		Short: "Job queue management",
+	}
+
+	// List jobs.
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List jobs",
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				ctx = auth.SetSuperUserContext(cli.Context())
+				svc = jobService()
+
+				statusFlag = cmd.Flags().Lookup("status").Value.String()
+				limitFlag  = cmd.Flags().Lookup("limit").Value.String()
+			)
+
+			limit, err := strconv.Atoi(limitFlag)
+			cli.HandleError(err)
+
+			set, _, err := svc.Find(ctx, jobs.JobFilter{
+				Status: jobs.Status(statusFlag),
+				PageFilter: rh.PageFilter{
+					PerPage: uint(limit),
+				},
+			})
+			cli.HandleError(err)
+
+			fmt.Fprintf(
+				cmd.OutOrStdout(),
+				"                     Created    Status     Progress        Service  Kind\n",
+			)
+
+			for _, j := range set {
+				fmt.Fprintf(
+					cmd.OutOrStdout(),
+					"%20d %s %-10s %5d/%5d/%-5d %-8s %s\n",
+					j.ID,
+					j.CreatedAt.Format("2006-01-02"),
+					j.Status,
+					j.Done,
+					j.Failed,
+					j.Total,
+					j.Service,
+					j.Kind,
+				)
+			}
+		},
+	}
+
+	listCmd.Flags().IntP("limit", "l", 20, "How many entry to display")
+	listCmd.Flags().StringP("status", "s", "", "Filter by status (queued, running, succeeded, failed, cancelled)")
+
+	cancelCmd := &cobra.Command{
+		Use:   "cancel [jobID]",
+		Short: "Cancel queued or running job",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				ctx = auth.SetSuperUserContext(cli.Context())
+			)
+
+			jobID, err := strconv.ParseUint(args[0], 10, 64)
+			cli.HandleError(err)
+
+			job, err := jobService().Cancel(ctx, jobID)
+			cli.HandleError(err)
+
+			cmd.Printf("Job %d: %s\n", job.ID, job.Status)
+		},
+	}
+
+	retryCmd := &cobra.Command{
+		Use:   "retry [jobID]",
+		Short: "Put failed or cancelled job back to queue",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var (
+				ctx = auth.SetSuperUserContext(cli.Context())
+			)
+
+			jobID, err := strconv.ParseUint(args[0], 10, 64)
+			cli.HandleError(err)
+
+			job, err := jobService().Retry(ctx, jobID)
+			cli.HandleError(err)
+
+			cmd.Printf("Job %d: %s\n", job.ID, job.Status)
+		},
+	}
+
+	cmd.AddCommand(
+		listCmd,
+		cancelCmd,
+		retryCmd,
+	)
+
+	return cmd
+}
+
+type (
+	jobManager interface {
+		Find(context.Context, jobs.JobFilter) (jobs.JobSet, jobs.JobFilter, error)
+		Cancel(context.Context, uint64) (*jobs.Job, error)
+		Retry(context.Context, uint64) (*jobs.Job, error)
+	}
+)
+
+// Job service without workers, used only to manage the queue
+func jobService() jobManager {
+	svc := jobs.NewService(logger.Default(), options.JobsOpt{})
+	svc.SetStore(jobs.NewStore(factory.Database.MustGet("system", "default")))
+	return svc
+}
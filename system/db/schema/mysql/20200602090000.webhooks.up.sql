@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS sys_webhook (
+    `id`            BIGINT(20)   UNSIGNED NOT NULL,
+    `name`          VARCHAR(64)           NOT NULL                           COMMENT 'Name of the webhook',
+    `url`           VARCHAR(512)          NOT NULL                           COMMENT 'Where the events are delivered to',
+    `secret`        VARCHAR(128)          NOT NULL                           COMMENT 'Secret for signing payloads',
+    `resource_type` VARCHAR(64)           NOT NULL                           COMMENT 'Resource type (eg: compose:record)',
+    `event_types`   JSON                  NOT NULL                           COMMENT 'Event types (all when empty)',
+    `constraints`   JSON                  NOT NULL                           COMMENT 'Event constraints',
+    `enabled`       BOOLEAN               NOT NULL DEFAULT TRUE,
+
+    `created_by`    BIGINT(20)   UNSIGNED NOT NULL DEFAULT 0,
+    `created_at`    DATETIME              NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`    DATETIME                  NULL DEFAULT NULL,
+    `deleted_at`    DATETIME                  NULL DEFAULT NULL,
+
+    PRIMARY KEY (`id`),
+    INDEX (`resource_type`)
+
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE IF NOT EXISTS sys_webhook_delivery (
+    `id`               BIGINT(20)   UNSIGNED NOT NULL,
+    `rel_webhook`      BIGINT(20)   UNSIGNED NOT NULL,
+    `event_type`       VARCHAR(64)           NOT NULL,
+    `payload`          JSON                  NOT NULL                           COMMENT 'Encoded event',
+
+    `status`           VARCHAR(16)           NOT NULL                           COMMENT 'pending, delivered or failed',
+    `attempts`         INT                   NOT NULL DEFAULT 0                 COMMENT 'Number of delivery attempts',
+    `next_attempt_at`  DATETIME                  NULL DEFAULT NULL,
+    `last_status_code` INT                   NOT NULL DEFAULT 0                 COMMENT 'HTTP status of the last attempt',
+    `last_error`       TEXT                  NOT NULL,
+
+    `created_at`       DATETIME              NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `delivered_at`     DATETIME                  NULL DEFAULT NULL,
+
+    PRIMARY KEY (`id`),
+    INDEX (`rel_webhook`),
+    INDEX (`status`, `next_attempt_at`)
+
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
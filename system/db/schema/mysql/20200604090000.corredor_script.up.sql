@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS sys_corredor_script (
+    `name`       VARCHAR(255) NOT NULL COMMENT 'Script name, unique across all embedded scripts',
+    `source`     MEDIUMTEXT   NOT NULL COMMENT 'Source code of the server script',
+    `enabled`    BOOLEAN      NOT NULL DEFAULT TRUE,
+
+    `created_at` DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` DATETIME         NULL DEFAULT NULL,
+
+    PRIMARY KEY (`name`)
+
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
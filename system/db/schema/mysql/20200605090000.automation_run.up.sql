@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS sys_automation_run (
+    `id`            BIGINT(20)   UNSIGNED NOT NULL,
+    `script`        VARCHAR(255)          NOT NULL,
+    `resource_type` VARCHAR(64)           NOT NULL,
+    `event_type`    VARCHAR(64)           NOT NULL,
+    `resource_id`   BIGINT(20)   UNSIGNED NOT NULL DEFAULT 0,
+    `invoker_id`    BIGINT(20)   UNSIGNED NOT NULL DEFAULT 0 COMMENT 'User that triggered the script',
+    `run_as_id`     BIGINT(20)   UNSIGNED NOT NULL DEFAULT 0 COMMENT 'User that script was running as',
+    `status`        VARCHAR(16)           NOT NULL COMMENT 'succeeded, aborted, failed',
+    `error`         TEXT                  NOT NULL,
+    `logs`          TEXT                  NOT NULL COMMENT 'Script logs (truncated)',
+    `duration`      INT(10)      UNSIGNED NOT NULL DEFAULT 0 COMMENT 'Execution time in milliseconds',
+    `created_at`    DATETIME              NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (`id`),
+    KEY `script` (`script`, `created_at`),
+    KEY `resource` (`resource_type`, `resource_id`),
+    KEY `created_at` (`created_at`)
+
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS sys_job (
+    `id`               BIGINT(20)   UNSIGNED NOT NULL,
+    `service`          VARCHAR(32)           NOT NULL COMMENT 'compose, system...',
+    `kind`             VARCHAR(64)           NOT NULL COMMENT 'record.import, record.export...',
+    `status`           VARCHAR(16)           NOT NULL COMMENT 'queued, running, succeeded, failed, cancelled',
+    `params`           TEXT                  NOT NULL COMMENT 'Job parameters (JSON)',
+    `input`            LONGBLOB                  NULL COMMENT 'Job input (uploaded file...)',
+    `result`           LONGBLOB                  NULL COMMENT 'Job result (exported file...)',
+    `result_name`      VARCHAR(255)          NOT NULL DEFAULT '',
+    `result_type`      VARCHAR(128)          NOT NULL DEFAULT '',
+    `result_size`      INT(10)      UNSIGNED NOT NULL DEFAULT 0,
+    `total`            INT(10)      UNSIGNED NOT NULL DEFAULT 0,
+    `done`             INT(10)      UNSIGNED NOT NULL DEFAULT 0,
+    `failed`           INT(10)      UNSIGNED NOT NULL DEFAULT 0,
+    `error`            TEXT                  NOT NULL,
+    `attempts`         INT(10)      UNSIGNED NOT NULL DEFAULT 0,
+    `max_attempts`     INT(10)      UNSIGNED NOT NULL DEFAULT 1,
+    `cancel_requested` BOOLEAN               NOT NULL DEFAULT FALSE,
+    `node`             VARCHAR(255)          NOT NULL DEFAULT '' COMMENT 'Node that is running the job',
+    `owned_by`         BIGINT(20)   UNSIGNED NOT NULL DEFAULT 0 COMMENT 'User that enqueued the job',
+    `owner_roles`      TEXT                  NOT NULL COMMENT 'Roles of the owner when job was enqueued',
+    `run_after`        DATETIME              NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `created_at`       DATETIME              NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `started_at`       DATETIME                  NULL DEFAULT NULL,
+    `finished_at`      DATETIME                  NULL DEFAULT NULL,
+    `updated_at`       DATETIME                  NULL DEFAULT NULL,
+
+    PRIMARY KEY (`id`),
+    KEY `queue` (`service`, `status`, `run_after`),
+    KEY `owner` (`owned_by`),
+    KEY `created_at` (`created_at`)
+
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
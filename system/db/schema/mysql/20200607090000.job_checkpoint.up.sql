@@ -0,0 +1 @@
+ALTER TABLE `sys_job` ADD `checkpoint` TEXT NULL AFTER `params`;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS sys_sink_nonce (
+    `route`      VARCHAR(64)  NOT NULL COMMENT 'Name of the sink route',
+    `nonce`      VARCHAR(128) NOT NULL,
+    `expires_at` DATETIME     NOT NULL,
+
+    PRIMARY KEY (`route`, `nonce`),
+    KEY `expires_at` (`expires_at`)
+
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
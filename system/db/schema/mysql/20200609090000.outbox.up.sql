@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS sys_outbox (
+    `id`            BIGINT(20)  UNSIGNED NOT NULL,
+    `resource_type` VARCHAR(64)          NOT NULL COMMENT 'Resource type (eg: system:...)',
+    `event_type`    VARCHAR(64)          NOT NULL,
+    `args`          LONGBLOB             NOT NULL COMMENT 'Encoded event arguments',
+    `created_at`    DATETIME             NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `claimed_until` DATETIME                 NULL DEFAULT NULL COMMENT 'Event is being relayed by one of the nodes',
+
+    PRIMARY KEY (`id`)
+
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
@@ -0,0 +1 @@
+ALTER TABLE `sys_job` DROP `result`;
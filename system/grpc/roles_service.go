@@ -43,3 +43,28 @@ func (gs roleService) Find(ctx context.Context, req *proto.FindRoleRequest) (rsp
 
 	return
 }
+
+// FindMembers returns IDs of users that are members of any of the given roles
+func (gs roleService) FindMembers(ctx context.Context, req *proto.FindMembersRoleRequest) (rsp *proto.FindMembersRoleResponse, err error) {
+	var (
+		mm   []*types.RoleMember
+		seen = map[uint64]bool{}
+	)
+
+	rsp = &proto.FindMembersRoleResponse{}
+
+	for _, roleID := range req.RoleID {
+		if mm, err = gs.roles.With(ctx).MemberList(roleID); err != nil {
+			return nil, err
+		}
+
+		for _, m := range mm {
+			if !seen[m.UserID] {
+				seen[m.UserID] = true
+				rsp.UserID = append(rsp.UserID, m.UserID)
+			}
+		}
+	}
+
+	return
+}
@@ -149,28 +149,112 @@ func (m *Role) GetName() string {
 	return ""
 }
 
+type FindMembersRoleRequest struct {
+	RoleID               []uint64 `protobuf:"varint,1,rep,packed,name=roleID,proto3" json:"roleID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FindMembersRoleRequest) Reset()         { *m = FindMembersRoleRequest{} }
+func (m *FindMembersRoleRequest) String() string { return proto.CompactTextString(m) }
+func (*FindMembersRoleRequest) ProtoMessage()    {}
+func (*FindMembersRoleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_48a3ff9f7c9032f8, []int{3}
+}
+
+func (m *FindMembersRoleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindMembersRoleRequest.Unmarshal(m, b)
+}
+func (m *FindMembersRoleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindMembersRoleRequest.Marshal(b, m, deterministic)
+}
+func (m *FindMembersRoleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindMembersRoleRequest.Merge(m, src)
+}
+func (m *FindMembersRoleRequest) XXX_Size() int {
+	return xxx_messageInfo_FindMembersRoleRequest.Size(m)
+}
+func (m *FindMembersRoleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindMembersRoleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindMembersRoleRequest proto.InternalMessageInfo
+
+func (m *FindMembersRoleRequest) GetRoleID() []uint64 {
+	if m != nil {
+		return m.RoleID
+	}
+	return nil
+}
+
+type FindMembersRoleResponse struct {
+	UserID               []uint64 `protobuf:"varint,1,rep,packed,name=userID,proto3" json:"userID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FindMembersRoleResponse) Reset()         { *m = FindMembersRoleResponse{} }
+func (m *FindMembersRoleResponse) String() string { return proto.CompactTextString(m) }
+func (*FindMembersRoleResponse) ProtoMessage()    {}
+func (*FindMembersRoleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_48a3ff9f7c9032f8, []int{4}
+}
+
+func (m *FindMembersRoleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindMembersRoleResponse.Unmarshal(m, b)
+}
+func (m *FindMembersRoleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindMembersRoleResponse.Marshal(b, m, deterministic)
+}
+func (m *FindMembersRoleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindMembersRoleResponse.Merge(m, src)
+}
+func (m *FindMembersRoleResponse) XXX_Size() int {
+	return xxx_messageInfo_FindMembersRoleResponse.Size(m)
+}
+func (m *FindMembersRoleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindMembersRoleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindMembersRoleResponse proto.InternalMessageInfo
+
+func (m *FindMembersRoleResponse) GetUserID() []uint64 {
+	if m != nil {
+		return m.UserID
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*FindRoleRequest)(nil), "system.FindRoleRequest")
 	proto.RegisterType((*FindRoleResponse)(nil), "system.FindRoleResponse")
 	proto.RegisterType((*Role)(nil), "system.Role")
+	proto.RegisterType((*FindMembersRoleRequest)(nil), "system.FindMembersRoleRequest")
+	proto.RegisterType((*FindMembersRoleResponse)(nil), "system.FindMembersRoleResponse")
 }
 
 func init() { proto.RegisterFile("role.proto", fileDescriptor_48a3ff9f7c9032f8) }
 
 var fileDescriptor_48a3ff9f7c9032f8 = []byte{
-	// 189 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2a, 0xca, 0xcf, 0x49,
-	0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2b, 0xae, 0x2c, 0x2e, 0x49, 0xcd, 0x55, 0x12,
-	0xe4, 0xe2, 0x77, 0xcb, 0xcc, 0x4b, 0x09, 0xca, 0xcf, 0x49, 0x0d, 0x4a, 0x2d, 0x2c, 0x4d, 0x2d,
-	0x2e, 0x51, 0x32, 0xe3, 0x12, 0x40, 0x08, 0x15, 0x17, 0xe4, 0xe7, 0x15, 0xa7, 0x0a, 0x29, 0x71,
-	0xb1, 0x82, 0x34, 0x17, 0x4b, 0x30, 0x2a, 0x30, 0x6b, 0x70, 0x1b, 0xf1, 0xe8, 0x41, 0xb4, 0xeb,
-	0x81, 0x15, 0x41, 0xa4, 0x94, 0x9c, 0xb8, 0x58, 0x40, 0x5c, 0x21, 0x3e, 0x2e, 0x26, 0x4f, 0x17,
-	0x09, 0x46, 0x05, 0x46, 0x0d, 0x96, 0x20, 0x26, 0x4f, 0x17, 0x21, 0x31, 0x2e, 0xb6, 0x8c, 0xc4,
-	0xbc, 0x94, 0x9c, 0x54, 0x09, 0x26, 0x05, 0x46, 0x0d, 0xce, 0x20, 0x28, 0x4f, 0x48, 0x88, 0x8b,
-	0x25, 0x2f, 0x31, 0x37, 0x55, 0x82, 0x19, 0x2c, 0x0a, 0x66, 0x1b, 0x39, 0x71, 0xb1, 0x82, 0xcc,
-	0x28, 0x16, 0xb2, 0xe4, 0x62, 0x01, 0x39, 0x42, 0x48, 0x1c, 0x66, 0x13, 0x9a, 0x2b, 0xa5, 0x24,
-	0x30, 0x25, 0x20, 0x6e, 0x75, 0x62, 0x8f, 0x62, 0x05, 0xfb, 0x31, 0x89, 0x0d, 0x4c, 0x19, 0x03,
-	0x02, 0x00, 0x00, 0xff, 0xff, 0x80, 0x25, 0xf0, 0xe7, 0xf8, 0x00, 0x00, 0x00,
+	// 248 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x51, 0x4f, 0x4b, 0xc3, 0x30,
+	0x14, 0x27, 0x6d, 0x5a, 0xf1, 0x4d, 0xfc, 0xf3, 0x0e, 0x5d, 0xd8, 0x41, 0x4b, 0x4e, 0x3d, 0x15,
+	0x9d, 0x20, 0x78, 0x1d, 0x43, 0xd8, 0x41, 0x0f, 0x39, 0x7a, 0xdb, 0xd8, 0x03, 0x85, 0xb6, 0x99,
+	0x7d, 0xdd, 0xc1, 0xaf, 0xe1, 0x27, 0x96, 0xa4, 0x91, 0x16, 0xcb, 0x4e, 0xed, 0xef, 0xbd, 0xdf,
+	0xbf, 0x24, 0x00, 0xad, 0xad, 0xa8, 0x3c, 0xb4, 0xb6, 0xb3, 0x98, 0xf2, 0x37, 0x77, 0x54, 0xeb,
+	0x1b, 0xb8, 0x7a, 0xf9, 0x6c, 0xf6, 0xc6, 0x56, 0x64, 0xe8, 0xeb, 0x48, 0xdc, 0xe9, 0x27, 0xb8,
+	0x1e, 0x46, 0x7c, 0xb0, 0x0d, 0x13, 0x6a, 0x48, 0x9c, 0x98, 0x95, 0xc8, 0xe3, 0x62, 0xb6, 0xbc,
+	0x28, 0x7b, 0x79, 0xe9, 0x49, 0xfd, 0x4a, 0xaf, 0x40, 0x3a, 0x88, 0x97, 0x10, 0x6d, 0xd6, 0x4a,
+	0xe4, 0xa2, 0x90, 0x26, 0xda, 0xac, 0x31, 0x83, 0xf4, 0x63, 0xdb, 0xec, 0x2b, 0x52, 0x51, 0x2e,
+	0x8a, 0x73, 0x13, 0x10, 0x22, 0xc8, 0x66, 0x5b, 0x93, 0x8a, 0xfd, 0xd4, 0xff, 0xeb, 0x7b, 0xc8,
+	0x5c, 0xf6, 0x2b, 0xd5, 0x3b, 0x6a, 0x79, 0xd4, 0xca, 0xb9, 0xb8, 0x18, 0xef, 0x1c, 0x17, 0xd2,
+	0x04, 0xa4, 0x1f, 0x60, 0x3e, 0x51, 0x84, 0xd2, 0x19, 0xa4, 0x47, 0xa6, 0x76, 0x90, 0xf4, 0x68,
+	0xf9, 0x23, 0x20, 0x71, 0x44, 0xc6, 0x67, 0x90, 0x4e, 0x8c, 0xf3, 0xbf, 0xf3, 0xfc, 0xbb, 0x8b,
+	0x85, 0x9a, 0x2e, 0x82, 0xf9, 0x1b, 0xcc, 0x46, 0xb9, 0x78, 0x3b, 0x26, 0x4e, 0xeb, 0x2f, 0xee,
+	0x4e, 0xee, 0x7b, 0xbf, 0xd5, 0xd9, 0x7b, 0xe2, 0x5f, 0x66, 0x97, 0xfa, 0xcf, 0xe3, 0xef, 0x00,
+	0x60, 0x0e, 0x64, 0xef, 0xae, 0x01, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -186,6 +270,7 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type RolesClient interface {
 	Find(ctx context.Context, in *FindRoleRequest, opts ...grpc.CallOption) (*FindRoleResponse, error)
+	FindMembers(ctx context.Context, in *FindMembersRoleRequest, opts ...grpc.CallOption) (*FindMembersRoleResponse, error)
 }
 
 type rolesClient struct {
@@ -205,9 +290,19 @@ func (c *rolesClient) Find(ctx context.Context, in *FindRoleRequest, opts ...grp
 	return out, nil
 }
 
+func (c *rolesClient) FindMembers(ctx context.Context, in *FindMembersRoleRequest, opts ...grpc.CallOption) (*FindMembersRoleResponse, error) {
+	out := new(FindMembersRoleResponse)
+	err := c.cc.Invoke(ctx, "/system.Roles/FindMembers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RolesServer is the server API for Roles service.
 type RolesServer interface {
 	Find(context.Context, *FindRoleRequest) (*FindRoleResponse, error)
+	FindMembers(context.Context, *FindMembersRoleRequest) (*FindMembersRoleResponse, error)
 }
 
 // UnimplementedRolesServer can be embedded to have forward compatible implementations.
@@ -217,6 +312,9 @@ type UnimplementedRolesServer struct {
 func (*UnimplementedRolesServer) Find(ctx context.Context, req *FindRoleRequest) (*FindRoleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Find not implemented")
 }
+func (*UnimplementedRolesServer) FindMembers(ctx context.Context, req *FindMembersRoleRequest) (*FindMembersRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindMembers not implemented")
+}
 
 func RegisterRolesServer(s *grpc.Server, srv RolesServer) {
 	s.RegisterService(&_Roles_serviceDesc, srv)
@@ -240,6 +338,24 @@ func _Roles_Find_Handler(srv interface{}, ctx context.Context, dec func(interfac
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Roles_FindMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindMembersRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RolesServer).FindMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/system.Roles/FindMembers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RolesServer).FindMembers(ctx, req.(*FindMembersRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Roles_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "system.Roles",
 	HandlerType: (*RolesServer)(nil),
@@ -248,6 +364,10 @@ var _Roles_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Find",
 			Handler:    _Roles_Find_Handler,
 		},
+		{
+			MethodName: "FindMembers",
+			Handler:    _Roles_FindMembers_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "role.proto",
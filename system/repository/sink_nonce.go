@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/titpetric/factory"
+)
+
+type (
+	SinkNonceRepository interface {
+		Use(route, nonce string, expiresAt time.Time) (bool, error)
+		DeleteExpired(now time.Time) (uint, error)
+	}
+
+	sinkNonce struct {
+		*repository
+	}
+)
+
+func SinkNonce(ctx context.Context, db *factory.DB) SinkNonceRepository {
+	return (&sinkNonce{}).With(ctx, db)
+}
+
+func (r sinkNonce) With(ctx context.Context, db *factory.DB) SinkNonceRepository {
+	return &sinkNonce{
+		repository: r.repository.With(ctx, db),
+	}
+}
+
+func (r sinkNonce) table() string {
+	return "sys_sink_nonce"
+}
+
+// Use stores nonce for the route and returns false if nonce was already used
+func (r sinkNonce) Use(route, nonce string, expiresAt time.Time) (bool, error) {
+	res, err := r.db().Exec(
+		"INSERT IGNORE INTO "+r.table()+" (route, nonce, expires_at) VALUES (?, ?, ?)",
+		route,
+		nonce,
+		expiresAt,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// DeleteExpired removes nonces that expired before the given time
+func (r sinkNonce) DeleteExpired(now time.Time) (uint, error) {
+	res, err := r.db().Exec("DELETE FROM "+r.table()+" WHERE expires_at < ?", now)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := res.RowsAffected()
+	return uint(n), err
+}
@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/titpetric/factory"
+
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+	"github.com/cortezaproject/corteza-server/system/types"
+)
+
+type (
+	WebhookRepository interface {
+		With(ctx context.Context, db *factory.DB) WebhookRepository
+
+		FindByID(ID uint64) (*types.Webhook, error)
+		Find(filter types.WebhookFilter) (set types.WebhookSet, f types.WebhookFilter, err error)
+		FindEnabled(resourceType string) (set types.WebhookSet, err error)
+
+		Create(mod *types.Webhook) (*types.Webhook, error)
+		Update(mod *types.Webhook) (*types.Webhook, error)
+		DeleteByID(ID uint64) error
+
+		FindDeliveryByID(ID uint64) (*types.WebhookDelivery, error)
+		FindDeliveries(filter types.WebhookDeliveryFilter) (set types.WebhookDeliverySet, f types.WebhookDeliveryFilter, err error)
+		FindDueDeliveries(now time.Time, limit uint) (set types.WebhookDeliverySet, err error)
+		ClaimDelivery(ID uint64, now, until time.Time) (bool, error)
+
+		CreateDelivery(mod *types.WebhookDelivery) (*types.WebhookDelivery, error)
+		UpdateDelivery(mod *types.WebhookDelivery) (*types.WebhookDelivery, error)
+	}
+
+	webhook struct {
+		*repository
+	}
+)
+
+const (
+	ErrWebhookNotFound         = repositoryError("WebhookNotFound")
+	ErrWebhookDeliveryNotFound = repositoryError("WebhookDeliveryNotFound")
+)
+
+func Webhook(ctx context.Context, db *factory.DB) WebhookRepository {
+	return (&webhook{}).With(ctx, db)
+}
+
+func (r webhook) With(ctx context.Context, db *factory.DB) WebhookRepository {
+	return &webhook{
+		repository: r.repository.With(ctx, db),
+	}
+}
+
+func (r webhook) table() string {
+	return "sys_webhook"
+}
+
+func (r webhook) tableDelivery() string {
+	return "sys_webhook_delivery"
+}
+
+func (r webhook) columns() []string {
+	return []string{
+		"w.id",
+		"w.name",
+		"w.url",
+		"w.secret",
+		"w.resource_type",
+		"w.event_types",
+		"w.constraints",
+		"w.enabled",
+		"w.created_at",
+		"w.created_by",
+		"w.updated_at",
+		"w.deleted_at",
+	}
+}
+
+func (r webhook) columnsDelivery() []string {
+	return []string{
+		"d.id",
+		"d.rel_webhook",
+		"d.event_type",
+		"d.payload",
+		"d.status",
+		"d.attempts",
+		"d.next_attempt_at",
+		"d.last_status_code",
+		"d.last_error",
+		"d.created_at",
+		"d.delivered_at",
+	}
+}
+
+func (r webhook) query() squirrel.SelectBuilder {
+	return squirrel.
+		Select(r.columns()...).
+		From(r.table() + " AS w").
+		Where("w.deleted_at IS NULL")
+}
+
+func (r webhook) queryDelivery() squirrel.SelectBuilder {
+	return squirrel.
+		Select(r.columnsDelivery()...).
+		From(r.tableDelivery() + " AS d")
+}
+
+func (r webhook) FindByID(ID uint64) (*types.Webhook, error) {
+	var (
+		w = &types.Webhook{}
+
+		q = r.query().
+			Where(squirrel.Eq{"w.id": ID})
+	)
+
+	if err := rh.FetchOne(r.db(), q, w); err != nil {
+		return nil, err
+	} else if w.ID == 0 {
+		return nil, ErrWebhookNotFound
+	}
+
+	return w, nil
+}
+
+func (r webhook) Find(filter types.WebhookFilter) (set types.WebhookSet, f types.WebhookFilter, err error) {
+	f = filter
+
+	if f.Sort == "" {
+		f.Sort = "w.id"
+	}
+
+	query := r.query()
+
+	if f.ResourceType != "" {
+		query = query.Where(squirrel.Eq{"w.resource_type": f.ResourceType})
+	}
+
+	if f.Query != "" {
+		q := "%" + f.Query + "%"
+		query = query.Where(squirrel.Or{
+			squirrel.Like{"w.name": q},
+			squirrel.Like{"w.url": q},
+		})
+	}
+
+	var orderBy []string
+	if orderBy, err = rh.ParseOrder(f.Sort, r.columns()...); err != nil {
+		return
+	} else {
+		query = query.OrderBy(orderBy...)
+	}
+
+	if f.Count, err = rh.Count(r.db(), query); err != nil || f.Count == 0 {
+		return
+	}
+
+	return set, f, rh.FetchPaged(r.db(), query, f.PageFilter, &set)
+}
+
+// FindEnabled returns all enabled webhooks for the resource type
+func (r webhook) FindEnabled(resourceType string) (set types.WebhookSet, err error) {
+	var query = r.query().
+		Where(squirrel.Eq{"w.resource_type": resourceType, "w.enabled": true})
+
+	return set, rh.FetchAll(r.db(), query, &set)
+}
+
+func (r webhook) Create(mod *types.Webhook) (*types.Webhook, error) {
+	mod.ID = factory.Sonyflake.NextID()
+	rh.SetCurrentTimeRounded(&mod.CreatedAt)
+	mod.UpdatedAt = nil
+
+	return mod, r.db().Insert(r.table(), mod)
+}
+
+func (r webhook) Update(mod *types.Webhook) (*types.Webhook, error) {
+	rh.SetCurrentTimeRounded(&mod.UpdatedAt)
+	return mod, r.db().Replace(r.table(), mod)
+}
+
+func (r webhook) DeleteByID(ID uint64) error {
+	return r.updateColumnByID(r.table(), "deleted_at", time.Now(), ID)
+}
+
+func (r webhook) FindDeliveryByID(ID uint64) (*types.WebhookDelivery, error) {
+	var (
+		d = &types.WebhookDelivery{}
+
+		q = r.queryDelivery().
+			Where(squirrel.Eq{"d.id": ID})
+	)
+
+	if err := rh.FetchOne(r.db(), q, d); err != nil {
+		return nil, err
+	} else if d.ID == 0 {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+
+	return d, nil
+}
+
+func (r webhook) FindDeliveries(filter types.WebhookDeliveryFilter) (set types.WebhookDeliverySet, f types.WebhookDeliveryFilter, err error) {
+	f = filter
+
+	if f.Sort == "" {
+		f.Sort = "d.id DESC"
+	}
+
+	query := r.queryDelivery()
+
+	if f.WebhookID > 0 {
+		query = query.Where(squirrel.Eq{"d.rel_webhook": f.WebhookID})
+	}
+
+	if f.Status != "" {
+		query = query.Where(squirrel.Eq{"d.status": f.Status})
+	}
+
+	var orderBy []string
+	if orderBy, err = rh.ParseOrder(f.Sort, r.columnsDelivery()...); err != nil {
+		return
+	} else {
+		query = query.OrderBy(orderBy...)
+	}
+
+	if f.Count, err = rh.Count(r.db(), query); err != nil || f.Count == 0 {
+		return
+	}
+
+	return set, f, rh.FetchPaged(r.db(), query, f.PageFilter, &set)
+}
+
+// FindDueDeliveries returns pending deliveries that should be attempted
+func (r webhook) FindDueDeliveries(now time.Time, limit uint) (set types.WebhookDeliverySet, err error) {
+	var query = r.queryDelivery().
+		Where(squirrel.Eq{"d.status": types.WebhookDeliveryPending}).
+		Where(squirrel.LtOrEq{"d.next_attempt_at": now}).
+		OrderBy("d.next_attempt_at").
+		Limit(uint64(limit))
+
+	return set, rh.FetchAll(r.db(), query, &set)
+}
+
+// ClaimDelivery postpones next attempt of the pending delivery that is due
+//
+// Returns false when delivery was already claimed (by another node);
+// delivery that is not updated before the claim expires is attempted again
+func (r webhook) ClaimDelivery(ID uint64, now, until time.Time) (bool, error) {
+	rsp, err := squirrel.ExecWith(r.db(), squirrel.Update(r.tableDelivery()).
+		Set("next_attempt_at", until).
+		Where(squirrel.Eq{"id": ID, "status": types.WebhookDeliveryPending}).
+		Where(squirrel.LtOrEq{"next_attempt_at": now}))
+
+	if err != nil {
+		return false, err
+	}
+
+	n, err := rsp.RowsAffected()
+	return n > 0, err
+}
+
+func (r webhook) CreateDelivery(mod *types.WebhookDelivery) (*types.WebhookDelivery, error) {
+	mod.ID = factory.Sonyflake.NextID()
+	rh.SetCurrentTimeRounded(&mod.CreatedAt)
+
+	return mod, r.db().Insert(r.tableDelivery(), mod)
+}
+
+func (r webhook) UpdateDelivery(mod *types.WebhookDelivery) (*types.WebhookDelivery, error) {
+	return mod, r.db().Replace(r.tableDelivery(), mod)
+}
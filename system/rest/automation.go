@@ -7,18 +7,35 @@ import (
 	"github.com/titpetric/factory/resputil"
 
 	"github.com/cortezaproject/corteza-server/pkg/corredor"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+	"github.com/cortezaproject/corteza-server/pkg/scheduler"
 	"github.com/cortezaproject/corteza-server/system/rest/request"
+	"github.com/cortezaproject/corteza-server/system/service"
 	"github.com/cortezaproject/corteza-server/system/service/event"
 )
 
 var _ = errors.Wrap
 
 type (
-	Automation struct{}
+	Automation struct {
+		ac automationAccessController
+	}
+
+	automationAccessController interface {
+		CanAccess(context.Context) bool
+	}
+
+	automationRunSetPayload struct {
+		Filter corredor.RunFilter `json:"filter"`
+		Set    corredor.RunSet    `json:"set"`
+	}
 )
 
 func (Automation) New() *Automation {
-	return &Automation{}
+	return &Automation{
+		ac: service.DefaultAccessControl,
+	}
 }
 
 func (ctrl *Automation) List(ctx context.Context, r *request.AutomationList) (interface{}, error) {
@@ -50,3 +67,48 @@ func (ctrl *Automation) Bundle(ctx context.Context, r *request.AutomationBundle)
 func (ctrl *Automation) TriggerScript(ctx context.Context, r *request.AutomationTriggerScript) (interface{}, error) {
 	return resputil.OK(), corredor.Service().Exec(ctx, r.Script, event.SystemOnManual())
 }
+
+// Scheduler returns status of the scheduler on this node
+func (ctrl *Automation) Scheduler(ctx context.Context, r *request.AutomationScheduler) (interface{}, error) {
+	if !ctrl.ac.CanAccess(ctx) {
+		return nil, service.ErrNoPermissions
+	}
+
+	return scheduler.Service().Status(ctx)
+}
+
+// Handlers returns all event handlers registered on this node
+func (ctrl *Automation) Handlers(ctx context.Context, r *request.AutomationHandlers) (interface{}, error) {
+	if !ctrl.ac.CanAccess(ctx) {
+		return nil, service.ErrNoPermissions
+	}
+
+	return eventbus.Service().Handlers(), nil
+}
+
+// Runs returns history of script executions
+func (ctrl *Automation) Runs(ctx context.Context, r *request.AutomationRuns) (interface{}, error) {
+	if !ctrl.ac.CanAccess(ctx) {
+		return nil, service.ErrNoPermissions
+	}
+
+	f := corredor.RunFilter{
+		Script:       r.Script,
+		ResourceType: r.ResourceType,
+		EventType:    r.EventType,
+		ResourceID:   r.ResourceID,
+		Status:       corredor.RunStatus(r.Status),
+		From:         r.From,
+		To:           r.To,
+
+		Sort:       rh.NormalizeSortColumns(r.Sort),
+		PageFilter: rh.Paging(r),
+	}
+
+	set, filter, err := corredor.Service().FindRuns(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	return &automationRunSetPayload{Filter: filter, Set: set}, nil
+}
@@ -32,6 +32,9 @@ type AutomationAPI interface {
 	List(context.Context, *request.AutomationList) (interface{}, error)
 	Bundle(context.Context, *request.AutomationBundle) (interface{}, error)
 	TriggerScript(context.Context, *request.AutomationTriggerScript) (interface{}, error)
+	Scheduler(context.Context, *request.AutomationScheduler) (interface{}, error)
+	Handlers(context.Context, *request.AutomationHandlers) (interface{}, error)
+	Runs(context.Context, *request.AutomationRuns) (interface{}, error)
 }
 
 // HTTP API interface
@@ -39,6 +42,9 @@ type Automation struct {
 	List          func(http.ResponseWriter, *http.Request)
 	Bundle        func(http.ResponseWriter, *http.Request)
 	TriggerScript func(http.ResponseWriter, *http.Request)
+	Scheduler     func(http.ResponseWriter, *http.Request)
+	Handlers      func(http.ResponseWriter, *http.Request)
+	Runs          func(http.ResponseWriter, *http.Request)
 }
 
 func NewAutomation(h AutomationAPI) *Automation {
@@ -103,6 +109,66 @@ func NewAutomation(h AutomationAPI) *Automation {
 				resputil.JSON(w, value)
 			}
 		},
+		Scheduler: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewAutomationScheduler()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Automation.Scheduler", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Scheduler(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Automation.Scheduler", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Automation.Scheduler", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Handlers: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewAutomationHandlers()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Automation.Handlers", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Handlers(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Automation.Handlers", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Automation.Handlers", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Runs: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewAutomationRuns()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Automation.Runs", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Runs(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Automation.Runs", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Automation.Runs", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
 	}
 }
 
@@ -112,5 +178,8 @@ func (h Automation) MountRoutes(r chi.Router, middlewares ...func(http.Handler)
 		r.Get("/automation/", h.List)
 		r.Get("/automation/{bundle}-{type}.{ext}", h.Bundle)
 		r.Post("/automation/trigger", h.TriggerScript)
+		r.Get("/automation/scheduler", h.Scheduler)
+		r.Get("/automation/handlers", h.Handlers)
+		r.Get("/automation/runs/", h.Runs)
 	})
 }
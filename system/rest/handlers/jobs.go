@@ -0,0 +1,162 @@
+package handlers
+
+/*
+	Hello! This file is auto-generated from `docs/src/spec.json`.
+
+	For development:
+	In order to update the generated files, edit this file under the location,
+	add your struct fields, imports, API definitions and whatever you want, and:
+
+	1. run [spec](https://github.com/titpetric/spec) in the same folder,
+	2. run `./_gen.php` in this folder.
+
+	You may edit `jobs.go`, `jobs.util.go` or `jobs_test.go` to
+	implement your API calls, helper functions and tests. The file `jobs.go`
+	is only generated the first time, and will not be overwritten if it exists.
+*/
+
+import (
+	"context"
+
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/titpetric/factory/resputil"
+
+	"github.com/cortezaproject/corteza-server/pkg/logger"
+	"github.com/cortezaproject/corteza-server/system/rest/request"
+)
+
+// Internal API interface
+type JobsAPI interface {
+	List(context.Context, *request.JobsList) (interface{}, error)
+	Read(context.Context, *request.JobsRead) (interface{}, error)
+	Cancel(context.Context, *request.JobsCancel) (interface{}, error)
+	Retry(context.Context, *request.JobsRetry) (interface{}, error)
+	Result(context.Context, *request.JobsResult) (interface{}, error)
+}
+
+// HTTP API interface
+type Jobs struct {
+	List   func(http.ResponseWriter, *http.Request)
+	Read   func(http.ResponseWriter, *http.Request)
+	Cancel func(http.ResponseWriter, *http.Request)
+	Retry  func(http.ResponseWriter, *http.Request)
+	Result func(http.ResponseWriter, *http.Request)
+}
+
+func NewJobs(h JobsAPI) *Jobs {
+	return &Jobs{
+		List: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewJobsList()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Jobs.List", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.List(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Jobs.List", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Jobs.List", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Read: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewJobsRead()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Jobs.Read", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Read(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Jobs.Read", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Jobs.Read", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Cancel: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewJobsCancel()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Jobs.Cancel", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Cancel(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Jobs.Cancel", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Jobs.Cancel", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Retry: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewJobsRetry()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Jobs.Retry", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Retry(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Jobs.Retry", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Jobs.Retry", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Result: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewJobsResult()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Jobs.Result", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Result(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Jobs.Result", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Jobs.Result", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+	}
+}
+
+func (h Jobs) MountRoutes(r chi.Router, middlewares ...func(http.Handler) http.Handler) {
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares...)
+		r.Get("/jobs/", h.List)
+		r.Get("/jobs/{jobID}", h.Read)
+		r.Post("/jobs/{jobID}/cancel", h.Cancel)
+		r.Post("/jobs/{jobID}/retry", h.Retry)
+		r.Get("/jobs/{jobID}/result", h.Result)
+	})
+}
@@ -0,0 +1,208 @@
+package handlers
+
+/*
+	Hello! This file is auto-generated from `docs/src/spec.json`.
+
+	For development:
+	In order to update the generated files, edit this file under the location,
+	add your struct fields, imports, API definitions and whatever you want, and:
+
+	1. run [spec](https://github.com/titpetric/spec) in the same folder,
+	2. run `./_gen.php` in this folder.
+
+	You may edit `webhook.go`, `webhook.util.go` or `webhook_test.go` to
+	implement your API calls, helper functions and tests. The file `webhook.go`
+	is only generated the first time, and will not be overwritten if it exists.
+*/
+
+import (
+	"context"
+
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/titpetric/factory/resputil"
+
+	"github.com/cortezaproject/corteza-server/pkg/logger"
+	"github.com/cortezaproject/corteza-server/system/rest/request"
+)
+
+// Internal API interface
+type WebhookAPI interface {
+	List(context.Context, *request.WebhookList) (interface{}, error)
+	Create(context.Context, *request.WebhookCreate) (interface{}, error)
+	Read(context.Context, *request.WebhookRead) (interface{}, error)
+	Update(context.Context, *request.WebhookUpdate) (interface{}, error)
+	Delete(context.Context, *request.WebhookDelete) (interface{}, error)
+	Deliveries(context.Context, *request.WebhookDeliveries) (interface{}, error)
+	Retry(context.Context, *request.WebhookRetry) (interface{}, error)
+}
+
+// HTTP API interface
+type Webhook struct {
+	List       func(http.ResponseWriter, *http.Request)
+	Create     func(http.ResponseWriter, *http.Request)
+	Read       func(http.ResponseWriter, *http.Request)
+	Update     func(http.ResponseWriter, *http.Request)
+	Delete     func(http.ResponseWriter, *http.Request)
+	Deliveries func(http.ResponseWriter, *http.Request)
+	Retry      func(http.ResponseWriter, *http.Request)
+}
+
+func NewWebhook(h WebhookAPI) *Webhook {
+	return &Webhook{
+		List: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewWebhookList()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Webhook.List", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.List(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Webhook.List", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Webhook.List", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Create: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewWebhookCreate()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Webhook.Create", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Create(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Webhook.Create", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Webhook.Create", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Read: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewWebhookRead()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Webhook.Read", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Read(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Webhook.Read", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Webhook.Read", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Update: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewWebhookUpdate()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Webhook.Update", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Update(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Webhook.Update", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Webhook.Update", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Delete: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewWebhookDelete()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Webhook.Delete", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Delete(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Webhook.Delete", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Webhook.Delete", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Deliveries: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewWebhookDeliveries()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Webhook.Deliveries", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Deliveries(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Webhook.Deliveries", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Webhook.Deliveries", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+		Retry: func(w http.ResponseWriter, r *http.Request) {
+			defer r.Body.Close()
+			params := request.NewWebhookRetry()
+			if err := params.Fill(r); err != nil {
+				logger.LogParamError("Webhook.Retry", r, err)
+				resputil.JSON(w, err)
+				return
+			}
+
+			value, err := h.Retry(r.Context(), params)
+			if err != nil {
+				logger.LogControllerError("Webhook.Retry", r, err, params.Auditable())
+				resputil.JSON(w, err)
+				return
+			}
+			logger.LogControllerCall("Webhook.Retry", r, params.Auditable())
+			if !serveHTTP(value, w, r) {
+				resputil.JSON(w, value)
+			}
+		},
+	}
+}
+
+func (h Webhook) MountRoutes(r chi.Router, middlewares ...func(http.Handler) http.Handler) {
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares...)
+		r.Get("/webhook/", h.List)
+		r.Post("/webhook/", h.Create)
+		r.Get("/webhook/{webhookID}", h.Read)
+		r.Put("/webhook/{webhookID}", h.Update)
+		r.Delete("/webhook/{webhookID}", h.Delete)
+		r.Get("/webhook/delivery/", h.Deliveries)
+		r.Post("/webhook/delivery/{deliveryID}/retry", h.Retry)
+	})
+}
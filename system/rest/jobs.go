@@ -0,0 +1,124 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/jobs"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+	"github.com/cortezaproject/corteza-server/system/rest/request"
+	"github.com/cortezaproject/corteza-server/system/service"
+)
+
+var _ = errors.Wrap
+
+type (
+	Jobs struct {
+		ac jobsAccessController
+	}
+
+	jobsAccessController interface {
+		CanAccess(context.Context) bool
+	}
+
+	jobSetPayload struct {
+		Filter jobs.JobFilter `json:"filter"`
+		Set    jobs.JobSet    `json:"set"`
+	}
+)
+
+func (Jobs) New() *Jobs {
+	return &Jobs{
+		ac: service.DefaultAccessControl,
+	}
+}
+
+// List returns jobs
+//
+// Users without access to the administration see only their own jobs
+func (ctrl *Jobs) List(ctx context.Context, r *request.JobsList) (interface{}, error) {
+	f := jobs.JobFilter{
+		Service: r.Service,
+		Kind:    r.Kind,
+		Status:  jobs.Status(r.Status),
+		OwnedBy: r.OwnedBy,
+
+		Sort:       rh.NormalizeSortColumns(r.Sort),
+		PageFilter: rh.Paging(r),
+	}
+
+	if !ctrl.ac.CanAccess(ctx) {
+		f.OwnedBy = auth.GetIdentityFromContext(ctx).Identity()
+	}
+
+	set, filter, err := jobs.Service().Find(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jobSetPayload{Filter: filter, Set: set}, nil
+}
+
+func (ctrl *Jobs) Read(ctx context.Context, r *request.JobsRead) (interface{}, error) {
+	return ctrl.load(ctx, r.JobID)
+}
+
+func (ctrl *Jobs) Cancel(ctx context.Context, r *request.JobsCancel) (interface{}, error) {
+	if _, err := ctrl.load(ctx, r.JobID); err != nil {
+		return nil, err
+	}
+
+	return jobs.Service().Cancel(ctx, r.JobID)
+}
+
+func (ctrl *Jobs) Retry(ctx context.Context, r *request.JobsRetry) (interface{}, error) {
+	if _, err := ctrl.load(ctx, r.JobID); err != nil {
+		return nil, err
+	}
+
+	return jobs.Service().Retry(ctx, r.JobID)
+}
+
+// Result serves file produced by the job
+//
+// Results can contain data that only the owner can see, so they are
+// not served to anyone else (not even to users with access to the administration)
+func (ctrl *Jobs) Result(ctx context.Context, r *request.JobsResult) (interface{}, error) {
+	if job, err := ctrl.load(ctx, r.JobID); err != nil {
+		return nil, err
+	} else if job.OwnedBy != auth.GetIdentityFromContext(ctx).Identity() {
+		return nil, service.ErrNoPermissions
+	}
+
+	job, data, err := jobs.Service().Result(ctx, r.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w http.ResponseWriter, req *http.Request) {
+		name := url.QueryEscape(job.ResultName)
+
+		w.Header().Add("Content-Type", job.ResultType)
+		w.Header().Add("Content-Disposition", "attachment; filename="+name)
+
+		http.ServeContent(w, req, name, *job.FinishedAt, data)
+	}, nil
+}
+
+// Loads job and checks if it's accessible to the current user
+func (ctrl *Jobs) load(ctx context.Context, jobID uint64) (*jobs.Job, error) {
+	job, err := jobs.Service().FindByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.OwnedBy != auth.GetIdentityFromContext(ctx).Identity() && !ctrl.ac.CanAccess(ctx) {
+		return nil, service.ErrNoPermissions
+	}
+
+	return job, nil
+}
@@ -25,6 +25,8 @@ import (
 
 	"github.com/go-chi/chi"
 	"github.com/pkg/errors"
+
+	"time"
 )
 
 var _ = chi.URLParam
@@ -289,6 +291,280 @@ func (r *AutomationTriggerScript) Fill(req *http.Request) (err error) {
 
 var _ RequestFiller = NewAutomationTriggerScript()
 
+// AutomationScheduler request parameters
+type AutomationScheduler struct {
+}
+
+// NewAutomationScheduler request
+func NewAutomationScheduler() *AutomationScheduler {
+	return &AutomationScheduler{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r AutomationScheduler) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *AutomationScheduler) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	return err
+}
+
+var _ RequestFiller = NewAutomationScheduler()
+
+// AutomationHandlers request parameters
+type AutomationHandlers struct {
+}
+
+// NewAutomationHandlers request
+func NewAutomationHandlers() *AutomationHandlers {
+	return &AutomationHandlers{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r AutomationHandlers) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *AutomationHandlers) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	return err
+}
+
+var _ RequestFiller = NewAutomationHandlers()
+
+// AutomationRuns request parameters
+type AutomationRuns struct {
+	hasScript bool
+	rawScript string
+	Script    string
+
+	hasResourceType bool
+	rawResourceType string
+	ResourceType    string
+
+	hasEventType bool
+	rawEventType string
+	EventType    string
+
+	hasResourceID bool
+	rawResourceID string
+	ResourceID    uint64 `json:",string"`
+
+	hasStatus bool
+	rawStatus string
+	Status    string
+
+	hasFrom bool
+	rawFrom string
+	From    *time.Time
+
+	hasTo bool
+	rawTo string
+	To    *time.Time
+
+	hasLimit bool
+	rawLimit string
+	Limit    uint
+
+	hasOffset bool
+	rawOffset string
+	Offset    uint
+
+	hasPage bool
+	rawPage string
+	Page    uint
+
+	hasPerPage bool
+	rawPerPage string
+	PerPage    uint
+
+	hasSort bool
+	rawSort string
+	Sort    string
+}
+
+// NewAutomationRuns request
+func NewAutomationRuns() *AutomationRuns {
+	return &AutomationRuns{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r AutomationRuns) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["script"] = r.Script
+	out["resourceType"] = r.ResourceType
+	out["eventType"] = r.EventType
+	out["resourceID"] = r.ResourceID
+	out["status"] = r.Status
+	out["from"] = r.From
+	out["to"] = r.To
+	out["limit"] = r.Limit
+	out["offset"] = r.Offset
+	out["page"] = r.Page
+	out["perPage"] = r.PerPage
+	out["sort"] = r.Sort
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *AutomationRuns) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := get["script"]; ok {
+		r.hasScript = true
+		r.rawScript = val
+		r.Script = val
+	}
+	if val, ok := get["resourceType"]; ok {
+		r.hasResourceType = true
+		r.rawResourceType = val
+		r.ResourceType = val
+	}
+	if val, ok := get["eventType"]; ok {
+		r.hasEventType = true
+		r.rawEventType = val
+		r.EventType = val
+	}
+	if val, ok := get["resourceID"]; ok {
+		r.hasResourceID = true
+		r.rawResourceID = val
+		r.ResourceID = parseUInt64(val)
+	}
+	if val, ok := get["status"]; ok {
+		r.hasStatus = true
+		r.rawStatus = val
+		r.Status = val
+	}
+	if val, ok := get["from"]; ok {
+		r.hasFrom = true
+		r.rawFrom = val
+
+		if r.From, err = parseISODatePtrWithErr(val); err != nil {
+			return err
+		}
+	}
+	if val, ok := get["to"]; ok {
+		r.hasTo = true
+		r.rawTo = val
+
+		if r.To, err = parseISODatePtrWithErr(val); err != nil {
+			return err
+		}
+	}
+	if val, ok := get["limit"]; ok {
+		r.hasLimit = true
+		r.rawLimit = val
+		r.Limit = parseUint(val)
+	}
+	if val, ok := get["offset"]; ok {
+		r.hasOffset = true
+		r.rawOffset = val
+		r.Offset = parseUint(val)
+	}
+	if val, ok := get["page"]; ok {
+		r.hasPage = true
+		r.rawPage = val
+		r.Page = parseUint(val)
+	}
+	if val, ok := get["perPage"]; ok {
+		r.hasPerPage = true
+		r.rawPerPage = val
+		r.PerPage = parseUint(val)
+	}
+	if val, ok := get["sort"]; ok {
+		r.hasSort = true
+		r.rawSort = val
+		r.Sort = val
+	}
+
+	return err
+}
+
+var _ RequestFiller = NewAutomationRuns()
+
 // HasResourceTypePrefixes returns true if resourceTypePrefixes was set
 func (r *AutomationList) HasResourceTypePrefixes() bool {
 	return r.hasResourceTypePrefixes
@@ -438,3 +714,183 @@ func (r *AutomationTriggerScript) RawScript() string {
 func (r *AutomationTriggerScript) GetScript() string {
 	return r.Script
 }
+
+// HasScript returns true if script was set
+func (r *AutomationRuns) HasScript() bool {
+	return r.hasScript
+}
+
+// RawScript returns raw value of script parameter
+func (r *AutomationRuns) RawScript() string {
+	return r.rawScript
+}
+
+// GetScript returns casted value of  script parameter
+func (r *AutomationRuns) GetScript() string {
+	return r.Script
+}
+
+// HasResourceType returns true if resourceType was set
+func (r *AutomationRuns) HasResourceType() bool {
+	return r.hasResourceType
+}
+
+// RawResourceType returns raw value of resourceType parameter
+func (r *AutomationRuns) RawResourceType() string {
+	return r.rawResourceType
+}
+
+// GetResourceType returns casted value of  resourceType parameter
+func (r *AutomationRuns) GetResourceType() string {
+	return r.ResourceType
+}
+
+// HasEventType returns true if eventType was set
+func (r *AutomationRuns) HasEventType() bool {
+	return r.hasEventType
+}
+
+// RawEventType returns raw value of eventType parameter
+func (r *AutomationRuns) RawEventType() string {
+	return r.rawEventType
+}
+
+// GetEventType returns casted value of  eventType parameter
+func (r *AutomationRuns) GetEventType() string {
+	return r.EventType
+}
+
+// HasResourceID returns true if resourceID was set
+func (r *AutomationRuns) HasResourceID() bool {
+	return r.hasResourceID
+}
+
+// RawResourceID returns raw value of resourceID parameter
+func (r *AutomationRuns) RawResourceID() string {
+	return r.rawResourceID
+}
+
+// GetResourceID returns casted value of  resourceID parameter
+func (r *AutomationRuns) GetResourceID() uint64 {
+	return r.ResourceID
+}
+
+// HasStatus returns true if status was set
+func (r *AutomationRuns) HasStatus() bool {
+	return r.hasStatus
+}
+
+// RawStatus returns raw value of status parameter
+func (r *AutomationRuns) RawStatus() string {
+	return r.rawStatus
+}
+
+// GetStatus returns casted value of  status parameter
+func (r *AutomationRuns) GetStatus() string {
+	return r.Status
+}
+
+// HasFrom returns true if from was set
+func (r *AutomationRuns) HasFrom() bool {
+	return r.hasFrom
+}
+
+// RawFrom returns raw value of from parameter
+func (r *AutomationRuns) RawFrom() string {
+	return r.rawFrom
+}
+
+// GetFrom returns casted value of  from parameter
+func (r *AutomationRuns) GetFrom() *time.Time {
+	return r.From
+}
+
+// HasTo returns true if to was set
+func (r *AutomationRuns) HasTo() bool {
+	return r.hasTo
+}
+
+// RawTo returns raw value of to parameter
+func (r *AutomationRuns) RawTo() string {
+	return r.rawTo
+}
+
+// GetTo returns casted value of  to parameter
+func (r *AutomationRuns) GetTo() *time.Time {
+	return r.To
+}
+
+// HasLimit returns true if limit was set
+func (r *AutomationRuns) HasLimit() bool {
+	return r.hasLimit
+}
+
+// RawLimit returns raw value of limit parameter
+func (r *AutomationRuns) RawLimit() string {
+	return r.rawLimit
+}
+
+// GetLimit returns casted value of  limit parameter
+func (r *AutomationRuns) GetLimit() uint {
+	return r.Limit
+}
+
+// HasOffset returns true if offset was set
+func (r *AutomationRuns) HasOffset() bool {
+	return r.hasOffset
+}
+
+// RawOffset returns raw value of offset parameter
+func (r *AutomationRuns) RawOffset() string {
+	return r.rawOffset
+}
+
+// GetOffset returns casted value of  offset parameter
+func (r *AutomationRuns) GetOffset() uint {
+	return r.Offset
+}
+
+// HasPage returns true if page was set
+func (r *AutomationRuns) HasPage() bool {
+	return r.hasPage
+}
+
+// RawPage returns raw value of page parameter
+func (r *AutomationRuns) RawPage() string {
+	return r.rawPage
+}
+
+// GetPage returns casted value of  page parameter
+func (r *AutomationRuns) GetPage() uint {
+	return r.Page
+}
+
+// HasPerPage returns true if perPage was set
+func (r *AutomationRuns) HasPerPage() bool {
+	return r.hasPerPage
+}
+
+// RawPerPage returns raw value of perPage parameter
+func (r *AutomationRuns) RawPerPage() string {
+	return r.rawPerPage
+}
+
+// GetPerPage returns casted value of  perPage parameter
+func (r *AutomationRuns) GetPerPage() uint {
+	return r.PerPage
+}
+
+// HasSort returns true if sort was set
+func (r *AutomationRuns) HasSort() bool {
+	return r.hasSort
+}
+
+// RawSort returns raw value of sort parameter
+func (r *AutomationRuns) RawSort() string {
+	return r.rawSort
+}
+
+// GetSort returns casted value of  sort parameter
+func (r *AutomationRuns) GetSort() string {
+	return r.Sort
+}
@@ -0,0 +1,598 @@
+package request
+
+/*
+	Hello! This file is auto-generated from `docs/src/spec.json`.
+
+	For development:
+	In order to update the generated files, edit this file under the location,
+	add your struct fields, imports, API definitions and whatever you want, and:
+
+	1. run [spec](https://github.com/titpetric/spec) in the same folder,
+	2. run `./_gen.php` in this folder.
+
+	You may edit `jobs.go`, `jobs.util.go` or `jobs_test.go` to
+	implement your API calls, helper functions and tests. The file `jobs.go`
+	is only generated the first time, and will not be overwritten if it exists.
+*/
+
+import (
+	"io"
+	"strings"
+
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/pkg/errors"
+)
+
+var _ = chi.URLParam
+var _ = multipart.FileHeader{}
+
+// JobsList request parameters
+type JobsList struct {
+	hasService bool
+	rawService string
+	Service    string
+
+	hasKind bool
+	rawKind string
+	Kind    string
+
+	hasStatus bool
+	rawStatus string
+	Status    string
+
+	hasOwnedBy bool
+	rawOwnedBy string
+	OwnedBy    uint64 `json:",string"`
+
+	hasLimit bool
+	rawLimit string
+	Limit    uint
+
+	hasOffset bool
+	rawOffset string
+	Offset    uint
+
+	hasPage bool
+	rawPage string
+	Page    uint
+
+	hasPerPage bool
+	rawPerPage string
+	PerPage    uint
+
+	hasSort bool
+	rawSort string
+	Sort    string
+}
+
+// NewJobsList request
+func NewJobsList() *JobsList {
+	return &JobsList{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r JobsList) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["service"] = r.Service
+	out["kind"] = r.Kind
+	out["status"] = r.Status
+	out["ownedBy"] = r.OwnedBy
+	out["limit"] = r.Limit
+	out["offset"] = r.Offset
+	out["page"] = r.Page
+	out["perPage"] = r.PerPage
+	out["sort"] = r.Sort
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *JobsList) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := get["service"]; ok {
+		r.hasService = true
+		r.rawService = val
+		r.Service = val
+	}
+	if val, ok := get["kind"]; ok {
+		r.hasKind = true
+		r.rawKind = val
+		r.Kind = val
+	}
+	if val, ok := get["status"]; ok {
+		r.hasStatus = true
+		r.rawStatus = val
+		r.Status = val
+	}
+	if val, ok := get["ownedBy"]; ok {
+		r.hasOwnedBy = true
+		r.rawOwnedBy = val
+		r.OwnedBy = parseUInt64(val)
+	}
+	if val, ok := get["limit"]; ok {
+		r.hasLimit = true
+		r.rawLimit = val
+		r.Limit = parseUint(val)
+	}
+	if val, ok := get["offset"]; ok {
+		r.hasOffset = true
+		r.rawOffset = val
+		r.Offset = parseUint(val)
+	}
+	if val, ok := get["page"]; ok {
+		r.hasPage = true
+		r.rawPage = val
+		r.Page = parseUint(val)
+	}
+	if val, ok := get["perPage"]; ok {
+		r.hasPerPage = true
+		r.rawPerPage = val
+		r.PerPage = parseUint(val)
+	}
+	if val, ok := get["sort"]; ok {
+		r.hasSort = true
+		r.rawSort = val
+		r.Sort = val
+	}
+
+	return err
+}
+
+var _ RequestFiller = NewJobsList()
+
+// JobsRead request parameters
+type JobsRead struct {
+	hasJobID bool
+	rawJobID string
+	JobID    uint64 `json:",string"`
+}
+
+// NewJobsRead request
+func NewJobsRead() *JobsRead {
+	return &JobsRead{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r JobsRead) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["jobID"] = r.JobID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *JobsRead) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasJobID = true
+	r.rawJobID = chi.URLParam(req, "jobID")
+	r.JobID = parseUInt64(chi.URLParam(req, "jobID"))
+
+	return err
+}
+
+var _ RequestFiller = NewJobsRead()
+
+// JobsCancel request parameters
+type JobsCancel struct {
+	hasJobID bool
+	rawJobID string
+	JobID    uint64 `json:",string"`
+}
+
+// NewJobsCancel request
+func NewJobsCancel() *JobsCancel {
+	return &JobsCancel{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r JobsCancel) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["jobID"] = r.JobID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *JobsCancel) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasJobID = true
+	r.rawJobID = chi.URLParam(req, "jobID")
+	r.JobID = parseUInt64(chi.URLParam(req, "jobID"))
+
+	return err
+}
+
+var _ RequestFiller = NewJobsCancel()
+
+// JobsRetry request parameters
+type JobsRetry struct {
+	hasJobID bool
+	rawJobID string
+	JobID    uint64 `json:",string"`
+}
+
+// NewJobsRetry request
+func NewJobsRetry() *JobsRetry {
+	return &JobsRetry{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r JobsRetry) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["jobID"] = r.JobID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *JobsRetry) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasJobID = true
+	r.rawJobID = chi.URLParam(req, "jobID")
+	r.JobID = parseUInt64(chi.URLParam(req, "jobID"))
+
+	return err
+}
+
+var _ RequestFiller = NewJobsRetry()
+
+// JobsResult request parameters
+type JobsResult struct {
+	hasJobID bool
+	rawJobID string
+	JobID    uint64 `json:",string"`
+}
+
+// NewJobsResult request
+func NewJobsResult() *JobsResult {
+	return &JobsResult{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r JobsResult) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["jobID"] = r.JobID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *JobsResult) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasJobID = true
+	r.rawJobID = chi.URLParam(req, "jobID")
+	r.JobID = parseUInt64(chi.URLParam(req, "jobID"))
+
+	return err
+}
+
+var _ RequestFiller = NewJobsResult()
+
+// HasService returns true if service was set
+func (r *JobsList) HasService() bool {
+	return r.hasService
+}
+
+// RawService returns raw value of service parameter
+func (r *JobsList) RawService() string {
+	return r.rawService
+}
+
+// GetService returns casted value of  service parameter
+func (r *JobsList) GetService() string {
+	return r.Service
+}
+
+// HasKind returns true if kind was set
+func (r *JobsList) HasKind() bool {
+	return r.hasKind
+}
+
+// RawKind returns raw value of kind parameter
+func (r *JobsList) RawKind() string {
+	return r.rawKind
+}
+
+// GetKind returns casted value of  kind parameter
+func (r *JobsList) GetKind() string {
+	return r.Kind
+}
+
+// HasStatus returns true if status was set
+func (r *JobsList) HasStatus() bool {
+	return r.hasStatus
+}
+
+// RawStatus returns raw value of status parameter
+func (r *JobsList) RawStatus() string {
+	return r.rawStatus
+}
+
+// GetStatus returns casted value of  status parameter
+func (r *JobsList) GetStatus() string {
+	return r.Status
+}
+
+// HasOwnedBy returns true if ownedBy was set
+func (r *JobsList) HasOwnedBy() bool {
+	return r.hasOwnedBy
+}
+
+// RawOwnedBy returns raw value of ownedBy parameter
+func (r *JobsList) RawOwnedBy() string {
+	return r.rawOwnedBy
+}
+
+// GetOwnedBy returns casted value of  ownedBy parameter
+func (r *JobsList) GetOwnedBy() uint64 {
+	return r.OwnedBy
+}
+
+// HasLimit returns true if limit was set
+func (r *JobsList) HasLimit() bool {
+	return r.hasLimit
+}
+
+// RawLimit returns raw value of limit parameter
+func (r *JobsList) RawLimit() string {
+	return r.rawLimit
+}
+
+// GetLimit returns casted value of  limit parameter
+func (r *JobsList) GetLimit() uint {
+	return r.Limit
+}
+
+// HasOffset returns true if offset was set
+func (r *JobsList) HasOffset() bool {
+	return r.hasOffset
+}
+
+// RawOffset returns raw value of offset parameter
+func (r *JobsList) RawOffset() string {
+	return r.rawOffset
+}
+
+// GetOffset returns casted value of  offset parameter
+func (r *JobsList) GetOffset() uint {
+	return r.Offset
+}
+
+// HasPage returns true if page was set
+func (r *JobsList) HasPage() bool {
+	return r.hasPage
+}
+
+// RawPage returns raw value of page parameter
+func (r *JobsList) RawPage() string {
+	return r.rawPage
+}
+
+// GetPage returns casted value of  page parameter
+func (r *JobsList) GetPage() uint {
+	return r.Page
+}
+
+// HasPerPage returns true if perPage was set
+func (r *JobsList) HasPerPage() bool {
+	return r.hasPerPage
+}
+
+// RawPerPage returns raw value of perPage parameter
+func (r *JobsList) RawPerPage() string {
+	return r.rawPerPage
+}
+
+// GetPerPage returns casted value of  perPage parameter
+func (r *JobsList) GetPerPage() uint {
+	return r.PerPage
+}
+
+// HasSort returns true if sort was set
+func (r *JobsList) HasSort() bool {
+	return r.hasSort
+}
+
+// RawSort returns raw value of sort parameter
+func (r *JobsList) RawSort() string {
+	return r.rawSort
+}
+
+// GetSort returns casted value of  sort parameter
+func (r *JobsList) GetSort() string {
+	return r.Sort
+}
+
+// HasJobID returns true if jobID was set
+func (r *JobsRead) HasJobID() bool {
+	return r.hasJobID
+}
+
+// RawJobID returns raw value of jobID parameter
+func (r *JobsRead) RawJobID() string {
+	return r.rawJobID
+}
+
+// GetJobID returns casted value of  jobID parameter
+func (r *JobsRead) GetJobID() uint64 {
+	return r.JobID
+}
+
+// HasJobID returns true if jobID was set
+func (r *JobsCancel) HasJobID() bool {
+	return r.hasJobID
+}
+
+// RawJobID returns raw value of jobID parameter
+func (r *JobsCancel) RawJobID() string {
+	return r.rawJobID
+}
+
+// GetJobID returns casted value of  jobID parameter
+func (r *JobsCancel) GetJobID() uint64 {
+	return r.JobID
+}
+
+// HasJobID returns true if jobID was set
+func (r *JobsRetry) HasJobID() bool {
+	return r.hasJobID
+}
+
+// RawJobID returns raw value of jobID parameter
+func (r *JobsRetry) RawJobID() string {
+	return r.rawJobID
+}
+
+// GetJobID returns casted value of  jobID parameter
+func (r *JobsRetry) GetJobID() uint64 {
+	return r.JobID
+}
+
+// HasJobID returns true if jobID was set
+func (r *JobsResult) HasJobID() bool {
+	return r.hasJobID
+}
+
+// RawJobID returns raw value of jobID parameter
+func (r *JobsResult) RawJobID() string {
+	return r.rawJobID
+}
+
+// GetJobID returns casted value of  jobID parameter
+func (r *JobsResult) GetJobID() uint64 {
+	return r.JobID
+}
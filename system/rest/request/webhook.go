@@ -0,0 +1,1119 @@
+package request
+
+/*
+	Hello! This file is auto-generated from `docs/src/spec.json`.
+
+	For development:
+	In order to update the generated files, edit this file under the location,
+	add your struct fields, imports, API definitions and whatever you want, and:
+
+	1. run [spec](https://github.com/titpetric/spec) in the same folder,
+	2. run `./_gen.php` in this folder.
+
+	You may edit `webhook.go`, `webhook.util.go` or `webhook_test.go` to
+	implement your API calls, helper functions and tests. The file `webhook.go`
+	is only generated the first time, and will not be overwritten if it exists.
+*/
+
+import (
+	"io"
+	"strings"
+
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/pkg/errors"
+
+	"github.com/cortezaproject/corteza-server/system/types"
+)
+
+var _ = chi.URLParam
+var _ = multipart.FileHeader{}
+
+// WebhookList request parameters
+type WebhookList struct {
+	hasQuery bool
+	rawQuery string
+	Query    string
+
+	hasResourceType bool
+	rawResourceType string
+	ResourceType    string
+
+	hasLimit bool
+	rawLimit string
+	Limit    uint
+
+	hasOffset bool
+	rawOffset string
+	Offset    uint
+
+	hasPage bool
+	rawPage string
+	Page    uint
+
+	hasPerPage bool
+	rawPerPage string
+	PerPage    uint
+
+	hasSort bool
+	rawSort string
+	Sort    string
+}
+
+// NewWebhookList request
+func NewWebhookList() *WebhookList {
+	return &WebhookList{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r WebhookList) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["query"] = r.Query
+	out["resourceType"] = r.ResourceType
+	out["limit"] = r.Limit
+	out["offset"] = r.Offset
+	out["page"] = r.Page
+	out["perPage"] = r.PerPage
+	out["sort"] = r.Sort
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *WebhookList) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := get["query"]; ok {
+		r.hasQuery = true
+		r.rawQuery = val
+		r.Query = val
+	}
+	if val, ok := get["resourceType"]; ok {
+		r.hasResourceType = true
+		r.rawResourceType = val
+		r.ResourceType = val
+	}
+	if val, ok := get["limit"]; ok {
+		r.hasLimit = true
+		r.rawLimit = val
+		r.Limit = parseUint(val)
+	}
+	if val, ok := get["offset"]; ok {
+		r.hasOffset = true
+		r.rawOffset = val
+		r.Offset = parseUint(val)
+	}
+	if val, ok := get["page"]; ok {
+		r.hasPage = true
+		r.rawPage = val
+		r.Page = parseUint(val)
+	}
+	if val, ok := get["perPage"]; ok {
+		r.hasPerPage = true
+		r.rawPerPage = val
+		r.PerPage = parseUint(val)
+	}
+	if val, ok := get["sort"]; ok {
+		r.hasSort = true
+		r.rawSort = val
+		r.Sort = val
+	}
+
+	return err
+}
+
+var _ RequestFiller = NewWebhookList()
+
+// WebhookCreate request parameters
+type WebhookCreate struct {
+	hasName bool
+	rawName string
+	Name    string
+
+	hasUrl bool
+	rawUrl string
+	Url    string
+
+	hasResourceType bool
+	rawResourceType string
+	ResourceType    string
+
+	hasEventTypes bool
+	rawEventTypes []string
+	EventTypes    []string
+
+	hasConstraints bool
+	rawConstraints string
+	Constraints    types.WebhookConstraintSet
+
+	hasEnabled bool
+	rawEnabled string
+	Enabled    bool
+}
+
+// NewWebhookCreate request
+func NewWebhookCreate() *WebhookCreate {
+	return &WebhookCreate{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r WebhookCreate) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["name"] = r.Name
+	out["url"] = r.Url
+	out["resourceType"] = r.ResourceType
+	out["eventTypes"] = r.EventTypes
+	out["constraints"] = r.Constraints
+	out["enabled"] = r.Enabled
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *WebhookCreate) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := post["name"]; ok {
+		r.hasName = true
+		r.rawName = val
+		r.Name = val
+	}
+	if val, ok := post["url"]; ok {
+		r.hasUrl = true
+		r.rawUrl = val
+		r.Url = val
+	}
+	if val, ok := post["resourceType"]; ok {
+		r.hasResourceType = true
+		r.rawResourceType = val
+		r.ResourceType = val
+	}
+
+	if val, ok := req.Form["eventTypes"]; ok {
+		r.hasEventTypes = true
+		r.rawEventTypes = val
+		r.EventTypes = parseStrings(val)
+	}
+
+	if val, ok := post["enabled"]; ok {
+		r.hasEnabled = true
+		r.rawEnabled = val
+		r.Enabled = parseBool(val)
+	}
+
+	return err
+}
+
+var _ RequestFiller = NewWebhookCreate()
+
+// WebhookRead request parameters
+type WebhookRead struct {
+	hasWebhookID bool
+	rawWebhookID string
+	WebhookID    uint64 `json:",string"`
+}
+
+// NewWebhookRead request
+func NewWebhookRead() *WebhookRead {
+	return &WebhookRead{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r WebhookRead) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["webhookID"] = r.WebhookID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *WebhookRead) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasWebhookID = true
+	r.rawWebhookID = chi.URLParam(req, "webhookID")
+	r.WebhookID = parseUInt64(chi.URLParam(req, "webhookID"))
+
+	return err
+}
+
+var _ RequestFiller = NewWebhookRead()
+
+// WebhookUpdate request parameters
+type WebhookUpdate struct {
+	hasWebhookID bool
+	rawWebhookID string
+	WebhookID    uint64 `json:",string"`
+
+	hasName bool
+	rawName string
+	Name    string
+
+	hasUrl bool
+	rawUrl string
+	Url    string
+
+	hasResourceType bool
+	rawResourceType string
+	ResourceType    string
+
+	hasEventTypes bool
+	rawEventTypes []string
+	EventTypes    []string
+
+	hasConstraints bool
+	rawConstraints string
+	Constraints    types.WebhookConstraintSet
+
+	hasEnabled bool
+	rawEnabled string
+	Enabled    bool
+}
+
+// NewWebhookUpdate request
+func NewWebhookUpdate() *WebhookUpdate {
+	return &WebhookUpdate{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r WebhookUpdate) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["webhookID"] = r.WebhookID
+	out["name"] = r.Name
+	out["url"] = r.Url
+	out["resourceType"] = r.ResourceType
+	out["eventTypes"] = r.EventTypes
+	out["constraints"] = r.Constraints
+	out["enabled"] = r.Enabled
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *WebhookUpdate) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasWebhookID = true
+	r.rawWebhookID = chi.URLParam(req, "webhookID")
+	r.WebhookID = parseUInt64(chi.URLParam(req, "webhookID"))
+	if val, ok := post["name"]; ok {
+		r.hasName = true
+		r.rawName = val
+		r.Name = val
+	}
+	if val, ok := post["url"]; ok {
+		r.hasUrl = true
+		r.rawUrl = val
+		r.Url = val
+	}
+	if val, ok := post["resourceType"]; ok {
+		r.hasResourceType = true
+		r.rawResourceType = val
+		r.ResourceType = val
+	}
+
+	if val, ok := req.Form["eventTypes"]; ok {
+		r.hasEventTypes = true
+		r.rawEventTypes = val
+		r.EventTypes = parseStrings(val)
+	}
+
+	if val, ok := post["enabled"]; ok {
+		r.hasEnabled = true
+		r.rawEnabled = val
+		r.Enabled = parseBool(val)
+	}
+
+	return err
+}
+
+var _ RequestFiller = NewWebhookUpdate()
+
+// WebhookDelete request parameters
+type WebhookDelete struct {
+	hasWebhookID bool
+	rawWebhookID string
+	WebhookID    uint64 `json:",string"`
+}
+
+// NewWebhookDelete request
+func NewWebhookDelete() *WebhookDelete {
+	return &WebhookDelete{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r WebhookDelete) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["webhookID"] = r.WebhookID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *WebhookDelete) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasWebhookID = true
+	r.rawWebhookID = chi.URLParam(req, "webhookID")
+	r.WebhookID = parseUInt64(chi.URLParam(req, "webhookID"))
+
+	return err
+}
+
+var _ RequestFiller = NewWebhookDelete()
+
+// WebhookDeliveries request parameters
+type WebhookDeliveries struct {
+	hasWebhookID bool
+	rawWebhookID string
+	WebhookID    uint64 `json:",string"`
+
+	hasStatus bool
+	rawStatus string
+	Status    string
+
+	hasLimit bool
+	rawLimit string
+	Limit    uint
+
+	hasOffset bool
+	rawOffset string
+	Offset    uint
+
+	hasPage bool
+	rawPage string
+	Page    uint
+
+	hasPerPage bool
+	rawPerPage string
+	PerPage    uint
+
+	hasSort bool
+	rawSort string
+	Sort    string
+}
+
+// NewWebhookDeliveries request
+func NewWebhookDeliveries() *WebhookDeliveries {
+	return &WebhookDeliveries{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r WebhookDeliveries) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["webhookID"] = r.WebhookID
+	out["status"] = r.Status
+	out["limit"] = r.Limit
+	out["offset"] = r.Offset
+	out["page"] = r.Page
+	out["perPage"] = r.PerPage
+	out["sort"] = r.Sort
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *WebhookDeliveries) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	if val, ok := get["webhookID"]; ok {
+		r.hasWebhookID = true
+		r.rawWebhookID = val
+		r.WebhookID = parseUInt64(val)
+	}
+	if val, ok := get["status"]; ok {
+		r.hasStatus = true
+		r.rawStatus = val
+		r.Status = val
+	}
+	if val, ok := get["limit"]; ok {
+		r.hasLimit = true
+		r.rawLimit = val
+		r.Limit = parseUint(val)
+	}
+	if val, ok := get["offset"]; ok {
+		r.hasOffset = true
+		r.rawOffset = val
+		r.Offset = parseUint(val)
+	}
+	if val, ok := get["page"]; ok {
+		r.hasPage = true
+		r.rawPage = val
+		r.Page = parseUint(val)
+	}
+	if val, ok := get["perPage"]; ok {
+		r.hasPerPage = true
+		r.rawPerPage = val
+		r.PerPage = parseUint(val)
+	}
+	if val, ok := get["sort"]; ok {
+		r.hasSort = true
+		r.rawSort = val
+		r.Sort = val
+	}
+
+	return err
+}
+
+var _ RequestFiller = NewWebhookDeliveries()
+
+// WebhookRetry request parameters
+type WebhookRetry struct {
+	hasDeliveryID bool
+	rawDeliveryID string
+	DeliveryID    uint64 `json:",string"`
+}
+
+// NewWebhookRetry request
+func NewWebhookRetry() *WebhookRetry {
+	return &WebhookRetry{}
+}
+
+// Auditable returns all auditable/loggable parameters
+func (r WebhookRetry) Auditable() map[string]interface{} {
+	var out = map[string]interface{}{}
+
+	out["deliveryID"] = r.DeliveryID
+
+	return out
+}
+
+// Fill processes request and fills internal variables
+func (r *WebhookRetry) Fill(req *http.Request) (err error) {
+	if strings.ToLower(req.Header.Get("content-type")) == "application/json" {
+		err = json.NewDecoder(req.Body).Decode(r)
+
+		switch {
+		case err == io.EOF:
+			err = nil
+		case err != nil:
+			return errors.Wrap(err, "error parsing http request body")
+		}
+	}
+
+	if err = req.ParseForm(); err != nil {
+		return err
+	}
+
+	get := map[string]string{}
+	post := map[string]string{}
+	urlQuery := req.URL.Query()
+	for name, param := range urlQuery {
+		get[name] = string(param[0])
+	}
+	postVars := req.Form
+	for name, param := range postVars {
+		post[name] = string(param[0])
+	}
+
+	r.hasDeliveryID = true
+	r.rawDeliveryID = chi.URLParam(req, "deliveryID")
+	r.DeliveryID = parseUInt64(chi.URLParam(req, "deliveryID"))
+
+	return err
+}
+
+var _ RequestFiller = NewWebhookRetry()
+
+// HasQuery returns true if query was set
+func (r *WebhookList) HasQuery() bool {
+	return r.hasQuery
+}
+
+// RawQuery returns raw value of query parameter
+func (r *WebhookList) RawQuery() string {
+	return r.rawQuery
+}
+
+// GetQuery returns casted value of  query parameter
+func (r *WebhookList) GetQuery() string {
+	return r.Query
+}
+
+// HasResourceType returns true if resourceType was set
+func (r *WebhookList) HasResourceType() bool {
+	return r.hasResourceType
+}
+
+// RawResourceType returns raw value of resourceType parameter
+func (r *WebhookList) RawResourceType() string {
+	return r.rawResourceType
+}
+
+// GetResourceType returns casted value of  resourceType parameter
+func (r *WebhookList) GetResourceType() string {
+	return r.ResourceType
+}
+
+// HasLimit returns true if limit was set
+func (r *WebhookList) HasLimit() bool {
+	return r.hasLimit
+}
+
+// RawLimit returns raw value of limit parameter
+func (r *WebhookList) RawLimit() string {
+	return r.rawLimit
+}
+
+// GetLimit returns casted value of  limit parameter
+func (r *WebhookList) GetLimit() uint {
+	return r.Limit
+}
+
+// HasOffset returns true if offset was set
+func (r *WebhookList) HasOffset() bool {
+	return r.hasOffset
+}
+
+// RawOffset returns raw value of offset parameter
+func (r *WebhookList) RawOffset() string {
+	return r.rawOffset
+}
+
+// GetOffset returns casted value of  offset parameter
+func (r *WebhookList) GetOffset() uint {
+	return r.Offset
+}
+
+// HasPage returns true if page was set
+func (r *WebhookList) HasPage() bool {
+	return r.hasPage
+}
+
+// RawPage returns raw value of page parameter
+func (r *WebhookList) RawPage() string {
+	return r.rawPage
+}
+
+// GetPage returns casted value of  page parameter
+func (r *WebhookList) GetPage() uint {
+	return r.Page
+}
+
+// HasPerPage returns true if perPage was set
+func (r *WebhookList) HasPerPage() bool {
+	return r.hasPerPage
+}
+
+// RawPerPage returns raw value of perPage parameter
+func (r *WebhookList) RawPerPage() string {
+	return r.rawPerPage
+}
+
+// GetPerPage returns casted value of  perPage parameter
+func (r *WebhookList) GetPerPage() uint {
+	return r.PerPage
+}
+
+// HasSort returns true if sort was set
+func (r *WebhookList) HasSort() bool {
+	return r.hasSort
+}
+
+// RawSort returns raw value of sort parameter
+func (r *WebhookList) RawSort() string {
+	return r.rawSort
+}
+
+// GetSort returns casted value of  sort parameter
+func (r *WebhookList) GetSort() string {
+	return r.Sort
+}
+
+// HasName returns true if name was set
+func (r *WebhookCreate) HasName() bool {
+	return r.hasName
+}
+
+// RawName returns raw value of name parameter
+func (r *WebhookCreate) RawName() string {
+	return r.rawName
+}
+
+// GetName returns casted value of  name parameter
+func (r *WebhookCreate) GetName() string {
+	return r.Name
+}
+
+// HasUrl returns true if url was set
+func (r *WebhookCreate) HasUrl() bool {
+	return r.hasUrl
+}
+
+// RawUrl returns raw value of url parameter
+func (r *WebhookCreate) RawUrl() string {
+	return r.rawUrl
+}
+
+// GetUrl returns casted value of  url parameter
+func (r *WebhookCreate) GetUrl() string {
+	return r.Url
+}
+
+// HasResourceType returns true if resourceType was set
+func (r *WebhookCreate) HasResourceType() bool {
+	return r.hasResourceType
+}
+
+// RawResourceType returns raw value of resourceType parameter
+func (r *WebhookCreate) RawResourceType() string {
+	return r.rawResourceType
+}
+
+// GetResourceType returns casted value of  resourceType parameter
+func (r *WebhookCreate) GetResourceType() string {
+	return r.ResourceType
+}
+
+// HasEventTypes returns true if eventTypes was set
+func (r *WebhookCreate) HasEventTypes() bool {
+	return r.hasEventTypes
+}
+
+// RawEventTypes returns raw value of eventTypes parameter
+func (r *WebhookCreate) RawEventTypes() []string {
+	return r.rawEventTypes
+}
+
+// GetEventTypes returns casted value of  eventTypes parameter
+func (r *WebhookCreate) GetEventTypes() []string {
+	return r.EventTypes
+}
+
+// HasConstraints returns true if constraints was set
+func (r *WebhookCreate) HasConstraints() bool {
+	return r.hasConstraints
+}
+
+// RawConstraints returns raw value of constraints parameter
+func (r *WebhookCreate) RawConstraints() string {
+	return r.rawConstraints
+}
+
+// GetConstraints returns casted value of  constraints parameter
+func (r *WebhookCreate) GetConstraints() types.WebhookConstraintSet {
+	return r.Constraints
+}
+
+// HasEnabled returns true if enabled was set
+func (r *WebhookCreate) HasEnabled() bool {
+	return r.hasEnabled
+}
+
+// RawEnabled returns raw value of enabled parameter
+func (r *WebhookCreate) RawEnabled() string {
+	return r.rawEnabled
+}
+
+// GetEnabled returns casted value of  enabled parameter
+func (r *WebhookCreate) GetEnabled() bool {
+	return r.Enabled
+}
+
+// HasWebhookID returns true if webhookID was set
+func (r *WebhookRead) HasWebhookID() bool {
+	return r.hasWebhookID
+}
+
+// RawWebhookID returns raw value of webhookID parameter
+func (r *WebhookRead) RawWebhookID() string {
+	return r.rawWebhookID
+}
+
+// GetWebhookID returns casted value of  webhookID parameter
+func (r *WebhookRead) GetWebhookID() uint64 {
+	return r.WebhookID
+}
+
+// HasWebhookID returns true if webhookID was set
+func (r *WebhookUpdate) HasWebhookID() bool {
+	return r.hasWebhookID
+}
+
+// RawWebhookID returns raw value of webhookID parameter
+func (r *WebhookUpdate) RawWebhookID() string {
+	return r.rawWebhookID
+}
+
+// GetWebhookID returns casted value of  webhookID parameter
+func (r *WebhookUpdate) GetWebhookID() uint64 {
+	return r.WebhookID
+}
+
+// HasName returns true if name was set
+func (r *WebhookUpdate) HasName() bool {
+	return r.hasName
+}
+
+// RawName returns raw value of name parameter
+func (r *WebhookUpdate) RawName() string {
+	return r.rawName
+}
+
+// GetName returns casted value of  name parameter
+func (r *WebhookUpdate) GetName() string {
+	return r.Name
+}
+
+// HasUrl returns true if url was set
+func (r *WebhookUpdate) HasUrl() bool {
+	return r.hasUrl
+}
+
+// RawUrl returns raw value of url parameter
+func (r *WebhookUpdate) RawUrl() string {
+	return r.rawUrl
+}
+
+// GetUrl returns casted value of  url parameter
+func (r *WebhookUpdate) GetUrl() string {
+	return r.Url
+}
+
+// HasResourceType returns true if resourceType was set
+func (r *WebhookUpdate) HasResourceType() bool {
+	return r.hasResourceType
+}
+
+// RawResourceType returns raw value of resourceType parameter
+func (r *WebhookUpdate) RawResourceType() string {
+	return r.rawResourceType
+}
+
+// GetResourceType returns casted value of  resourceType parameter
+func (r *WebhookUpdate) GetResourceType() string {
+	return r.ResourceType
+}
+
+// HasEventTypes returns true if eventTypes was set
+func (r *WebhookUpdate) HasEventTypes() bool {
+	return r.hasEventTypes
+}
+
+// RawEventTypes returns raw value of eventTypes parameter
+func (r *WebhookUpdate) RawEventTypes() []string {
+	return r.rawEventTypes
+}
+
+// GetEventTypes returns casted value of  eventTypes parameter
+func (r *WebhookUpdate) GetEventTypes() []string {
+	return r.EventTypes
+}
+
+// HasConstraints returns true if constraints was set
+func (r *WebhookUpdate) HasConstraints() bool {
+	return r.hasConstraints
+}
+
+// RawConstraints returns raw value of constraints parameter
+func (r *WebhookUpdate) RawConstraints() string {
+	return r.rawConstraints
+}
+
+// GetConstraints returns casted value of  constraints parameter
+func (r *WebhookUpdate) GetConstraints() types.WebhookConstraintSet {
+	return r.Constraints
+}
+
+// HasEnabled returns true if enabled was set
+func (r *WebhookUpdate) HasEnabled() bool {
+	return r.hasEnabled
+}
+
+// RawEnabled returns raw value of enabled parameter
+func (r *WebhookUpdate) RawEnabled() string {
+	return r.rawEnabled
+}
+
+// GetEnabled returns casted value of  enabled parameter
+func (r *WebhookUpdate) GetEnabled() bool {
+	return r.Enabled
+}
+
+// HasWebhookID returns true if webhookID was set
+func (r *WebhookDelete) HasWebhookID() bool {
+	return r.hasWebhookID
+}
+
+// RawWebhookID returns raw value of webhookID parameter
+func (r *WebhookDelete) RawWebhookID() string {
+	return r.rawWebhookID
+}
+
+// GetWebhookID returns casted value of  webhookID parameter
+func (r *WebhookDelete) GetWebhookID() uint64 {
+	return r.WebhookID
+}
+
+// HasWebhookID returns true if webhookID was set
+func (r *WebhookDeliveries) HasWebhookID() bool {
+	return r.hasWebhookID
+}
+
+// RawWebhookID returns raw value of webhookID parameter
+func (r *WebhookDeliveries) RawWebhookID() string {
+	return r.rawWebhookID
+}
+
+// GetWebhookID returns casted value of  webhookID parameter
+func (r *WebhookDeliveries) GetWebhookID() uint64 {
+	return r.WebhookID
+}
+
+// HasStatus returns true if status was set
+func (r *WebhookDeliveries) HasStatus() bool {
+	return r.hasStatus
+}
+
+// RawStatus returns raw value of status parameter
+func (r *WebhookDeliveries) RawStatus() string {
+	return r.rawStatus
+}
+
+// GetStatus returns casted value of  status parameter
+func (r *WebhookDeliveries) GetStatus() string {
+	return r.Status
+}
+
+// HasLimit returns true if limit was set
+func (r *WebhookDeliveries) HasLimit() bool {
+	return r.hasLimit
+}
+
+// RawLimit returns raw value of limit parameter
+func (r *WebhookDeliveries) RawLimit() string {
+	return r.rawLimit
+}
+
+// GetLimit returns casted value of  limit parameter
+func (r *WebhookDeliveries) GetLimit() uint {
+	return r.Limit
+}
+
+// HasOffset returns true if offset was set
+func (r *WebhookDeliveries) HasOffset() bool {
+	return r.hasOffset
+}
+
+// RawOffset returns raw value of offset parameter
+func (r *WebhookDeliveries) RawOffset() string {
+	return r.rawOffset
+}
+
+// GetOffset returns casted value of  offset parameter
+func (r *WebhookDeliveries) GetOffset() uint {
+	return r.Offset
+}
+
+// HasPage returns true if page was set
+func (r *WebhookDeliveries) HasPage() bool {
+	return r.hasPage
+}
+
+// RawPage returns raw value of page parameter
+func (r *WebhookDeliveries) RawPage() string {
+	return r.rawPage
+}
+
+// GetPage returns casted value of  page parameter
+func (r *WebhookDeliveries) GetPage() uint {
+	return r.Page
+}
+
+// HasPerPage returns true if perPage was set
+func (r *WebhookDeliveries) HasPerPage() bool {
+	return r.hasPerPage
+}
+
+// RawPerPage returns raw value of perPage parameter
+func (r *WebhookDeliveries) RawPerPage() string {
+	return r.rawPerPage
+}
+
+// GetPerPage returns casted value of  perPage parameter
+func (r *WebhookDeliveries) GetPerPage() uint {
+	return r.PerPage
+}
+
+// HasSort returns true if sort was set
+func (r *WebhookDeliveries) HasSort() bool {
+	return r.hasSort
+}
+
+// RawSort returns raw value of sort parameter
+func (r *WebhookDeliveries) RawSort() string {
+	return r.rawSort
+}
+
+// GetSort returns casted value of  sort parameter
+func (r *WebhookDeliveries) GetSort() string {
+	return r.Sort
+}
+
+// HasDeliveryID returns true if deliveryID was set
+func (r *WebhookRetry) HasDeliveryID() bool {
+	return r.hasDeliveryID
+}
+
+// RawDeliveryID returns raw value of deliveryID parameter
+func (r *WebhookRetry) RawDeliveryID() string {
+	return r.rawDeliveryID
+}
+
+// GetDeliveryID returns casted value of  deliveryID parameter
+func (r *WebhookRetry) GetDeliveryID() uint64 {
+	return r.DeliveryID
+}
@@ -38,5 +38,7 @@ func MountRoutes(r chi.Router) {
 		handlers.NewSettings(Settings{}.New()).MountRoutes(r)
 		handlers.NewStats(Stats{}.New()).MountRoutes(r)
 		handlers.NewReminder(Reminder{}.New()).MountRoutes(r)
+		handlers.NewWebhook(Webhook{}.New()).MountRoutes(r)
+		handlers.NewJobs(Jobs{}.New()).MountRoutes(r)
 	})
 }
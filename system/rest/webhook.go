@@ -0,0 +1,106 @@
+package rest
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/titpetric/factory/resputil"
+
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+	"github.com/cortezaproject/corteza-server/system/rest/request"
+	"github.com/cortezaproject/corteza-server/system/service"
+	"github.com/cortezaproject/corteza-server/system/types"
+)
+
+var _ = errors.Wrap
+
+type (
+	Webhook struct {
+		webhook service.WebhookService
+	}
+
+	webhookSetPayload struct {
+		Filter types.WebhookFilter `json:"filter"`
+		Set    types.WebhookSet    `json:"set"`
+	}
+
+	webhookDeliverySetPayload struct {
+		Filter types.WebhookDeliveryFilter `json:"filter"`
+		Set    types.WebhookDeliverySet    `json:"set"`
+	}
+)
+
+func (Webhook) New() *Webhook {
+	return &Webhook{
+		webhook: service.DefaultWebhook,
+	}
+}
+
+func (ctrl Webhook) List(ctx context.Context, r *request.WebhookList) (interface{}, error) {
+	f := types.WebhookFilter{
+		Query:        r.Query,
+		ResourceType: r.ResourceType,
+
+		Sort:       rh.NormalizeSortColumns(r.Sort),
+		PageFilter: rh.Paging(r),
+	}
+
+	set, filter, err := ctrl.webhook.With(ctx).Find(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhookSetPayload{Filter: filter, Set: set}, nil
+}
+
+func (ctrl Webhook) Create(ctx context.Context, r *request.WebhookCreate) (interface{}, error) {
+	return ctrl.webhook.With(ctx).Create(&types.Webhook{
+		Name:         r.Name,
+		URL:          r.Url,
+		ResourceType: r.ResourceType,
+		EventTypes:   r.EventTypes,
+		Constraints:  r.Constraints,
+		Enabled:      r.Enabled,
+	})
+}
+
+func (ctrl Webhook) Read(ctx context.Context, r *request.WebhookRead) (interface{}, error) {
+	return ctrl.webhook.With(ctx).FindByID(r.WebhookID)
+}
+
+func (ctrl Webhook) Update(ctx context.Context, r *request.WebhookUpdate) (interface{}, error) {
+	return ctrl.webhook.With(ctx).Update(&types.Webhook{
+		ID:           r.WebhookID,
+		Name:         r.Name,
+		URL:          r.Url,
+		ResourceType: r.ResourceType,
+		EventTypes:   r.EventTypes,
+		Constraints:  r.Constraints,
+		Enabled:      r.Enabled,
+	})
+}
+
+func (ctrl Webhook) Delete(ctx context.Context, r *request.WebhookDelete) (interface{}, error) {
+	return resputil.OK(), ctrl.webhook.With(ctx).DeleteByID(r.WebhookID)
+}
+
+func (ctrl Webhook) Deliveries(ctx context.Context, r *request.WebhookDeliveries) (interface{}, error) {
+	f := types.WebhookDeliveryFilter{
+		WebhookID: r.WebhookID,
+		Status:    types.WebhookDeliveryStatus(r.Status),
+
+		Sort:       rh.NormalizeSortColumns(r.Sort),
+		PageFilter: rh.Paging(r),
+	}
+
+	set, filter, err := ctrl.webhook.With(ctx).FindDeliveries(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhookDeliverySetPayload{Filter: filter, Set: set}, nil
+}
+
+func (ctrl Webhook) Retry(ctx context.Context, r *request.WebhookRetry) (interface{}, error) {
+	return ctrl.webhook.With(ctx).RetryDelivery(r.DeliveryID)
+}
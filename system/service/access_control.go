@@ -42,6 +42,7 @@ func (svc accessControl) Effective(ctx context.Context) (ee permissions.Effectiv
 	ee.Push(types.SystemPermissionResource, "application.create", svc.CanCreateApplication(ctx))
 	ee.Push(types.SystemPermissionResource, "role.create", svc.CanCreateRole(ctx))
 	ee.Push(types.SystemPermissionResource, "organisation.create", svc.CanCreateOrganisation(ctx))
+	ee.Push(types.SystemPermissionResource, "webhook.manage", svc.CanManageWebhooks(ctx))
 
 	return
 }
@@ -62,6 +63,10 @@ func (svc accessControl) CanManageSettings(ctx context.Context) bool {
 	return svc.can(ctx, types.SystemPermissionResource, "settings.manage")
 }
 
+func (svc accessControl) CanManageWebhooks(ctx context.Context) bool {
+	return svc.can(ctx, types.SystemPermissionResource, "webhook.manage")
+}
+
 func (svc accessControl) CanCreateOrganisation(ctx context.Context) bool {
 	return svc.can(ctx, types.SystemPermissionResource, "organisation.create")
 }
@@ -209,6 +214,7 @@ func (svc accessControl) Whitelist() permissions.Whitelist {
 		"user.create",
 		"application.create",
 		"reminder.assign",
+		"webhook.manage",
 	)
 
 	wl.Set(
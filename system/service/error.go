@@ -21,6 +21,8 @@ const (
 	ErrNoScriptCreatePermissions      serviceError = "NoScriptCreatePermissions"
 	ErrNoReminderAssignPermissions    serviceError = "NoReminderAssignPermissions"
 
+	ErrWebhookInvalid serviceError = "WebhookInvalid"
+
 	ErrUserSuspended serviceError = "UserSuspended"
 	ErrUserDeleted   serviceError = "UserDeleted"
 	ErrUserInvalid   serviceError = "UserInvalid"
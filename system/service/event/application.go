@@ -7,7 +7,17 @@ import (
 
 // Match returns false if given conditions do not match event & resource internals
 func (res applicationBase) Match(c eventbus.ConstraintMatcher) bool {
-	return applicationMatch(res.application, c)
+	return eventbus.MatchFirst(
+		func() bool { return applicationMatch(res.application, c) },
+		func() bool {
+			return res.oldApplication != nil && eventbus.MatchChange(
+				c,
+				func(c eventbus.ConstraintMatcher) bool { return applicationMatch(res.oldApplication, c) },
+				func(c eventbus.ConstraintMatcher) bool { return applicationMatch(res.application, c) },
+			)
+		},
+		func() bool { return eventbus.MatchInvoker(res.invoker, c) },
+	)
 }
 
 // Handles application matchers
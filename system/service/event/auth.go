@@ -4,5 +4,8 @@ import "github.com/cortezaproject/corteza-server/pkg/eventbus"
 
 // Match returns false if given conditions do not match event & resource internals
 func (res authBase) Match(c eventbus.ConstraintMatcher) bool {
-	return userMatch(res.user, c)
+	return eventbus.MatchFirst(
+		func() bool { return userMatch(res.user, c) },
+		func() bool { return eventbus.MatchInvoker(res.invoker, c) },
+	)
 }
@@ -15,7 +15,10 @@ func (res mailBase) Match(c eventbus.ConstraintMatcher) bool {
 	//   constraint#1 AND constraint#2 AND constraint#3 ...
 	//
 	// When there are multiple values, Match() can decide how to treat them (OR, AND...)
-	return mailMatch(res.message, c)
+	return eventbus.MatchFirst(
+		func() bool { return mailMatch(res.message, c) },
+		func() bool { return eventbus.MatchInvoker(res.invoker, c) },
+	)
 }
 
 // Handles role matchers
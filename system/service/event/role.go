@@ -7,7 +7,17 @@ import (
 
 // Match returns false if given conditions do not match event & resource internals
 func (res roleBase) Match(c eventbus.ConstraintMatcher) bool {
-	return roleMatch(res.role, c)
+	return eventbus.MatchFirst(
+		func() bool { return roleMatch(res.role, c) },
+		func() bool {
+			return res.oldRole != nil && eventbus.MatchChange(
+				c,
+				func(c eventbus.ConstraintMatcher) bool { return roleMatch(res.oldRole, c) },
+				func(c eventbus.ConstraintMatcher) bool { return roleMatch(res.role, c) },
+			)
+		},
+		func() bool { return eventbus.MatchInvoker(res.invoker, c) },
+	)
 }
 
 // Handles role matchers
@@ -9,5 +9,6 @@ func (res roleMemberBase) Match(c eventbus.ConstraintMatcher) bool {
 	return eventbus.MatchFirst(
 		func() bool { return userMatch(res.user, c) },
 		func() bool { return roleMatch(res.role, c) },
+		func() bool { return eventbus.MatchInvoker(res.invoker, c) },
 	)
 }
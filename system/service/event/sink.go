@@ -10,6 +10,7 @@ const (
 	sinkMatchRequestGet    = "request.get."
 	sinkMatchRequestPost   = "request.post."
 	sinkMatchRequestHeader = "request.header."
+	sinkMatchRequestParam  = "request.param."
 )
 
 // Match returns false if given conditions do not match event & resource internals
@@ -31,11 +32,14 @@ func sinkMatch(r *types.SinkRequest, c eventbus.ConstraintMatcher) bool {
 		return c.Match(r.Username)
 	case "request.password":
 		return c.Match(r.Password)
+	case "request.route":
+		// name of the matched sink route
+		return c.Match(r.Route)
 	case "request.content-type":
 		return c.Match(r.Header.Get("content-type"))
 	}
 
-	// Dynamically check matcher name if it contains request.(get|post|header).*
+	// Dynamically check matcher name if it contains request.(get|post|param|header).*
 	// and use value for matcher:
 	//
 	// to match "&foo=bar" in URL string use .where('request.get.foo', 'bar')
@@ -50,6 +54,10 @@ func sinkMatch(r *types.SinkRequest, c eventbus.ConstraintMatcher) bool {
 		return c.Match(r.PostForm.Get(c.Name()[len(sinkMatchRequestPost):]))
 	}
 
+	if strings.HasPrefix(c.Name(), sinkMatchRequestParam) {
+		return c.Match(r.Params[c.Name()[len(sinkMatchRequestParam):]])
+	}
+
 	if strings.HasPrefix(c.Name(), sinkMatchRequestHeader) {
 		return c.Match(r.Header.Get(c.Name()[len(sinkMatchRequestHeader):]))
 	}
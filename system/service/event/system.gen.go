@@ -44,6 +44,13 @@ type (
 	systemOnTimestamp struct {
 		*systemBase
 	}
+
+	// systemOnCron
+	//
+	// This type is auto-generated.
+	systemOnCron struct {
+		*systemBase
+	}
 )
 
 // ResourceType returns "system"
@@ -74,6 +81,13 @@ func (systemOnTimestamp) EventType() string {
 	return "onTimestamp"
 }
 
+// EventType on systemOnCron returns "onCron"
+//
+// This function is auto-generated.
+func (systemOnCron) EventType() string {
+	return "onCron"
+}
+
 // SystemOnManual creates onManual for system resource
 //
 // This function is auto-generated.
@@ -146,6 +160,30 @@ func SystemOnTimestampImmutable() *systemOnTimestamp {
 	}
 }
 
+// SystemOnCron creates onCron for system resource
+//
+// This function is auto-generated.
+func SystemOnCron() *systemOnCron {
+	return &systemOnCron{
+		systemBase: &systemBase{
+			immutable: false,
+		},
+	}
+}
+
+// SystemOnCronImmutable creates onCron for system resource
+//
+// None of the arguments will be mutable!
+//
+// This function is auto-generated.
+func SystemOnCronImmutable() *systemOnCron {
+	return &systemOnCron{
+		systemBase: &systemBase{
+			immutable: true,
+		},
+	}
+}
+
 // SetInvoker sets new invoker value
 //
 // This function is auto-generated.
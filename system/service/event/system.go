@@ -15,8 +15,13 @@ func (res systemOnTimestamp) Match(c eventbus.ConstraintMatcher) bool {
 	return scheduler.OnTimestamp(c.Values()...)
 }
 
+// Match returns false if given conditions do not match event & resource internals
+func (res systemOnCron) Match(c eventbus.ConstraintMatcher) bool {
+	return scheduler.OnCron(c.Values()...)
+}
+
 // Match returns false if given conditions do not match event & resource internals
 func (res systemBase) Match(c eventbus.ConstraintMatcher) bool {
-	// No constraints are supported for system.
-	return false
+	// No constraints except invoker are supported for system.
+	return eventbus.MatchInvoker(res.invoker, c)
 }
@@ -7,7 +7,17 @@ import (
 
 // Match returns false if given conditions do not match event & resource internals
 func (res userBase) Match(c eventbus.ConstraintMatcher) bool {
-	return userMatch(res.user, c)
+	return eventbus.MatchFirst(
+		func() bool { return userMatch(res.user, c) },
+		func() bool {
+			return res.oldUser != nil && eventbus.MatchChange(
+				c,
+				func(c eventbus.ConstraintMatcher) bool { return userMatch(res.oldUser, c) },
+				func(c eventbus.ConstraintMatcher) bool { return userMatch(res.user, c) },
+			)
+		},
+		func() bool { return eventbus.MatchInvoker(res.invoker, c) },
+	)
 }
 
 // Handles user matchers
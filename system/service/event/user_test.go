@@ -1,6 +1,7 @@
 package event
 
 import (
+	"github.com/cortezaproject/corteza-server/pkg/auth"
 	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 	"github.com/cortezaproject/corteza-server/system/types"
 	"testing"
@@ -20,3 +21,23 @@ func TestUserMatching(t *testing.T) {
 
 	a.True(res.Match(cUsr))
 }
+
+func TestUserMatchChangeAndInvoker(t *testing.T) {
+	var (
+		a   = assert.New(t)
+		res = &userBase{
+			user:    &types.User{Handle: "new"},
+			oldUser: &types.User{Handle: "old"},
+			invoker: auth.NewIdentity(1, 2),
+		}
+	)
+
+	a.True(res.Match(eventbus.MustMakeConstraint("user.handle", "changed")))
+	a.True(res.Match(eventbus.MustMakeConstraint("user.handle", "changedFrom", "old")))
+	a.False(res.Match(eventbus.MustMakeConstraint("user.email", "changed")))
+	a.True(res.Match(eventbus.MustMakeConstraint("invoker", "member of", "2")))
+	a.False(res.Match(eventbus.MustMakeConstraint("invoker", "member of", "3")))
+
+	res.oldUser = nil
+	a.False(res.Match(eventbus.MustMakeConstraint("user.handle", "changed")))
+}
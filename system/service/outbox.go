@@ -0,0 +1,43 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cortezaproject/corteza-server/pkg/outbox"
+	"github.com/cortezaproject/corteza-server/system/service/event"
+	"github.com/cortezaproject/corteza-server/system/types"
+)
+
+func registerOutboxDecoders() {
+	outbox.RegisterDecoder("system:user", decodeUserOutboxEvent)
+}
+
+// decodeUserOutboxEvent restores user event from the outbox
+// so that webhook constraints can be matched against it
+func decodeUserOutboxEvent(eventType string, args map[string][]byte) (outbox.Event, error) {
+	var (
+		u, old *types.User
+	)
+
+	for name, dst := range map[string]interface{}{"user": &u, "oldUser": &old} {
+		if len(args[name]) == 0 {
+			continue
+		}
+
+		if err := json.Unmarshal(args[name], dst); err != nil {
+			return nil, fmt.Errorf("could not decode %s: %v", name, err)
+		}
+	}
+
+	switch eventType {
+	case "afterCreate":
+		return event.UserAfterCreateImmutable(u, old), nil
+	case "afterUpdate":
+		return event.UserAfterUpdateImmutable(u, old), nil
+	case "afterDelete":
+		return event.UserAfterDeleteImmutable(u, old), nil
+	}
+
+	return nil, fmt.Errorf("unsupported system:user event %q", eventType)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/cortezaproject/corteza-server/pkg/app/options"
 	intAuth "github.com/cortezaproject/corteza-server/pkg/auth"
 	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/pkg/outbox"
 	"github.com/cortezaproject/corteza-server/pkg/permissions"
 	"github.com/cortezaproject/corteza-server/pkg/settings"
 	"github.com/cortezaproject/corteza-server/system/repository"
@@ -83,6 +84,10 @@ var (
 	DefaultApplication  ApplicationService
 	DefaultReminder     ReminderService
 	DefaultAttachment   AttachmentService
+	DefaultWebhook      WebhookService
+
+	// DefaultOutbox records system events (see pkg/outbox)
+	DefaultOutbox = outbox.New("sys_outbox")
 
 	DefaultStatistics *statistics
 )
@@ -147,9 +152,15 @@ func Initialize(ctx context.Context, log *zap.Logger, c Config) (err error) {
 	DefaultOrganisation = Organisation(ctx)
 	DefaultApplication = Application(ctx)
 	DefaultReminder = Reminder(ctx)
-	DefaultSink = Sink()
+	DefaultSink = Sink(ctx)
 	DefaultStatistics = Statistics(ctx)
 	DefaultAttachment = Attachment(DefaultStore)
+	DefaultWebhook = Webhook(ctx)
+
+	// Events are recorded in the outbox and relayed to webhooks
+	outbox.Register(DefaultOutbox, repository.DB(ctx))
+	outbox.SetHandler(DefaultWebhook)
+	registerOutboxDecoders()
 
 	return
 }
@@ -167,4 +178,7 @@ func Activate(ctx context.Context) (err error) {
 func Watchers(ctx context.Context) {
 	// Reloading permissions on change
 	DefaultPermissions.Watch(ctx)
+
+	watchWebhookDeliveries(ctx, DefaultLogger.Named("webhook"))
+	watchSinkNonces(ctx, DefaultLogger.Named("sink"))
 }
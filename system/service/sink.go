@@ -1,12 +1,22 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,6 +26,8 @@ import (
 	internalAuth "github.com/cortezaproject/corteza-server/pkg/auth"
 	"github.com/cortezaproject/corteza-server/pkg/eventbus"
 	"github.com/cortezaproject/corteza-server/pkg/logger"
+	"github.com/cortezaproject/corteza-server/pkg/sentry"
+	"github.com/cortezaproject/corteza-server/system/repository"
 	"github.com/cortezaproject/corteza-server/system/service/event"
 	"github.com/cortezaproject/corteza-server/system/types"
 )
@@ -25,6 +37,8 @@ type (
 		logger     *zap.Logger
 		signer     internalAuth.Signer
 		eventbus   sinkEventDispatcher
+		settings   *types.Settings
+		nonces     sinkNonceStore
 		isMonolith bool
 	}
 
@@ -47,6 +61,18 @@ type (
 
 	sinkEventDispatcher interface {
 		WaitFor(ctx context.Context, ev eventbus.Event) (err error)
+		Dispatch(ctx context.Context, ev eventbus.Event)
+	}
+
+	sinkNonceStore interface {
+		Use(route, nonce string, expiresAt time.Time) (bool, error)
+		DeleteExpired(now time.Time) (uint, error)
+	}
+
+	// sinkError is written to the response as-is, with its status
+	sinkError struct {
+		status  int
+		message string
 	}
 )
 
@@ -58,13 +84,26 @@ const (
 
 	SinkSignUrlParamName      = "__sign"
 	SinkSignUrlParamDelimiter = "_"
+
+	// Default headers with HMAC signature, nonce and timestamp for sink routes
+	SinkSignatureHeader = "X-Sink-Signature"
+	SinkNonceHeader     = "X-Sink-Nonce"
+	SinkTimestampHeader = "X-Sink-Timestamp"
+
+	sinkDefaultMaxBodySize   = 32 << 10 // 32k limit
+	sinkDefaultReplayTTL     = time.Minute * 5
+	sinkMaxNonceLength       = 128
+	sinkMaxNonceRouteKey     = 64
+	sinkNonceCleanupInterval = time.Minute * 10
 )
 
-func Sink() *sink {
+func Sink(ctx context.Context) *sink {
 	return &sink{
 		logger:     DefaultLogger,
 		signer:     internalAuth.DefaultSigner,
 		eventbus:   eventbus.Service(),
+		settings:   CurrentSettings,
+		nonces:     repository.SinkNonce(ctx, repository.DB(ctx)),
 		isMonolith: true,
 	}
 }
@@ -101,79 +140,313 @@ func (svc sink) GetPath() string {
 }
 
 // ProcessRequest handles sink request validation and processing
+//
+// Requests that match one of the configured sink routes are processed by the route,
+// all others must be signed (see SignURL)
 func (svc sink) ProcessRequest(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 
+	if svc.settings != nil {
+		if route, params := svc.settings.Sink.Routes.Match(r.Method, svc.trimPath(r.URL.Path)); route != nil {
+			svc.processRoute(route, params, w, r)
+			return
+		}
+	}
+
+	srup, err := svc.verifySignature(r)
+	if err != nil {
+		writeSinkError(w, err)
+		return
+	}
+
+	var body io.Reader
+	if srup.MaxBodySize > 0 {
+		// See if there is content length param and reject it right away
+		if r.ContentLength > srup.MaxBodySize {
+			http.Error(w, "content length exceeds max size limit", http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		// Utilize body only when max-body-size limit is set
+		body = http.MaxBytesReader(w, r.Body, srup.MaxBodySize)
+	} else {
+		body = http.MaxBytesReader(w, r.Body, sinkDefaultMaxBodySize)
+	}
+
+	if err := svc.process(sinkContentType(r), w, r, body); err != nil {
+		http.Error(w, "sink request process error", http.StatusInternalServerError)
+		return
+	}
+}
+
+// verifySignature verifies signed sink URL and checks request against signed params
+func (svc sink) verifySignature(r *http.Request) (*SinkRequestUrlParams, error) {
 	param := r.URL.Query().Get(SinkSignUrlParamName)
 	if len(param) == 0 {
-		http.Error(w, "missing sink signature parameter", http.StatusBadRequest)
-		return
+		return nil, sinkError{http.StatusBadRequest, "missing sink signature parameter"}
 	}
 
 	split := strings.SplitN(param, SinkSignUrlParamDelimiter, 2)
 	if len(split) < 2 {
-		http.Error(w, "invalid sink signature parameter", http.StatusUnauthorized)
-		return
+		return nil, sinkError{http.StatusUnauthorized, "invalid sink signature parameter"}
 	}
 
 	params, err := base64.StdEncoding.DecodeString(split[1])
 	if err != nil {
-		http.Error(w, "bad encoding of sink parameters", http.StatusBadRequest)
-		return
+		return nil, sinkError{http.StatusBadRequest, "bad encoding of sink parameters"}
 	}
 
 	if !svc.signer.Verify(split[0], 0, params) {
-		http.Error(w, "invalid signature", http.StatusUnauthorized)
-		return
+		return nil, sinkError{http.StatusUnauthorized, "invalid signature"}
 	}
 
 	srup := &SinkRequestUrlParams{}
 	if err := json.Unmarshal(params, srup); err != nil {
 		// Impossible scenario :)
 		// How can we have verified signature of an invalid JSON ?!
-		http.Error(w, "invalid sink request url params", http.StatusInternalServerError)
-		return
+		return nil, sinkError{http.StatusInternalServerError, "invalid sink request url params"}
 	}
 
 	if srup.Method != "" && srup.Method != r.Method {
-		http.Error(w, "invalid method", http.StatusUnauthorized)
+		return nil, sinkError{http.StatusUnauthorized, "invalid method"}
+	}
+
+	if srup.ContentType != "" && strings.ToLower(srup.ContentType) != sinkContentType(r) {
+		return nil, sinkError{http.StatusUnauthorized, "invalid content-type"}
+	}
+
+	if srup.Expires != nil && srup.Expires.Before(time.Now()) {
+		return nil, sinkError{http.StatusGone, "signature expired"}
+	}
+
+	return srup, nil
+}
+
+// processRoute authenticates, parses and dispatches request that matched sink route
+func (svc sink) processRoute(route *types.SinkRoute, params map[string]string, w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx         = r.Context()
+		contentType = sinkContentType(r)
+		maxBodySize = route.MaxBodySize
+	)
+
+	if route.ContentType != "" && strings.ToLower(route.ContentType) != contentType {
+		http.Error(w, "unsupported content-type", http.StatusUnsupportedMediaType)
 		return
 	}
 
-	contentType := strings.ToLower(r.Header.Get("content-type"))
-	if i := strings.Index(contentType, ";"); i > 0 {
-		contentType = contentType[0 : i-1]
+	if maxBodySize <= 0 {
+		maxBodySize = sinkDefaultMaxBodySize
 	}
 
-	if srup.ContentType != "" {
-		if strings.ToLower(srup.ContentType) != contentType {
-			http.Error(w, "invalid content-type", http.StatusUnauthorized)
-			return
-		}
+	if r.ContentLength > maxBodySize {
+		http.Error(w, "content length exceeds max size limit", http.StatusRequestEntityTooLarge)
+		return
 	}
 
-	if srup.Expires != nil && srup.Expires.Before(time.Now()) {
-		http.Error(w, "signature expired", http.StatusGone)
+	raw, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, "content length exceeds max size limit", http.StatusRequestEntityTooLarge)
 		return
 	}
 
-	var body io.Reader
-	if srup.MaxBodySize > 0 {
-		// See if there is content length param and reject it right away
-		if r.ContentLength > srup.MaxBodySize {
-			http.Error(w, "content length exceeds max size limit", http.StatusRequestEntityTooLarge)
-		}
+	if err = svc.authenticate(route, r, raw); err != nil {
+		writeSinkError(w, err)
+		return
+	}
 
-		// Utilize body only when max-body-size limit is set
-		body = http.MaxBytesReader(w, r.Body, srup.MaxBodySize)
-	} else {
-		body = http.MaxBytesReader(w, r.Body, 32<<10) // 32k limit
+	if err = svc.checkReplay(route, r, time.Now()); err != nil {
+		writeSinkError(w, err)
+		return
 	}
 
-	if err := svc.process(contentType, w, r, body); err != nil {
+	payload, err := parseSinkPayload(contentType, r.Header.Get("content-type"), raw)
+	if err != nil {
+		http.Error(w, "could not parse request body", http.StatusBadRequest)
+		return
+	}
+
+	svc.sanitizeRequest(r)
+
+	sr, err := types.NewSinkRequest(r, nil)
+	if err != nil {
+		svc.log(ctx).Error("could create sink request event", zap.Error(err))
 		http.Error(w, "sink request process error", http.StatusInternalServerError)
 		return
 	}
+
+	sr.Body = raw
+	sr.Route = route.Name
+	sr.Params = params
+	sr.Payload = payload
+
+	if form, ok := payload.(url.Values); ok {
+		sr.PostForm = form
+	}
+
+	if route.Auth.Mode == types.SinkAuthBasic {
+		// Credentials are verified, no need to pass them to the handlers
+		sr.Password = ""
+	}
+
+	rsp := &types.SinkResponse{
+		Status: route.Response.Status,
+		Header: http.Header{},
+		Body:   route.Response.Body,
+	}
+
+	if route.Response.ContentType != "" {
+		rsp.Header.Set("Content-Type", route.Response.ContentType)
+	}
+
+	if route.Sync {
+		if rsp.Status == 0 {
+			rsp.Status = http.StatusOK
+		}
+
+		if err = svc.eventbus.WaitFor(ctx, event.SinkOnRequest(rsp, sr)); err != nil {
+			svc.log(ctx, zap.String("route", route.Name)).Error("could not process event", zap.Error(err))
+			http.Error(w, "sink request process error", http.StatusInternalServerError)
+			return
+		}
+	} else {
+		if rsp.Status == 0 {
+			rsp.Status = http.StatusAccepted
+		}
+
+		// Request is handled in the background, handlers get a copy of the response
+		// and can not change what was already sent
+		async := *rsp
+		svc.eventbus.Dispatch(context.Background(), event.SinkOnRequestImmutable(&async, sr))
+	}
+
+	if err = writeSinkResponse(w, rsp); err != nil {
+		svc.log(ctx).Error("could not write sink response", zap.Error(err))
+	}
+}
+
+// authenticate checks request against route's auth mode
+//
+// Routes without auth mode expect signed URL
+func (svc sink) authenticate(route *types.SinkRoute, r *http.Request, body []byte) error {
+	switch route.Auth.Mode {
+	case "", types.SinkAuthSignature:
+		_, err := svc.verifySignature(r)
+		return err
+
+	case types.SinkAuthHMAC:
+		if route.Auth.Secret == "" {
+			return sinkError{http.StatusUnauthorized, "sink route secret not configured"}
+		}
+
+		header := route.Auth.Header
+		if header == "" {
+			header = SinkSignatureHeader
+		}
+
+		signature := r.Header.Get(header)
+		if signature == "" {
+			return sinkError{http.StatusUnauthorized, "missing signature"}
+		}
+
+		if route.Replay.Enabled {
+			// Nonce and timestamp are part of the signed payload so they can not be replaced
+			body = append([]byte(r.Header.Get(sinkNonceHeader(route))+"."+r.Header.Get(sinkTimestampHeader(route))+"."), body...)
+		}
+
+		expected := strings.TrimPrefix(webhookSignature(route.Auth.Secret, body), "sha256=")
+		if !hmac.Equal([]byte(expected), []byte(strings.TrimPrefix(signature, "sha256="))) {
+			return sinkError{http.StatusUnauthorized, "invalid signature"}
+		}
+
+		return nil
+
+	case types.SinkAuthBasic:
+		username, password, ok := r.BasicAuth()
+		if !ok ||
+			subtle.ConstantTimeCompare([]byte(username), []byte(route.Auth.Username)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(password), []byte(route.Auth.Password)) != 1 {
+			return sinkError{http.StatusUnauthorized, "invalid credentials"}
+		}
+
+		return nil
+	}
+
+	return sinkError{http.StatusUnauthorized, fmt.Sprintf("unsupported auth mode %q", route.Auth.Mode)}
+}
+
+// checkReplay rejects requests without nonce, requests signed more than TTL ago
+// and requests with nonce that was already used on this route
+//
+// Nonce and timestamp are bound to the request only when it is signed (hmac auth mode),
+// replay protection on routes with any other auth mode is refused.
+//
+// Requests older than TTL are rejected so nonce needs to be remembered only for that period
+func (svc sink) checkReplay(route *types.SinkRoute, r *http.Request, now time.Time) error {
+	if !route.Replay.Enabled {
+		return nil
+	}
+
+	if route.Auth.Mode != types.SinkAuthHMAC {
+		return sinkError{http.StatusUnauthorized, "replay protection requires hmac auth mode"}
+	}
+
+	nonce := r.Header.Get(sinkNonceHeader(route))
+	if nonce == "" {
+		return sinkError{http.StatusBadRequest, "missing nonce"}
+	}
+
+	if len(nonce) > sinkMaxNonceLength {
+		return sinkError{http.StatusBadRequest, "nonce too long"}
+	}
+
+	ts, err := strconv.ParseInt(r.Header.Get(sinkTimestampHeader(route)), 10, 64)
+	if err != nil {
+		return sinkError{http.StatusBadRequest, "missing or invalid timestamp"}
+	}
+
+	ttl := time.Duration(route.Replay.TTL) * time.Second
+	if ttl <= 0 {
+		ttl = sinkDefaultReplayTTL
+	}
+
+	signedAt := time.Unix(ts, 0)
+	if now.Sub(signedAt) > ttl || signedAt.Sub(now) > ttl {
+		return sinkError{http.StatusUnauthorized, "request expired"}
+	}
+
+	fresh, err := svc.nonces.Use(sinkNonceRouteKey(route), nonce, signedAt.Add(ttl))
+	if err != nil {
+		svc.log(r.Context()).Error("could not store nonce", zap.Error(err))
+		return sinkError{http.StatusInternalServerError, "sink request process error"}
+	}
+
+	if !fresh {
+		return sinkError{http.StatusConflict, "nonce already used"}
+	}
+
+	return nil
+}
+
+// Removes sink sign url param and sink path prefix from the request URL
+func (svc sink) sanitizeRequest(r *http.Request) {
+	sanitizedURL := r.URL
+	sanitizedQuery := r.URL.Query()
+	sanitizedQuery.Del(SinkSignUrlParamName)
+	sanitizedURL.RawQuery = sanitizedQuery.Encode()
+	sanitizedURL.Path = svc.trimPath(sanitizedURL.Path)
+
+	r.URL = sanitizedURL
+	r.RequestURI = sanitizedURL.String()
+}
+
+// Returns right side of sink path
+func (svc sink) trimPath(path string) string {
+	if strings.HasPrefix(path, svc.GetPath()) {
+		return path[len(svc.GetPath()):]
+	}
+
+	return path
 }
 
 // Processes sink request, casts it and forwards it to processor (depending on content type)
@@ -184,7 +457,6 @@ func (svc sink) ProcessRequest(w http.ResponseWriter, r *http.Request) {
 // This is useful to enforce mail processing
 // b) Max-body-size check might be limited via sink params
 // and io.Reader that is passed is limited w/ io.LimitReader
-//
 func (svc *sink) process(contentType string, w http.ResponseWriter, r *http.Request, body io.Reader) (err error) {
 	ctx := r.Context()
 
@@ -211,16 +483,7 @@ func (svc *sink) process(contentType string, w http.ResponseWriter, r *http.Requ
 		)
 
 		// Sanitize URL by removing sink sign url param
-		sanitizedURL := r.URL
-		sanitizedQuery := r.URL.Query()
-		sanitizedQuery.Del(SinkSignUrlParamName)
-		sanitizedURL.RawQuery = sanitizedQuery.Encode()
-		if strings.HasPrefix(sanitizedURL.Path, svc.GetPath()) {
-			sanitizedURL.Path = sanitizedURL.Path[len(svc.GetPath()):]
-		}
-
-		r.URL = sanitizedURL
-		r.RequestURI = sanitizedURL.String()
+		svc.sanitizeRequest(r)
 
 		sr, err = types.NewSinkRequest(r, body)
 		if err != nil {
@@ -236,30 +499,183 @@ func (svc *sink) process(contentType string, w http.ResponseWriter, r *http.Requ
 		}
 
 		// Now write everything we've received from the script
-		//if err = rsp.Header.Write(w); err != nil {
-		//	return
-		//}
-		for k, vv := range rsp.Header {
-			for _, v := range vv {
-				w.Header().Add(k, v)
+		err = writeSinkResponse(w, rsp)
+	}
+
+	return
+}
+
+func (e sinkError) Error() string {
+	return e.message
+}
+
+// Writes sink error (or generic error) to the response
+func writeSinkError(w http.ResponseWriter, err error) {
+	if serr, ok := err.(sinkError); ok {
+		http.Error(w, serr.message, serr.status)
+		return
+	}
+
+	http.Error(w, "sink request process error", http.StatusInternalServerError)
+}
+
+// Writes headers, status and body (string or []byte) from sink response
+func writeSinkResponse(w http.ResponseWriter, rsp *types.SinkResponse) (err error) {
+	for k, vv := range rsp.Header {
+		for _, v := range vv {
+			w.Header().Add(k, v)
+		}
+	}
+
+	w.WriteHeader(rsp.Status)
+
+	var output []byte
+	if bb, ok := rsp.Body.([]byte); ok {
+		// Ok, handled
+		output = bb
+	} else if s, ok := rsp.Body.(string); ok {
+		output = []byte(s)
+	}
+
+	_, err = w.Write(output)
+	return
+}
+
+// Returns lowercased media type of the request (without parameters)
+func sinkContentType(r *http.Request) string {
+	contentType := strings.ToLower(r.Header.Get("content-type"))
+	if i := strings.Index(contentType, ";"); i > 0 {
+		contentType = contentType[0:i]
+	}
+
+	return strings.TrimSpace(contentType)
+}
+
+// watchSinkNonces periodically removes expired nonces
+func watchSinkNonces(ctx context.Context, log *zap.Logger) {
+	go func() {
+		defer sentry.Recover()
+
+		var ticker = time.NewTicker(sinkNonceCleanupInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				count, err := DefaultSink.nonces.DeleteExpired(time.Now())
+				if err != nil {
+					log.Error("could not remove expired nonces", zap.Error(err))
+				} else if count > 0 {
+					log.Debug("expired nonces removed", zap.Uint("count", count))
+				}
 			}
 		}
-		w.WriteHeader(rsp.Status)
-
-		var output []byte
-		if bb, ok := rsp.Body.([]byte); ok {
-			// Ok, handled
-			output = bb
-		} else if s, ok := rsp.Body.(string); ok {
-			output = []byte(s)
+	}()
+
+	log.Debug("sink nonce watcher initialized")
+}
+
+// sinkNonceRouteKey identifies route in the nonce store
+//
+// Route name (or path when route has no name) is used;
+// keys that would not fit into the store are hashed
+func sinkNonceRouteKey(route *types.SinkRoute) string {
+	key := route.Name
+	if key == "" {
+		key = route.Path
+	}
+
+	if len(key) > sinkMaxNonceRouteKey {
+		sum := sha256.Sum256([]byte(key))
+		return hex.EncodeToString(sum[:])
+	}
+
+	return key
+}
+
+func sinkNonceHeader(route *types.SinkRoute) string {
+	if route.Replay.Header != "" {
+		return route.Replay.Header
+	}
+
+	return SinkNonceHeader
+}
+
+func sinkTimestampHeader(route *types.SinkRoute) string {
+	if route.Replay.TimestampHeader != "" {
+		return route.Replay.TimestampHeader
+	}
+
+	return SinkTimestampHeader
+}
+
+// parseSinkPayload parses request body into structured payload
+//
+// JSON is decoded into generic value, form-encoded body into url.Values,
+// multipart body into fields & files and RFC822 message into MailMessage.
+// Body of any other content type is not parsed (nil is returned)
+func parseSinkPayload(contentType, header string, body []byte) (interface{}, error) {
+	if len(body) == 0 {
+		return nil, nil
+	}
+
+	switch {
+	case contentType == "application/json" || strings.HasSuffix(contentType, "+json"):
+		var payload interface{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, err
 		}
 
-		if _, err = w.Write(output); err != nil {
-			return
+		return payload, nil
+
+	case contentType == "application/x-www-form-urlencoded":
+		return url.ParseQuery(string(body))
+
+	case contentType == "multipart/form-data":
+		_, params, err := mime.ParseMediaType(header)
+		if err != nil {
+			return nil, err
 		}
+
+		return parseSinkMultipart(multipart.NewReader(bytes.NewReader(body), params["boundary"]))
+
+	case contentType == SinkContentTypeMail:
+		return types.NewMailMessage(bytes.NewReader(body))
 	}
 
-	return
+	return nil, nil
+}
+
+func parseSinkMultipart(mr *multipart.Reader) (*types.SinkRequestForm, error) {
+	var form = &types.SinkRequestForm{Fields: url.Values{}}
+
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return form, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		content, err := ioutil.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+
+		if part.FileName() == "" {
+			form.Fields.Add(part.FormName(), string(content))
+			continue
+		}
+
+		form.Files = append(form.Files, &types.SinkRequestFile{
+			Field:       part.FormName(),
+			Name:        part.FileName(),
+			ContentType: part.Header.Get("content-type"),
+			Size:        int64(len(content)),
+			Content:     content,
+		})
+	}
 }
 
 func (svc sink) log(ctx context.Context, fields ...zapcore.Field) *zap.Logger {
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/system/types"
+)
+
+type (
+	mockSinkDispatcher struct {
+		requests []*types.SinkRequest
+		async    int
+	}
+
+	mockNonceStore map[string]bool
+
+	sinkRequestEvent interface {
+		Request() *types.SinkRequest
+		Response() *types.SinkResponse
+	}
+)
+
+func (d *mockSinkDispatcher) WaitFor(_ context.Context, ev eventbus.Event) error {
+	sev := ev.(sinkRequestEvent)
+	d.requests = append(d.requests, sev.Request())
+
+	sev.Response().Status = http.StatusCreated
+	sev.Response().Body = "handled " + sev.Request().Params["orderID"]
+	return nil
+}
+
+func (d *mockSinkDispatcher) Dispatch(_ context.Context, ev eventbus.Event) {
+	d.requests = append(d.requests, ev.(sinkRequestEvent).Request())
+	d.async++
+}
+
+func (s mockNonceStore) Use(route, nonce string, _ time.Time) (bool, error) {
+	if s[route+nonce] {
+		return false, nil
+	}
+
+	s[route+nonce] = true
+	return true, nil
+}
+
+func (s mockNonceStore) DeleteExpired(time.Time) (uint, error) {
+	return 0, nil
+}
+
+func makeSinkTestService(routes ...*types.SinkRoute) (*sink, *mockSinkDispatcher) {
+	var (
+		d   = &mockSinkDispatcher{}
+		set = &types.Settings{}
+	)
+
+	set.Sink.Routes = routes
+
+	return &sink{
+		logger:     zap.NewNop(),
+		eventbus:   d,
+		settings:   set,
+		nonces:     mockNonceStore{},
+		isMonolith: true,
+	}, d
+}
+
+func TestSinkRoute_hmac(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		body = `{"status":"paid","amount":42}`
+
+		svc, d = makeSinkTestService(&types.SinkRoute{
+			Name:   "orders",
+			Path:   "/orders/{orderID}",
+			Method: "POST",
+			Sync:   true,
+			Auth:   types.SinkRouteAuth{Mode: types.SinkAuthHMAC, Secret: "s3cr3t"},
+			Replay: types.SinkRouteReplay{Enabled: true},
+		})
+
+		now     = strconv.FormatInt(time.Now().Unix(), 10)
+		expired = strconv.FormatInt(time.Now().Add(-sinkDefaultReplayTTL-time.Minute).Unix(), 10)
+
+		sign = func(secret, nonce, ts string) string {
+			return webhookSignature(secret, []byte(nonce+"."+ts+"."+body))
+		}
+
+		send = func(nonce, ts, signature string) *httptest.ResponseRecorder {
+			r := httptest.NewRequest("POST", "/system/sink/orders/42", strings.NewReader(body))
+			r.Header.Set("Content-Type", "application/json; charset=utf-8")
+			r.Header.Set(SinkNonceHeader, nonce)
+			r.Header.Set(SinkTimestampHeader, ts)
+			r.Header.Set(SinkSignatureHeader, signature)
+
+			w := httptest.NewRecorder()
+			svc.ProcessRequest(w, r)
+			return w
+		}
+	)
+
+	w := send("n1", now, sign("s3cr3t", "n1", now))
+	req.Equal(http.StatusCreated, w.Code)
+	req.Equal("handled 42", w.Body.String())
+	req.Len(d.requests, 1)
+	req.Equal("orders", d.requests[0].Route)
+	req.Equal("/orders/42", d.requests[0].Path)
+	req.Equal(map[string]interface{}{"status": "paid", "amount": float64(42)}, d.requests[0].Payload)
+
+	// replayed request
+	w = send("n1", now, sign("s3cr3t", "n1", now))
+	req.Equal(http.StatusConflict, w.Code)
+
+	// nonce and timestamp are signed and can not be replaced
+	w = send("n2", now, sign("s3cr3t", "n1", now))
+	req.Equal(http.StatusUnauthorized, w.Code)
+
+	w = send("n2", now, sign("s3cr3t", "n2", expired))
+	req.Equal(http.StatusUnauthorized, w.Code)
+
+	// request signed too long ago
+	w = send("n2", expired, sign("s3cr3t", "n2", expired))
+	req.Equal(http.StatusUnauthorized, w.Code)
+
+	w = send("", now, sign("s3cr3t", "", now))
+	req.Equal(http.StatusBadRequest, w.Code)
+
+	w = send("n3", "", sign("s3cr3t", "n3", ""))
+	req.Equal(http.StatusBadRequest, w.Code)
+
+	w = send("n3", now, sign("invalid", "n3", now))
+	req.Equal(http.StatusUnauthorized, w.Code)
+
+	req.Len(d.requests, 1)
+}
+
+func TestSinkRoute_replayRequiresHMAC(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		svc, d = makeSinkTestService(&types.SinkRoute{
+			Name:   "form",
+			Path:   "/form",
+			Auth:   types.SinkRouteAuth{Mode: types.SinkAuthBasic, Username: "user", Password: "pass"},
+			Replay: types.SinkRouteReplay{Enabled: true},
+		})
+	)
+
+	r := httptest.NewRequest("POST", "/system/sink/form", strings.NewReader("name=foo"))
+	r.Header.Set(SinkNonceHeader, "n1")
+	r.SetBasicAuth("user", "pass")
+
+	w := httptest.NewRecorder()
+	svc.ProcessRequest(w, r)
+	req.Equal(http.StatusUnauthorized, w.Code)
+	req.Empty(d.requests)
+}
+
+func TestSinkNonceRouteKey(t *testing.T) {
+	var (
+		req  = require.New(t)
+		long = strings.Repeat("/segment", 10)
+	)
+
+	req.Equal("orders", sinkNonceRouteKey(&types.SinkRoute{Name: "orders", Path: "/orders"}))
+	req.Equal("/orders", sinkNonceRouteKey(&types.SinkRoute{Path: "/orders"}))
+
+	key := sinkNonceRouteKey(&types.SinkRoute{Path: long})
+	req.Len(key, sinkMaxNonceRouteKey)
+	req.NotEqual(key, sinkNonceRouteKey(&types.SinkRoute{Path: long + "/other"}))
+}
+
+func TestSinkRoute_basicAuth(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		svc, d = makeSinkTestService(&types.SinkRoute{
+			Name:        "form",
+			Path:        "/form",
+			ContentType: "application/x-www-form-urlencoded",
+			Auth:        types.SinkRouteAuth{Mode: types.SinkAuthBasic, Username: "user", Password: "pass"},
+			Response:    types.SinkRouteResponse{Body: "thanks", ContentType: "text/plain"},
+		})
+
+		send = func(contentType, password string) *httptest.ResponseRecorder {
+			r := httptest.NewRequest("POST", "/system/sink/form", strings.NewReader("name=foo&tag=a&tag=b"))
+			r.Header.Set("Content-Type", contentType)
+			r.SetBasicAuth("user", password)
+
+			w := httptest.NewRecorder()
+			svc.ProcessRequest(w, r)
+			return w
+		}
+	)
+
+	w := send("application/x-www-form-urlencoded", "pass")
+	req.Equal(http.StatusAccepted, w.Code)
+	req.Equal("thanks", w.Body.String())
+	req.Equal("text/plain", w.Header().Get("Content-Type"))
+	req.Equal(1, d.async)
+	req.Equal(url.Values{"name": {"foo"}, "tag": {"a", "b"}}, d.requests[0].Payload)
+	req.Equal("foo", d.requests[0].PostForm.Get("name"))
+	req.Empty(d.requests[0].Password)
+
+	w = send("application/x-www-form-urlencoded", "wrong")
+	req.Equal(http.StatusUnauthorized, w.Code)
+
+	w = send("application/json", "pass")
+	req.Equal(http.StatusUnsupportedMediaType, w.Code)
+}
+
+func TestSinkRoute_maxBodySize(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		svc, _ = makeSinkTestService(&types.SinkRoute{
+			Path:        "/upload",
+			MaxBodySize: 4,
+			Auth:        types.SinkRouteAuth{Mode: types.SinkAuthBasic},
+		})
+
+		r = httptest.NewRequest("POST", "/system/sink/upload", strings.NewReader("too large"))
+		w = httptest.NewRecorder()
+	)
+
+	svc.ProcessRequest(w, r)
+	req.Equal(http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestParseSinkPayload(t *testing.T) {
+	var (
+		req = require.New(t)
+		buf = &bytes.Buffer{}
+		mw  = multipart.NewWriter(buf)
+	)
+
+	req.NoError(mw.WriteField("name", "foo"))
+	fw, err := mw.CreateFormFile("file", "a.txt")
+	req.NoError(err)
+	_, _ = fw.Write([]byte("content"))
+	req.NoError(mw.Close())
+
+	payload, err := parseSinkPayload("multipart/form-data", mw.FormDataContentType(), buf.Bytes())
+	req.NoError(err)
+	form := payload.(*types.SinkRequestForm)
+	req.Equal("foo", form.Fields.Get("name"))
+	req.Len(form.Files, 1)
+	req.Equal("a.txt", form.Files[0].Name)
+	req.Equal("file", form.Files[0].Field)
+	req.Equal([]byte("content"), form.Files[0].Content)
+
+	payload, err = parseSinkPayload(SinkContentTypeMail, "", []byte("Subject: Hello\r\nFrom: foo@example.tld\r\n\r\nBody"))
+	req.NoError(err)
+	req.Equal("Hello", payload.(*types.MailMessage).Subject)
+
+	payload, err = parseSinkPayload("application/vnd.api+json", "", []byte(`{"tags":["a","b"],"count":2}`))
+	req.NoError(err)
+	req.Equal(map[string]interface{}{"tags": []interface{}{"a", "b"}, "count": float64(2)}, payload)
+
+	_, err = parseSinkPayload("application/json", "", []byte("{invalid"))
+	req.Error(err)
+
+	payload, err = parseSinkPayload("text/plain", "", []byte("plain"))
+	req.NoError(err)
+	req.Nil(payload)
+}
+
+func TestSinkContentType(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", nil)
+	r.Header.Set("Content-Type", "Application/JSON; charset=utf-8")
+	require.Equal(t, "application/json", sinkContentType(r))
+}
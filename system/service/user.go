@@ -292,6 +292,10 @@ func (svc user) Create(new *types.User) (u *types.User, err error) {
 			return
 		}
 
+		if err = DefaultOutbox.Record(svc.ctx, svc.db, event.UserAfterCreate(new, u)); err != nil {
+			return
+		}
+
 		defer svc.eventbus.Dispatch(svc.ctx, event.UserAfterCreate(new, u))
 		return
 	})
@@ -345,6 +349,10 @@ func (svc user) Update(upd *types.User) (u *types.User, err error) {
 			return
 		}
 
+		if err = DefaultOutbox.Record(svc.ctx, svc.db, event.UserAfterUpdate(upd, u)); err != nil {
+			return
+		}
+
 		defer svc.eventbus.Dispatch(svc.ctx, event.UserAfterUpdate(upd, u))
 		return
 	})
@@ -432,12 +440,18 @@ func (svc user) Delete(ID uint64) (err error) {
 		return
 	}
 
-	if err = svc.user.DeleteByID(ID); err != nil {
-		return
-	}
+	return svc.db.Transaction(func() (err error) {
+		if err = svc.user.DeleteByID(ID); err != nil {
+			return
+		}
 
-	defer svc.eventbus.Dispatch(svc.ctx, event.UserAfterDelete(nil, del))
-	return
+		if err = DefaultOutbox.Record(svc.ctx, svc.db, event.UserAfterDelete(nil, del)); err != nil {
+			return
+		}
+
+		defer svc.eventbus.Dispatch(svc.ctx, event.UserAfterDelete(nil, del))
+		return
+	})
 }
 
 func (svc user) Undelete(ID uint64) (err error) {
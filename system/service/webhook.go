@@ -0,0 +1,472 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/titpetric/factory"
+	"go.uber.org/zap"
+
+	intAuth "github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/outbox"
+	"github.com/cortezaproject/corteza-server/pkg/sentry"
+	"github.com/cortezaproject/corteza-server/system/repository"
+	"github.com/cortezaproject/corteza-server/system/types"
+)
+
+type (
+	webhook struct {
+		db     *factory.DB
+		ctx    context.Context
+		logger *zap.Logger
+		client *http.Client
+
+		ac webhookAccessController
+
+		webhook repository.WebhookRepository
+	}
+
+	webhookAccessController interface {
+		CanManageWebhooks(context.Context) bool
+	}
+
+	// webhookPayload is sent to webhook's URL
+	webhookPayload struct {
+		ResourceType string                     `json:"resourceType"`
+		EventType    string                     `json:"eventType"`
+		Args         map[string]json.RawMessage `json:"args"`
+	}
+
+	WebhookService interface {
+		With(ctx context.Context) WebhookService
+
+		FindByID(webhookID uint64) (*types.Webhook, error)
+		Find(filter types.WebhookFilter) (types.WebhookSet, types.WebhookFilter, error)
+
+		Create(new *types.Webhook) (*types.Webhook, error)
+		Update(upd *types.Webhook) (*types.Webhook, error)
+		DeleteByID(webhookID uint64) error
+
+		FindDeliveries(filter types.WebhookDeliveryFilter) (types.WebhookDeliverySet, types.WebhookDeliveryFilter, error)
+		RetryDelivery(deliveryID uint64) (*types.WebhookDelivery, error)
+
+		Handle(ctx context.Context, ev outbox.Event) error
+		Deliver(now time.Time) (uint, error)
+	}
+)
+
+const (
+	// How often are pending deliveries checked
+	webhookDeliveryInterval = time.Second * 15
+
+	// How many deliveries are attempted at once
+	webhookDeliveryBatchSize = 100
+
+	// Delivery is marked as failed after that many attempts
+	webhookMaxAttempts = 10
+
+	// Delay before the first retry, doubled with each attempt
+	webhookRetryDelay    = time.Second * 30
+	webhookRetryMaxDelay = time.Hour * 6
+
+	// Response body is trimmed to this length before it is stored as an error
+	webhookMaxErrorLength = 1024
+
+	// How long is claimed delivery reserved for the node that is sending it
+	// (must be longer than the client timeout)
+	webhookClaimDuration = time.Minute
+)
+
+func Webhook(ctx context.Context) WebhookService {
+	return (&webhook{
+		logger: DefaultLogger.Named("webhook"),
+		client: &http.Client{Timeout: time.Second * 10},
+		ac:     DefaultAccessControl,
+	}).With(ctx)
+}
+
+func (svc webhook) With(ctx context.Context) WebhookService {
+	db := repository.DB(ctx)
+	return &webhook{
+		db:     db,
+		ctx:    ctx,
+		logger: svc.logger,
+		client: svc.client,
+
+		ac: svc.ac,
+
+		webhook: repository.Webhook(ctx, db),
+	}
+}
+
+func (svc webhook) FindByID(webhookID uint64) (w *types.Webhook, err error) {
+	if !svc.ac.CanManageWebhooks(svc.ctx) {
+		return nil, ErrNoPermissions.withStack()
+	}
+
+	if w, err = svc.webhook.FindByID(webhookID); err != nil {
+		return
+	}
+
+	w.Secret = ""
+	return
+}
+
+func (svc webhook) Find(filter types.WebhookFilter) (set types.WebhookSet, f types.WebhookFilter, err error) {
+	if !svc.ac.CanManageWebhooks(svc.ctx) {
+		return nil, filter, ErrNoPermissions.withStack()
+	}
+
+	if set, f, err = svc.webhook.Find(filter); err != nil {
+		return
+	}
+
+	_ = set.Walk(func(w *types.Webhook) error {
+		w.Secret = ""
+		return nil
+	})
+
+	return
+}
+
+// Create adds a new webhook
+//
+// Secret is generated when not set and returned only here
+func (svc webhook) Create(new *types.Webhook) (*types.Webhook, error) {
+	if !svc.ac.CanManageWebhooks(svc.ctx) {
+		return nil, ErrNoPermissions.withStack()
+	}
+
+	if err := svc.validate(new); err != nil {
+		return nil, err
+	}
+
+	if new.Secret == "" {
+		var secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+
+		new.Secret = hex.EncodeToString(secret)
+	}
+
+	new.CreatedBy = intAuth.GetIdentityFromContext(svc.ctx).Identity()
+	return svc.webhook.Create(new)
+}
+
+// Update modifies webhook
+//
+// Secret is only changed when set
+func (svc webhook) Update(upd *types.Webhook) (w *types.Webhook, err error) {
+	if !svc.ac.CanManageWebhooks(svc.ctx) {
+		return nil, ErrNoPermissions.withStack()
+	}
+
+	if err = svc.validate(upd); err != nil {
+		return
+	}
+
+	if w, err = svc.webhook.FindByID(upd.ID); err != nil {
+		return
+	}
+
+	w.Name = upd.Name
+	w.URL = upd.URL
+	w.ResourceType = upd.ResourceType
+	w.EventTypes = upd.EventTypes
+	w.Constraints = upd.Constraints
+	w.Enabled = upd.Enabled
+
+	if upd.Secret != "" {
+		w.Secret = upd.Secret
+	}
+
+	if w, err = svc.webhook.Update(w); err != nil {
+		return
+	}
+
+	w.Secret = ""
+	return
+}
+
+func (svc webhook) DeleteByID(webhookID uint64) (err error) {
+	if !svc.ac.CanManageWebhooks(svc.ctx) {
+		return ErrNoPermissions.withStack()
+	}
+
+	if _, err = svc.webhook.FindByID(webhookID); err != nil {
+		return
+	}
+
+	return svc.webhook.DeleteByID(webhookID)
+}
+
+func (svc webhook) validate(w *types.Webhook) error {
+	if strings.TrimSpace(w.Name) == "" || w.ResourceType == "" {
+		return ErrWebhookInvalid.withStack()
+	}
+
+	if u, err := url.Parse(w.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrWebhookInvalid.withStack()
+	}
+
+	if err := w.Validate(); err != nil {
+		return ErrWebhookInvalid.withStack()
+	}
+
+	return nil
+}
+
+// FindDeliveries lists deliveries
+//
+// Use status filter with "failed" to get deliveries that will not be retried (dead-letters)
+func (svc webhook) FindDeliveries(filter types.WebhookDeliveryFilter) (types.WebhookDeliverySet, types.WebhookDeliveryFilter, error) {
+	if !svc.ac.CanManageWebhooks(svc.ctx) {
+		return nil, filter, ErrNoPermissions.withStack()
+	}
+
+	return svc.webhook.FindDeliveries(filter)
+}
+
+// RetryDelivery schedules delivery to be attempted again
+func (svc webhook) RetryDelivery(deliveryID uint64) (d *types.WebhookDelivery, err error) {
+	if !svc.ac.CanManageWebhooks(svc.ctx) {
+		return nil, ErrNoPermissions.withStack()
+	}
+
+	if d, err = svc.webhook.FindDeliveryByID(deliveryID); err != nil {
+		return
+	}
+
+	var now = time.Now()
+	d.Status = types.WebhookDeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = &now
+
+	return svc.webhook.UpdateDelivery(d)
+}
+
+// Handle creates deliveries for all enabled webhooks that match the event
+//
+// Implements outbox.Handler; events are relayed from the outbox of the service that recorded them
+func (svc webhook) Handle(ctx context.Context, ev outbox.Event) (err error) {
+	var (
+		ww      types.WebhookSet
+		payload []byte
+		now     = time.Now()
+	)
+
+	if ww, err = svc.webhook.FindEnabled(ev.ResourceType()); err != nil {
+		return
+	}
+
+	ww, _ = ww.Filter(func(w *types.Webhook) (bool, error) {
+		return w.Matches(ev), nil
+	})
+
+	if len(ww) == 0 {
+		return
+	}
+
+	if payload, err = encodeWebhookPayload(ev); err != nil {
+		return
+	}
+
+	return svc.db.Transaction(func() (err error) {
+		for _, w := range ww {
+			_, err = svc.webhook.CreateDelivery(&types.WebhookDelivery{
+				WebhookID:     w.ID,
+				EventType:     ev.EventType(),
+				Payload:       payload,
+				Status:        types.WebhookDeliveryPending,
+				NextAttemptAt: &now,
+			})
+
+			if err != nil {
+				return
+			}
+		}
+
+		return
+	})
+}
+
+// Deliver attempts all due deliveries
+//
+// Returns number of successful deliveries
+func (svc webhook) Deliver(now time.Time) (count uint, err error) {
+	var (
+		dd       types.WebhookDeliverySet
+		webhooks = map[uint64]*types.Webhook{}
+	)
+
+	if dd, err = svc.webhook.FindDueDeliveries(now, webhookDeliveryBatchSize); err != nil {
+		return
+	}
+
+	for _, d := range dd {
+		var claimed bool
+		if claimed, err = svc.webhook.ClaimDelivery(d.ID, now, now.Add(webhookClaimDuration)); err != nil {
+			return
+		} else if !claimed {
+			// delivery is being sent by another node
+			continue
+		}
+
+		w, ok := webhooks[d.WebhookID]
+		if !ok {
+			if w, err = svc.webhook.FindByID(d.WebhookID); err != nil && err != repository.ErrWebhookNotFound {
+				return
+			}
+
+			webhooks[d.WebhookID] = w
+		}
+
+		d.Attempts++
+
+		if w == nil {
+			d.LastError = "webhook removed"
+			d.Status = types.WebhookDeliveryFailed
+		} else if !w.Enabled {
+			d.LastError = "webhook disabled"
+			d.Status = types.WebhookDeliveryFailed
+		} else if d.LastStatusCode, err = svc.send(w, d); err == nil {
+			d.Status = types.WebhookDeliveryDelivered
+			d.DeliveredAt = &now
+			d.LastError = ""
+			count++
+		} else {
+			d.LastError = err.Error()
+			if len(d.LastError) > webhookMaxErrorLength {
+				d.LastError = d.LastError[:webhookMaxErrorLength]
+			}
+
+			if d.Attempts >= webhookMaxAttempts {
+				d.Status = types.WebhookDeliveryFailed
+			} else {
+				next := now.Add(webhookBackoff(d.Attempts))
+				d.NextAttemptAt = &next
+			}
+		}
+
+		if _, err = svc.webhook.UpdateDelivery(d); err != nil {
+			return
+		}
+	}
+
+	return count, nil
+}
+
+// send posts signed payload to webhook's URL
+//
+// Any non-2xx response is considered a failure
+func (svc webhook) send(w *types.Webhook, d *types.WebhookDelivery) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Corteza-Event", d.EventType)
+	req.Header.Set("X-Corteza-Delivery", strconv.FormatUint(d.ID, 10))
+	req.Header.Set("X-Corteza-Signature", webhookSignature(w.Secret, d.Payload))
+
+	rsp, err := svc.client.Do(req.WithContext(svc.ctx))
+	if err != nil {
+		return 0, err
+	}
+
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(io.LimitReader(rsp.Body, webhookMaxErrorLength))
+		return rsp.StatusCode, fmt.Errorf("unexpected response status %d: %s", rsp.StatusCode, body)
+	}
+
+	return rsp.StatusCode, nil
+}
+
+func encodeWebhookPayload(ev outbox.Event) ([]byte, error) {
+	args, err := ev.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	var p = webhookPayload{
+		ResourceType: ev.ResourceType(),
+		EventType:    ev.EventType(),
+		Args:         make(map[string]json.RawMessage, len(args)),
+	}
+
+	for k, v := range args {
+		p.Args[k] = v
+	}
+
+	return json.Marshal(p)
+}
+
+// webhookSignature returns hex encoded HMAC-SHA256 of the payload
+func webhookSignature(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookBackoff returns delay before the next attempt
+func webhookBackoff(attempts uint) time.Duration {
+	var delay = webhookRetryDelay
+	for i := uint(1); i < attempts && delay < webhookRetryMaxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > webhookRetryMaxDelay {
+		delay = webhookRetryMaxDelay
+	}
+
+	return delay
+}
+
+// watchWebhookDeliveries periodically relays recorded events (creating deliveries)
+// and delivers pending webhook deliveries
+func watchWebhookDeliveries(ctx context.Context, log *zap.Logger) {
+	go func() {
+		defer sentry.Recover()
+
+		var ticker = time.NewTicker(webhookDeliveryInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if count, err := outbox.Relay(ctx, time.Now()); err != nil {
+					log.Error("could not relay events from outbox", zap.Error(err))
+				} else if count > 0 {
+					log.Debug("events relayed from outbox", zap.Uint("count", count))
+				}
+
+				count, err := DefaultWebhook.With(intAuth.SetSuperUserContext(ctx)).Deliver(time.Now())
+				if err != nil {
+					log.Error("could not deliver webhooks", zap.Error(err))
+				} else if count > 0 {
+					log.Debug("webhooks delivered", zap.Uint("count", count))
+				}
+			}
+		}
+	}()
+
+	log.Debug("webhook delivery watcher initialized")
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cortezaproject/corteza-server/system/types"
+)
+
+func TestWebhookBackoff(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal(webhookRetryDelay, webhookBackoff(1))
+	req.Equal(webhookRetryDelay*2, webhookBackoff(2))
+	req.Equal(webhookRetryDelay*8, webhookBackoff(4))
+	req.Equal(webhookRetryMaxDelay, webhookBackoff(webhookMaxAttempts*2))
+}
+
+func TestWebhookSend(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		payload   = []byte(`{"resourceType":"system:user"}`)
+		signature string
+		status    = http.StatusOK
+
+		srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			signature = r.Header.Get("X-Corteza-Signature")
+			w.WriteHeader(status)
+		}))
+
+		svc = webhook{ctx: context.Background(), client: &http.Client{Timeout: time.Second}}
+		w   = &types.Webhook{URL: srv.URL, Secret: "s3cr3t"}
+		d   = &types.WebhookDelivery{ID: 42, EventType: "afterCreate", Payload: payload}
+	)
+
+	defer srv.Close()
+
+	code, err := svc.send(w, d)
+	req.NoError(err)
+	req.Equal(http.StatusOK, code)
+	req.Equal(webhookSignature("s3cr3t", payload), signature)
+	req.Contains(signature, "sha256=")
+
+	status = http.StatusInternalServerError
+	code, err = svc.send(w, d)
+	req.Error(err)
+	req.Equal(http.StatusInternalServerError, code)
+}
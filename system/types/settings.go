@@ -103,6 +103,11 @@ type (
 			} `json:"-"`
 		}
 
+		Sink struct {
+			// Declarative routes for incoming HTTP requests
+			Routes SinkRouteSet `kv:"routes,final"`
+		} `kv:"sink" json:"-"`
+
 		UI struct {
 			// Corteza One configuration settings
 			One struct {
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 type (
@@ -27,6 +28,98 @@ type (
 		// RawBody will be base64 encoded!
 		// (might contain binary data)
 		Body []byte `json:"rawBody,string"`
+
+		// Name of the matched sink route and parameters from its path
+		Route  string            `json:"route,omitempty"`
+		Params map[string]string `json:"params,omitempty"`
+
+		// Body parsed according to content type (JSON, form, multipart, mail)
+		Payload interface{} `json:"payload,omitempty"`
+	}
+
+	// SinkRoute maps incoming requests to the sink event
+	//
+	// Routes are configured in settings (sink.routes) as JSON array
+	SinkRoute struct {
+		// Passed to the event as request.route
+		Name string `json:"name"`
+
+		// Path (right side of /sink); segments in braces ({orderID}) are captured as params,
+		// trailing * matches the rest of the path
+		Path string `json:"path"`
+
+		// Expected method and content type (any when empty)
+		Method      string `json:"method"`
+		ContentType string `json:"contentType"`
+
+		Auth SinkRouteAuth `json:"auth"`
+
+		// Max size of the body, 32k when not set
+		MaxBodySize int64 `json:"maxBodySize"`
+
+		// When enabled, each request must have unique nonce
+		Replay SinkRouteReplay `json:"replay"`
+
+		// Wait for the event handlers and respond with status & body they set;
+		// otherwise request is accepted right away and handled in background
+		Sync bool `json:"sync"`
+
+		// Default response
+		Response SinkRouteResponse `json:"response"`
+	}
+
+	SinkRouteSet []*SinkRoute
+
+	// SinkRequestForm holds parsed multipart/form-data body
+	SinkRequestForm struct {
+		Fields url.Values         `json:"fields"`
+		Files  []*SinkRequestFile `json:"files"`
+	}
+
+	SinkRequestFile struct {
+		Field       string `json:"field"`
+		Name        string `json:"name"`
+		ContentType string `json:"contentType"`
+		Size        int64  `json:"size"`
+
+		// Content will be base64 encoded
+		Content []byte `json:"content"`
+	}
+
+	SinkRouteAuth struct {
+		Mode SinkAuthMode `json:"mode"`
+
+		// Secret for HMAC signature of the body and header that holds the signature
+		Secret string `json:"secret"`
+		Header string `json:"header"`
+
+		// Credentials for basic auth
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+
+	SinkAuthMode string
+
+	// SinkRouteReplay can only be enabled on routes with hmac auth mode;
+	// nonce and timestamp are signed together with the body
+	SinkRouteReplay struct {
+		Enabled bool `json:"enabled"`
+
+		// Header with the nonce
+		Header string `json:"header"`
+
+		// Header with the request timestamp (unix seconds)
+		TimestampHeader string `json:"timestampHeader"`
+
+		// How long (in seconds) is request accepted after it was signed;
+		// nonce is remembered for the same period
+		TTL int `json:"ttl"`
+	}
+
+	SinkRouteResponse struct {
+		Status      int    `json:"status"`
+		ContentType string `json:"contentType"`
+		Body        string `json:"body"`
 	}
 
 	SinkResponse struct {
@@ -36,6 +129,64 @@ type (
 	}
 )
 
+const (
+	// Request must be signed with sink's signature (see sink signature command)
+	SinkAuthSignature SinkAuthMode = "signature"
+
+	// Body must be signed with HMAC-SHA256 of the route secret
+	SinkAuthHMAC SinkAuthMode = "hmac"
+
+	// Request must have basic auth credentials
+	SinkAuthBasic SinkAuthMode = "basic"
+)
+
+// Match checks route's method & path and returns params captured from the path
+func (r SinkRoute) Match(method, path string) (map[string]string, bool) {
+	if r.Method != "" && !strings.EqualFold(r.Method, method) {
+		return nil, false
+	}
+
+	var (
+		pp     = strings.Split(strings.Trim(r.Path, "/"), "/")
+		ss     = strings.Split(strings.Trim(path, "/"), "/")
+		params = make(map[string]string)
+	)
+
+	for i, p := range pp {
+		if p == "*" && i == len(pp)-1 {
+			params["*"] = strings.Join(ss[i:], "/")
+			return params, true
+		}
+
+		if i >= len(ss) {
+			return nil, false
+		}
+
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") && ss[i] != "" {
+			params[p[1:len(p)-1]] = ss[i]
+		} else if p != ss[i] {
+			return nil, false
+		}
+	}
+
+	if len(ss) != len(pp) {
+		return nil, false
+	}
+
+	return params, true
+}
+
+// Match returns first route that matches method & path
+func (set SinkRouteSet) Match(method, path string) (*SinkRoute, map[string]string) {
+	for _, r := range set {
+		if params, ok := r.Match(method, path); ok {
+			return r, params
+		}
+	}
+
+	return nil, nil
+}
+
 func NewSinkRequest(r *http.Request, body io.Reader) (sr *SinkRequest, err error) {
 	sr = &SinkRequest{
 		Method:     r.Method,
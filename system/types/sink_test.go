@@ -0,0 +1,44 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSinkRouteSet_Match(t *testing.T) {
+	var (
+		req = require.New(t)
+
+		set = SinkRouteSet{
+			{Name: "orders", Path: "/orders/{orderID}", Method: "POST"},
+			{Name: "files", Path: "/files/*"},
+			{Name: "ping", Path: "/ping"},
+		}
+	)
+
+	r, params := set.Match("POST", "/orders/42")
+	req.NotNil(r)
+	req.Equal("orders", r.Name)
+	req.Equal("42", params["orderID"])
+
+	r, _ = set.Match("GET", "/orders/42")
+	req.Nil(r)
+
+	r, _ = set.Match("POST", "/orders/42/items")
+	req.Nil(r)
+
+	r, _ = set.Match("POST", "/orders/")
+	req.Nil(r)
+
+	r, params = set.Match("PUT", "/files/a/b.txt")
+	req.NotNil(r)
+	req.Equal("a/b.txt", params["*"])
+
+	r, _ = set.Match("GET", "/ping/")
+	req.NotNil(r)
+	req.Equal("ping", r.Name)
+
+	r, _ = set.Match("GET", "/pong")
+	req.Nil(r)
+}
@@ -0,0 +1,182 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/pkg/errors"
+
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/pkg/rh"
+)
+
+type (
+	// Webhook subscribes external URL to events of one resource type
+	Webhook struct {
+		ID   uint64 `json:"webhookID,string" db:"id"`
+		Name string `json:"name"             db:"name"`
+		URL  string `json:"url"              db:"url"`
+
+		// Used for signing payloads; only returned when webhook is created
+		Secret string `json:"secret,omitempty" db:"secret"`
+
+		ResourceType string               `json:"resourceType" db:"resource_type"`
+		EventTypes   WebhookEventTypes    `json:"eventTypes"   db:"event_types"`
+		Constraints  WebhookConstraintSet `json:"constraints"  db:"constraints"`
+		Enabled      bool                 `json:"enabled"      db:"enabled"`
+
+		CreatedAt time.Time  `json:"createdAt,omitempty" db:"created_at"`
+		CreatedBy uint64     `json:"createdBy,string"    db:"created_by"`
+		UpdatedAt *time.Time `json:"updatedAt,omitempty" db:"updated_at"`
+		DeletedAt *time.Time `json:"deletedAt,omitempty" db:"deleted_at"`
+	}
+
+	WebhookEventTypes []string
+
+	// WebhookConstraint works in the same way as constraints of automation script triggers
+	//
+	// Eg: {"name": "module.handle", "op": "eq", "values": ["lead"]}
+	WebhookConstraint struct {
+		Name   string   `json:"name"`
+		Op     string   `json:"op,omitempty"`
+		Values []string `json:"values"`
+	}
+
+	WebhookConstraintSet []*WebhookConstraint
+
+	WebhookFilter struct {
+		Query        string `json:"query"`
+		ResourceType string `json:"resourceType"`
+
+		Sort string `json:"sort"`
+
+		// Standard paging fields & helpers
+		rh.PageFilter
+	}
+
+	// WebhookDelivery holds event payload for one webhook and tracks delivery attempts
+	WebhookDelivery struct {
+		ID        uint64         `json:"deliveryID,string" db:"id"`
+		WebhookID uint64         `json:"webhookID,string"  db:"rel_webhook"`
+		EventType string         `json:"eventType"         db:"event_type"`
+		Payload   types.JSONText `json:"payload"           db:"payload"`
+
+		Status         WebhookDeliveryStatus `json:"status"              db:"status"`
+		Attempts       uint                  `json:"attempts"            db:"attempts"`
+		NextAttemptAt  *time.Time            `json:"nextAttemptAt"       db:"next_attempt_at"`
+		LastStatusCode int                   `json:"lastStatusCode"      db:"last_status_code"`
+		LastError      string                `json:"lastError,omitempty" db:"last_error"`
+
+		CreatedAt   time.Time  `json:"createdAt"   db:"created_at"`
+		DeliveredAt *time.Time `json:"deliveredAt" db:"delivered_at"`
+	}
+
+	WebhookDeliveryFilter struct {
+		WebhookID uint64                `json:"webhookID,string"`
+		Status    WebhookDeliveryStatus `json:"status"`
+
+		Sort string `json:"sort"`
+
+		// Standard paging fields & helpers
+		rh.PageFilter
+	}
+
+	WebhookDeliveryStatus string
+)
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+
+	// Delivery failed too many times and will not be retried
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// Matches returns true if webhook is enabled and subscribed to the event
+func (w Webhook) Matches(ev eventbus.Event) bool {
+	if !w.Enabled || w.ResourceType != ev.ResourceType() {
+		return false
+	}
+
+	if len(w.EventTypes) > 0 && !w.EventTypes.Has(ev.EventType()) {
+		return false
+	}
+
+	for _, c := range w.Constraints {
+		m, err := eventbus.ConstraintMaker(c.Name, c.Op, c.Values...)
+		if err != nil || !ev.Match(m) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Validate checks webhook constraints
+func (w Webhook) Validate() error {
+	for _, c := range w.Constraints {
+		if _, err := eventbus.ConstraintMaker(c.Name, c.Op, c.Values...); err != nil {
+			return errors.Wrapf(err, "invalid constraint %q", c.Name)
+		}
+	}
+
+	return nil
+}
+
+func (tt WebhookEventTypes) Has(t string) bool {
+	for i := range tt {
+		if tt[i] == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (tt *WebhookEventTypes) Scan(value interface{}) error {
+	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
+	switch value.(type) {
+	case nil:
+		*tt = WebhookEventTypes{}
+	case []uint8:
+		b := value.([]byte)
+		if err := json.Unmarshal(b, tt); err != nil {
+			return errors.Wrapf(err, "Can not scan '%v' into WebhookEventTypes", string(b))
+		}
+	}
+
+	return nil
+}
+
+func (tt WebhookEventTypes) Value() (driver.Value, error) {
+	if tt == nil {
+		tt = WebhookEventTypes{}
+	}
+
+	return json.Marshal(tt)
+}
+
+func (set *WebhookConstraintSet) Scan(value interface{}) error {
+	//lint:ignore S1034 This typecast is intentional, we need to get []byte out of a []uint8
+	switch value.(type) {
+	case nil:
+		*set = WebhookConstraintSet{}
+	case []uint8:
+		b := value.([]byte)
+		if err := json.Unmarshal(b, set); err != nil {
+			return errors.Wrapf(err, "Can not scan '%v' into WebhookConstraintSet", string(b))
+		}
+	}
+
+	return nil
+}
+
+func (set WebhookConstraintSet) Value() (driver.Value, error) {
+	if set == nil {
+		set = WebhookConstraintSet{}
+	}
+
+	return json.Marshal(set)
+}
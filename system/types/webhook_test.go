@@ -0,0 +1,64 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+)
+
+type (
+	webhookTestEvent struct {
+		rType  string
+		eType  string
+		handle string
+	}
+)
+
+func (ev webhookTestEvent) ResourceType() string { return ev.rType }
+func (ev webhookTestEvent) EventType() string    { return ev.eType }
+
+func (ev webhookTestEvent) Match(c eventbus.ConstraintMatcher) bool {
+	return c.Name() == "module.handle" && c.Match(ev.handle)
+}
+
+func TestWebhookMatches(t *testing.T) {
+	var (
+		ev = webhookTestEvent{rType: "compose:record", eType: "afterCreate", handle: "lead"}
+
+		tests = []struct {
+			name  string
+			w     Webhook
+			match bool
+		}{
+			{"disabled",
+				Webhook{ResourceType: "compose:record"},
+				false},
+			{"all events of resource",
+				Webhook{Enabled: true, ResourceType: "compose:record"},
+				true},
+			{"other resource",
+				Webhook{Enabled: true, ResourceType: "system:user"},
+				false},
+			{"matching event type",
+				Webhook{Enabled: true, ResourceType: "compose:record", EventTypes: WebhookEventTypes{"afterUpdate", "afterCreate"}},
+				true},
+			{"other event type",
+				Webhook{Enabled: true, ResourceType: "compose:record", EventTypes: WebhookEventTypes{"afterDelete"}},
+				false},
+			{"matching constraint",
+				Webhook{Enabled: true, ResourceType: "compose:record", Constraints: WebhookConstraintSet{{Name: "module.handle", Values: []string{"lead"}}}},
+				true},
+			{"failing constraint",
+				Webhook{Enabled: true, ResourceType: "compose:record", Constraints: WebhookConstraintSet{{Name: "module.handle", Op: "!=", Values: []string{"lead"}}}},
+				false},
+		}
+	)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.w.Matches(ev); got != tt.match {
+				t.Errorf("Matches() = %v, want %v", got, tt.match)
+			}
+		})
+	}
+}
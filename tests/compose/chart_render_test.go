@@ -0,0 +1,247 @@
+package compose
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+	"github.com/titpetric/factory"
+
+	"github.com/cortezaproject/corteza-server/compose/repository"
+	"github.com/cortezaproject/corteza-server/compose/service"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+)
+
+func (h helper) repoChartSubscription() repository.ChartSubscriptionRepository {
+	return repository.ChartSubscription(context.Background(), db())
+}
+
+func (h helper) repoMakeReportChart(m *types.Module) *types.Chart {
+	c, err := h.repoChart().Create(&types.Chart{
+		Name:        "report chart",
+		Handle:      "report_chart",
+		NamespaceID: m.NamespaceID,
+		Config: types.ChartConfig{
+			Reports: []*types.ChartConfigReport{{
+				ModuleID:   m.ID,
+				Metrics:    []map[string]interface{}{{"field": "count", "type": "bar"}},
+				Dimensions: []map[string]interface{}{{"field": "name"}},
+			}},
+		},
+	})
+
+	h.a.NoError(err)
+	return c
+}
+
+func TestChartRender(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ChartPermissionResource.AppendWildcard(), "read")
+
+	module := h.repoMakeRecordModuleWithFields("chart render module")
+	h.repoMakeRecord(module, &types.RecordValue{Name: "name", Value: "foo"})
+	chart := h.repoMakeReportChart(module)
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/chart/%d/render.svg", module.NamespaceID, chart.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Header("Content-Type", "image/svg+xml").
+		Assert(func(rsp *http.Response, _ *http.Request) error {
+			b, err := ioutil.ReadAll(rsp.Body)
+			if err != nil {
+				return err
+			}
+
+			if !strings.HasPrefix(string(b), "<svg") {
+				return fmt.Errorf("expecting svg, got %q", b)
+			}
+
+			return nil
+		}).
+		End()
+}
+
+func TestChartRender_unsupportedFormat(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ChartPermissionResource.AppendWildcard(), "read")
+
+	module := h.repoMakeRecordModuleWithFields("chart render module")
+	chart := h.repoMakeReportChart(module)
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/chart/%d/render.gif", module.NamespaceID, chart.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("unsupported chart format \"gif\"")).
+		End()
+}
+
+func TestChartSubscriptionCreate(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ChartPermissionResource.AppendWildcard(), "read")
+	h.allow(types.ChartPermissionResource.AppendWildcard(), "update")
+
+	module := h.repoMakeRecordModuleWithFields("chart subscription module")
+	chart := h.repoMakeReportChart(module)
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/chart/%d/subscription/", module.NamespaceID, chart.ID)).
+		FormData("frequency", "weekly").
+		FormData("users", fmt.Sprintf("%d", h.cUser.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.frequency`, "weekly")).
+		Assert(jsonpath.Equal(`$.response.format`, "png")).
+		Assert(jsonpath.Present(`$.response.subscriptionID`)).
+		End()
+}
+
+func TestChartSubscriptionCreate_invalid(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ChartPermissionResource.AppendWildcard(), "read")
+	h.allow(types.ChartPermissionResource.AppendWildcard(), "update")
+
+	module := h.repoMakeRecordModuleWithFields("chart subscription module")
+	chart := h.repoMakeReportChart(module)
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/chart/%d/subscription/", module.NamespaceID, chart.ID)).
+		FormData("frequency", "hourly").
+		FormData("users", fmt.Sprintf("%d", h.cUser.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("compose.service.ChartSubscriptionInvalid")).
+		End()
+
+	// only user IDs are accepted as recipients
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/chart/%d/subscription/", module.NamespaceID, chart.ID)).
+		FormData("frequency", "weekly").
+		FormData("users", "foo@example.tld").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("compose.service.ChartSubscriptionInvalid")).
+		End()
+}
+
+func TestChartSubscriptionCreate_forbidden(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ChartPermissionResource.AppendWildcard(), "read")
+	h.deny(types.ChartPermissionResource.AppendWildcard(), "update")
+
+	module := h.repoMakeRecordModuleWithFields("chart subscription module")
+	chart := h.repoMakeReportChart(module)
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/chart/%d/subscription/", module.NamespaceID, chart.ID)).
+		FormData("frequency", "weekly").
+		FormData("users", fmt.Sprintf("%d", h.cUser.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("compose.service.NoUpdatePermissions")).
+		End()
+}
+
+func TestChartSubscriptionListAndDelete(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ChartPermissionResource.AppendWildcard(), "read")
+
+	module := h.repoMakeRecordModuleWithFields("chart subscription module")
+	chart := h.repoMakeReportChart(module)
+
+	s, err := h.repoChartSubscription().Create(&types.ChartSubscription{
+		NamespaceID: chart.NamespaceID,
+		ChartID:     chart.ID,
+		Frequency:   types.ChartSubscriptionDaily,
+		Format:      "png",
+		Recipients:  types.ChartSubscriptionRecipients{Users: []string{"foo@example.tld"}},
+		CreatedBy:   h.cUser.ID,
+	})
+	h.a.NoError(err)
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/chart/%d/subscription/", module.NamespaceID, chart.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 1)).
+		End()
+
+	h.apiInit().
+		Delete(fmt.Sprintf("/namespace/%d/chart/%d/subscription/%d", module.NamespaceID, chart.ID, s.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	_, err = h.repoChartSubscription().FindByID(chart.NamespaceID, s.ID)
+	h.a.Error(err)
+}
+
+func TestChartSubscriptionClaim(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("chart subscription module")
+	chart := h.repoMakeReportChart(module)
+	due := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	s, err := h.repoChartSubscription().Create(&types.ChartSubscription{
+		NamespaceID: chart.NamespaceID,
+		ChartID:     chart.ID,
+		Frequency:   types.ChartSubscriptionDaily,
+		Format:      "png",
+		Recipients:  types.ChartSubscriptionRecipients{Users: []string{"foo@example.tld"}},
+		NextRunAt:   due,
+		CreatedBy:   h.cUser.ID,
+	})
+	h.a.NoError(err)
+
+	s.NextRunAt = due.Add(time.Hour * 24)
+
+	claimed, err := h.repoChartSubscription().Claim(s, due)
+	h.a.NoError(err)
+	h.a.True(claimed)
+
+	// already claimed (eg: by another node)
+	claimed, err = h.repoChartSubscription().Claim(s, due)
+	h.a.NoError(err)
+	h.a.False(claimed)
+}
+
+func TestChartSubscriptionSendDue_creatorPermissions(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("chart subscription module")
+	chart := h.repoMakeReportChart(module)
+	due := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	// creator without any roles can not read the chart
+	s, err := h.repoChartSubscription().Create(&types.ChartSubscription{
+		NamespaceID: chart.NamespaceID,
+		ChartID:     chart.ID,
+		Frequency:   types.ChartSubscriptionDaily,
+		Format:      "png",
+		Recipients:  types.ChartSubscriptionRecipients{Users: []string{"foo@example.tld"}},
+		NextRunAt:   due,
+		CreatedBy:   factory.Sonyflake.NextID(),
+	})
+	h.a.NoError(err)
+
+	count, err := service.DefaultChartSubscription.With(auth.SetSuperUserContext(context.Background())).SendDue(time.Now())
+	h.a.NoError(err)
+	h.a.Zero(count)
+
+	// rescheduled even though it was not sent
+	s, err = h.repoChartSubscription().FindByID(chart.NamespaceID, s.ID)
+	h.a.NoError(err)
+	h.a.True(s.NextRunAt.After(time.Now()))
+}
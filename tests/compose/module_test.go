@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	jsonpath "github.com/steinfletcher/apitest-jsonpath"
 
@@ -28,7 +29,7 @@ func (h helper) repoSaveModule(mod *types.Module) *types.Module {
 		Create(mod)
 	h.a.NoError(err)
 
-	err = h.repoModule().UpdateFields(m.ID, m.Fields, false)
+	err = h.repoModule().UpdateFields(m.ID, m.Fields)
 	h.a.NoError(err)
 
 	return m
@@ -224,7 +225,7 @@ func TestModuleFieldsUpdate(t *testing.T) {
 	h.a.Equal(ff[1].Kind, "DateTime")
 }
 
-func TestModuleFieldsPreventUpdate_ifRecordExists(t *testing.T) {
+func TestModuleFieldsUpdate_refuseDestructiveMigration(t *testing.T) {
 	h := newHelper(t)
 	h.allow(types.NamespacePermissionResource.AppendWildcard(), "read")
 	ns := h.repoMakeNamespace("some-namespace")
@@ -239,20 +240,157 @@ func TestModuleFieldsPreventUpdate_ifRecordExists(t *testing.T) {
 		JSON(fjs).
 		Expect(t).
 		Status(http.StatusOK).
-		Assert(helpers.AssertNoErrors).
+		Assert(helpers.AssertError("compose.service.ModuleMigrationNotConfirmed")).
 		End()
 
 	ff, err := h.repoModule().FindFields(m.ID)
 	h.a.NoError(err)
 	h.a.NotNil(ff)
-	h.a.Len(ff, 2)
+	h.a.Len(ff, 1)
 
 	h.a.Nil(ff[0].UpdatedAt)
 	h.a.Equal(ff[0].Name, "existing")
 	h.a.Equal(ff[0].Kind, "String")
-	h.a.Nil(ff[1].UpdatedAt)
-	h.a.Equal(ff[1].Name, "new")
-	h.a.Equal(ff[1].Kind, "DateTime")
+}
+
+func TestModuleFieldsUpdate_renameMigratesValues(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.NamespacePermissionResource.AppendWildcard(), "read")
+	ns := h.repoMakeNamespace("some-namespace")
+	m := h.repoMakeModule(ns, "some-module",
+		&types.ModuleField{Kind: "String", Name: "first"},
+		&types.ModuleField{Kind: "String", Name: "second"},
+	)
+	r := h.repoMakeRecord(m,
+		&types.RecordValue{Name: "first", Value: "1st"},
+		&types.RecordValue{Name: "second", Value: "2nd"},
+	)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "update")
+
+	// Fields swap their names
+	fjs := fmt.Sprintf(`{ "name": "%s", "fields": [{ "fieldID": "%d", "name": "second", "kind": "String" }, { "fieldID": "%d", "name": "first", "kind": "String" }] }`, m.Name, m.Fields[0].ID, m.Fields[1].ID)
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d", ns.ID, m.ID)).
+		JSON(fjs).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	vv, err := h.repoRecord().LoadValues([]string{"first", "second"}, []uint64{r.ID})
+	h.a.NoError(err)
+	h.a.Len(vv, 2)
+	h.a.Equal("2nd", vv.FilterByName("first")[0].Value)
+	h.a.Equal("1st", vv.FilterByName("second")[0].Value)
+
+	mm, err := service.DefaultModule.With(h.secCtx()).FindMigrations(ns.ID, m.ID)
+	h.a.NoError(err)
+	h.a.Len(mm, 1)
+	h.a.Equal(types.ModuleMigrationCompleted, mm[0].Status)
+	h.a.Equal(uint(2), mm[0].Processed)
+}
+
+func TestModuleFieldsUpdate_confirmedMigration(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.NamespacePermissionResource.AppendWildcard(), "read")
+	ns := h.repoMakeNamespace("some-namespace")
+	m := h.repoMakeModule(ns, "some-module",
+		&types.ModuleField{Kind: "String", Name: "amount"},
+		&types.ModuleField{Kind: "String", Name: "removed"},
+	)
+	valid := h.repoMakeRecord(m, &types.RecordValue{Name: "amount", Value: " 42 "}, &types.RecordValue{Name: "removed", Value: "a"})
+	invalid := h.repoMakeRecord(m, &types.RecordValue{Name: "amount", Value: "many"})
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "update")
+
+	fjs := fmt.Sprintf(`{ "name": "%s", "confirmMigration": true, "fields": [{ "fieldID": "%d", "name": "amount", "kind": "Number" }] }`, m.Name, m.Fields[0].ID)
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d", ns.ID, m.ID)).
+		JSON(fjs).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	vv, err := h.repoRecord().LoadValues([]string{"amount", "removed"}, []uint64{valid.ID, invalid.ID})
+	h.a.NoError(err)
+	h.a.Len(vv, 1)
+	h.a.Equal(valid.ID, vv[0].RecordID)
+	h.a.Equal("42", vv[0].Value)
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/migration", ns.ID, m.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response`, 1)).
+		Assert(jsonpath.Equal(`$.response[0].status`, "completed")).
+		Assert(jsonpath.Equal(`$.response[0].processed`, float64(3))).
+		End()
+}
+
+func TestModuleMigrationPlan(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.NamespacePermissionResource.AppendWildcard(), "read")
+	ns := h.repoMakeNamespace("some-namespace")
+	m := h.repoMakeModule(ns, "some-module", &types.ModuleField{Kind: "String", Name: "existing"})
+	h.repoMakeRecord(m, &types.RecordValue{Name: "existing", Value: "value"})
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "update")
+
+	fjs := fmt.Sprintf(`{ "fields": [{ "fieldID": "%d", "name": "renamed", "kind": "Number" }] }`, m.Fields[0].ID)
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/migration/plan", ns.ID, m.ID)).
+		JSON(fjs).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.destructive`, true)).
+		Assert(jsonpath.Len(`$.response.steps`, 2)).
+		Assert(jsonpath.Equal(`$.response.steps[0].action`, "rename")).
+		Assert(jsonpath.Equal(`$.response.steps[1].action`, "convert")).
+		Assert(jsonpath.Equal(`$.response.steps[1].values`, float64(1))).
+		End()
+
+	// Nothing changed
+	ff, err := h.repoModule().FindFields(m.ID)
+	h.a.NoError(err)
+	h.a.Equal(ff[0].Name, "existing")
+}
+
+func TestModuleMigrationClaim(t *testing.T) {
+	h := newHelper(t)
+	ns := h.repoMakeNamespace("some-namespace")
+	m := h.repoMakeModule(ns, "some-module", &types.ModuleField{Kind: "String", Name: "existing"})
+	repo := repository.ModuleMigration(context.Background(), db())
+
+	mm, err := repo.Create(&types.ModuleMigration{
+		NamespaceID: ns.ID,
+		ModuleID:    m.ID,
+		Status:      types.ModuleMigrationPending,
+		Total:       1,
+	})
+	h.a.NoError(err)
+
+	loaded := *mm
+
+	claimed, err := repo.Claim(mm)
+	h.a.NoError(err)
+	h.a.True(claimed)
+	h.a.Equal(types.ModuleMigrationRunning, mm.Status)
+
+	// already claimed (eg: by another node)
+	claimed, err = repo.Claim(&loaded)
+	h.a.NoError(err)
+	h.a.False(claimed)
+
+	// running and recently updated
+	set, err := repo.FindUnfinished(time.Now().Add(-time.Minute))
+	h.a.NoError(err)
+	h.a.Nil(set.FindByID(mm.ID))
+
+	// running but not updated since (interrupted)
+	set, err = repo.FindUnfinished(time.Now().Add(time.Minute))
+	h.a.NoError(err)
+	h.a.NotNil(set.FindByID(mm.ID))
 }
 
 func TestModuleDeleteForbidden(t *testing.T) {
@@ -1,13 +1,19 @@
 package compose
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
 
 	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+	"github.com/titpetric/factory"
 
+	"github.com/cortezaproject/corteza-server/compose/decoder"
+	"github.com/cortezaproject/corteza-server/compose/encoder"
 	"github.com/cortezaproject/corteza-server/compose/repository"
 	"github.com/cortezaproject/corteza-server/compose/service"
 	"github.com/cortezaproject/corteza-server/compose/types"
@@ -174,3 +180,268 @@ func TestNamespaceDelete(t *testing.T) {
 	ns, err := h.repoNamespace().FindByID(ns.ID)
 	h.a.Error(err, "compose.repository.NamespaceNotFound")
 }
+
+func TestNamespaceClone(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ComposePermissionResource, "namespace.create")
+	h.allow(types.NamespacePermissionResource.AppendWildcard(), "read")
+	h.allow(types.NamespacePermissionResource.AppendWildcard(), "update")
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "read")
+	h.allow(types.PagePermissionResource.AppendWildcard(), "read")
+	h.allow(types.ChartPermissionResource.AppendWildcard(), "read")
+
+	var (
+		ns   = h.repoMakeNamespace("clone-src-" + string(rand.Bytes(20)))
+		slug = "clone-dst-" + string(rand.Bytes(20))
+
+		parent = h.repoMakeModule(ns, "parent")
+		child  = h.repoMakeModule(ns, "child",
+			&types.ModuleField{Name: "title", Kind: "String"},
+			&types.ModuleField{Name: "parent", Kind: "Record", Options: types.ModuleFieldOptions{"moduleID": fmt.Sprintf("%d", parent.ID)}},
+		)
+
+		page = h.repoMakePage(ns, "parent page")
+	)
+
+	page.ModuleID = parent.ID
+	_, err := h.repoPage().Update(page)
+	h.a.NoError(err)
+
+	chart := &types.Chart{Name: "chart", Handle: "chart", NamespaceID: ns.ID}
+	chart.Config.Reports = []*types.ChartConfigReport{{ModuleID: child.ID}}
+	chart, err = h.repoChart().Create(chart)
+	h.a.NoError(err)
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/clone", ns.ID)).
+		FormData("name", "cloned namespace").
+		FormData("slug", slug).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.slug`, slug)).
+		End()
+
+	clone, err := h.repoNamespace().FindBySlug(slug)
+	h.a.NoError(err)
+	h.a.NotEqual(ns.ID, clone.ID)
+
+	mm, _, err := h.repoModule().Find(types.ModuleFilter{NamespaceID: clone.ID})
+	h.a.NoError(err)
+	h.a.Len(mm, 2)
+
+	var clonedParent, clonedChild *types.Module
+	for _, m := range mm {
+		h.a.NotEqual(parent.ID, m.ID)
+		h.a.NotEqual(child.ID, m.ID)
+
+		switch m.Name {
+		case "parent":
+			clonedParent = m
+		case "child":
+			clonedChild = m
+		}
+	}
+
+	h.a.NotNil(clonedParent)
+	h.a.NotNil(clonedChild)
+
+	ff, err := h.repoModule().FindFields(clonedChild.ID)
+	h.a.NoError(err)
+	h.a.Len(ff, 2)
+	h.a.Equal(fmt.Sprintf("%d", clonedParent.ID), ff.FindByName("parent").Options["moduleID"])
+
+	pp, _, err := h.repoPage().Find(types.PageFilter{NamespaceID: clone.ID})
+	h.a.NoError(err)
+	h.a.Len(pp, 1)
+	h.a.Equal(clonedParent.ID, pp[0].ModuleID)
+
+	cc, _, err := h.repoChart().Find(types.ChartFilter{NamespaceID: clone.ID})
+	h.a.NoError(err)
+	h.a.Len(cc, 1)
+	h.a.Equal(clonedChild.ID, cc[0].Config.Reports[0].ModuleID)
+}
+
+func TestNamespaceCloneForbidden(t *testing.T) {
+	h := newHelper(t)
+	ns := h.repoMakeNamespace("some-namespace")
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/clone", ns.ID)).
+		FormData("name", "cloned namespace").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("compose.service.NoReadPermissions")).
+		End()
+}
+
+func TestNamespaceTemplateInstall_withRecords(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ComposePermissionResource, "namespace.create")
+	h.allow(types.NamespacePermissionResource.AppendWildcard(), "read")
+	h.allow(types.NamespacePermissionResource.AppendWildcard(), "update")
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "read")
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.read")
+
+	var (
+		ns   = h.repoMakeNamespace("tpl-src-" + string(rand.Bytes(20)))
+		slug = "tpl-dst-" + string(rand.Bytes(20))
+		svc  = service.DefaultNamespace.With(h.secCtx())
+	)
+
+	m := h.repoMakeModule(ns, "mod",
+		&types.ModuleField{Name: "title", Kind: "String"},
+		&types.ModuleField{Name: "ref", Kind: "Record"},
+	)
+
+	target := h.repoMakeRecord(m, &types.RecordValue{Name: "title", Value: "target"})
+	h.repoMakeRecord(m,
+		&types.RecordValue{Name: "title", Value: "source"},
+		&types.RecordValue{Name: "ref", Value: fmt.Sprintf("%d", target.ID), Ref: target.ID},
+	)
+
+	tpl, err := svc.Template(ns.ID, true)
+	h.a.NoError(err)
+	h.a.Len(tpl.Records, 2)
+
+	installed, err := svc.Install(tpl, "installed", slug)
+	h.a.NoError(err)
+	h.a.NotEqual(ns.ID, installed.ID)
+
+	mm, _, err := h.repoModule().Find(types.ModuleFilter{NamespaceID: installed.ID})
+	h.a.NoError(err)
+	h.a.Len(mm, 1)
+
+	rr, _, err := h.repoRecord().Find(mm[0], types.RecordFilter{NamespaceID: installed.ID, ModuleID: mm[0].ID})
+	h.a.NoError(err)
+	h.a.Len(rr, 2)
+
+	vv, err := h.repoRecord().LoadValues([]string{"title", "ref"}, rr.IDs())
+	h.a.NoError(err)
+
+	var copiedTarget uint64
+	_ = vv.Walk(func(v *types.RecordValue) error {
+		if v.Name == "title" && v.Value == "target" {
+			copiedTarget = v.RecordID
+		}
+		return nil
+	})
+
+	h.a.NotZero(copiedTarget)
+	h.a.NotEqual(target.ID, copiedTarget)
+
+	ref := vv.FilterByName("ref")
+	h.a.Len(ref, 1)
+	h.a.Equal(copiedTarget, ref[0].Ref)
+}
+
+func TestNamespaceTemplate_readPermissions(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.NamespacePermissionResource.AppendWildcard(), "read")
+	h.allow(types.NamespacePermissionResource.AppendWildcard(), "update")
+
+	var (
+		ns  = h.repoMakeNamespace("tpl-perm-" + string(rand.Bytes(20)))
+		svc = service.DefaultNamespace.With(h.secCtx())
+
+		readable = h.repoMakeModule(ns, "readable",
+			&types.ModuleField{Name: "public", Kind: "String"},
+			&types.ModuleField{Name: "secret", Kind: "String"},
+		)
+		noRecords = h.repoMakeModule(ns, "no records", &types.ModuleField{Name: "title", Kind: "String"})
+		hidden    = h.repoMakeModule(ns, "hidden")
+
+		hiddenPage = h.repoMakePage(ns, "hidden page")
+	)
+
+	h.allow(readable.PermissionResource(), "read")
+	h.allow(readable.PermissionResource(), "record.read")
+	h.allow(noRecords.PermissionResource(), "read")
+	h.deny(noRecords.PermissionResource(), "record.read")
+	h.deny(hidden.PermissionResource(), "read")
+	h.deny(hiddenPage.PermissionResource(), "read")
+	h.deny(readable.Fields.FindByName("secret").PermissionResource(), "record.value.read")
+
+	h.repoMakeRecord(readable,
+		&types.RecordValue{Name: "public", Value: "public value"},
+		&types.RecordValue{Name: "secret", Value: "secret value"},
+	)
+	h.repoMakeRecord(noRecords, &types.RecordValue{Name: "title", Value: "title"})
+
+	tpl, err := svc.Template(ns.ID, true)
+	h.a.NoError(err)
+
+	h.a.Len(tpl.Modules, 2)
+	h.a.Nil(tpl.Modules.FindByID(hidden.ID))
+	h.a.Nil(tpl.Pages.FindByID(hiddenPage.ID))
+
+	h.a.Len(tpl.Records, 1)
+	h.a.Equal(readable.ID, tpl.Records[0].ModuleID)
+	h.a.Len(tpl.Records[0].Values, 1)
+	h.a.Equal("public", tpl.Records[0].Values[0].Name)
+}
+
+func TestNamespaceTemplateInstall_archiveWithAttachments(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ComposePermissionResource, "namespace.create")
+	h.allow(types.NamespacePermissionResource.AppendWildcard(), "read")
+	h.allow(types.NamespacePermissionResource.AppendWildcard(), "update")
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "read")
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.read")
+
+	var (
+		ns   = h.repoMakeNamespace("archive-src-" + string(rand.Bytes(20)))
+		slug = "archive-dst-" + string(rand.Bytes(20))
+		svc  = service.DefaultNamespace.With(h.secCtx())
+		buf  = &bytes.Buffer{}
+	)
+
+	m := h.repoMakeModule(ns, "mod", &types.ModuleField{Name: "file", Kind: "File"})
+
+	att := &types.Attachment{ID: factory.Sonyflake.NextID(), NamespaceID: ns.ID, Kind: types.RecordAttachment, Name: "file.txt"}
+	att.Meta.Original.Extension = "txt"
+	att.Url = service.DefaultStore.Original(att.ID, "txt")
+	h.a.NoError(service.DefaultStore.Save(att.Url, strings.NewReader("file content")))
+
+	att, err := repository.Attachment(context.Background(), db()).Create(att)
+	h.a.NoError(err)
+
+	src := h.repoMakeRecord(m, &types.RecordValue{Name: "file", Value: fmt.Sprintf("%d", att.ID), Ref: att.ID})
+
+	tpl, err := svc.Template(ns.ID, true)
+	h.a.NoError(err)
+	h.a.Len(tpl.Attachments, 1)
+	h.a.NoError(encoder.NamespaceTemplate(buf, tpl))
+
+	tpl, err = decoder.NamespaceTemplate(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	h.a.NoError(err)
+
+	installed, err := svc.Install(tpl, "", slug)
+	h.a.NoError(err)
+
+	mm, _, err := h.repoModule().Find(types.ModuleFilter{NamespaceID: installed.ID})
+	h.a.NoError(err)
+	h.a.Len(mm, 1)
+
+	rr, _, err := h.repoRecord().Find(mm[0], types.RecordFilter{NamespaceID: installed.ID, ModuleID: mm[0].ID})
+	h.a.NoError(err)
+	h.a.Len(rr, 1)
+	h.a.NotEqual(src.ID, rr[0].ID)
+	h.a.Equal(src.CreatedAt.Unix(), rr[0].CreatedAt.Unix())
+	h.a.Equal(src.OwnedBy, rr[0].OwnedBy)
+
+	vv, err := h.repoRecord().LoadValues([]string{"file"}, rr.IDs())
+	h.a.NoError(err)
+	h.a.Len(vv, 1)
+	h.a.NotEqual(att.ID, vv[0].Ref)
+
+	copied, err := repository.Attachment(context.Background(), db()).FindByID(installed.ID, vv[0].Ref)
+	h.a.NoError(err)
+	h.a.NotEqual(att.Url, copied.Url)
+
+	fh, err := service.DefaultStore.Open(copied.Url)
+	h.a.NoError(err)
+	content, err := ioutil.ReadAll(fh)
+	h.a.NoError(err)
+	h.a.Equal("file content", string(content))
+}
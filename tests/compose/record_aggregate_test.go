@@ -0,0 +1,96 @@
+package compose
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+)
+
+func (h helper) makeAggregateModules() (*types.Module, *types.Module) {
+	ns := h.repoMakeNamespace("aggregate testing namespace")
+
+	h.allow(types.NamespacePermissionResource.AppendWildcard(), "read")
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "read")
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.read")
+	h.allow(types.ModuleFieldPermissionResource.AppendWildcard(), "record.value.read")
+
+	account := h.repoMakeModule(ns, "account", &types.ModuleField{Name: "industry"})
+	opportunity := h.repoMakeModule(ns, "opportunity",
+		&types.ModuleField{Name: "amount", Kind: "Number"},
+		&types.ModuleField{Name: "account", Kind: "Record", Options: types.ModuleFieldOptions{"moduleID": strconv.FormatUint(account.ID, 10)}},
+	)
+
+	return account, opportunity
+}
+
+func TestRecordAggregate(t *testing.T) {
+	h := newHelper(t)
+	account, opportunity := h.makeAggregateModules()
+
+	it := h.repoMakeRecord(account, &types.RecordValue{Name: "industry", Value: "IT"})
+	retail := h.repoMakeRecord(account, &types.RecordValue{Name: "industry", Value: "Retail"})
+
+	for _, o := range []struct {
+		account *types.Record
+		amount  string
+	}{{it, "100"}, {it, "300"}, {it, "200"}, {retail, "50"}} {
+		h.repoMakeRecord(opportunity,
+			&types.RecordValue{Name: "amount", Value: o.amount},
+			&types.RecordValue{Name: "account", Value: strconv.FormatUint(o.account.ID, 10), Ref: o.account.ID},
+		)
+	}
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/aggregate", opportunity.NamespaceID, opportunity.ID)).
+		JSON(`{
+			"metrics": [
+				{ "aggregate": "count" },
+				{ "alias": "total", "field": "amount", "aggregate": "sum" },
+				{ "alias": "median", "field": "amount", "aggregate": "percentile", "percentile": 50 },
+				{ "alias": "share", "field": "amount", "aggregate": "sum", "divideBy": "count" }
+			],
+			"dimensions": [ { "alias": "industry", "field": "account.industry" } ],
+			"having": [ { "metric": "count", "operator": ">", "value": 1 } ]
+		}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.dimensions[0].name`, "industry")).
+		Assert(jsonpath.Len(`$.response.metrics`, 4)).
+		Assert(jsonpath.Len(`$.response.rows`, 1)).
+		Assert(jsonpath.Equal(`$.response.rows[0].dimensions[0]`, "IT")).
+		Assert(jsonpath.Equal(`$.response.rows[0].metrics`, []interface{}{3.0, 600.0, 200.0, 200.0})).
+		End()
+}
+
+func TestRecordAggregate_unknownField(t *testing.T) {
+	h := newHelper(t)
+	_, opportunity := h.makeAggregateModules()
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/aggregate", opportunity.NamespaceID, opportunity.ID)).
+		JSON(`{ "dimensions": [ { "field": "account.foo" } ] }`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError(`can not generate report query: unknown field "account.foo"`)).
+		End()
+}
+
+func TestRecordAggregate_invalid(t *testing.T) {
+	h := newHelper(t)
+	_, opportunity := h.makeAggregateModules()
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/aggregate", opportunity.NamespaceID, opportunity.ID)).
+		JSON(`{ "metrics": [ { "field": "amount", "aggregate": "median" } ] }`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError(`unsupported aggregate function "MEDIAN"`)).
+		End()
+}
@@ -0,0 +1,159 @@
+package compose
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+
+	"github.com/cortezaproject/corteza-server/compose/repository"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+)
+
+func (h helper) repoRecordComment() repository.RecordCommentRepository {
+	return repository.RecordComment(context.Background(), db())
+}
+
+func (h helper) repoMakeRecordComment(r *types.Record, userID uint64, msg string) *types.RecordComment {
+	c, err := h.repoRecordComment().Create(&types.RecordComment{
+		NamespaceID: r.NamespaceID,
+		ModuleID:    r.ModuleID,
+		RecordID:    r.ID,
+		Message:     msg,
+		CreatedBy:   userID,
+	})
+
+	h.a.NoError(err)
+	return c
+}
+
+func TestRecordCommentCreate(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record testing module")
+	record := h.repoMakeRecord(module)
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/%d/comment/", module.NamespaceID, module.ID, record.ID)).
+		FormData("message", "first comment").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.message`, "first comment")).
+		Assert(jsonpath.Equal(`$.response.createdBy`, fmt.Sprintf("%d", h.cUser.ID))).
+		End()
+}
+
+func TestRecordCommentCreate_empty(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record testing module")
+	record := h.repoMakeRecord(module)
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/%d/comment/", module.NamespaceID, module.ID, record.ID)).
+		FormData("message", "  ").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("compose.service.RecordCommentEmpty")).
+		End()
+}
+
+func TestRecordCommentReply(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record testing module")
+	record := h.repoMakeRecord(module)
+	parent := h.repoMakeRecordComment(record, h.cUser.ID, "question")
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/%d/comment/", module.NamespaceID, module.ID, record.ID)).
+		FormData("message", "answer").
+		FormData("replyTo", fmt.Sprintf("%d", parent.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.replyTo`, fmt.Sprintf("%d", parent.ID))).
+		End()
+}
+
+func TestRecordCommentList(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record testing module")
+	record := h.repoMakeRecord(module)
+	h.repoMakeRecordComment(record, h.cUser.ID, "one")
+	h.repoMakeRecordComment(record, h.cUser.ID, "two")
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/%d/comment/", module.NamespaceID, module.ID, record.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 2)).
+		Assert(jsonpath.Equal(`$.response.set[0].message`, "one")).
+		End()
+}
+
+func TestRecordCommentUpdate_forbidden(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record testing module")
+	record := h.repoMakeRecord(module)
+	comment := h.repoMakeRecordComment(record, h.cUser.ID+1, "not mine")
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/%d/comment/%d", module.NamespaceID, module.ID, record.ID, comment.ID)).
+		FormData("message", "changed").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("compose.service.NoUpdatePermissions")).
+		End()
+}
+
+func TestRecordCommentDelete(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record testing module")
+	record := h.repoMakeRecord(module)
+	comment := h.repoMakeRecordComment(record, h.cUser.ID, "mine")
+
+	h.apiInit().
+		Delete(fmt.Sprintf("/namespace/%d/module/%d/record/%d/comment/%d", module.NamespaceID, module.ID, record.ID, comment.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	_, err := h.repoRecordComment().FindByID(module.NamespaceID, comment.ID)
+	h.a.Error(err)
+}
+
+func TestRecordActivity(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.update")
+
+	module := h.repoMakeRecordModuleWithFields("record testing module")
+	record := h.repoMakeRecord(module, &types.RecordValue{Name: "name", Value: "before"})
+	h.repoMakeRecordComment(record, h.cUser.ID, "comment")
+
+	_, rve, err := h.updateRecordValues(record, &types.RecordValue{Name: "name", Value: "after"})
+	h.a.NoError(err)
+	h.a.Nil(rve)
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/%d/activity", module.NamespaceID, module.ID, record.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response`, 2)).
+		Assert(jsonpath.Equal(`$.response[0].kind`, "comment")).
+		Assert(jsonpath.Equal(`$.response[1].kind`, "change")).
+		Assert(jsonpath.Equal(`$.response[1].change.fields[0].field`, "name")).
+		Assert(jsonpath.Equal(`$.response[1].change.fields[0].old[0]`, "before")).
+		Assert(jsonpath.Equal(`$.response[1].change.fields[0].new[0]`, "after")).
+		End()
+}
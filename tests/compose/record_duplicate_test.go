@@ -0,0 +1,257 @@
+package compose
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+)
+
+func (h helper) repoMakeDuplicatesModule() *types.Module {
+	m := h.repoMakeRecordModuleWithFields(
+		"duplicates module",
+		&types.ModuleField{Name: "name"},
+		&types.ModuleField{Name: "email", Kind: "Email"},
+		&types.ModuleField{Name: "phone"},
+	)
+
+	m.Meta = []byte(`{"duplicates":{"threshold":0.5,"rules":[` +
+		`{"field":"email","match":"email","weight":2},` +
+		`{"field":"phone","match":"phone"},` +
+		`{"field":"name","match":"name"}]}}`)
+
+	_, err := h.repoModule().Update(m)
+	h.a.NoError(err)
+
+	return m
+}
+
+func TestRecordDuplicates(t *testing.T) {
+	h := newHelper(t)
+
+	var (
+		m  = h.repoMakeDuplicatesModule()
+		r1 = h.repoMakeRecord(m,
+			&types.RecordValue{Name: "name", Value: "John Doe"},
+			&types.RecordValue{Name: "email", Value: "john@example.tld"},
+		)
+		r2 = h.repoMakeRecord(m,
+			&types.RecordValue{Name: "name", Value: "Doe, John"},
+			&types.RecordValue{Name: "email", Value: "John+crm@example.tld"},
+		)
+		_ = h.repoMakeRecord(m,
+			&types.RecordValue{Name: "name", Value: "Jane Roe"},
+			&types.RecordValue{Name: "email", Value: "jane@example.tld"},
+		)
+	)
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/duplicates", m.NamespaceID, m.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response`, 1)).
+		Assert(jsonpath.Len(`$.response[0].records`, 2)).
+		Assert(jsonpath.Equal(`$.response[0].records[0].recordID`, fmt.Sprintf("%d", r1.ID))).
+		Assert(jsonpath.Equal(`$.response[0].records[1].recordID`, fmt.Sprintf("%d", r2.ID))).
+		End()
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/%d/duplicates", m.NamespaceID, m.ID, r1.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response`, 1)).
+		Assert(jsonpath.Equal(`$.response[0].recordID`, fmt.Sprintf("%d", r2.ID))).
+		End()
+}
+
+func TestRecordCreate_duplicateWarning(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.create")
+
+	var (
+		m = h.repoMakeDuplicatesModule()
+		r = h.repoMakeRecord(m, &types.RecordValue{Name: "phone", Value: "+386 40 123 456"}, &types.RecordValue{Name: "email", Value: "j@example.tld"})
+	)
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/", m.NamespaceID, m.ID)).
+		JSON(`{"values":[{"name":"phone","value":"38640123456"},{"name":"email","value":"J@example.tld"}]}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.duplicates`, 1)).
+		Assert(jsonpath.Equal(`$.response.duplicates[0].recordID`, fmt.Sprintf("%d", r.ID))).
+		End()
+}
+
+func TestRecordMergeForbidden(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.update")
+
+	var (
+		m  = h.repoMakeDuplicatesModule()
+		r1 = h.repoMakeRecord(m)
+		r2 = h.repoMakeRecord(m)
+	)
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/%d/merge", m.NamespaceID, m.ID, r1.ID)).
+		FormData("sourceRecordID", fmt.Sprintf("%d", r2.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("compose.service.NoDeletePermissions")).
+		End()
+}
+
+func TestRecordMerge(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.update")
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.delete")
+
+	var (
+		m      = h.repoMakeDuplicatesModule()
+		target = h.repoMakeRecord(m,
+			&types.RecordValue{Name: "name", Value: "John Doe"},
+			&types.RecordValue{Name: "email", Value: "john@example.tld"},
+		)
+		source = h.repoMakeRecord(m,
+			&types.RecordValue{Name: "name", Value: "J. Doe"},
+			&types.RecordValue{Name: "email", Value: "john+crm@example.tld"},
+			&types.RecordValue{Name: "phone", Value: "040 123 456"},
+		)
+
+		refModule = h.repoMakeModule(
+			&types.Namespace{ID: m.NamespaceID},
+			"referencing module",
+			&types.ModuleField{Name: "contact", Kind: "Record", Options: types.ModuleFieldOptions{"moduleID": fmt.Sprintf("%d", m.ID)}},
+		)
+
+		ref = h.repoMakeRecord(refModule, &types.RecordValue{Name: "contact", Value: fmt.Sprintf("%d", source.ID), Ref: source.ID})
+	)
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/%d/merge", m.NamespaceID, m.ID, target.ID)).
+		JSON(fmt.Sprintf(`{"sourceRecordID":"%d","fields":["email"]}`, source.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.recordID`, fmt.Sprintf("%d", target.ID))).
+		Assert(jsonpath.Equal(`$.response.values[0].value`, "John Doe")).
+		Assert(jsonpath.Equal(`$.response.values[1].value`, "john+crm@example.tld")).
+		Assert(jsonpath.Equal(`$.response.values[2].value`, "040 123 456")).
+		End()
+
+	_, err := h.repoRecord().FindByID(m.NamespaceID, source.ID)
+	h.a.Error(err, "merged record should be deleted")
+
+	vv, err := h.repoRecord().LoadValues([]string{"contact"}, []uint64{ref.ID})
+	h.a.NoError(err)
+	h.a.Len(vv, 1)
+	h.a.Equal(target.ID, vv[0].Ref)
+}
+
+func TestRecordDuplicates_unreadableFields(t *testing.T) {
+	h := newHelper(t)
+
+	var (
+		m = h.repoMakeDuplicatesModule()
+		r = h.repoMakeRecord(m,
+			&types.RecordValue{Name: "name", Value: "John Doe"},
+			&types.RecordValue{Name: "email", Value: "john@example.tld"},
+		)
+		d = h.repoMakeRecord(m,
+			&types.RecordValue{Name: "name", Value: "John Doe"},
+			&types.RecordValue{Name: "email", Value: "john@example.tld"},
+		)
+	)
+
+	h.deny(m.Fields.FindByName("email").PermissionResource(), "record.value.read")
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/%d/duplicates", m.NamespaceID, m.ID, r.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response`, 1)).
+		Assert(jsonpath.Equal(`$.response[0].recordID`, fmt.Sprintf("%d", d.ID))).
+		Assert(jsonpath.Len(`$.response[0].fields`, 1)).
+		Assert(jsonpath.Equal(`$.response[0].fields[0]`, "name")).
+		End()
+}
+
+func TestRecordMerge_refsDeduplicated(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.update")
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.delete")
+
+	var (
+		m      = h.repoMakeDuplicatesModule()
+		target = h.repoMakeRecord(m)
+		source = h.repoMakeRecord(m)
+
+		refModule = h.repoMakeModule(
+			&types.Namespace{ID: m.NamespaceID},
+			"referencing module",
+			&types.ModuleField{Name: "contacts", Kind: "Record", Multi: true, Options: types.ModuleFieldOptions{"moduleID": fmt.Sprintf("%d", m.ID)}},
+		)
+
+		ref = h.repoMakeRecord(refModule,
+			&types.RecordValue{Name: "contacts", Value: fmt.Sprintf("%d", source.ID), Ref: source.ID, Place: 0},
+			&types.RecordValue{Name: "contacts", Value: fmt.Sprintf("%d", target.ID), Ref: target.ID, Place: 1},
+		)
+	)
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/%d/merge", m.NamespaceID, m.ID, target.ID)).
+		FormData("sourceRecordID", fmt.Sprintf("%d", source.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	vv, err := h.repoRecord().LoadValues([]string{"contacts"}, []uint64{ref.ID})
+	h.a.NoError(err)
+	h.a.Len(vv, 1)
+	h.a.Equal(target.ID, vv[0].Ref)
+}
+
+func TestRecordMerge_refsForbidden(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.update")
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.delete")
+
+	var (
+		m      = h.repoMakeDuplicatesModule()
+		target = h.repoMakeRecord(m)
+		source = h.repoMakeRecord(m)
+
+		refModule = h.repoMakeModule(
+			&types.Namespace{ID: m.NamespaceID},
+			"referencing module",
+			&types.ModuleField{Name: "contact", Kind: "Record", Options: types.ModuleFieldOptions{"moduleID": fmt.Sprintf("%d", m.ID)}},
+		)
+
+		ref = h.repoMakeRecord(refModule, &types.RecordValue{Name: "contact", Value: fmt.Sprintf("%d", source.ID), Ref: source.ID})
+	)
+
+	h.deny(refModule.PermissionResource(), "record.update")
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/%d/merge", m.NamespaceID, m.ID, target.ID)).
+		FormData("sourceRecordID", fmt.Sprintf("%d", source.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("compose.service.NoUpdatePermissions")).
+		End()
+
+	vv, err := h.repoRecord().LoadValues([]string{"contact"}, []uint64{ref.ID})
+	h.a.NoError(err)
+	h.a.Len(vv, 1)
+	h.a.Equal(source.ID, vv[0].Ref)
+}
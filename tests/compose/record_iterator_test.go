@@ -0,0 +1,57 @@
+package compose
+
+import (
+	"context"
+	"testing"
+
+	"github.com/titpetric/factory"
+
+	"github.com/cortezaproject/corteza-server/compose/service"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+)
+
+func TestRecordIteratorChunk_clone(t *testing.T) {
+	var (
+		h = newHelper(t)
+		m = h.repoMakeRecordModuleWithFields("record iterator clone")
+
+		iterated []uint64
+		afterID  uint64
+	)
+
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.update")
+
+	for i := 0; i < 5; i++ {
+		h.repoMakeRecord(m, &types.RecordValue{Name: "name", Value: "original"})
+	}
+
+	var (
+		beforeID = factory.Sonyflake.NextID()
+		filter   = types.RecordFilter{NamespaceID: m.NamespaceID, ModuleID: m.ID}
+
+		fn = func(_ context.Context, ev eventbus.Event) error {
+			iterated = append(iterated, ev.(interface{ Record() *types.Record }).Record().ID)
+			return nil
+		}
+	)
+
+	for chunks := 0; chunks < 10; chunks++ {
+		lastID, n, _, err := service.DefaultRecord.With(h.secCtx()).IteratorChunk(filter, fn, "clone", afterID, beforeID, 2)
+		h.a.NoError(err)
+
+		if n == 0 {
+			break
+		}
+
+		// position must not jump to the ID of the clone
+		h.a.Less(lastID, beforeID)
+		afterID = lastID
+	}
+
+	h.a.Len(iterated, 5)
+
+	set, _, err := h.repoRecord().Find(m, types.RecordFilter{})
+	h.a.NoError(err)
+	h.a.Len(set, 10)
+}
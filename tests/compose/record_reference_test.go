@@ -0,0 +1,183 @@
+package compose
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+)
+
+// repoMakeRefModules makes module and another module that references it
+// with record-reference field for each of the on-delete policies
+func (h helper) repoMakeRefModules() (m, refModule *types.Module) {
+	m = h.repoMakeRecordModuleWithFields("referenced module", &types.ModuleField{Name: "name"})
+
+	refField := func(name string, p types.RecordRefOnDelete) *types.ModuleField {
+		return &types.ModuleField{
+			Name: name,
+			Kind: "Record",
+			Options: types.ModuleFieldOptions{
+				"moduleID": fmt.Sprintf("%d", m.ID),
+				"onDelete": string(p),
+			},
+		}
+	}
+
+	refModule = h.repoMakeModule(
+		&types.Namespace{ID: m.NamespaceID},
+		"referencing module",
+		refField("restricted", types.RecordRefOnDeleteRestrict),
+		refField("cleared", types.RecordRefOnDeleteSetNull),
+		refField("cascaded", types.RecordRefOnDeleteCascade),
+	)
+
+	return
+}
+
+func (h helper) repoMakeRefValue(name string, r *types.Record) *types.RecordValue {
+	return &types.RecordValue{Name: name, Value: fmt.Sprintf("%d", r.ID), Ref: r.ID}
+}
+
+func TestRecordDelete_restrictedReference(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.delete")
+
+	var (
+		m, refModule = h.repoMakeRefModules()
+		r            = h.repoMakeRecord(m)
+		_            = h.repoMakeRecord(refModule, h.repoMakeRefValue("restricted", r))
+	)
+
+	h.apiInit().
+		Delete(fmt.Sprintf("/namespace/%d/module/%d/record/%d", m.NamespaceID, m.ID, r.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("failed to delete record: compose.service.RecordReferenced")).
+		End()
+
+	_, err := h.repoRecord().FindByID(m.NamespaceID, r.ID)
+	h.a.NoError(err, "restricted record should not be deleted")
+}
+
+func TestRecordDelete_clearedReference(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.delete")
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.update")
+
+	var (
+		m, refModule = h.repoMakeRefModules()
+		r            = h.repoMakeRecord(m)
+		ref          = h.repoMakeRecord(refModule, h.repoMakeRefValue("cleared", r))
+	)
+
+	h.apiInit().
+		Delete(fmt.Sprintf("/namespace/%d/module/%d/record/%d", m.NamespaceID, m.ID, r.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	_, err := h.repoRecord().FindByID(m.NamespaceID, ref.ID)
+	h.a.NoError(err)
+
+	vv, err := h.repoRecord().LoadValues([]string{"cleared"}, []uint64{ref.ID})
+	h.a.NoError(err)
+	h.a.Len(vv, 0)
+}
+
+func TestRecordDelete_clearedReferenceForbidden(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.delete")
+
+	var (
+		m, refModule = h.repoMakeRefModules()
+		r            = h.repoMakeRecord(m)
+		ref          = h.repoMakeRecord(refModule, h.repoMakeRefValue("cleared", r))
+	)
+
+	h.deny(types.ModulePermissionResource.AppendID(refModule.ID), "record.update")
+
+	h.apiInit().
+		Delete(fmt.Sprintf("/namespace/%d/module/%d/record/%d", m.NamespaceID, m.ID, r.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("failed to delete record: compose.service.NoUpdatePermissions")).
+		End()
+
+	_, err := h.repoRecord().FindByID(m.NamespaceID, r.ID)
+	h.a.NoError(err, "referenced record should not be deleted")
+
+	vv, err := h.repoRecord().LoadValues([]string{"cleared"}, []uint64{ref.ID})
+	h.a.NoError(err)
+	h.a.Len(vv, 1)
+}
+
+func TestRecordDelete_cascadedReference(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.delete")
+
+	var (
+		m, refModule = h.repoMakeRefModules()
+		r            = h.repoMakeRecord(m)
+		ref          = h.repoMakeRecord(refModule, h.repoMakeRefValue("cascaded", r))
+	)
+
+	h.apiInit().
+		Delete(fmt.Sprintf("/namespace/%d/module/%d/record/%d", m.NamespaceID, m.ID, r.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	_, err := h.repoRecord().FindByID(m.NamespaceID, ref.ID)
+	h.a.Error(err, "referencing record should be deleted")
+}
+
+func TestRecordDeleteImpact(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.read")
+
+	var (
+		m, refModule = h.repoMakeRefModules()
+		r            = h.repoMakeRecord(m)
+		ref1         = h.repoMakeRecord(refModule, h.repoMakeRefValue("cleared", r))
+		ref2         = h.repoMakeRecord(refModule, h.repoMakeRefValue("cascaded", r))
+	)
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/%d/impact", m.NamespaceID, m.ID, r.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response`, 2)).
+		Assert(jsonpath.Equal(`$.response[0].recordID`, fmt.Sprintf("%d", ref1.ID))).
+		Assert(jsonpath.Equal(`$.response[0].onDelete`, "setNull")).
+		Assert(jsonpath.Equal(`$.response[1].recordID`, fmt.Sprintf("%d", ref2.ID))).
+		Assert(jsonpath.Equal(`$.response[1].onDelete`, "cascade")).
+		End()
+}
+
+func TestRecordDeleteImpact_unreadable(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.read")
+
+	var (
+		m, refModule = h.repoMakeRefModules()
+		r            = h.repoMakeRecord(m)
+		_            = h.repoMakeRecord(refModule, h.repoMakeRefValue("cleared", r))
+	)
+
+	h.deny(types.ModulePermissionResource.AppendID(refModule.ID), "record.read")
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/%d/impact", m.NamespaceID, m.ID, r.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.NotPresent(`$.response[0]`)).
+		End()
+}
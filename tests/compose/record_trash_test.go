@@ -0,0 +1,132 @@
+package compose
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+
+	"github.com/cortezaproject/corteza-server/compose/service"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+)
+
+func (h helper) repoDeleteRecord(r *types.Record, deletedAt time.Time) {
+	r.DeletedAt = &deletedAt
+	h.a.NoError(h.repoRecord().Delete(r))
+	h.a.NoError(h.repoRecord().DeleteValues(r))
+}
+
+func TestRecordUndeleteForbidden(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record testing module")
+	record := h.repoMakeRecord(module)
+	h.repoDeleteRecord(record, time.Now())
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/%d/undelete", module.NamespaceID, module.ID, record.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("compose.service.NoDeletePermissions")).
+		End()
+}
+
+func TestRecordUndelete(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.delete")
+
+	module := h.repoMakeRecordModuleWithFields("record testing module")
+	record := h.repoMakeRecord(module, &types.RecordValue{Name: "name", Value: "restored"})
+	h.repoDeleteRecord(record, time.Now())
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/%d/undelete", module.NamespaceID, module.ID, record.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.recordID`, fmt.Sprintf("%d", record.ID))).
+		Assert(jsonpath.Equal(`$.response.values[0].value`, "restored")).
+		End()
+
+	_, err := h.repoRecord().FindByID(module.NamespaceID, record.ID)
+	h.a.NoError(err)
+}
+
+func TestRecordUndelete_references(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.delete")
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.update")
+
+	var (
+		m, refModule = h.repoMakeRefModules()
+		r            = h.repoMakeRecord(m)
+		cleared      = h.repoMakeRecord(refModule, h.repoMakeRefValue("cleared", r))
+		cascaded     = h.repoMakeRecord(refModule, h.repoMakeRefValue("cascaded", r))
+	)
+
+	h.apiInit().
+		Delete(fmt.Sprintf("/namespace/%d/module/%d/record/%d", m.NamespaceID, m.ID, r.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	h.apiInit().
+		Post(fmt.Sprintf("/namespace/%d/module/%d/record/%d/undelete", m.NamespaceID, m.ID, r.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	_, err := h.repoRecord().FindByID(m.NamespaceID, cascaded.ID)
+	h.a.NoError(err, "cascaded record should be restored")
+
+	vv, err := h.repoRecord().LoadValues([]string{"cleared"}, []uint64{cleared.ID})
+	h.a.NoError(err)
+	h.a.Len(vv, 1, "cleared reference should be restored")
+}
+
+func TestNamespaceTrash(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.delete")
+
+	module := h.repoMakeRecordModuleWithFields("record testing module")
+	_ = h.repoMakeRecord(module)
+	deleted := h.repoMakeRecord(module)
+	h.repoDeleteRecord(deleted, time.Now())
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/trash", module.NamespaceID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 1)).
+		Assert(jsonpath.Equal(`$.response.set[0].recordID`, fmt.Sprintf("%d", deleted.ID))).
+		End()
+}
+
+func TestRecordPurge(t *testing.T) {
+	h := newHelper(t)
+
+	module := h.repoMakeRecordModuleWithFields("record testing module")
+	old := h.repoMakeRecord(module)
+	recent := h.repoMakeRecord(module)
+
+	h.repoDeleteRecord(old, time.Now().Add(-48*time.Hour))
+	h.repoDeleteRecord(recent, time.Now())
+
+	count, err := service.DefaultRecord.With(auth.SetSuperUserContext(context.Background())).Purge(time.Now().Add(-24 * time.Hour))
+	h.a.NoError(err)
+	h.a.True(count > 0)
+
+	_, err = h.repoRecord().FindDeletedByID(module.NamespaceID, old.ID)
+	h.a.Error(err, "old deleted record should be purged")
+
+	_, err = h.repoRecord().FindDeletedByID(module.NamespaceID, recent.ID)
+	h.a.NoError(err, "recently deleted record should be kept")
+}
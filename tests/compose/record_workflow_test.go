@@ -0,0 +1,149 @@
+package compose
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+
+	"github.com/cortezaproject/corteza-server/compose/service"
+	"github.com/cortezaproject/corteza-server/compose/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+)
+
+func (h helper) repoMakeWorkflowModule(roleID uint64) *types.Module {
+	return h.repoMakeRecordModuleWithFields(
+		"workflow module",
+		&types.ModuleField{Name: "title"},
+		&types.ModuleField{Name: "note"},
+		&types.ModuleField{
+			Name: "status",
+			Kind: "Select",
+			Options: types.ModuleFieldOptions{
+				"workflow": map[string]interface{}{
+					"states":  []string{"draft", "submitted", "approved"},
+					"initial": "draft",
+					"transitions": []map[string]interface{}{
+						{"name": "submit", "from": []string{"draft"}, "to": "submitted", "required": []string{"note"}},
+						{"name": "approve", "from": []string{"submitted"}, "to": "approved", "roles": []string{fmt.Sprintf("%d", roleID)}, "guard": "title = 'ok'"},
+					},
+				},
+			},
+		},
+	)
+}
+
+func (h helper) updateRecordValues(r *types.Record, vv ...*types.RecordValue) (*types.Record, *types.RecordValueErrorSet, error) {
+	rec, err := service.DefaultRecord.With(h.secCtx()).Update(&types.Record{
+		ID:          r.ID,
+		ModuleID:    r.ModuleID,
+		NamespaceID: r.NamespaceID,
+		Values:      vv,
+	})
+
+	if rve, ok := err.(*types.RecordValueErrorSet); ok {
+		return nil, rve, nil
+	}
+
+	return rec, nil, err
+}
+
+func TestRecordWorkflow_initialState(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.create")
+
+	m := h.repoMakeWorkflowModule(h.roleID)
+
+	rec, err := service.DefaultRecord.With(h.secCtx()).Create(&types.Record{ModuleID: m.ID, NamespaceID: m.NamespaceID})
+	h.a.NoError(err)
+	h.a.Equal("draft", rec.Values.FilterByName("status")[0].Value)
+
+	_, err = service.DefaultRecord.With(h.secCtx()).Create(&types.Record{
+		ModuleID:    m.ID,
+		NamespaceID: m.NamespaceID,
+		Values:      types.RecordValueSet{{Name: "status", Value: "approved"}},
+	})
+
+	h.a.IsType(&types.RecordValueErrorSet{}, err)
+	h.a.Equal("invalidState", err.(*types.RecordValueErrorSet).Set[0].Kind)
+}
+
+func TestRecordWorkflow_transitions(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.update")
+
+	var (
+		m = h.repoMakeWorkflowModule(h.roleID)
+		r = h.repoMakeRecord(m, &types.RecordValue{Name: "status", Value: "draft"})
+	)
+
+	// Skipping states is not allowed
+	_, rve, err := h.updateRecordValues(r, &types.RecordValue{Name: "status", Value: "approved"})
+	h.a.NoError(err)
+	h.a.NotNil(rve)
+	h.a.Equal("transitionNotAllowed", rve.Set[0].Kind)
+
+	// Required fields
+	_, rve, err = h.updateRecordValues(r, &types.RecordValue{Name: "status", Value: "submitted"})
+	h.a.NoError(err)
+	h.a.NotNil(rve)
+	h.a.Equal("empty", rve.Set[0].Kind)
+	h.a.Equal("note", rve.Set[0].Meta["field"])
+
+	_, rve, err = h.updateRecordValues(r,
+		&types.RecordValue{Name: "status", Value: "submitted"},
+		&types.RecordValue{Name: "note", Value: "please approve"},
+	)
+	h.a.NoError(err)
+	h.a.Nil(rve)
+
+	// Guard (title must be 'ok')
+	_, rve, err = h.updateRecordValues(r,
+		&types.RecordValue{Name: "status", Value: "approved"},
+		&types.RecordValue{Name: "note", Value: "please approve"},
+	)
+	h.a.NoError(err)
+	h.a.NotNil(rve)
+	h.a.Equal("transitionGuard", rve.Set[0].Kind)
+
+	_, rve, err = h.updateRecordValues(r,
+		&types.RecordValue{Name: "status", Value: "approved"},
+		&types.RecordValue{Name: "note", Value: "please approve"},
+		&types.RecordValue{Name: "title", Value: "ok"},
+	)
+	h.a.NoError(err)
+	h.a.Nil(rve)
+
+	h.apiInit().
+		Get(fmt.Sprintf("/namespace/%d/module/%d/record/%d/transitions", m.NamespaceID, m.ID, r.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response`, 2)).
+		Assert(jsonpath.Equal(`$.response[0].transition`, "submit")).
+		Assert(jsonpath.Equal(`$.response[1].from`, "submitted")).
+		Assert(jsonpath.Equal(`$.response[1].to`, "approved")).
+		End()
+}
+
+func TestRecordWorkflow_transitionForbidden(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.ModulePermissionResource.AppendWildcard(), "record.update")
+
+	var (
+		m = h.repoMakeWorkflowModule(h.roleID + 1)
+		r = h.repoMakeRecord(m,
+			&types.RecordValue{Name: "status", Value: "submitted"},
+			&types.RecordValue{Name: "title", Value: "ok"},
+		)
+	)
+
+	_, rve, err := h.updateRecordValues(r,
+		&types.RecordValue{Name: "status", Value: "approved"},
+		&types.RecordValue{Name: "title", Value: "ok"},
+	)
+	h.a.NoError(err)
+	h.a.NotNil(rve)
+	h.a.Equal("transitionForbidden", rve.Set[0].Kind)
+}
@@ -40,6 +40,9 @@ type (
 	TestApp struct {
 		helpers.TestApp
 	}
+
+	// Issues JWTs without connecting to system service
+	testSystemUser struct{}
 )
 
 var (
@@ -58,9 +61,14 @@ func db() *factory.DB {
 func (app *TestApp) Initialize(ctx context.Context) (err error) {
 	service.DefaultPermissions = permissions.NewTestService(ctx, app.Log, db(), "messaging_permission_rules")
 	service.DefaultStore, err = plain.NewWithAfero(afero.NewMemMapFs(), "test")
+	service.DefaultSystemUser = testSystemUser{}
 	return
 }
 
+func (testSystemUser) MakeJWT(_ context.Context, ID uint64) (string, error) {
+	return auth.DefaultJwtHandler.Encode(auth.NewIdentity(ID)), nil
+}
+
 func (app *TestApp) Activate(ctx context.Context) (err error) {
 	service.DefaultPermissions.(*permissions.TestService).Reload(ctx)
 	return
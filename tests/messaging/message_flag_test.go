@@ -3,11 +3,14 @@ package messaging
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"testing"
 
 	"github.com/steinfletcher/apitest"
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
 
 	"github.com/cortezaproject/corteza-server/messaging/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
 )
 
 func (h helper) apiMessageSetFlag(msg *types.Message, method, flag string) *apitest.Response {
@@ -66,3 +69,56 @@ func TestMessageFlag(t *testing.T) {
 
 	h.a.False(hasReaction("foo"), "expecting message not to have reaction")
 }
+
+func TestMessageFlagInvalidReaction(t *testing.T) {
+	h := newHelper(t)
+	msg := h.repoMakeMessage("flag target", h.repoMakePublicCh(), h.cUser)
+
+	h.apiInit().
+		Post(fmt.Sprintf("/channels/%d/messages/%d/reaction/%s", msg.ChannelID, msg.ID, "pin")).
+		Expect(h.t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("messaging.service.InvalidReaction")).
+		End()
+
+	h.a.False(h.repoMsgFlagLoad(msg.ID).IsPinned())
+}
+
+func TestMessageFlagPinnedList(t *testing.T) {
+	h := newHelper(t)
+	ch := h.repoMakePublicCh()
+	pinned := h.repoMakeMessage("pinned", ch, h.cUser)
+	h.repoMakeMessage("not pinned", ch, h.cUser)
+
+	h.apiMessageSetFlag(pinned, "POST", "pin").
+		End()
+
+	h.apiInit().
+		Get(fmt.Sprintf("/channels/%d/messages/pinned", ch.ID)).
+		Expect(h.t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response`, 1)).
+		Assert(jsonpath.Equal(`$.response[0].message.message`, "pinned")).
+		Assert(jsonpath.Equal(`$.response[0].pinnedBy`, strconv.FormatUint(h.cUser.ID, 10))).
+		End()
+}
+
+func TestMessageFlagSavedItems(t *testing.T) {
+	h := newHelper(t)
+	saved := h.repoMakeMessage("saved", h.repoMakePublicCh(), h.cUser)
+	h.repoMakeMessage("not saved", h.repoMakePublicCh(), h.cUser)
+
+	h.apiMessageSetFlag(saved, "POST", "bookmark").
+		End()
+
+	h.apiInit().
+		Get("/saved-items/").
+		Expect(h.t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response`, 1)).
+		Assert(jsonpath.Equal(`$.response[0].channelID`, strconv.FormatUint(saved.ChannelID, 10))).
+		Assert(jsonpath.Equal(`$.response[0].message.message`, "saved")).
+		End()
+}
@@ -0,0 +1,118 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+
+	"github.com/cortezaproject/corteza-server/messaging/repository"
+	"github.com/cortezaproject/corteza-server/messaging/service"
+	"github.com/cortezaproject/corteza-server/messaging/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+)
+
+func (h helper) repoScheduledMessage() repository.ScheduledMessageRepository {
+	return repository.ScheduledMessage(context.Background(), db())
+}
+
+func (h helper) repoMakeScheduledMessage(msg string, ch *types.Channel, next time.Time) *types.ScheduledMessage {
+	sm, err := h.repoScheduledMessage().Create(&types.ScheduledMessage{
+		Message:     msg,
+		ChannelID:   ch.ID,
+		UserID:      h.cUser.ID,
+		ScheduledAt: &next,
+		NextRunAt:   &next,
+	})
+
+	h.a.NoError(err)
+	return sm
+}
+
+func TestScheduledMessageCreate(t *testing.T) {
+	h := newHelper(t)
+	ch := h.repoMakePublicCh()
+
+	rval := struct {
+		Response struct {
+			ID uint64 `json:"scheduledMessageID,string"`
+		}
+	}{}
+
+	h.apiInit().
+		Post("/scheduled-messages/").
+		JSON(fmt.Sprintf(`{"channelID":"%d","message":"scheduled message","cron":"0 9 * * 1"}`, ch.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Present(`$.response.scheduledMessageID`)).
+		Assert(jsonpath.Present(`$.response.nextRunAt`)).
+		End().
+		JSON(&rval)
+
+	sm, err := h.repoScheduledMessage().FindByID(rval.Response.ID)
+	h.a.NoError(err)
+	h.a.Equal(`scheduled message`, sm.Message)
+	h.a.True(sm.IsRecurring())
+}
+
+func TestScheduledMessageCreate_invalidCron(t *testing.T) {
+	h := newHelper(t)
+	ch := h.repoMakePublicCh()
+
+	h.apiInit().
+		Post("/scheduled-messages/").
+		JSON(fmt.Sprintf(`{"channelID":"%d","message":"scheduled message","cron":"foo"}`, ch.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError(`invalid cron expression "foo": syntax error in minute field: 'foo'`)).
+		End()
+}
+
+func TestScheduledMessageCreate_forbidden(t *testing.T) {
+	h := newHelper(t)
+	ch := h.repoMakePublicCh()
+	h.deny(types.ChannelPermissionResource.AppendWildcard(), "message.send")
+
+	h.apiInit().
+		Post("/scheduled-messages/").
+		JSON(fmt.Sprintf(`{"channelID":"%d","message":"scheduled message","cron":"0 9 * * 1"}`, ch.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("messaging.service.NoPermissions")).
+		End()
+}
+
+func TestScheduledMessageCancel(t *testing.T) {
+	h := newHelper(t)
+	sm := h.repoMakeScheduledMessage("scheduled message", h.repoMakePublicCh(), time.Now().Add(time.Hour))
+
+	h.apiInit().
+		Delete(fmt.Sprintf("/scheduled-messages/%d", sm.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	_, err := h.repoScheduledMessage().FindByID(sm.ID)
+	h.a.EqualError(err, "messaging.repository.ScheduledMessageNotFound")
+}
+
+func TestScheduledMessageDeliver(t *testing.T) {
+	h := newHelper(t)
+	sm := h.repoMakeScheduledMessage("scheduled message", h.repoMakePublicCh(), time.Now().Add(-time.Minute))
+
+	h.a.NoError(service.DefaultScheduledMessage.With(h.secCtx()).Deliver())
+
+	sm, err := h.repoScheduledMessage().FindByID(sm.ID)
+	h.a.NoError(err)
+	h.a.Nil(sm.NextRunAt, "expecting one-off message not to be scheduled again")
+	h.a.NotNil(sm.LastRunAt)
+	h.a.Empty(sm.LastError)
+
+	m := h.repoMsgExistingLoad(sm.LastMessageID)
+	h.a.Equal(`scheduled message`, m.Message)
+}
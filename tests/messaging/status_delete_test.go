@@ -1,9 +1,29 @@
 package messaging
 
 import (
+	"net/http"
 	"testing"
+
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+
+	"github.com/cortezaproject/corteza-server/messaging/service"
+	"github.com/cortezaproject/corteza-server/messaging/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
 )
 
 func TestStatusDelete(t *testing.T) {
-	t.Skip("to be implemented")
+	h := newHelper(t)
+	defer h.connect()()
+
+	_, err := service.DefaultPresence.With(h.secCtx()).SetStatus(types.PresenceAway, "", "out for lunch", nil)
+	h.a.NoError(err)
+
+	h.apiInit().
+		Delete("/status/").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.status`, "online")).
+		Assert(jsonpath.NotPresent(`$.response.message`)).
+		End()
 }
@@ -1,9 +1,49 @@
 package messaging
 
 import (
+	"fmt"
+	"net/http"
 	"testing"
+
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+
+	"github.com/cortezaproject/corteza-server/messaging/service"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
 )
 
+// connect registers a (fake) websocket session of the current user
+func (h helper) connect() func() {
+	service.DefaultPresence.With(h.secCtx()).Connected(h.cUser.ID)
+	return func() {
+		service.DefaultPresence.With(h.secCtx()).Disconnected(h.cUser.ID)
+	}
+}
+
 func TestStatusList(t *testing.T) {
-	t.Skip("to be implemented")
+	h := newHelper(t)
+	defer h.connect()()
+
+	h.apiInit().
+		Get("/status/").
+		Query("userID", fmt.Sprintf("%d", h.cUser.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response`, 1)).
+		Assert(jsonpath.Equal(`$.response[0].userID`, fmt.Sprintf("%d", h.cUser.ID))).
+		Assert(jsonpath.Equal(`$.response[0].status`, "online")).
+		End()
+}
+
+func TestStatusList_offline(t *testing.T) {
+	h := newHelper(t)
+
+	h.apiInit().
+		Get("/status/").
+		Query("userID", fmt.Sprintf("%d", h.cUser.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response[0].status`, "offline")).
+		End()
 }
@@ -1,9 +1,62 @@
 package messaging
 
 import (
+	"net/http"
 	"testing"
+
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+
+	"github.com/cortezaproject/corteza-server/messaging/service"
+	"github.com/cortezaproject/corteza-server/messaging/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
 )
 
 func TestStatusSet(t *testing.T) {
-	t.Skip("to be implemented")
+	h := newHelper(t)
+	defer h.connect()()
+
+	h.apiInit().
+		Post("/status/").
+		FormData("status", "dnd").
+		FormData("icon", ":headphones:").
+		FormData("message", "focusing").
+		FormData("expires", "1h").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.status`, "dnd")).
+		Assert(jsonpath.Equal(`$.response.message`, "focusing")).
+		Assert(jsonpath.Present(`$.response.expiresAt`)).
+		End()
+
+	pp, err := service.DefaultPresence.With(h.secCtx()).Find(h.cUser.ID)
+	h.a.NoError(err)
+	h.a.Len(pp, 1)
+	h.a.Equal(types.PresenceDoNotDisturb, pp[0].Status)
+	h.a.Equal(":headphones:", pp[0].Icon)
+}
+
+func TestStatusSet_invalidStatus(t *testing.T) {
+	h := newHelper(t)
+
+	h.apiInit().
+		Post("/status/").
+		FormData("status", "offline").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError(`invalid status "offline"`)).
+		End()
+}
+
+func TestStatusSet_invalidExpiration(t *testing.T) {
+	h := newHelper(t)
+
+	h.apiInit().
+		Post("/status/").
+		FormData("message", "lunch").
+		FormData("expires", "whenever").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError(`could not parse status expiration "whenever"`)).
+		End()
 }
@@ -0,0 +1,117 @@
+package system
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/corredor"
+	"github.com/cortezaproject/corteza-server/pkg/eventbus"
+	"github.com/cortezaproject/corteza-server/system/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+)
+
+func TestAutomationScheduler_forbidden(t *testing.T) {
+	h := newHelper(t)
+
+	h.apiInit().
+		Get("/automation/scheduler").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("system.service.NoPermissions")).
+		End()
+}
+
+func TestAutomationScheduler(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.SystemPermissionResource, "access")
+
+	h.apiInit().
+		Get("/automation/scheduler").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Present(`$.response.node`)).
+		Assert(jsonpath.Present(`$.response.events[? @.resourceType=="system" && @.eventType=="onInterval"]`)).
+		End()
+}
+
+func TestAutomationRuns_forbidden(t *testing.T) {
+	h := newHelper(t)
+
+	h.apiInit().
+		Get("/automation/runs/").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("system.service.NoPermissions")).
+		End()
+}
+
+func TestAutomationRuns(t *testing.T) {
+	var (
+		h      = newHelper(t)
+		script = "/" + rs() + ".js"
+	)
+
+	h.allow(types.SystemPermissionResource, "access")
+
+	h.a.NoError(corredor.NewRunStore(db()).Create(context.Background(), &corredor.Run{
+		Script:       script,
+		ResourceType: "system:user",
+		EventType:    "beforeUpdate",
+		ResourceID:   42,
+		Status:       corredor.RunAborted,
+		CreatedAt:    time.Now(),
+	}))
+
+	h.apiInit().
+		Get("/automation/runs/").
+		Query("script", script).
+		Query("status", "aborted").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 1)).
+		Assert(jsonpath.Equal(`$.response.set[0].resourceID`, "42")).
+		Assert(jsonpath.Equal(`$.response.set[0].eventType`, "beforeUpdate")).
+		End()
+}
+
+func TestAutomationHandlers_forbidden(t *testing.T) {
+	h := newHelper(t)
+
+	h.apiInit().
+		Get("/automation/handlers").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("system.service.NoPermissions")).
+		End()
+}
+
+func TestAutomationHandlers(t *testing.T) {
+	var (
+		h    = newHelper(t)
+		name = rs()
+	)
+
+	h.allow(types.SystemPermissionResource, "access")
+
+	ptr := eventbus.Service().Register(
+		func(context.Context, eventbus.Event) error { return nil },
+		eventbus.Name(name),
+		eventbus.For("system:user"),
+		eventbus.On("beforeUpdate"),
+		eventbus.Constraint(eventbus.MustMakeConstraint("user.handle", "=", "foo")),
+	)
+	defer eventbus.Service().Unregister(ptr)
+
+	h.apiInit().
+		Get("/automation/handlers").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Present(`$.response[? @.name=="` + name + `"]`)).
+		End()
+}
@@ -0,0 +1,143 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+
+	"github.com/cortezaproject/corteza-server/pkg/jobs"
+	"github.com/cortezaproject/corteza-server/system/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+)
+
+func (h helper) repoMakeJob(ownedBy uint64, status jobs.Status) *jobs.Job {
+	var (
+		n   = time.Now()
+		job = &jobs.Job{
+			Service:     "test",
+			Kind:        rs(),
+			Status:      status,
+			Params:      []byte("{}"),
+			MaxAttempts: 1,
+			OwnedBy:     ownedBy,
+			RunAfter:    n,
+			CreatedAt:   n,
+		}
+	)
+
+	h.a.NoError(jobs.NewStore(db()).Create(context.Background(), job, nil))
+	return job
+}
+
+func TestJobsStore_requeuedJobUpdate(t *testing.T) {
+	var (
+		h     = newHelper(t)
+		ctx   = context.Background()
+		store = jobs.NewStore(db())
+		job   = h.repoMakeJob(h.cUser.ID, jobs.StatusQueued)
+	)
+
+	stale, err := store.Claim(ctx, job.Service, []string{job.Kind}, "stale-node", time.Now())
+	h.a.NoError(err)
+	h.a.NotNil(stale)
+
+	h.a.NoError(store.Requeue(ctx, time.Now().Add(time.Minute)))
+	requeued, err := store.FindByID(ctx, job.ID)
+	h.a.NoError(err)
+	h.a.Equal(jobs.StatusQueued, requeued.Status)
+	h.a.Empty(requeued.Node)
+
+	claimed, err := store.Claim(ctx, job.Service, []string{job.Kind}, "new-node", time.Now())
+	h.a.NoError(err)
+	h.a.NotNil(claimed)
+
+	// stale node can not overwrite job claimed by the new node
+	stale.Status = jobs.StatusSucceeded
+	h.a.NoError(store.Update(ctx, stale))
+
+	current, err := store.FindByID(ctx, job.ID)
+	h.a.NoError(err)
+	h.a.Equal(jobs.StatusRunning, current.Status)
+	h.a.Equal("new-node", current.Node)
+}
+
+func TestJobsList(t *testing.T) {
+	h := newHelper(t)
+
+	own := h.repoMakeJob(h.cUser.ID, jobs.StatusQueued)
+	h.repoMakeJob(h.cUser.ID+1, jobs.StatusQueued)
+
+	h.apiInit().
+		Get("/jobs/").
+		Query("kind", own.Kind).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 1)).
+		Assert(jsonpath.Equal(`$.response.set[0].jobID`, fmt.Sprintf("%d", own.ID))).
+		End()
+}
+
+func TestJobsRead_forbidden(t *testing.T) {
+	h := newHelper(t)
+	job := h.repoMakeJob(h.cUser.ID+1, jobs.StatusQueued)
+
+	h.apiInit().
+		Get(fmt.Sprintf("/jobs/%d", job.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("system.service.NoPermissions")).
+		End()
+}
+
+func TestJobsRead(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.SystemPermissionResource, "access")
+	job := h.repoMakeJob(h.cUser.ID+1, jobs.StatusQueued)
+
+	h.apiInit().
+		Get(fmt.Sprintf("/jobs/%d", job.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.kind`, job.Kind)).
+		End()
+}
+
+func TestJobsCancel(t *testing.T) {
+	h := newHelper(t)
+	job := h.repoMakeJob(h.cUser.ID, jobs.StatusQueued)
+
+	h.apiInit().
+		Post(fmt.Sprintf("/jobs/%d/cancel", job.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.status`, "cancelled")).
+		End()
+
+	h.apiInit().
+		Post(fmt.Sprintf("/jobs/%d/retry", job.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.status`, "queued")).
+		End()
+}
+
+func TestJobsResult_forbidden(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.SystemPermissionResource, "access")
+	job := h.repoMakeJob(h.cUser.ID+1, jobs.StatusSucceeded)
+
+	h.apiInit().
+		Get(fmt.Sprintf("/jobs/%d/result", job.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("system.service.NoPermissions")).
+		End()
+}
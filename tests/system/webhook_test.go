@@ -0,0 +1,288 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cortezaproject/corteza-server/pkg/auth"
+	"github.com/cortezaproject/corteza-server/pkg/outbox"
+	"github.com/cortezaproject/corteza-server/system/repository"
+	"github.com/cortezaproject/corteza-server/system/service"
+	"github.com/cortezaproject/corteza-server/system/types"
+	"github.com/cortezaproject/corteza-server/tests/helpers"
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+)
+
+func (h helper) repoWebhook() repository.WebhookRepository {
+	return repository.Webhook(context.Background(), db())
+}
+
+func (h helper) repoMakeWebhook(name string) *types.Webhook {
+	w, err := h.
+		repoWebhook().
+		Create(&types.Webhook{
+			Name:         name,
+			URL:          "http://localhost/" + name,
+			Secret:       "secret",
+			ResourceType: "system:user",
+			Enabled:      true,
+		})
+
+	h.a.NoError(err)
+
+	return w
+}
+
+func (h helper) repoMakeWebhookDelivery(w *types.Webhook, status types.WebhookDeliveryStatus) *types.WebhookDelivery {
+	d, err := h.
+		repoWebhook().
+		CreateDelivery(&types.WebhookDelivery{
+			WebhookID: w.ID,
+			EventType: "afterCreate",
+			Payload:   []byte(`{}`),
+			Status:    status,
+			Attempts:  10,
+		})
+
+	h.a.NoError(err)
+
+	return d
+}
+
+func TestWebhookList_forbidden(t *testing.T) {
+	h := newHelper(t)
+
+	h.apiInit().
+		Get("/webhook/").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("system.service.NoPermissions")).
+		End()
+}
+
+func TestWebhookList(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.SystemPermissionResource, "webhook.manage")
+
+	h.repoMakeWebhook("test_webhook_list")
+
+	h.apiInit().
+		Get("/webhook/").
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Present(`$.response.set[? @.name=="test_webhook_list"]`)).
+		Assert(jsonpath.NotPresent(`$.response.set[? @.secret=="secret"]`)).
+		End()
+}
+
+func TestWebhookCreate(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.SystemPermissionResource, "webhook.manage")
+
+	h.apiInit().
+		Post("/webhook/").
+		JSON(`{"name":"test_webhook_create","url":"https://example.tld/hook","resourceType":"compose:record","eventTypes":["afterCreate"],"constraints":[{"name":"module.handle","values":["lead"]}],"enabled":true}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.name`, "test_webhook_create")).
+		Assert(jsonpath.Present(`$.response.secret`)).
+		Assert(jsonpath.Len(`$.response.constraints`, 1)).
+		End()
+}
+
+func TestWebhookCreate_invalid(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.SystemPermissionResource, "webhook.manage")
+
+	h.apiInit().
+		Post("/webhook/").
+		JSON(`{"name":"test_webhook_invalid","url":"not-an-url","resourceType":"compose:record"}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertError("system.service.WebhookInvalid")).
+		End()
+}
+
+func TestWebhookUpdate(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.SystemPermissionResource, "webhook.manage")
+
+	w := h.repoMakeWebhook("test_webhook_update")
+
+	h.apiInit().
+		Put(fmt.Sprintf("/webhook/%d", w.ID)).
+		JSON(`{"name":"test_webhook_updated","url":"https://example.tld/hook","resourceType":"system:user","enabled":false}`).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.name`, "test_webhook_updated")).
+		Assert(jsonpath.Equal(`$.response.enabled`, false)).
+		End()
+}
+
+func TestWebhookDelete(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.SystemPermissionResource, "webhook.manage")
+
+	w := h.repoMakeWebhook("test_webhook_delete")
+
+	h.apiInit().
+		Delete(fmt.Sprintf("/webhook/%d", w.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		End()
+
+	_, err := h.repoWebhook().FindByID(w.ID)
+	h.a.Error(err)
+}
+
+func TestWebhookDeliveriesAndRetry(t *testing.T) {
+	h := newHelper(t)
+	h.allow(types.SystemPermissionResource, "webhook.manage")
+
+	var (
+		w = h.repoMakeWebhook("test_webhook_deliveries")
+		d = h.repoMakeWebhookDelivery(w, types.WebhookDeliveryFailed)
+	)
+
+	h.repoMakeWebhookDelivery(w, types.WebhookDeliveryDelivered)
+
+	h.apiInit().
+		Get("/webhook/delivery/").
+		QueryParams(map[string]string{
+			"webhookID": fmt.Sprintf("%d", w.ID),
+			"status":    "failed",
+		}).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Len(`$.response.set`, 1)).
+		Assert(jsonpath.Equal(`$.response.set[0].deliveryID`, fmt.Sprintf("%d", d.ID))).
+		End()
+
+	h.apiInit().
+		Post(fmt.Sprintf("/webhook/delivery/%d/retry", d.ID)).
+		Expect(t).
+		Status(http.StatusOK).
+		Assert(helpers.AssertNoErrors).
+		Assert(jsonpath.Equal(`$.response.status`, "pending")).
+		Assert(jsonpath.Equal(`$.response.attempts`, float64(0))).
+		End()
+
+	d, err := h.repoWebhook().FindDeliveryByID(d.ID)
+	h.a.NoError(err)
+	h.a.True(d.NextAttemptAt.Before(time.Now().Add(time.Second)))
+}
+
+func TestWebhookDeliveryClaim(t *testing.T) {
+	var (
+		h   = newHelper(t)
+		w   = h.repoMakeWebhook("test_webhook_claim")
+		now = time.Now()
+	)
+
+	d, err := h.repoWebhook().CreateDelivery(&types.WebhookDelivery{
+		WebhookID:     w.ID,
+		EventType:     "afterCreate",
+		Payload:       []byte(`{}`),
+		Status:        types.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+	})
+	h.a.NoError(err)
+
+	claimed, err := h.repoWebhook().ClaimDelivery(d.ID, now.Add(time.Second), now.Add(time.Minute))
+	h.a.NoError(err)
+	h.a.True(claimed)
+
+	// another node
+	claimed, err = h.repoWebhook().ClaimDelivery(d.ID, now.Add(time.Second), now.Add(time.Minute))
+	h.a.NoError(err)
+	h.a.False(claimed)
+
+	// claim expired
+	claimed, err = h.repoWebhook().ClaimDelivery(d.ID, now.Add(time.Minute*2), now.Add(time.Minute*3))
+	h.a.NoError(err)
+	h.a.True(claimed)
+}
+
+func TestWebhookDeliver_disabled(t *testing.T) {
+	var (
+		h   = newHelper(t)
+		w   = h.repoMakeWebhook("test_webhook_disabled")
+		now = time.Now()
+	)
+
+	w.Enabled = false
+	_, err := h.repoWebhook().Update(w)
+	h.a.NoError(err)
+
+	d, err := h.repoWebhook().CreateDelivery(&types.WebhookDelivery{
+		WebhookID:     w.ID,
+		EventType:     "afterCreate",
+		Payload:       []byte(`{}`),
+		Status:        types.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+	})
+	h.a.NoError(err)
+
+	_, err = service.DefaultWebhook.With(auth.SetSuperUserContext(context.Background())).Deliver(now.Add(time.Second))
+	h.a.NoError(err)
+
+	d, err = h.repoWebhook().FindDeliveryByID(d.ID)
+	h.a.NoError(err)
+	h.a.Equal(types.WebhookDeliveryFailed, d.Status)
+	h.a.Equal("webhook disabled", d.LastError)
+}
+
+func TestWebhookOutboxRelay(t *testing.T) {
+	var (
+		h      = newHelper(t)
+		ctx    = auth.SetSuperUserContext(context.Background())
+		handle = "webhook_" + rs()
+		w      = h.repoMakeWebhook("outbox-relay")
+		other  = h.repoMakeWebhook("outbox-relay-other")
+	)
+
+	w.Constraints = types.WebhookConstraintSet{{Name: "user.handle", Op: "eq", Values: []string{handle}}}
+	_, err := h.repoWebhook().Update(w)
+	h.a.NoError(err)
+
+	other.Constraints = types.WebhookConstraintSet{{Name: "user.handle", Op: "eq", Values: []string{"not-" + handle}}}
+	_, err = h.repoWebhook().Update(other)
+	h.a.NoError(err)
+
+	_, err = service.DefaultUser.With(ctx).Create(&types.User{Email: handle + "@example.tld", Handle: handle})
+	h.a.NoError(err)
+
+	// Event is recorded but not relayed yet
+	dd, _, err := h.repoWebhook().FindDeliveries(types.WebhookDeliveryFilter{WebhookID: w.ID})
+	h.a.NoError(err)
+	h.a.Empty(dd)
+
+	_, err = outbox.Relay(context.Background(), time.Now())
+	h.a.NoError(err)
+
+	dd, _, err = h.repoWebhook().FindDeliveries(types.WebhookDeliveryFilter{WebhookID: w.ID})
+	h.a.NoError(err)
+	h.a.Len(dd, 1)
+	h.a.Equal("afterCreate", dd[0].EventType)
+	h.a.Contains(string(dd[0].Payload), handle)
+
+	dd, _, err = h.repoWebhook().FindDeliveries(types.WebhookDeliveryFilter{WebhookID: other.ID})
+	h.a.NoError(err)
+	h.a.Empty(dd)
+
+	// Relayed events are removed from the outbox
+	_, err = outbox.Relay(context.Background(), time.Now())
+	h.a.NoError(err)
+
+	dd, _, err = h.repoWebhook().FindDeliveries(types.WebhookDeliveryFilter{WebhookID: w.ID})
+	h.a.NoError(err)
+	h.a.Len(dd, 1)
+}